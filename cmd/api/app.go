@@ -0,0 +1,147 @@
+package main
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/handler"
+	"github.com/putteror/access-control-management/internal/app/repository"
+	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/config"
+	"github.com/putteror/access-control-management/internal/router"
+	"gorm.io/gorm"
+)
+
+// newRouter builds the repositories, services and handlers on a database session and returns
+// their router with the retention service. The session decides which tenants the handlers see,
+// see common.WithTenant. onTenantChanged is called when a tenant is updated or deleted.
+func newRouter(cfg *config.Config, db *gorm.DB, fileRepo repository.FileRepository, retentionPolicy common.RetentionPolicy, onTenantChanged func(tenantID string)) (*gin.Engine, service.RetentionService) {
+	// Files of a tenant are stored in its own folder
+	fileRepo = repository.NewTenantFileRepo(fileRepo, db)
+
+	accessControlDeviceRepo := repository.NewAccessControlDeviceRepository(db)
+	accessControlGroupRepo := repository.NewAccessControlGroupRepository(db)
+	accessControlRuleRepo := repository.NewAccessControlRuleRepository(db)
+	accessControlServerRepo := repository.NewAccessControlServerRepository(db)
+	accessRecordRepo := repository.NewAccessRecordRepository(db)
+	AttendanceRepo := repository.NewAttendanceRepository(db)
+	attendanceRecordRepo := repository.NewAttendanceRecordRepository(db)
+	attendanceCorrectionRepo := repository.NewAttendanceCorrectionRepository(db)
+	auditLogRepo := repository.NewAuditLogRepository(db)
+	holidayCalendarRepo := repository.NewHolidayCalendarRepository(db)
+	leaveRequestRepo := repository.NewLeaveRequestRepository(db)
+	leaveTypeRepo := repository.NewLeaveTypeRepository(db)
+	locationRepo := repository.NewLocationRepository(db)
+	overtimeRuleRepo := repository.NewOvertimeRuleRepository(db)
+	payrollExportRepo := repository.NewPayrollExportRepository(db)
+	payrollExportTemplateRepo := repository.NewPayrollExportTemplateRepository(db)
+	personRepo := repository.NewPersonRepository(db)
+	personCardRepo := repository.NewPersonCardRepository(db)
+	personLicenseRepo := repository.NewPersonLicensePlateRepository(db)
+	personShiftRepo := repository.NewPersonShiftRepository(db)
+	registerFormAnswerRepo := repository.NewRegisterFormFieldAnswerRepository(db)
+	retentionRunRepo := repository.NewRetentionRunRepository(db)
+	shiftRotationRepo := repository.NewShiftRotationRepository(db)
+	shiftTemplateRepo := repository.NewShiftTemplateRepository(db)
+	tenantRepo := repository.NewTenantRepository(db)
+	userRepository := repository.NewUserRepository(db)
+	visitorVehicleRepo := repository.NewVisitorVehicleRepository(db)
+	accessScanSessionRepo := repository.NewAccessScanSessionRepository(db)
+
+	accessControlDeviceService := service.NewAccessControlDeviceService(accessControlDeviceRepo, accessControlServerRepo, locationRepo)
+	accessControlGroupService := service.NewAccessControlGroupService(accessControlGroupRepo, accessControlDeviceRepo, holidayCalendarRepo, locationRepo, db)
+	accessControlRuleService := service.NewAccessControlRuleService(accessControlRuleRepo, accessControlGroupRepo, db)
+	accessDecisionService := service.NewAccessDecisionService(personRepo, personCardRepo, personLicenseRepo, visitorVehicleRepo, accessControlDeviceRepo, accessControlRuleRepo, accessControlGroupRepo, accessRecordRepo, accessScanSessionRepo, holidayCalendarRepo, fileRepo, locationRepo)
+	accessRecordService := service.NewAccessRecordService(accessRecordRepo, personRepo, accessControlDeviceRepo, locationRepo, db)
+	accessControlServerService := service.NewAccessControlServerService(accessControlServerRepo)
+	attendanceService := service.NewAttendanceService(AttendanceRepo, holidayCalendarRepo, overtimeRuleRepo, db)
+	attendanceRecordService := service.NewAttendanceRecordService(attendanceRecordRepo, AttendanceRepo, personRepo, accessRecordRepo, holidayCalendarRepo, personShiftRepo, shiftRotationRepo, shiftTemplateRepo, leaveRequestRepo, leaveTypeRepo, attendanceCorrectionRepo, overtimeRuleRepo, userRepository, payrollExportRepo, accessControlDeviceRepo, locationRepo)
+	attendanceCorrectionService := service.NewAttendanceCorrectionService(attendanceCorrectionRepo, personRepo, userRepository, attendanceRecordService)
+	auditLogService := service.NewAuditLogService(auditLogRepo)
+	authService := service.NewAuthService(userRepository, tenantRepo)
+	fileService := service.NewFileService(fileRepo, personRepo, accessRecordRepo, visitorVehicleRepo, db)
+	holidayCalendarService := service.NewHolidayCalendarService(holidayCalendarRepo, db)
+	leaveRequestService := service.NewLeaveRequestService(leaveRequestRepo, leaveTypeRepo, personRepo, userRepository, AttendanceRepo, personShiftRepo, shiftRotationRepo, shiftTemplateRepo, holidayCalendarRepo, attendanceRecordService)
+	leaveTypeService := service.NewLeaveTypeService(leaveTypeRepo)
+	locationService := service.NewLocationService(locationRepo, accessControlDeviceRepo, accessRecordRepo, personRepo)
+	overtimeRuleService := service.NewOvertimeRuleService(overtimeRuleRepo)
+	payrollExportService := service.NewPayrollExportService(payrollExportRepo, payrollExportTemplateRepo, attendanceRecordRepo, personRepo, userRepository, fileRepo, db)
+	payrollExportTemplateService := service.NewPayrollExportTemplateService(payrollExportTemplateRepo, db)
+	personService := service.NewPersonService(personRepo, personCardRepo, personLicenseRepo, accessControlRuleRepo, AttendanceRepo, fileRepo, common.FaceImageOptions{
+		Size:          cfg.FaceImage.Size,
+		ThumbnailSize: cfg.FaceImage.ThumbnailSize,
+		Quality:       common.FaceImageJPEGQuality,
+	}, db)
+	dataSubjectService := service.NewDataSubjectService(personService, personRepo, personCardRepo, personLicenseRepo, registerFormAnswerRepo, accessRecordRepo, attendanceRecordRepo, leaveRequestRepo, attendanceCorrectionRepo, visitorVehicleRepo, auditLogRepo, userRepository, fileRepo)
+	personCardService := service.NewPersonCardService(personCardRepo, personRepo, db)
+	personShiftService := service.NewPersonShiftService(personShiftRepo, shiftRotationRepo, shiftTemplateRepo, personRepo)
+	retentionService := service.NewRetentionService(retentionRunRepo, accessRecordRepo, attendanceRecordRepo, auditLogRepo, personRepo, visitorVehicleRepo, userRepository, fileRepo, retentionPolicy)
+	shiftRotationService := service.NewShiftRotationService(shiftRotationRepo, shiftTemplateRepo, db)
+	shiftTemplateService := service.NewShiftTemplateService(shiftTemplateRepo)
+	tenantService := service.NewTenantService(tenantRepo, onTenantChanged)
+	userService := service.NewUserService(userRepository, db)
+	visitorVehicleService := service.NewVisitorVehicleService(visitorVehicleRepo)
+
+	accessControlDeviceHandler := handler.NewAccessControlDeviceHandler(accessControlDeviceService)
+	accessControlGroupHandler := handler.NewAccessControlGroupHandler(accessControlGroupService)
+	accessControlRuleHandler := handler.NewAccessControlRuleHandler(accessControlRuleService)
+	accessControlServerHandler := handler.NewAccessControlServerHandler(accessControlServerService)
+	accessDecisionHandler := handler.NewAccessDecisionHandler(accessDecisionService)
+	accessRecordHandler := handler.NewAccessRecordHandler(accessRecordService)
+	attendanceHandler := handler.NewAttendanceHandler(attendanceService)
+	attendanceCorrectionHandler := handler.NewAttendanceCorrectionHandler(attendanceCorrectionService)
+	attendanceRecordHandler := handler.NewAttendanceRecordHandler(attendanceRecordService)
+	auditLogHandler := handler.NewAuditLogHandler(auditLogService)
+	authHandler := handler.NewAuthHandler(authService)
+	dataSubjectHandler := handler.NewDataSubjectHandler(dataSubjectService)
+	fileHandler := handler.NewFileHandler(fileService)
+	holidayCalendarHandler := handler.NewHolidayCalendarHandler(holidayCalendarService)
+	leaveRequestHandler := handler.NewLeaveRequestHandler(leaveRequestService)
+	leaveTypeHandler := handler.NewLeaveTypeHandler(leaveTypeService)
+	locationHandler := handler.NewLocationHandler(locationService)
+	overtimeRuleHandler := handler.NewOvertimeRuleHandler(overtimeRuleService)
+	payrollExportHandler := handler.NewPayrollExportHandler(payrollExportService)
+	payrollExportTemplateHandler := handler.NewPayrollExportTemplateHandler(payrollExportTemplateService)
+	personHandler := handler.NewPersonHandler(personService)
+	personCardHandler := handler.NewPersonCardHandler(personCardService)
+	personShiftHandler := handler.NewPersonShiftHandler(personShiftService)
+	retentionHandler := handler.NewRetentionHandler(retentionService)
+	shiftRotationHandler := handler.NewShiftRotationHandler(shiftRotationService)
+	shiftTemplateHandler := handler.NewShiftTemplateHandler(shiftTemplateService)
+	tenantHandler := handler.NewTenantHandler(tenantService)
+	userHandler := handler.NewUserHandler(userService)
+	visitorVehicleHandler := handler.NewVisitorVehicleHandler(visitorVehicleService)
+
+	appRouter := router.NewRouter(
+		cfg.CORS,
+		accessControlDeviceHandler,
+		accessControlGroupHandler,
+		accessControlRuleHandler,
+		accessControlServerHandler,
+		accessDecisionHandler,
+		accessRecordHandler,
+		attendanceHandler,
+		attendanceCorrectionHandler,
+		attendanceRecordHandler,
+		auditLogHandler,
+		authHandler,
+		dataSubjectHandler,
+		fileHandler,
+		holidayCalendarHandler,
+		leaveRequestHandler,
+		leaveTypeHandler,
+		locationHandler,
+		overtimeRuleHandler,
+		payrollExportHandler,
+		payrollExportTemplateHandler,
+		personHandler,
+		personCardHandler,
+		personShiftHandler,
+		retentionHandler,
+		shiftRotationHandler,
+		shiftTemplateHandler,
+		tenantHandler,
+		userHandler,
+		visitorVehicleHandler,
+	)
+	return appRouter, retentionService
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/putteror/access-control-management/internal/config"
+)
+
+// runConfigCommand runs "config print", which writes the effective config as YAML with its secrets
+// redacted followed by the problems found by validation.
+func runConfigCommand(cfg *config.Config, args []string) error {
+	if len(args) != 1 || args[0] != "print" {
+		return fmt.Errorf("usage: config print")
+	}
+	if err := cfg.WriteYAML(os.Stdout); err != nil {
+		return err
+	}
+	return cfg.Validate()
+}
@@ -1,79 +1,273 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+	_ "time/tzdata"
 
-	"github.com/putteror/access-control-management/internal/app/handler"
+	"github.com/putteror/access-control-management/internal/app/common"
 	"github.com/putteror/access-control-management/internal/app/repository"
 	"github.com/putteror/access-control-management/internal/app/service"
 	"github.com/putteror/access-control-management/internal/config"
 	"github.com/putteror/access-control-management/internal/database"
 	"github.com/putteror/access-control-management/internal/router"
+	"gorm.io/gorm"
 )
 
 func main() {
-	cfg, err := config.LoadConfig()
+	cfg, args, err := config.LoadConfig(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
 	if err != nil {
 		log.Fatalf("Error loading config: %v", err)
 	}
 
+	// "config print" shows the effective config with its secrets redacted and exits
+	if len(args) > 0 && args[0] == "config" {
+		if err := runConfigCommand(cfg, args[1:]); err != nil {
+			log.Fatalf("Error running config: %v", err)
+		}
+		return
+	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Error loading config: %v", err)
+	}
+
+	location, err := time.LoadLocation(cfg.TimeZone)
+	if err != nil {
+		log.Fatalf("Error loading time zone: %v", err)
+	}
+	time.Local = location
+	common.JwtSecret = []byte(cfg.JWT.Secret)
+	common.JwtExpiration = time.Duration(cfg.JWT.ExpirationHours) * time.Hour
+	common.AccessRecordChainKey, err = common.ParseEncryptionKey(cfg.Encryption.ChainKey)
+	if err != nil {
+		log.Fatalf("Error loading access record chain key: %v", err)
+	}
+
+	if err := setEncryptionKeys(cfg); err != nil {
+		log.Fatalf("Error loading encryption keys: %v", err)
+	}
+
 	db, err := database.NewPostgresDB(cfg)
 	if err != nil {
 		log.Fatalf("Error connecting to database: %v", err)
 	}
 
-	database.AutoMigrate(db)
-
-	// wd, err := os.Getwd()
-	// if err != nil {
-	// 	log.Fatalf("Error getting working directory: %v", err)
-	// }
-	// uploadPath := filepath.Join(wd, common.UploadPath)
-	// fileRepo := repository.NewFileSystemRepo(uploadPath)
-
-	accessControlDeviceRepo := repository.NewAccessControlDeviceRepository(db)
-	accessControlGroupRepo := repository.NewAccessControlGroupRepository(db)
-	accessControlRuleRepo := repository.NewAccessControlRuleRepository(db)
-	accessControlServerRepo := repository.NewAccessControlServerRepository(db)
-	accessRecordRepo := repository.NewAccessRecordRepository(db)
-	AttendanceRepo := repository.NewAttendanceRepository(db)
-	personRepo := repository.NewPersonRepository(db)
-	userRepository := repository.NewUserRepository(db)
-
-	accessControlDeviceService := service.NewAccessControlDeviceService(accessControlDeviceRepo, accessControlServerRepo)
-	accessControlGroupService := service.NewAccessControlGroupService(accessControlGroupRepo, accessControlDeviceRepo, db)
-	accessControlRuleService := service.NewAccessControlRuleService(accessControlRuleRepo, accessControlGroupRepo, db)
-	accessRecordService := service.NewAccessRecordService(accessRecordRepo, personRepo, accessControlDeviceRepo)
-	accessControlServerService := service.NewAccessControlServerService(accessControlServerRepo)
-	attendanceService := service.NewAttendanceService(AttendanceRepo, db)
-	authService := service.NewAuthService(userRepository)
-	personService := service.NewPersonService(personRepo, repository.NewPersonCardRepository(db), repository.NewPersonLicensePlateRepository(db), accessControlRuleRepo, AttendanceRepo, db)
-	userService := service.NewUserService(userRepository, db)
-
-	accessControlDeviceHandler := handler.NewAccessControlDeviceHandler(accessControlDeviceService)
-	accessControlGroupHandler := handler.NewAccessControlGroupHandler(accessControlGroupService)
-	accessControlRuleHandler := handler.NewAccessControlRuleHandler(accessControlRuleService)
-	accessControlServerHandler := handler.NewAccessControlServerHandler(accessControlServerService)
-	accessRecordHandler := handler.NewAccessRecordHandler(accessRecordService)
-	attendanceHandler := handler.NewAttendanceHandler(attendanceService)
-	authHandler := handler.NewAuthHandler(authService)
-	personHandler := handler.NewPersonHandler(personService)
-	userHandler := handler.NewUserHandler(userService)
-
-	appRouter := router.NewRouter(
-		accessControlDeviceHandler,
-		accessControlGroupHandler,
-		accessControlRuleHandler,
-		accessControlServerHandler,
-		accessRecordHandler,
-		attendanceHandler,
-		authHandler,
-		personHandler,
-		userHandler,
-	)
-
-	log.Printf("Server is starting on port %s", cfg.Port)
-	if err := appRouter.Run(":" + cfg.Port); err != nil {
-		log.Fatalf("Could not listen on %s: %v\n", cfg.Port, err)
+	// "migrate" manages the schema version and exits
+	if len(args) > 0 && args[0] == "migrate" {
+		if err := runMigrateCommand(db, args[1:]); err != nil {
+			log.Fatalf("Error running migrate: %v", err)
+		}
+		return
+	}
+	// Startup tasks and background jobs work on the data of every tenant
+	globalDB := db.WithContext(common.WithAllTenants(context.Background()))
+	if cfg.Database.AutoMigrate {
+		log.Println("DB_AUTO_MIGRATE is enabled, creating the schema from the models")
+		database.AutoMigrate(globalDB)
+	} else if err := database.CheckSchemaVersion(db); err != nil {
+		log.Fatalf("Error checking database schema: %v", err)
+	}
+
+	fileRepo, err := newFileRepository(cfg)
+	if err != nil {
+		log.Fatalf("Error creating file storage: %v", err)
+	}
+
+	// Values in plain text or encrypted with an old key are re-encrypted with the current key. The
+	// "rotate-encryption-key" command only does this and exits without serving requests.
+	if err := reEncryptColumns(repository.NewPersonRepository(globalDB), repository.NewAccessControlDeviceRepository(globalDB), repository.NewAccessControlServerRepository(globalDB)); err != nil {
+		log.Fatalf("Error re-encrypting data: %v", err)
+	}
+	if len(args) > 0 && args[0] == "rotate-encryption-key" {
+		log.Printf("Encrypted columns use key '%s'", cfg.Encryption.KeyID)
+		return
+	}
+
+	// Access records are only sealed when appended and once by the migrations, records outside the
+	// chain or of an older chain version were written to the table directly
+	if err := reportUnsealedAccessRecords(repository.NewAccessRecordRepository(globalDB)); err != nil {
+		log.Fatalf("Error checking access records: %v", err)
+	}
+
+	// License plates stored before the current normalization get their comparison key once
+	if err := setMissingLicensePlateKeys(repository.NewPersonLicensePlateRepository(globalDB), repository.NewVisitorVehicleRepository(globalDB)); err != nil {
+		log.Fatalf("Error normalizing license plates: %v", err)
+	}
+
+	retentionPolicy := common.RetentionPolicy{
+		AccessRecordDays:     cfg.Retention.AccessRecordDays,
+		AttendanceRecordDays: cfg.Retention.AttendanceRecordDays,
+		FaceImageDays:        cfg.Retention.FaceImageDays,
+		AuditLogDays:         cfg.Retention.AuditLogDays,
+		VisitorDays:          cfg.Retention.VisitorDays,
+	}
+
+	// The global router serves logins and super admins in the global scope, every tenant gets its
+	// own router on first use. Routers of changed tenants are dropped and built again.
+	var tenantRouters *tenantRouters
+	tenantRouters = newTenantRouters(db, func(tenantDB *gorm.DB) http.Handler {
+		tenantRouter, _ := newRouter(cfg, tenantDB, fileRepo, retentionPolicy, tenantRouters.evict)
+		return tenantRouter
+	})
+	globalRouter, retentionService := newRouter(cfg, globalDB, fileRepo, retentionPolicy, tenantRouters.evict)
+	appRouter := router.NewTenantRouter(globalRouter, tenantRouters.resolve)
+
+	if cfg.Jobs.RetentionIntervalHours > 0 && retentionPolicy.Enabled() {
+		go runRetentionJob(retentionService, time.Duration(cfg.Jobs.RetentionIntervalHours)*time.Hour)
+	}
+
+	server := &http.Server{
+		Addr:         cfg.Address(),
+		Handler:      appRouter,
+		ReadTimeout:  time.Duration(cfg.Server.ReadTimeoutSeconds) * time.Second,
+		WriteTimeout: time.Duration(cfg.Server.WriteTimeoutSeconds) * time.Second,
+	}
+	log.Printf("Server is starting on port %d", cfg.Server.Port)
+	if cfg.Server.TLS.Enabled {
+		err = server.ListenAndServeTLS(cfg.Server.TLS.CertFile, cfg.Server.TLS.KeyFile)
+	} else {
+		err = server.ListenAndServe()
+	}
+	if err != nil {
+		log.Fatalf("Could not listen on %d: %v\n", cfg.Server.Port, err)
+	}
+}
+
+// runRetentionJob purges expired data at startup and then on every interval.
+func runRetentionJob(retentionService service.RetentionService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		run, err := retentionService.Run(common.RetentionTriggerSchedule, "")
+		if err != nil {
+			log.Printf("Retention run failed: %v", err)
+		} else if run.Error != nil {
+			log.Printf("Retention run %s failed: %s", run.ID, *run.Error)
+		}
+		<-ticker.C
+	}
+}
+
+// setEncryptionKeys loads the current and old encryption keys and the blind index key from the
+// config.
+func setEncryptionKeys(cfg *config.Config) error {
+	keys := make(map[string][]byte)
+	for id, encoded := range cfg.Encryption.OldKeys {
+		key, err := common.ParseEncryptionKey(encoded)
+		if err != nil {
+			return fmt.Errorf("old key '%s': %w", id, err)
+		}
+		keys[id] = key
+	}
+	key, err := common.ParseEncryptionKey(cfg.Encryption.Key)
+	if err != nil {
+		return err
+	}
+	keys[cfg.Encryption.KeyID] = key
+	indexKey, err := common.ParseEncryptionKey(cfg.Encryption.IndexKey)
+	if err != nil {
+		return fmt.Errorf("index key: %w", err)
+	}
+	return common.SetEncryptionKeys(cfg.Encryption.KeyID, keys, indexKey)
+}
+
+// reEncryptColumns rewrites the encrypted columns holding plain text or a value encrypted with an
+// old key, so old keys can be removed from the config afterwards.
+func reEncryptColumns(personRepo repository.PersonRepository, deviceRepo repository.AccessControlDeviceRepository, serverRepo repository.AccessControlServerRepository) error {
+	prefix, err := common.CurrentEncryptionPrefix()
+	if err != nil {
+		return err
+	}
+	tables := []struct {
+		name      string
+		reEncrypt func(prefix string) (int, error)
+	}{
+		{"people", personRepo.ReEncrypt},
+		{"access control devices", deviceRepo.ReEncrypt},
+		{"access control servers", serverRepo.ReEncrypt},
+	}
+	for _, table := range tables {
+		rewritten, err := table.reEncrypt(prefix)
+		if err != nil {
+			return fmt.Errorf("%s: %w", table.name, err)
+		}
+		if rewritten > 0 {
+			log.Printf("Re-encrypted %d %s", rewritten, table.name)
+		}
+	}
+	return nil
+}
+
+// reportUnsealedAccessRecords logs the access records that were not sealed by the server.
+// Verification reports them as tampered.
+func reportUnsealedAccessRecords(accessRecordRepo repository.AccessRecordRepository) error {
+	unchained, err := accessRecordRepo.CountUnchained()
+	if err != nil {
+		return err
+	}
+	outdated, err := accessRecordRepo.CountOutdated()
+	if err != nil {
+		return err
+	}
+	if unchained > 0 {
+		log.Printf("WARNING: %d access records are not in the hash chain, they were added to the table directly", unchained)
+	}
+	if outdated > 0 {
+		log.Printf("WARNING: %d access records are not sealed with chain version %d", outdated, common.AccessRecordChainVersion)
+	}
+	return nil
+}
+
+// setMissingLicensePlateKeys sets the normalized plate text of the registered plates and the
+// visitor vehicles that have none.
+func setMissingLicensePlateKeys(plateRepo repository.PersonLicensePlateRepository, vehicleRepo repository.VisitorVehicleRepository) error {
+	plates, err := plateRepo.SetMissingNormalizedPlateTexts()
+	if err != nil {
+		return err
+	}
+	vehicles, err := vehicleRepo.SetMissingNormalizedPlateTexts()
+	if err != nil {
+		return err
+	}
+	if plates > 0 || vehicles > 0 {
+		log.Printf("Normalized %d license plates and %d visitor vehicles", plates, vehicles)
+	}
+	return nil
+}
+
+// newFileRepository creates the file storage selected by the storage driver.
+func newFileRepository(cfg *config.Config) (repository.FileRepository, error) {
+	switch cfg.Storage.Driver {
+	case "local":
+		basePath := cfg.Storage.LocalPath
+		if basePath == "" {
+			wd, err := os.Getwd()
+			if err != nil {
+				return nil, fmt.Errorf("error getting working directory: %w", err)
+			}
+			basePath = filepath.Join(wd, common.UploadPath)
+		}
+		return repository.NewFileSystemRepo(basePath), nil
+	case "s3":
+		return repository.NewS3FileRepo(repository.S3Config{
+			Endpoint:  cfg.Storage.S3.Endpoint,
+			Region:    cfg.Storage.S3.Region,
+			Bucket:    cfg.Storage.S3.Bucket,
+			AccessKey: cfg.Storage.S3.AccessKey,
+			SecretKey: cfg.Storage.S3.SecretKey,
+			UseSSL:    cfg.Storage.S3.UseSSL,
+		})
+	default:
+		return nil, fmt.Errorf("unknown storage driver '%s'", cfg.Storage.Driver)
 	}
 }
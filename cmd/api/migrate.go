@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/repository"
+	"github.com/putteror/access-control-management/internal/database"
+	"gorm.io/gorm"
+)
+
+const migrateUsage = "usage: migrate up | down [steps] | to <version> | status"
+
+// runMigrateCommand runs "migrate up", "migrate down [steps]", "migrate to <version>" or
+// "migrate status".
+func runMigrateCommand(db *gorm.DB, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf(migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		if len(args) != 1 {
+			return fmt.Errorf(migrateUsage)
+		}
+		if err := database.MigrateUp(db, dataMigrations()); err != nil {
+			return err
+		}
+	case "down":
+		steps := 1
+		if len(args) == 2 {
+			number, err := strconv.Atoi(args[1])
+			if err != nil || number <= 0 {
+				return fmt.Errorf("steps must be a positive integer")
+			}
+			steps = number
+		} else if len(args) > 2 {
+			return fmt.Errorf(migrateUsage)
+		}
+		if err := database.MigrateDown(db, steps); err != nil {
+			return err
+		}
+	case "to":
+		if len(args) != 2 {
+			return fmt.Errorf(migrateUsage)
+		}
+		version, err := strconv.ParseUint(args[1], 10, 32)
+		if err != nil {
+			return fmt.Errorf("version must be zero or a positive integer")
+		}
+		if err := database.MigrateTo(db, uint(version), dataMigrations()); err != nil {
+			return err
+		}
+	case "status":
+		if len(args) != 1 {
+			return fmt.Errorf(migrateUsage)
+		}
+	default:
+		return fmt.Errorf(migrateUsage)
+	}
+
+	return printMigrationStatus(db)
+}
+
+// dataMigrations returns the data migrations by the version of the migration they follow.
+func dataMigrations() map[uint]database.DataMigration {
+	return map[uint]database.DataMigration{
+		10: sealAccessRecordChain,
+		11: reindexPeople,
+	}
+}
+
+// sealAccessRecordChain re-seals the access records of older chain versions with the chain key
+// and appends the records stored before the hash chain existed to the chain.
+func sealAccessRecordChain(db *gorm.DB) error {
+	accessRecordRepo := repository.NewAccessRecordRepository(db.WithContext(common.WithAllTenants(context.Background())))
+	resealed, err := accessRecordRepo.ResealChain()
+	if err != nil {
+		return fmt.Errorf("failed to re-seal access records: %w", err)
+	}
+	if resealed > 0 {
+		log.Printf("Re-sealed %d access records with chain version %d", resealed, common.AccessRecordChainVersion)
+	}
+	sealed, err := accessRecordRepo.SealUnchained()
+	if err != nil {
+		return fmt.Errorf("failed to seal access records: %w", err)
+	}
+	if sealed > 0 {
+		log.Printf("Sealed %d access records into the hash chain", sealed)
+	}
+	return nil
+}
+
+// reindexPeople recomputes the blind indexes of the people, which were keyed with a key derived
+// from the encryption key before encryption.index_key existed.
+func reindexPeople(db *gorm.DB) error {
+	personRepo := repository.NewPersonRepository(db.WithContext(common.WithAllTenants(context.Background())))
+	reindexed, err := personRepo.Reindex()
+	if err != nil {
+		return fmt.Errorf("failed to reindex people: %w", err)
+	}
+	if reindexed > 0 {
+		log.Printf("Reindexed %d people with the blind index key", reindexed)
+	}
+	return nil
+}
+
+// printMigrationStatus prints the schema version and every migration with whether it is applied.
+func printMigrationStatus(db *gorm.DB) error {
+	status, err := database.GetMigrationStatus(db)
+	if err != nil {
+		return err
+	}
+	state := "up to date"
+	switch {
+	case status.Dirty:
+		state = "dirty"
+	case status.Version < status.Latest:
+		state = fmt.Sprintf("%d pending", status.Latest-status.Version)
+	case status.Version > status.Latest:
+		state = "newer than this build"
+	}
+	fmt.Printf("Schema version %d of %d (%s)\n", status.Version, status.Latest, state)
+	for _, migration := range status.Migrations {
+		applied := "pending"
+		if migration.Version <= status.Version {
+			applied = "applied"
+		}
+		fmt.Printf("  %06d_%s  %s\n", migration.Version, migration.Name, applied)
+	}
+	return nil
+}
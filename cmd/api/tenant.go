@@ -0,0 +1,137 @@
+package main
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/repository"
+	"gorm.io/gorm"
+)
+
+// maxTenantRouters bounds the tenant routers kept in memory. When a tenant beyond it sends a
+// request the router of the least recently served tenant is dropped, and built again on its next
+// request.
+const maxTenantRouters = 64
+
+// tenantCheckInterval is how long a served tenant is trusted to stay active before it is read
+// again. Changes made through this server evict the tenant right away, the interval bounds how
+// long other servers keep serving a tenant deactivated or deleted elsewhere.
+const tenantCheckInterval = 5 * time.Second
+
+// tenantRouters keeps the routers of the recently served tenants, built on a database session
+// scoped to the tenant on its first request.
+type tenantRouters struct {
+	db         *gorm.DB
+	tenantRepo repository.TenantRepository
+	build      func(tenantDB *gorm.DB) http.Handler
+	now        func() time.Time
+
+	mu      sync.Mutex
+	routers map[string]*list.Element
+	// recent holds the cached tenantRouter values, the most recently served first
+	recent *list.List
+}
+
+// tenantRouter is the router of a tenant. It is built once by the first request that needs it,
+// outside of the lock so other tenants are served meanwhile.
+type tenantRouter struct {
+	tenantID  string
+	checkedAt time.Time
+	once      sync.Once
+	handler   http.Handler
+}
+
+func newTenantRouters(db *gorm.DB, build func(tenantDB *gorm.DB) http.Handler) *tenantRouters {
+	return &tenantRouters{
+		db:         db,
+		tenantRepo: repository.NewTenantRepository(db),
+		build:      build,
+		now:        time.Now,
+		routers:    make(map[string]*list.Element),
+		recent:     list.New(),
+	}
+}
+
+// resolve returns the router of a tenant. The tenant is checked again when it was last checked
+// more than tenantCheckInterval ago, so tokens of a deactivated or deleted tenant stop working
+// shortly after and its router is dropped.
+func (t *tenantRouters) resolve(tenantID string) (http.Handler, error) {
+	tenantUUID, err := uuid.Parse(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("tenant with ID '%s' not found", tenantID)
+	}
+
+	router := t.checked(tenantUUID.String())
+	if router == nil {
+		tenant, err := t.tenantRepo.GetByID(tenantUUID)
+		if err != nil {
+			t.evict(tenantUUID.String())
+			return nil, fmt.Errorf("tenant with ID '%s' not found", tenantID)
+		}
+		if !tenant.IsActive {
+			t.evict(tenant.ID.String())
+			return nil, fmt.Errorf("tenant '%s' is not active", tenant.Name)
+		}
+		router = t.store(tenant.ID.String())
+	}
+
+	router.once.Do(func() {
+		router.handler = t.build(t.db.WithContext(common.WithTenant(context.Background(), router.tenantID)))
+	})
+	return router.handler, nil
+}
+
+// checked returns the router of a tenant checked within tenantCheckInterval, nil when the tenant
+// must be checked first.
+func (t *tenantRouters) checked(tenantID string) *tenantRouter {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	element, ok := t.routers[tenantID]
+	if !ok {
+		return nil
+	}
+	router := element.Value.(*tenantRouter)
+	if t.now().Sub(router.checkedAt) >= tenantCheckInterval {
+		return nil
+	}
+	t.recent.MoveToFront(element)
+	return router
+}
+
+// store returns the router of a tenant that was just checked, adding it when the tenant is not
+// cached and dropping the least recently served tenant beyond maxTenantRouters.
+func (t *tenantRouters) store(tenantID string) *tenantRouter {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if element, ok := t.routers[tenantID]; ok {
+		router := element.Value.(*tenantRouter)
+		router.checkedAt = t.now()
+		t.recent.MoveToFront(element)
+		return router
+	}
+
+	router := &tenantRouter{tenantID: tenantID, checkedAt: t.now()}
+	t.routers[tenantID] = t.recent.PushFront(router)
+	if t.recent.Len() > maxTenantRouters {
+		oldest := t.recent.Back()
+		t.recent.Remove(oldest)
+		delete(t.routers, oldest.Value.(*tenantRouter).tenantID)
+	}
+	return router
+}
+
+// evict drops the router of a tenant, e.g. when the tenant is changed or deleted.
+func (t *tenantRouters) evict(tenantID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if element, ok := t.routers[tenantID]; ok {
+		t.recent.Remove(element)
+		delete(t.routers, tenantID)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/repository"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// tenantLookups serves tenants from memory and counts the lookups. Only GetByID is implemented.
+type tenantLookups struct {
+	repository.TenantRepository
+	mu      sync.Mutex
+	tenants map[uuid.UUID]*model.Tenant
+	lookups int
+}
+
+func (r *tenantLookups) GetByID(id uuid.UUID) (*model.Tenant, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lookups++
+	tenant, ok := r.tenants[id]
+	if !ok {
+		return nil, errors.New("record not found")
+	}
+	copied := *tenant
+	return &copied, nil
+}
+
+func (r *tenantLookups) setActive(id uuid.UUID, active bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tenants[id].IsActive = active
+}
+
+func newTestTenantRouters(t *testing.T, tenants ...*model.Tenant) (*tenantRouters, *tenantLookups, *atomic.Int32, *time.Time) {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &tenantLookups{tenants: make(map[uuid.UUID]*model.Tenant)}
+	for _, tenant := range tenants {
+		repo.tenants[tenant.ID] = tenant
+	}
+	builds := &atomic.Int32{}
+	now := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	routers := newTenantRouters(db, func(tenantDB *gorm.DB) http.Handler {
+		builds.Add(1)
+		// A slow build makes concurrent first requests overlap
+		time.Sleep(10 * time.Millisecond)
+		return http.NotFoundHandler()
+	})
+	routers.tenantRepo = repo
+	routers.now = func() time.Time { return now }
+	return routers, repo, builds, &now
+}
+
+func TestTenantRoutersCheckInterval(t *testing.T) {
+	tenant := &model.Tenant{BaseModel: model.BaseModel{ID: uuid.New()}, Name: "Branch", IsActive: true}
+	routers, repo, builds, now := newTestTenantRouters(t, tenant)
+	resolve := func() error {
+		_, err := routers.resolve(tenant.ID.String())
+		return err
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := resolve(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if repo.lookups != 1 || builds.Load() != 1 {
+		t.Errorf("3 requests looked up the tenant %d times and built %d routers, want 1 and 1", repo.lookups, builds.Load())
+	}
+
+	// Deactivated elsewhere, the tenant is served until it is checked again
+	repo.setActive(tenant.ID, false)
+	*now = now.Add(tenantCheckInterval - time.Second)
+	if err := resolve(); err != nil {
+		t.Errorf("resolve() within the check interval = %v, want nil", err)
+	}
+	*now = now.Add(time.Second)
+	if err := resolve(); err == nil {
+		t.Error("resolve() of a deactivated tenant = nil, want an error")
+	}
+
+	// Evicted routers are built again
+	repo.setActive(tenant.ID, true)
+	if err := resolve(); err != nil {
+		t.Fatal(err)
+	}
+	routers.evict(tenant.ID.String())
+	if err := resolve(); err != nil {
+		t.Fatal(err)
+	}
+	if builds.Load() != 3 {
+		t.Errorf("built %d routers, want 3", builds.Load())
+	}
+
+	if _, err := routers.resolve(uuid.NewString()); err == nil {
+		t.Error("resolve() of an unknown tenant = nil, want an error")
+	}
+}
+
+func TestTenantRoutersBuildOnce(t *testing.T) {
+	tenant := &model.Tenant{BaseModel: model.BaseModel{ID: uuid.New()}, Name: "Branch", IsActive: true}
+	routers, _, builds, _ := newTestTenantRouters(t, tenant)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if handler, err := routers.resolve(tenant.ID.String()); err != nil || handler == nil {
+				t.Errorf("resolve() = %v, %v, want the router", handler, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if builds.Load() != 1 {
+		t.Errorf("concurrent requests built %d routers, want 1", builds.Load())
+	}
+}
@@ -0,0 +1,57 @@
+// Command openapi prints the OpenAPI document of the API or generates its Go client in
+// pkg/client. The server serves the same document at /openapi.json.
+//
+//	go run ./cmd/openapi spec
+//	go run ./cmd/openapi -o pkg/client/client_gen.go client
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/putteror/access-control-management/internal/openapi"
+	"github.com/putteror/access-control-management/internal/router"
+)
+
+func main() {
+	output := flag.String("o", "", "write to this file instead of the standard output")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: openapi [-o file] spec|client")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := openapi.CheckRoutes(router.Routes()); err != nil {
+		log.Fatalf("Error checking routes: %v", err)
+	}
+	document, err := openapi.Build()
+	if err != nil {
+		log.Fatalf("Error documenting routes: %v", err)
+	}
+
+	var content []byte
+	switch flag.Arg(0) {
+	case "spec":
+		content, err = json.MarshalIndent(document, "", "  ")
+		content = append(content, '\n')
+	case "client":
+		content, err = openapi.GenerateClient("client")
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatalf("Error generating %s: %v", flag.Arg(0), err)
+	}
+
+	if *output == "" {
+		os.Stdout.Write(content)
+		return
+	}
+	if err := os.WriteFile(*output, content, 0644); err != nil {
+		log.Fatalf("Error writing %s: %v", *output, err)
+	}
+}
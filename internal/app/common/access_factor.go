@@ -0,0 +1,85 @@
+package common
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Credential factors presented at a device
+const (
+	AccessFactorCard         = "card"
+	AccessFactorFace         = "face"
+	AccessFactorPIN          = "pin"
+	AccessFactorLicensePlate = "license_plate"
+	// AccessFactorTwoPerson marks a decision granted by the two-person rule
+	AccessFactorTwoPerson = "two_person"
+)
+
+// Authentication modes of an access control group
+const (
+	AuthModeAny         = "any"
+	AuthModeCardAndFace = "card_and_face"
+	AuthModeCardAndPIN  = "card_and_pin"
+	AuthModeTwoPerson   = "two_person"
+
+	// DefaultTwoPersonWindowSeconds is how long the second person has to present a credential.
+	DefaultTwoPersonWindowSeconds = 30
+)
+
+var AUTH_MODE_LIST = []string{
+	AuthModeAny,
+	AuthModeCardAndFace,
+	AuthModeCardAndPIN,
+	AuthModeTwoPerson,
+}
+
+// Scan sessions collect the credentials presented one after the other at a device
+const (
+	AccessScanSessionStatusOpen    = "open"
+	AccessScanSessionStatusGranted = "granted"
+	AccessScanSessionStatusDenied  = "denied"
+
+	// AccessScanSessionTimeoutSeconds is how long a session waits for the next credential.
+	AccessScanSessionTimeoutSeconds = 30
+)
+
+const (
+	// PINMaxFailedAttempts is the number of wrong PINs in a row after which the PIN of a person is locked.
+	PINMaxFailedAttempts = 5
+	// PINLockoutMinutes is how long a locked PIN is refused, even when it is right.
+	PINLockoutMinutes = 15
+)
+
+var pinPattern = regexp.MustCompile(`^[0-9]{4,8}$`)
+
+// ValidatePIN checks that a PIN is 4 to 8 digits.
+func ValidatePIN(pin string) bool {
+	return pinPattern.MatchString(pin)
+}
+
+// IsAuthModeSatisfied reports whether the factors presented by one person satisfy an authentication mode.
+// For the two-person rule this only covers a single person; the second person is checked by the caller.
+func IsAuthModeSatisfied(authMode string, factors []string) bool {
+	switch authMode {
+	case AuthModeCardAndFace:
+		return slices.Contains(factors, AccessFactorCard) && slices.Contains(factors, AccessFactorFace)
+	case AuthModeCardAndPIN:
+		return slices.Contains(factors, AccessFactorCard) && slices.Contains(factors, AccessFactorPIN)
+	default:
+		return len(factors) > 0
+	}
+}
+
+// JoinAccessFactors stores a factor list as a comma separated string.
+func JoinAccessFactors(factors []string) string {
+	return strings.Join(factors, ",")
+}
+
+// SplitAccessFactors reads a comma separated factor list. An empty string gives an empty list.
+func SplitAccessFactors(factors string) []string {
+	if factors == "" {
+		return []string{}
+	}
+	return strings.Split(factors, ",")
+}
@@ -1,5 +1,16 @@
 package common
 
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+)
+
+// AccessRecordChainKey keys the hashes of the access record chain, so only the server can seal a
+// record. It is set from the config at startup and cannot be rotated without re-sealing the chain.
+var AccessRecordChainKey []byte
+
 var ACCESS_RECORD_TYPE = []string{
 	"in",
 	"out",
@@ -28,3 +39,87 @@ func ValidateAccessRecordResult(accessRecordResult string) bool {
 	}
 	return false
 }
+
+// Access decision reasons
+const (
+	AccessReasonGranted              = "granted"
+	AccessReasonUnknownCredential    = "unknown_credential"
+	AccessReasonLowConfidence        = "low_confidence"
+	AccessReasonAmbiguousPlate       = "ambiguous_license_plate"
+	AccessReasonFuzzyPlate           = "fuzzy_license_plate"
+	AccessReasonCardLost             = "card_lost"
+	AccessReasonCardSuspended        = "card_suspended"
+	AccessReasonCardRevoked          = "card_revoked"
+	AccessReasonCardNotYetValid      = "card_not_yet_valid"
+	AccessReasonCardExpired          = "card_expired"
+	AccessReasonPersonNotYetValid    = "person_not_yet_valid"
+	AccessReasonPersonExpired        = "person_expired"
+	AccessReasonNoAccessRule         = "no_access_rule"
+	AccessReasonDeviceNotAllowed     = "device_not_allowed"
+	AccessReasonOutsideSchedule      = "outside_schedule"
+	AccessReasonHoliday              = "holiday"
+	AccessReasonCredentialMismatch   = "credential_mismatch"
+	AccessReasonInvalidPIN           = "invalid_pin"
+	AccessReasonPINLocked            = "pin_locked"
+	AccessReasonFactorRequired       = "additional_factor_required"
+	AccessReasonSecondPerson         = "second_person_required"
+	AccessReasonAuthModeNotSupported = "auth_mode_not_supported"
+)
+
+const (
+	// AccessRecordChainLockKey is the advisory lock that serializes appends to the access record hash chain.
+	AccessRecordChainLockKey = 7254311
+	// AccessRecordVerifyBatchSize is the number of access records read at a time while verifying the chain.
+	AccessRecordVerifyBatchSize = 1000
+	// AccessRecordVerifyMaxIssues limits the issues listed by one chain verification.
+	AccessRecordVerifyMaxIssues = 100
+	// AccessRecordChainVersion is the version of the hash of new access records. Version 2 covers
+	// the tenant of a record, version 1 did not, and version 3 is an HMAC keyed with
+	// AccessRecordChainKey instead of a plain SHA-256 hash. Records of older versions are re-sealed
+	// once by the migration that introduced version 3, see AccessRecordRepository.ResealChain.
+	AccessRecordChainVersion = 3
+)
+
+// Problems found by the access record chain verification
+const (
+	AccessRecordChainGap        = "gap"
+	AccessRecordChainDuplicate  = "duplicate"
+	AccessRecordChainBrokenLink = "broken_link"
+	AccessRecordChainTampered   = "tampered"
+	AccessRecordChainDeleted    = "deleted"
+	AccessRecordChainUnchained  = "unchained"
+)
+
+// Fields of an access record that an annotation can correct
+var ACCESS_RECORD_ANNOTATION_FIELD_LIST = []string{
+	"personID",
+	"accessControlDeviceID",
+	"type",
+	"result",
+	"accessTime",
+	"cardNumber",
+	"plateText",
+}
+
+// ChainHash is the HMAC-SHA256 of a hash chain entry keyed with AccessRecordChainKey: the hash of
+// the previous entry followed by the payload of the entry. It panics when the key is not set, a
+// chain sealed without a key could be rewritten by anyone with access to the database.
+func ChainHash(prevHash string, payload []byte) string {
+	if len(AccessRecordChainKey) == 0 {
+		panic("access record chain key is not set")
+	}
+	return chainHash(hmac.New(sha256.New, AccessRecordChainKey), prevHash, payload)
+}
+
+// LegacyChainHash is the unkeyed SHA-256 hash of the chain versions before 3. It only checks old
+// records before they are re-sealed.
+func LegacyChainHash(prevHash string, payload []byte) string {
+	return chainHash(sha256.New(), prevHash, payload)
+}
+
+func chainHash(digest hash.Hash, prevHash string, payload []byte) string {
+	digest.Write([]byte(prevHash))
+	digest.Write([]byte("\n"))
+	digest.Write(payload)
+	return hex.EncodeToString(digest.Sum(nil))
+}
@@ -0,0 +1,30 @@
+package common
+
+import "testing"
+
+func TestChainHash(t *testing.T) {
+	previous := AccessRecordChainKey
+	t.Cleanup(func() { AccessRecordChainKey = previous })
+
+	AccessRecordChainKey = []byte("0123456789abcdef0123456789abcdef")
+	hash := ChainHash("prev", []byte("payload"))
+	if hash == LegacyChainHash("prev", []byte("payload")) {
+		t.Error("ChainHash equals the unkeyed hash")
+	}
+	if hash == ChainHash("other", []byte("payload")) {
+		t.Error("ChainHash does not cover the previous hash")
+	}
+
+	AccessRecordChainKey = []byte("fedcba9876543210fedcba9876543210")
+	if hash == ChainHash("prev", []byte("payload")) {
+		t.Error("ChainHash does not depend on the key")
+	}
+
+	AccessRecordChainKey = nil
+	defer func() {
+		if recover() == nil {
+			t.Error("ChainHash without a key did not panic")
+		}
+	}()
+	ChainHash("prev", []byte("payload"))
+}
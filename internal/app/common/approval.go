@@ -0,0 +1,25 @@
+package common
+
+// Statuses of requests that need approval, such as leave requests and attendance corrections
+const (
+	ApprovalStatusPending   = "pending"
+	ApprovalStatusApproved  = "approved"
+	ApprovalStatusRejected  = "rejected"
+	ApprovalStatusCancelled = "cancelled"
+)
+
+var APPROVAL_STATUS_LIST = []string{
+	ApprovalStatusPending,
+	ApprovalStatusApproved,
+	ApprovalStatusRejected,
+	ApprovalStatusCancelled,
+}
+
+func ValidateApprovalStatus(status string) bool {
+	for _, v := range APPROVAL_STATUS_LIST {
+		if v == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package common
+
+// Attendance record statuses
+const (
+	AttendanceStatusPresent    = "present"
+	AttendanceStatusLate       = "late"
+	AttendanceStatusIncomplete = "incomplete"
+	AttendanceStatusAbsent     = "absent"
+	AttendanceStatusHoliday    = "holiday"
+	AttendanceStatusDayOff     = "day_off"
+	AttendanceStatusLeave      = "leave"
+
+	// AttendanceCalculateMaxDays limits the date range of one calculation request.
+	AttendanceCalculateMaxDays = 366
+)
+
+// Attendance correction types
+const (
+	AttendanceCorrectionClockIn  = "clock_in"
+	AttendanceCorrectionClockOut = "clock_out"
+)
+
+var ATTENDANCE_STATUS_LIST = []string{
+	AttendanceStatusPresent,
+	AttendanceStatusLate,
+	AttendanceStatusIncomplete,
+	AttendanceStatusAbsent,
+	AttendanceStatusHoliday,
+	AttendanceStatusDayOff,
+	AttendanceStatusLeave,
+}
+
+var ATTENDANCE_CORRECTION_TYPE_LIST = []string{
+	AttendanceCorrectionClockIn,
+	AttendanceCorrectionClockOut,
+}
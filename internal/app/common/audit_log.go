@@ -0,0 +1,14 @@
+package common
+
+// Audit log actions
+const (
+	AuditActionRetentionPurge    = "retention.purge"
+	AuditActionDataSubjectExport = "data_subject.export"
+	AuditActionDataSubjectErase  = "data_subject.erase"
+)
+
+// Audit log entity types
+const (
+	AuditEntityRetentionRun = "retention_run"
+	AuditEntityPerson       = "person"
+)
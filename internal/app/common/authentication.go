@@ -2,11 +2,17 @@ package common
 
 import (
 	"fmt"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
-var JwtSecret = []byte("access-control-management-secret-key")
+// JwtSecret signs the tokens and JwtExpiration is how long they are valid. Both are set from the
+// config at startup.
+var (
+	JwtSecret     []byte
+	JwtExpiration = 24 * time.Hour
+)
 
 const workFactor = 12 // ค่ามาตรฐานทั่วไปคือ 10-12, ค่าที่สูงขึ้นคือปลอดภัยขึ้น
 
@@ -1,10 +1,39 @@
 package common
 
+// Person types
+const (
+	PersonTypeEmployee = "employee"
+	PersonTypeVisitor  = "visitor"
+)
+
 const (
 	// UploadPaths
 	UploadPath = "/uploads"
 
+	// FileURLPrefix is the API route stored files are served from
+	FileURLPrefix = "/api/files"
+
 	FaceImagePath = "/images/faces/people"
 	IDCardPath    = "/images/id-cards"
 	PassportPath  = "/images/passports"
+
+	// Face image limits
+	FaceImageMaxBytes  = 10 << 20 // 10 MB
+	FaceImageMinWidth  = 200
+	FaceImageMinHeight = 200
+
+	// Face image variants
+	FaceImageJPEGQuality        = 90
+	FaceImageNormalizedFileName = "normalized.jpg"
+	FaceImageThumbnailFileName  = "thumbnail.jpg"
+
+	// Face image ZIP import
+	FaceImageImportMaxBytes = 500 << 20 // 500 MB
+	FaceImageManifestName   = "manifest.csv"
 )
+
+// FACE_IMAGE_CONTENT_TYPES maps the accepted face image MIME types to their file extension.
+var FACE_IMAGE_CONTENT_TYPES = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+}
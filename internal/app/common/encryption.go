@@ -0,0 +1,174 @@
+package common
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Encrypted columns are stored as "enc:<key ID>:<base64 of nonce and AES-256-GCM ciphertext>".
+// Values without the prefix were written before encryption was enabled and are read as they are.
+const (
+	EncryptedValuePrefix = "enc:"
+	EncryptionKeySize    = 32
+	// SecretMask replaces a stored secret in responses. Sending it back on an update keeps the
+	// stored secret.
+	SecretMask = "********"
+)
+
+type encryptionKeyring struct {
+	currentID string
+	ciphers   map[string]cipher.AEAD
+	indexKey  []byte
+}
+
+var (
+	keyringMu sync.RWMutex
+	keyring   *encryptionKeyring
+)
+
+// ParseEncryptionKey decodes a base64 encoded 32 byte key.
+func ParseEncryptionKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("encryption key is not valid base64: %w", err)
+	}
+	if len(key) != EncryptionKeySize {
+		return nil, fmt.Errorf("encryption key must be %d bytes, got %d", EncryptionKeySize, len(key))
+	}
+	return key, nil
+}
+
+// SetEncryptionKeys sets the keys used for encrypted columns. keys maps key IDs to keys, new
+// values are encrypted with currentID while the other keys can still decrypt older values. Blind
+// indexes are keyed with indexKey, which stays the same when the encryption key is rotated.
+func SetEncryptionKeys(currentID string, keys map[string][]byte, indexKey []byte) error {
+	if _, ok := keys[currentID]; !ok {
+		return fmt.Errorf("encryption key '%s' is missing", currentID)
+	}
+	ring := &encryptionKeyring{currentID: currentID, ciphers: make(map[string]cipher.AEAD)}
+	for id, key := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return fmt.Errorf("encryption key ID '%s' must be non-empty and cannot contain ':'", id)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return fmt.Errorf("invalid encryption key '%s': %w", id, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return fmt.Errorf("invalid encryption key '%s': %w", id, err)
+		}
+		ring.ciphers[id] = aead
+	}
+	if len(indexKey) != EncryptionKeySize {
+		return fmt.Errorf("blind index key must be %d bytes, got %d", EncryptionKeySize, len(indexKey))
+	}
+	ring.indexKey = indexKey
+
+	keyringMu.Lock()
+	keyring = ring
+	keyringMu.Unlock()
+	return nil
+}
+
+func getKeyring() (*encryptionKeyring, error) {
+	keyringMu.RLock()
+	defer keyringMu.RUnlock()
+	if keyring == nil {
+		return nil, fmt.Errorf("encryption keys are not configured")
+	}
+	return keyring, nil
+}
+
+// CurrentEncryptionPrefix returns the prefix of values encrypted with the current key. Stored
+// values without it need to be re-encrypted after a key rotation.
+func CurrentEncryptionPrefix() (string, error) {
+	ring, err := getKeyring()
+	if err != nil {
+		return "", err
+	}
+	return EncryptedValuePrefix + ring.currentID + ":", nil
+}
+
+// EncryptValue encrypts a value with the current key.
+func EncryptValue(plain string) (string, error) {
+	ring, err := getKeyring()
+	if err != nil {
+		return "", err
+	}
+	aead := ring.ciphers[ring.currentID]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plain), nil)
+	return EncryptedValuePrefix + ring.currentID + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptValue decrypts a stored value. Values without the encrypted prefix are returned as they
+// are.
+func DecryptValue(stored string) (string, error) {
+	if !strings.HasPrefix(stored, EncryptedValuePrefix) {
+		return stored, nil
+	}
+	keyID, encoded, ok := strings.Cut(strings.TrimPrefix(stored, EncryptedValuePrefix), ":")
+	if !ok {
+		return "", fmt.Errorf("encrypted value is malformed")
+	}
+	ring, err := getKeyring()
+	if err != nil {
+		return "", err
+	}
+	aead, ok := ring.ciphers[keyID]
+	if !ok {
+		return "", fmt.Errorf("encryption key '%s' is not configured", keyID)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("encrypted value is malformed")
+	}
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value with key '%s': %w", keyID, err)
+	}
+	return string(plain), nil
+}
+
+// BlindIndex returns a keyed hash of a value for exact, case-insensitive lookups on an encrypted
+// column. A nil value has no index and a blank value has an empty one. It panics when the keys are
+// not set, which happens at startup before any value is stored or looked up.
+func BlindIndex(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	index := ""
+	if strings.TrimSpace(*value) == "" {
+		return &index
+	}
+	ring, err := getKeyring()
+	if err != nil {
+		panic(err)
+	}
+	mac := hmac.New(sha256.New, ring.indexKey)
+	mac.Write([]byte(strings.ToLower(strings.TrimSpace(*value))))
+	index = hex.EncodeToString(mac.Sum(nil))
+	return &index
+}
+
+// MaskSecret hides a secret in a response. Nil or empty secrets stay empty so clients can tell
+// whether one is set.
+func MaskSecret(value *string) *string {
+	if value == nil || *value == "" {
+		return nil
+	}
+	mask := SecretMask
+	return &mask
+}
@@ -0,0 +1,48 @@
+package common
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBlindIndexSurvivesKeyRotation(t *testing.T) {
+	t.Cleanup(func() { keyring = nil })
+	oldKey := bytes.Repeat([]byte{1}, EncryptionKeySize)
+	newKey := bytes.Repeat([]byte{2}, EncryptionKeySize)
+	indexKey := bytes.Repeat([]byte{3}, EncryptionKeySize)
+	email := "Someone@Example.com"
+
+	if err := SetEncryptionKeys("1", map[string][]byte{"1": oldKey}, indexKey); err != nil {
+		t.Fatal(err)
+	}
+	before := *BlindIndex(&email)
+
+	if err := SetEncryptionKeys("2", map[string][]byte{"1": oldKey, "2": newKey}, indexKey); err != nil {
+		t.Fatal(err)
+	}
+	if after := *BlindIndex(&email); after != before {
+		t.Errorf("BlindIndex(%q) = %q after rotating the encryption key, want %q", email, after, before)
+	}
+	lower := "someone@example.com "
+	if index := *BlindIndex(&lower); index != before {
+		t.Errorf("BlindIndex(%q) = %q, want %q", lower, index, before)
+	}
+
+	if err := SetEncryptionKeys("2", map[string][]byte{"2": newKey}, bytes.Repeat([]byte{4}, EncryptionKeySize)); err != nil {
+		t.Fatal(err)
+	}
+	if after := *BlindIndex(&email); after == before {
+		t.Errorf("BlindIndex(%q) did not change with the index key", email)
+	}
+}
+
+func TestBlindIndexWithoutKeys(t *testing.T) {
+	keyring = nil
+	email := "someone@example.com"
+	defer func() {
+		if recover() == nil {
+			t.Error("BlindIndex without keys did not panic")
+		}
+	}()
+	BlindIndex(&email)
+}
@@ -0,0 +1,106 @@
+package common
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Error codes of the error responses. Clients can rely on them, unlike on the messages.
+const (
+	ErrorCodeBadRequest   = "BAD_REQUEST"
+	ErrorCodeValidation   = "VALIDATION_FAILED"
+	ErrorCodeUnauthorized = "UNAUTHORIZED"
+	ErrorCodeForbidden    = "FORBIDDEN"
+	ErrorCodeNotFound     = "NOT_FOUND"
+	ErrorCodeConflict     = "CONFLICT"
+	ErrorCodeInternal     = "INTERNAL_ERROR"
+)
+
+// NotFoundError is returned when a resource of a request does not exist.
+type NotFoundError struct {
+	Message string
+}
+
+func (e *NotFoundError) Error() string { return e.Message }
+
+// NewNotFoundError returns a NotFoundError with a formatted message.
+func NewNotFoundError(format string, args ...interface{}) error {
+	return &NotFoundError{Message: fmt.Errorf(format, args...).Error()}
+}
+
+// ConflictError is returned when a request conflicts with the current state, e.g. a duplicate name
+// or a change of a request that is no longer pending.
+type ConflictError struct {
+	Message string
+}
+
+func (e *ConflictError) Error() string { return e.Message }
+
+// NewConflictError returns a ConflictError with a formatted message.
+func NewConflictError(format string, args ...interface{}) error {
+	return &ConflictError{Message: fmt.Errorf(format, args...).Error()}
+}
+
+// ForbiddenError is returned when the user may not do what a request asks.
+type ForbiddenError struct {
+	Message string
+}
+
+func (e *ForbiddenError) Error() string { return e.Message }
+
+// NewForbiddenError returns a ForbiddenError with a formatted message.
+func NewForbiddenError(format string, args ...interface{}) error {
+	return &ForbiddenError{Message: fmt.Errorf(format, args...).Error()}
+}
+
+// FieldError is the error of one field of a request.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError is returned when a request is invalid. Fields lists the invalid fields when
+// they are known.
+type ValidationError struct {
+	Message string
+	Fields  []FieldError
+}
+
+func (e *ValidationError) Error() string { return e.Message }
+
+// NewValidationError returns a ValidationError with a formatted message.
+func NewValidationError(format string, args ...interface{}) error {
+	return &ValidationError{Message: fmt.Errorf(format, args...).Error()}
+}
+
+// NewFieldError returns a ValidationError of one field with a formatted message.
+func NewFieldError(field string, format string, args ...interface{}) error {
+	message := fmt.Errorf(format, args...).Error()
+	return &ValidationError{Message: message, Fields: []FieldError{{Field: field, Message: message}}}
+}
+
+// ErrorBody is the body of every error response.
+type ErrorBody struct {
+	Success bool         `json:"success"`
+	Message string       `json:"message"`
+	Code    string       `json:"code"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+// StatusErrorCode returns the error code of an HTTP status.
+func StatusErrorCode(httpStatus int) string {
+	switch httpStatus {
+	case http.StatusUnauthorized:
+		return ErrorCodeUnauthorized
+	case http.StatusForbidden:
+		return ErrorCodeForbidden
+	case http.StatusNotFound:
+		return ErrorCodeNotFound
+	case http.StatusConflict:
+		return ErrorCodeConflict
+	}
+	if httpStatus >= http.StatusInternalServerError {
+		return ErrorCodeInternal
+	}
+	return ErrorCodeBadRequest
+}
@@ -0,0 +1,128 @@
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateLayout is the layout of date only values such as holiday dates.
+const DateLayout = "2006-01-02"
+
+// ICalendarEvent is an all-day or timed event read from an iCalendar file, reduced to dates.
+// EndDate is inclusive.
+type ICalendarEvent struct {
+	Summary   string
+	StartDate string
+	EndDate   string
+}
+
+// ValidateDateStr checks that a string is a date in "2006-01-02" format.
+func ValidateDateStr(date string) bool {
+	_, err := time.Parse(DateLayout, date)
+	return err == nil
+}
+
+// ParseICalendar reads the VEVENT entries of an iCalendar (.ics) file. Only SUMMARY, DTSTART and
+// DTEND are used. All-day events have an exclusive DTEND as per RFC 5545, so one day is taken off.
+func ParseICalendar(data []byte) ([]ICalendarEvent, error) {
+	var events []ICalendarEvent
+	var current *ICalendarEvent
+	var endValue string
+	var endIsDate bool
+
+	for i, line := range unfoldICalendarLines(data) {
+		name, value, ok := splitICalendarLine(line)
+		if !ok {
+			continue
+		}
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			current = &ICalendarEvent{}
+			endValue, endIsDate = "", false
+		case name == "END" && value == "VEVENT":
+			if current == nil {
+				return nil, fmt.Errorf("unexpected END:VEVENT at line %d", i+1)
+			}
+			if current.StartDate == "" {
+				return nil, fmt.Errorf("event '%s' has no DTSTART", current.Summary)
+			}
+			current.EndDate = current.StartDate
+			if endValue != "" {
+				endDate, err := parseICalendarDate(endValue)
+				if err != nil {
+					return nil, fmt.Errorf("event '%s' has an invalid DTEND: %w", current.Summary, err)
+				}
+				if endIsDate {
+					endDate = endDate.AddDate(0, 0, -1)
+				}
+				if endDate.Format(DateLayout) > current.StartDate {
+					current.EndDate = endDate.Format(DateLayout)
+				}
+			}
+			events = append(events, *current)
+			current = nil
+		case current == nil:
+			continue
+		case name == "SUMMARY":
+			current.Summary = unescapeICalendarText(value)
+		case name == "DTSTART":
+			startDate, err := parseICalendarDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("event '%s' has an invalid DTSTART: %w", current.Summary, err)
+			}
+			current.StartDate = startDate.Format(DateLayout)
+		case name == "DTEND":
+			endValue = value
+			// A DATE value has no time part, a DATE-TIME value does
+			endIsDate = !strings.Contains(value, "T")
+		}
+	}
+	if current != nil {
+		return nil, fmt.Errorf("missing END:VEVENT")
+	}
+	return events, nil
+}
+
+// unfoldICalendarLines joins folded lines (continuation lines start with a space or a tab).
+func unfoldICalendarLines(data []byte) []string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// splitICalendarLine splits "NAME;PARAM=X:VALUE" into its name and value, dropping the parameters.
+func splitICalendarLine(line string) (name string, value string, ok bool) {
+	colon := strings.Index(line, ":")
+	if colon < 0 {
+		return "", "", false
+	}
+	name, value = line[:colon], line[colon+1:]
+	if semicolon := strings.Index(name, ";"); semicolon >= 0 {
+		name = name[:semicolon]
+	}
+	return strings.ToUpper(name), strings.TrimSpace(value), true
+}
+
+// parseICalendarDate reads the date part of a DATE ("20250101") or DATE-TIME ("20250101T090000Z") value.
+func parseICalendarDate(value string) (time.Time, error) {
+	if len(value) < len("20060102") {
+		return time.Time{}, fmt.Errorf("'%s' is not a date", value)
+	}
+	return time.Parse("20060102", value[:len("20060102")])
+}
+
+func unescapeICalendarText(text string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(text)
+}
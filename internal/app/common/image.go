@@ -0,0 +1,242 @@
+package common
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// FaceImageOptions controls how uploaded face images are normalized.
+type FaceImageOptions struct {
+	// Size is the maximum width/height of the normalized image sent to devices.
+	Size int
+	// ThumbnailSize is the maximum width/height of the thumbnail shown in the UI.
+	ThumbnailSize int
+	// Quality is the JPEG quality of the normalized image and the thumbnail.
+	Quality int
+}
+
+// ProcessedFaceImage holds every stored variant of an uploaded face image.
+type ProcessedFaceImage struct {
+	Original          []byte
+	OriginalExtension string
+	Normalized        []byte
+	Thumbnail         []byte
+}
+
+// ValidateFaceImage checks the MIME type and the resolution of a face image.
+// It returns the file extension matching the detected MIME type.
+func ValidateFaceImage(data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", NewValidationError("image is empty")
+	}
+	if len(data) > FaceImageMaxBytes {
+		return "", NewValidationError("image is larger than %d MB", FaceImageMaxBytes>>20)
+	}
+
+	contentType := http.DetectContentType(data)
+	extension, ok := FACE_IMAGE_CONTENT_TYPES[contentType]
+	if !ok {
+		return "", NewValidationError("unsupported image type '%s', only JPEG and PNG are allowed", contentType)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", NewValidationError("failed to read image: %w", err)
+	}
+	if config.Width < FaceImageMinWidth || config.Height < FaceImageMinHeight {
+		return "", NewValidationError("image is %dx%d, minimum is %dx%d", config.Width, config.Height, FaceImageMinWidth, FaceImageMinHeight)
+	}
+
+	return extension, nil
+}
+
+// ProcessFaceImage validates a face image, applies its EXIF orientation and produces the
+// normalized JPEG for devices and the JPEG thumbnail for the UI. The original bytes are kept as-is.
+func ProcessFaceImage(data []byte, options FaceImageOptions) (*ProcessedFaceImage, error) {
+	extension, err := ValidateFaceImage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	img = applyOrientation(img, readJPEGOrientation(data))
+
+	quality := options.Quality
+	if quality <= 0 {
+		quality = jpeg.DefaultQuality
+	}
+
+	normalized, err := encodeJPEG(resizeToFit(img, options.Size), quality)
+	if err != nil {
+		return nil, err
+	}
+	thumbnail, err := encodeJPEG(resizeToFit(img, options.ThumbnailSize), quality)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProcessedFaceImage{
+		Original:          data,
+		OriginalExtension: extension,
+		Normalized:        normalized,
+		Thumbnail:         thumbnail,
+	}, nil
+}
+
+// resizeToFit scales img down so that neither side is larger than size. Images are never upscaled.
+func resizeToFit(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if size <= 0 || (width <= size && height <= size) {
+		return img
+	}
+
+	if width >= height {
+		height = height * size / width
+		width = size
+	} else {
+		width = width * size / height
+		height = size
+	}
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, xdraw.Src, nil)
+	return dst
+}
+
+func encodeJPEG(img image.Image, quality int) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := jpeg.Encode(&buffer, img, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// readJPEGOrientation returns the EXIF orientation (1-8) of a JPEG image, or 1 when there is none.
+func readJPEGOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	offset := 2
+	for offset+4 <= len(data) {
+		if data[offset] != 0xFF {
+			return 1
+		}
+		marker := data[offset+1]
+		// Start of scan: no more metadata segments
+		if marker == 0xDA {
+			return 1
+		}
+		segmentLength := int(binary.BigEndian.Uint16(data[offset+2 : offset+4]))
+		segmentEnd := offset + 2 + segmentLength
+		if segmentLength < 2 || segmentEnd > len(data) {
+			return 1
+		}
+
+		segment := data[offset+4 : segmentEnd]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return readTIFFOrientation(segment[6:])
+		}
+		offset = segmentEnd
+	}
+	return 1
+}
+
+// readTIFFOrientation reads the orientation tag (0x0112) of IFD0 in a TIFF/EXIF block.
+func readTIFFOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifdOffset := int(order.Uint32(tiff[4:8]))
+	if ifdOffset+2 > len(tiff) {
+		return 1
+	}
+	entryCount := int(order.Uint16(tiff[ifdOffset : ifdOffset+2]))
+	for i := 0; i < entryCount; i++ {
+		entry := ifdOffset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:entry+2]) == 0x0112 {
+			orientation := int(order.Uint16(tiff[entry+8 : entry+10]))
+			if orientation < 1 || orientation > 8 {
+				return 1
+			}
+			return orientation
+		}
+	}
+	return 1
+}
+
+// applyOrientation rotates/flips img so that it is displayed upright for the given EXIF orientation.
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	src := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+	width, height := bounds.Dx(), bounds.Dy()
+
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+
+	for y := 0; y < dstHeight; y++ {
+		for x := 0; x < dstWidth; x++ {
+			var srcX, srcY int
+			switch orientation {
+			case 2: // mirror horizontal
+				srcX, srcY = width-1-x, y
+			case 3: // rotate 180
+				srcX, srcY = width-1-x, height-1-y
+			case 4: // mirror vertical
+				srcX, srcY = x, height-1-y
+			case 5: // transpose
+				srcX, srcY = y, x
+			case 6: // rotate 90 clockwise
+				srcX, srcY = y, height-1-x
+			case 7: // transverse
+				srcX, srcY = width-1-y, height-1-x
+			case 8: // rotate 90 counter-clockwise
+				srcX, srcY = width-1-y, x
+			}
+			srcIndex := src.PixOffset(srcX, srcY)
+			dstIndex := dst.PixOffset(x, y)
+			copy(dst.Pix[dstIndex:dstIndex+4], src.Pix[srcIndex:srcIndex+4])
+		}
+	}
+	return dst
+}
@@ -0,0 +1,12 @@
+package common
+
+// Half-day leave periods
+const (
+	LeaveHalfDayMorning   = "morning"
+	LeaveHalfDayAfternoon = "afternoon"
+)
+
+var LEAVE_HALF_DAY_LIST = []string{
+	LeaveHalfDayMorning,
+	LeaveHalfDayAfternoon,
+}
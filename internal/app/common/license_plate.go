@@ -0,0 +1,110 @@
+package common
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// AccessControlDeviceTypeLPRCamera is the device type of license plate recognition cameras.
+	AccessControlDeviceTypeLPRCamera = "lpr_camera"
+
+	// LicensePlateMinConfidence is the lowest recognition confidence (0-1) a gate opens for.
+	LicensePlateMinConfidence = 0.6
+	// LicensePlateMaxDistance is the number of character edits tolerated by fuzzy matching.
+	LicensePlateMaxDistance = 1
+	// LicensePlateMinFuzzyLength avoids fuzzy matching on plates too short to tell apart.
+	LicensePlateMinFuzzyLength = 5
+	// LicensePlateFuzzyAnchorLength is the length of the prefix and the suffix fuzzy candidates
+	// are looked up by. A plate one edit away from another of at least LicensePlateMinFuzzyLength
+	// characters shares its first or its last LicensePlateFuzzyAnchorLength characters.
+	LicensePlateFuzzyAnchorLength = 2
+	// LicensePlateMaxFuzzyCandidates bounds the registered plates compared by fuzzy matching.
+	LicensePlateMaxFuzzyCandidates = 200
+	// LicensePlateRekeyBatchSize is the number of plates read at a time when setting missing
+	// normalized plate texts.
+	LicensePlateRekeyBatchSize = 500
+	// licensePlateMinRegionLength is the shortest trailing letter run treated as a region name
+	// (e.g. a Thai province) instead of a part of the plate.
+	licensePlateMinRegionLength = 4
+
+	LicensePlateImagePath = "/images/license-plates"
+)
+
+// licensePlateConfusables maps characters that cameras often mix up to one canonical character.
+// Only O/0 and I/1 are folded: folding more pairs such as S/5 or B/8 makes distinct plates share a
+// key, and with fuzzy matching on top a gate would open for another vehicle.
+var licensePlateConfusables = map[rune]rune{
+	'O': '0',
+	'I': '1',
+}
+
+// NormalizeLicensePlate returns the comparison key of a plate: upper case, without separators
+// and with confusable characters folded, so "ab-1O2" and "AB 102" give the same key.
+// A trailing region name (e.g. "1กข 1234 กรุงเทพมหานคร") is dropped.
+func NormalizeLicensePlate(plate string) string {
+	var builder strings.Builder
+	for _, r := range strings.ToUpper(plate) {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			continue
+		}
+		if canonical, ok := licensePlateConfusables[r]; ok {
+			r = canonical
+		}
+		builder.WriteRune(r)
+	}
+	return stripLicensePlateRegion(builder.String())
+}
+
+// stripLicensePlateRegion removes a trailing run of letters when it is long enough to be a region name
+// and the plate still has digits before it.
+func stripLicensePlateRegion(plate string) string {
+	end := len(plate)
+	letters := 0
+	for end > 0 {
+		r, size := utf8.DecodeLastRuneInString(plate[:end])
+		if !unicode.IsLetter(r) {
+			break
+		}
+		end -= size
+		letters++
+	}
+	if letters < licensePlateMinRegionLength || end == 0 {
+		return plate
+	}
+	return plate[:end]
+}
+
+// LicensePlateDistance is the Levenshtein distance between two normalized plates.
+func LicensePlateDistance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// IsFuzzyLicensePlateMatch reports whether two normalized plates are close enough to be the same vehicle.
+func IsFuzzyLicensePlateMatch(a string, b string) bool {
+	if a == b {
+		return true
+	}
+	if utf8.RuneCountInString(a) < LicensePlateMinFuzzyLength || utf8.RuneCountInString(b) < LicensePlateMinFuzzyLength {
+		return false
+	}
+	return LicensePlateDistance(a, b) <= LicensePlateMaxDistance
+}
@@ -0,0 +1,73 @@
+package common
+
+import "testing"
+
+func TestNormalizeLicensePlate(t *testing.T) {
+	tests := []struct {
+		name  string
+		plate string
+		want  string
+	}{
+		{"upper cases and drops separators", "ab-12 34", "AB1234"},
+		{"folds O and I", "AO 1I2", "A0112"},
+		{"keeps other confusables apart", "S5 B8 G6 Z2", "S5B8G6Z2"},
+		{"drops a Thai province", "1กข 1234 กรุงเทพมหานคร", "1กข1234"},
+		{"keeps a short trailing letter run", "1234 AB", "1234AB"},
+		{"empty", " - ", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeLicensePlate(tt.plate); got != tt.want {
+				t.Errorf("NormalizeLicensePlate(%q) = %q, want %q", tt.plate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripLicensePlateRegion(t *testing.T) {
+	tests := []struct {
+		name  string
+		plate string
+		want  string
+	}{
+		{"region after digits, vowel marks already dropped", "1กข1234กรงเทพมหานคร", "1กข1234"},
+		{"latin region", "AB1234ABCD", "AB1234"},
+		{"run shorter than a region", "1234ABC", "1234ABC"},
+		{"only letters", "ABCDEFG", "ABCDEFG"},
+		{"no trailing letters", "AB1234", "AB1234"},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stripLicensePlateRegion(tt.plate); got != tt.want {
+				t.Errorf("stripLicensePlateRegion(%q) = %q, want %q", tt.plate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsFuzzyLicensePlateMatch(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want bool
+	}{
+		{"equal", "AB1234", "AB1234", true},
+		{"equal short plates", "AB1", "AB1", true},
+		{"one substitution", "AB12345", "AB12346", true},
+		{"one insertion", "AB1234", "AB12345", true},
+		{"one deletion", "AB12345", "AB1245", true},
+		{"two edits", "AB12345", "AB12367", false},
+		{"short plates are never fuzzy", "AB12", "AB13", false},
+		{"one side too short", "AB12", "AB123", false},
+		{"multibyte characters count once", "1กข1234", "1กค1234", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsFuzzyLicensePlateMatch(tt.a, tt.b); got != tt.want {
+				t.Errorf("IsFuzzyLicensePlateMatch(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
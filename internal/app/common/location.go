@@ -0,0 +1,34 @@
+package common
+
+// Location types from the top of the hierarchy down. A location can only be placed under a
+// location of a higher level, levels may be skipped such as a door directly in a building.
+const (
+	LocationTypeSite     = "site"
+	LocationTypeBuilding = "building"
+	LocationTypeFloor    = "floor"
+	LocationTypeZone     = "zone"
+	LocationTypeDoor     = "door"
+)
+
+var LOCATION_TYPE_LIST = []string{
+	LocationTypeSite,
+	LocationTypeBuilding,
+	LocationTypeFloor,
+	LocationTypeZone,
+	LocationTypeDoor,
+}
+
+// LocationPathSeparator joins the names of a location and its parents, such as
+// "HQ / Tower A / Floor 3".
+const LocationPathSeparator = " / "
+
+// LocationTypeLevel returns the level of a location type, 0 for the site, and -1 for an unknown
+// type.
+func LocationTypeLevel(locationType string) int {
+	for i, v := range LOCATION_TYPE_LIST {
+		if v == locationType {
+			return i
+		}
+	}
+	return -1
+}
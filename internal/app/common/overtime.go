@@ -0,0 +1,33 @@
+package common
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Overtime day types, each with its own multiplier
+const (
+	OvertimeDayWeekday = "weekday"
+	OvertimeDayWeekend = "weekend"
+	OvertimeDayHoliday = "holiday"
+)
+
+// DefaultOvertimeWeekendDays are Saturday and Sunday.
+const DefaultOvertimeWeekendDays = "6,7"
+
+// ParseWeekendDays parses a comma separated list of days of week (1 = Monday ... 7 = Sunday).
+func ParseWeekendDays(weekendDays string) ([]int, bool) {
+	var days []int
+	for _, part := range strings.Split(weekendDays, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		day, err := strconv.Atoi(part)
+		if err != nil || day < 1 || day > 7 {
+			return nil, false
+		}
+		days = append(days, day)
+	}
+	return days, true
+}
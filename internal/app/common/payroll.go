@@ -0,0 +1,152 @@
+package common
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Payroll export file formats
+const (
+	PayrollFormatCSV        = "csv"
+	PayrollFormatFixedWidth = "fixed_width"
+)
+
+var PAYROLL_FORMAT_LIST = []string{
+	PayrollFormatCSV,
+	PayrollFormatFixedWidth,
+}
+
+// Statuses of a generated payroll export. A locked export closes its period.
+const (
+	PayrollExportStatusOpen   = "open"
+	PayrollExportStatusLocked = "locked"
+)
+
+// Payroll export column fields
+const (
+	PayrollFieldEmployeeID            = "employee_id"
+	PayrollFieldFirstName             = "first_name"
+	PayrollFieldLastName              = "last_name"
+	PayrollFieldCompany               = "company"
+	PayrollFieldDepartment            = "department"
+	PayrollFieldPeriodStart           = "period_start"
+	PayrollFieldPeriodEnd             = "period_end"
+	PayrollFieldWorkedDays            = "worked_days"
+	PayrollFieldWorkedHours           = "worked_hours"
+	PayrollFieldAbsentDays            = "absent_days"
+	PayrollFieldLateMinutes           = "late_minutes"
+	PayrollFieldEarlyLeaveMinutes     = "early_leave_minutes"
+	PayrollFieldLeaveDays             = "leave_days"
+	PayrollFieldOvertimeHours         = "overtime_hours"
+	PayrollFieldWeightedOvertimeHours = "weighted_overtime_hours"
+)
+
+var PAYROLL_FIELD_LIST = []string{
+	PayrollFieldEmployeeID,
+	PayrollFieldFirstName,
+	PayrollFieldLastName,
+	PayrollFieldCompany,
+	PayrollFieldDepartment,
+	PayrollFieldPeriodStart,
+	PayrollFieldPeriodEnd,
+	PayrollFieldWorkedDays,
+	PayrollFieldWorkedHours,
+	PayrollFieldAbsentDays,
+	PayrollFieldLateMinutes,
+	PayrollFieldEarlyLeaveMinutes,
+	PayrollFieldLeaveDays,
+	PayrollFieldOvertimeHours,
+	PayrollFieldWeightedOvertimeHours,
+}
+
+// IsPayrollNumericField reports whether a payroll field holds a number, right aligned by default
+// in fixed-width files.
+func IsPayrollNumericField(field string) bool {
+	switch field {
+	case PayrollFieldWorkedDays, PayrollFieldWorkedHours, PayrollFieldAbsentDays, PayrollFieldLateMinutes,
+		PayrollFieldEarlyLeaveMinutes, PayrollFieldLeaveDays, PayrollFieldOvertimeHours, PayrollFieldWeightedOvertimeHours:
+		return true
+	}
+	return false
+}
+
+// Alignment of a fixed-width column
+const (
+	PayrollAlignLeft  = "left"
+	PayrollAlignRight = "right"
+)
+
+const (
+	DefaultPayrollDelimiter  = ","
+	DefaultPayrollDateFormat = "YYYY-MM-DD"
+
+	// PayrollExportPath is the storage folder of generated payroll files.
+	PayrollExportPath = "/exports/payroll"
+)
+
+var payrollDateTokens = strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "01", "DD", "02")
+
+// ConvertPayrollDateFormat converts a date format made of YYYY (or YY), MM and DD separated by
+// '-', '/', '.' or spaces to a Go time layout.
+func ConvertPayrollDateFormat(dateFormat string) (string, bool) {
+	rest := strings.NewReplacer("YYYY", "", "YY", "", "MM", "", "DD", "").Replace(dateFormat)
+	if strings.Trim(rest, "-/. ") != "" || !strings.Contains(dateFormat, "YY") || !strings.Contains(dateFormat, "MM") || !strings.Contains(dateFormat, "DD") {
+		return "", false
+	}
+	return payrollDateTokens.Replace(dateFormat), true
+}
+
+// ValidatePayrollDelimiter checks that a CSV delimiter is a single character that csv accepts.
+func ValidatePayrollDelimiter(delimiter string) bool {
+	if utf8.RuneCountInString(delimiter) != 1 {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(delimiter)
+	return r != '"' && r != '\r' && r != '\n' && r != utf8.RuneError
+}
+
+// PayrollColumn is the layout of one column of a payroll file. Width and Align are used by
+// fixed-width files only.
+type PayrollColumn struct {
+	Header string
+	Width  int
+	Align  string
+}
+
+// WritePayrollCSV writes rows as CSV with the given single character delimiter.
+func WritePayrollCSV(w io.Writer, delimiter string, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	writer.Comma, _ = utf8.DecodeRuneInString(delimiter)
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write csv file: %w", err)
+	}
+	return nil
+}
+
+// WritePayrollFixedWidth writes rows as fixed-width lines, padding every value with spaces to the
+// width of its column. A value longer than its column is an error rather than being cut.
+func WritePayrollFixedWidth(w io.Writer, columns []PayrollColumn, rows [][]string) error {
+	var builder strings.Builder
+	for _, row := range rows {
+		for i, value := range row {
+			column := columns[i]
+			padding := column.Width - utf8.RuneCountInString(value)
+			if padding < 0 {
+				return NewValidationError("value '%s' of column '%s' is longer than %d characters", value, column.Header, column.Width)
+			}
+			if column.Align == PayrollAlignRight {
+				builder.WriteString(strings.Repeat(" ", padding) + value)
+			} else {
+				builder.WriteString(value + strings.Repeat(" ", padding))
+			}
+		}
+		builder.WriteString("\n")
+	}
+	if _, err := io.WriteString(w, builder.String()); err != nil {
+		return fmt.Errorf("failed to write fixed-width file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package common
+
+const (
+	PersonCardStatusActive    = "active"
+	PersonCardStatusLost      = "lost"
+	PersonCardStatusSuspended = "suspended"
+	PersonCardStatusRevoked   = "revoked"
+
+	PersonCardActionIssued        = "issued"
+	PersonCardActionUpdated       = "updated"
+	PersonCardActionStatusChanged = "status_changed"
+	PersonCardActionRemoved       = "removed"
+)
+
+var PERSON_CARD_STATUS = []string{
+	PersonCardStatusActive,
+	PersonCardStatusLost,
+	PersonCardStatusSuspended,
+	PersonCardStatusRevoked,
+}
+
+func ValidatePersonCardStatus(status string) bool {
+	for _, v := range PERSON_CARD_STATUS {
+		if v == status {
+			return true
+		}
+	}
+	return false
+}
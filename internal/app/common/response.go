@@ -32,10 +32,11 @@ func SuccessResponse(c *gin.Context, message string, data interface{}) {
 	})
 }
 
-// Function to send an error response
+// Function to send an error response, with the error code of its status
 func ErrorResponse(c *gin.Context, httpStatus int, message string) {
-	c.JSON(httpStatus, APIResponse{
+	c.JSON(httpStatus, ErrorBody{
 		Success: false,
 		Message: message,
+		Code:    StatusErrorCode(httpStatus),
 	})
 }
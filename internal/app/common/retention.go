@@ -0,0 +1,46 @@
+package common
+
+// Retention data classes
+const (
+	RetentionAccessRecords     = "access_records"
+	RetentionAttendanceRecords = "attendance_records"
+	RetentionFaceImages        = "face_images"
+	RetentionAuditLogs         = "audit_logs"
+	RetentionVisitors          = "visitors"
+)
+
+// What started a retention run
+const (
+	RetentionTriggerSchedule = "schedule"
+	RetentionTriggerManual   = "manual"
+)
+
+// Statuses of a retention run. A failed run may still have purged some data classes.
+const (
+	RetentionRunStatusRunning   = "running"
+	RetentionRunStatusCompleted = "completed"
+	RetentionRunStatusFailed    = "failed"
+)
+
+const (
+	// RetentionArchivePath is the folder of the NDJSON archives, one folder per day
+	RetentionArchivePath = "/archives/retention"
+	RetentionArchiveExt  = ".ndjson.gz"
+	RetentionBatchSize   = 1000
+)
+
+// RetentionPolicy is how many days each data class is kept, 0 keeps it forever. Access records,
+// attendance records and audit logs are counted from their own time. Face images and visitors are
+// counted from the person's ExpireAt, so people without an expiry keep them.
+type RetentionPolicy struct {
+	AccessRecordDays     int
+	AttendanceRecordDays int
+	FaceImageDays        int
+	AuditLogDays         int
+	VisitorDays          int
+}
+
+// Enabled reports whether any data class has a retention period.
+func (p RetentionPolicy) Enabled() bool {
+	return p.AccessRecordDays > 0 || p.AttendanceRecordDays > 0 || p.FaceImageDays > 0 || p.AuditLogDays > 0 || p.VisitorDays > 0
+}
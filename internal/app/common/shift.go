@@ -0,0 +1,11 @@
+package common
+
+// AttendancePunchWindowMinutes is how long before the start and after the end of a shift a punch
+// still belongs to that shift, unless the shift's early in or late out tolerance is longer.
+const AttendancePunchWindowMinutes = 240
+
+// ShiftCrossesMidnight reports whether a shift ending at endTime ends on the day after it starts.
+// Both times are "15:04:05".
+func ShiftCrossesMidnight(startTime string, endTime string) bool {
+	return endTime <= startTime
+}
@@ -0,0 +1,120 @@
+package common
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	SpreadsheetFormatCSV  = "csv"
+	SpreadsheetFormatXLSX = "xlsx"
+
+	// DefaultSheetName is the sheet used when writing XLSX files.
+	DefaultSheetName = "Sheet1"
+)
+
+// SpreadsheetFormatFromFilename returns the spreadsheet format based on the file extension.
+func SpreadsheetFormatFromFilename(filename string) (string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return SpreadsheetFormatCSV, nil
+	case ".xlsx":
+		return SpreadsheetFormatXLSX, nil
+	default:
+		return "", NewValidationError("unsupported file type '%s', only .csv and .xlsx are allowed", filepath.Ext(filename))
+	}
+}
+
+// ReadSpreadsheet reads every row of an uploaded CSV or XLSX file.
+// For XLSX files only the first sheet is read.
+func ReadSpreadsheet(fileHeader *multipart.FileHeader) ([][]string, error) {
+	format, err := SpreadsheetFormatFromFilename(fileHeader.Filename)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer file.Close()
+
+	if format == SpreadsheetFormatCSV {
+		reader := csv.NewReader(file)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		rows, err := reader.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read csv file: %w", err)
+		}
+		// Strip the UTF-8 BOM that Excel adds when saving as CSV
+		if len(rows) > 0 && len(rows[0]) > 0 {
+			rows[0][0] = strings.TrimPrefix(rows[0][0], "\ufeff")
+		}
+		return rows, nil
+	}
+
+	workbook, err := excelize.OpenReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read xlsx file: %w", err)
+	}
+	defer workbook.Close()
+
+	sheets := workbook.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, NewValidationError("xlsx file has no sheet")
+	}
+	rows, err := workbook.GetRows(sheets[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read xlsx rows: %w", err)
+	}
+	return rows, nil
+}
+
+// WriteSpreadsheet writes rows to w in the given format.
+func WriteSpreadsheet(w io.Writer, format string, rows [][]string) error {
+	switch format {
+	case SpreadsheetFormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.WriteAll(rows); err != nil {
+			return fmt.Errorf("failed to write csv: %w", err)
+		}
+		return nil
+	case SpreadsheetFormatXLSX:
+		workbook := excelize.NewFile()
+		defer workbook.Close()
+		for i, row := range rows {
+			cell, err := excelize.CoordinatesToCellName(1, i+1)
+			if err != nil {
+				return err
+			}
+			values := make([]interface{}, len(row))
+			for j, value := range row {
+				values[j] = value
+			}
+			if err := workbook.SetSheetRow(DefaultSheetName, cell, &values); err != nil {
+				return fmt.Errorf("failed to write xlsx row: %w", err)
+			}
+		}
+		if err := workbook.Write(w); err != nil {
+			return fmt.Errorf("failed to write xlsx: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported spreadsheet format '%s'", format)
+	}
+}
+
+// SpreadsheetContentType returns the MIME type for the given spreadsheet format.
+func SpreadsheetContentType(format string) string {
+	if format == SpreadsheetFormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv"
+}
@@ -0,0 +1,62 @@
+package common
+
+import (
+	"context"
+	"path"
+	"strings"
+)
+
+// DefaultTenantCode is the code of the tenant that owns the data stored before tenants existed.
+const DefaultTenantCode = "default"
+
+// TenantFileRoot is the storage folder holding a folder of files for every tenant.
+const TenantFileRoot = "/tenants"
+
+type tenantContextKey struct{}
+
+// tenantScope is the tenant a database session works in. allTenants is the global scope of
+// super admins and background jobs, which sees the data of every tenant.
+type tenantScope struct {
+	tenantID   string
+	allTenants bool
+}
+
+// WithTenant returns a context whose database queries only see the data of one tenant.
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantScope{tenantID: tenantID})
+}
+
+// WithAllTenants returns a context whose database queries see the data of every tenant.
+func WithAllTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantScope{allTenants: true})
+}
+
+// TenantFromContext returns the tenant of a context. ok is false when the context has no tenant
+// scope at all, allTenants is true for the global scope.
+func TenantFromContext(ctx context.Context) (tenantID string, allTenants bool, ok bool) {
+	if ctx == nil {
+		return "", false, false
+	}
+	scope, ok := ctx.Value(tenantContextKey{}).(tenantScope)
+	if !ok {
+		return "", false, false
+	}
+	return scope.tenantID, scope.allTenants, true
+}
+
+// TenantFilePath returns the path of a file in the storage folder of a tenant.
+func TenantFilePath(tenantID string, filePath string) string {
+	return path.Join(TenantFileRoot, tenantID, filePath)
+}
+
+// SplitTenantFilePath splits a path in the storage folder of a tenant into the tenant and the path
+// in its folder. ok is false for a path outside the tenant folders, such as a file stored before
+// tenants existed.
+func SplitTenantFilePath(filePath string) (tenantID string, tenantPath string, ok bool) {
+	rest, found := strings.CutPrefix(path.Clean("/"+filePath), TenantFileRoot+"/")
+	if !found {
+		return "", "", false
+	}
+	tenantID, tenantPath, _ = strings.Cut(rest, "/")
+	return tenantID, "/" + tenantPath, true
+}
@@ -1,6 +1,16 @@
 package common
 
-import "time"
+import (
+	"strings"
+	"time"
+)
+
+// ClockLayout is the layout of time of day values such as schedule start and end times.
+const ClockLayout = "15:04:05"
+
+// LegacyTimestampLayout is the layout of timestamps without a time zone offset accepted before
+// RFC 3339. Such timestamps are read in the local time of the device or the server.
+const LegacyTimestampLayout = "2006-01-02 15:04:05"
 
 var DefaultAttendanceStartTime = "08:00:00"
 var DefaultAttendanceEndTime = "16:00:00"
@@ -9,11 +19,51 @@ var DefaultZero = 0
 var DefaultAccessControlStartTime = "00:00:00"
 var DefaultAccessControlEndTime = "23:59:59"
 
-func ConvertTimeStrToTime(timeStr string) (time.Time, error) {
-	layout := "2006-01-02 15:04:05"
-	t, err := time.Parse(layout, timeStr)
-	if err != nil {
-		return t, err
+// ParseTimestamp parses an RFC 3339 timestamp such as "2024-05-01T08:30:00+07:00". A timestamp in
+// LegacyTimestampLayout has no offset and is read in loc, the server time zone when loc is nil.
+func ParseTimestamp(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	return time.ParseInLocation(LegacyTimestampLayout, value, loc)
+}
+
+// FormatTimestamp formats a timestamp as RFC 3339 with the offset of loc, the server time zone
+// when loc is nil.
+func FormatTimestamp(t time.Time, loc *time.Location) string {
+	if loc == nil {
+		loc = time.Local
+	}
+	return t.In(loc).Format(time.RFC3339)
+}
+
+// LoadTimeZone loads an IANA time zone such as "Asia/Bangkok". An empty name gives the server
+// time zone.
+func LoadTimeZone(name *string) (*time.Location, error) {
+	if name == nil || *name == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(*name)
+}
+
+// ValidateTimeZone checks that a name is an IANA time zone. "Local" is refused because it means
+// the time zone of whichever server reads it.
+func ValidateTimeZone(name string) bool {
+	if strings.TrimSpace(name) == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+// ValidateClockStr checks that a string is a time of day in "15:04:05" or "15:04" format.
+func ValidateClockStr(clock string) bool {
+	if _, err := time.Parse(ClockLayout, clock); err == nil {
+		return true
 	}
-	return t, nil
+	_, err := time.Parse("15:04", clock)
+	return err == nil
 }
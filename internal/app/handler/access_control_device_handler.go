@@ -1,13 +1,11 @@
 package handler
 
 import (
-	"net/http"
-
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 	"github.com/putteror/access-control-management/internal/app/common"
 	"github.com/putteror/access-control-management/internal/app/schema"
 	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
 )
 
 type AccessControlDeviceHandler struct {
@@ -18,16 +16,16 @@ func NewAccessControlDeviceHandler(service service.AccessControlDeviceService) *
 	return &AccessControlDeviceHandler{service: service}
 }
 
-func init() {
-	validate = validator.New()
-}
-
 // GetAll retrieves all access control devices.
 func (h *AccessControlDeviceHandler) GetAll(c *gin.Context) {
 
 	var searchQuery schema.AccessControlDeviceSearchQuery
 	if err := c.ShouldBindQuery(&searchQuery); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, "Invalid search query parameter")
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(searchQuery); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -39,14 +37,14 @@ func (h *AccessControlDeviceHandler) GetAll(c *gin.Context) {
 	}
 	devices, err := h.service.GetAll(searchQuery)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 	deviceResponses := make([]schema.AccessControlDeviceResponse, len(devices))
 	for i, device := range devices {
 		response, err := h.service.ConvertToResponse(&device)
 		if err != nil {
-			common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+			c.Error(err)
 			return
 		}
 		deviceResponses[i] = *response
@@ -68,13 +66,13 @@ func (h *AccessControlDeviceHandler) GetByID(c *gin.Context) {
 
 	device, err := h.service.GetByID(id)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusNotFound, "Device not found")
+		c.Error(err)
 		return
 	}
 
 	deviceResponse, err := h.service.ConvertToResponse(device)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusNotFound, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -85,24 +83,24 @@ func (h *AccessControlDeviceHandler) GetByID(c *gin.Context) {
 func (h *AccessControlDeviceHandler) Create(c *gin.Context) {
 	var bodyRequest schema.AccessControlDeviceRequest
 	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		c.Error(validation.BindError(err))
 		return
 	}
 
-	if err := validate.Struct(bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
 		return
 	}
 
 	deviceModel, err := h.service.Create(&bodyRequest)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
 	deviceResponse, err := h.service.ConvertToResponse(deviceModel)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusNotFound, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -115,23 +113,23 @@ func (h *AccessControlDeviceHandler) Update(c *gin.Context) {
 
 	var bodyRequest schema.AccessControlDeviceRequest
 	if err := c.ShouldBind(&bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		c.Error(validation.BindError(err))
 		return
 	}
-	if err := validate.Struct(bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
 		return
 	}
 
 	deviceModel, err := h.service.Update(id, &bodyRequest)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
 	deviceResponse, err := h.service.ConvertToResponse(deviceModel)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusNotFound, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -142,19 +140,23 @@ func (h *AccessControlDeviceHandler) PartialUpdate(c *gin.Context) {
 
 	var bodyRequest schema.AccessControlDeviceRequest
 	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Partial(bodyRequest); err != nil {
+		c.Error(err)
 		return
 	}
 
 	deviceModel, err := h.service.PartialUpdate(id, &bodyRequest)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
 	deviceResponse, err := h.service.ConvertToResponse(deviceModel)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusNotFound, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -166,7 +168,7 @@ func (h *AccessControlDeviceHandler) Delete(c *gin.Context) {
 	id := c.Param("id")
 
 	if err := h.service.Delete(id); err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -1,19 +1,13 @@
 package handler
 
 import (
-	"net/http"
-	"strings"
-
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 	"github.com/putteror/access-control-management/internal/app/common"
 	"github.com/putteror/access-control-management/internal/app/schema"
 	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
 )
 
-// ต้องมั่นใจว่ามีการประกาศ var validate *validator.Validate และมีการเรียก init()
-// แต่เพื่อให้สอดคล้องกับไฟล์ต้นฉบับ จะขอเว้นไว้ และสมมติว่ามีการประกาศไว้แล้ว
-
 type AccessControlGroupHandler struct {
 	service service.AccessControlGroupService
 }
@@ -22,25 +16,6 @@ func NewAccessControlGroupHandler(service service.AccessControlGroupService) *Ac
 	return &AccessControlGroupHandler{service: service}
 }
 
-func init() {
-	validate = validator.New()
-}
-
-// ## Error Handling Utility
-// ฟังก์ชันนี้ช่วยจัดการข้อผิดพลาดเฉพาะที่เกี่ยวกับชื่อซ้ำ
-
-func handleErrorResponse(c *gin.Context, err error, defaultMessage string) {
-	// ตรวจสอบข้อความ error สำหรับกรณีชื่อซ้ำที่กำหนดไว้ก่อนหน้า
-	if strings.Contains(err.Error(), "accessControlGroup name is already exist") {
-		// นำไปใช้กับ format ที่กำหนดไว้ [2025-07-05]
-		message := "Group name is already exist"
-		common.ErrorResponse(c, http.StatusBadRequest, message)
-		return
-	}
-	// สำหรับข้อผิดพลาดอื่น ๆ
-	common.ErrorResponse(c, http.StatusInternalServerError, defaultMessage)
-}
-
 // ---
 
 // ## Get Operations
@@ -50,7 +25,11 @@ func (h *AccessControlGroupHandler) GetAll(c *gin.Context) {
 
 	var searchQuery schema.AccessControlGroupSearchQuery
 	if err := c.ShouldBindQuery(&searchQuery); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, "Invalid search query parameter")
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(searchQuery); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -62,7 +41,7 @@ func (h *AccessControlGroupHandler) GetAll(c *gin.Context) {
 	}
 	groups, err := h.service.GetAll(searchQuery)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -70,7 +49,7 @@ func (h *AccessControlGroupHandler) GetAll(c *gin.Context) {
 	for i, group := range groups {
 		response, err := h.service.ConvertToResponse(&group)
 		if err != nil {
-			common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+			c.Error(err)
 			return
 		}
 		groupResponses[i] = *response
@@ -92,13 +71,13 @@ func (h *AccessControlGroupHandler) GetByID(c *gin.Context) {
 
 	group, err := h.service.GetByID(id)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusNotFound, "Group not found")
+		c.Error(err)
 		return
 	}
 
 	groupResponse, err := h.service.ConvertToResponse(group)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -113,25 +92,24 @@ func (h *AccessControlGroupHandler) GetByID(c *gin.Context) {
 func (h *AccessControlGroupHandler) Create(c *gin.Context) {
 	var bodyRequest schema.AccessControlGroupRequest
 	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		c.Error(validation.BindError(err))
 		return
 	}
 
-	if err := validate.Struct(bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
 		return
 	}
 
 	groupModel, err := h.service.Create(&bodyRequest)
 	if err != nil {
-		// ใช้ handleErrorResponse เพื่อจัดการข้อผิดพลาดชื่อซ้ำ
-		handleErrorResponse(c, err, err.Error())
+		c.Error(err)
 		return
 	}
 
 	groupResponse, err := h.service.ConvertToResponse(groupModel)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -145,27 +123,23 @@ func (h *AccessControlGroupHandler) Update(c *gin.Context) {
 	var bodyRequest schema.AccessControlGroupRequest
 	// ควรใช้ ShouldBindJSON แทน ShouldBind เผื่อการตรวจสอบ Content-Type ที่เข้มงวดกว่า
 	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		c.Error(validation.BindError(err))
 		return
 	}
-	if err := validate.Struct(bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
 		return
 	}
 
 	groupModel, err := h.service.Update(id, &bodyRequest)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			common.ErrorResponse(c, http.StatusNotFound, err.Error())
-			return
-		}
-		handleErrorResponse(c, err, err.Error())
+		c.Error(err)
 		return
 	}
 
 	groupResponse, err := h.service.ConvertToResponse(groupModel)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -178,24 +152,23 @@ func (h *AccessControlGroupHandler) PartialUpdate(c *gin.Context) {
 
 	var bodyRequest schema.AccessControlGroupRequest
 	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Partial(bodyRequest); err != nil {
+		c.Error(err)
 		return
 	}
-	// ใน Partial Update มักจะไม่เรียก validate.Struct() เว้นแต่คุณจะใช้ validation เฉพาะบางฟิลด์
 
 	groupModel, err := h.service.PartialUpdate(id, &bodyRequest)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			common.ErrorResponse(c, http.StatusNotFound, err.Error())
-			return
-		}
-		handleErrorResponse(c, err, err.Error())
+		c.Error(err)
 		return
 	}
 
 	groupResponse, err := h.service.ConvertToResponse(groupModel)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -207,11 +180,7 @@ func (h *AccessControlGroupHandler) Delete(c *gin.Context) {
 	id := c.Param("id")
 
 	if err := h.service.Delete(id); err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			common.ErrorResponse(c, http.StatusNotFound, err.Error())
-			return
-		}
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -1,14 +1,11 @@
 package handler
 
 import (
-	"net/http"
-	"strings"
-
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 	"github.com/putteror/access-control-management/internal/app/common"
 	"github.com/putteror/access-control-management/internal/app/schema"
 	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
 )
 
 type AccessControlRuleHandler struct {
@@ -20,21 +17,6 @@ func NewAccessControlRuleHandler(service service.AccessControlRuleService) *Acce
 	return &AccessControlRuleHandler{service: service}
 }
 
-// ## Error Handling Utility
-// ฟังก์ชันนี้ช่วยจัดการข้อผิดพลาดเฉพาะที่เกี่ยวกับชื่อซ้ำสำหรับ Rule
-
-func handleRuleErrorResponse(c *gin.Context, err error, defaultMessage string) {
-	// ตรวจสอบข้อความ error สำหรับกรณีชื่อซ้ำที่กำหนดไว้ก่อนหน้า
-	if strings.Contains(err.Error(), "accessControlRule name is already exist") {
-		// นำไปใช้กับ format ที่กำหนดไว้ [2025-07-05]
-		message := "Rule name is already exist" // ใช้ข้อความเดิมตามที่ user request ให้จำ
-		common.ErrorResponse(c, http.StatusBadRequest, message)
-		return
-	}
-	// สำหรับข้อผิดพลาดอื่น ๆ ที่มาจาก Service
-	common.ErrorResponse(c, http.StatusInternalServerError, defaultMessage)
-}
-
 // ---
 
 // ## Get Operations
@@ -44,7 +26,11 @@ func (h *AccessControlRuleHandler) GetAll(c *gin.Context) {
 
 	var searchQuery schema.AccessControlRuleSearchQuery
 	if err := c.ShouldBindQuery(&searchQuery); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, "Invalid search query parameter")
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(searchQuery); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -56,7 +42,7 @@ func (h *AccessControlRuleHandler) GetAll(c *gin.Context) {
 	}
 	rules, err := h.service.GetAll(searchQuery)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -64,7 +50,7 @@ func (h *AccessControlRuleHandler) GetAll(c *gin.Context) {
 	for _, rule := range rules {
 		response, err := h.service.ConvertToResponse(&rule)
 		if err != nil {
-			common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+			c.Error(err)
 			return
 		}
 		ruleResponses = append(ruleResponses, *response)
@@ -88,13 +74,13 @@ func (h *AccessControlRuleHandler) GetByID(c *gin.Context) {
 
 	rule, err := h.service.GetByID(id)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusNotFound, "Rule not found")
+		c.Error(err)
 		return
 	}
 
 	ruleResponse, err := h.service.ConvertToResponse(rule)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -109,29 +95,24 @@ func (h *AccessControlRuleHandler) GetByID(c *gin.Context) {
 func (h *AccessControlRuleHandler) Create(c *gin.Context) {
 	var bodyRequest schema.AccessControlRuleRequest
 	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		c.Error(validation.BindError(err))
 		return
 	}
 
-	// ใช้ validate.Struct() เพื่อตรวจสอบ Name (required)
-	if validate == nil {
-		validate = validator.New()
-	}
-	if err := validate.Struct(bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
 		return
 	}
 
 	ruleModel, err := h.service.Create(&bodyRequest)
 	if err != nil {
-		// ใช้ handleRuleErrorResponse เพื่อจัดการข้อผิดพลาดชื่อซ้ำ
-		handleRuleErrorResponse(c, err, err.Error())
+		c.Error(err)
 		return
 	}
 
 	ruleResponse, err := h.service.ConvertToResponse(ruleModel)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -144,32 +125,24 @@ func (h *AccessControlRuleHandler) Update(c *gin.Context) {
 
 	var bodyRequest schema.AccessControlRuleRequest
 	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		c.Error(validation.BindError(err))
 		return
 	}
 
-	// ใช้ validate.Struct() เพื่อตรวจสอบ Name (required)
-	if validate == nil {
-		validate = validator.New()
-	}
-	if err := validate.Struct(bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
 		return
 	}
 
 	ruleModel, err := h.service.Update(id, &bodyRequest)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			common.ErrorResponse(c, http.StatusNotFound, err.Error())
-			return
-		}
-		handleRuleErrorResponse(c, err, err.Error())
+		c.Error(err)
 		return
 	}
 
 	ruleResponse, err := h.service.ConvertToResponse(ruleModel)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -182,26 +155,23 @@ func (h *AccessControlRuleHandler) PartialUpdate(c *gin.Context) {
 
 	var bodyRequest schema.AccessControlRuleRequest
 	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Partial(bodyRequest); err != nil {
+		c.Error(err)
 		return
 	}
-	// ใน Partial Update เราจะไม่เรียก validate.Struct() เต็มรูปแบบ เพราะ Name อาจไม่ถูกส่งมา
-	// แต่ Name ใน schema ถูกตั้งเป็น required ทำให้เกิดความย้อนแย้งเล็กน้อย
-	// เราจะสมมติว่าถ้า Name ไม่ได้ถูกส่งมาใน JSON จะเป็น string ว่าง และ Service จะจัดการเอง
 
 	ruleModel, err := h.service.PartialUpdate(id, &bodyRequest)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			common.ErrorResponse(c, http.StatusNotFound, err.Error())
-			return
-		}
-		handleRuleErrorResponse(c, err, err.Error())
+		c.Error(err)
 		return
 	}
 
 	ruleResponse, err := h.service.ConvertToResponse(ruleModel)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -213,11 +183,7 @@ func (h *AccessControlRuleHandler) Delete(c *gin.Context) {
 	id := c.Param("id")
 
 	if err := h.service.Delete(id); err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			common.ErrorResponse(c, http.StatusNotFound, err.Error())
-			return
-		}
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
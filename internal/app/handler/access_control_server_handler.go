@@ -1,18 +1,13 @@
 package handler
 
 import (
-	"net/http"
-
 	"github.com/gin-gonic/gin"
 	"github.com/putteror/access-control-management/internal/app/common"
 	"github.com/putteror/access-control-management/internal/app/schema"
 	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
 )
 
-// ตัวแปร validate ถูกกำหนดไว้แล้วในไฟล์อื่น (น่าจะอยู่ในไฟล์เดียวกันกับ AccessControlDeviceHandler หรือไฟล์ที่ import)
-// หากต้องการให้โค้ดนี้รันได้โดยสมบูรณ์ อาจต้องเพิ่ม var validate *validator.Validate
-// แต่เพื่อให้สอดคล้องกับไฟล์ต้นฉบับ จะขอเว้นไว้ และสมมติว่ามีการประกาศไว้แล้ว
-
 type AccessControlServerHandler struct {
 	service service.AccessControlServerService
 }
@@ -26,7 +21,11 @@ func (h *AccessControlServerHandler) GetAll(c *gin.Context) {
 
 	var searchQuery schema.AccessControlServerSearchQuery
 	if err := c.ShouldBindQuery(&searchQuery); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, "Invalid search query parameter")
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(searchQuery); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -38,14 +37,14 @@ func (h *AccessControlServerHandler) GetAll(c *gin.Context) {
 	}
 	servers, err := h.service.GetAll(searchQuery)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 	serverResponses := make([]schema.AccessControlServerResponse, len(servers))
 	for i, server := range servers {
 		response, err := h.service.ConvertToResponse(&server)
 		if err != nil {
-			common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+			c.Error(err)
 			return
 		}
 		serverResponses[i] = *response
@@ -67,13 +66,13 @@ func (h *AccessControlServerHandler) GetByID(c *gin.Context) {
 
 	server, err := h.service.GetByID(id)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusNotFound, "Server not found")
+		c.Error(err)
 		return
 	}
 
 	serverResponse, err := h.service.ConvertToResponse(server)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusNotFound, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -84,25 +83,24 @@ func (h *AccessControlServerHandler) GetByID(c *gin.Context) {
 func (h *AccessControlServerHandler) Create(c *gin.Context) {
 	var bodyRequest schema.AccessControlServerRequest
 	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		c.Error(validation.BindError(err))
 		return
 	}
 
-	// ต้องมั่นใจว่ามีการประกาศ var validate = validator.New() ไว้ในไฟล์เดียวกันหรือไฟล์ที่ import
-	if err := validate.Struct(bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
 		return
 	}
 
 	serverModel, err := h.service.Create(&bodyRequest)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
 	serverResponse, err := h.service.ConvertToResponse(serverModel)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusNotFound, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -115,23 +113,23 @@ func (h *AccessControlServerHandler) Update(c *gin.Context) {
 
 	var bodyRequest schema.AccessControlServerRequest
 	if err := c.ShouldBind(&bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		c.Error(validation.BindError(err))
 		return
 	}
-	if err := validate.Struct(bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
 		return
 	}
 
 	serverModel, err := h.service.Update(id, &bodyRequest)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
 	serverResponse, err := h.service.ConvertToResponse(serverModel)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusNotFound, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -144,19 +142,23 @@ func (h *AccessControlServerHandler) PartialUpdate(c *gin.Context) {
 
 	var bodyRequest schema.AccessControlServerRequest
 	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Partial(bodyRequest); err != nil {
+		c.Error(err)
 		return
 	}
 
 	serverModel, err := h.service.PartialUpdate(id, &bodyRequest)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
 	serverResponse, err := h.service.ConvertToResponse(serverModel)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusNotFound, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -168,7 +170,7 @@ func (h *AccessControlServerHandler) Delete(c *gin.Context) {
 	id := c.Param("id")
 
 	if err := h.service.Delete(id); err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
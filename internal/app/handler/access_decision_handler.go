@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
+)
+
+// AccessDecisionHandler handles credential checks sent by devices.
+type AccessDecisionHandler struct {
+	service service.AccessDecisionService
+}
+
+// NewAccessDecisionHandler creates a new instance of AccessDecisionHandler.
+func NewAccessDecisionHandler(service service.AccessDecisionService) *AccessDecisionHandler {
+	return &AccessDecisionHandler{service: service}
+}
+
+// Decide returns whether the presented credential opens the device.
+// A refused credential is still a successful request: the answer is in the "granted" field.
+func (h *AccessDecisionHandler) Decide(c *gin.Context) {
+	var bodyRequest schema.AccessDecisionRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	decision, err := h.service.Decide(&bodyRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Success", decision)
+}
+
+// DecideLicensePlate handles a plate read sent by a license plate camera as multipart form data,
+// with the camera snapshot in the optional "image" field.
+func (h *AccessDecisionHandler) DecideLicensePlate(c *gin.Context) {
+	var bodyRequest schema.LicensePlateEventRequest
+	if err := c.ShouldBind(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	plateImageFile, err := c.FormFile("image")
+	if err != nil && err != http.ErrMissingFile {
+		common.ErrorResponse(c, http.StatusBadRequest, "Failed to get image file: "+err.Error())
+		return
+	}
+
+	decision, err := h.service.DecideLicensePlate(&bodyRequest, plateImageFile)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Success", decision)
+}
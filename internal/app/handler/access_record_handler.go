@@ -1,14 +1,11 @@
 package handler
 
 import (
-	"net/http"
-	"strings"
-
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 	"github.com/putteror/access-control-management/internal/app/common"
 	"github.com/putteror/access-control-management/internal/app/schema"
 	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
 )
 
 type AccessRecordHandler struct {
@@ -19,16 +16,16 @@ func NewAccessRecordHandler(service service.AccessRecordService) *AccessRecordHa
 	return &AccessRecordHandler{service: service}
 }
 
-func init() {
-	validate = validator.New()
-}
-
 // Get All
 func (h *AccessRecordHandler) GetAll(c *gin.Context) {
 
 	var searchQuery schema.AccessRecordSearchQuery
 	if err := c.ShouldBindQuery(&searchQuery); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, "Invalid search query parameter")
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(searchQuery); err != nil {
+		c.Error(err)
 		return
 	}
 	if searchQuery.Page <= 0 {
@@ -39,14 +36,14 @@ func (h *AccessRecordHandler) GetAll(c *gin.Context) {
 	}
 	records, err := h.service.GetAll(searchQuery)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 	recordResponses := make([]schema.AccessRecordResponse, len(records))
 	for i, record := range records {
 		response, err := h.service.ConvertToResponse(&record)
 		if err != nil {
-			common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+			c.Error(err)
 			return
 		}
 		recordResponses[i] = *response
@@ -69,13 +66,13 @@ func (h *AccessRecordHandler) GetByID(c *gin.Context) {
 
 	itemModel, err := h.service.GetByID(id)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusNotFound, "Record not found")
+		c.Error(err)
 		return
 	}
 
 	itemResponse, err := h.service.ConvertToResponse(itemModel)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -85,104 +82,72 @@ func (h *AccessRecordHandler) GetByID(c *gin.Context) {
 func (h *AccessRecordHandler) Create(c *gin.Context) {
 	var bodyRequest schema.AccessRecordRequest
 	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		c.Error(validation.BindError(err))
 		return
 	}
 
-	if err := validate.Struct(bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
 		return
 	}
 
 	itemModel, err := h.service.Create(&bodyRequest)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
 	itemResponse, err := h.service.ConvertToResponse(itemModel)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
 	common.SuccessResponse(c, "Create record success", itemResponse)
 }
 
-// Update updates an existing item (Full Update).
-func (h *AccessRecordHandler) Update(c *gin.Context) {
-	id := c.Param("id")
-
-	var bodyRequest schema.AccessRecordRequest
+// Annotate adds an annotation to an access record. Access records themselves cannot be changed.
+func (h *AccessRecordHandler) Annotate(c *gin.Context) {
+	var bodyRequest schema.AccessRecordAnnotationRequest
 	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		c.Error(validation.BindError(err))
 		return
 	}
-	if err := validate.Struct(bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
 		return
 	}
 
-	itemModel, err := h.service.Update(id, &bodyRequest)
+	annotation, err := h.service.Annotate(c.Param("id"), &bodyRequest, c.GetString("user"))
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			common.ErrorResponse(c, http.StatusNotFound, err.Error())
-			return
-		}
-		handleErrorResponse(c, err, err.Error())
-		return
-	}
-
-	itemResponse, err := h.service.ConvertToResponse(itemModel)
-	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
-	common.SuccessResponse(c, "Update item success", itemResponse)
+	common.SuccessResponse(c, "Annotate record success", h.service.ConvertAnnotationToResponse(annotation))
 }
 
-// PartialUpdate updates an existing item (Partial Update).
-func (h *AccessRecordHandler) PartialUpdate(c *gin.Context) {
-	id := c.Param("id")
-
-	var bodyRequest schema.AccessRecordRequest
-	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
-		return
-	}
-
-	itemModel, err := h.service.PartialUpdate(id, &bodyRequest)
+// GetAnnotations retrieves the annotations of an access record.
+func (h *AccessRecordHandler) GetAnnotations(c *gin.Context) {
+	annotations, err := h.service.GetAnnotations(c.Param("id"))
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			common.ErrorResponse(c, http.StatusNotFound, err.Error())
-			return
-		}
-		handleErrorResponse(c, err, err.Error())
+		c.Error(err)
 		return
 	}
 
-	itemResponse, err := h.service.ConvertToResponse(itemModel)
-	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
-		return
+	annotationResponses := make([]schema.AccessRecordAnnotationResponse, len(annotations))
+	for i, annotation := range annotations {
+		annotationResponses[i] = h.service.ConvertAnnotationToResponse(&annotation)
 	}
-
-	common.SuccessResponse(c, "Update item success", itemResponse)
+	common.SuccessResponse(c, "Success", annotationResponses)
 }
 
-// Delete deletes an item by its ID.
-func (h *AccessRecordHandler) Delete(c *gin.Context) {
-	id := c.Param("id")
-
-	if err := h.service.Delete(id); err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			common.ErrorResponse(c, http.StatusNotFound, err.Error())
-			return
-		}
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+// Verify checks the hash chain of all access records for tampering and gaps.
+func (h *AccessRecordHandler) Verify(c *gin.Context) {
+	result, err := h.service.VerifyChain()
+	if err != nil {
+		c.Error(err)
 		return
 	}
-
-	common.SuccessResponse(c, "Item deleted successfully", nil)
+	common.SuccessResponse(c, "Success", result)
 }
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
+)
+
+// AttendanceCorrectionHandler handles the attendance correction endpoints.
+type AttendanceCorrectionHandler struct {
+	service service.AttendanceCorrectionService
+}
+
+// NewAttendanceCorrectionHandler creates a new instance of AttendanceCorrectionHandler.
+func NewAttendanceCorrectionHandler(service service.AttendanceCorrectionService) *AttendanceCorrectionHandler {
+	return &AttendanceCorrectionHandler{service: service}
+}
+
+// GetAll retrieves attendance corrections, filtered by person, status and date range.
+func (h *AttendanceCorrectionHandler) GetAll(c *gin.Context) {
+	var searchQuery schema.AttendanceCorrectionSearchQuery
+	if err := c.ShouldBindQuery(&searchQuery); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(searchQuery); err != nil {
+		c.Error(err)
+		return
+	}
+	if searchQuery.Page <= 0 {
+		searchQuery.Page = common.DefaultPage
+	}
+	if searchQuery.Limit <= 0 {
+		searchQuery.Limit = common.DefaultPageSize
+	}
+
+	corrections, err := h.service.GetAll(searchQuery)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	correctionResponses := make([]schema.AttendanceCorrectionResponse, 0, len(corrections))
+	for _, correction := range corrections {
+		response, err := h.service.ConvertToResponse(&correction)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		correctionResponses = append(correctionResponses, *response)
+	}
+
+	pageData := common.PageResponse{
+		Page:      searchQuery.Page,
+		Size:      searchQuery.Limit,
+		Total:     len(corrections),
+		TotalPage: (len(corrections) + searchQuery.Limit - 1) / searchQuery.Limit,
+	}
+
+	common.GetDataListResponse(c, "Success", correctionResponses, pageData)
+}
+
+// GetByID retrieves an attendance correction.
+func (h *AttendanceCorrectionHandler) GetByID(c *gin.Context) {
+	correction, err := h.service.GetByID(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	h.respond(c, "Success", correction)
+}
+
+// Create files a manual clock-in or clock-out. It stays pending until approved.
+func (h *AttendanceCorrectionHandler) Create(c *gin.Context) {
+	var bodyRequest schema.AttendanceCorrectionRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	correction, err := h.service.Create(&bodyRequest, c.GetString("user"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	h.respond(c, "Create attendance correction success", correction)
+}
+
+// Approve approves a pending correction. Only users with the time attendance permission may approve.
+func (h *AttendanceCorrectionHandler) Approve(c *gin.Context) {
+	var bodyRequest schema.DecisionRequest
+	if !bindDecisionRequest(c, &bodyRequest) {
+		return
+	}
+
+	correction, err := h.service.Approve(c.Param("id"), &bodyRequest, c.GetString("user"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	h.respond(c, "Approve attendance correction success", correction)
+}
+
+// Reject rejects a pending correction. Only users with the time attendance permission may reject.
+func (h *AttendanceCorrectionHandler) Reject(c *gin.Context) {
+	var bodyRequest schema.DecisionRequest
+	if !bindDecisionRequest(c, &bodyRequest) {
+		return
+	}
+
+	correction, err := h.service.Reject(c.Param("id"), &bodyRequest, c.GetString("user"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	h.respond(c, "Reject attendance correction success", correction)
+}
+
+// Cancel withdraws a pending or approved correction.
+func (h *AttendanceCorrectionHandler) Cancel(c *gin.Context) {
+	var bodyRequest schema.DecisionRequest
+	if !bindDecisionRequest(c, &bodyRequest) {
+		return
+	}
+
+	correction, err := h.service.Cancel(c.Param("id"), &bodyRequest, c.GetString("user"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	h.respond(c, "Cancel attendance correction success", correction)
+}
+
+// respond writes an attendance correction as the success response.
+func (h *AttendanceCorrectionHandler) respond(c *gin.Context, message string, correction *model.AttendanceCorrection) {
+	response, err := h.service.ConvertToResponse(correction)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	common.SuccessResponse(c, message, response)
+}
@@ -1,21 +1,13 @@
 package handler
 
 import (
-	"net/http"
-	"strings"
-
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 	"github.com/putteror/access-control-management/internal/app/common"
 	"github.com/putteror/access-control-management/internal/app/schema"
 	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
 )
 
-// ต้องมั่นใจว่ามีการประกาศ var validate *validator.Validate และมีการเรียก init()
-// เราจะสมมติว่ามีการประกาศและเรียกใช้ในไฟล์หลักของ package handler แล้ว
-// var validate *validator.Validate
-// func init() { validate = validator.New() }
-
 type AttendanceHandler struct {
 	service service.AttendanceService
 }
@@ -24,20 +16,6 @@ func NewAttendanceHandler(service service.AttendanceService) *AttendanceHandler
 	return &AttendanceHandler{service: service}
 }
 
-// ## Error Handling Utility
-// ฟังก์ชันนี้ช่วยจัดการข้อผิดพลาดเฉพาะที่เกี่ยวกับชื่อซ้ำสำหรับ Attendance
-func handleAttendanceErrorResponse(c *gin.Context, err error, defaultMessage string) {
-	// ตรวจสอบข้อความ error สำหรับกรณีชื่อซ้ำที่กำหนดไว้ก่อนหน้า
-	if strings.Contains(err.Error(), "ApplicationForm name is already exist") {
-		// นำไปใช้กับ format ที่กำหนดไว้ [2025-07-05]
-		message := "Attendance name is already exist"
-		common.ErrorResponse(c, http.StatusBadRequest, message)
-		return
-	}
-	// สำหรับข้อผิดพลาดอื่น ๆ ที่มาจาก Service
-	common.ErrorResponse(c, http.StatusInternalServerError, defaultMessage)
-}
-
 // ---
 
 // ## Get Operations
@@ -47,7 +25,11 @@ func (h *AttendanceHandler) GetAll(c *gin.Context) {
 
 	var searchQuery schema.AttendanceSearchQuery
 	if err := c.ShouldBindQuery(&searchQuery); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, "Invalid search query parameter")
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(searchQuery); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -59,7 +41,7 @@ func (h *AttendanceHandler) GetAll(c *gin.Context) {
 	}
 	attendances, err := h.service.GetAll(searchQuery)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -67,7 +49,7 @@ func (h *AttendanceHandler) GetAll(c *gin.Context) {
 	for _, attendance := range attendances {
 		response, err := h.service.ConvertToResponse(&attendance)
 		if err != nil {
-			common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+			c.Error(err)
 			return
 		}
 		attendanceResponses = append(attendanceResponses, *response)
@@ -91,13 +73,13 @@ func (h *AttendanceHandler) GetByID(c *gin.Context) {
 
 	attendance, err := h.service.GetByID(id)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusNotFound, "Attendance record not found")
+		c.Error(err)
 		return
 	}
 
 	attendanceResponse, err := h.service.ConvertToResponse(attendance)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -112,29 +94,24 @@ func (h *AttendanceHandler) GetByID(c *gin.Context) {
 func (h *AttendanceHandler) Create(c *gin.Context) {
 	var bodyRequest schema.AttendanceRequest
 	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		c.Error(validation.BindError(err))
 		return
 	}
 
-	// ตรวจสอบโครงสร้าง
-	if validate == nil {
-		validate = validator.New()
-	}
-	if err := validate.Struct(bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
 		return
 	}
 
 	attendanceModel, err := h.service.Create(&bodyRequest)
 	if err != nil {
-		// ใช้ handleAttendanceErrorResponse เพื่อจัดการข้อผิดพลาดชื่อซ้ำ
-		handleAttendanceErrorResponse(c, err, err.Error())
+		c.Error(err)
 		return
 	}
 
 	attendanceResponse, err := h.service.ConvertToResponse(attendanceModel)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -147,32 +124,24 @@ func (h *AttendanceHandler) Update(c *gin.Context) {
 
 	var bodyRequest schema.AttendanceRequest
 	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		c.Error(validation.BindError(err))
 		return
 	}
 
-	// ตรวจสอบโครงสร้าง
-	if validate == nil {
-		validate = validator.New()
-	}
-	if err := validate.Struct(bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
 		return
 	}
 
 	attendanceModel, err := h.service.Update(id, &bodyRequest)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			common.ErrorResponse(c, http.StatusNotFound, err.Error())
-			return
-		}
-		handleAttendanceErrorResponse(c, err, err.Error())
+		c.Error(err)
 		return
 	}
 
 	attendanceResponse, err := h.service.ConvertToResponse(attendanceModel)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -185,24 +154,23 @@ func (h *AttendanceHandler) PartialUpdate(c *gin.Context) {
 
 	var bodyRequest schema.AttendanceRequest
 	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Partial(bodyRequest); err != nil {
+		c.Error(err)
 		return
 	}
-	// ใน Partial Update มักจะไม่เรียก validate.Struct() เต็มรูปแบบ
 
 	attendanceModel, err := h.service.PartialUpdate(id, &bodyRequest)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			common.ErrorResponse(c, http.StatusNotFound, err.Error())
-			return
-		}
-		handleAttendanceErrorResponse(c, err, err.Error())
+		c.Error(err)
 		return
 	}
 
 	attendanceResponse, err := h.service.ConvertToResponse(attendanceModel)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -214,11 +182,7 @@ func (h *AttendanceHandler) Delete(c *gin.Context) {
 	id := c.Param("id")
 
 	if err := h.service.Delete(id); err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			common.ErrorResponse(c, http.StatusNotFound, err.Error())
-			return
-		}
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
+)
+
+// AttendanceRecordHandler handles the calculated attendance endpoints.
+type AttendanceRecordHandler struct {
+	service service.AttendanceRecordService
+}
+
+// NewAttendanceRecordHandler creates a new instance of AttendanceRecordHandler.
+func NewAttendanceRecordHandler(service service.AttendanceRecordService) *AttendanceRecordHandler {
+	return &AttendanceRecordHandler{service: service}
+}
+
+// GetAll retrieves calculated attendance records, filtered by person, date range and status.
+func (h *AttendanceRecordHandler) GetAll(c *gin.Context) {
+	var searchQuery schema.AttendanceRecordSearchQuery
+	if err := c.ShouldBindQuery(&searchQuery); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(searchQuery); err != nil {
+		c.Error(err)
+		return
+	}
+	if searchQuery.Page <= 0 {
+		searchQuery.Page = common.DefaultPage
+	}
+	if searchQuery.Limit <= 0 {
+		searchQuery.Limit = common.DefaultPageSize
+	}
+
+	records, err := h.service.GetAll(searchQuery)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	recordResponses, err := h.convertToResponses(records)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	pageData := common.PageResponse{
+		Page:      searchQuery.Page,
+		Size:      searchQuery.Limit,
+		Total:     len(records),
+		TotalPage: (len(records) + searchQuery.Limit - 1) / searchQuery.Limit,
+	}
+
+	common.GetDataListResponse(c, "Success", recordResponses, pageData)
+}
+
+// Summary totals the calculated attendance of a date range per person.
+func (h *AttendanceRecordHandler) Summary(c *gin.Context) {
+	var searchQuery schema.AttendanceSummaryQuery
+	if err := c.ShouldBindQuery(&searchQuery); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(searchQuery); err != nil {
+		c.Error(err)
+		return
+	}
+
+	summaries, err := h.service.Summarize(searchQuery)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Success", summaries)
+}
+
+// Calculate recalculates attendance for a date range and returns the resulting records.
+func (h *AttendanceRecordHandler) Calculate(c *gin.Context) {
+	var bodyRequest schema.AttendanceCalculateRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	records, err := h.service.Calculate(&bodyRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	recordResponses, err := h.convertToResponses(records)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Calculate attendance success", recordResponses)
+}
+
+// ApproveOvertime approves the pending overtime of a record, optionally fewer minutes than calculated.
+func (h *AttendanceRecordHandler) ApproveOvertime(c *gin.Context) {
+	var bodyRequest schema.OvertimeDecisionRequest
+	if !bindOvertimeDecisionRequest(c, &bodyRequest) {
+		return
+	}
+
+	record, err := h.service.ApproveOvertime(c.Param("id"), &bodyRequest, c.GetString("user"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	h.respond(c, "Approve overtime success", record)
+}
+
+// RejectOvertime rejects the pending overtime of a record.
+func (h *AttendanceRecordHandler) RejectOvertime(c *gin.Context) {
+	var bodyRequest schema.OvertimeDecisionRequest
+	if !bindOvertimeDecisionRequest(c, &bodyRequest) {
+		return
+	}
+
+	record, err := h.service.RejectOvertime(c.Param("id"), &bodyRequest, c.GetString("user"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	h.respond(c, "Reject overtime success", record)
+}
+
+// bindOvertimeDecisionRequest binds the optional body of an overtime decision.
+func bindOvertimeDecisionRequest(c *gin.Context, bodyRequest *schema.OvertimeDecisionRequest) bool {
+	if c.Request.ContentLength == 0 {
+		return true
+	}
+	if err := c.ShouldBindJSON(bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return false
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return false
+	}
+	return true
+}
+
+// respond writes an attendance record as the success response.
+func (h *AttendanceRecordHandler) respond(c *gin.Context, message string, record *model.AttendanceRecord) {
+	response, err := h.service.ConvertToResponse(record)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	common.SuccessResponse(c, message, response)
+}
+
+func (h *AttendanceRecordHandler) convertToResponses(records []model.AttendanceRecord) ([]schema.AttendanceRecordResponse, error) {
+	recordResponses := make([]schema.AttendanceRecordResponse, 0, len(records))
+	for _, record := range records {
+		response, err := h.service.ConvertToResponse(&record)
+		if err != nil {
+			return nil, err
+		}
+		recordResponses = append(recordResponses, *response)
+	}
+	return recordResponses, nil
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
+)
+
+// AuditLogHandler handles the audit log endpoints.
+type AuditLogHandler struct {
+	service service.AuditLogService
+}
+
+// NewAuditLogHandler creates a new instance of AuditLogHandler.
+func NewAuditLogHandler(service service.AuditLogService) *AuditLogHandler {
+	return &AuditLogHandler{service: service}
+}
+
+// GetAll retrieves audit logs, filtered by action, entity and user.
+func (h *AuditLogHandler) GetAll(c *gin.Context) {
+	var searchQuery schema.AuditLogSearchQuery
+	if err := c.ShouldBindQuery(&searchQuery); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(searchQuery); err != nil {
+		c.Error(err)
+		return
+	}
+	if searchQuery.Page <= 0 {
+		searchQuery.Page = common.DefaultPage
+	}
+	if searchQuery.Limit <= 0 {
+		searchQuery.Limit = common.DefaultPageSize
+	}
+
+	auditLogs, err := h.service.GetAll(searchQuery)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	auditLogResponses := make([]schema.AuditLogResponse, len(auditLogs))
+	for i, auditLog := range auditLogs {
+		auditLogResponses[i] = h.service.ConvertToResponse(&auditLog)
+	}
+
+	pageData := common.PageResponse{
+		Page:      searchQuery.Page,
+		Size:      searchQuery.Limit,
+		Total:     len(auditLogs),
+		TotalPage: (len(auditLogs) + searchQuery.Limit - 1) / searchQuery.Limit,
+	}
+
+	common.GetDataListResponse(c, "Success", auditLogResponses, pageData)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/putteror/access-control-management/internal/app/schema"
 	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
 )
 
 // AuthHandler handles authentication-related HTTP requests.
@@ -22,16 +23,41 @@ func NewAuthHandler(authService service.AuthService) *AuthHandler {
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req schema.LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(req); err != nil {
+		c.Error(err)
 		return
 	}
 
 	// Call service to authenticate and generate a token
 	token, err := h.authService.Login(req.Username, req.Password)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schema.TokenResponse{Token: token})
+}
+
+// SwitchTenant issues a token of the current super admin for another tenant or the global scope.
+func (h *AuthHandler) SwitchTenant(c *gin.Context) {
+	var req schema.SwitchTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	token, err := h.authService.SwitchTenant(c.GetString("user"), req.TenantID)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"token": token})
+	c.JSON(http.StatusOK, schema.TokenResponse{Token: token})
 }
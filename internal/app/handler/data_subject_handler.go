@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
+)
+
+// DataSubjectHandler handles the personal data export and erasure endpoints of a person.
+type DataSubjectHandler struct {
+	service service.DataSubjectService
+}
+
+// NewDataSubjectHandler creates a new instance of DataSubjectHandler.
+func NewDataSubjectHandler(service service.DataSubjectService) *DataSubjectHandler {
+	return &DataSubjectHandler{service: service}
+}
+
+// Export downloads a ZIP with all data stored about a person.
+func (h *DataSubjectHandler) Export(c *gin.Context) {
+	var query schema.DataSubjectExportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(query); err != nil {
+		c.Error(err)
+		return
+	}
+
+	var buffer bytes.Buffer
+	if err := h.service.Export(c.Param("id"), query.Reason, c.GetString("user"), &buffer); err != nil {
+		c.Error(err)
+		return
+	}
+
+	fileName := fmt.Sprintf("person-%s-%s.zip", c.Param("id"), time.Now().Format("20060102"))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
+	c.Data(http.StatusOK, "application/zip", buffer.Bytes())
+}
+
+// Erase anonymizes a person and removes their personal data.
+func (h *DataSubjectHandler) Erase(c *gin.Context) {
+	var bodyRequest schema.DataSubjectEraseRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	person, err := h.service.Erase(c.Param("id"), &bodyRequest, c.GetString("user"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response, err := h.service.ConvertToResponse(person)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	common.SuccessResponse(c, "Erase person data success", response)
+}
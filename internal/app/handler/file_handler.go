@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"path"
+
+	"github.com/gin-gonic/gin"
+	"github.com/putteror/access-control-management/internal/app/service"
+)
+
+type FileHandler struct {
+	service service.FileService
+}
+
+func NewFileHandler(service service.FileService) *FileHandler {
+	return &FileHandler{service: service}
+}
+
+// Get streams a stored file, e.g. GET /api/files/images/faces/people/<id>/<file>.jpg
+func (h *FileHandler) Get(c *gin.Context) {
+	filePath := c.Param("filepath")
+
+	file, err := h.service.Open(filePath)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer file.Close()
+
+	contentType := mime.TypeByExtension(path.Ext(filePath))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Cache-Control", "private, max-age=3600")
+	c.Status(http.StatusOK)
+	io.Copy(c.Writer, file)
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
+)
+
+// HolidayCalendarHandler handles the holiday calendar endpoints.
+type HolidayCalendarHandler struct {
+	service service.HolidayCalendarService
+}
+
+// NewHolidayCalendarHandler creates a new instance of HolidayCalendarHandler.
+func NewHolidayCalendarHandler(service service.HolidayCalendarService) *HolidayCalendarHandler {
+	return &HolidayCalendarHandler{service: service}
+}
+
+// GetAll retrieves holiday calendars.
+func (h *HolidayCalendarHandler) GetAll(c *gin.Context) {
+	var searchQuery schema.HolidayCalendarSearchQuery
+	if err := c.ShouldBindQuery(&searchQuery); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(searchQuery); err != nil {
+		c.Error(err)
+		return
+	}
+	if searchQuery.Page <= 0 {
+		searchQuery.Page = common.DefaultPage
+	}
+	if searchQuery.Limit <= 0 {
+		searchQuery.Limit = common.DefaultPageSize
+	}
+
+	calendars, err := h.service.GetAll(searchQuery)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	calendarResponses := make([]schema.HolidayCalendarResponse, 0, len(calendars))
+	for _, calendar := range calendars {
+		response, err := h.service.ConvertToResponse(&calendar)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		calendarResponses = append(calendarResponses, *response)
+	}
+
+	pageData := common.PageResponse{
+		Page:      searchQuery.Page,
+		Size:      searchQuery.Limit,
+		Total:     len(calendars),
+		TotalPage: (len(calendars) + searchQuery.Limit - 1) / searchQuery.Limit,
+	}
+
+	common.GetDataListResponse(c, "Success", calendarResponses, pageData)
+}
+
+// GetByID retrieves a holiday calendar with its holidays.
+func (h *HolidayCalendarHandler) GetByID(c *gin.Context) {
+	calendar, err := h.service.GetByID(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response, err := h.service.ConvertToResponse(calendar)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Success", response)
+}
+
+// Create creates a holiday calendar.
+func (h *HolidayCalendarHandler) Create(c *gin.Context) {
+	var bodyRequest schema.HolidayCalendarRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	calendar, err := h.service.Create(&bodyRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response, err := h.service.ConvertToResponse(calendar)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Create holiday calendar success", response)
+}
+
+// Update replaces a holiday calendar and its holidays.
+func (h *HolidayCalendarHandler) Update(c *gin.Context) {
+	var bodyRequest schema.HolidayCalendarRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	calendar, err := h.service.Update(c.Param("id"), &bodyRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response, err := h.service.ConvertToResponse(calendar)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Update holiday calendar success", response)
+}
+
+// Delete deletes a holiday calendar.
+func (h *HolidayCalendarHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Holiday calendar deleted successfully", nil)
+}
+
+// Import adds the holidays of an iCalendar (.ics) file sent in the "file" form field.
+// With replace=true the existing holidays of the calendar are dropped first.
+func (h *HolidayCalendarHandler) Import(c *gin.Context) {
+	var query schema.HolidayCalendarImportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(query); err != nil {
+		c.Error(err)
+		return
+	}
+	icsFile, err := c.FormFile("file")
+	if err != nil {
+		common.ErrorResponse(c, http.StatusBadRequest, "Failed to get calendar file: "+err.Error())
+		return
+	}
+
+	imported, err := h.service.Import(c.Param("id"), icsFile, query.Replace)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	calendar, err := h.service.GetByID(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response, err := h.service.ConvertToResponse(calendar)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Import holiday calendar success", schema.HolidayCalendarImportResponse{
+		Imported: imported,
+		Calendar: response,
+	})
+}
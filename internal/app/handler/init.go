@@ -1,5 +0,0 @@
-package handler
-
-import "github.com/go-playground/validator/v10"
-
-var validate *validator.Validate
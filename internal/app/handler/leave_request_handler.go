@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
+)
+
+// LeaveRequestHandler handles the leave request and leave balance endpoints.
+type LeaveRequestHandler struct {
+	service service.LeaveRequestService
+}
+
+// NewLeaveRequestHandler creates a new instance of LeaveRequestHandler.
+func NewLeaveRequestHandler(service service.LeaveRequestService) *LeaveRequestHandler {
+	return &LeaveRequestHandler{service: service}
+}
+
+// GetAll retrieves leave requests, filtered by person, leave type, status and date range.
+func (h *LeaveRequestHandler) GetAll(c *gin.Context) {
+	var searchQuery schema.LeaveRequestSearchQuery
+	if err := c.ShouldBindQuery(&searchQuery); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(searchQuery); err != nil {
+		c.Error(err)
+		return
+	}
+	if searchQuery.Page <= 0 {
+		searchQuery.Page = common.DefaultPage
+	}
+	if searchQuery.Limit <= 0 {
+		searchQuery.Limit = common.DefaultPageSize
+	}
+
+	leaveRequests, err := h.service.GetAll(searchQuery)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	leaveRequestResponses := make([]schema.LeaveRequestResponse, 0, len(leaveRequests))
+	for _, leaveRequest := range leaveRequests {
+		response, err := h.service.ConvertToResponse(&leaveRequest)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		leaveRequestResponses = append(leaveRequestResponses, *response)
+	}
+
+	pageData := common.PageResponse{
+		Page:      searchQuery.Page,
+		Size:      searchQuery.Limit,
+		Total:     len(leaveRequests),
+		TotalPage: (len(leaveRequests) + searchQuery.Limit - 1) / searchQuery.Limit,
+	}
+
+	common.GetDataListResponse(c, "Success", leaveRequestResponses, pageData)
+}
+
+// GetByID retrieves a leave request.
+func (h *LeaveRequestHandler) GetByID(c *gin.Context) {
+	leaveRequest, err := h.service.GetByID(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	h.respond(c, "Success", leaveRequest)
+}
+
+// Create files a leave request on behalf of a person. It stays pending until approved.
+func (h *LeaveRequestHandler) Create(c *gin.Context) {
+	var bodyRequest schema.LeaveRequestRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	leaveRequest, err := h.service.Create(&bodyRequest, c.GetString("user"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	h.respond(c, "Create leave request success", leaveRequest)
+}
+
+// Approve approves a pending leave request. Only users with the time attendance permission may approve.
+func (h *LeaveRequestHandler) Approve(c *gin.Context) {
+	var bodyRequest schema.DecisionRequest
+	if !bindDecisionRequest(c, &bodyRequest) {
+		return
+	}
+
+	leaveRequest, err := h.service.Approve(c.Param("id"), &bodyRequest, c.GetString("user"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	h.respond(c, "Approve leave request success", leaveRequest)
+}
+
+// Reject rejects a pending leave request. Only users with the time attendance permission may reject.
+func (h *LeaveRequestHandler) Reject(c *gin.Context) {
+	var bodyRequest schema.DecisionRequest
+	if !bindDecisionRequest(c, &bodyRequest) {
+		return
+	}
+
+	leaveRequest, err := h.service.Reject(c.Param("id"), &bodyRequest, c.GetString("user"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	h.respond(c, "Reject leave request success", leaveRequest)
+}
+
+// Cancel withdraws a pending or approved leave request.
+func (h *LeaveRequestHandler) Cancel(c *gin.Context) {
+	var bodyRequest schema.DecisionRequest
+	if !bindDecisionRequest(c, &bodyRequest) {
+		return
+	}
+
+	leaveRequest, err := h.service.Cancel(c.Param("id"), &bodyRequest, c.GetString("user"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	h.respond(c, "Cancel leave request success", leaveRequest)
+}
+
+// GetBalances retrieves the leave balances of a person for ?year=, the current year by default.
+func (h *LeaveRequestHandler) GetBalances(c *gin.Context) {
+	var query schema.LeaveBalanceQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(query); err != nil {
+		c.Error(err)
+		return
+	}
+	balances, err := h.service.GetBalances(c.Param("id"), query.Year)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Success", balances)
+}
+
+// SaveBalance sets the entitlement of a person for a leave type in a year.
+func (h *LeaveRequestHandler) SaveBalance(c *gin.Context) {
+	var bodyRequest schema.LeaveBalanceRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	balance, err := h.service.SaveBalance(c.Param("id"), &bodyRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Save leave balance success", balance)
+}
+
+// bindDecisionRequest reads the optional decision note. An empty body is allowed.
+func bindDecisionRequest(c *gin.Context, bodyRequest *schema.DecisionRequest) bool {
+	if c.Request.ContentLength == 0 {
+		return true
+	}
+	if err := c.ShouldBindJSON(bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return false
+	}
+	return true
+}
+
+// respond writes a leave request as the success response.
+func (h *LeaveRequestHandler) respond(c *gin.Context, message string, leaveRequest *model.LeaveRequest) {
+	response, err := h.service.ConvertToResponse(leaveRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	common.SuccessResponse(c, message, response)
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
+)
+
+// LeaveTypeHandler handles the leave type endpoints.
+type LeaveTypeHandler struct {
+	service service.LeaveTypeService
+}
+
+// NewLeaveTypeHandler creates a new instance of LeaveTypeHandler.
+func NewLeaveTypeHandler(service service.LeaveTypeService) *LeaveTypeHandler {
+	return &LeaveTypeHandler{service: service}
+}
+
+// GetAll retrieves leave types.
+func (h *LeaveTypeHandler) GetAll(c *gin.Context) {
+	var searchQuery schema.LeaveTypeSearchQuery
+	if err := c.ShouldBindQuery(&searchQuery); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(searchQuery); err != nil {
+		c.Error(err)
+		return
+	}
+	if searchQuery.Page <= 0 {
+		searchQuery.Page = common.DefaultPage
+	}
+	if searchQuery.Limit <= 0 {
+		searchQuery.Limit = common.DefaultPageSize
+	}
+
+	leaveTypes, err := h.service.GetAll(searchQuery)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	leaveTypeResponses := make([]schema.LeaveTypeResponse, len(leaveTypes))
+	for i, leaveType := range leaveTypes {
+		leaveTypeResponses[i] = *h.service.ConvertToResponse(&leaveType)
+	}
+
+	pageData := common.PageResponse{
+		Page:      searchQuery.Page,
+		Size:      searchQuery.Limit,
+		Total:     len(leaveTypes),
+		TotalPage: (len(leaveTypes) + searchQuery.Limit - 1) / searchQuery.Limit,
+	}
+
+	common.GetDataListResponse(c, "Success", leaveTypeResponses, pageData)
+}
+
+// GetByID retrieves a leave type.
+func (h *LeaveTypeHandler) GetByID(c *gin.Context) {
+	leaveType, err := h.service.GetByID(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Success", h.service.ConvertToResponse(leaveType))
+}
+
+// Create creates a leave type.
+func (h *LeaveTypeHandler) Create(c *gin.Context) {
+	var bodyRequest schema.LeaveTypeRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	leaveType, err := h.service.Create(&bodyRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Create leave type success", h.service.ConvertToResponse(leaveType))
+}
+
+// Update replaces a leave type.
+func (h *LeaveTypeHandler) Update(c *gin.Context) {
+	var bodyRequest schema.LeaveTypeRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	leaveType, err := h.service.Update(c.Param("id"), &bodyRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Update leave type success", h.service.ConvertToResponse(leaveType))
+}
+
+// Delete deletes a leave type no leave was requested for.
+func (h *LeaveTypeHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Leave type deleted successfully", nil)
+}
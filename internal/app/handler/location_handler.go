@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
+)
+
+// LocationHandler handles the location endpoints.
+type LocationHandler struct {
+	service service.LocationService
+}
+
+// NewLocationHandler creates a new instance of LocationHandler.
+func NewLocationHandler(service service.LocationService) *LocationHandler {
+	return &LocationHandler{service: service}
+}
+
+// GetAll retrieves locations.
+func (h *LocationHandler) GetAll(c *gin.Context) {
+	var searchQuery schema.LocationSearchQuery
+	if err := c.ShouldBindQuery(&searchQuery); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(searchQuery); err != nil {
+		c.Error(err)
+		return
+	}
+	if searchQuery.Page <= 0 {
+		searchQuery.Page = common.DefaultPage
+	}
+	if searchQuery.Limit <= 0 {
+		searchQuery.Limit = common.DefaultPageSize
+	}
+
+	locations, err := h.service.GetAll(searchQuery)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	locationResponses := make([]schema.LocationResponse, len(locations))
+	for i, location := range locations {
+		locationResponse, err := h.service.ConvertToResponse(&location)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		locationResponses[i] = *locationResponse
+	}
+
+	pageData := common.PageResponse{
+		Page:      searchQuery.Page,
+		Size:      searchQuery.Limit,
+		Total:     len(locations),
+		TotalPage: (len(locations) + searchQuery.Limit - 1) / searchQuery.Limit,
+	}
+
+	common.GetDataListResponse(c, "Success", locationResponses, pageData)
+}
+
+// GetTree retrieves every location as a tree with the devices placed at each location.
+func (h *LocationHandler) GetTree(c *gin.Context) {
+	tree, err := h.service.GetTree()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Success", tree)
+}
+
+// GetByID retrieves a location.
+func (h *LocationHandler) GetByID(c *gin.Context) {
+	location, err := h.service.GetByID(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	locationResponse, err := h.service.ConvertToResponse(location)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	common.SuccessResponse(c, "Success", locationResponse)
+}
+
+// GetOccupancy retrieves the people currently inside a location.
+func (h *LocationHandler) GetOccupancy(c *gin.Context) {
+	var query schema.LocationOccupancyQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(query); err != nil {
+		c.Error(err)
+		return
+	}
+
+	occupancy, err := h.service.GetOccupancy(c.Param("id"), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Success", occupancy)
+}
+
+// Create creates a location.
+func (h *LocationHandler) Create(c *gin.Context) {
+	var bodyRequest schema.LocationRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	location, err := h.service.Create(&bodyRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	locationResponse, err := h.service.ConvertToResponse(location)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	common.SuccessResponse(c, "Create location success", locationResponse)
+}
+
+// Update replaces a location.
+func (h *LocationHandler) Update(c *gin.Context) {
+	var bodyRequest schema.LocationRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	location, err := h.service.Update(c.Param("id"), &bodyRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	locationResponse, err := h.service.ConvertToResponse(location)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	common.SuccessResponse(c, "Update location success", locationResponse)
+}
+
+// Delete deletes a location without child locations, devices or access control groups.
+func (h *LocationHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Location deleted successfully", nil)
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
+)
+
+// OvertimeRuleHandler handles the overtime rule endpoints.
+type OvertimeRuleHandler struct {
+	service service.OvertimeRuleService
+}
+
+// NewOvertimeRuleHandler creates a new instance of OvertimeRuleHandler.
+func NewOvertimeRuleHandler(service service.OvertimeRuleService) *OvertimeRuleHandler {
+	return &OvertimeRuleHandler{service: service}
+}
+
+// GetAll retrieves overtime rules.
+func (h *OvertimeRuleHandler) GetAll(c *gin.Context) {
+	var searchQuery schema.OvertimeRuleSearchQuery
+	if err := c.ShouldBindQuery(&searchQuery); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(searchQuery); err != nil {
+		c.Error(err)
+		return
+	}
+	if searchQuery.Page <= 0 {
+		searchQuery.Page = common.DefaultPage
+	}
+	if searchQuery.Limit <= 0 {
+		searchQuery.Limit = common.DefaultPageSize
+	}
+
+	rules, err := h.service.GetAll(searchQuery)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	ruleResponses := make([]schema.OvertimeRuleResponse, len(rules))
+	for i, rule := range rules {
+		ruleResponses[i] = *h.service.ConvertToResponse(&rule)
+	}
+
+	pageData := common.PageResponse{
+		Page:      searchQuery.Page,
+		Size:      searchQuery.Limit,
+		Total:     len(rules),
+		TotalPage: (len(rules) + searchQuery.Limit - 1) / searchQuery.Limit,
+	}
+
+	common.GetDataListResponse(c, "Success", ruleResponses, pageData)
+}
+
+// GetByID retrieves an overtime rule.
+func (h *OvertimeRuleHandler) GetByID(c *gin.Context) {
+	rule, err := h.service.GetByID(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Success", h.service.ConvertToResponse(rule))
+}
+
+// Create creates an overtime rule.
+func (h *OvertimeRuleHandler) Create(c *gin.Context) {
+	var bodyRequest schema.OvertimeRuleRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	rule, err := h.service.Create(&bodyRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Create overtime rule success", h.service.ConvertToResponse(rule))
+}
+
+// Update replaces an overtime rule.
+func (h *OvertimeRuleHandler) Update(c *gin.Context) {
+	var bodyRequest schema.OvertimeRuleRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	rule, err := h.service.Update(c.Param("id"), &bodyRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Update overtime rule success", h.service.ConvertToResponse(rule))
+}
+
+// Delete deletes an overtime rule and detaches it from attendance profiles.
+func (h *OvertimeRuleHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Overtime rule deleted successfully", nil)
+}
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+
+	"github.com/gin-gonic/gin"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
+)
+
+// PayrollExportHandler handles the generated payroll export endpoints.
+type PayrollExportHandler struct {
+	service service.PayrollExportService
+}
+
+// NewPayrollExportHandler creates a new instance of PayrollExportHandler.
+func NewPayrollExportHandler(service service.PayrollExportService) *PayrollExportHandler {
+	return &PayrollExportHandler{service: service}
+}
+
+// GetAll retrieves payroll exports, filtered by template, company and status.
+func (h *PayrollExportHandler) GetAll(c *gin.Context) {
+	var searchQuery schema.PayrollExportSearchQuery
+	if err := c.ShouldBindQuery(&searchQuery); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(searchQuery); err != nil {
+		c.Error(err)
+		return
+	}
+	if searchQuery.Page <= 0 {
+		searchQuery.Page = common.DefaultPage
+	}
+	if searchQuery.Limit <= 0 {
+		searchQuery.Limit = common.DefaultPageSize
+	}
+
+	exports, err := h.service.GetAll(searchQuery)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	exportResponses := make([]schema.PayrollExportResponse, len(exports))
+	for i, export := range exports {
+		response, err := h.service.ConvertToResponse(&export)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		exportResponses[i] = *response
+	}
+
+	pageData := common.PageResponse{
+		Page:      searchQuery.Page,
+		Size:      searchQuery.Limit,
+		Total:     len(exports),
+		TotalPage: (len(exports) + searchQuery.Limit - 1) / searchQuery.Limit,
+	}
+
+	common.GetDataListResponse(c, "Success", exportResponses, pageData)
+}
+
+// GetByID retrieves a payroll export.
+func (h *PayrollExportHandler) GetByID(c *gin.Context) {
+	export, err := h.service.GetByID(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	h.respond(c, "Success", export)
+}
+
+// Generate generates the payroll file of a period.
+func (h *PayrollExportHandler) Generate(c *gin.Context) {
+	var bodyRequest schema.PayrollExportRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	export, err := h.service.Generate(&bodyRequest, c.GetString("user"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	h.respond(c, "Generate payroll export success", export)
+}
+
+// Download streams the generated payroll file.
+func (h *PayrollExportHandler) Download(c *gin.Context) {
+	export, file, err := h.service.Open(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer file.Close()
+
+	contentType := "text/plain; charset=utf-8"
+	if path.Ext(export.FileName) == ".csv" {
+		contentType = "text/csv; charset=utf-8"
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", export.FileName))
+	c.Status(http.StatusOK)
+	io.Copy(c.Writer, file)
+}
+
+// Lock closes the period of an export. Only users with the time attendance permission may lock.
+func (h *PayrollExportHandler) Lock(c *gin.Context) {
+	export, err := h.service.Lock(c.Param("id"), c.GetString("user"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	h.respond(c, "Lock payroll export success", export)
+}
+
+// Delete deletes an open payroll export.
+func (h *PayrollExportHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+	common.SuccessResponse(c, "Payroll export deleted successfully", nil)
+}
+
+// GetChanges lists the people whose attendance changed since the export was generated.
+func (h *PayrollExportHandler) GetChanges(c *gin.Context) {
+	changes, err := h.service.GetChanges(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	common.SuccessResponse(c, "Success", changes)
+}
+
+// respond writes a payroll export as the success response.
+func (h *PayrollExportHandler) respond(c *gin.Context, message string, export *model.PayrollExport) {
+	response, err := h.service.ConvertToResponse(export)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	common.SuccessResponse(c, message, response)
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
+)
+
+// PayrollExportTemplateHandler handles the payroll export template endpoints.
+type PayrollExportTemplateHandler struct {
+	service service.PayrollExportTemplateService
+}
+
+// NewPayrollExportTemplateHandler creates a new instance of PayrollExportTemplateHandler.
+func NewPayrollExportTemplateHandler(service service.PayrollExportTemplateService) *PayrollExportTemplateHandler {
+	return &PayrollExportTemplateHandler{service: service}
+}
+
+// GetAll retrieves payroll export templates.
+func (h *PayrollExportTemplateHandler) GetAll(c *gin.Context) {
+	var searchQuery schema.PayrollExportTemplateSearchQuery
+	if err := c.ShouldBindQuery(&searchQuery); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(searchQuery); err != nil {
+		c.Error(err)
+		return
+	}
+	if searchQuery.Page <= 0 {
+		searchQuery.Page = common.DefaultPage
+	}
+	if searchQuery.Limit <= 0 {
+		searchQuery.Limit = common.DefaultPageSize
+	}
+
+	templates, err := h.service.GetAll(searchQuery)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	templateResponses := make([]schema.PayrollExportTemplateResponse, len(templates))
+	for i, template := range templates {
+		response, err := h.service.ConvertToResponse(&template)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		templateResponses[i] = *response
+	}
+
+	pageData := common.PageResponse{
+		Page:      searchQuery.Page,
+		Size:      searchQuery.Limit,
+		Total:     len(templates),
+		TotalPage: (len(templates) + searchQuery.Limit - 1) / searchQuery.Limit,
+	}
+
+	common.GetDataListResponse(c, "Success", templateResponses, pageData)
+}
+
+// GetByID retrieves a payroll export template.
+func (h *PayrollExportTemplateHandler) GetByID(c *gin.Context) {
+	template, err := h.service.GetByID(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.respond(c, "Success", template)
+}
+
+// Create creates a payroll export template.
+func (h *PayrollExportTemplateHandler) Create(c *gin.Context) {
+	var bodyRequest schema.PayrollExportTemplateRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	template, err := h.service.Create(&bodyRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.respond(c, "Create payroll export template success", template)
+}
+
+// Update replaces a payroll export template.
+func (h *PayrollExportTemplateHandler) Update(c *gin.Context) {
+	var bodyRequest schema.PayrollExportTemplateRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	template, err := h.service.Update(c.Param("id"), &bodyRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.respond(c, "Update payroll export template success", template)
+}
+
+// Delete deletes a payroll export template. Generated exports keep its name.
+func (h *PayrollExportTemplateHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Payroll export template deleted successfully", nil)
+}
+
+// respond writes a payroll export template as the success response.
+func (h *PayrollExportTemplateHandler) respond(c *gin.Context, message string, template *model.PayrollExportTemplate) {
+	response, err := h.service.ConvertToResponse(template)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	common.SuccessResponse(c, message, response)
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
+)
+
+// PersonCardHandler handles the card endpoints of a person.
+type PersonCardHandler struct {
+	service service.PersonCardService
+}
+
+// NewPersonCardHandler creates a new instance of PersonCardHandler.
+func NewPersonCardHandler(service service.PersonCardService) *PersonCardHandler {
+	return &PersonCardHandler{service: service}
+}
+
+// GetAll retrieves every card of a person.
+func (h *PersonCardHandler) GetAll(c *gin.Context) {
+	cards, err := h.service.GetAll(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	cardResponses := make([]schema.PersonCardResponse, len(cards))
+	for i, card := range cards {
+		cardResponses[i] = *h.service.ConvertToResponse(&card)
+	}
+
+	common.SuccessResponse(c, "Success", cardResponses)
+}
+
+// GetByID retrieves a card of a person.
+func (h *PersonCardHandler) GetByID(c *gin.Context) {
+	card, err := h.service.GetByID(c.Param("id"), c.Param("cardId"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Success", h.service.ConvertToResponse(card))
+}
+
+// Create issues a new card to a person.
+func (h *PersonCardHandler) Create(c *gin.Context) {
+	var bodyRequest schema.PersonCardRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	card, err := h.service.Create(c.Param("id"), &bodyRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Create card success", h.service.ConvertToResponse(card))
+}
+
+// UpdateValidity changes the validity window of a card.
+func (h *PersonCardHandler) UpdateValidity(c *gin.Context) {
+	var bodyRequest schema.PersonCardValidityRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+
+	card, err := h.service.UpdateValidity(c.Param("id"), c.Param("cardId"), &bodyRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Update card success", h.service.ConvertToResponse(card))
+}
+
+// ChangeStatus marks a card as active, lost, suspended or revoked.
+func (h *PersonCardHandler) ChangeStatus(c *gin.Context) {
+	var bodyRequest schema.PersonCardStatusRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	card, err := h.service.ChangeStatus(c.Param("id"), c.Param("cardId"), &bodyRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Change card status success", h.service.ConvertToResponse(card))
+}
+
+// Delete removes a card from a person.
+func (h *PersonCardHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Param("id"), c.Param("cardId")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Card deleted successfully", nil)
+}
+
+// GetHistory retrieves the card history of a person, or of one card when cardId is in the path.
+func (h *PersonCardHandler) GetHistory(c *gin.Context) {
+	histories, err := h.service.GetHistory(c.Param("id"), c.Param("cardId"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	historyResponses := make([]schema.PersonCardHistoryResponse, len(histories))
+	for i, history := range histories {
+		historyResponses[i] = *h.service.ConvertHistoryToResponse(&history)
+	}
+
+	common.SuccessResponse(c, "Success", historyResponses)
+}
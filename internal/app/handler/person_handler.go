@@ -1,15 +1,16 @@
 package handler
 
 import (
+	"bytes"
+	"fmt"
 	"net/http"
-	"strings"
 	"time"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/putteror/access-control-management/internal/app/common"
 	"github.com/putteror/access-control-management/internal/app/model"
 	"github.com/putteror/access-control-management/internal/app/schema"
 	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
 
 	"github.com/gin-gonic/gin"
 )
@@ -22,31 +23,6 @@ func NewPersonHandler(service service.PersonService) *PersonHandler {
 	return &PersonHandler{service: service}
 }
 
-func init() {
-	validate = validator.New()
-	validate.RegisterValidation("personType", validatePersonType)
-}
-
-func validatePersonType(fl validator.FieldLevel) bool {
-	personType := strings.ToLower(fl.Field().String())
-	for _, allowedType := range schema.PERSON_TYPE_LIST {
-		if personType == allowedType {
-			return true
-		}
-	}
-	return false
-}
-
-func personHandleErrorResponse(c *gin.Context, err error, defaultMessage string) {
-	if strings.Contains(err.Error(), "person name is already exist") {
-		// นำไปใช้กับ format ที่กำหนดไว้ [2025-07-05]
-		message := "ApplicationForm name is already exist"
-		common.ErrorResponse(c, http.StatusBadRequest, message)
-		return
-	}
-	common.ErrorResponse(c, http.StatusInternalServerError, defaultMessage)
-}
-
 // ---
 
 // ## Get Operations
@@ -55,7 +31,11 @@ func personHandleErrorResponse(c *gin.Context, err error, defaultMessage string)
 func (h *PersonHandler) GetAll(c *gin.Context) {
 	var searchQuery schema.PersonSearchQuery
 	if err := c.ShouldBindQuery(&searchQuery); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, "Invalid search query parameter")
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(searchQuery); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -68,7 +48,7 @@ func (h *PersonHandler) GetAll(c *gin.Context) {
 
 	persons, err := h.service.GetAll(searchQuery)
 	if err != nil {
-		personHandleErrorResponse(c, err, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -76,7 +56,7 @@ func (h *PersonHandler) GetAll(c *gin.Context) {
 	for i, person := range persons {
 		response, err := h.service.ConvertToResponse(&person)
 		if err != nil {
-			common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+			c.Error(err)
 			return
 		}
 		personResponses[i] = *response
@@ -98,13 +78,13 @@ func (h *PersonHandler) GetByID(c *gin.Context) {
 
 	person, err := h.service.GetByID(id)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusNotFound, "Person not found")
+		c.Error(err)
 		return
 	}
 
 	personResponse, err := h.service.ConvertToResponse(person)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -119,11 +99,11 @@ func (h *PersonHandler) GetByID(c *gin.Context) {
 func (h *PersonHandler) Create(c *gin.Context) {
 	var bodyRequest schema.PersonRequest
 	if err := c.ShouldBind(&bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		c.Error(validation.BindError(err))
 		return
 	}
-	if err := validate.Struct(bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -133,38 +113,16 @@ func (h *PersonHandler) Create(c *gin.Context) {
 		return
 	}
 
-	dob, err := parseTimeFromRequest(bodyRequest.DateOfBirth)
-	if err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, "Invalid date of birth format")
-		return
-	}
-
-	activate, err := parseTimeFromRequest(bodyRequest.ActiveAt)
-	if err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, "Invalid active date format")
-		return
-	}
-
-	expire, err := parseTimeFromRequest(bodyRequest.ExpireAt)
-	if err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, "Invalid expire date format")
-		return
-	}
-	if expire != nil && activate != nil && expire.Before(*activate) {
-		common.ErrorResponse(c, http.StatusBadRequest, "expire date must be after active date")
-		return
-	}
-
-	person := convertToModel(&bodyRequest, dob, activate, expire)
+	person := convertToModel(&bodyRequest)
 
 	if err := h.service.Save("", person, faceImageFile, bodyRequest.CardIDs, bodyRequest.LicensePlateTexts); err != nil {
-		personHandleErrorResponse(c, err, err.Error())
+		c.Error(err)
 		return
 	}
 
 	personResponse, err := h.service.ConvertToResponse(person)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -177,11 +135,11 @@ func (h *PersonHandler) Update(c *gin.Context) {
 
 	var bodyRequest schema.PersonRequest
 	if err := c.ShouldBind(&bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		c.Error(validation.BindError(err))
 		return
 	}
-	if err := validate.Struct(bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -191,136 +149,227 @@ func (h *PersonHandler) Update(c *gin.Context) {
 		return
 	}
 
-	dob, err := parseTimeFromRequest(bodyRequest.DateOfBirth)
-	if err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, "Invalid date of birth format")
+	person := convertToModel(&bodyRequest)
+
+	if err := h.service.Save(id, person, faceImageFile, bodyRequest.CardIDs, bodyRequest.LicensePlateTexts); err != nil {
+		c.Error(err)
 		return
 	}
 
-	activate, err := parseTimeFromRequest(bodyRequest.ActiveAt)
+	personResponse, err := h.service.ConvertToResponse(person)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, "Invalid active date format")
+		c.Error(err)
 		return
 	}
 
-	expire, err := parseTimeFromRequest(bodyRequest.ExpireAt)
-	if err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, "Invalid expire date format")
+	common.SuccessResponse(c, "Update person success", personResponse)
+}
+
+// PartialUpdate updates an existing person.
+func (h *PersonHandler) PartialUpdate(c *gin.Context) {
+	id := c.Param("id")
+
+	var bodyRequest schema.PersonRequest
+	if err := c.ShouldBind(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
 		return
 	}
-	if expire != nil && activate != nil && expire.Before(*activate) {
-		common.ErrorResponse(c, http.StatusBadRequest, "expire date must be after active date")
+	if err := validation.Partial(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	faceImageFile, err := c.FormFile("faceImage")
+	if err != nil && err != http.ErrMissingFile {
+		common.ErrorResponse(c, http.StatusBadRequest, "Failed to get image file: "+err.Error())
 		return
 	}
 
-	person := convertToModel(&bodyRequest, dob, activate, expire)
+	person := convertToModel(&bodyRequest)
 
-	if err := h.service.Save(id, person, faceImageFile, bodyRequest.CardIDs, bodyRequest.LicensePlateTexts); err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			common.ErrorResponse(c, http.StatusNotFound, err.Error())
-			return
-		}
-		personHandleErrorResponse(c, err, err.Error())
+	if err := h.service.PartialUpdate(id, person, faceImageFile, bodyRequest.CardIDs, bodyRequest.LicensePlateTexts); err != nil {
+		c.Error(err)
 		return
 	}
 
 	personResponse, err := h.service.ConvertToResponse(person)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
 	common.SuccessResponse(c, "Update person success", personResponse)
 }
 
-// PartialUpdate updates an existing person.
-func (h *PersonHandler) PartialUpdate(c *gin.Context) {
+// Delete deletes a person by its ID.
+func (h *PersonHandler) Delete(c *gin.Context) {
 	id := c.Param("id")
 
-	var bodyRequest schema.PersonRequest
-	if err := c.ShouldBind(&bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	if err := h.service.Delete(id); err != nil {
+		c.Error(err)
 		return
 	}
 
-	faceImageFile, err := c.FormFile("faceImage")
-	if err != nil && err != http.ErrMissingFile {
-		common.ErrorResponse(c, http.StatusBadRequest, "Failed to get image file: "+err.Error())
+	common.SuccessResponse(c, "Person deleted successfully", nil)
+}
+
+// ---
+
+// ## Import / Export Operations
+
+// Import creates people from an uploaded CSV or XLSX file.
+// With ?dryRun=true the file is only validated and nothing is saved.
+func (h *PersonHandler) Import(c *gin.Context) {
+	var query schema.PersonImportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(query); err != nil {
+		c.Error(err)
 		return
 	}
 
-	dob, err := parseTimeFromRequest(bodyRequest.DateOfBirth)
+	file, err := c.FormFile("file")
 	if err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, "Invalid date of birth format")
+		common.ErrorResponse(c, http.StatusBadRequest, "Import file is required")
 		return
 	}
 
-	activate, err := parseTimeFromRequest(bodyRequest.ActiveAt)
+	result, err := h.service.Import(file, query.DryRun)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, "Invalid active date format")
+		c.Error(err)
 		return
 	}
 
-	expire, err := parseTimeFromRequest(bodyRequest.ExpireAt)
-	if err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, "Invalid expire date format")
+	if len(result.Errors) > 0 {
+		c.JSON(http.StatusBadRequest, common.APIResponse{
+			Success: false,
+			Message: "Import file has invalid rows, nothing was imported",
+			Data:    result,
+		})
 		return
 	}
 
-	person := convertToModel(&bodyRequest, dob, activate, expire)
+	message := "Import people success"
+	if query.DryRun {
+		message = "Import file is valid"
+	}
+	common.SuccessResponse(c, message, result)
+}
 
-	if err := h.service.PartialUpdate(id, person, faceImageFile, bodyRequest.CardIDs, bodyRequest.LicensePlateTexts); err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			common.ErrorResponse(c, http.StatusNotFound, err.Error())
-			return
-		}
-		personHandleErrorResponse(c, err, err.Error())
+// Export downloads the people matching the search query as a CSV or XLSX file.
+func (h *PersonHandler) Export(c *gin.Context) {
+	var query schema.PersonExportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(query); err != nil {
+		c.Error(err)
+		return
+	}
+	if query.Format == "" {
+		query.Format = common.SpreadsheetFormatCSV
+	}
+	if query.Format != common.SpreadsheetFormatCSV && query.Format != common.SpreadsheetFormatXLSX {
+		common.ErrorResponse(c, http.StatusBadRequest, "format must be 'csv' or 'xlsx'")
 		return
 	}
 
-	personResponse, err := h.service.ConvertToResponse(person)
+	rows, err := h.service.Export(query.PersonSearchQuery)
 	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var buffer bytes.Buffer
+	if err := common.WriteSpreadsheet(&buffer, query.Format, rows); err != nil {
 		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	common.SuccessResponse(c, "Update person success", personResponse)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=people.%s", query.Format))
+	c.Data(http.StatusOK, common.SpreadsheetContentType(query.Format), buffer.Bytes())
 }
 
-// Delete deletes a person by its ID.
-func (h *PersonHandler) Delete(c *gin.Context) {
-	id := c.Param("id")
+// SetPIN sets the PIN of a person.
+func (h *PersonHandler) SetPIN(c *gin.Context) {
+	var bodyRequest schema.PersonPINRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
 
-	if err := h.service.Delete(id); err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			common.ErrorResponse(c, http.StatusNotFound, err.Error())
-			return
-		}
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	if err := h.service.SetPIN(c.Param("id"), *bodyRequest.PIN); err != nil {
+		c.Error(err)
 		return
 	}
 
-	common.SuccessResponse(c, "Person deleted successfully", nil)
+	common.SuccessResponse(c, "Set person PIN success", nil)
+}
+
+// ClearPIN removes the PIN of a person.
+func (h *PersonHandler) ClearPIN(c *gin.Context) {
+	if err := h.service.ClearPIN(c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Clear person PIN success", nil)
+}
+
+// ImportFaceImages sets face images of people from an uploaded ZIP archive.
+// ?matchBy=personId|email|manifest selects how image files are matched to people.
+func (h *PersonHandler) ImportFaceImages(c *gin.Context) {
+	var query schema.PersonFaceImageImportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(query); err != nil {
+		c.Error(err)
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		common.ErrorResponse(c, http.StatusBadRequest, "ZIP file is required")
+		return
+	}
+
+	result, err := h.service.ImportFaceImages(file, query.MatchBy)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Import face images finished", result)
 }
 
 // ---
 
 // ## Helper Functions
 
-// parseTimeFromRequest safely parses a string into a time.Time pointer.
-func parseTimeFromRequest(timeString *string) (*time.Time, error) {
-	if timeString == nil || *timeString == "" {
-		return nil, nil
+// parseTimestamp parses an optional timestamp of a request that was validated with the timestamp
+// tag, so it cannot fail.
+func parseTimestamp(value *string) *time.Time {
+	if value == nil || *value == "" {
+		return nil
 	}
-	parsedTime, err := time.Parse("2006-01-02", *timeString)
+	parsed, err := common.ParseTimestamp(*value, nil)
 	if err != nil {
-		return nil, err
+		return nil
 	}
-	return &parsedTime, nil
+	return &parsed
 }
 
 // convertToModel converts a schema.PersonRequest to a model.Person.
-func convertToModel(bodyRequest *schema.PersonRequest, dob, activate, expire *time.Time) *model.Person {
+func convertToModel(bodyRequest *schema.PersonRequest) *model.Person {
 	return &model.Person{
 		FirstName:           *bodyRequest.FirstName,
 		MiddleName:          bodyRequest.MiddleName,
@@ -328,15 +377,15 @@ func convertToModel(bodyRequest *schema.PersonRequest, dob, activate, expire *ti
 		PersonType:          *bodyRequest.PersonType,
 		PersonID:            bodyRequest.PersonID,
 		Gender:              bodyRequest.Gender,
-		DateOfBirth:         dob,
+		DateOfBirth:         parseTimestamp(bodyRequest.DateOfBirth),
 		Company:             bodyRequest.Company,
 		Department:          bodyRequest.Department,
 		JobPosition:         bodyRequest.JobPosition,
 		Address:             bodyRequest.Address,
 		MobileNumber:        bodyRequest.MobileNumber,
 		Email:               bodyRequest.Email,
-		ActiveAt:            activate,
-		ExpireAt:            expire,
+		ActiveAt:            parseTimestamp(bodyRequest.ActiveAt),
+		ExpireAt:            parseTimestamp(bodyRequest.ExpireAt),
 		AccessControlRuleID: bodyRequest.AccessControlRuleID,
 		TimeAttendanceID:    bodyRequest.TimeAttendanceID,
 	}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
+)
+
+// PersonShiftHandler handles the shift assignment and override endpoints of a person.
+type PersonShiftHandler struct {
+	service service.PersonShiftService
+}
+
+// NewPersonShiftHandler creates a new instance of PersonShiftHandler.
+func NewPersonShiftHandler(service service.PersonShiftService) *PersonShiftHandler {
+	return &PersonShiftHandler{service: service}
+}
+
+// GetAssignments retrieves the rotation assignments of a person.
+func (h *PersonShiftHandler) GetAssignments(c *gin.Context) {
+	assignments, err := h.service.GetAssignments(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	assignmentResponses := make([]schema.PersonShiftAssignmentResponse, 0, len(assignments))
+	for _, assignment := range assignments {
+		response, err := h.service.ConvertAssignmentToResponse(&assignment)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		assignmentResponses = append(assignmentResponses, *response)
+	}
+
+	common.SuccessResponse(c, "Success", assignmentResponses)
+}
+
+// CreateAssignment puts a person on a shift rotation.
+func (h *PersonShiftHandler) CreateAssignment(c *gin.Context) {
+	var bodyRequest schema.PersonShiftAssignmentRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	assignment, err := h.service.CreateAssignment(c.Param("id"), &bodyRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response, err := h.service.ConvertAssignmentToResponse(assignment)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Create shift assignment success", response)
+}
+
+// DeleteAssignment removes a rotation assignment of a person.
+func (h *PersonShiftHandler) DeleteAssignment(c *gin.Context) {
+	if err := h.service.DeleteAssignment(c.Param("id"), c.Param("assignmentId")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Shift assignment deleted successfully", nil)
+}
+
+// GetOverrides retrieves the shift overrides of a person.
+func (h *PersonShiftHandler) GetOverrides(c *gin.Context) {
+	overrides, err := h.service.GetOverrides(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	overrideResponses := make([]schema.PersonShiftOverrideResponse, 0, len(overrides))
+	for _, override := range overrides {
+		response, err := h.service.ConvertOverrideToResponse(&override)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		overrideResponses = append(overrideResponses, *response)
+	}
+
+	common.SuccessResponse(c, "Success", overrideResponses)
+}
+
+// SaveOverride sets the shift of a person on one date.
+func (h *PersonShiftHandler) SaveOverride(c *gin.Context) {
+	var bodyRequest schema.PersonShiftOverrideRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	override, err := h.service.SaveOverride(c.Param("id"), &bodyRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response, err := h.service.ConvertOverrideToResponse(override)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Save shift override success", response)
+}
+
+// DeleteOverride removes a shift override of a person.
+func (h *PersonShiftHandler) DeleteOverride(c *gin.Context) {
+	if err := h.service.DeleteOverride(c.Param("id"), c.Param("overrideId")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Shift override deleted successfully", nil)
+}
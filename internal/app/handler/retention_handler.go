@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
+)
+
+// RetentionHandler handles the data retention endpoints.
+type RetentionHandler struct {
+	service service.RetentionService
+}
+
+// NewRetentionHandler creates a new instance of RetentionHandler.
+func NewRetentionHandler(service service.RetentionService) *RetentionHandler {
+	return &RetentionHandler{service: service}
+}
+
+// GetPolicy returns the configured retention period of each data class.
+func (h *RetentionHandler) GetPolicy(c *gin.Context) {
+	common.SuccessResponse(c, "Success", h.service.GetPolicy())
+}
+
+// GetAllRuns retrieves the report of past retention runs.
+func (h *RetentionHandler) GetAllRuns(c *gin.Context) {
+	var searchQuery schema.RetentionRunSearchQuery
+	if err := c.ShouldBindQuery(&searchQuery); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(searchQuery); err != nil {
+		c.Error(err)
+		return
+	}
+	if searchQuery.Page <= 0 {
+		searchQuery.Page = common.DefaultPage
+	}
+	if searchQuery.Limit <= 0 {
+		searchQuery.Limit = common.DefaultPageSize
+	}
+
+	runs, err := h.service.GetAllRuns(searchQuery)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	runResponses := make([]schema.RetentionRunResponse, len(runs))
+	for i, run := range runs {
+		response, err := h.service.ConvertRunToResponse(&run)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		runResponses[i] = *response
+	}
+
+	pageData := common.PageResponse{
+		Page:      searchQuery.Page,
+		Size:      searchQuery.Limit,
+		Total:     len(runs),
+		TotalPage: (len(runs) + searchQuery.Limit - 1) / searchQuery.Limit,
+	}
+
+	common.GetDataListResponse(c, "Success", runResponses, pageData)
+}
+
+// GetRunByID retrieves what a retention run purged.
+func (h *RetentionHandler) GetRunByID(c *gin.Context) {
+	run, err := h.service.GetRunByID(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	h.respond(c, "Success", run)
+}
+
+// Run starts a retention run now instead of waiting for the schedule.
+func (h *RetentionHandler) Run(c *gin.Context) {
+	run, err := h.service.Run(common.RetentionTriggerManual, c.GetString("user"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	h.respond(c, "Retention run finished", run)
+}
+
+// respond writes a retention run as the success response.
+func (h *RetentionHandler) respond(c *gin.Context, message string, run *model.RetentionRun) {
+	response, err := h.service.ConvertRunToResponse(run)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	common.SuccessResponse(c, message, response)
+}
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
+)
+
+// ShiftRotationHandler handles the shift rotation endpoints.
+type ShiftRotationHandler struct {
+	service service.ShiftRotationService
+}
+
+// NewShiftRotationHandler creates a new instance of ShiftRotationHandler.
+func NewShiftRotationHandler(service service.ShiftRotationService) *ShiftRotationHandler {
+	return &ShiftRotationHandler{service: service}
+}
+
+// GetAll retrieves shift rotations.
+func (h *ShiftRotationHandler) GetAll(c *gin.Context) {
+	var searchQuery schema.ShiftRotationSearchQuery
+	if err := c.ShouldBindQuery(&searchQuery); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(searchQuery); err != nil {
+		c.Error(err)
+		return
+	}
+	if searchQuery.Page <= 0 {
+		searchQuery.Page = common.DefaultPage
+	}
+	if searchQuery.Limit <= 0 {
+		searchQuery.Limit = common.DefaultPageSize
+	}
+
+	rotations, err := h.service.GetAll(searchQuery)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	rotationResponses := make([]schema.ShiftRotationResponse, 0, len(rotations))
+	for _, rotation := range rotations {
+		response, err := h.service.ConvertToResponse(&rotation)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		rotationResponses = append(rotationResponses, *response)
+	}
+
+	pageData := common.PageResponse{
+		Page:      searchQuery.Page,
+		Size:      searchQuery.Limit,
+		Total:     len(rotations),
+		TotalPage: (len(rotations) + searchQuery.Limit - 1) / searchQuery.Limit,
+	}
+
+	common.GetDataListResponse(c, "Success", rotationResponses, pageData)
+}
+
+// GetByID retrieves a shift rotation with its cycle days.
+func (h *ShiftRotationHandler) GetByID(c *gin.Context) {
+	rotation, err := h.service.GetByID(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response, err := h.service.ConvertToResponse(rotation)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Success", response)
+}
+
+// Create creates a shift rotation.
+func (h *ShiftRotationHandler) Create(c *gin.Context) {
+	var bodyRequest schema.ShiftRotationRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	rotation, err := h.service.Create(&bodyRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response, err := h.service.ConvertToResponse(rotation)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Create shift rotation success", response)
+}
+
+// Update replaces a shift rotation and its cycle days.
+func (h *ShiftRotationHandler) Update(c *gin.Context) {
+	var bodyRequest schema.ShiftRotationRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	rotation, err := h.service.Update(c.Param("id"), &bodyRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response, err := h.service.ConvertToResponse(rotation)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Update shift rotation success", response)
+}
+
+// Delete deletes a shift rotation that no person is assigned to.
+func (h *ShiftRotationHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Shift rotation deleted successfully", nil)
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
+)
+
+// ShiftTemplateHandler handles the shift template endpoints.
+type ShiftTemplateHandler struct {
+	service service.ShiftTemplateService
+}
+
+// NewShiftTemplateHandler creates a new instance of ShiftTemplateHandler.
+func NewShiftTemplateHandler(service service.ShiftTemplateService) *ShiftTemplateHandler {
+	return &ShiftTemplateHandler{service: service}
+}
+
+// GetAll retrieves shift templates.
+func (h *ShiftTemplateHandler) GetAll(c *gin.Context) {
+	var searchQuery schema.ShiftTemplateSearchQuery
+	if err := c.ShouldBindQuery(&searchQuery); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(searchQuery); err != nil {
+		c.Error(err)
+		return
+	}
+	if searchQuery.Page <= 0 {
+		searchQuery.Page = common.DefaultPage
+	}
+	if searchQuery.Limit <= 0 {
+		searchQuery.Limit = common.DefaultPageSize
+	}
+
+	templates, err := h.service.GetAll(searchQuery)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	templateResponses := make([]schema.ShiftTemplateResponse, len(templates))
+	for i, template := range templates {
+		templateResponses[i] = *h.service.ConvertToResponse(&template)
+	}
+
+	pageData := common.PageResponse{
+		Page:      searchQuery.Page,
+		Size:      searchQuery.Limit,
+		Total:     len(templates),
+		TotalPage: (len(templates) + searchQuery.Limit - 1) / searchQuery.Limit,
+	}
+
+	common.GetDataListResponse(c, "Success", templateResponses, pageData)
+}
+
+// GetByID retrieves a shift template.
+func (h *ShiftTemplateHandler) GetByID(c *gin.Context) {
+	template, err := h.service.GetByID(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Success", h.service.ConvertToResponse(template))
+}
+
+// Create creates a shift template.
+func (h *ShiftTemplateHandler) Create(c *gin.Context) {
+	var bodyRequest schema.ShiftTemplateRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	template, err := h.service.Create(&bodyRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Create shift template success", h.service.ConvertToResponse(template))
+}
+
+// Update replaces a shift template.
+func (h *ShiftTemplateHandler) Update(c *gin.Context) {
+	var bodyRequest schema.ShiftTemplateRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	template, err := h.service.Update(c.Param("id"), &bodyRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Update shift template success", h.service.ConvertToResponse(template))
+}
+
+// Delete deletes a shift template that no rotation or override uses.
+func (h *ShiftTemplateHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Shift template deleted successfully", nil)
+}
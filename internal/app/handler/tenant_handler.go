@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
+)
+
+// TenantHandler handles the tenant endpoints of super admins.
+type TenantHandler struct {
+	service service.TenantService
+}
+
+// NewTenantHandler creates a new instance of TenantHandler.
+func NewTenantHandler(service service.TenantService) *TenantHandler {
+	return &TenantHandler{service: service}
+}
+
+// GetAll retrieves tenants.
+func (h *TenantHandler) GetAll(c *gin.Context) {
+	var searchQuery schema.TenantSearchQuery
+	if err := c.ShouldBindQuery(&searchQuery); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(searchQuery); err != nil {
+		c.Error(err)
+		return
+	}
+	if searchQuery.Page <= 0 {
+		searchQuery.Page = common.DefaultPage
+	}
+	if searchQuery.Limit <= 0 {
+		searchQuery.Limit = common.DefaultPageSize
+	}
+
+	tenants, err := h.service.GetAll(searchQuery)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	tenantResponses := make([]schema.TenantResponse, len(tenants))
+	for i, tenant := range tenants {
+		tenantResponses[i] = *h.service.ConvertToResponse(&tenant)
+	}
+
+	pageData := common.PageResponse{
+		Page:      searchQuery.Page,
+		Size:      searchQuery.Limit,
+		Total:     len(tenants),
+		TotalPage: (len(tenants) + searchQuery.Limit - 1) / searchQuery.Limit,
+	}
+
+	common.GetDataListResponse(c, "Success", tenantResponses, pageData)
+}
+
+// GetByID retrieves a tenant.
+func (h *TenantHandler) GetByID(c *gin.Context) {
+	tenant, err := h.service.GetByID(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Success", h.service.ConvertToResponse(tenant))
+}
+
+// Create creates a tenant.
+func (h *TenantHandler) Create(c *gin.Context) {
+	var bodyRequest schema.TenantRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	tenant, err := h.service.Create(&bodyRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Create tenant success", h.service.ConvertToResponse(tenant))
+}
+
+// Update replaces a tenant.
+func (h *TenantHandler) Update(c *gin.Context) {
+	var bodyRequest schema.TenantRequest
+	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(bodyRequest); err != nil {
+		c.Error(err)
+		return
+	}
+
+	tenant, err := h.service.Update(c.Param("id"), &bodyRequest)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Update tenant success", h.service.ConvertToResponse(tenant))
+}
+
+// Delete deletes a tenant without users or people.
+func (h *TenantHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Tenant deleted successfully", nil)
+}
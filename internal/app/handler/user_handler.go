@@ -1,20 +1,13 @@
 package handler
 
 import (
-	"net/http"
-	"strings"
-
 	"github.com/gin-gonic/gin"
 	"github.com/putteror/access-control-management/internal/app/common"
 	"github.com/putteror/access-control-management/internal/app/schema"
 	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
 )
 
-// ต้องมั่นใจว่ามีการประกาศ var validate *validator.Validate และมีการเรียก init()
-// เราจะสมมติว่ามีการประกาศและเรียกใช้ในไฟล์หลักของ package handler แล้ว
-// var validate *validator.Validate
-// func init() { validate = validator.New() }
-
 type UserHandler struct {
 	service service.UserService
 }
@@ -23,20 +16,6 @@ func NewUserHandler(service service.UserService) *UserHandler {
 	return &UserHandler{service: service}
 }
 
-// ## Error Handling Utility
-// ฟังก์ชันนี้ช่วยจัดการข้อผิดพลาดเฉพาะที่เกี่ยวกับชื่อซ้ำสำหรับ User
-func handleUserErrorResponse(c *gin.Context, err error, defaultMessage string) {
-	// ตรวจสอบข้อความ error สำหรับกรณีชื่อซ้ำที่กำหนดไว้ก่อนหน้า [2025-07-05]
-	if strings.Contains(err.Error(), "ApplicationForm name is already exist") {
-		// นำไปใช้กับ format ที่กำหนดไว้
-		message := "User name is already exist" // เปลี่ยนให้สื่อถึง User
-		common.ErrorResponse(c, http.StatusBadRequest, message)
-		return
-	}
-	// สำหรับข้อผิดพลาดอื่น ๆ ที่มาจาก Service
-	common.ErrorResponse(c, http.StatusInternalServerError, defaultMessage)
-}
-
 // ---
 
 // ## Get Operations
@@ -46,7 +25,11 @@ func (h *UserHandler) GetAll(c *gin.Context) {
 
 	var searchQuery schema.UserSearchQuery
 	if err := c.ShouldBindQuery(&searchQuery); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, "Invalid search query parameter")
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(searchQuery); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -60,7 +43,7 @@ func (h *UserHandler) GetAll(c *gin.Context) {
 	// UserService.GetAll คืนค่าเป็น []model.User
 	userModels, err := h.service.GetAll(searchQuery)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -69,7 +52,7 @@ func (h *UserHandler) GetAll(c *gin.Context) {
 		// ต้องแปลงเป็น Response Schema
 		response, err := h.service.ConvertToResponse(&userModel)
 		if err != nil {
-			common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+			c.Error(err)
 			return
 		}
 		userResponses = append(userResponses, *response)
@@ -94,17 +77,13 @@ func (h *UserHandler) GetByID(c *gin.Context) {
 	// UserService.GetByID คืนค่าเป็น *model.User
 	userModel, err := h.service.GetByID(id)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			common.ErrorResponse(c, http.StatusNotFound, err.Error())
-			return
-		}
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
 	userResponse, err := h.service.ConvertToResponse(userModel)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -119,21 +98,20 @@ func (h *UserHandler) GetByID(c *gin.Context) {
 func (h *UserHandler) Create(c *gin.Context) {
 	var bodyRequest schema.UserRequest
 	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		c.Error(validation.BindError(err))
 		return
 	}
 
 	// UserService.Create คืนค่าเป็น *model.User
 	userModel, err := h.service.Create(&bodyRequest)
 	if err != nil {
-		// ใช้ handleUserErrorResponse เพื่อจัดการข้อผิดพลาดชื่อซ้ำ
-		handleUserErrorResponse(c, err, err.Error())
+		c.Error(err)
 		return
 	}
 
 	userResponse, err := h.service.ConvertToResponse(userModel)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -146,24 +124,20 @@ func (h *UserHandler) Update(c *gin.Context) {
 
 	var bodyRequest schema.UserRequest
 	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		c.Error(validation.BindError(err))
 		return
 	}
 
 	// UserService.Update คืนค่าเป็น *model.User
 	userModel, err := h.service.Update(id, &bodyRequest)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			common.ErrorResponse(c, http.StatusNotFound, err.Error())
-			return
-		}
-		handleUserErrorResponse(c, err, err.Error())
+		c.Error(err)
 		return
 	}
 
 	userResponse, err := h.service.ConvertToResponse(userModel)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -176,24 +150,24 @@ func (h *UserHandler) PartialUpdate(c *gin.Context) {
 
 	var bodyRequest schema.UserRequest
 	if err := c.ShouldBindJSON(&bodyRequest); err != nil {
-		common.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Partial(bodyRequest); err != nil {
+		c.Error(err)
 		return
 	}
 
 	// UserService.PartialUpdate คืนค่าเป็น *model.User
 	userModel, err := h.service.PartialUpdate(id, &bodyRequest)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			common.ErrorResponse(c, http.StatusNotFound, err.Error())
-			return
-		}
-		handleUserErrorResponse(c, err, err.Error())
+		c.Error(err)
 		return
 	}
 
 	userResponse, err := h.service.ConvertToResponse(userModel)
 	if err != nil {
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -205,11 +179,7 @@ func (h *UserHandler) Delete(c *gin.Context) {
 	id := c.Param("id")
 
 	if err := h.service.Delete(id); err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			common.ErrorResponse(c, http.StatusNotFound, err.Error())
-			return
-		}
-		common.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"github.com/putteror/access-control-management/internal/app/service"
+	"github.com/putteror/access-control-management/internal/app/validation"
+)
+
+// VisitorVehicleHandler handles the visitor vehicle endpoints.
+type VisitorVehicleHandler struct {
+	service service.VisitorVehicleService
+}
+
+// NewVisitorVehicleHandler creates a new instance of VisitorVehicleHandler.
+func NewVisitorVehicleHandler(service service.VisitorVehicleService) *VisitorVehicleHandler {
+	return &VisitorVehicleHandler{service: service}
+}
+
+// GetAll retrieves visitor vehicles, most recently seen first.
+func (h *VisitorVehicleHandler) GetAll(c *gin.Context) {
+	var searchQuery schema.VisitorVehicleSearchQuery
+	if err := c.ShouldBindQuery(&searchQuery); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	if err := validation.Struct(searchQuery); err != nil {
+		c.Error(err)
+		return
+	}
+	if searchQuery.Page <= 0 {
+		searchQuery.Page = common.DefaultPage
+	}
+	if searchQuery.Limit <= 0 {
+		searchQuery.Limit = common.DefaultPageSize
+	}
+
+	vehicles, err := h.service.GetAll(searchQuery)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	vehicleResponses := make([]schema.VisitorVehicleResponse, len(vehicles))
+	for i, vehicle := range vehicles {
+		vehicleResponses[i] = *h.service.ConvertToResponse(&vehicle)
+	}
+
+	pageData := common.PageResponse{
+		Page:      searchQuery.Page,
+		Size:      searchQuery.Limit,
+		Total:     len(vehicles),
+		TotalPage: (len(vehicles) + searchQuery.Limit - 1) / searchQuery.Limit,
+	}
+
+	common.GetDataListResponse(c, "Success", vehicleResponses, pageData)
+}
+
+// GetByID retrieves a visitor vehicle by its ID.
+func (h *VisitorVehicleHandler) GetByID(c *gin.Context) {
+	vehicle, err := h.service.GetByID(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Success", h.service.ConvertToResponse(vehicle))
+}
+
+// Delete deletes a visitor vehicle by its ID.
+func (h *VisitorVehicleHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SuccessResponse(c, "Visitor vehicle deleted successfully", nil)
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/putteror/access-control-management/internal/config"
+)
+
+// CORSMiddleware allows cross-origin requests from the allowed origins of the config. Preflight
+// requests are answered here without reaching the handlers.
+func CORSMiddleware(corsConfig config.CORSConfig) gin.HandlerFunc {
+	allowAll := slices.Contains(corsConfig.AllowedOrigins, "*")
+	methods := strings.Join(corsConfig.AllowedMethods, ", ")
+	headers := strings.Join(corsConfig.AllowedHeaders, ", ")
+	maxAge := strconv.Itoa(corsConfig.MaxAgeSeconds)
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" || (!allowAll && !slices.Contains(corsConfig.AllowedOrigins, origin)) {
+			c.Next()
+			return
+		}
+
+		if allowAll {
+			c.Header("Access-Control-Allow-Origin", "*")
+		} else {
+			c.Header("Access-Control-Allow-Origin", origin)
+			c.Header("Vary", "Origin")
+		}
+		if corsConfig.AllowCredentials {
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
+
+		if c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != "" {
+			c.Header("Access-Control-Allow-Methods", methods)
+			c.Header("Access-Control-Allow-Headers", headers)
+			c.Header("Access-Control-Max-Age", maxAge)
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"gorm.io/gorm"
+)
+
+// ErrorMiddleware writes the error response of the last error a handler added with c.Error. The
+// status and the code come from the type of the error, errors of other types are internal errors.
+// Handlers that already wrote a response are left alone.
+func ErrorMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		status, body := ErrorBody(c.Errors.Last().Err)
+		c.JSON(status, body)
+	}
+}
+
+// ErrorBody returns the status and the body of the error response of an error.
+func ErrorBody(err error) (int, common.ErrorBody) {
+	body := common.ErrorBody{Success: false, Message: err.Error()}
+	var (
+		notFound   *common.NotFoundError
+		conflict   *common.ConflictError
+		forbidden  *common.ForbiddenError
+		validation *common.ValidationError
+	)
+	status := http.StatusInternalServerError
+	switch {
+	case errors.As(err, &notFound):
+		status = http.StatusNotFound
+	case errors.As(err, &conflict):
+		status = http.StatusConflict
+	case errors.As(err, &forbidden):
+		status = http.StatusForbidden
+	case errors.As(err, &validation):
+		status = http.StatusBadRequest
+		body.Code = common.ErrorCodeValidation
+		body.Fields = validation.Fields
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		// A record a service looked up without checking, e.g. wrapped as "failed to get ...: %w"
+		status = http.StatusNotFound
+	}
+	if body.Code == "" {
+		body.Code = common.StatusErrorCode(status)
+	}
+	return status, body
+}
@@ -17,43 +17,50 @@ func JWTAuthMiddleware() gin.HandlerFunc {
 		// 1. Get the token from the Authorization header.
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
-			c.Abort()
-			return
-		}
-
-		// The token should be in the format "Bearer <token>".
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-		if tokenString == authHeader {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header format must be 'Bearer <token>'"})
+			common.ErrorResponse(c, http.StatusUnauthorized, "Authorization header is required")
 			c.Abort()
 			return
 		}
 
 		// 2. Parse and validate the token.
-		token, err := jwt.ParseWithClaims(tokenString, &schema.CustomClaims{}, func(token *jwt.Token) (interface{}, error) {
-			// Ensure the token's signing method is HMAC.
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, errors.New("unexpected signing method")
-			}
-			return common.JwtSecret, nil // Return the secret key.
-		})
-
+		claims, err := ParseToken(authHeader)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
-			c.Abort()
-			return
-		}
-
-		// 3. Extract claims and set them in the context.
-		claims, ok := token.Claims.(*schema.CustomClaims)
-		if !ok || !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
+			common.ErrorResponse(c, http.StatusUnauthorized, err.Error())
 			c.Abort()
 			return
 		}
 
+		// 3. Set the claims in the context.
 		c.Set("user", claims.Username)
+		c.Set("tenantID", claims.TenantID)
+		c.Set("superAdmin", claims.SuperAdmin)
 		c.Next()
 	}
 }
+
+// ParseToken validates the "Bearer <token>" value of an Authorization header and returns its
+// claims. Only super admins may have a token without a tenant.
+func ParseToken(authHeader string) (*schema.CustomClaims, error) {
+	// The token should be in the format "Bearer <token>".
+	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+	if tokenString == authHeader {
+		return nil, errors.New("Authorization header format must be 'Bearer <token>'")
+	}
+
+	token, err := jwt.ParseWithClaims(tokenString, &schema.CustomClaims{}, func(token *jwt.Token) (interface{}, error) {
+		// Ensure the token's signing method is HMAC.
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return common.JwtSecret, nil // Return the secret key.
+	})
+	if err != nil {
+		return nil, errors.New("Invalid or expired token")
+	}
+
+	claims, ok := token.Claims.(*schema.CustomClaims)
+	if !ok || !token.Valid || (claims.TenantID == "" && !claims.SuperAdmin) {
+		return nil, errors.New("Invalid token claims")
+	}
+	return claims, nil
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/putteror/access-control-management/internal/app/common"
+)
+
+// RequireSuperAdmin only lets super admins through. It must run after JWTAuthMiddleware.
+func RequireSuperAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !c.GetBool("superAdmin") {
+			common.ErrorResponse(c, http.StatusForbidden, "user is not allowed to manage tenants")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireGlobalScope only lets super admins in the global scope through, for endpoints that work
+// on the data of every tenant at once. It must run after JWTAuthMiddleware.
+func RequireGlobalScope() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !c.GetBool("superAdmin") || c.GetString("tenantID") != "" {
+			common.ErrorResponse(c, http.StatusForbidden, "user is not allowed to use this endpoint outside the global scope, switch to it first")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -2,17 +2,22 @@ package model
 
 type AccessControlDevice struct {
 	BaseModel
+	TenantScoped
 	Name                  string  `json:"name"`
 	Type                  string  `json:"type"`
 	HostAddress           string  `json:"host_address"`
 	Username              *string `json:"username"`
-	Password              *string `json:"password"`
-	AccessToken           *string `json:"access_token"`
-	ApiToken              *string `json:"api_token"`
+	Password              *string `json:"password" gorm:"serializer:encrypted"`
+	AccessToken           *string `json:"access_token" gorm:"serializer:encrypted"`
+	ApiToken              *string `json:"api_token" gorm:"serializer:encrypted"`
 	AccessControlServerID *string `json:"access_control_server_id"`
-	RecordScan            bool    `json:"record_scan"`
-	RecordAttendance      bool    `json:"record_attendance"`
-	AllowClockIn          bool    `json:"allow_clock_in"`
-	AllowClockOut         bool    `json:"allow_clock_out"`
-	Status                string  `json:"status"`
+	LocationID            *string `json:"location_id" gorm:"index"`
+	// TimeZone is the IANA time zone schedules are evaluated in at the device. Without it the
+	// device uses the time zone of its location, or of the server.
+	TimeZone         *string `json:"time_zone"`
+	RecordScan       bool    `json:"record_scan"`
+	RecordAttendance bool    `json:"record_attendance"`
+	AllowClockIn     bool    `json:"allow_clock_in"`
+	AllowClockOut    bool    `json:"allow_clock_out"`
+	Status           string  `json:"status"`
 }
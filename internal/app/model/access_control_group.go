@@ -2,5 +2,11 @@ package model
 
 type AccessControlGroup struct {
 	BaseModel
+	TenantScoped
 	Name string `json:"name"`
+	// AuthMode is the credential combination required at the devices of the group
+	AuthMode               string `json:"auth_mode" gorm:"default:any"`
+	TwoPersonWindowSeconds int    `json:"two_person_window_seconds" gorm:"default:30"`
+	// HolidayCalendarID overrides the group schedule on the calendar's holidays
+	HolidayCalendarID *string `json:"holiday_calendar_id"`
 }
@@ -2,6 +2,7 @@ package model
 
 type AccessControlGroupDevice struct {
 	BaseModel
+	TenantScoped
 	AccessControlGroupID  string `json:"access_control_group_id"`
 	AccessControlDeviceID string `json:"access_control_device_id"`
 }
@@ -0,0 +1,10 @@
+package model
+
+// AccessControlGroupLocation adds every device at a location or below it to a group, such as all
+// doors of a floor, including devices installed there later.
+type AccessControlGroupLocation struct {
+	BaseModel
+	TenantScoped
+	AccessControlGroupID string `json:"access_control_group_id" gorm:"index"`
+	LocationID           string `json:"location_id" gorm:"index"`
+}
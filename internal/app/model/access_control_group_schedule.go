@@ -1,7 +1,10 @@
 package model
 
+// AccessControlGroupSchedule is a time window of a group. Dates and clock times are in the local time
+// of the device the access is requested at, see the time zones of devices and locations.
 type AccessControlGroupSchedule struct {
 	BaseModel
+	TenantScoped
 	AccessControlGroupID string  `json:"access_control_group_id"`
 	DayOfWeek            int     `json:"day_of_week"`
 	Date                 *string `json:"date"`
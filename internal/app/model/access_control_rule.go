@@ -2,5 +2,6 @@ package model
 
 type AccessControlRule struct {
 	BaseModel
+	TenantScoped
 	Name string `json:"name"`
 }
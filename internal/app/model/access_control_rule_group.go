@@ -2,6 +2,7 @@ package model
 
 type AccessControlRuleGroup struct {
 	BaseModel
+	TenantScoped
 	AccessControlGroupID string `json:"access_control_group_id"`
 	AccessControlRuleID  string `json:"access_control_rule_id"`
 }
@@ -6,13 +6,14 @@ import (
 
 type AccessControlServer struct {
 	BaseModel
+	TenantScoped
 	Name        string     `json:"name"`
 	Type        string     `json:"type"`
 	HostAddress string     `json:"host_address"`
 	Username    *string    `json:"username"`
-	Password    *string    `json:"password"`
-	AccessToken *string    `json:"access_token"`
-	ApiToken    *string    `json:"api_token"`
+	Password    *string    `json:"password" gorm:"serializer:encrypted"`
+	AccessToken *string    `json:"access_token" gorm:"serializer:encrypted"`
+	ApiToken    *string    `json:"api_token" gorm:"serializer:encrypted"`
 	Status      string     `json:"status"`
 	LastSyncAt  *time.Time `json:"last_sync_at"`
 }
@@ -1,12 +1,99 @@
 package model
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
+// AccessRecord is an access event. Access records are append-only: every record is chained to
+// the previous one by Sequence and PrevHash, and Hash covers PrevHash and the record fields.
+// Corrections are stored as AccessRecordAnnotation rows instead of editing the record.
 type AccessRecord struct {
 	BaseModel
+	TenantScoped
 	PersonID              *string   `json:"person_id"`
 	AccessControlDeviceID *string   `json:"access_control_device_id"`
 	Type                  string    `json:"type"`
 	Result                string    `json:"result"`
 	AccessTime            time.Time `json:"access_time"`
+	CardNumber            *string   `json:"card_number"`
+	PlateText             *string   `json:"plate_text"`
+	PlateConfidence       *float64  `json:"plate_confidence"`
+	PlateImagePath        *string   `json:"plate_image_path"`
+	Reason                *string   `json:"reason"`
+	Factors               *string   `json:"factors"`
+	ScanSessionID         *string   `json:"scan_session_id"`
+	Sequence              int64     `json:"sequence" gorm:"index"`
+	PrevHash              string    `json:"prev_hash"`
+	Hash                  string    `json:"hash"`
+	ChainVersion          int       `json:"chain_version" gorm:"not null;default:1"`
+}
+
+// HashPayload returns the fields of the record covered by its hash. From chain version 2 on the
+// payload also covers the version and the tenant, so moving a record to another tenant breaks its
+// hash. The payload of version 1 is kept as it was for the records not re-sealed yet.
+func (a *AccessRecord) HashPayload() []byte {
+	var chainVersion int
+	var tenantID *string
+	if a.ChainVersion >= 2 {
+		chainVersion = a.ChainVersion
+		tenantID = &a.TenantID
+	}
+	payload, _ := json.Marshal(struct {
+		ChainVersion          int      `json:"chain_version,omitempty"`
+		Sequence              int64    `json:"sequence"`
+		ID                    string   `json:"id"`
+		TenantID              *string  `json:"tenant_id,omitempty"`
+		PersonID              *string  `json:"person_id"`
+		AccessControlDeviceID *string  `json:"access_control_device_id"`
+		Type                  string   `json:"type"`
+		Result                string   `json:"result"`
+		AccessTime            string   `json:"access_time"`
+		CardNumber            *string  `json:"card_number"`
+		PlateText             *string  `json:"plate_text"`
+		PlateConfidence       *float64 `json:"plate_confidence"`
+		PlateImagePath        *string  `json:"plate_image_path"`
+		Reason                *string  `json:"reason"`
+		Factors               *string  `json:"factors"`
+		ScanSessionID         *string  `json:"scan_session_id"`
+	}{
+		ChainVersion:          chainVersion,
+		Sequence:              a.Sequence,
+		ID:                    a.ID.String(),
+		TenantID:              tenantID,
+		PersonID:              a.PersonID,
+		AccessControlDeviceID: a.AccessControlDeviceID,
+		Type:                  a.Type,
+		Result:                a.Result,
+		AccessTime:            a.AccessTime.UTC().Format(time.RFC3339Nano),
+		CardNumber:            a.CardNumber,
+		PlateText:             a.PlateText,
+		PlateConfidence:       a.PlateConfidence,
+		PlateImagePath:        a.PlateImagePath,
+		Reason:                a.Reason,
+		Factors:               a.Factors,
+		ScanSessionID:         a.ScanSessionID,
+	})
+	return payload
+}
+
+// AccessRecordAnnotation corrects or explains an access record without changing it. Field and
+// Value give the corrected value of one field when the annotation is a correction.
+type AccessRecordAnnotation struct {
+	BaseModel
+	TenantScoped
+	AccessRecordID string  `json:"access_record_id" gorm:"index"`
+	Field          *string `json:"field"`
+	Value          *string `json:"value"`
+	Note           string  `json:"note"`
+	CreatedBy      string  `json:"created_by"`
+}
+
+// AccessRecordChainCheckpoint is the last access record purged from the start of the hash chain
+// by the retention job. Verification continues the chain from its Sequence and Hash.
+type AccessRecordChainCheckpoint struct {
+	BaseModel
+	Sequence       int64   `json:"sequence" gorm:"uniqueIndex"`
+	Hash           string  `json:"hash"`
+	RetentionRunID *string `json:"retention_run_id"`
 }
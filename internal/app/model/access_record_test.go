@@ -0,0 +1,87 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func newHashedAccessRecord(chainVersion int) *AccessRecord {
+	personID := "person-1"
+	deviceID := "device-1"
+	return &AccessRecord{
+		BaseModel:             BaseModel{ID: uuid.MustParse("6f1c1a52-4f0e-4e44-9d5e-2d0c1f0b7a11")},
+		TenantScoped:          TenantScoped{TenantID: "tenant-1"},
+		PersonID:              &personID,
+		AccessControlDeviceID: &deviceID,
+		Type:                  "in",
+		Result:                "success",
+		AccessTime:            time.Date(2026, 1, 2, 3, 4, 5, 600, time.UTC),
+		Sequence:              7,
+		ChainVersion:          chainVersion,
+	}
+}
+
+func TestHashPayloadFields(t *testing.T) {
+	tests := []struct {
+		name         string
+		chainVersion int
+		wantVersion  bool
+		wantTenant   bool
+	}{
+		{"version 1 leaves out the version and the tenant", 1, false, false},
+		{"version 2 covers the version and the tenant", 2, true, true},
+		{"version 3 covers the version and the tenant", 3, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var payload map[string]interface{}
+			if err := json.Unmarshal(newHashedAccessRecord(tt.chainVersion).HashPayload(), &payload); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := payload["chain_version"]; ok != tt.wantVersion {
+				t.Errorf("payload has chain_version = %v, want %v", ok, tt.wantVersion)
+			}
+			if _, ok := payload["tenant_id"]; ok != tt.wantTenant {
+				t.Errorf("payload has tenant_id = %v, want %v", ok, tt.wantTenant)
+			}
+			if payload["sequence"] != float64(7) || payload["access_time"] != "2026-01-02T03:04:05.0000006Z" {
+				t.Errorf("payload = %v, want sequence 7 and the access time in UTC", payload)
+			}
+		})
+	}
+}
+
+func TestHashPayloadChanges(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(record *AccessRecord)
+		want   bool
+	}{
+		{"result", func(record *AccessRecord) { record.Result = "failed" }, true},
+		{"person", func(record *AccessRecord) { record.PersonID = nil }, true},
+		{"sequence", func(record *AccessRecord) { record.Sequence++ }, true},
+		{"tenant", func(record *AccessRecord) { record.TenantID = "tenant-2" }, true},
+		{"chain version", func(record *AccessRecord) { record.ChainVersion = 2 }, true},
+		{"access time in another zone", func(record *AccessRecord) {
+			record.AccessTime = record.AccessTime.In(time.FixedZone("ICT", 7*60*60))
+		}, false},
+		{"hash fields", func(record *AccessRecord) {
+			record.PrevHash = "prev"
+			record.Hash = "hash"
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := newHashedAccessRecord(3)
+			before := record.HashPayload()
+			tt.change(record)
+			if changed := !bytes.Equal(before, record.HashPayload()); changed != tt.want {
+				t.Errorf("payload changed = %v, want %v", changed, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// AccessScanSession collects the credentials presented one after the other at a device
+// until a group's authentication mode is satisfied or the session expires.
+type AccessScanSession struct {
+	BaseModel
+	TenantScoped
+	AccessControlDeviceID string    `json:"access_control_device_id"`
+	Type                  string    `json:"type"`
+	Status                string    `json:"status" gorm:"default:open"`
+	ExpiresAt             time.Time `json:"expires_at"`
+	PersonID              *string   `json:"person_id"`
+	// Factors is a comma separated list of the factors presented by PersonID
+	Factors string `json:"factors"`
+	// FirstPersonID is the person waiting for a second person under the two-person rule
+	FirstPersonID *string    `json:"first_person_id"`
+	FirstPersonAt *time.Time `json:"first_person_at"`
+}
@@ -2,5 +2,10 @@ package model
 
 type Attendance struct {
 	BaseModel
+	TenantScoped
 	Name string `json:"name"`
+	// HolidayCalendarID marks the calendar's holidays as days off in attendance calculation
+	HolidayCalendarID *string `json:"holiday_calendar_id"`
+	// OvertimeRuleID enables overtime calculation with the rule's rounding and multipliers
+	OvertimeRuleID *string `json:"overtime_rule_id"`
 }
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// AttendanceCorrection is a manual clock-in or clock-out of a person for an attendance date,
+// used when a punch is missing or wrong. Once approved it replaces the clock-in or clock-out of
+// that date in attendance calculation; the access records themselves are never changed.
+type AttendanceCorrection struct {
+	BaseModel
+	TenantScoped
+	PersonID string `json:"person_id" gorm:"index"`
+	// Date is the attendance date the correction belongs to; Time can be on the next day for a night shift
+	Date        string    `json:"date"`
+	Type        string    `json:"type"`
+	Time        time.Time `json:"time"`
+	Reason      string    `json:"reason"`
+	Status      string    `json:"status" gorm:"default:pending"`
+	RequestedBy *string   `json:"requested_by"`
+	// DecidedByUserID is the user who approved or rejected the correction
+	DecidedByUserID *string    `json:"decided_by_user_id"`
+	DecidedAt       *time.Time `json:"decided_at"`
+	DecisionNote    *string    `json:"decision_note"`
+}
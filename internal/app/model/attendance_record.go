@@ -1,10 +1,49 @@
 package model
 
+import "time"
+
+// AttendanceRecord is the calculated attendance of a person on one date.
 type AttendanceRecord struct {
 	BaseModel
-	PersonID             string `json:"person_id"`
+	TenantScoped
+	PersonID             string `json:"person_id" gorm:"index:idx_attendance_record_person_date"`
 	AttendanceScheduleID string `json:"attendance_schedule_id"`
-	AccessRecordId       string `json:"access_record_id"`
-	Date                 string `json:"date"`
-	Status               string `json:"status"`
+	// AccessRecordId is the access record used as clock-in
+	AccessRecordId string `json:"access_record_id"`
+	Date           string `json:"date" gorm:"index:idx_attendance_record_person_date"`
+	Status         string `json:"status"`
+	// HolidayID is set when the date is a holiday of the person's attendance profile
+	HolidayID   *string `json:"holiday_id"`
+	HolidayName *string `json:"holiday_name"`
+	// ShiftTemplateID is set when the day was planned from a shift rotation or override
+	ShiftTemplateID *string `json:"shift_template_id"`
+	ShiftName       *string `json:"shift_name"`
+	// LeaveRequestID is set when approved leave covers the date, LeaveDays is 0.5 for half-day leave
+	LeaveRequestID *string `json:"leave_request_id"`
+	LeaveTypeName  *string `json:"leave_type_name"`
+	LeaveDays      float64 `json:"leave_days"`
+	// Expected hours of the day, from the shift, the schedule or the holiday's special hours.
+	// EndsNextDay marks a shift that crosses midnight.
+	ScheduleStartTime      *string    `json:"schedule_start_time"`
+	ScheduleEndTime        *string    `json:"schedule_end_time"`
+	EndsNextDay            bool       `json:"ends_next_day"`
+	CheckInAt              *time.Time `json:"check_in_at"`
+	CheckOutAt             *time.Time `json:"check_out_at"`
+	CheckOutAccessRecordID *string    `json:"check_out_access_record_id"`
+	// Set when an approved attendance correction gave the clock-in or clock-out
+	CheckInCorrectionID  *string `json:"check_in_correction_id"`
+	CheckOutCorrectionID *string `json:"check_out_correction_id"`
+	LateMinutes          int     `json:"late_minutes"`
+	EarlyLeaveMinutes    int     `json:"early_leave_minutes"`
+	WorkedMinutes        int     `json:"worked_minutes"`
+	// Overtime from the overtime rule of the attendance profile, OvertimeStatus is empty when there
+	// is none. A decision is kept across recalculation while OvertimeMinutes stays the same.
+	OvertimeMinutes         int        `json:"overtime_minutes"`
+	OvertimeDayType         *string    `json:"overtime_day_type"`
+	OvertimeMultiplier      float64    `json:"overtime_multiplier"`
+	OvertimeStatus          *string    `json:"overtime_status"`
+	ApprovedOvertimeMinutes int        `json:"approved_overtime_minutes"`
+	OvertimeDecidedByUserID *string    `json:"overtime_decided_by_user_id"`
+	OvertimeDecidedAt       *time.Time `json:"overtime_decided_at"`
+	OvertimeDecisionNote    *string    `json:"overtime_decision_note"`
 }
@@ -2,6 +2,7 @@ package model
 
 type AttendanceSchedule struct {
 	BaseModel
+	TenantScoped
 	AttendanceID    string  `json:"attendance_id"`
 	DayOfWeek       int     `json:"day_of_week"`
 	Date            *string `json:"date"`
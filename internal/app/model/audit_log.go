@@ -0,0 +1,14 @@
+package model
+
+// AuditLog records an action that changed or removed data outside the normal CRUD flow, such as a
+// retention purge. Username is "system" for scheduled jobs. Entries of jobs running for every tenant
+// have no TenantID.
+type AuditLog struct {
+	BaseModel
+	TenantID   *string `json:"tenant_id" gorm:"index"`
+	Action     string  `json:"action" gorm:"index"`
+	EntityType string  `json:"entity_type"`
+	EntityID   *string `json:"entity_id" gorm:"index"`
+	Username   string  `json:"username"`
+	Detail     *string `json:"detail"`
+}
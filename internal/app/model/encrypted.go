@@ -0,0 +1,69 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/putteror/access-control-management/internal/app/common"
+	"gorm.io/gorm/schema"
+)
+
+func init() {
+	schema.RegisterSerializer("encrypted", EncryptedSerializer{})
+}
+
+// EncryptedSerializer encrypts string and *string fields tagged `gorm:"serializer:encrypted"` at
+// rest. Queries cannot compare encrypted columns, lookups use a blind index column instead.
+type EncryptedSerializer struct{}
+
+// Scan decrypts a column into the field.
+func (EncryptedSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	fieldValue := reflect.New(field.FieldType)
+
+	if dbValue != nil {
+		var stored string
+		switch v := dbValue.(type) {
+		case []byte:
+			stored = string(v)
+		case string:
+			stored = v
+		default:
+			return fmt.Errorf("unsupported value %T for encrypted field %s", dbValue, field.Name)
+		}
+		plain, err := common.DecryptValue(stored)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt field %s: %w", field.Name, err)
+		}
+		if field.FieldType.Kind() == reflect.Ptr {
+			fieldValue.Elem().Set(reflect.ValueOf(&plain))
+		} else {
+			fieldValue.Elem().SetString(plain)
+		}
+	}
+
+	field.ReflectValueOf(ctx, dst).Set(fieldValue.Elem())
+	return nil
+}
+
+// Value encrypts the field for the column. Nil and empty values are stored as they are.
+func (EncryptedSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	var plain string
+	switch v := fieldValue.(type) {
+	case nil:
+		return nil, nil
+	case *string:
+		if v == nil {
+			return nil, nil
+		}
+		plain = *v
+	case string:
+		plain = v
+	default:
+		return nil, fmt.Errorf("unsupported type %T for encrypted field %s", fieldValue, field.Name)
+	}
+	if plain == "" {
+		return "", nil
+	}
+	return common.EncryptValue(plain)
+}
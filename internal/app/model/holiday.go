@@ -0,0 +1,14 @@
+package model
+
+// Holiday is a date range of a holiday calendar, both ends inclusive ("2006-01-02").
+// Without StartTime/EndTime the whole day is closed, otherwise only those special hours apply.
+type Holiday struct {
+	BaseModel
+	TenantScoped
+	HolidayCalendarID string  `json:"holiday_calendar_id"`
+	Name              string  `json:"name"`
+	StartDate         string  `json:"start_date"`
+	EndDate           string  `json:"end_date"`
+	StartTime         *string `json:"start_time"`
+	EndTime           *string `json:"end_time"`
+}
@@ -0,0 +1,10 @@
+package model
+
+// HolidayCalendar is a named set of holidays (e.g. Thai public holidays) attached to
+// access control groups and attendance profiles.
+type HolidayCalendar struct {
+	BaseModel
+	TenantScoped
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+}
@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+// LeaveRequest is a request of a person to be absent from StartDate to EndDate ("2006-01-02",
+// both inclusive). A half-day request covers the morning or afternoon of a single date.
+type LeaveRequest struct {
+	BaseModel
+	TenantScoped
+	PersonID    string  `json:"person_id" gorm:"index"`
+	LeaveTypeID string  `json:"leave_type_id" gorm:"index"`
+	StartDate   string  `json:"start_date"`
+	EndDate     string  `json:"end_date"`
+	HalfDay     *string `json:"half_day"`
+	// Days is the number of working days the request takes from the balances, see
+	// LeaveRequestYear for the days of each year
+	Days        float64 `json:"days"`
+	Reason      *string `json:"reason"`
+	Status      string  `json:"status" gorm:"default:pending"`
+	RequestedBy *string `json:"requested_by"`
+	// DecidedByUserID is the user who approved or rejected the request
+	DecidedByUserID *string    `json:"decided_by_user_id"`
+	DecidedAt       *time.Time `json:"decided_at"`
+	DecisionNote    *string    `json:"decision_note"`
+}
+
+// LeaveRequestYear is the part of the days of a leave request that falls in a calendar year. A
+// leave over New Year takes its days from the balance of each year.
+type LeaveRequestYear struct {
+	BaseModel
+	TenantScoped
+	LeaveRequestID string  `json:"leave_request_id" gorm:"index"`
+	Year           int     `json:"year"`
+	Days           float64 `json:"days"`
+}
+
+// LeaveBalance is the entitlement of a person for a leave type in a year, replacing the
+// leave type's DaysPerYear.
+type LeaveBalance struct {
+	BaseModel
+	TenantScoped
+	PersonID     string  `json:"person_id" gorm:"index"`
+	LeaveTypeID  string  `json:"leave_type_id"`
+	Year         int     `json:"year"`
+	EntitledDays float64 `json:"entitled_days"`
+}
@@ -0,0 +1,12 @@
+package model
+
+// LeaveType is a kind of leave such as sick, annual or business leave. DaysPerYear is the
+// default yearly entitlement of every person, overridable per person by a LeaveBalance.
+type LeaveType struct {
+	BaseModel
+	TenantScoped
+	Name         string  `json:"name"`
+	Description  *string `json:"description"`
+	DaysPerYear  float64 `json:"days_per_year"`
+	AllowHalfDay bool    `json:"allow_half_day" gorm:"default:true"`
+}
@@ -0,0 +1,14 @@
+package model
+
+// Location is a place in the site, building, floor, zone and door hierarchy that devices are
+// installed at. Root locations have no ParentID. TimeZone applies to the devices at the location
+// and below it, a location without one uses the time zone of its parent.
+type Location struct {
+	BaseModel
+	TenantScoped
+	Name        string  `json:"name"`
+	Type        string  `json:"type"`
+	ParentID    *string `json:"parent_id" gorm:"index"`
+	Description *string `json:"description"`
+	TimeZone    *string `json:"time_zone"`
+}
@@ -0,0 +1,18 @@
+package model
+
+// OvertimeRule turns the time worked beyond the schedule into overtime. Overtime shorter than
+// MinimumMinutes is dropped, the rest is rounded down to RoundingMinutes and capped at
+// DailyCapMinutes (0 = no cap). The multiplier depends on whether the date is a weekday, one of
+// WeekendDays (comma separated, 1 = Monday ... 7 = Sunday) or a holiday.
+type OvertimeRule struct {
+	BaseModel
+	TenantScoped
+	Name              string  `json:"name"`
+	MinimumMinutes    int     `json:"minimum_minutes"`
+	RoundingMinutes   int     `json:"rounding_minutes"`
+	DailyCapMinutes   int     `json:"daily_cap_minutes"`
+	WeekendDays       string  `json:"weekend_days" gorm:"default:'6,7'"`
+	WeekdayMultiplier float64 `json:"weekday_multiplier" gorm:"default:1.5"`
+	WeekendMultiplier float64 `json:"weekend_multiplier" gorm:"default:2"`
+	HolidayMultiplier float64 `json:"holiday_multiplier" gorm:"default:3"`
+}
@@ -0,0 +1,68 @@
+package model
+
+import "time"
+
+// PayrollExportTemplate is the layout of the payroll input file of a company. Templates without a
+// Company can be used for any company.
+type PayrollExportTemplate struct {
+	BaseModel
+	TenantScoped
+	Name    string  `json:"name"`
+	Company *string `json:"company"`
+	// Format is "csv" or "fixed_width", Delimiter is used by CSV files only
+	Format        string `json:"format"`
+	Delimiter     string `json:"delimiter" gorm:"default:','"`
+	DateFormat    string `json:"date_format" gorm:"default:'YYYY-MM-DD'"`
+	IncludeHeader bool   `json:"include_header"`
+}
+
+// PayrollExportColumn is one column of a payroll export template, in Position order.
+type PayrollExportColumn struct {
+	BaseModel
+	TenantScoped
+	TemplateID string `json:"template_id" gorm:"index"`
+	Position   int    `json:"position"`
+	Field      string `json:"field"`
+	Header     string `json:"header"`
+	// Width and Align lay out fixed-width files
+	Width int    `json:"width"`
+	Align string `json:"align"`
+}
+
+// PayrollExport is a generated payroll file of a period. Once locked, later changes to the
+// attendance of the period set ChangedAfterLock instead of going unnoticed.
+type PayrollExport struct {
+	BaseModel
+	TenantScoped
+	TemplateID       string     `json:"template_id"`
+	TemplateName     string     `json:"template_name"`
+	Company          *string    `json:"company"`
+	StartDate        string     `json:"start_date"`
+	EndDate          string     `json:"end_date"`
+	FileName         string     `json:"file_name"`
+	FilePath         string     `json:"file_path"`
+	Rows             int        `json:"rows"`
+	Status           string     `json:"status" gorm:"default:'open'"`
+	GeneratedBy      string     `json:"generated_by"`
+	LockedByUserID   *string    `json:"locked_by_user_id"`
+	LockedAt         *time.Time `json:"locked_at"`
+	ChangedAfterLock bool       `json:"changed_after_lock"`
+	ChangedAt        *time.Time `json:"changed_at"`
+}
+
+// PayrollExportLine keeps the exported totals of one person, to compare with the attendance
+// after the export was generated.
+type PayrollExportLine struct {
+	BaseModel
+	TenantScoped
+	ExportID              string  `json:"export_id" gorm:"index"`
+	PersonID              string  `json:"person_id" gorm:"index"`
+	WorkedDays            int     `json:"worked_days"`
+	WorkedMinutes         int     `json:"worked_minutes"`
+	AbsentDays            int     `json:"absent_days"`
+	LateMinutes           int     `json:"late_minutes"`
+	EarlyLeaveMinutes     int     `json:"early_leave_minutes"`
+	LeaveDays             float64 `json:"leave_days"`
+	OvertimeMinutes       int     `json:"overtime_minutes"`
+	WeightedOvertimeHours float64 `json:"weighted_overtime_hours"`
+}
@@ -4,23 +4,37 @@ import "time"
 
 type Person struct {
 	BaseModel
-	FirstName           string     `json:"first_name"`
-	MiddleName          *string    `json:"middle_name"`
-	LastName            string     `json:"last_name"`
-	PersonType          string     `json:"person_type"`
-	PersonID            *string    `json:"person_id"`
-	Gender              *string    `json:"gender"`
-	DateOfBirth         *time.Time `json:"date_of_birth"`
-	Company             *string    `json:"company"`
-	Department          *string    `json:"department"`
-	JobPosition         *string    `json:"job_position"`
-	Address             *string    `json:"address"`
-	MobileNumber        *string    `json:"mobile_number"`
-	Email               *string    `json:"email"`
-	FaceImagePath       *string    `json:"face_image_path"`
+	TenantScoped
+	FirstName               string     `json:"first_name"`
+	MiddleName              *string    `json:"middle_name"`
+	LastName                string     `json:"last_name"`
+	PersonType              string     `json:"person_type"`
+	PersonID                *string    `json:"person_id" gorm:"serializer:encrypted"`
+	Gender                  *string    `json:"gender"`
+	DateOfBirth             *time.Time `json:"date_of_birth"`
+	Company                 *string    `json:"company"`
+	Department              *string    `json:"department"`
+	JobPosition             *string    `json:"job_position"`
+	Address                 *string    `json:"address" gorm:"serializer:encrypted"`
+	MobileNumber            *string    `json:"mobile_number" gorm:"serializer:encrypted"`
+	Email                   *string    `json:"email" gorm:"serializer:encrypted"`
+	FaceImagePath           *string    `json:"face_image_path"`
+	FaceImageNormalizedPath *string    `json:"face_image_normalized_path"`
+	FaceImageThumbnailPath  *string    `json:"face_image_thumbnail_path"`
+	PINHash                 *string    `json:"-"`
+	// PINFailedAttempts counts the wrong PINs entered in a row, PINLockedUntil is set once there
+	// were too many
+	PINFailedAttempts   int        `json:"-" gorm:"not null;default:0"`
+	PINLockedUntil      *time.Time `json:"pin_locked_until"`
 	IsVerified          bool       `json:"is_verified" gorm:"default:false"`
 	ActiveAt            *time.Time `json:"active_at"`
 	ExpireAt            *time.Time `json:"expire_at"`
 	AccessControlRuleID *string    `json:"rule_id"`
 	TimeAttendanceID    *string    `json:"time_attendance_id"`
+	// Blind indexes of the encrypted PersonID, Email and MobileNumber for exact lookups
+	PersonIDIndex     *string `json:"-" gorm:"index"`
+	EmailIndex        *string `json:"-" gorm:"index"`
+	MobileNumberIndex *string `json:"-" gorm:"index"`
+	// ErasedAt is set once the personal data of the person was erased on request
+	ErasedAt *time.Time `json:"erased_at"`
 }
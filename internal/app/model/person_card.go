@@ -4,8 +4,11 @@ import "time"
 
 type PersonCard struct {
 	BaseModel
-	CardNumber string    `json:"card_number" gorm:"unique"`
-	PersonID   string    `json:"person_id"` // FK to people table
-	ActiveAt   time.Time `json:"active_at"`
-	ExpireAt   time.Time `json:"expire_at"`
+	TenantID   string     `json:"tenant_id" gorm:"uniqueIndex:idx_person_cards_tenant_card_number"`
+	CardNumber string     `json:"card_number" gorm:"uniqueIndex:idx_person_cards_tenant_card_number"`
+	PersonID   string     `json:"person_id"` // FK to people table
+	Status     string     `json:"status" gorm:"default:active"`
+	Reason     *string    `json:"reason"`
+	ActiveAt   *time.Time `json:"active_at"`
+	ExpireAt   *time.Time `json:"expire_at"`
 }
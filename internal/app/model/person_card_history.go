@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// PersonCardHistory is one entry of the issuance history of a card.
+// Card number and person are copied so the entry stays readable after the card is removed.
+type PersonCardHistory struct {
+	BaseModel
+	TenantScoped
+	PersonCardID string     `json:"person_card_id" gorm:"index"`
+	PersonID     string     `json:"person_id" gorm:"index"`
+	CardNumber   string     `json:"card_number"`
+	Action       string     `json:"action"`
+	Status       string     `json:"status"`
+	Reason       *string    `json:"reason"`
+	ActiveAt     *time.Time `json:"active_at"`
+	ExpireAt     *time.Time `json:"expire_at"`
+}
@@ -2,6 +2,9 @@ package model
 
 type PersonLicensePlate struct {
 	BaseModel
-	LicensePlateText string `json:"license_plate_text" gorm:"unique"`
-	PersonID         string `json:"person_id"` // FK to people table
+	TenantID         string `json:"tenant_id" gorm:"uniqueIndex:idx_person_license_plates_tenant_text;index:idx_person_license_plates_tenant_normalized"`
+	LicensePlateText string `json:"license_plate_text" gorm:"uniqueIndex:idx_person_license_plates_tenant_text"`
+	// NormalizedPlateText is the comparison key of the plate, see common.NormalizeLicensePlate
+	NormalizedPlateText string `json:"normalized_plate_text" gorm:"index:idx_person_license_plates_tenant_normalized"`
+	PersonID            string `json:"person_id"` // FK to people table
 }
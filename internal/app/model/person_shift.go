@@ -0,0 +1,23 @@
+package model
+
+// PersonShiftAssignment puts a person on a shift rotation for a date range. An empty EndDate
+// means until further notice.
+type PersonShiftAssignment struct {
+	BaseModel
+	TenantScoped
+	PersonID        string  `json:"person_id" gorm:"index"`
+	ShiftRotationID string  `json:"shift_rotation_id"`
+	StartDate       string  `json:"start_date"`
+	EndDate         *string `json:"end_date"`
+}
+
+// PersonShiftOverride replaces the planned shift of a person on one date. Without a shift
+// template the date is a day off.
+type PersonShiftOverride struct {
+	BaseModel
+	TenantScoped
+	PersonID        string  `json:"person_id" gorm:"index"`
+	Date            string  `json:"date"`
+	ShiftTemplateID *string `json:"shift_template_id"`
+	Reason          *string `json:"reason"`
+}
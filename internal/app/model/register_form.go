@@ -2,5 +2,6 @@ package model
 
 type RegisterForm struct {
 	BaseModel
+	TenantScoped
 	Name string `json:"name"`
 }
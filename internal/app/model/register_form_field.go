@@ -2,6 +2,7 @@ package model
 
 type RegisterFormField struct {
 	BaseModel
+	TenantScoped
 	Name           string `json:"name"`
 	RegisterFormID string `json:"register_form_id"`
 	FieldType      string `json:"field_type"`
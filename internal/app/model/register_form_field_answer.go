@@ -2,6 +2,7 @@ package model
 
 type RegisterFormFieldAnswer struct {
 	BaseModel
+	TenantScoped
 	Name                string `json:"name"`
 	RegisterFormID      string `json:"register_form_id"`
 	RegisterFormFieldID string `json:"register_form_field_id"`
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// RetentionRun is one run of the retention job. Its items report what was purged.
+type RetentionRun struct {
+	BaseModel
+	// Trigger is "schedule" or "manual", TriggeredBy is the user of a manual run
+	Trigger     string     `json:"trigger"`
+	TriggeredBy *string    `json:"triggered_by"`
+	Status      string     `json:"status" gorm:"default:'running'"`
+	StartedAt   time.Time  `json:"started_at" gorm:"index"`
+	FinishedAt  *time.Time `json:"finished_at"`
+	Error       *string    `json:"error"`
+}
+
+// RetentionRunItem is what a run purged of one data class. Data older than Cutoff was purged,
+// ArchivePath is the NDJSON archive of the purged rows when the data class is archived.
+type RetentionRunItem struct {
+	BaseModel
+	RunID       string    `json:"run_id" gorm:"index"`
+	DataClass   string    `json:"data_class"`
+	Cutoff      time.Time `json:"cutoff"`
+	Purged      int       `json:"purged"`
+	ArchivePath *string   `json:"archive_path"`
+	Detail      *string   `json:"detail"`
+}
@@ -0,0 +1,21 @@
+package model
+
+// ShiftRotation is a repeating cycle of shifts and days off, e.g. 4-on-4-off, anchored on
+// AnchorDate ("2006-01-02") which is day 0 of the cycle.
+type ShiftRotation struct {
+	BaseModel
+	TenantScoped
+	Name       string `json:"name"`
+	AnchorDate string `json:"anchor_date"`
+	CycleDays  int    `json:"cycle_days"`
+}
+
+// ShiftRotationDay is the shift worked on one day of a rotation cycle. Days of the cycle
+// without a row are days off.
+type ShiftRotationDay struct {
+	BaseModel
+	TenantScoped
+	ShiftRotationID string `json:"shift_rotation_id"`
+	DayIndex        int    `json:"day_index"`
+	ShiftTemplateID string `json:"shift_template_id"`
+}
@@ -0,0 +1,15 @@
+package model
+
+// ShiftTemplate is a named working shift. An EndTime at or before StartTime means the shift
+// crosses midnight and ends on the next day (e.g. 22:00:00 - 06:00:00).
+type ShiftTemplate struct {
+	BaseModel
+	TenantScoped
+	Name            string `json:"name"`
+	StartTime       string `json:"start_time"`
+	EndTime         string `json:"end_time"`
+	EarlyInMinutes  int    `json:"early_in_minutes"`
+	LateInMinutes   int    `json:"late_in_minutes"`
+	EarlyOutMinutes int    `json:"early_out_minutes"`
+	LateOutMinutes  int    `json:"late_out_minutes"`
+}
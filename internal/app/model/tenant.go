@@ -0,0 +1,16 @@
+package model
+
+// Tenant is a client company or site sharing the deployment. Every tenant only sees its own data,
+// see TenantScoped.
+type Tenant struct {
+	BaseModel
+	Name     string `json:"name"`
+	Code     string `json:"code" gorm:"unique"`
+	IsActive bool   `json:"is_active"`
+}
+
+// TenantScoped is embedded by every model that belongs to a tenant. The database layer sets
+// TenantID on create and filters every query by it, so repositories never handle it themselves.
+type TenantScoped struct {
+	TenantID string `json:"tenant_id" gorm:"index"`
+}
@@ -2,9 +2,13 @@ package model
 
 type User struct {
 	BaseModel
+	TenantScoped
+	// Username is unique across tenants, users log in before their tenant is known
 	Username     string         `json:"username" gorm:"unique"`
 	PasswordHash string         `json:"-"`
 	PermissionID string         // Foreign Key to UserPermission table
 	Permission   UserPermission `json:"permission" gorm:"foreignKey:PermissionID"`
 	Status       string         `json:"status"`
+	// IsSuperAdmin allows managing tenants and switching between them
+	IsSuperAdmin bool `json:"is_super_admin" gorm:"default:false"`
 }
@@ -2,6 +2,7 @@ package model
 
 type UserPermission struct {
 	BaseModel
+	TenantScoped
 	PeoplePermission         bool `json:"people_permission"`
 	DevicePermission         bool `json:"device_permission"`
 	RulePermission           bool `json:"rule_permission"`
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// VisitorVehicle is a vehicle seen by a license plate camera that is not registered to any person.
+type VisitorVehicle struct {
+	BaseModel
+	TenantID              string    `json:"tenant_id" gorm:"uniqueIndex:idx_visitor_vehicles_tenant_plate"`
+	PlateText             string    `json:"plate_text"`
+	NormalizedPlateText   string    `json:"normalized_plate_text" gorm:"uniqueIndex:idx_visitor_vehicles_tenant_plate"`
+	FirstSeenAt           time.Time `json:"first_seen_at"`
+	LastSeenAt            time.Time `json:"last_seen_at"`
+	SeenCount             int       `json:"seen_count"`
+	AccessControlDeviceID *string   `json:"access_control_device_id"`
+	LastImagePath         *string   `json:"last_image_path"`
+}
@@ -18,6 +18,8 @@ type AccessControlDeviceRepository interface {
 	Delete(id uuid.UUID) error
 	IsExistName(name string, excludeID uuid.UUID) (bool, error)
 	IsExistHostAddress(hostAddress string, excludeID uuid.UUID) (bool, error)
+	GetByLocationIDs(locationIDs []string) ([]model.AccessControlDevice, error)
+	ReEncrypt(prefix string) (int, error)
 }
 
 // accessControlDeviceRepositoryImpl is the implementation of AccessControlDeviceRepository.
@@ -48,6 +50,14 @@ func (r *accessControlDeviceRepositoryImpl) GetAll(searchQuery schema.AccessCont
 		query = query.Where("host_address ILIKE ?", "%"+searchQuery.HostAddress+"%")
 	}
 
+	if searchQuery.LocationID != "" {
+		locationIDs, err := locationSubtreeIDs(r.db, []string{searchQuery.LocationID})
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("location_id IN ?", locationIDs)
+	}
+
 	var page int = searchQuery.Page
 	var limit int = searchQuery.Limit
 	offset := (page - 1) * limit
@@ -69,12 +79,12 @@ func (r *accessControlDeviceRepositoryImpl) GetByID(id uuid.UUID) (*model.Access
 
 // Create creates a new access control device record.
 func (r *accessControlDeviceRepositoryImpl) Create(device *model.AccessControlDevice) error {
-	return r.db.Create(device).Error
+	return translateError(r.db.Create(device).Error)
 }
 
 // Update updates an existing access control device record.
 func (r *accessControlDeviceRepositoryImpl) Update(device *model.AccessControlDevice) error {
-	return r.db.Save(device).Error
+	return translateError(r.db.Save(device).Error)
 }
 
 // Delete deletes an access control device by its ID.
@@ -107,3 +117,22 @@ func (r *accessControlDeviceRepositoryImpl) IsExistHostAddress(hostAddress strin
 	}
 	return count > 0, nil
 }
+
+// GetByLocationIDs retrieves the devices placed directly at the given locations.
+func (r *accessControlDeviceRepositoryImpl) GetByLocationIDs(locationIDs []string) ([]model.AccessControlDevice, error) {
+	var devices []model.AccessControlDevice
+	if len(locationIDs) == 0 {
+		return devices, nil
+	}
+	if err := r.db.Where("location_id IN ?", locationIDs).Order("name").Find(&devices).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve devices by location: %w", err)
+	}
+	return devices, nil
+}
+
+// ReEncrypt rewrites the devices whose credentials are not encrypted with the key of prefix and
+// returns how many were rewritten.
+func (r *accessControlDeviceRepositoryImpl) ReEncrypt(prefix string) (int, error) {
+	columns := []string{"password", "access_token", "api_token"}
+	return reEncryptRows[model.AccessControlDevice](r.db, prefix, columns, nil)
+}
@@ -23,6 +23,12 @@ type AccessControlGroupRepository interface {
 	GetDeviceIDsByGroupID(groupID uuid.UUID) ([]string, error)
 	CreateGroupDevices(groupDevices []model.AccessControlGroupDevice) error
 	DeleteGroupDevicesByGroupID(groupID uuid.UUID, tx *gorm.DB) error
+	GetMemberDeviceIDsByGroupID(groupID uuid.UUID) ([]string, error)
+
+	// Location relationship methods
+	GetLocationIDsByGroupID(groupID uuid.UUID) ([]string, error)
+	CreateGroupLocations(groupLocations []model.AccessControlGroupLocation) error
+	DeleteGroupLocationsByGroupID(groupID uuid.UUID, tx *gorm.DB) error
 	GetAccessControlGroupScheduleByGroupID(groupID string) ([]model.AccessControlGroupSchedule, error)
 	CreateAccessControlGroupSchedule(groupSchedules []model.AccessControlGroupSchedule) error
 	DeleteAccessControlGroupScheduleByGroupID(groupID uuid.UUID, tx *gorm.DB) error
@@ -69,12 +75,12 @@ func (r *accessControlGroupRepositoryImpl) GetByID(id uuid.UUID) (*model.AccessC
 
 // Create creates a new access control group record.
 func (r *accessControlGroupRepositoryImpl) Create(group *model.AccessControlGroup) error {
-	return r.db.Create(group).Error
+	return translateError(r.db.Create(group).Error)
 }
 
 // Update updates an existing access control group record.
 func (r *accessControlGroupRepositoryImpl) Update(group *model.AccessControlGroup) error {
-	return r.db.Save(group).Error
+	return translateError(r.db.Save(group).Error)
 }
 
 // Delete deletes an access control group by its ID.
@@ -85,6 +91,9 @@ func (r *accessControlGroupRepositoryImpl) Delete(id uuid.UUID) error {
 		if err := r.DeleteGroupDevicesByGroupID(id, tx); err != nil {
 			return err
 		}
+		if err := r.DeleteGroupLocationsByGroupID(id, tx); err != nil {
+			return err
+		}
 		// 2. ลบข้อมูลจากตารางหลัก (AccessControlGroup)
 		if err := tx.Unscoped().Where("id = ?", id).Delete(&model.AccessControlGroup{}).Error; err != nil {
 			return err
@@ -131,7 +140,7 @@ func (r *accessControlGroupRepositoryImpl) CreateGroupDevices(groupDevices []mod
 	if len(groupDevices) == 0 {
 		return nil
 	}
-	return r.db.Create(&groupDevices).Error
+	return translateError(r.db.Create(&groupDevices).Error)
 }
 
 // DeleteGroupDevicesByGroupID deletes all AccessControlGroupDevice records for a group ID.
@@ -144,6 +153,70 @@ func (r *accessControlGroupRepositoryImpl) DeleteGroupDevicesByGroupID(groupID u
 		Delete(&model.AccessControlGroupDevice{}).Error
 }
 
+// GetMemberDeviceIDsByGroupID retrieves the IDs of the devices a group covers, the devices added
+// to it directly and the devices at its locations or below them.
+func (r *accessControlGroupRepositoryImpl) GetMemberDeviceIDsByGroupID(groupID uuid.UUID) ([]string, error) {
+	deviceIDs, err := r.GetDeviceIDsByGroupID(groupID)
+	if err != nil {
+		return nil, err
+	}
+	locationIDs, err := r.GetLocationIDsByGroupID(groupID)
+	if err != nil || len(locationIDs) == 0 {
+		return deviceIDs, err
+	}
+	subtreeIDs, err := locationSubtreeIDs(r.db, locationIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var locationDeviceIDs []string
+	if err := r.db.Model(&model.AccessControlDevice{}).
+		Where("location_id IN ?", subtreeIDs).
+		Pluck("id", &locationDeviceIDs).Error; err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(deviceIDs))
+	for _, id := range deviceIDs {
+		seen[id] = true
+	}
+	for _, id := range locationDeviceIDs {
+		if !seen[id] {
+			seen[id] = true
+			deviceIDs = append(deviceIDs, id)
+		}
+	}
+	return deviceIDs, nil
+}
+
+// --- Location Relationship Methods ---
+
+// GetLocationIDsByGroupID retrieves the IDs of the locations of a group.
+func (r *accessControlGroupRepositoryImpl) GetLocationIDsByGroupID(groupID uuid.UUID) ([]string, error) {
+	var locationIDs []string
+	err := r.db.Model(&model.AccessControlGroupLocation{}).
+		Select("location_id").
+		Where("access_control_group_id = ?", groupID).
+		Find(&locationIDs).Error
+	return locationIDs, err
+}
+
+// CreateGroupLocations inserts multiple AccessControlGroupLocation records.
+func (r *accessControlGroupRepositoryImpl) CreateGroupLocations(groupLocations []model.AccessControlGroupLocation) error {
+	if len(groupLocations) == 0 {
+		return nil
+	}
+	return translateError(r.db.Create(&groupLocations).Error)
+}
+
+// DeleteGroupLocationsByGroupID deletes all AccessControlGroupLocation records for a group ID.
+func (r *accessControlGroupRepositoryImpl) DeleteGroupLocationsByGroupID(groupID uuid.UUID, tx *gorm.DB) error {
+	if tx == nil {
+		tx = r.db
+	}
+	return tx.Unscoped().Where("access_control_group_id = ?", groupID).
+		Delete(&model.AccessControlGroupLocation{}).Error
+}
+
 // GetAccessControlGroupScheduleByGroupID
 func (r *accessControlGroupRepositoryImpl) GetAccessControlGroupScheduleByGroupID(groupID string) ([]model.AccessControlGroupSchedule, error) {
 	var groupSchedules []model.AccessControlGroupSchedule
@@ -156,7 +229,7 @@ func (r *accessControlGroupRepositoryImpl) CreateAccessControlGroupSchedule(grou
 	if len(groupSchedules) == 0 {
 		return nil
 	}
-	return r.db.Create(&groupSchedules).Error
+	return translateError(r.db.Create(&groupSchedules).Error)
 }
 
 // DeleteGroupSchecule
@@ -13,6 +13,7 @@ import (
 type AccessControlRuleRepository interface {
 	GetAll(searchQuery schema.AccessControlRuleSearchQuery) ([]model.AccessControlRule, error)
 	GetByID(id uuid.UUID) (*model.AccessControlRule, error)
+	GetByName(name string) (*model.AccessControlRule, error)
 	Create(rule *model.AccessControlRule) error
 	Update(rule *model.AccessControlRule) error
 	Delete(id uuid.UUID) error
@@ -72,14 +73,23 @@ func (r *accessControlRuleRepositoryImpl) GetByID(id uuid.UUID) (*model.AccessCo
 	return &rule, nil
 }
 
+// GetByName retrieves a rule by its name.
+func (r *accessControlRuleRepositoryImpl) GetByName(name string) (*model.AccessControlRule, error) {
+	var rule model.AccessControlRule
+	if err := r.db.First(&rule, "name = ?", name).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
 // Create creates a new access control rule record.
 func (r *accessControlRuleRepositoryImpl) Create(rule *model.AccessControlRule) error {
-	return r.db.Create(rule).Error
+	return translateError(r.db.Create(rule).Error)
 }
 
 // Update updates an existing access control rule record.
 func (r *accessControlRuleRepositoryImpl) Update(rule *model.AccessControlRule) error {
-	return r.db.Save(rule).Error
+	return translateError(r.db.Save(rule).Error)
 }
 
 // Delete deletes an access control rule by its ID.
@@ -139,7 +149,7 @@ func (r *accessControlRuleRepositoryImpl) CreateRuleGroups(ruleGroups []model.Ac
 		return nil
 	}
 	// ใช้ r.db โดยตรง (สมมติว่า Service Layer จะจัดการ Transaction)
-	return r.db.Create(&ruleGroups).Error
+	return translateError(r.db.Create(&ruleGroups).Error)
 }
 
 // DeleteRuleGroupsByRuleID deletes all AccessControlRuleGroup records for a rule ID.
@@ -18,6 +18,7 @@ type AccessControlServerRepository interface {
 	Delete(id uuid.UUID) error
 	IsExistName(name string, excludeID uuid.UUID) (bool, error)
 	IsExistHostAddress(hostAddress string, excludeID uuid.UUID) (bool, error)
+	ReEncrypt(prefix string) (int, error)
 }
 
 // accessControlServerRepositoryImpl is the implementation of AccessControlServerRepository.
@@ -69,12 +70,12 @@ func (r *accessControlServerRepositoryImpl) GetByID(id uuid.UUID) (*model.Access
 
 // Create creates a new access control server record.
 func (r *accessControlServerRepositoryImpl) Create(server *model.AccessControlServer) error {
-	return r.db.Create(server).Error
+	return translateError(r.db.Create(server).Error)
 }
 
 // Update updates an existing access control server record.
 func (r *accessControlServerRepositoryImpl) Update(server *model.AccessControlServer) error {
-	return r.db.Save(server).Error
+	return translateError(r.db.Save(server).Error)
 }
 
 // Delete deletes an access control server by its ID.
@@ -107,3 +108,10 @@ func (r *accessControlServerRepositoryImpl) IsExistHostAddress(hostAddress strin
 	}
 	return count > 0, nil
 }
+
+// ReEncrypt rewrites the servers whose credentials are not encrypted with the key of prefix and
+// returns how many were rewritten.
+func (r *accessControlServerRepositoryImpl) ReEncrypt(prefix string) (int, error) {
+	columns := []string{"password", "access_token", "api_token"}
+	return reEncryptRows[model.AccessControlServer](r.db, prefix, columns, nil)
+}
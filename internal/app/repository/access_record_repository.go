@@ -2,19 +2,45 @@ package repository
 
 import (
 	"fmt"
+	"log"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
 	"github.com/putteror/access-control-management/internal/app/model"
 	"github.com/putteror/access-control-management/internal/app/schema"
 	"gorm.io/gorm"
 )
 
+// AccessRecordRepository is append-only: access records are added to the hash chain and never
+// updated or deleted. Only the retention job removes records, from the start of the chain.
 type AccessRecordRepository interface {
 	GetAll(searchQuery schema.AccessRecordSearchQuery) ([]model.AccessRecord, error)
 	GetByID(id uuid.UUID) (*model.AccessRecord, error)
-	Create(accessRecord *model.AccessRecord) error
-	Update(accessRecord *model.AccessRecord) error
-	Delete(id uuid.UUID) error
+	GetByPersonID(personID string) ([]model.AccessRecord, error)
+	Append(accessRecord *model.AccessRecord) error
+	GetAttendancePunches(personID string, from time.Time, to time.Time) ([]model.AccessRecord, error)
+	GetLatestByPerson(since time.Time) ([]model.AccessRecord, error)
+	GetLastAttendancePunch(personID string, before time.Time) (*model.AccessRecord, error)
+	IsExistPlateImagePath(imagePath string) (bool, error)
+
+	// Hash chain methods
+	GetChain(afterSequence int64, limit int) ([]model.AccessRecord, error)
+	GetChainHashes(sequences []int64) (map[int64]string, error)
+	CountUnchained() (int64, error)
+	CountOutdated() (int64, error)
+	SealUnchained() (int, error)
+	ResealChain() (int, error)
+	GetChainCheckpoint() (*model.AccessRecordChainCheckpoint, error)
+
+	// Retention methods
+	GetPurgeBoundary(before time.Time) (int64, error)
+	PurgeChain(throughSequence int64, retentionRunID string) (int, error)
+
+	// Annotation methods
+	GetAnnotationsByRecordID(accessRecordID uuid.UUID) ([]model.AccessRecordAnnotation, error)
+	GetAnnotationsByRecordIDs(accessRecordIDs []string) ([]model.AccessRecordAnnotation, error)
+	CreateAnnotation(annotation *model.AccessRecordAnnotation) error
 }
 
 type AccessRecordRepositoryImpl struct {
@@ -30,6 +56,18 @@ func (r *AccessRecordRepositoryImpl) GetAll(searchQuery schema.AccessRecordSearc
 
 	query := r.db.Model(&model.AccessRecord{})
 
+	if searchQuery.LocationID != "" {
+		locationIDs, err := locationSubtreeIDs(r.db, []string{searchQuery.LocationID})
+		if err != nil {
+			return nil, err
+		}
+		var deviceIDs []string
+		if err := r.db.Model(&model.AccessControlDevice{}).Where("location_id IN ?", locationIDs).Pluck("id", &deviceIDs).Error; err != nil {
+			return nil, fmt.Errorf("failed to retrieve devices of location: %w", err)
+		}
+		query = query.Where("access_control_device_id IN ?", deviceIDs)
+	}
+
 	var page int = searchQuery.Page
 	var limit int = searchQuery.Limit
 	offset := (page - 1) * limit
@@ -48,14 +86,409 @@ func (r *AccessRecordRepositoryImpl) GetByID(id uuid.UUID) (*model.AccessRecord,
 	return &accessRecord, nil
 }
 
-func (r *AccessRecordRepositoryImpl) Create(accessRecord *model.AccessRecord) error {
-	return r.db.Create(accessRecord).Error
+// GetByPersonID retrieves every access record of a person, oldest first.
+func (r *AccessRecordRepositoryImpl) GetByPersonID(personID string) ([]model.AccessRecord, error) {
+	var accessRecords []model.AccessRecord
+	if err := r.db.Where("person_id = ?", personID).Order("access_time").Find(&accessRecords).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve access records of person: %w", err)
+	}
+	return accessRecords, nil
+}
+
+// IsExistPlateImagePath checks if an access record has an image as its plate snapshot.
+func (r *AccessRecordRepositoryImpl) IsExistPlateImagePath(imagePath string) (bool, error) {
+	var count int64
+	if err := r.db.Model(&model.AccessRecord{}).Where("plate_image_path = ?", imagePath).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check plate image path existence: %w", err)
+	}
+	return count > 0, nil
+}
+
+// Append adds an access record at the end of the hash chain. Appends are serialized by an
+// advisory lock so every record gets the next sequence and the hash of the record before it.
+func (r *AccessRecordRepositoryImpl) Append(accessRecord *model.AccessRecord) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", common.AccessRecordChainLockKey).Error; err != nil {
+			return fmt.Errorf("failed to lock access record chain: %w", err)
+		}
+		last, err := lastChainedAccessRecord(tx)
+		if err != nil {
+			return err
+		}
+
+		if accessRecord.ID == uuid.Nil {
+			accessRecord.ID = uuid.New()
+		}
+		// The database keeps microseconds, the hash must cover the stored value
+		accessRecord.AccessTime = accessRecord.AccessTime.Truncate(time.Microsecond)
+		// The hash covers the tenant, which the create callback would only set after hashing
+		if tenantID, allTenants, ok := common.TenantFromContext(tx.Statement.Context); ok && !allTenants {
+			accessRecord.TenantID = tenantID
+		}
+		chainAccessRecord(accessRecord, last)
+		return translateError(tx.Create(accessRecord).Error)
+	})
+}
+
+// GetAttendancePunches retrieves the successful access records of a person in [from, to) made at
+// devices that record attendance, oldest first.
+func (r *AccessRecordRepositoryImpl) GetAttendancePunches(personID string, from time.Time, to time.Time) ([]model.AccessRecord, error) {
+	var accessRecords []model.AccessRecord
+	err := r.db.Model(&model.AccessRecord{}).
+		Joins("JOIN access_control_devices ON access_control_devices.id::text = access_records.access_control_device_id AND access_control_devices.deleted_at IS NULL").
+		Where("access_records.person_id = ? AND access_records.result = ?", personID, "success").
+		Where("access_control_devices.record_attendance = ?", true).
+		Where("access_records.access_time >= ? AND access_records.access_time < ?", from, to).
+		Order("access_records.access_time").
+		Find(&accessRecords).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve attendance punches: %w", err)
+	}
+	return accessRecords, nil
+}
+
+// GetLastAttendancePunch retrieves the last attendance punch of a person before a time, nil when
+// the person never punched.
+func (r *AccessRecordRepositoryImpl) GetLastAttendancePunch(personID string, before time.Time) (*model.AccessRecord, error) {
+	var accessRecords []model.AccessRecord
+	err := r.db.Model(&model.AccessRecord{}).
+		Joins("JOIN access_control_devices ON access_control_devices.id::text = access_records.access_control_device_id AND access_control_devices.deleted_at IS NULL").
+		Where("access_records.person_id = ? AND access_records.result = ?", personID, "success").
+		Where("access_control_devices.record_attendance = ?", true).
+		Where("access_records.access_time < ?", before).
+		Order("access_records.access_time DESC").
+		Limit(1).
+		Find(&accessRecords).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve last attendance punch: %w", err)
+	}
+	if len(accessRecords) == 0 {
+		return nil, nil
+	}
+	return &accessRecords[0], nil
+}
+
+// GetLatestByPerson retrieves the last successful access record of every person since a time.
+func (r *AccessRecordRepositoryImpl) GetLatestByPerson(since time.Time) ([]model.AccessRecord, error) {
+	var accessRecords []model.AccessRecord
+	err := r.db.Model(&model.AccessRecord{}).
+		Select("DISTINCT ON (person_id) *").
+		Where("person_id IS NOT NULL AND result = ? AND access_time >= ?", "success", since).
+		Order("person_id, access_time DESC, sequence DESC").
+		Find(&accessRecords).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve latest access records: %w", err)
+	}
+	return accessRecords, nil
+}
+
+// GetChain retrieves chained access records after a sequence in chain order, including soft
+// deleted ones so verification can report them. The chain spans every tenant.
+func (r *AccessRecordRepositoryImpl) GetChain(afterSequence int64, limit int) ([]model.AccessRecord, error) {
+	var accessRecords []model.AccessRecord
+	err := r.db.Unscoped().Where("sequence > ?", afterSequence).Order("sequence").Order("id").Limit(limit).Find(&accessRecords).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve access record chain: %w", err)
+	}
+	return accessRecords, nil
+}
+
+// GetChainHashes returns the hashes of the chained access records at the given sequences, of
+// every tenant. Only the hashes are read, so a tenant can check the links of its records to the
+// records of other tenants before them.
+func (r *AccessRecordRepositoryImpl) GetChainHashes(sequences []int64) (map[int64]string, error) {
+	hashes := make(map[int64]string, len(sequences))
+	if len(sequences) == 0 {
+		return hashes, nil
+	}
+	var rows []struct {
+		Sequence int64
+		Hash     string
+	}
+	err := allTenants(r.db).Unscoped().Model(&model.AccessRecord{}).Select("sequence", "hash").Where("sequence IN ?", sequences).Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve access record chain hashes: %w", err)
+	}
+	for _, row := range rows {
+		hashes[row.Sequence] = row.Hash
+	}
+	return hashes, nil
 }
 
-func (r *AccessRecordRepositoryImpl) Update(accessRecord *model.AccessRecord) error {
-	return r.db.Save(accessRecord).Error
+// CountUnchained counts the access records that are not part of the hash chain.
+func (r *AccessRecordRepositoryImpl) CountUnchained() (int64, error) {
+	var count int64
+	if err := r.db.Unscoped().Model(&model.AccessRecord{}).Where("sequence = 0").Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count unchained access records: %w", err)
+	}
+	return count, nil
+}
+
+// CountOutdated counts the chained access records sealed with an older chain version.
+func (r *AccessRecordRepositoryImpl) CountOutdated() (int64, error) {
+	var count int64
+	err := r.db.Unscoped().Model(&model.AccessRecord{}).Where("sequence > 0 AND chain_version < ?", common.AccessRecordChainVersion).Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count outdated access records: %w", err)
+	}
+	return count, nil
 }
 
-func (r *AccessRecordRepositoryImpl) Delete(id uuid.UUID) error {
-	return r.db.Unscoped().Where("id = ?", id).Delete(&model.AccessRecord{}).Error
+// SealUnchained appends the access records stored before the hash chain existed to the chain,
+// oldest first, and returns how many were sealed. It runs once, in the migration that introduced
+// the current chain version; records added to the table directly later stay unchained and
+// verification reports them.
+func (r *AccessRecordRepositoryImpl) SealUnchained() (int, error) {
+	sealed := 0
+	err := allTenants(r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", common.AccessRecordChainLockKey).Error; err != nil {
+			return fmt.Errorf("failed to lock access record chain: %w", err)
+		}
+		last, err := lastChainedAccessRecord(tx)
+		if err != nil {
+			return err
+		}
+
+		for {
+			var accessRecords []model.AccessRecord
+			err := tx.Unscoped().Where("sequence = 0").Order("created_at").Order("id").Limit(common.AccessRecordVerifyBatchSize).Find(&accessRecords).Error
+			if err != nil {
+				return fmt.Errorf("failed to retrieve unchained access records: %w", err)
+			}
+			if len(accessRecords) == 0 {
+				return nil
+			}
+			for i := range accessRecords {
+				accessRecord := &accessRecords[i]
+				chainAccessRecord(accessRecord, last)
+				err := tx.Unscoped().Model(accessRecord).UpdateColumns(map[string]interface{}{
+					"sequence":      accessRecord.Sequence,
+					"chain_version": accessRecord.ChainVersion,
+					"prev_hash":     accessRecord.PrevHash,
+					"hash":          accessRecord.Hash,
+				}).Error
+				if err != nil {
+					return fmt.Errorf("failed to seal access record: %w", err)
+				}
+				last = accessRecord
+				sealed++
+			}
+		}
+	})
+	return sealed, err
+}
+
+// ResealChain rehashes the chain with the current chain version, from the first record of an
+// older version to the end of the chain, and returns how many records were re-sealed. Every record
+// is checked against its old hash and link first: the re-seal stops at the first record that does
+// not match, so tampering is never sealed over and verification keeps reporting it. It runs once,
+// in the migration that introduced the current chain version.
+func (r *AccessRecordRepositoryImpl) ResealChain() (int, error) {
+	resealed := 0
+	err := allTenants(r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", common.AccessRecordChainLockKey).Error; err != nil {
+			return fmt.Errorf("failed to lock access record chain: %w", err)
+		}
+		var firstOld []model.AccessRecord
+		err := tx.Unscoped().Where("sequence > 0 AND chain_version < ?", common.AccessRecordChainVersion).Order("sequence").Limit(1).Find(&firstOld).Error
+		if err != nil {
+			return fmt.Errorf("failed to retrieve access records of an old chain version: %w", err)
+		}
+		if len(firstOld) == 0 {
+			return nil
+		}
+
+		resealer := newChainResealer(&firstOld[0])
+		for {
+			var accessRecords []model.AccessRecord
+			err := tx.Unscoped().Where("sequence >= ?", resealer.expected).Order("sequence").Order("id").Limit(common.AccessRecordVerifyBatchSize).Find(&accessRecords).Error
+			if err != nil {
+				return fmt.Errorf("failed to retrieve access record chain: %w", err)
+			}
+			if len(accessRecords) == 0 {
+				return nil
+			}
+			for i := range accessRecords {
+				accessRecord := &accessRecords[i]
+				if !resealer.reseal(accessRecord) {
+					log.Printf("Access record chain is not intact at sequence %d, records from there on keep their hashes", resealer.expected)
+					return nil
+				}
+				err := tx.Unscoped().Model(accessRecord).UpdateColumns(map[string]interface{}{
+					"chain_version": accessRecord.ChainVersion,
+					"prev_hash":     accessRecord.PrevHash,
+					"hash":          accessRecord.Hash,
+				}).Error
+				if err != nil {
+					return fmt.Errorf("failed to re-seal access record: %w", err)
+				}
+				resealed++
+			}
+		}
+	})
+	return resealed, err
+}
+
+// chainResealer re-seals a chain in order from its first record of an old chain version.
+type chainResealer struct {
+	// oldPrevHash is the old hash of the last record, which the next record must link to
+	oldPrevHash string
+	// newPrevHash is the new hash of the last record, which the next record is linked to
+	newPrevHash string
+	expected    int64
+}
+
+// newChainResealer starts a re-seal at the first record of an old version. The record before it
+// keeps its hash, the chain is rehashed from its link on.
+func newChainResealer(firstOld *model.AccessRecord) *chainResealer {
+	return &chainResealer{
+		oldPrevHash: firstOld.PrevHash,
+		newPrevHash: firstOld.PrevHash,
+		expected:    firstOld.Sequence,
+	}
+}
+
+// reseal checks the next record of the chain against its old hash and link and seals it with the
+// current chain version. It returns false, leaving the record as it is, when the record does not
+// match.
+func (c *chainResealer) reseal(accessRecord *model.AccessRecord) bool {
+	if accessRecord.Sequence != c.expected || accessRecord.PrevHash != c.oldPrevHash ||
+		sealedHash(accessRecord) != accessRecord.Hash {
+		return false
+	}
+	c.oldPrevHash = accessRecord.Hash
+
+	accessRecord.ChainVersion = common.AccessRecordChainVersion
+	accessRecord.PrevHash = c.newPrevHash
+	accessRecord.Hash = common.ChainHash(accessRecord.PrevHash, accessRecord.HashPayload())
+	c.newPrevHash = accessRecord.Hash
+	c.expected++
+	return true
+}
+
+// sealedHash returns the hash a record was sealed with by its chain version.
+func sealedHash(accessRecord *model.AccessRecord) string {
+	if accessRecord.ChainVersion < 3 {
+		return common.LegacyChainHash(accessRecord.PrevHash, accessRecord.HashPayload())
+	}
+	return common.ChainHash(accessRecord.PrevHash, accessRecord.HashPayload())
+}
+
+// GetChainCheckpoint returns the latest retention checkpoint of the chain, nil when no records
+// were purged.
+func (r *AccessRecordRepositoryImpl) GetChainCheckpoint() (*model.AccessRecordChainCheckpoint, error) {
+	var checkpoints []model.AccessRecordChainCheckpoint
+	if err := r.db.Order("sequence DESC").Limit(1).Find(&checkpoints).Error; err != nil {
+		return nil, fmt.Errorf("failed to get access record chain checkpoint: %w", err)
+	}
+	if len(checkpoints) == 0 {
+		return nil, nil
+	}
+	return &checkpoints[0], nil
+}
+
+// GetPurgeBoundary returns the last sequence of the longest start of the chain whose records are
+// all older than before, 0 when the first record is not. Only a start of the chain can be purged,
+// so an old record after a newer one is kept until the newer one expires too.
+func (r *AccessRecordRepositoryImpl) GetPurgeBoundary(before time.Time) (int64, error) {
+	var boundary struct {
+		FirstKept *int64
+		Last      *int64
+	}
+	err := allTenants(r.db).Unscoped().Model(&model.AccessRecord{}).
+		Select("MIN(CASE WHEN access_time >= ? THEN sequence END) AS first_kept, MAX(sequence) AS last", before).
+		Where("sequence > 0").
+		Scan(&boundary).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to get access record purge boundary: %w", err)
+	}
+	switch {
+	case boundary.Last == nil:
+		return 0, nil
+	case boundary.FirstKept == nil:
+		return *boundary.Last, nil
+	default:
+		return *boundary.FirstKept - 1, nil
+	}
+}
+
+// PurgeChain deletes the chained access records up to and including a sequence with their
+// annotations, and stores the last purged record as the new checkpoint of the chain. It returns
+// how many records were deleted.
+func (r *AccessRecordRepositoryImpl) PurgeChain(throughSequence int64, retentionRunID string) (int, error) {
+	purged := 0
+	err := allTenants(r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", common.AccessRecordChainLockKey).Error; err != nil {
+			return fmt.Errorf("failed to lock access record chain: %w", err)
+		}
+		var last model.AccessRecord
+		if err := tx.Unscoped().Where("sequence = ?", throughSequence).Order("id").First(&last).Error; err != nil {
+			return fmt.Errorf("failed to get last purged access record: %w", err)
+		}
+
+		purgedIDs := tx.Unscoped().Model(&model.AccessRecord{}).Select("id::text").Where("sequence > 0 AND sequence <= ?", throughSequence)
+		if err := tx.Unscoped().Where("access_record_id IN (?)", purgedIDs).Delete(&model.AccessRecordAnnotation{}).Error; err != nil {
+			return fmt.Errorf("failed to delete access record annotations: %w", err)
+		}
+		result := tx.Unscoped().Where("sequence > 0 AND sequence <= ?", throughSequence).Delete(&model.AccessRecord{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete access records: %w", result.Error)
+		}
+		purged = int(result.RowsAffected)
+
+		checkpoint := &model.AccessRecordChainCheckpoint{
+			Sequence:       last.Sequence,
+			Hash:           last.Hash,
+			RetentionRunID: &retentionRunID,
+		}
+		if err := tx.Create(checkpoint).Error; err != nil {
+			return fmt.Errorf("failed to create access record chain checkpoint: %w", err)
+		}
+		return nil
+	})
+	return purged, err
+}
+
+// GetAnnotationsByRecordID retrieves the annotations of an access record, oldest first.
+func (r *AccessRecordRepositoryImpl) GetAnnotationsByRecordID(accessRecordID uuid.UUID) ([]model.AccessRecordAnnotation, error) {
+	var annotations []model.AccessRecordAnnotation
+	err := r.db.Where("access_record_id = ?", accessRecordID.String()).Order("created_at").Find(&annotations).Error
+	return annotations, err
+}
+
+// GetAnnotationsByRecordIDs retrieves the annotations of several access records, oldest first.
+func (r *AccessRecordRepositoryImpl) GetAnnotationsByRecordIDs(accessRecordIDs []string) ([]model.AccessRecordAnnotation, error) {
+	var annotations []model.AccessRecordAnnotation
+	err := r.db.Where("access_record_id IN ?", accessRecordIDs).Order("created_at").Find(&annotations).Error
+	return annotations, err
+}
+
+// CreateAnnotation inserts a new annotation. Annotations are never updated or deleted either.
+func (r *AccessRecordRepositoryImpl) CreateAnnotation(annotation *model.AccessRecordAnnotation) error {
+	return translateError(r.db.Create(annotation).Error)
+}
+
+// lastChainedAccessRecord returns the end of the hash chain, nil when the chain is empty. The
+// chain is shared by every tenant, so the end is looked up across all of them.
+func lastChainedAccessRecord(tx *gorm.DB) (*model.AccessRecord, error) {
+	var accessRecords []model.AccessRecord
+	if err := allTenants(tx).Unscoped().Where("sequence > 0").Order("sequence DESC").Limit(1).Find(&accessRecords).Error; err != nil {
+		return nil, fmt.Errorf("failed to get last access record of the chain: %w", err)
+	}
+	if len(accessRecords) == 0 {
+		return nil, nil
+	}
+	return &accessRecords[0], nil
+}
+
+// chainAccessRecord sets the sequence and hashes of a record that follows last in the chain.
+func chainAccessRecord(accessRecord *model.AccessRecord, last *model.AccessRecord) {
+	accessRecord.ChainVersion = common.AccessRecordChainVersion
+	accessRecord.Sequence = 1
+	accessRecord.PrevHash = ""
+	if last != nil {
+		accessRecord.Sequence = last.Sequence + 1
+		accessRecord.PrevHash = last.Hash
+	}
+	accessRecord.Hash = common.ChainHash(accessRecord.PrevHash, accessRecord.HashPayload())
 }
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+)
+
+func setChainKey(t *testing.T) {
+	t.Helper()
+	previous := common.AccessRecordChainKey
+	common.AccessRecordChainKey = []byte("0123456789abcdef0123456789abcdef")
+	t.Cleanup(func() { common.AccessRecordChainKey = previous })
+}
+
+// legacyChain returns a chain of records sealed with the unkeyed hash of chain version 2,
+// starting after a record with the given hash.
+func legacyChain(prevHash string, firstSequence int64, count int) []model.AccessRecord {
+	records := make([]model.AccessRecord, count)
+	for i := range records {
+		record := &records[i]
+		record.ID = uuid.New()
+		record.TenantID = "tenant-1"
+		record.Type = "in"
+		record.Result = "success"
+		record.AccessTime = time.Date(2026, 1, 1, 8, i, 0, 0, time.UTC)
+		record.ChainVersion = 2
+		record.Sequence = firstSequence + int64(i)
+		record.PrevHash = prevHash
+		record.Hash = common.LegacyChainHash(record.PrevHash, record.HashPayload())
+		prevHash = record.Hash
+	}
+	return records
+}
+
+func TestResealChain(t *testing.T) {
+	setChainKey(t)
+
+	tests := []struct {
+		name string
+		// tamper changes the chain after it was sealed
+		tamper func(records []model.AccessRecord)
+		// wantResealed is how many records from the start are re-sealed
+		wantResealed int
+	}{
+		{"intact chain", func(records []model.AccessRecord) {}, 4},
+		{"changed field", func(records []model.AccessRecord) { records[2].Result = "failed" }, 2},
+		{"broken link", func(records []model.AccessRecord) { records[1].PrevHash = "forged" }, 1},
+		{"gap", func(records []model.AccessRecord) { records[3].Sequence++ }, 3},
+		{"unkeyed hash claiming the current version", func(records []model.AccessRecord) {
+			records[0].ChainVersion = common.AccessRecordChainVersion
+		}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records := legacyChain("checkpoint", 11, 4)
+			tt.tamper(records)
+			original := append([]model.AccessRecord(nil), records...)
+
+			resealer := newChainResealer(&records[0])
+			resealed := 0
+			for i := range records {
+				if !resealer.reseal(&records[i]) {
+					break
+				}
+				resealed++
+			}
+			if resealed != tt.wantResealed {
+				t.Fatalf("re-sealed %d records, want %d", resealed, tt.wantResealed)
+			}
+
+			prevHash := "checkpoint"
+			for i := range records {
+				record := &records[i]
+				if i >= resealed {
+					if *record != original[i] {
+						t.Errorf("record %d after the break was changed", record.Sequence)
+					}
+					continue
+				}
+				if record.ChainVersion != common.AccessRecordChainVersion {
+					t.Errorf("record %d has chain version %d, want %d", record.Sequence, record.ChainVersion, common.AccessRecordChainVersion)
+				}
+				if record.PrevHash != prevHash {
+					t.Errorf("record %d links to %q, want %q", record.Sequence, record.PrevHash, prevHash)
+				}
+				if want := common.ChainHash(record.PrevHash, record.HashPayload()); record.Hash != want {
+					t.Errorf("record %d has hash %q, want the keyed hash %q", record.Sequence, record.Hash, want)
+				}
+				prevHash = record.Hash
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// AccessScanSessionRepository is the interface for access scan session data access.
+type AccessScanSessionRepository interface {
+	GetByID(id uuid.UUID) (*model.AccessScanSession, error)
+	Create(session *model.AccessScanSession) error
+	Update(session *model.AccessScanSession) error
+}
+
+// accessScanSessionRepositoryImpl is the implementation of AccessScanSessionRepository.
+type accessScanSessionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAccessScanSessionRepository creates a new instance of AccessScanSessionRepository.
+func NewAccessScanSessionRepository(db *gorm.DB) AccessScanSessionRepository {
+	return &accessScanSessionRepositoryImpl{db: db}
+}
+
+// GetByID retrieves a scan session by its ID.
+func (r *accessScanSessionRepositoryImpl) GetByID(id uuid.UUID) (*model.AccessScanSession, error) {
+	var session model.AccessScanSession
+	if err := r.db.First(&session, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Create inserts a new scan session.
+func (r *accessScanSessionRepositoryImpl) Create(session *model.AccessScanSession) error {
+	return translateError(r.db.Create(session).Error)
+}
+
+// Update updates a scan session.
+func (r *accessScanSessionRepositoryImpl) Update(session *model.AccessScanSession) error {
+	return translateError(r.db.Save(session).Error)
+}
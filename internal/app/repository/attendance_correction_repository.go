@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// AttendanceCorrectionRepository is the interface for attendance correction data access.
+type AttendanceCorrectionRepository interface {
+	GetAll(searchQuery schema.AttendanceCorrectionSearchQuery) ([]model.AttendanceCorrection, error)
+	GetByID(id uuid.UUID) (*model.AttendanceCorrection, error)
+	Create(correction *model.AttendanceCorrection) error
+	Update(correction *model.AttendanceCorrection) error
+	IsExistOpen(personID string, date string, correctionType string) (bool, error)
+	GetApprovedByPersonAndRange(personID string, startDate string, endDate string) ([]model.AttendanceCorrection, error)
+	GetByPersonID(personID string) ([]model.AttendanceCorrection, error)
+	AnonymizeByPersonID(personID string) error
+	DeleteByPersonID(personID string) error
+}
+
+// attendanceCorrectionRepositoryImpl is the implementation of AttendanceCorrectionRepository.
+type attendanceCorrectionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAttendanceCorrectionRepository creates a new instance of AttendanceCorrectionRepository.
+func NewAttendanceCorrectionRepository(db *gorm.DB) AttendanceCorrectionRepository {
+	return &attendanceCorrectionRepositoryImpl{db: db}
+}
+
+// GetAll retrieves attendance corrections, newest date first, with pagination.
+func (r *attendanceCorrectionRepositoryImpl) GetAll(searchQuery schema.AttendanceCorrectionSearchQuery) ([]model.AttendanceCorrection, error) {
+	var corrections []model.AttendanceCorrection
+	query := r.db.Model(&model.AttendanceCorrection{})
+
+	if searchQuery.PersonID != "" {
+		query = query.Where("person_id = ?", searchQuery.PersonID)
+	}
+	if searchQuery.Status != "" {
+		query = query.Where("status = ?", searchQuery.Status)
+	}
+	if searchQuery.StartDate != "" {
+		query = query.Where("date >= ?", searchQuery.StartDate)
+	}
+	if searchQuery.EndDate != "" {
+		query = query.Where("date <= ?", searchQuery.EndDate)
+	}
+
+	offset := (searchQuery.Page - 1) * searchQuery.Limit
+	if err := query.Order("date DESC").Order("time").Offset(offset).Limit(searchQuery.Limit).Find(&corrections).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve paginated attendance corrections: %w", err)
+	}
+	return corrections, nil
+}
+
+// GetByID retrieves an attendance correction by its ID.
+func (r *attendanceCorrectionRepositoryImpl) GetByID(id uuid.UUID) (*model.AttendanceCorrection, error) {
+	var correction model.AttendanceCorrection
+	if err := r.db.First(&correction, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &correction, nil
+}
+
+// Create inserts a new attendance correction.
+func (r *attendanceCorrectionRepositoryImpl) Create(correction *model.AttendanceCorrection) error {
+	return translateError(r.db.Create(correction).Error)
+}
+
+// Update updates an attendance correction.
+func (r *attendanceCorrectionRepositoryImpl) Update(correction *model.AttendanceCorrection) error {
+	return translateError(r.db.Save(correction).Error)
+}
+
+// IsExistOpen checks if a pending or approved correction of the same type exists for a person and date.
+func (r *attendanceCorrectionRepositoryImpl) IsExistOpen(personID string, date string, correctionType string) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.AttendanceCorrection{}).
+		Where("person_id = ? AND date = ? AND type = ?", personID, date, correctionType).
+		Where("status IN ?", []string{common.ApprovalStatusPending, common.ApprovalStatusApproved}).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check attendance correction existence: %w", err)
+	}
+	return count > 0, nil
+}
+
+// GetApprovedByPersonAndRange retrieves the approved corrections of a person from startDate to endDate.
+func (r *attendanceCorrectionRepositoryImpl) GetApprovedByPersonAndRange(personID string, startDate string, endDate string) ([]model.AttendanceCorrection, error) {
+	var corrections []model.AttendanceCorrection
+	err := r.db.Where("person_id = ? AND status = ?", personID, common.ApprovalStatusApproved).
+		Where("date >= ? AND date <= ?", startDate, endDate).
+		Find(&corrections).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve approved attendance corrections: %w", err)
+	}
+	return corrections, nil
+}
+
+// DeleteByPersonID deletes the attendance corrections of a person.
+// GetByPersonID retrieves every attendance correction of a person, oldest first.
+func (r *attendanceCorrectionRepositoryImpl) GetByPersonID(personID string) ([]model.AttendanceCorrection, error) {
+	var corrections []model.AttendanceCorrection
+	if err := r.db.Where("person_id = ?", personID).Order("date").Find(&corrections).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve attendance corrections: %w", err)
+	}
+	return corrections, nil
+}
+
+// AnonymizeByPersonID clears the free text of the corrections of a person and keeps the times.
+func (r *attendanceCorrectionRepositoryImpl) AnonymizeByPersonID(personID string) error {
+	return r.db.Model(&model.AttendanceCorrection{}).Where("person_id = ?", personID).Updates(map[string]interface{}{
+		"reason":        "",
+		"decision_note": nil,
+	}).Error
+}
+
+func (r *attendanceCorrectionRepositoryImpl) DeleteByPersonID(personID string) error {
+	return r.db.Unscoped().Where("person_id = ?", personID).Delete(&model.AttendanceCorrection{}).Error
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// AttendanceRecordRepository is the interface for calculated attendance data access.
+type AttendanceRecordRepository interface {
+	GetAll(searchQuery schema.AttendanceRecordSearchQuery) ([]model.AttendanceRecord, error)
+	GetByID(id uuid.UUID) (*model.AttendanceRecord, error)
+	GetByPersonAndDate(personID string, date string) (*model.AttendanceRecord, error)
+	Create(record *model.AttendanceRecord) error
+	Update(record *model.AttendanceRecord) error
+
+	// Retention methods
+	GetBefore(date string, afterID uuid.UUID, limit int) ([]model.AttendanceRecord, error)
+	DeleteByIDs(ids []uuid.UUID) (int, error)
+}
+
+// attendanceRecordRepositoryImpl is the implementation of AttendanceRecordRepository.
+type attendanceRecordRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAttendanceRecordRepository creates a new instance of AttendanceRecordRepository.
+func NewAttendanceRecordRepository(db *gorm.DB) AttendanceRecordRepository {
+	return &attendanceRecordRepositoryImpl{db: db}
+}
+
+// GetAll retrieves attendance records ordered by date, with pagination unless All is set.
+func (r *attendanceRecordRepositoryImpl) GetAll(searchQuery schema.AttendanceRecordSearchQuery) ([]model.AttendanceRecord, error) {
+	var records []model.AttendanceRecord
+	query := r.db.Model(&model.AttendanceRecord{})
+
+	if searchQuery.PersonID != "" {
+		query = query.Where("person_id = ?", searchQuery.PersonID)
+	}
+	if searchQuery.StartDate != "" {
+		query = query.Where("date >= ?", searchQuery.StartDate)
+	}
+	if searchQuery.EndDate != "" {
+		query = query.Where("date <= ?", searchQuery.EndDate)
+	}
+	if searchQuery.Status != "" {
+		query = query.Where("status = ?", searchQuery.Status)
+	}
+	if searchQuery.OvertimeStatus != "" {
+		query = query.Where("overtime_status = ?", searchQuery.OvertimeStatus)
+	}
+
+	query = query.Order("date").Order("person_id")
+	if !searchQuery.All {
+		offset := (searchQuery.Page - 1) * searchQuery.Limit
+		query = query.Offset(offset).Limit(searchQuery.Limit)
+	}
+	if err := query.Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve attendance records: %w", err)
+	}
+	return records, nil
+}
+
+// GetByID retrieves an attendance record by its ID.
+func (r *attendanceRecordRepositoryImpl) GetByID(id uuid.UUID) (*model.AttendanceRecord, error) {
+	var record model.AttendanceRecord
+	if err := r.db.First(&record, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// GetByPersonAndDate retrieves the attendance record of a person on a date.
+func (r *attendanceRecordRepositoryImpl) GetByPersonAndDate(personID string, date string) (*model.AttendanceRecord, error) {
+	var record model.AttendanceRecord
+	if err := r.db.First(&record, "person_id = ? AND date = ?", personID, date).Error; err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Create inserts a new attendance record.
+func (r *attendanceRecordRepositoryImpl) Create(record *model.AttendanceRecord) error {
+	return translateError(r.db.Create(record).Error)
+}
+
+// Update updates an attendance record.
+func (r *attendanceRecordRepositoryImpl) Update(record *model.AttendanceRecord) error {
+	return translateError(r.db.Save(record).Error)
+}
+
+// GetBefore retrieves the attendance records of dates before a date in ID order, starting after
+// an ID, so the retention job can walk them in batches.
+func (r *attendanceRecordRepositoryImpl) GetBefore(date string, afterID uuid.UUID, limit int) ([]model.AttendanceRecord, error) {
+	var records []model.AttendanceRecord
+	query := r.db.Unscoped().Where("date < ?", date)
+	if afterID != uuid.Nil {
+		query = query.Where("id > ?", afterID)
+	}
+	if err := query.Order("id").Limit(limit).Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve attendance records: %w", err)
+	}
+	return records, nil
+}
+
+// DeleteByIDs deletes attendance records by their IDs and returns how many were deleted.
+func (r *attendanceRecordRepositoryImpl) DeleteByIDs(ids []uuid.UUID) (int, error) {
+	result := r.db.Unscoped().Where("id IN ?", ids).Delete(&model.AttendanceRecord{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete attendance records: %w", result.Error)
+	}
+	return int(result.RowsAffected), nil
+}
@@ -13,6 +13,7 @@ import (
 type AttendanceRepository interface {
 	GetAll(searchQuery schema.AttendanceSearchQuery) ([]model.Attendance, error)
 	GetByID(id uuid.UUID) (*model.Attendance, error)
+	GetByName(name string) (*model.Attendance, error)
 	Create(attendance *model.Attendance) error
 	Update(attendance *model.Attendance) error
 	Delete(id uuid.UUID) error
@@ -71,14 +72,23 @@ func (r *attendanceRepositoryImpl) GetByID(id uuid.UUID) (*model.Attendance, err
 	return &attendance, nil
 }
 
+// GetByName retrieves an attendance record by its name.
+func (r *attendanceRepositoryImpl) GetByName(name string) (*model.Attendance, error) {
+	var attendance model.Attendance
+	if err := r.db.First(&attendance, "name = ?", name).Error; err != nil {
+		return nil, err
+	}
+	return &attendance, nil
+}
+
 // Create creates a new attendance record.
 func (r *attendanceRepositoryImpl) Create(attendance *model.Attendance) error {
-	return r.db.Create(attendance).Error
+	return translateError(r.db.Create(attendance).Error)
 }
 
 // Update updates an existing attendance record.
 func (r *attendanceRepositoryImpl) Update(attendance *model.Attendance) error {
-	return r.db.Save(attendance).Error
+	return translateError(r.db.Save(attendance).Error)
 }
 
 // Delete deletes an attendance record by its ID.
@@ -128,7 +138,7 @@ func (r *attendanceRepositoryImpl) CreateAttendanceSchedules(schedules []model.A
 	if len(schedules) == 0 {
 		return nil
 	}
-	return r.db.Create(&schedules).Error
+	return translateError(r.db.Create(&schedules).Error)
 }
 
 // DeleteAttendanceSchedulesByAttendanceID deletes all AttendanceSchedule records for an attendance ID.
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// AuditLogRepository is the interface for audit log data access. Audit logs are only removed by
+// the retention job.
+type AuditLogRepository interface {
+	GetAll(searchQuery schema.AuditLogSearchQuery) ([]model.AuditLog, error)
+	Create(auditLog *model.AuditLog) error
+
+	// Retention methods
+	GetBefore(before time.Time, afterID uuid.UUID, limit int) ([]model.AuditLog, error)
+	DeleteByIDs(ids []uuid.UUID) (int, error)
+}
+
+// auditLogRepositoryImpl is the implementation of AuditLogRepository.
+type auditLogRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository creates a new instance of AuditLogRepository.
+func NewAuditLogRepository(db *gorm.DB) AuditLogRepository {
+	return &auditLogRepositoryImpl{db: db}
+}
+
+// GetAll retrieves audit logs, newest first, with pagination.
+func (r *auditLogRepositoryImpl) GetAll(searchQuery schema.AuditLogSearchQuery) ([]model.AuditLog, error) {
+	var auditLogs []model.AuditLog
+	query := r.db.Model(&model.AuditLog{})
+
+	if searchQuery.Action != "" {
+		query = query.Where("action = ?", searchQuery.Action)
+	}
+	if searchQuery.EntityType != "" {
+		query = query.Where("entity_type = ?", searchQuery.EntityType)
+	}
+	if searchQuery.EntityID != "" {
+		query = query.Where("entity_id = ?", searchQuery.EntityID)
+	}
+	if searchQuery.Username != "" {
+		query = query.Where("username = ?", searchQuery.Username)
+	}
+
+	offset := (searchQuery.Page - 1) * searchQuery.Limit
+	if err := query.Order("created_at DESC").Offset(offset).Limit(searchQuery.Limit).Find(&auditLogs).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve paginated audit logs: %w", err)
+	}
+	return auditLogs, nil
+}
+
+// Create inserts a new audit log.
+func (r *auditLogRepositoryImpl) Create(auditLog *model.AuditLog) error {
+	return translateError(r.db.Create(auditLog).Error)
+}
+
+// GetBefore retrieves the audit logs created before a time in ID order, starting after an ID, so
+// the retention job can walk them in batches.
+func (r *auditLogRepositoryImpl) GetBefore(before time.Time, afterID uuid.UUID, limit int) ([]model.AuditLog, error) {
+	var auditLogs []model.AuditLog
+	query := r.db.Unscoped().Where("created_at < ?", before)
+	if afterID != uuid.Nil {
+		query = query.Where("id > ?", afterID)
+	}
+	if err := query.Order("id").Limit(limit).Find(&auditLogs).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve audit logs: %w", err)
+	}
+	return auditLogs, nil
+}
+
+// DeleteByIDs deletes audit logs by their IDs and returns how many were deleted.
+func (r *auditLogRepositoryImpl) DeleteByIDs(ids []uuid.UUID) (int, error) {
+	result := r.db.Unscoped().Where("id IN ?", ids).Delete(&model.AuditLog{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete audit logs: %w", result.Error)
+	}
+	return int(result.RowsAffected), nil
+}
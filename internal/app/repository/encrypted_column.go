@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// reEncryptBatchSize is the number of rows rewritten per query when re-encrypting.
+const reEncryptBatchSize = 500
+
+// reEncryptRows rewrites the rows of T whose encrypted columns hold plain text or a value
+// encrypted with another key than prefix. Loading decrypts the columns and saving encrypts them
+// with the current key, prepare can fill derived columns such as blind indexes first. Soft deleted
+// rows are included and updated_at is left unchanged.
+func reEncryptRows[T any](db *gorm.DB, prefix string, columns []string, prepare func(row *T), updateColumns ...string) (int, error) {
+	conditions := make([]string, len(columns))
+	args := make([]interface{}, 0, len(columns)*2)
+	for i, column := range columns {
+		conditions[i] = fmt.Sprintf("(%s IS NOT NULL AND %s != '' AND LEFT(%s, ?) != ?)", column, column, column)
+		args = append(args, len(prefix), prefix)
+	}
+	where := strings.Join(conditions, " OR ")
+	selected := append(append([]string{}, columns...), updateColumns...)
+
+	rewritten := 0
+	for {
+		var rows []T
+		if err := db.Unscoped().Where(where, args...).Order("id").Limit(reEncryptBatchSize).Find(&rows).Error; err != nil {
+			return rewritten, fmt.Errorf("failed to retrieve rows to re-encrypt: %w", err)
+		}
+		if len(rows) == 0 {
+			return rewritten, nil
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			for i := range rows {
+				if prepare != nil {
+					prepare(&rows[i])
+				}
+				if err := tx.Unscoped().Model(&rows[i]).Select(selected).UpdateColumns(&rows[i]).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return rewritten, fmt.Errorf("failed to re-encrypt rows: %w", err)
+		}
+		rewritten += len(rows)
+	}
+}
+
+// reindexRows recomputes the blind indexes of every row of T with prepare and writes only the
+// index columns. Soft deleted rows are included and updated_at is left unchanged. It returns the
+// number of rows rewritten.
+func reindexRows[T any](db *gorm.DB, prepare func(row *T), indexColumns ...string) (int, error) {
+	rewritten := 0
+	for {
+		var rows []T
+		if err := db.Unscoped().Order("id").Offset(rewritten).Limit(reEncryptBatchSize).Find(&rows).Error; err != nil {
+			return rewritten, fmt.Errorf("failed to retrieve rows to reindex: %w", err)
+		}
+		if len(rows) == 0 {
+			return rewritten, nil
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			for i := range rows {
+				prepare(&rows[i])
+				if err := tx.Unscoped().Model(&rows[i]).Select(indexColumns).UpdateColumns(&rows[i]).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return rewritten, fmt.Errorf("failed to reindex rows: %w", err)
+		}
+		rewritten += len(rows)
+	}
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/putteror/access-control-management/internal/app/common"
+	"gorm.io/gorm"
+)
+
+// translateError turns a unique constraint violation into a common.ConflictError, the database
+// being the last line of defence against duplicates the services did not catch. Other errors are
+// returned as they are. It relies on the TranslateError option of the gorm config.
+func translateError(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return common.NewConflictError("a record with the same unique value already exists")
+	}
+	return err
+}
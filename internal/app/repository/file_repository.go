@@ -14,6 +14,8 @@ import (
 // This interface can be implemented by different storage types (local, S3, etc.).
 type FileRepository interface {
 	Save(fileHeader *multipart.FileHeader, folderPath string) (string, error)
+	SaveReader(src io.Reader, fileName string, folderPath string) (string, error)
+	Open(filePath string) (io.ReadCloser, error)
 	Delete(filePath string) error
 }
 
@@ -29,6 +31,19 @@ func NewFileSystemRepo(basePath string) FileRepository {
 
 // Save saves a file to the local file system and returns its path.
 func (r *fileSystemRepo) Save(fileHeader *multipart.FileHeader, folderPath string) (string, error) {
+	// Open the source file from the multipart form data.
+	src, err := fileHeader.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open file header: %w", err)
+	}
+	defer src.Close()
+
+	return r.SaveReader(src, fileHeader.Filename, folderPath)
+}
+
+// SaveReader saves the content of src to the local file system and returns its path.
+// Only the extension of fileName is kept, the stored file gets a unique name.
+func (r *fileSystemRepo) SaveReader(src io.Reader, fileName string, folderPath string) (string, error) {
 
 	save_file_path := filepath.Join(r.basePath, folderPath)
 
@@ -38,7 +53,7 @@ func (r *fileSystemRepo) Save(fileHeader *multipart.FileHeader, folderPath strin
 	}
 
 	// 2. Create a unique filename.
-	filename := uuid.New().String() + filepath.Ext(fileHeader.Filename)
+	filename := uuid.New().String() + filepath.Ext(fileName)
 	internalFilePath := filepath.Join(save_file_path, filename)
 	returnFilePath := filepath.Join(folderPath, filename)
 
@@ -49,14 +64,7 @@ func (r *fileSystemRepo) Save(fileHeader *multipart.FileHeader, folderPath strin
 	}
 	defer dst.Close()
 
-	// 4. Open the source file from the multipart form data.
-	src, err := fileHeader.Open()
-	if err != nil {
-		return "", fmt.Errorf("failed to open file header: %w", err)
-	}
-	defer src.Close()
-
-	// 5. Use io.Copy for efficient and safe file copying.
+	// 4. Use io.Copy for efficient and safe file copying.
 	if _, err := io.Copy(dst, src); err != nil {
 		return "", fmt.Errorf("failed to save file content: %w", err)
 	}
@@ -68,8 +76,18 @@ func (r *fileSystemRepo) Save(fileHeader *multipart.FileHeader, folderPath strin
 	return returnFilePath, nil
 }
 
+// Open opens a stored file for reading.
+func (r *fileSystemRepo) Open(filePath string) (io.ReadCloser, error) {
+	return os.Open(r.internalPath(filePath))
+}
+
 // Delete removes a file from the local file system.
 func (r *fileSystemRepo) Delete(filePath string) error {
-	internalFilePath := filepath.Join(r.basePath, filePath)
-	return os.Remove(internalFilePath)
+	return os.Remove(r.internalPath(filePath))
+}
+
+// internalPath resolves a stored file path inside basePath.
+// Cleaning the path as an absolute path keeps ".." from escaping basePath.
+func (r *fileSystemRepo) internalPath(filePath string) string {
+	return filepath.Join(r.basePath, filepath.Clean("/"+filePath))
 }
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// HolidayCalendarRepository is the interface for holiday calendar data access.
+type HolidayCalendarRepository interface {
+	GetAll(searchQuery schema.HolidayCalendarSearchQuery) ([]model.HolidayCalendar, error)
+	GetByID(id uuid.UUID) (*model.HolidayCalendar, error)
+	Create(calendar *model.HolidayCalendar) error
+	Update(calendar *model.HolidayCalendar) error
+	Delete(id uuid.UUID) error
+	IsExistName(name string, excludeID uuid.UUID) (bool, error)
+
+	// Holiday relationship methods
+	GetHolidaysByCalendarID(calendarID uuid.UUID) ([]model.Holiday, error)
+	GetHolidayByDate(calendarID string, date string) (*model.Holiday, error)
+	CreateHolidays(holidays []model.Holiday) error
+	DeleteHolidaysByCalendarID(calendarID uuid.UUID, tx *gorm.DB) error
+}
+
+// holidayCalendarRepositoryImpl is the implementation of HolidayCalendarRepository.
+type holidayCalendarRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewHolidayCalendarRepository creates a new instance of HolidayCalendarRepository.
+func NewHolidayCalendarRepository(db *gorm.DB) HolidayCalendarRepository {
+	return &holidayCalendarRepositoryImpl{db: db}
+}
+
+// --- CRUD Operations ---
+
+// GetAll retrieves holiday calendars with pagination.
+func (r *holidayCalendarRepositoryImpl) GetAll(searchQuery schema.HolidayCalendarSearchQuery) ([]model.HolidayCalendar, error) {
+	var calendars []model.HolidayCalendar
+	query := r.db.Model(&model.HolidayCalendar{})
+
+	if searchQuery.Name != "" {
+		query = query.Where("name ILIKE ?", "%"+searchQuery.Name+"%")
+	}
+
+	offset := (searchQuery.Page - 1) * searchQuery.Limit
+	if err := query.Order("name").Offset(offset).Limit(searchQuery.Limit).Find(&calendars).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve paginated holiday calendars: %w", err)
+	}
+	return calendars, nil
+}
+
+// GetByID retrieves a holiday calendar by its ID.
+func (r *holidayCalendarRepositoryImpl) GetByID(id uuid.UUID) (*model.HolidayCalendar, error) {
+	var calendar model.HolidayCalendar
+	if err := r.db.First(&calendar, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &calendar, nil
+}
+
+// Create inserts a new holiday calendar.
+func (r *holidayCalendarRepositoryImpl) Create(calendar *model.HolidayCalendar) error {
+	return translateError(r.db.Create(calendar).Error)
+}
+
+// Update updates a holiday calendar.
+func (r *holidayCalendarRepositoryImpl) Update(calendar *model.HolidayCalendar) error {
+	return translateError(r.db.Save(calendar).Error)
+}
+
+// Delete deletes a holiday calendar and its holidays, and detaches it from groups and attendance profiles.
+func (r *holidayCalendarRepositoryImpl) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.DeleteHolidaysByCalendarID(id, tx); err != nil {
+			return err
+		}
+		if err := tx.Model(&model.AccessControlGroup{}).Where("holiday_calendar_id = ?", id.String()).Update("holiday_calendar_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&model.Attendance{}).Where("holiday_calendar_id = ?", id.String()).Update("holiday_calendar_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("id = ?", id).Delete(&model.HolidayCalendar{}).Error
+	})
+}
+
+// IsExistName checks if a holiday calendar with the given name exists.
+func (r *holidayCalendarRepositoryImpl) IsExistName(name string, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	db := r.db.Model(&model.HolidayCalendar{}).Where("name = ? AND deleted_at IS NULL", name)
+	if excludeID != uuid.Nil {
+		db = db.Where("id != ?", excludeID)
+	}
+	if err := db.Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check holiday calendar name existence: %w", err)
+	}
+	return count > 0, nil
+}
+
+// --- Holiday Relationship Methods ---
+
+// GetHolidaysByCalendarID retrieves the holidays of a calendar ordered by date.
+func (r *holidayCalendarRepositoryImpl) GetHolidaysByCalendarID(calendarID uuid.UUID) ([]model.Holiday, error) {
+	var holidays []model.Holiday
+	err := r.db.Where("holiday_calendar_id = ?", calendarID).Order("start_date").Find(&holidays).Error
+	return holidays, err
+}
+
+// GetHolidayByDate retrieves the holiday of a calendar covering a date ("2006-01-02").
+func (r *holidayCalendarRepositoryImpl) GetHolidayByDate(calendarID string, date string) (*model.Holiday, error) {
+	var holiday model.Holiday
+	err := r.db.Where("holiday_calendar_id = ? AND start_date <= ? AND end_date >= ?", calendarID, date, date).
+		Order("start_date DESC").First(&holiday).Error
+	if err != nil {
+		return nil, err
+	}
+	return &holiday, nil
+}
+
+// CreateHolidays inserts multiple holidays.
+func (r *holidayCalendarRepositoryImpl) CreateHolidays(holidays []model.Holiday) error {
+	if len(holidays) == 0 {
+		return nil
+	}
+	return translateError(r.db.Create(&holidays).Error)
+}
+
+// DeleteHolidaysByCalendarID deletes all holidays of a calendar.
+func (r *holidayCalendarRepositoryImpl) DeleteHolidaysByCalendarID(calendarID uuid.UUID, tx *gorm.DB) error {
+	if tx == nil {
+		tx = r.db
+	}
+	return tx.Unscoped().Where("holiday_calendar_id = ?", calendarID).Delete(&model.Holiday{}).Error
+}
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// LeaveRequestRepository is the interface for leave request and leave balance data access.
+type LeaveRequestRepository interface {
+	GetAll(searchQuery schema.LeaveRequestSearchQuery) ([]model.LeaveRequest, error)
+	GetByID(id uuid.UUID) (*model.LeaveRequest, error)
+	Create(leaveRequest *model.LeaveRequest, years []model.LeaveRequestYear) error
+	Update(leaveRequest *model.LeaveRequest) error
+	GetOverlapping(personID string, startDate string, endDate string, excludeID uuid.UUID) ([]model.LeaveRequest, error)
+	GetApprovedByPersonAndRange(personID string, startDate string, endDate string) ([]model.LeaveRequest, error)
+	GetYears(leaveRequestID uuid.UUID) ([]model.LeaveRequestYear, error)
+	SumDays(personID string, leaveTypeID string, year int, statuses []string, excludeID uuid.UUID) (float64, error)
+	GetByPersonID(personID string) ([]model.LeaveRequest, error)
+	AnonymizeByPersonID(personID string) error
+	DeleteByPersonID(personID string) error
+
+	// Leave balance methods
+	GetBalancesByPersonAndYear(personID string, year int) ([]model.LeaveBalance, error)
+	GetBalance(personID string, leaveTypeID string, year int) (*model.LeaveBalance, error)
+	SaveBalance(balance *model.LeaveBalance) error
+}
+
+// leaveRequestRepositoryImpl is the implementation of LeaveRequestRepository.
+type leaveRequestRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewLeaveRequestRepository creates a new instance of LeaveRequestRepository.
+func NewLeaveRequestRepository(db *gorm.DB) LeaveRequestRepository {
+	return &leaveRequestRepositoryImpl{db: db}
+}
+
+// GetAll retrieves leave requests, newest first, with pagination.
+func (r *leaveRequestRepositoryImpl) GetAll(searchQuery schema.LeaveRequestSearchQuery) ([]model.LeaveRequest, error) {
+	var leaveRequests []model.LeaveRequest
+	query := r.db.Model(&model.LeaveRequest{})
+
+	if searchQuery.PersonID != "" {
+		query = query.Where("person_id = ?", searchQuery.PersonID)
+	}
+	if searchQuery.LeaveTypeID != "" {
+		query = query.Where("leave_type_id = ?", searchQuery.LeaveTypeID)
+	}
+	if searchQuery.Status != "" {
+		query = query.Where("status = ?", searchQuery.Status)
+	}
+	// Requests overlapping the searched date range
+	if searchQuery.StartDate != "" {
+		query = query.Where("end_date >= ?", searchQuery.StartDate)
+	}
+	if searchQuery.EndDate != "" {
+		query = query.Where("start_date <= ?", searchQuery.EndDate)
+	}
+
+	offset := (searchQuery.Page - 1) * searchQuery.Limit
+	if err := query.Order("start_date DESC").Offset(offset).Limit(searchQuery.Limit).Find(&leaveRequests).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve paginated leave requests: %w", err)
+	}
+	return leaveRequests, nil
+}
+
+// GetByID retrieves a leave request by its ID.
+func (r *leaveRequestRepositoryImpl) GetByID(id uuid.UUID) (*model.LeaveRequest, error) {
+	var leaveRequest model.LeaveRequest
+	if err := r.db.First(&leaveRequest, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &leaveRequest, nil
+}
+
+// Create inserts a new leave request with its days by year.
+func (r *leaveRequestRepositoryImpl) Create(leaveRequest *model.LeaveRequest, years []model.LeaveRequestYear) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(leaveRequest).Error; err != nil {
+			return translateError(err)
+		}
+		if len(years) == 0 {
+			return nil
+		}
+		for i := range years {
+			years[i].LeaveRequestID = leaveRequest.ID.String()
+		}
+		return translateError(tx.Create(&years).Error)
+	})
+}
+
+// Update updates a leave request.
+func (r *leaveRequestRepositoryImpl) Update(leaveRequest *model.LeaveRequest) error {
+	return translateError(r.db.Save(leaveRequest).Error)
+}
+
+// GetOverlapping retrieves the pending and approved requests of a person overlapping a date range.
+func (r *leaveRequestRepositoryImpl) GetOverlapping(personID string, startDate string, endDate string, excludeID uuid.UUID) ([]model.LeaveRequest, error) {
+	var leaveRequests []model.LeaveRequest
+	query := r.db.Where("person_id = ? AND status IN ?", personID, []string{common.ApprovalStatusPending, common.ApprovalStatusApproved}).
+		Where("start_date <= ? AND end_date >= ?", endDate, startDate)
+	if excludeID != uuid.Nil {
+		query = query.Where("id != ?", excludeID)
+	}
+	if err := query.Find(&leaveRequests).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve overlapping leave requests: %w", err)
+	}
+	return leaveRequests, nil
+}
+
+// GetApprovedByPersonAndRange retrieves the approved requests of a person overlapping a date range.
+func (r *leaveRequestRepositoryImpl) GetApprovedByPersonAndRange(personID string, startDate string, endDate string) ([]model.LeaveRequest, error) {
+	var leaveRequests []model.LeaveRequest
+	err := r.db.Where("person_id = ? AND status = ?", personID, common.ApprovalStatusApproved).
+		Where("start_date <= ? AND end_date >= ?", endDate, startDate).
+		Order("start_date").
+		Find(&leaveRequests).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve approved leave requests: %w", err)
+	}
+	return leaveRequests, nil
+}
+
+// GetYears retrieves the days by year of a leave request, ordered by year.
+func (r *leaveRequestRepositoryImpl) GetYears(leaveRequestID uuid.UUID) ([]model.LeaveRequestYear, error) {
+	var years []model.LeaveRequestYear
+	if err := r.db.Where("leave_request_id = ?", leaveRequestID.String()).Order("year").Find(&years).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve leave request years: %w", err)
+	}
+	return years, nil
+}
+
+// SumDays totals the days in a year of a person's requests of a leave type.
+func (r *leaveRequestRepositoryImpl) SumDays(personID string, leaveTypeID string, year int, statuses []string, excludeID uuid.UUID) (float64, error) {
+	var total float64
+	query := r.db.Model(&model.LeaveRequestYear{}).
+		Select("COALESCE(SUM(leave_request_years.days), 0)").
+		Joins("JOIN leave_requests ON leave_requests.id::text = leave_request_years.leave_request_id AND leave_requests.deleted_at IS NULL").
+		Where("leave_requests.person_id = ? AND leave_requests.leave_type_id = ? AND leave_requests.status IN ?", personID, leaveTypeID, statuses).
+		Where("leave_request_years.year = ?", year)
+	if excludeID != uuid.Nil {
+		query = query.Where("leave_requests.id != ?", excludeID)
+	}
+	if err := query.Scan(&total).Error; err != nil {
+		return 0, fmt.Errorf("failed to sum leave days: %w", err)
+	}
+	return total, nil
+}
+
+// DeleteByPersonID deletes the leave requests and balances of a person.
+// GetByPersonID retrieves every leave request of a person, oldest first.
+func (r *leaveRequestRepositoryImpl) GetByPersonID(personID string) ([]model.LeaveRequest, error) {
+	var leaveRequests []model.LeaveRequest
+	if err := r.db.Where("person_id = ?", personID).Order("start_date").Find(&leaveRequests).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve leave requests: %w", err)
+	}
+	return leaveRequests, nil
+}
+
+// AnonymizeByPersonID clears the free text of the leave requests of a person and keeps the
+// dates and days.
+func (r *leaveRequestRepositoryImpl) AnonymizeByPersonID(personID string) error {
+	return r.db.Model(&model.LeaveRequest{}).Where("person_id = ?", personID).Updates(map[string]interface{}{
+		"reason":        nil,
+		"decision_note": nil,
+	}).Error
+}
+
+func (r *leaveRequestRepositoryImpl) DeleteByPersonID(personID string) error {
+	leaveRequestIDs := r.db.Unscoped().Model(&model.LeaveRequest{}).Select("id::text").Where("person_id = ?", personID)
+	if err := r.db.Unscoped().Where("leave_request_id IN (?)", leaveRequestIDs).Delete(&model.LeaveRequestYear{}).Error; err != nil {
+		return err
+	}
+	if err := r.db.Unscoped().Where("person_id = ?", personID).Delete(&model.LeaveRequest{}).Error; err != nil {
+		return err
+	}
+	return r.db.Unscoped().Where("person_id = ?", personID).Delete(&model.LeaveBalance{}).Error
+}
+
+// GetBalancesByPersonAndYear retrieves the leave balances of a person in a year.
+func (r *leaveRequestRepositoryImpl) GetBalancesByPersonAndYear(personID string, year int) ([]model.LeaveBalance, error) {
+	var balances []model.LeaveBalance
+	if err := r.db.Where("person_id = ? AND year = ?", personID, year).Find(&balances).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve leave balances: %w", err)
+	}
+	return balances, nil
+}
+
+// GetBalance retrieves the balance of a person for a leave type in a year.
+func (r *leaveRequestRepositoryImpl) GetBalance(personID string, leaveTypeID string, year int) (*model.LeaveBalance, error) {
+	var balance model.LeaveBalance
+	if err := r.db.First(&balance, "person_id = ? AND leave_type_id = ? AND year = ?", personID, leaveTypeID, year).Error; err != nil {
+		return nil, err
+	}
+	return &balance, nil
+}
+
+// SaveBalance creates or updates a leave balance.
+func (r *leaveRequestRepositoryImpl) SaveBalance(balance *model.LeaveBalance) error {
+	return translateError(r.db.Save(balance).Error)
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// LeaveTypeRepository is the interface for leave type data access.
+type LeaveTypeRepository interface {
+	GetAll(searchQuery schema.LeaveTypeSearchQuery) ([]model.LeaveType, error)
+	GetByID(id uuid.UUID) (*model.LeaveType, error)
+	Create(leaveType *model.LeaveType) error
+	Update(leaveType *model.LeaveType) error
+	Delete(id uuid.UUID) error
+	IsExistName(name string, excludeID uuid.UUID) (bool, error)
+	IsInUse(id uuid.UUID) (bool, error)
+}
+
+// leaveTypeRepositoryImpl is the implementation of LeaveTypeRepository.
+type leaveTypeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewLeaveTypeRepository creates a new instance of LeaveTypeRepository.
+func NewLeaveTypeRepository(db *gorm.DB) LeaveTypeRepository {
+	return &leaveTypeRepositoryImpl{db: db}
+}
+
+// GetAll retrieves leave types with pagination.
+func (r *leaveTypeRepositoryImpl) GetAll(searchQuery schema.LeaveTypeSearchQuery) ([]model.LeaveType, error) {
+	var leaveTypes []model.LeaveType
+	query := r.db.Model(&model.LeaveType{})
+
+	if searchQuery.Name != "" {
+		query = query.Where("name ILIKE ?", "%"+searchQuery.Name+"%")
+	}
+
+	offset := (searchQuery.Page - 1) * searchQuery.Limit
+	if err := query.Order("name").Offset(offset).Limit(searchQuery.Limit).Find(&leaveTypes).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve paginated leave types: %w", err)
+	}
+	return leaveTypes, nil
+}
+
+// GetByID retrieves a leave type by its ID.
+func (r *leaveTypeRepositoryImpl) GetByID(id uuid.UUID) (*model.LeaveType, error) {
+	var leaveType model.LeaveType
+	if err := r.db.First(&leaveType, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &leaveType, nil
+}
+
+// Create inserts a new leave type.
+func (r *leaveTypeRepositoryImpl) Create(leaveType *model.LeaveType) error {
+	return translateError(r.db.Create(leaveType).Error)
+}
+
+// Update updates a leave type.
+func (r *leaveTypeRepositoryImpl) Update(leaveType *model.LeaveType) error {
+	return translateError(r.db.Save(leaveType).Error)
+}
+
+// Delete deletes a leave type and the balances kept for it.
+func (r *leaveTypeRepositoryImpl) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("leave_type_id = ?", id.String()).Delete(&model.LeaveBalance{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("id = ?", id).Delete(&model.LeaveType{}).Error
+	})
+}
+
+// IsExistName checks if a leave type with the given name exists.
+func (r *leaveTypeRepositoryImpl) IsExistName(name string, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	db := r.db.Model(&model.LeaveType{}).Where("name = ? AND deleted_at IS NULL", name)
+	if excludeID != uuid.Nil {
+		db = db.Where("id != ?", excludeID)
+	}
+	if err := db.Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check leave type name existence: %w", err)
+	}
+	return count > 0, nil
+}
+
+// IsInUse checks if a leave request was made for a leave type.
+func (r *leaveTypeRepositoryImpl) IsInUse(id uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.Model(&model.LeaveRequest{}).Where("leave_type_id = ?", id.String()).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check leave type usage: %w", err)
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// LocationRepository is the interface for location data access.
+type LocationRepository interface {
+	GetAll(searchQuery schema.LocationSearchQuery) ([]model.Location, error)
+	GetAllLocations() ([]model.Location, error)
+	GetByID(id uuid.UUID) (*model.Location, error)
+	Create(location *model.Location) error
+	Update(location *model.Location) error
+	Delete(id uuid.UUID) error
+	IsExistName(name string, parentID *string, excludeID uuid.UUID) (bool, error)
+	IsInUse(id uuid.UUID) (bool, error)
+	GetSubtreeIDs(rootIDs []string) ([]string, error)
+}
+
+// locationRepositoryImpl is the implementation of LocationRepository.
+type locationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewLocationRepository creates a new instance of LocationRepository.
+func NewLocationRepository(db *gorm.DB) LocationRepository {
+	return &locationRepositoryImpl{db: db}
+}
+
+// GetAll retrieves locations with pagination.
+func (r *locationRepositoryImpl) GetAll(searchQuery schema.LocationSearchQuery) ([]model.Location, error) {
+	var locations []model.Location
+	query := r.db.Model(&model.Location{})
+
+	if searchQuery.Name != "" {
+		query = query.Where("name ILIKE ?", "%"+searchQuery.Name+"%")
+	}
+	if searchQuery.Type != "" {
+		query = query.Where("type = ?", searchQuery.Type)
+	}
+	if searchQuery.ParentID != "" {
+		query = query.Where("parent_id = ?", searchQuery.ParentID)
+	}
+
+	offset := (searchQuery.Page - 1) * searchQuery.Limit
+	if err := query.Order("name").Offset(offset).Limit(searchQuery.Limit).Find(&locations).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve paginated locations: %w", err)
+	}
+	return locations, nil
+}
+
+// GetAllLocations retrieves every location, for building the tree and the paths of locations.
+func (r *locationRepositoryImpl) GetAllLocations() ([]model.Location, error) {
+	var locations []model.Location
+	if err := r.db.Order("name").Find(&locations).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve locations: %w", err)
+	}
+	return locations, nil
+}
+
+// GetByID retrieves a location by its ID.
+func (r *locationRepositoryImpl) GetByID(id uuid.UUID) (*model.Location, error) {
+	var location model.Location
+	if err := r.db.First(&location, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &location, nil
+}
+
+// Create inserts a new location.
+func (r *locationRepositoryImpl) Create(location *model.Location) error {
+	return translateError(r.db.Create(location).Error)
+}
+
+// Update updates a location.
+func (r *locationRepositoryImpl) Update(location *model.Location) error {
+	return translateError(r.db.Save(location).Error)
+}
+
+// Delete deletes a location by its ID.
+func (r *locationRepositoryImpl) Delete(id uuid.UUID) error {
+	return r.db.Unscoped().Where("id = ?", id).Delete(&model.Location{}).Error
+}
+
+// IsExistName checks if a location with the given name exists under the same parent.
+func (r *locationRepositoryImpl) IsExistName(name string, parentID *string, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	db := r.db.Model(&model.Location{}).Where("name = ? AND deleted_at IS NULL", name)
+	if parentID != nil {
+		db = db.Where("parent_id = ?", *parentID)
+	} else {
+		db = db.Where("parent_id IS NULL")
+	}
+	if excludeID != uuid.Nil {
+		db = db.Where("id != ?", excludeID)
+	}
+	if err := db.Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check location existence: %w", err)
+	}
+	return count > 0, nil
+}
+
+// IsInUse checks if a location has child locations, devices or access control groups.
+func (r *locationRepositoryImpl) IsInUse(id uuid.UUID) (bool, error) {
+	checks := []struct {
+		model  interface{}
+		column string
+	}{
+		{&model.Location{}, "parent_id"},
+		{&model.AccessControlDevice{}, "location_id"},
+		{&model.AccessControlGroupLocation{}, "location_id"},
+	}
+	for _, check := range checks {
+		var count int64
+		if err := r.db.Model(check.model).Where(check.column+" = ?", id.String()).Count(&count).Error; err != nil {
+			return false, fmt.Errorf("failed to check location usage: %w", err)
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetSubtreeIDs returns the IDs of the given locations and of every location below them.
+func (r *locationRepositoryImpl) GetSubtreeIDs(rootIDs []string) ([]string, error) {
+	return locationSubtreeIDs(r.db, rootIDs)
+}
+
+// locationSubtreeIDs returns rootIDs with the IDs of every location below them. The tree is walked
+// in Go rather than with a recursive query so the locations stay filtered by the tenant.
+func locationSubtreeIDs(db *gorm.DB, rootIDs []string) ([]string, error) {
+	if len(rootIDs) == 0 {
+		return nil, nil
+	}
+	var locations []model.Location
+	if err := db.Select("id", "parent_id").Where("parent_id IS NOT NULL").Find(&locations).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve locations: %w", err)
+	}
+	children := make(map[string][]string)
+	for _, location := range locations {
+		children[*location.ParentID] = append(children[*location.ParentID], location.ID.String())
+	}
+
+	seen := make(map[string]bool)
+	var ids []string
+	queue := append([]string{}, rootIDs...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+		queue = append(queue, children[id]...)
+	}
+	return ids, nil
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// OvertimeRuleRepository is the interface for overtime rule data access.
+type OvertimeRuleRepository interface {
+	GetAll(searchQuery schema.OvertimeRuleSearchQuery) ([]model.OvertimeRule, error)
+	GetByID(id uuid.UUID) (*model.OvertimeRule, error)
+	Create(rule *model.OvertimeRule) error
+	Update(rule *model.OvertimeRule) error
+	Delete(id uuid.UUID) error
+	IsExistName(name string, excludeID uuid.UUID) (bool, error)
+}
+
+// overtimeRuleRepositoryImpl is the implementation of OvertimeRuleRepository.
+type overtimeRuleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewOvertimeRuleRepository creates a new instance of OvertimeRuleRepository.
+func NewOvertimeRuleRepository(db *gorm.DB) OvertimeRuleRepository {
+	return &overtimeRuleRepositoryImpl{db: db}
+}
+
+// GetAll retrieves overtime rules with pagination.
+func (r *overtimeRuleRepositoryImpl) GetAll(searchQuery schema.OvertimeRuleSearchQuery) ([]model.OvertimeRule, error) {
+	var rules []model.OvertimeRule
+	query := r.db.Model(&model.OvertimeRule{})
+
+	if searchQuery.Name != "" {
+		query = query.Where("name ILIKE ?", "%"+searchQuery.Name+"%")
+	}
+
+	offset := (searchQuery.Page - 1) * searchQuery.Limit
+	if err := query.Order("name").Offset(offset).Limit(searchQuery.Limit).Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve paginated overtime rules: %w", err)
+	}
+	return rules, nil
+}
+
+// GetByID retrieves an overtime rule by its ID.
+func (r *overtimeRuleRepositoryImpl) GetByID(id uuid.UUID) (*model.OvertimeRule, error) {
+	var rule model.OvertimeRule
+	if err := r.db.First(&rule, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// Create inserts a new overtime rule.
+func (r *overtimeRuleRepositoryImpl) Create(rule *model.OvertimeRule) error {
+	return translateError(r.db.Create(rule).Error)
+}
+
+// Update updates an overtime rule.
+func (r *overtimeRuleRepositoryImpl) Update(rule *model.OvertimeRule) error {
+	return translateError(r.db.Save(rule).Error)
+}
+
+// Delete deletes an overtime rule and detaches it from attendance profiles.
+func (r *overtimeRuleRepositoryImpl) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Attendance{}).Where("overtime_rule_id = ?", id.String()).Update("overtime_rule_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("id = ?", id).Delete(&model.OvertimeRule{}).Error
+	})
+}
+
+// IsExistName checks if an overtime rule with the given name exists.
+func (r *overtimeRuleRepositoryImpl) IsExistName(name string, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	db := r.db.Model(&model.OvertimeRule{}).Where("name = ? AND deleted_at IS NULL", name)
+	if excludeID != uuid.Nil {
+		db = db.Where("id != ?", excludeID)
+	}
+	if err := db.Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check overtime rule name existence: %w", err)
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// PayrollExportRepository is the interface for generated payroll export data access.
+type PayrollExportRepository interface {
+	GetAll(searchQuery schema.PayrollExportSearchQuery) ([]model.PayrollExport, error)
+	GetByID(id uuid.UUID) (*model.PayrollExport, error)
+	Create(export *model.PayrollExport) error
+	Update(export *model.PayrollExport) error
+	Delete(id uuid.UUID) error
+	GetLockedOverlapping(templateID string, company *string, startDate string, endDate string) (*model.PayrollExport, error)
+	FlagChangedAfterLock(personID string, date string, changedAt time.Time) error
+
+	// Line relationship methods
+	GetLinesByExportID(exportID uuid.UUID) ([]model.PayrollExportLine, error)
+	CreateLines(lines []model.PayrollExportLine) error
+}
+
+// payrollExportRepositoryImpl is the implementation of PayrollExportRepository.
+type payrollExportRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewPayrollExportRepository creates a new instance of PayrollExportRepository.
+func NewPayrollExportRepository(db *gorm.DB) PayrollExportRepository {
+	return &payrollExportRepositoryImpl{db: db}
+}
+
+// GetAll retrieves payroll exports, latest period first, with pagination.
+func (r *payrollExportRepositoryImpl) GetAll(searchQuery schema.PayrollExportSearchQuery) ([]model.PayrollExport, error) {
+	var exports []model.PayrollExport
+	query := r.db.Model(&model.PayrollExport{})
+
+	if searchQuery.TemplateID != "" {
+		query = query.Where("template_id = ?", searchQuery.TemplateID)
+	}
+	if searchQuery.Company != "" {
+		query = query.Where("company = ?", searchQuery.Company)
+	}
+	if searchQuery.Status != "" {
+		query = query.Where("status = ?", searchQuery.Status)
+	}
+
+	offset := (searchQuery.Page - 1) * searchQuery.Limit
+	if err := query.Order("start_date DESC").Order("created_at DESC").Offset(offset).Limit(searchQuery.Limit).Find(&exports).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve paginated payroll exports: %w", err)
+	}
+	return exports, nil
+}
+
+// GetByID retrieves a payroll export by its ID.
+func (r *payrollExportRepositoryImpl) GetByID(id uuid.UUID) (*model.PayrollExport, error) {
+	var export model.PayrollExport
+	if err := r.db.First(&export, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+// Create inserts a new payroll export.
+func (r *payrollExportRepositoryImpl) Create(export *model.PayrollExport) error {
+	return translateError(r.db.Create(export).Error)
+}
+
+// Update updates a payroll export.
+func (r *payrollExportRepositoryImpl) Update(export *model.PayrollExport) error {
+	return translateError(r.db.Save(export).Error)
+}
+
+// Delete deletes a payroll export and its lines.
+func (r *payrollExportRepositoryImpl) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("export_id = ?", id.String()).Delete(&model.PayrollExportLine{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("id = ?", id).Delete(&model.PayrollExport{}).Error
+	})
+}
+
+// GetLockedOverlapping retrieves a locked export of the same template and company whose period
+// overlaps the given one, nil when there is none.
+func (r *payrollExportRepositoryImpl) GetLockedOverlapping(templateID string, company *string, startDate string, endDate string) (*model.PayrollExport, error) {
+	var exports []model.PayrollExport
+	query := r.db.Where("template_id = ? AND status = ? AND start_date <= ? AND end_date >= ?", templateID, common.PayrollExportStatusLocked, endDate, startDate)
+	if company != nil {
+		query = query.Where("company = ?", *company)
+	} else {
+		query = query.Where("company IS NULL")
+	}
+	if err := query.Limit(1).Find(&exports).Error; err != nil {
+		return nil, fmt.Errorf("failed to check locked payroll exports: %w", err)
+	}
+	if len(exports) == 0 {
+		return nil, nil
+	}
+	return &exports[0], nil
+}
+
+// FlagChangedAfterLock marks the locked exports that include a person and a date as changed.
+func (r *payrollExportRepositoryImpl) FlagChangedAfterLock(personID string, date string, changedAt time.Time) error {
+	exportIDs := r.db.Model(&model.PayrollExportLine{}).Select("export_id").Where("person_id = ?", personID)
+	err := r.db.Model(&model.PayrollExport{}).
+		Where("status = ? AND start_date <= ? AND end_date >= ? AND id::text IN (?)", common.PayrollExportStatusLocked, date, date, exportIDs).
+		Updates(map[string]interface{}{"changed_after_lock": true, "changed_at": changedAt}).Error
+	if err != nil {
+		return fmt.Errorf("failed to flag locked payroll exports: %w", err)
+	}
+	return nil
+}
+
+// GetLinesByExportID retrieves the exported totals of every person of an export.
+func (r *payrollExportRepositoryImpl) GetLinesByExportID(exportID uuid.UUID) ([]model.PayrollExportLine, error) {
+	var lines []model.PayrollExportLine
+	err := r.db.Where("export_id = ?", exportID.String()).Find(&lines).Error
+	return lines, err
+}
+
+// CreateLines inserts multiple export lines.
+func (r *payrollExportRepositoryImpl) CreateLines(lines []model.PayrollExportLine) error {
+	if len(lines) == 0 {
+		return nil
+	}
+	return translateError(r.db.Create(&lines).Error)
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// PayrollExportTemplateRepository is the interface for payroll export template data access.
+type PayrollExportTemplateRepository interface {
+	GetAll(searchQuery schema.PayrollExportTemplateSearchQuery) ([]model.PayrollExportTemplate, error)
+	GetByID(id uuid.UUID) (*model.PayrollExportTemplate, error)
+	Create(template *model.PayrollExportTemplate) error
+	Update(template *model.PayrollExportTemplate) error
+	Delete(id uuid.UUID) error
+	IsExistName(name string, company *string, excludeID uuid.UUID) (bool, error)
+
+	// Column relationship methods
+	GetColumnsByTemplateID(templateID uuid.UUID) ([]model.PayrollExportColumn, error)
+	CreateColumns(columns []model.PayrollExportColumn) error
+	DeleteColumnsByTemplateID(templateID uuid.UUID, tx *gorm.DB) error
+}
+
+// payrollExportTemplateRepositoryImpl is the implementation of PayrollExportTemplateRepository.
+type payrollExportTemplateRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewPayrollExportTemplateRepository creates a new instance of PayrollExportTemplateRepository.
+func NewPayrollExportTemplateRepository(db *gorm.DB) PayrollExportTemplateRepository {
+	return &payrollExportTemplateRepositoryImpl{db: db}
+}
+
+// GetAll retrieves payroll export templates with pagination.
+func (r *payrollExportTemplateRepositoryImpl) GetAll(searchQuery schema.PayrollExportTemplateSearchQuery) ([]model.PayrollExportTemplate, error) {
+	var templates []model.PayrollExportTemplate
+	query := r.db.Model(&model.PayrollExportTemplate{})
+
+	if searchQuery.Name != "" {
+		query = query.Where("name ILIKE ?", "%"+searchQuery.Name+"%")
+	}
+	if searchQuery.Company != "" {
+		query = query.Where("company = ?", searchQuery.Company)
+	}
+
+	offset := (searchQuery.Page - 1) * searchQuery.Limit
+	if err := query.Order("company").Order("name").Offset(offset).Limit(searchQuery.Limit).Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve paginated payroll export templates: %w", err)
+	}
+	return templates, nil
+}
+
+// GetByID retrieves a payroll export template by its ID.
+func (r *payrollExportTemplateRepositoryImpl) GetByID(id uuid.UUID) (*model.PayrollExportTemplate, error) {
+	var template model.PayrollExportTemplate
+	if err := r.db.First(&template, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// Create inserts a new payroll export template.
+func (r *payrollExportTemplateRepositoryImpl) Create(template *model.PayrollExportTemplate) error {
+	return translateError(r.db.Create(template).Error)
+}
+
+// Update updates a payroll export template.
+func (r *payrollExportTemplateRepositoryImpl) Update(template *model.PayrollExportTemplate) error {
+	return translateError(r.db.Save(template).Error)
+}
+
+// Delete deletes a payroll export template and its columns. Generated exports keep the template name.
+func (r *payrollExportTemplateRepositoryImpl) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.DeleteColumnsByTemplateID(id, tx); err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("id = ?", id).Delete(&model.PayrollExportTemplate{}).Error
+	})
+}
+
+// IsExistName checks if a template with the given name exists for the same company.
+func (r *payrollExportTemplateRepositoryImpl) IsExistName(name string, company *string, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	db := r.db.Model(&model.PayrollExportTemplate{}).Where("name = ? AND deleted_at IS NULL", name)
+	if company != nil {
+		db = db.Where("company = ?", *company)
+	} else {
+		db = db.Where("company IS NULL")
+	}
+	if excludeID != uuid.Nil {
+		db = db.Where("id != ?", excludeID)
+	}
+	if err := db.Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check payroll export template name existence: %w", err)
+	}
+	return count > 0, nil
+}
+
+// GetColumnsByTemplateID retrieves the columns of a template in file order.
+func (r *payrollExportTemplateRepositoryImpl) GetColumnsByTemplateID(templateID uuid.UUID) ([]model.PayrollExportColumn, error) {
+	var columns []model.PayrollExportColumn
+	err := r.db.Where("template_id = ?", templateID).Order("position").Find(&columns).Error
+	return columns, err
+}
+
+// CreateColumns inserts multiple template columns.
+func (r *payrollExportTemplateRepositoryImpl) CreateColumns(columns []model.PayrollExportColumn) error {
+	if len(columns) == 0 {
+		return nil
+	}
+	return translateError(r.db.Create(&columns).Error)
+}
+
+// DeleteColumnsByTemplateID deletes all columns of a template.
+func (r *payrollExportTemplateRepositoryImpl) DeleteColumnsByTemplateID(templateID uuid.UUID, tx *gorm.DB) error {
+	if tx == nil {
+		tx = r.db
+	}
+	return tx.Unscoped().Where("template_id = ?", templateID).Delete(&model.PayrollExportColumn{}).Error
+}
@@ -2,9 +2,10 @@ package repository
 
 import (
 	"fmt"
-	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
 	"github.com/putteror/access-control-management/internal/app/model"
 	"github.com/putteror/access-control-management/internal/app/schema"
 	"gorm.io/gorm"
@@ -16,23 +17,49 @@ import (
 type PersonRepository interface {
 	GetAll(searchQuery schema.PersonSearchQuery) ([]model.Person, error)
 	GetByID(id uuid.UUID) (*model.Person, error)
+	GetByPersonID(personID string) (*model.Person, error)
+	GetByEmail(email string) (*model.Person, error)
+	GetWithTimeAttendance() ([]model.Person, error)
+	GetExpired(personType string, before time.Time) ([]model.Person, error)
+	GetExpiredWithFaceImage(before time.Time) ([]model.Person, error)
 	Create(person *model.Person) error
 	Update(id string, person *model.Person) error
+	UpdatePINHash(id string, pinHash *string) error
+	RecordFailedPIN(id string, maxAttempts int, lockedUntil time.Time) error
+	ResetFailedPINs(id string) error
+	ClearFaceImage(id string) error
 	Delete(id uuid.UUID) error
+	Erase(id uuid.UUID, erasedAt time.Time) error
 	IsExistPersonID(personID string, excludeID uuid.UUID) (bool, error)
+	IsExistFaceImagePath(imagePath string) (bool, error)
 	IsExistName(firstName string, lastName string, excludeID uuid.UUID) (bool, error)
+	ReEncrypt(prefix string) (int, error)
+	Reindex() (int, error)
 }
 
 // PersonCardRepository is the interface for person card data access.
 type PersonCardRepository interface {
 	Create(cards []model.PersonCard) error
+	GetByID(id uuid.UUID) (*model.PersonCard, error)
+	GetByCardNumber(cardNumber string) (*model.PersonCard, error)
+	GetByPersonID(personID string) ([]model.PersonCard, error)
 	GetCardNumbersByPersonID(personID string) ([]string, error)
+	Update(card *model.PersonCard) error
+	Delete(id uuid.UUID) error
 	DeleteByPersonID(personID string) error
+	IsExistCardNumber(cardNumber string, excludeID uuid.UUID) (bool, error)
+	CreateHistory(histories []model.PersonCardHistory) error
+	GetHistoryByPersonID(personID string) ([]model.PersonCardHistory, error)
+	GetHistoryByCardID(cardID string) ([]model.PersonCardHistory, error)
+	DeleteHistoryByPersonID(personID string) error
 }
 
 // PersonLicensePlateRepository is the interface for person license plate data access.
 type PersonLicensePlateRepository interface {
 	Create(plates []model.PersonLicensePlate) error
+	GetByNormalizedPlateText(normalizedPlateText string) ([]model.PersonLicensePlate, error)
+	GetFuzzyCandidates(normalizedPlateText string) ([]model.PersonLicensePlate, bool, error)
+	SetMissingNormalizedPlateTexts() (int, error)
 	GetLicensePlateTextsByPersonID(personID string) ([]string, error)
 	DeleteByPersonID(personID string) error
 }
@@ -93,11 +120,12 @@ func (r *personRepositoryImpl) GetAll(searchQuery schema.PersonSearchQuery) ([]m
 	if searchQuery.JobPosition != "" {
 		query = query.Where("job_position ILIKE ?", "%"+searchQuery.JobPosition+"%")
 	}
+	// Mobile number and email are encrypted, so they only match exactly through their blind index
 	if searchQuery.MobileNumber != "" {
-		query = query.Where("mobile_number ILIKE ?", "%"+searchQuery.MobileNumber+"%")
+		query = query.Where("mobile_number_index = ?", common.BlindIndex(&searchQuery.MobileNumber))
 	}
 	if searchQuery.Email != "" {
-		query = query.Where("email ILIKE ?", "%"+searchQuery.Email+"%")
+		query = query.Where("email_index = ?", common.BlindIndex(&searchQuery.Email))
 	}
 
 	if !searchQuery.All {
@@ -121,22 +149,111 @@ func (r *personRepositoryImpl) GetByID(id uuid.UUID) (*model.Person, error) {
 	return &person, nil
 }
 
+// GetByPersonID retrieves a person by its PersonID (case-insensitive).
+func (r *personRepositoryImpl) GetByPersonID(personID string) (*model.Person, error) {
+	var person model.Person
+	if err := r.db.First(&person, "person_id_index = ?", common.BlindIndex(&personID)).Error; err != nil {
+		return nil, err
+	}
+	return &person, nil
+}
+
+// GetByEmail retrieves a person by its email (case-insensitive).
+func (r *personRepositoryImpl) GetByEmail(email string) (*model.Person, error) {
+	var person model.Person
+	if err := r.db.First(&person, "email_index = ?", common.BlindIndex(&email)).Error; err != nil {
+		return nil, err
+	}
+	return &person, nil
+}
+
+// GetWithTimeAttendance retrieves every person that has an attendance profile.
+func (r *personRepositoryImpl) GetWithTimeAttendance() ([]model.Person, error) {
+	var people []model.Person
+	if err := r.db.Where("time_attendance_id IS NOT NULL AND time_attendance_id != ''").Find(&people).Error; err != nil {
+		return nil, err
+	}
+	return people, nil
+}
+
+// GetExpired retrieves the people of a type whose ExpireAt is before a time.
+func (r *personRepositoryImpl) GetExpired(personType string, before time.Time) ([]model.Person, error) {
+	var people []model.Person
+	if err := r.db.Where("person_type = ? AND expire_at < ?", personType, before).Find(&people).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve expired people: %w", err)
+	}
+	return people, nil
+}
+
+// GetExpiredWithFaceImage retrieves the people with a face image whose ExpireAt is before a time.
+func (r *personRepositoryImpl) GetExpiredWithFaceImage(before time.Time) ([]model.Person, error) {
+	var people []model.Person
+	err := r.db.Where("expire_at < ?", before).
+		Where("face_image_path IS NOT NULL AND face_image_path != ''").
+		Find(&people).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve expired people with a face image: %w", err)
+	}
+	return people, nil
+}
+
 // Create creates a new person record.
 func (r *personRepositoryImpl) Create(person *model.Person) error {
-	return r.db.Create(person).Error
+	setPersonBlindIndexes(person)
+	return translateError(r.db.Create(person).Error)
 }
 
 // Update updates an existing person record.
 func (r *personRepositoryImpl) Update(id string, person *model.Person) error {
+	setPersonBlindIndexes(person)
 	return r.db.Model(&model.Person{}).Where("id = ?", id).Updates(person).Error
 }
 
+// UpdatePINHash sets or, when pinHash is nil, clears the PIN of a person. A new PIN is no longer
+// locked.
+func (r *personRepositoryImpl) UpdatePINHash(id string, pinHash *string) error {
+	return r.db.Model(&model.Person{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"pin_hash":            pinHash,
+		"pin_failed_attempts": 0,
+		"pin_locked_until":    nil,
+	}).Error
+}
+
+// RecordFailedPIN counts a wrong PIN of a person. The maxAttempts-th wrong PIN in a row locks the
+// PIN until lockedUntil and starts the count again. The count is kept in the database so parallel
+// decisions cannot lose an attempt.
+func (r *personRepositoryImpl) RecordFailedPIN(id string, maxAttempts int, lockedUntil time.Time) error {
+	return r.db.Model(&model.Person{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"pin_failed_attempts": gorm.Expr("CASE WHEN pin_failed_attempts + 1 >= ? THEN 0 ELSE pin_failed_attempts + 1 END", maxAttempts),
+		"pin_locked_until":    gorm.Expr("CASE WHEN pin_failed_attempts + 1 >= ? THEN ? ELSE pin_locked_until END", maxAttempts, lockedUntil),
+	}).Error
+}
+
+// ResetFailedPINs clears the count of wrong PINs of a person after a right one.
+func (r *personRepositoryImpl) ResetFailedPINs(id string) error {
+	return r.db.Model(&model.Person{}).
+		Where("id = ? AND (pin_failed_attempts > 0 OR pin_locked_until IS NOT NULL)", id).
+		Updates(map[string]interface{}{"pin_failed_attempts": 0, "pin_locked_until": nil}).Error
+}
+
+// ClearFaceImage removes the face image paths of a person.
+func (r *personRepositoryImpl) ClearFaceImage(id string) error {
+	return r.db.Model(&model.Person{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"face_image_path":            nil,
+		"face_image_normalized_path": nil,
+		"face_image_thumbnail_path":  nil,
+	}).Error
+}
+
 // Delete deletes a person by its ID and all related records.
 func (r *personRepositoryImpl) Delete(id uuid.UUID) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		// Create repository instances with the transaction
 		txCardRepo := NewPersonCardRepository(tx)
 		txLicenseRepo := NewPersonLicensePlateRepository(tx)
+		txShiftRepo := NewPersonShiftRepository(tx)
+		txLeaveRepo := NewLeaveRequestRepository(tx)
+		txCorrectionRepo := NewAttendanceCorrectionRepository(tx)
 
 		// Delete related records first
 		if err := txCardRepo.DeleteByPersonID(id.String()); err != nil {
@@ -145,6 +262,15 @@ func (r *personRepositoryImpl) Delete(id uuid.UUID) error {
 		if err := txLicenseRepo.DeleteByPersonID(id.String()); err != nil {
 			return err
 		}
+		if err := txShiftRepo.DeleteByPersonID(id.String()); err != nil {
+			return err
+		}
+		if err := txLeaveRepo.DeleteByPersonID(id.String()); err != nil {
+			return err
+		}
+		if err := txCorrectionRepo.DeleteByPersonID(id.String()); err != nil {
+			return err
+		}
 
 		// Delete the person record itself
 		if err := tx.Unscoped().Where("id = ?", id).Delete(&model.Person{}).Error; err != nil {
@@ -154,10 +280,66 @@ func (r *personRepositoryImpl) Delete(id uuid.UUID) error {
 	})
 }
 
+// Erase anonymizes a person in place. Identifying fields, credentials, access rule and attendance
+// profile are cleared and cards, plates and form answers deleted, while the person row, company,
+// department and the access and attendance history stay for statistics.
+func (r *personRepositoryImpl) Erase(id uuid.UUID, erasedAt time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		txCardRepo := NewPersonCardRepository(tx)
+		txLicenseRepo := NewPersonLicensePlateRepository(tx)
+		txAnswerRepo := NewRegisterFormFieldAnswerRepository(tx)
+		txLeaveRepo := NewLeaveRequestRepository(tx)
+		txCorrectionRepo := NewAttendanceCorrectionRepository(tx)
+
+		if err := txCardRepo.DeleteByPersonID(id.String()); err != nil {
+			return err
+		}
+		if err := txCardRepo.DeleteHistoryByPersonID(id.String()); err != nil {
+			return err
+		}
+		if err := txLicenseRepo.DeleteByPersonID(id.String()); err != nil {
+			return err
+		}
+		if err := txAnswerRepo.DeleteByPersonID(id.String()); err != nil {
+			return err
+		}
+		if err := txLeaveRepo.AnonymizeByPersonID(id.String()); err != nil {
+			return err
+		}
+		if err := txCorrectionRepo.AnonymizeByPersonID(id.String()); err != nil {
+			return err
+		}
+
+		return tx.Model(&model.Person{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"first_name":                 "Erased",
+			"middle_name":                nil,
+			"last_name":                  id.String()[:8],
+			"person_id":                  nil,
+			"person_id_index":            nil,
+			"gender":                     nil,
+			"date_of_birth":              nil,
+			"address":                    nil,
+			"mobile_number":              nil,
+			"mobile_number_index":        nil,
+			"email":                      nil,
+			"email_index":                nil,
+			"face_image_path":            nil,
+			"face_image_normalized_path": nil,
+			"face_image_thumbnail_path":  nil,
+			"pin_hash":                   nil,
+			"is_verified":                false,
+			"expire_at":                  erasedAt,
+			"access_control_rule_id":     nil,
+			"time_attendance_id":         nil,
+			"erased_at":                  erasedAt,
+		}).Error
+	})
+}
+
 // IsExistPersonID checks if a person with the given PersonID exists.
 func (r *personRepositoryImpl) IsExistPersonID(personID string, excludeID uuid.UUID) (bool, error) {
 	var count int64
-	db := r.db.Model(&model.Person{}).Where("person_id = ? AND deleted_at IS NULL", strings.ToLower(personID))
+	db := r.db.Model(&model.Person{}).Where("person_id_index = ? AND deleted_at IS NULL", common.BlindIndex(&personID))
 	if excludeID != uuid.Nil {
 		db = db.Where("id != ?", excludeID)
 	}
@@ -167,6 +349,18 @@ func (r *personRepositoryImpl) IsExistPersonID(personID string, excludeID uuid.U
 	return count > 0, nil
 }
 
+// IsExistFaceImagePath checks if a person has an image as one of their face image variants.
+func (r *personRepositoryImpl) IsExistFaceImagePath(imagePath string) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.Person{}).
+		Where("face_image_path = ? OR face_image_normalized_path = ? OR face_image_thumbnail_path = ?", imagePath, imagePath, imagePath).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check face image path existence: %w", err)
+	}
+	return count > 0, nil
+}
+
 // IsExistName checks if a person with the given first name and last name exists.
 func (r *personRepositoryImpl) IsExistName(firstName string, lastName string, excludeID uuid.UUID) (bool, error) {
 	var count int64
@@ -180,6 +374,26 @@ func (r *personRepositoryImpl) IsExistName(firstName string, lastName string, ex
 	return count > 0, nil
 }
 
+// ReEncrypt rewrites the people whose personal data is not encrypted with the key of prefix and
+// recomputes their blind indexes. It returns the number of people rewritten.
+func (r *personRepositoryImpl) ReEncrypt(prefix string) (int, error) {
+	columns := []string{"person_id", "address", "mobile_number", "email"}
+	return reEncryptRows(r.db, prefix, columns, setPersonBlindIndexes, "person_id_index", "mobile_number_index", "email_index")
+}
+
+// Reindex recomputes the blind indexes of every person with the current index key and returns the
+// number of people rewritten.
+func (r *personRepositoryImpl) Reindex() (int, error) {
+	return reindexRows(r.db, setPersonBlindIndexes, "person_id_index", "mobile_number_index", "email_index")
+}
+
+// setPersonBlindIndexes computes the blind indexes of the encrypted lookup fields of a person.
+func setPersonBlindIndexes(person *model.Person) {
+	person.PersonIDIndex = common.BlindIndex(person.PersonID)
+	person.EmailIndex = common.BlindIndex(person.Email)
+	person.MobileNumberIndex = common.BlindIndex(person.MobileNumber)
+}
+
 // --- PersonCardRepository Methods ---
 
 // Create inserts multiple PersonCard records.
@@ -187,7 +401,7 @@ func (r *personCardRepositoryImpl) Create(cards []model.PersonCard) error {
 	if len(cards) == 0 {
 		return nil
 	}
-	return r.db.Create(&cards).Error
+	return translateError(r.db.Create(&cards).Error)
 }
 
 // GetCardNumbersByPersonID retrieves card numbers for a person.
@@ -200,11 +414,93 @@ func (r *personCardRepositoryImpl) GetCardNumbersByPersonID(personID string) ([]
 	return cardNumbers, err
 }
 
+// GetByID retrieves a person card by its ID.
+func (r *personCardRepositoryImpl) GetByID(id uuid.UUID) (*model.PersonCard, error) {
+	var card model.PersonCard
+	if err := r.db.First(&card, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+// GetByCardNumber retrieves a person card by its card number.
+func (r *personCardRepositoryImpl) GetByCardNumber(cardNumber string) (*model.PersonCard, error) {
+	var card model.PersonCard
+	if err := r.db.First(&card, "card_number = ?", cardNumber).Error; err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+// GetByPersonID retrieves every card of a person, oldest first.
+func (r *personCardRepositoryImpl) GetByPersonID(personID string) ([]model.PersonCard, error) {
+	var cards []model.PersonCard
+	if err := r.db.Where("person_id = ?", personID).Order("created_at").Find(&cards).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve person cards: %w", err)
+	}
+	return cards, nil
+}
+
+// Update updates a person card.
+func (r *personCardRepositoryImpl) Update(card *model.PersonCard) error {
+	return translateError(r.db.Save(card).Error)
+}
+
+// Delete deletes a person card by its ID.
+// The row is removed for good so the card number can be issued again.
+func (r *personCardRepositoryImpl) Delete(id uuid.UUID) error {
+	return r.db.Unscoped().Where("id = ?", id).Delete(&model.PersonCard{}).Error
+}
+
 // DeleteByPersonID deletes all person cards for a given person ID.
 func (r *personCardRepositoryImpl) DeleteByPersonID(personID string) error {
 	return r.db.Unscoped().Where("person_id = ?", personID).Delete(&model.PersonCard{}).Error
 }
 
+// IsExistCardNumber checks if a card with the given number exists.
+func (r *personCardRepositoryImpl) IsExistCardNumber(cardNumber string, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	db := r.db.Model(&model.PersonCard{}).Where("card_number = ?", cardNumber)
+	if excludeID != uuid.Nil {
+		db = db.Where("id != ?", excludeID)
+	}
+	if err := db.Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check card number existence: %w", err)
+	}
+	return count > 0, nil
+}
+
+// CreateHistory inserts multiple PersonCardHistory records.
+func (r *personCardRepositoryImpl) CreateHistory(histories []model.PersonCardHistory) error {
+	if len(histories) == 0 {
+		return nil
+	}
+	return translateError(r.db.Create(&histories).Error)
+}
+
+// GetHistoryByPersonID retrieves the card history of a person, newest first.
+func (r *personCardRepositoryImpl) GetHistoryByPersonID(personID string) ([]model.PersonCardHistory, error) {
+	var histories []model.PersonCardHistory
+	if err := r.db.Where("person_id = ?", personID).Order("created_at DESC").Find(&histories).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve person card history: %w", err)
+	}
+	return histories, nil
+}
+
+// DeleteHistoryByPersonID deletes the card history of a person.
+func (r *personCardRepositoryImpl) DeleteHistoryByPersonID(personID string) error {
+	return r.db.Unscoped().Where("person_id = ?", personID).Delete(&model.PersonCardHistory{}).Error
+}
+
+// GetHistoryByCardID retrieves the history of a card, newest first.
+func (r *personCardRepositoryImpl) GetHistoryByCardID(cardID string) ([]model.PersonCardHistory, error) {
+	var histories []model.PersonCardHistory
+	if err := r.db.Where("person_card_id = ?", cardID).Order("created_at DESC").Find(&histories).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve card history: %w", err)
+	}
+	return histories, nil
+}
+
 // --- PersonLicensePlateRepository Methods ---
 
 // Create inserts multiple PersonLicensePlate records.
@@ -212,7 +508,66 @@ func (r *personLicensePlateRepositoryImpl) Create(plates []model.PersonLicensePl
 	if len(plates) == 0 {
 		return nil
 	}
-	return r.db.Create(&plates).Error
+	return translateError(r.db.Create(&plates).Error)
+}
+
+// GetByNormalizedPlateText retrieves the registered plates with a normalized plate text.
+func (r *personLicensePlateRepositoryImpl) GetByNormalizedPlateText(normalizedPlateText string) ([]model.PersonLicensePlate, error) {
+	var plates []model.PersonLicensePlate
+	if err := r.db.Where("normalized_plate_text = ?", normalizedPlateText).Find(&plates).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve person license plates: %w", err)
+	}
+	return plates, nil
+}
+
+// GetFuzzyCandidates retrieves the registered plates that can be one edit away from a normalized
+// plate text: their length differs by at most one and they share its prefix or its suffix. At
+// most common.LicensePlateMaxFuzzyCandidates plates are returned; complete is false when there
+// are more, so the caller cannot tell which plates were left out.
+func (r *personLicensePlateRepositoryImpl) GetFuzzyCandidates(normalizedPlateText string) (plates []model.PersonLicensePlate, complete bool, err error) {
+	runes := []rune(normalizedPlateText)
+	if len(runes) < common.LicensePlateMinFuzzyLength {
+		return nil, true, nil
+	}
+	// Normalized plates only hold letters and digits, there is nothing to escape for LIKE
+	prefix := string(runes[:common.LicensePlateFuzzyAnchorLength])
+	suffix := string(runes[len(runes)-common.LicensePlateFuzzyAnchorLength:])
+
+	err = r.db.Where("char_length(normalized_plate_text) BETWEEN ? AND ?", len(runes)-1, len(runes)+1).
+		Where("normalized_plate_text LIKE ? OR normalized_plate_text LIKE ?", prefix+"%", "%"+suffix).
+		Order("normalized_plate_text").Order("id").
+		Limit(common.LicensePlateMaxFuzzyCandidates + 1).
+		Find(&plates).Error
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to retrieve person license plates: %w", err)
+	}
+	if len(plates) > common.LicensePlateMaxFuzzyCandidates {
+		return plates[:common.LicensePlateMaxFuzzyCandidates], false, nil
+	}
+	return plates, true, nil
+}
+
+// SetMissingNormalizedPlateTexts sets the normalized plate text of the registered plates of every
+// tenant that have none, as left by the migration that added it.
+func (r *personLicensePlateRepositoryImpl) SetMissingNormalizedPlateTexts() (int, error) {
+	updated := 0
+	for {
+		var plates []model.PersonLicensePlate
+		err := allTenants(r.db).Unscoped().Where("normalized_plate_text IS NULL").Limit(common.LicensePlateRekeyBatchSize).Find(&plates).Error
+		if err != nil {
+			return updated, fmt.Errorf("failed to retrieve person license plates without a normalized plate text: %w", err)
+		}
+		if len(plates) == 0 {
+			return updated, nil
+		}
+		for _, plate := range plates {
+			err := allTenants(r.db).Unscoped().Model(&plate).UpdateColumn("normalized_plate_text", common.NormalizeLicensePlate(plate.LicensePlateText)).Error
+			if err != nil {
+				return updated, fmt.Errorf("failed to set normalized plate text of license plate %s: %w", plate.ID, err)
+			}
+			updated++
+		}
+	}
 }
 
 // GetLicensePlateTextsByPersonID retrieves license plate texts for a person.
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// PersonShiftRepository is the interface for the shift assignments and overrides of people.
+type PersonShiftRepository interface {
+	GetAssignmentsByPersonID(personID string) ([]model.PersonShiftAssignment, error)
+	GetAssignmentByID(id uuid.UUID) (*model.PersonShiftAssignment, error)
+	CreateAssignment(assignment *model.PersonShiftAssignment) error
+	DeleteAssignment(id uuid.UUID) error
+	DeleteByPersonID(personID string) error
+
+	GetOverridesByPersonID(personID string) ([]model.PersonShiftOverride, error)
+	GetOverrideByID(id uuid.UUID) (*model.PersonShiftOverride, error)
+	GetOverrideByPersonAndDate(personID string, date string) (*model.PersonShiftOverride, error)
+	SaveOverride(override *model.PersonShiftOverride) error
+	DeleteOverride(id uuid.UUID) error
+}
+
+// personShiftRepositoryImpl is the implementation of PersonShiftRepository.
+type personShiftRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewPersonShiftRepository creates a new instance of PersonShiftRepository.
+func NewPersonShiftRepository(db *gorm.DB) PersonShiftRepository {
+	return &personShiftRepositoryImpl{db: db}
+}
+
+// GetAssignmentsByPersonID retrieves the rotation assignments of a person ordered by start date.
+func (r *personShiftRepositoryImpl) GetAssignmentsByPersonID(personID string) ([]model.PersonShiftAssignment, error) {
+	var assignments []model.PersonShiftAssignment
+	err := r.db.Where("person_id = ?", personID).Order("start_date").Find(&assignments).Error
+	return assignments, err
+}
+
+// GetAssignmentByID retrieves a rotation assignment by its ID.
+func (r *personShiftRepositoryImpl) GetAssignmentByID(id uuid.UUID) (*model.PersonShiftAssignment, error) {
+	var assignment model.PersonShiftAssignment
+	if err := r.db.First(&assignment, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+// CreateAssignment inserts a new rotation assignment.
+func (r *personShiftRepositoryImpl) CreateAssignment(assignment *model.PersonShiftAssignment) error {
+	return translateError(r.db.Create(assignment).Error)
+}
+
+// DeleteAssignment deletes a rotation assignment by its ID.
+func (r *personShiftRepositoryImpl) DeleteAssignment(id uuid.UUID) error {
+	return r.db.Unscoped().Where("id = ?", id).Delete(&model.PersonShiftAssignment{}).Error
+}
+
+// GetOverridesByPersonID retrieves the shift overrides of a person ordered by date.
+func (r *personShiftRepositoryImpl) GetOverridesByPersonID(personID string) ([]model.PersonShiftOverride, error) {
+	var overrides []model.PersonShiftOverride
+	err := r.db.Where("person_id = ?", personID).Order("date").Find(&overrides).Error
+	return overrides, err
+}
+
+// GetOverrideByID retrieves a shift override by its ID.
+func (r *personShiftRepositoryImpl) GetOverrideByID(id uuid.UUID) (*model.PersonShiftOverride, error) {
+	var override model.PersonShiftOverride
+	if err := r.db.First(&override, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &override, nil
+}
+
+// GetOverrideByPersonAndDate retrieves the shift override of a person on a date.
+func (r *personShiftRepositoryImpl) GetOverrideByPersonAndDate(personID string, date string) (*model.PersonShiftOverride, error) {
+	var override model.PersonShiftOverride
+	if err := r.db.First(&override, "person_id = ? AND date = ?", personID, date).Error; err != nil {
+		return nil, err
+	}
+	return &override, nil
+}
+
+// SaveOverride creates or updates a shift override.
+func (r *personShiftRepositoryImpl) SaveOverride(override *model.PersonShiftOverride) error {
+	return translateError(r.db.Save(override).Error)
+}
+
+// DeleteOverride deletes a shift override by its ID.
+func (r *personShiftRepositoryImpl) DeleteOverride(id uuid.UUID) error {
+	return r.db.Unscoped().Where("id = ?", id).Delete(&model.PersonShiftOverride{}).Error
+}
+
+// DeleteByPersonID deletes the shift assignments and overrides of a person.
+func (r *personShiftRepositoryImpl) DeleteByPersonID(personID string) error {
+	if err := r.db.Unscoped().Where("person_id = ?", personID).Delete(&model.PersonShiftAssignment{}).Error; err != nil {
+		return err
+	}
+	return r.db.Unscoped().Where("person_id = ?", personID).Delete(&model.PersonShiftOverride{}).Error
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/putteror/access-control-management/internal/app/model"
+	"gorm.io/gorm"
+)
+
+// RegisterFormFieldAnswerRepository is the interface for register form answer data access.
+type RegisterFormFieldAnswerRepository interface {
+	GetByPersonID(personID string) ([]model.RegisterFormFieldAnswer, error)
+	DeleteByPersonID(personID string) error
+}
+
+// registerFormFieldAnswerRepositoryImpl is the implementation of RegisterFormFieldAnswerRepository.
+type registerFormFieldAnswerRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewRegisterFormFieldAnswerRepository creates a new instance of RegisterFormFieldAnswerRepository.
+func NewRegisterFormFieldAnswerRepository(db *gorm.DB) RegisterFormFieldAnswerRepository {
+	return &registerFormFieldAnswerRepositoryImpl{db: db}
+}
+
+// GetByPersonID retrieves the register form answers of a person.
+func (r *registerFormFieldAnswerRepositoryImpl) GetByPersonID(personID string) ([]model.RegisterFormFieldAnswer, error) {
+	var answers []model.RegisterFormFieldAnswer
+	if err := r.db.Where("person_id = ?", personID).Order("created_at").Find(&answers).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve register form answers: %w", err)
+	}
+	return answers, nil
+}
+
+// DeleteByPersonID deletes the register form answers of a person.
+func (r *registerFormFieldAnswerRepositoryImpl) DeleteByPersonID(personID string) error {
+	return r.db.Unscoped().Where("person_id = ?", personID).Delete(&model.RegisterFormFieldAnswer{}).Error
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// RetentionRunRepository is the interface for retention run data access.
+type RetentionRunRepository interface {
+	GetAll(searchQuery schema.RetentionRunSearchQuery) ([]model.RetentionRun, error)
+	GetByID(id uuid.UUID) (*model.RetentionRun, error)
+	Create(run *model.RetentionRun) error
+	Update(run *model.RetentionRun) error
+
+	// Item relationship methods
+	GetItemsByRunID(runID uuid.UUID) ([]model.RetentionRunItem, error)
+	CreateItem(item *model.RetentionRunItem) error
+}
+
+// retentionRunRepositoryImpl is the implementation of RetentionRunRepository.
+type retentionRunRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewRetentionRunRepository creates a new instance of RetentionRunRepository.
+func NewRetentionRunRepository(db *gorm.DB) RetentionRunRepository {
+	return &retentionRunRepositoryImpl{db: db}
+}
+
+// GetAll retrieves retention runs, latest first, with pagination.
+func (r *retentionRunRepositoryImpl) GetAll(searchQuery schema.RetentionRunSearchQuery) ([]model.RetentionRun, error) {
+	var runs []model.RetentionRun
+	query := r.db.Model(&model.RetentionRun{})
+
+	if searchQuery.Trigger != "" {
+		query = query.Where("trigger = ?", searchQuery.Trigger)
+	}
+	if searchQuery.Status != "" {
+		query = query.Where("status = ?", searchQuery.Status)
+	}
+
+	offset := (searchQuery.Page - 1) * searchQuery.Limit
+	if err := query.Order("started_at DESC").Offset(offset).Limit(searchQuery.Limit).Find(&runs).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve paginated retention runs: %w", err)
+	}
+	return runs, nil
+}
+
+// GetByID retrieves a retention run by its ID.
+func (r *retentionRunRepositoryImpl) GetByID(id uuid.UUID) (*model.RetentionRun, error) {
+	var run model.RetentionRun
+	if err := r.db.First(&run, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// Create inserts a new retention run.
+func (r *retentionRunRepositoryImpl) Create(run *model.RetentionRun) error {
+	return translateError(r.db.Create(run).Error)
+}
+
+// Update updates a retention run.
+func (r *retentionRunRepositoryImpl) Update(run *model.RetentionRun) error {
+	return translateError(r.db.Save(run).Error)
+}
+
+// GetItemsByRunID retrieves what a retention run purged, in purge order.
+func (r *retentionRunRepositoryImpl) GetItemsByRunID(runID uuid.UUID) ([]model.RetentionRunItem, error) {
+	var items []model.RetentionRunItem
+	err := r.db.Where("run_id = ?", runID.String()).Order("created_at").Find(&items).Error
+	return items, err
+}
+
+// CreateItem inserts what a retention run purged of one data class.
+func (r *retentionRunRepositoryImpl) CreateItem(item *model.RetentionRunItem) error {
+	return translateError(r.db.Create(item).Error)
+}
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// S3Config holds the connection settings of an S3-compatible object storage (AWS S3, MinIO, ...).
+type S3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	UseSSL    bool
+}
+
+// s3FileRepo is the FileRepository implementation for S3-compatible object storage.
+// Requests use path-style addressing and AWS Signature Version 4.
+type s3FileRepo struct {
+	config S3Config
+	client *http.Client
+}
+
+// NewS3FileRepo creates a new instance of FileRepository backed by S3-compatible storage.
+func NewS3FileRepo(config S3Config) (FileRepository, error) {
+	if config.Endpoint == "" || config.Bucket == "" {
+		return nil, fmt.Errorf("s3 endpoint and bucket are required")
+	}
+	if config.AccessKey == "" || config.SecretKey == "" {
+		return nil, fmt.Errorf("s3 access key and secret key are required")
+	}
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+	return &s3FileRepo{
+		config: config,
+		client: &http.Client{Timeout: 60 * time.Second},
+	}, nil
+}
+
+// Save uploads a multipart file and returns its path.
+func (r *s3FileRepo) Save(fileHeader *multipart.FileHeader, folderPath string) (string, error) {
+	src, err := fileHeader.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open file header: %w", err)
+	}
+	defer src.Close()
+
+	return r.SaveReader(src, fileHeader.Filename, folderPath)
+}
+
+// SaveReader uploads the content of src and returns its path.
+// Only the extension of fileName is kept, the stored object gets a unique name.
+func (r *s3FileRepo) SaveReader(src io.Reader, fileName string, folderPath string) (string, error) {
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file content: %w", err)
+	}
+
+	filePath := path.Join("/", folderPath, uuid.New().String()+filepath.Ext(fileName))
+	resp, err := r.do(http.MethodPut, filePath, data, http.DetectContentType(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to upload file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to upload file: %s", readS3Error(resp))
+	}
+	return filePath, nil
+}
+
+// Open downloads a stored object. It returns os.ErrNotExist when the object does not exist.
+func (r *s3FileRepo) Open(filePath string) (io.ReadCloser, error) {
+	resp, err := r.do(http.MethodGet, filePath, nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, os.ErrNotExist
+	default:
+		defer resp.Body.Close()
+		return nil, fmt.Errorf("failed to download file: %s", readS3Error(resp))
+	}
+}
+
+// Delete removes a stored object. S3 does not report missing objects, deleting one succeeds.
+func (r *s3FileRepo) Delete(filePath string) error {
+	resp, err := r.do(http.MethodDelete, filePath, nil, "")
+	if err != nil {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	default:
+		return fmt.Errorf("failed to delete file: %s", readS3Error(resp))
+	}
+}
+
+// do sends a signed request for the object at filePath.
+func (r *s3FileRepo) do(method string, filePath string, body []byte, contentType string) (*http.Response, error) {
+	scheme := "http"
+	if r.config.UseSSL {
+		scheme = "https"
+	}
+	key := strings.TrimPrefix(path.Clean("/"+filePath), "/")
+	canonicalURI := "/" + s3URIEncode(r.config.Bucket) + "/" + s3URIEncode(key)
+
+	req, err := http.NewRequest(method, scheme+"://"+r.config.Endpoint+canonicalURI, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	r.sign(req, canonicalURI, body, time.Now().UTC())
+
+	return r.client.Do(req)
+}
+
+// sign adds the AWS Signature Version 4 headers to req.
+func (r *s3FileRepo) sign(req *http.Request, canonicalURI string, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	dateStamp := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI,
+		"", // no query string
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := dateStamp + "/" + r.config.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	signingKey := hmacSHA256([]byte("AWS4"+r.config.SecretKey), dateStamp)
+	signingKey = hmacSHA256(signingKey, r.config.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		r.config.AccessKey, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3URIEncode encodes a path the way AWS expects in the canonical request:
+// every byte except unreserved characters and '/' is percent-encoded.
+func s3URIEncode(value string) string {
+	var builder strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || c == '/' {
+			builder.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&builder, "%%%02X", c)
+	}
+	return builder.String()
+}
+
+func readS3Error(resp *http.Response) string {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Sprintf("%s %s", resp.Status, strings.TrimSpace(string(body)))
+}
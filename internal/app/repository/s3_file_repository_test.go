@@ -0,0 +1,352 @@
+package repository
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testS3Bucket    = "faces"
+	testS3Region    = "ap-southeast-1"
+	testS3AccessKey = "minio"
+	testS3SecretKey = "minio-secret"
+)
+
+var s3AuthorizationPattern = regexp.MustCompile(`^AWS4-HMAC-SHA256 Credential=([^/]+)/(\d{8})/([^/]+)/s3/aws4_request, SignedHeaders=([a-z0-9;-]+), Signature=([0-9a-f]{64})$`)
+
+// s3Stub is a MinIO-style object storage serving one bucket from memory. It checks the path-style
+// URL and the Signature Version 4 of every request the way the server does.
+type s3Stub struct {
+	t *testing.T
+
+	mu       sync.Mutex
+	objects  map[string][]byte
+	requests []*http.Request
+	// failWith makes every request fail with this status when it is set
+	failWith int
+}
+
+func newS3Stub(t *testing.T) (*s3Stub, *httptest.Server) {
+	stub := &s3Stub{t: t, objects: make(map[string][]byte)}
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+	return stub, server
+}
+
+func (s *s3Stub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		s.t.Errorf("failed to read request body: %v", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r)
+
+	if message := checkS3Signature(r, body, testS3SecretKey); message != "" {
+		writeS3Error(w, http.StatusForbidden, "SignatureDoesNotMatch", message)
+		return
+	}
+	if s.failWith != 0 {
+		writeS3Error(w, s.failWith, "InternalError", "We encountered an internal error, please try again.")
+		return
+	}
+
+	bucket, key, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if !ok || bucket != testS3Bucket || key == "" {
+		writeS3Error(w, http.StatusBadRequest, "InvalidRequest", "expected a path-style URL /"+testS3Bucket+"/<key>, got "+r.URL.Path)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		s.objects[key] = body
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		object, ok := s.objects[key]
+		if !ok {
+			writeS3Error(w, http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+			return
+		}
+		w.Write(object)
+	case http.MethodDelete:
+		// Like S3 and MinIO, deleting a missing key succeeds
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeS3Error(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed.")
+	}
+}
+
+func (s *s3Stub) lastRequest() *http.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[len(s.requests)-1]
+}
+
+func (s *s3Stub) failRequestsWith(status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failWith = status
+}
+
+func (s *s3Stub) objectCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.objects)
+}
+
+func (s *s3Stub) hasObject(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.objects[key]
+	return ok
+}
+
+func writeS3Error(w http.ResponseWriter, status int, code string, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	io.WriteString(w, "<Error><Code>"+code+"</Code><Message>"+message+"</Message></Error>")
+}
+
+// checkS3Signature verifies the Signature Version 4 of a request and returns why it is invalid,
+// or an empty string when it is valid.
+func checkS3Signature(r *http.Request, body []byte, secretKey string) string {
+	match := s3AuthorizationPattern.FindStringSubmatch(r.Header.Get("Authorization"))
+	if match == nil {
+		return "malformed Authorization header: " + r.Header.Get("Authorization")
+	}
+	accessKey, dateStamp, region, signedHeaders, signature := match[1], match[2], match[3], match[4], match[5]
+	if accessKey != testS3AccessKey {
+		return "unknown access key " + accessKey
+	}
+	if region != testS3Region {
+		return "unexpected region " + region
+	}
+
+	amzDate := r.Header.Get("X-Amz-Date")
+	requestTime, err := time.Parse("20060102T150405Z", amzDate)
+	if err != nil || !strings.HasPrefix(amzDate, dateStamp) {
+		return "invalid X-Amz-Date " + amzDate
+	}
+	if skew := time.Since(requestTime); skew > 15*time.Minute || skew < -15*time.Minute {
+		return "request time too skewed"
+	}
+	payloadSum := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(payloadSum[:])
+	if r.Header.Get("X-Amz-Content-Sha256") != payloadHash {
+		return "X-Amz-Content-Sha256 does not match the payload"
+	}
+
+	var canonicalHeaders strings.Builder
+	for _, name := range strings.Split(signedHeaders, ";") {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		r.URL.EscapedPath(),
+		r.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	canonicalSum := sha256.Sum256([]byte(canonicalRequest))
+	scope := dateStamp + "/" + region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalSum[:])
+
+	key := []byte("AWS4" + secretKey)
+	for _, part := range []string{dateStamp, region, "s3", "aws4_request", stringToSign} {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(part))
+		key = mac.Sum(nil)
+	}
+	if hex.EncodeToString(key) != signature {
+		return "The request signature we calculated does not match the signature you provided."
+	}
+	return ""
+}
+
+func newTestS3FileRepo(t *testing.T, server *httptest.Server, secretKey string) FileRepository {
+	repo, err := NewS3FileRepo(S3Config{
+		Endpoint:  strings.TrimPrefix(server.URL, "http://"),
+		Region:    testS3Region,
+		Bucket:    testS3Bucket,
+		AccessKey: testS3AccessKey,
+		SecretKey: secretKey,
+	})
+	if err != nil {
+		t.Fatalf("NewS3FileRepo() error = %v", err)
+	}
+	return repo
+}
+
+func TestS3FileRepoSaveOpenDelete(t *testing.T) {
+	stub, server := newS3Stub(t)
+	repo := newTestS3FileRepo(t, server, testS3SecretKey)
+
+	content := []byte("\xff\xd8\xff\xe0 face image")
+	filePath, err := repo.SaveReader(bytes.NewReader(content), "upload.JPG", "/images/faces/people")
+	if err != nil {
+		t.Fatalf("SaveReader() error = %v", err)
+	}
+	if !regexp.MustCompile(`^/images/faces/people/[0-9a-f-]{36}\.JPG$`).MatchString(filePath) {
+		t.Errorf("SaveReader() path = %q, want a unique name under /images/faces/people", filePath)
+	}
+	put := stub.lastRequest()
+	if put.Method != http.MethodPut || put.URL.Path != "/"+testS3Bucket+filePath {
+		t.Errorf("upload request = %s %s, want PUT /%s%s", put.Method, put.URL.Path, testS3Bucket, filePath)
+	}
+	if contentType := put.Header.Get("Content-Type"); contentType != "image/jpeg" {
+		t.Errorf("upload Content-Type = %q, want image/jpeg", contentType)
+	}
+
+	file, err := repo.Open(filePath)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	got, err := io.ReadAll(file)
+	file.Close()
+	if err != nil {
+		t.Fatalf("failed to read opened file: %v", err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Errorf("Open() content = %q, want %q", got, content)
+	}
+
+	if err := repo.Delete(filePath); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.Open(filePath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Open() of a deleted file error = %v, want os.ErrNotExist", err)
+	}
+	if err := repo.Delete(filePath); err != nil {
+		t.Errorf("Delete() of a deleted file error = %v, want nil", err)
+	}
+}
+
+func TestS3FileRepoSaveMultipart(t *testing.T) {
+	stub, server := newS3Stub(t)
+	repo := newTestS3FileRepo(t, server, testS3SecretKey)
+
+	var form bytes.Buffer
+	writer := multipart.NewWriter(&form)
+	part, err := writer.CreateFormFile("file", "plate.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write([]byte("\x89PNG\r\n\x1a\n plate"))
+	writer.Close()
+	parsed, err := multipart.NewReader(&form, writer.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filePath, err := repo.Save(parsed.File["file"][0], "images/license-plates/2024-05-01")
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if !strings.HasPrefix(filePath, "/images/license-plates/2024-05-01/") || !strings.HasSuffix(filePath, ".png") {
+		t.Errorf("Save() path = %q, want a .png under /images/license-plates/2024-05-01", filePath)
+	}
+	if !stub.hasObject(strings.TrimPrefix(filePath, "/")) {
+		t.Errorf("object %q was not stored", filePath)
+	}
+}
+
+func TestS3FileRepoEncodesKeys(t *testing.T) {
+	stub, server := newS3Stub(t)
+	repo := newTestS3FileRepo(t, server, testS3SecretKey)
+
+	// Keys are percent-encoded in the URL and the signature alike, the stub rejects a mismatch
+	if _, err := repo.SaveReader(strings.NewReader("report"), "payroll.csv", "exports/2024 05+final"); err != nil {
+		t.Fatalf("SaveReader() error = %v", err)
+	}
+	if escaped := stub.lastRequest().URL.EscapedPath(); !strings.HasPrefix(escaped, "/"+testS3Bucket+"/exports/2024%2005%2Bfinal/") {
+		t.Errorf("upload path = %q, want the folder percent-encoded", escaped)
+	}
+}
+
+func TestS3FileRepoSignsRequests(t *testing.T) {
+	stub, server := newS3Stub(t)
+	repo := newTestS3FileRepo(t, server, "wrong-secret")
+
+	_, err := repo.SaveReader(strings.NewReader("data"), "a.txt", "files")
+	if err == nil || !strings.Contains(err.Error(), "403") || !strings.Contains(err.Error(), "SignatureDoesNotMatch") {
+		t.Fatalf("SaveReader() with a wrong secret error = %v, want the 403 SignatureDoesNotMatch response", err)
+	}
+
+	request := stub.lastRequest()
+	match := s3AuthorizationPattern.FindStringSubmatch(request.Header.Get("Authorization"))
+	if match == nil {
+		t.Fatalf("Authorization = %q, want an AWS4-HMAC-SHA256 header", request.Header.Get("Authorization"))
+	}
+	if match[4] != "host;x-amz-content-sha256;x-amz-date" {
+		t.Errorf("SignedHeaders = %q, want host;x-amz-content-sha256;x-amz-date", match[4])
+	}
+	if count := stub.objectCount(); count != 0 {
+		t.Errorf("a request with a bad signature stored %d objects", count)
+	}
+}
+
+func TestS3FileRepoErrorResponses(t *testing.T) {
+	stub, server := newS3Stub(t)
+	repo := newTestS3FileRepo(t, server, testS3SecretKey)
+	filePath, err := repo.SaveReader(strings.NewReader("data"), "a.txt", "files")
+	if err != nil {
+		t.Fatalf("SaveReader() error = %v", err)
+	}
+
+	stub.failRequestsWith(http.StatusInternalServerError)
+	if _, err := repo.SaveReader(strings.NewReader("data"), "b.txt", "files"); err == nil || !strings.Contains(err.Error(), "500") || !strings.Contains(err.Error(), "InternalError") {
+		t.Errorf("SaveReader() error = %v, want the 500 response", err)
+	}
+	if _, err := repo.Open(filePath); err == nil || errors.Is(err, os.ErrNotExist) || !strings.Contains(err.Error(), "500") {
+		t.Errorf("Open() error = %v, want the 500 response", err)
+	}
+	if err := repo.Delete(filePath); err == nil || errors.Is(err, os.ErrNotExist) || !strings.Contains(err.Error(), "500") {
+		t.Errorf("Delete() error = %v, want the 500 response", err)
+	}
+
+	server.Close()
+	if _, err := repo.Open(filePath); err == nil {
+		t.Error("Open() of an unreachable server succeeded")
+	}
+}
+
+func TestNewS3FileRepoRequiresSettings(t *testing.T) {
+	valid := S3Config{Endpoint: "localhost:9000", Bucket: testS3Bucket, AccessKey: testS3AccessKey, SecretKey: testS3SecretKey}
+	tests := map[string]func(config *S3Config){
+		"endpoint":   func(config *S3Config) { config.Endpoint = "" },
+		"bucket":     func(config *S3Config) { config.Bucket = "" },
+		"access key": func(config *S3Config) { config.AccessKey = "" },
+		"secret key": func(config *S3Config) { config.SecretKey = "" },
+	}
+	for name, clear := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := valid
+			clear(&config)
+			if _, err := NewS3FileRepo(config); err == nil {
+				t.Errorf("NewS3FileRepo() without %s succeeded", name)
+			}
+		})
+	}
+	if _, err := NewS3FileRepo(valid); err != nil {
+		t.Errorf("NewS3FileRepo() error = %v", err)
+	}
+}
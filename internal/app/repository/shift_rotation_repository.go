@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// ShiftRotationRepository is the interface for shift rotation data access.
+type ShiftRotationRepository interface {
+	GetAll(searchQuery schema.ShiftRotationSearchQuery) ([]model.ShiftRotation, error)
+	GetByID(id uuid.UUID) (*model.ShiftRotation, error)
+	Create(rotation *model.ShiftRotation) error
+	Update(rotation *model.ShiftRotation) error
+	Delete(id uuid.UUID) error
+	IsExistName(name string, excludeID uuid.UUID) (bool, error)
+	IsInUse(id uuid.UUID) (bool, error)
+
+	// Rotation day relationship methods
+	GetDaysByRotationID(rotationID uuid.UUID) ([]model.ShiftRotationDay, error)
+	CreateDays(days []model.ShiftRotationDay) error
+	DeleteDaysByRotationID(rotationID uuid.UUID, tx *gorm.DB) error
+}
+
+// shiftRotationRepositoryImpl is the implementation of ShiftRotationRepository.
+type shiftRotationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewShiftRotationRepository creates a new instance of ShiftRotationRepository.
+func NewShiftRotationRepository(db *gorm.DB) ShiftRotationRepository {
+	return &shiftRotationRepositoryImpl{db: db}
+}
+
+// GetAll retrieves shift rotations with pagination.
+func (r *shiftRotationRepositoryImpl) GetAll(searchQuery schema.ShiftRotationSearchQuery) ([]model.ShiftRotation, error) {
+	var rotations []model.ShiftRotation
+	query := r.db.Model(&model.ShiftRotation{})
+
+	if searchQuery.Name != "" {
+		query = query.Where("name ILIKE ?", "%"+searchQuery.Name+"%")
+	}
+
+	offset := (searchQuery.Page - 1) * searchQuery.Limit
+	if err := query.Order("name").Offset(offset).Limit(searchQuery.Limit).Find(&rotations).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve paginated shift rotations: %w", err)
+	}
+	return rotations, nil
+}
+
+// GetByID retrieves a shift rotation by its ID.
+func (r *shiftRotationRepositoryImpl) GetByID(id uuid.UUID) (*model.ShiftRotation, error) {
+	var rotation model.ShiftRotation
+	if err := r.db.First(&rotation, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &rotation, nil
+}
+
+// Create inserts a new shift rotation.
+func (r *shiftRotationRepositoryImpl) Create(rotation *model.ShiftRotation) error {
+	return translateError(r.db.Create(rotation).Error)
+}
+
+// Update updates a shift rotation.
+func (r *shiftRotationRepositoryImpl) Update(rotation *model.ShiftRotation) error {
+	return translateError(r.db.Save(rotation).Error)
+}
+
+// Delete deletes a shift rotation and its days.
+func (r *shiftRotationRepositoryImpl) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.DeleteDaysByRotationID(id, tx); err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("id = ?", id).Delete(&model.ShiftRotation{}).Error
+	})
+}
+
+// IsExistName checks if a shift rotation with the given name exists.
+func (r *shiftRotationRepositoryImpl) IsExistName(name string, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	db := r.db.Model(&model.ShiftRotation{}).Where("name = ? AND deleted_at IS NULL", name)
+	if excludeID != uuid.Nil {
+		db = db.Where("id != ?", excludeID)
+	}
+	if err := db.Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check shift rotation name existence: %w", err)
+	}
+	return count > 0, nil
+}
+
+// IsInUse checks if a shift rotation is assigned to a person.
+func (r *shiftRotationRepositoryImpl) IsInUse(id uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.Model(&model.PersonShiftAssignment{}).Where("shift_rotation_id = ?", id.String()).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check shift rotation usage: %w", err)
+	}
+	return count > 0, nil
+}
+
+// GetDaysByRotationID retrieves the working days of a rotation ordered by day index.
+func (r *shiftRotationRepositoryImpl) GetDaysByRotationID(rotationID uuid.UUID) ([]model.ShiftRotationDay, error) {
+	var days []model.ShiftRotationDay
+	err := r.db.Where("shift_rotation_id = ?", rotationID).Order("day_index").Find(&days).Error
+	return days, err
+}
+
+// CreateDays inserts multiple rotation days.
+func (r *shiftRotationRepositoryImpl) CreateDays(days []model.ShiftRotationDay) error {
+	if len(days) == 0 {
+		return nil
+	}
+	return translateError(r.db.Create(&days).Error)
+}
+
+// DeleteDaysByRotationID deletes all days of a rotation.
+func (r *shiftRotationRepositoryImpl) DeleteDaysByRotationID(rotationID uuid.UUID, tx *gorm.DB) error {
+	if tx == nil {
+		tx = r.db
+	}
+	return tx.Unscoped().Where("shift_rotation_id = ?", rotationID).Delete(&model.ShiftRotationDay{}).Error
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// ShiftTemplateRepository is the interface for shift template data access.
+type ShiftTemplateRepository interface {
+	GetAll(searchQuery schema.ShiftTemplateSearchQuery) ([]model.ShiftTemplate, error)
+	GetByID(id uuid.UUID) (*model.ShiftTemplate, error)
+	Create(template *model.ShiftTemplate) error
+	Update(template *model.ShiftTemplate) error
+	Delete(id uuid.UUID) error
+	IsExistName(name string, excludeID uuid.UUID) (bool, error)
+	IsInUse(id uuid.UUID) (bool, error)
+}
+
+// shiftTemplateRepositoryImpl is the implementation of ShiftTemplateRepository.
+type shiftTemplateRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewShiftTemplateRepository creates a new instance of ShiftTemplateRepository.
+func NewShiftTemplateRepository(db *gorm.DB) ShiftTemplateRepository {
+	return &shiftTemplateRepositoryImpl{db: db}
+}
+
+// GetAll retrieves shift templates with pagination.
+func (r *shiftTemplateRepositoryImpl) GetAll(searchQuery schema.ShiftTemplateSearchQuery) ([]model.ShiftTemplate, error) {
+	var templates []model.ShiftTemplate
+	query := r.db.Model(&model.ShiftTemplate{})
+
+	if searchQuery.Name != "" {
+		query = query.Where("name ILIKE ?", "%"+searchQuery.Name+"%")
+	}
+
+	offset := (searchQuery.Page - 1) * searchQuery.Limit
+	if err := query.Order("name").Offset(offset).Limit(searchQuery.Limit).Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve paginated shift templates: %w", err)
+	}
+	return templates, nil
+}
+
+// GetByID retrieves a shift template by its ID.
+func (r *shiftTemplateRepositoryImpl) GetByID(id uuid.UUID) (*model.ShiftTemplate, error) {
+	var template model.ShiftTemplate
+	if err := r.db.First(&template, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// Create inserts a new shift template.
+func (r *shiftTemplateRepositoryImpl) Create(template *model.ShiftTemplate) error {
+	return translateError(r.db.Create(template).Error)
+}
+
+// Update updates a shift template.
+func (r *shiftTemplateRepositoryImpl) Update(template *model.ShiftTemplate) error {
+	return translateError(r.db.Save(template).Error)
+}
+
+// Delete deletes a shift template by its ID.
+func (r *shiftTemplateRepositoryImpl) Delete(id uuid.UUID) error {
+	return r.db.Unscoped().Where("id = ?", id).Delete(&model.ShiftTemplate{}).Error
+}
+
+// IsExistName checks if a shift template with the given name exists.
+func (r *shiftTemplateRepositoryImpl) IsExistName(name string, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	db := r.db.Model(&model.ShiftTemplate{}).Where("name = ? AND deleted_at IS NULL", name)
+	if excludeID != uuid.Nil {
+		db = db.Where("id != ?", excludeID)
+	}
+	if err := db.Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check shift template name existence: %w", err)
+	}
+	return count > 0, nil
+}
+
+// IsInUse checks if a shift template is used by a rotation or a person's shift override.
+func (r *shiftTemplateRepositoryImpl) IsInUse(id uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.Model(&model.ShiftRotationDay{}).Where("shift_template_id = ?", id.String()).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check shift template usage: %w", err)
+	}
+	if count > 0 {
+		return true, nil
+	}
+	if err := r.db.Model(&model.PersonShiftOverride{}).Where("shift_template_id = ?", id.String()).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check shift template usage: %w", err)
+	}
+	return count > 0, nil
+}
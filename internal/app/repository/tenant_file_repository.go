@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"io"
+	"mime/multipart"
+
+	"github.com/putteror/access-control-management/internal/app/common"
+	"gorm.io/gorm"
+)
+
+// tenantFileRepo stores the files of a tenant in its own folder, see common.TenantFilePath, so the
+// stored files of tenants never share a path. Opening and deleting take the stored path as it is.
+type tenantFileRepo struct {
+	FileRepository
+	db *gorm.DB
+}
+
+// NewTenantFileRepo wraps a FileRepository so it stores files in the folder of the tenant of a
+// database session. Sessions in the global scope store files as the wrapped repository does.
+func NewTenantFileRepo(fileRepo FileRepository, db *gorm.DB) FileRepository {
+	return &tenantFileRepo{FileRepository: fileRepo, db: db}
+}
+
+// Save stores a multipart file in the folder of the tenant and returns its path.
+func (r *tenantFileRepo) Save(fileHeader *multipart.FileHeader, folderPath string) (string, error) {
+	return r.FileRepository.Save(fileHeader, r.folder(folderPath))
+}
+
+// SaveReader stores the content of src in the folder of the tenant and returns its path.
+func (r *tenantFileRepo) SaveReader(src io.Reader, fileName string, folderPath string) (string, error) {
+	return r.FileRepository.SaveReader(src, fileName, r.folder(folderPath))
+}
+
+func (r *tenantFileRepo) folder(folderPath string) string {
+	tenantID, allTenants, ok := common.TenantFromContext(r.db.Statement.Context)
+	if !ok || allTenants {
+		return folderPath
+	}
+	return common.TenantFilePath(tenantID, folderPath)
+}
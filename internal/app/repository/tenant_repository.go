@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// TenantRepository is the interface for tenant data access. Tenants are not scoped by a tenant
+// themselves, so every tenant can be read from any session.
+type TenantRepository interface {
+	GetAll(searchQuery schema.TenantSearchQuery) ([]model.Tenant, error)
+	GetByID(id uuid.UUID) (*model.Tenant, error)
+	GetByCode(code string) (*model.Tenant, error)
+	Create(tenant *model.Tenant) error
+	Update(tenant *model.Tenant) error
+	Delete(id uuid.UUID) error
+	IsExistCode(code string, excludeID uuid.UUID) (bool, error)
+	IsInUse(id uuid.UUID) (bool, error)
+}
+
+// tenantRepositoryImpl is the implementation of TenantRepository.
+type tenantRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewTenantRepository creates a new instance of TenantRepository.
+func NewTenantRepository(db *gorm.DB) TenantRepository {
+	return &tenantRepositoryImpl{db: db}
+}
+
+// GetAll retrieves tenants with pagination.
+func (r *tenantRepositoryImpl) GetAll(searchQuery schema.TenantSearchQuery) ([]model.Tenant, error) {
+	var tenants []model.Tenant
+	query := r.db.Model(&model.Tenant{})
+
+	if searchQuery.Name != "" {
+		query = query.Where("name ILIKE ?", "%"+searchQuery.Name+"%")
+	}
+	if searchQuery.Code != "" {
+		query = query.Where("code = ?", searchQuery.Code)
+	}
+
+	offset := (searchQuery.Page - 1) * searchQuery.Limit
+	if err := query.Order("name").Offset(offset).Limit(searchQuery.Limit).Find(&tenants).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve paginated tenants: %w", err)
+	}
+	return tenants, nil
+}
+
+// GetByID retrieves a tenant by its ID.
+func (r *tenantRepositoryImpl) GetByID(id uuid.UUID) (*model.Tenant, error) {
+	var tenant model.Tenant
+	if err := r.db.First(&tenant, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &tenant, nil
+}
+
+// GetByCode retrieves a tenant by its code.
+func (r *tenantRepositoryImpl) GetByCode(code string) (*model.Tenant, error) {
+	var tenant model.Tenant
+	if err := r.db.First(&tenant, "code = ?", code).Error; err != nil {
+		return nil, err
+	}
+	return &tenant, nil
+}
+
+// Create inserts a new tenant.
+func (r *tenantRepositoryImpl) Create(tenant *model.Tenant) error {
+	return translateError(r.db.Create(tenant).Error)
+}
+
+// Update updates a tenant.
+func (r *tenantRepositoryImpl) Update(tenant *model.Tenant) error {
+	return translateError(r.db.Save(tenant).Error)
+}
+
+// Delete soft deletes a tenant.
+func (r *tenantRepositoryImpl) Delete(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&model.Tenant{}).Error
+}
+
+// IsExistCode checks if a tenant with the given code exists.
+func (r *tenantRepositoryImpl) IsExistCode(code string, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	db := r.db.Model(&model.Tenant{}).Where("code = ? AND deleted_at IS NULL", code)
+	if excludeID != uuid.Nil {
+		db = db.Where("id != ?", excludeID)
+	}
+	if err := db.Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check tenant code existence: %w", err)
+	}
+	return count > 0, nil
+}
+
+// IsInUse checks if a tenant still has users or people.
+func (r *tenantRepositoryImpl) IsInUse(id uuid.UUID) (bool, error) {
+	db := allTenants(r.db)
+	for _, owned := range []interface{}{&model.User{}, &model.Person{}} {
+		var count int64
+		if err := db.Model(owned).Where("tenant_id = ?", id.String()).Count(&count).Error; err != nil {
+			return false, fmt.Errorf("failed to check tenant usage: %w", err)
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// allTenants returns a session of db that sees the data of every tenant, for lookups that must
+// cross tenants such as logging in or extending the access record hash chain.
+func allTenants(db *gorm.DB) *gorm.DB {
+	return db.WithContext(common.WithAllTenants(db.Statement.Context))
+}
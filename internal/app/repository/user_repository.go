@@ -86,11 +86,11 @@ func (r *userRepositoryImpl) GetByID(id uuid.UUID) (*model.User, error) {
 	return &user, nil
 }
 
-// GetByUsername retrieves a user record by their username.
+// GetByUsername retrieves a user record by their username in any tenant.
 func (r *userRepositoryImpl) GetByUsername(username string) (*model.User, error) {
 	var user model.User
 	// ไม่จำเป็นต้อง Preload Permission ในกรณีนี้ถ้าใช้เพื่อการ Login/Auth เพียงอย่างเดียว
-	if err := r.db.Where("username = ?", username).First(&user).Error; err != nil {
+	if err := allTenants(r.db).Where("username = ?", username).First(&user).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
@@ -99,12 +99,12 @@ func (r *userRepositoryImpl) GetByUsername(username string) (*model.User, error)
 // Create creates a new user record.
 // Note: ควรใช้ CreateUserWithPermission สำหรับการสร้าง User พร้อมสิทธิ์
 func (r *userRepositoryImpl) Create(user *model.User) error {
-	return r.db.Create(user).Error
+	return translateError(r.db.Create(user).Error)
 }
 
 // Update updates an existing user record.
 func (r *userRepositoryImpl) Update(user *model.User) error {
-	return r.db.Save(user).Error
+	return translateError(r.db.Save(user).Error)
 }
 
 // Delete deletes a user record by its ID.
@@ -131,10 +131,10 @@ func (r *userRepositoryImpl) Delete(id uuid.UUID) error {
 	return err
 }
 
-// IsExistUsername checks if a user record with the given username exists.
+// IsExistUsername checks if a user record with the given username exists in any tenant.
 func (r *userRepositoryImpl) IsExistUsername(username string, excludeID uuid.UUID) (bool, error) {
 	var count int64
-	db := r.db.Model(&model.User{}).Where("username = ? AND deleted_at IS NULL", username)
+	db := allTenants(r.db).Model(&model.User{}).Where("username = ? AND deleted_at IS NULL", username)
 	if excludeID != uuid.Nil {
 		db = db.Where("id != ?", excludeID)
 	}
@@ -162,7 +162,7 @@ func (r *userRepositoryImpl) CreatePermission(permission *model.UserPermission,
 	if tx == nil {
 		tx = r.db
 	}
-	return tx.Create(permission).Error
+	return translateError(tx.Create(permission).Error)
 }
 
 // UpdatePermission updates an existing UserPermission record within the provided transaction.
@@ -170,7 +170,7 @@ func (r *userRepositoryImpl) UpdatePermission(permission *model.UserPermission,
 	if tx == nil {
 		tx = r.db
 	}
-	return tx.Save(permission).Error
+	return translateError(tx.Save(permission).Error)
 }
 
 // DeletePermission deletes a UserPermission record by its ID within the provided transaction.
@@ -195,7 +195,7 @@ func (r *userRepositoryImpl) CreateUserWithPermission(user *model.User, permissi
 
 		user.PermissionID = permission.ID.String()
 
-		return tx.Create(user).Error
+		return translateError(tx.Create(user).Error)
 	})
 }
 
@@ -208,6 +208,6 @@ func (r *userRepositoryImpl) UpdateUserAndPermission(user *model.User, permissio
 		}
 
 		// 2. อัพเดท User
-		return tx.Save(user).Error
+		return translateError(tx.Save(user).Error)
 	})
 }
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// VisitorVehicleRepository is the interface for visitor vehicle data access.
+type VisitorVehicleRepository interface {
+	GetAll(searchQuery schema.VisitorVehicleSearchQuery) ([]model.VisitorVehicle, error)
+	GetByID(id uuid.UUID) (*model.VisitorVehicle, error)
+	GetByNormalizedPlateText(normalizedPlateText string) (*model.VisitorVehicle, error)
+	Create(vehicle *model.VisitorVehicle) error
+	Update(vehicle *model.VisitorVehicle) error
+	Delete(id uuid.UUID) error
+	IsExistImagePath(imagePath string) (bool, error)
+	SetMissingNormalizedPlateTexts() (int, error)
+}
+
+// visitorVehicleRepositoryImpl is the implementation of VisitorVehicleRepository.
+type visitorVehicleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewVisitorVehicleRepository creates a new instance of VisitorVehicleRepository.
+func NewVisitorVehicleRepository(db *gorm.DB) VisitorVehicleRepository {
+	return &visitorVehicleRepositoryImpl{db: db}
+}
+
+// GetAll retrieves visitor vehicles with pagination, most recently seen first.
+func (r *visitorVehicleRepositoryImpl) GetAll(searchQuery schema.VisitorVehicleSearchQuery) ([]model.VisitorVehicle, error) {
+	var vehicles []model.VisitorVehicle
+	query := r.db.Model(&model.VisitorVehicle{})
+
+	if searchQuery.PlateText != "" {
+		query = query.Where("plate_text ILIKE ?", "%"+searchQuery.PlateText+"%")
+	}
+
+	offset := (searchQuery.Page - 1) * searchQuery.Limit
+	if err := query.Order("last_seen_at DESC").Offset(offset).Limit(searchQuery.Limit).Find(&vehicles).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve paginated visitor vehicles: %w", err)
+	}
+	return vehicles, nil
+}
+
+// GetByID retrieves a visitor vehicle by its ID.
+func (r *visitorVehicleRepositoryImpl) GetByID(id uuid.UUID) (*model.VisitorVehicle, error) {
+	var vehicle model.VisitorVehicle
+	if err := r.db.First(&vehicle, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &vehicle, nil
+}
+
+// GetByNormalizedPlateText retrieves a visitor vehicle by its normalized plate text.
+func (r *visitorVehicleRepositoryImpl) GetByNormalizedPlateText(normalizedPlateText string) (*model.VisitorVehicle, error) {
+	var vehicle model.VisitorVehicle
+	if err := r.db.First(&vehicle, "normalized_plate_text = ?", normalizedPlateText).Error; err != nil {
+		return nil, err
+	}
+	return &vehicle, nil
+}
+
+// Create inserts a new visitor vehicle.
+func (r *visitorVehicleRepositoryImpl) Create(vehicle *model.VisitorVehicle) error {
+	return translateError(r.db.Create(vehicle).Error)
+}
+
+// Update updates a visitor vehicle.
+func (r *visitorVehicleRepositoryImpl) Update(vehicle *model.VisitorVehicle) error {
+	return translateError(r.db.Save(vehicle).Error)
+}
+
+// Delete deletes a visitor vehicle by its ID.
+func (r *visitorVehicleRepositoryImpl) Delete(id uuid.UUID) error {
+	return r.db.Unscoped().Where("id = ?", id).Delete(&model.VisitorVehicle{}).Error
+}
+
+// IsExistImagePath checks if a visitor vehicle still shows an image as its last snapshot.
+func (r *visitorVehicleRepositoryImpl) IsExistImagePath(imagePath string) (bool, error) {
+	var count int64
+	if err := r.db.Model(&model.VisitorVehicle{}).Where("last_image_path = ?", imagePath).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// SetMissingNormalizedPlateTexts sets the normalized plate text of the visitor vehicles of every
+// tenant that have none, as left by a migration that changed how plates are normalized.
+func (r *visitorVehicleRepositoryImpl) SetMissingNormalizedPlateTexts() (int, error) {
+	updated := 0
+	for {
+		var vehicles []model.VisitorVehicle
+		err := allTenants(r.db).Unscoped().Where("normalized_plate_text IS NULL").Limit(common.LicensePlateRekeyBatchSize).Find(&vehicles).Error
+		if err != nil {
+			return updated, fmt.Errorf("failed to retrieve visitor vehicles without a normalized plate text: %w", err)
+		}
+		if len(vehicles) == 0 {
+			return updated, nil
+		}
+		for _, vehicle := range vehicles {
+			err := allTenants(r.db).Unscoped().Model(&vehicle).UpdateColumn("normalized_plate_text", common.NormalizeLicensePlate(vehicle.PlateText)).Error
+			if err != nil {
+				return updated, fmt.Errorf("failed to set normalized plate text of visitor vehicle %s: %w", vehicle.ID, translateError(err))
+			}
+			updated++
+		}
+	}
+}
@@ -2,17 +2,19 @@ package schema
 
 // AccessControlDeviceSearchQuery defines the search parameters for devices.
 type AccessControlDeviceSearchQuery struct {
-	Name        string `json:"name"`
-	Type        string `json:"type"`
-	HostAddress string `json:"hostAddress"`
-	Page        int    `json:"page"`
-	Limit       int    `json:"limit"`
+	Name        string `form:"name"`
+	Type        string `form:"type"`
+	HostAddress string `form:"hostAddress"`
+	// LocationID also matches the devices of the locations below it
+	LocationID string `form:"locationId" validate:"omitempty,uuid"`
+	Page       int    `form:"page"`
+	Limit      int    `form:"limit"`
 }
 
 type AccessControlDeviceRequest struct {
-	Name                  *string `json:"name" validate:"required"`
-	Type                  *string `json:"type" validate:"required"`
-	HostAddress           *string `json:"hostAddress" validate:"required"`
+	Name                  *string `json:"name" validate:"required,notblank"`
+	Type                  *string `json:"type" validate:"required,notblank"`
+	HostAddress           *string `json:"hostAddress" validate:"required,notblank,hostAddress"`
 	Username              *string `json:"username"`
 	Password              *string `json:"password"`
 	AccessToken           *string `json:"accessToken"`
@@ -22,7 +24,9 @@ type AccessControlDeviceRequest struct {
 	AllowClockIn          *bool   `json:"allowClockIn"`
 	AllowClockOut         *bool   `json:"allowClockOut"`
 	Status                *string `json:"status"`
-	AccessControlServerID *string `json:"accessControlServerId"`
+	AccessControlServerID *string `json:"accessControlServerId" validate:"omitempty,uuid"`
+	LocationID            *string `json:"locationId" validate:"omitempty,uuid"`
+	TimeZone              *string `json:"timeZone" validate:"omitempty,timeZone"`
 }
 
 type AccessControlDeviceInfoResponse struct {
@@ -46,4 +50,6 @@ type AccessControlDeviceResponse struct {
 	AllowClockIn        bool                             `json:"allowClockIn"`
 	AllowClockOut       bool                             `json:"allowClockOut"`
 	AccessControlServer *AccessControlServerInfoResponse `json:"accessControlServer"`
+	Location            *LocationInfoResponse            `json:"location"`
+	TimeZone            *string                          `json:"timeZone"`
 }
@@ -1,25 +1,29 @@
 package schema
 
-// AccessControlDeviceSearchQuery defines the search parameters for devices.
+// AccessControlGroupSearchQuery defines the search parameters for groups.
 type AccessControlGroupSearchQuery struct {
-	Name  string `json:"name"`
-	Page  int    `json:"page"`
-	Limit int    `json:"limit"`
+	Name  string `form:"name"`
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
 }
 
 // Request
 
 type AccessControlGroupScheduleRequest struct {
-	DayOfWeek *int    `json:"dayOfWeek"`
-	Date      *string `json:"date"`
-	StartTime *string `json:"startTime"`
-	EndTime   *string `json:"endTime"`
+	DayOfWeek *int    `json:"dayOfWeek" validate:"required,dayOfWeek"`
+	Date      *string `json:"date" validate:"omitempty,date"`
+	StartTime *string `json:"startTime" validate:"omitempty,timeOfDay"`
+	EndTime   *string `json:"endTime" validate:"omitempty,timeOfDay,after=StartTime"`
 }
 
 type AccessControlGroupRequest struct {
-	Name                        *string                             `json:"name" validate:"required"`
-	AccessControlDeviceIDs      []string                            `json:"accessControlDeviceIds"`
-	AccessControlGroupSchedules []AccessControlGroupScheduleRequest `json:"accessControlSchedules"`
+	Name                        *string                             `json:"name" validate:"required,notblank"`
+	AuthMode                    *string                             `json:"authMode" validate:"omitempty,authMode"`
+	TwoPersonWindowSeconds      *int                                `json:"twoPersonWindowSeconds" validate:"omitempty,min=1"`
+	HolidayCalendarID           *string                             `json:"holidayCalendarId" validate:"omitempty,uuid"`
+	AccessControlDeviceIDs      []string                            `json:"accessControlDeviceIds" validate:"dive,required,uuid"`
+	LocationIDs                 []string                            `json:"locationIds" validate:"dive,required,uuid"`
+	AccessControlGroupSchedules []AccessControlGroupScheduleRequest `json:"accessControlSchedules" validate:"dive"`
 }
 
 // Response
@@ -39,6 +43,10 @@ type AccessControlGroupInfoResponse struct {
 type AccessControlGroupResponse struct {
 	ID                          string                               `json:"id"`
 	Name                        string                               `json:"name"`
+	AuthMode                    string                               `json:"authMode"`
+	TwoPersonWindowSeconds      int                                  `json:"twoPersonWindowSeconds"`
+	HolidayCalendar             *HolidayCalendarInfoResponse         `json:"holidayCalendar"`
 	AccessControlDevices        []AccessControlDeviceInfoResponse    `json:"accessControlDevices"`
+	Locations                   []LocationInfoResponse               `json:"locations"`
 	AccessControlGroupSchedules []AccessControlGroupScheduleResponse `json:"accessControlSchedules"`
 }
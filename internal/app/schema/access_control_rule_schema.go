@@ -8,8 +8,8 @@ type AccessControlRuleSearchQuery struct {
 }
 
 type AccessControlRuleRequest struct {
-	Name                  string   `form:"name" validate:"required"`
-	AccessControlGroupIDs []string `json:"accessControlGroupIds"`
+	Name                  string   `json:"name" validate:"required,notblank"`
+	AccessControlGroupIDs []string `json:"accessControlGroupIds" validate:"dive,required,uuid"`
 }
 
 type AccessControlRuleInfoResponse struct {
@@ -2,9 +2,9 @@ package schema
 
 // AccessControlServerRequest defines the request body for creating/updating a server.
 type AccessControlServerRequest struct {
-	Name        *string `json:"name" validate:"required"`
-	Type        *string `json:"type" validate:"required"`
-	HostAddress *string `json:"hostAddress" validate:"required"`
+	Name        *string `json:"name" validate:"required,notblank"`
+	Type        *string `json:"type" validate:"required,notblank"`
+	HostAddress *string `json:"hostAddress" validate:"required,notblank,hostAddress"`
 	Username    *string `json:"username"`
 	Password    *string `json:"password"`
 	AccessToken *string `json:"accessToken"`
@@ -15,8 +15,8 @@ type AccessControlServerRequest struct {
 // AccessControlServerSearchQuery defines the search parameters for servers.
 type AccessControlServerSearchQuery struct {
 	Name        string `form:"name"`
-	Type        string `json:"type"`
-	HostAddress string `json:"hostAddress"`
+	Type        string `form:"type"`
+	HostAddress string `form:"hostAddress"`
 	Page        int    `form:"page"`
 	Limit       int    `form:"limit"`
 }
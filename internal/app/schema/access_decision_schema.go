@@ -0,0 +1,44 @@
+package schema
+
+// Request
+
+// AccessDecisionRequest carries the credentials presented at a device. At least one of
+// cardNumber, facePersonId or pin is required. When a group needs more than one factor the
+// device sends the next credential with the sessionId returned by the previous scan.
+type AccessDecisionRequest struct {
+	AccessControlDeviceID *string `json:"accessControlDeviceId" validate:"required,notblank,uuid"`
+	Type                  *string `json:"type" validate:"required,notblank,accessRecordType"`
+	SessionID             *string `json:"sessionId"`
+	CardNumber            *string `json:"cardNumber"`
+	// FacePersonID is the person recognized by the device's face matcher
+	FacePersonID *string `json:"facePersonId"`
+	PIN          *string `json:"pin"`
+	// AccessTime is an RFC 3339 timestamp and defaults to now when empty
+	AccessTime *string `json:"accessTime" validate:"omitempty,timestamp"`
+}
+
+type LicensePlateEventRequest struct {
+	AccessControlDeviceID *string  `form:"accessControlDeviceId" validate:"required,notblank,uuid"`
+	PlateText             *string  `form:"plateText" validate:"required,notblank"`
+	Confidence            *float64 `form:"confidence" validate:"required,min=0,max=1"`
+	Type                  *string  `form:"type" validate:"required,notblank,accessRecordType"`
+	// AccessTime is an RFC 3339 timestamp and defaults to now when empty
+	AccessTime *string `form:"accessTime" validate:"omitempty,timestamp"`
+	// Plate image will receive in function
+}
+
+// Response
+
+type AccessDecisionResponse struct {
+	Granted        bool                `json:"granted"`
+	Reason         string              `json:"reason"`
+	Person         *PersonInfoResponse `json:"person"`
+	AccessRecordID string              `json:"accessRecordId"`
+	// Pending means the scan was accepted but another credential is needed in the same session
+	Pending   bool     `json:"pending"`
+	SessionID *string  `json:"sessionId,omitempty"`
+	Factors   []string `json:"factors"`
+	// Set for license plate events only
+	MatchedPlateText *string `json:"matchedPlateText,omitempty"`
+	VisitorVehicleID *string `json:"visitorVehicleId,omitempty"`
+}
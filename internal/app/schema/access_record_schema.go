@@ -1,21 +1,31 @@
 package schema
 
 type AccessRecordSearchQuery struct {
-	PersonID              string `form:"personID"`
-	AccessControlDeviceID string `form:"accessControlDeviceID"`
-	Type                  string `form:"type"`
-	Result                string `form:"result"`
+	PersonID              string `form:"personId" validate:"omitempty,uuid"`
+	AccessControlDeviceID string `form:"accessControlDeviceId" validate:"omitempty,uuid"`
+	Type                  string `form:"type" validate:"omitempty,accessRecordType"`
+	Result                string `form:"result" validate:"omitempty,accessRecordResult"`
 	AccessTime            string `form:"accessTime"`
-	Page                  int    `form:"page"`
-	Limit                 int    `form:"limit"`
+	// LocationID matches the records of devices at the location or below it
+	LocationID string `form:"locationId" validate:"omitempty,uuid"`
+	Page       int    `form:"page"`
+	Limit      int    `form:"limit"`
 }
 
 type AccessRecordRequest struct {
-	PersonID              *string `json:"personID" `
-	AccessControlDeviceID *string `json:"accessControlDeviceID"`
-	Type                  *string `json:"type" validate:"required"`
-	Result                *string `json:"result" validate:"required"`
-	AccessTime            *string `json:"accessTime" validate:"required"`
+	PersonID              *string `json:"personId" validate:"omitempty,uuid"`
+	AccessControlDeviceID *string `json:"accessControlDeviceId" validate:"omitempty,uuid"`
+	Type                  *string `json:"type" validate:"required,notblank,accessRecordType"`
+	Result                *string `json:"result" validate:"required,notblank,accessRecordResult"`
+	AccessTime            *string `json:"accessTime" validate:"required,notblank,timestamp"`
+}
+
+// AccessRecordAnnotationRequest corrects or explains an access record. field and value give the
+// corrected value of one field, note is the reason.
+type AccessRecordAnnotationRequest struct {
+	Field *string `json:"field" validate:"omitempty,accessRecordAnnotationField"`
+	Value *string `json:"value"`
+	Note  *string `json:"note" validate:"required,notblank"`
 }
 
 type AccessRecordPersonResponse struct {
@@ -35,10 +45,53 @@ type AccessRecordDeviceResponse struct {
 }
 
 type AccessRecordResponse struct {
-	ID                  string                      `json:"id"`
-	Person              *AccessRecordPersonResponse `json:"person"`
-	AccessControlDevice *AccessRecordDeviceResponse `json:"accessControlDevice"`
-	Type                string                      `json:"type"`
-	Result              string                      `json:"result"`
-	AccessTime          string                      `json:"accessTime"`
+	ID                  string                           `json:"id"`
+	Person              *AccessRecordPersonResponse      `json:"person"`
+	AccessControlDevice *AccessRecordDeviceResponse      `json:"accessControlDevice"`
+	Type                string                           `json:"type"`
+	Result              string                           `json:"result"`
+	AccessTime          string                           `json:"accessTime"`
+	CardNumber          *string                          `json:"cardNumber"`
+	PlateText           *string                          `json:"plateText"`
+	PlateConfidence     *float64                         `json:"plateConfidence"`
+	PlateImageURL       string                           `json:"plateImageUrl"`
+	Reason              *string                          `json:"reason"`
+	Factors             []string                         `json:"factors"`
+	ScanSessionID       *string                          `json:"scanSessionId"`
+	Sequence            int64                            `json:"sequence"`
+	Hash                string                           `json:"hash"`
+	Annotations         []AccessRecordAnnotationResponse `json:"annotations"`
+}
+
+type AccessRecordAnnotationResponse struct {
+	ID        string  `json:"id"`
+	Field     *string `json:"field"`
+	Value     *string `json:"value"`
+	Note      string  `json:"note"`
+	CreatedBy string  `json:"createdBy"`
+	CreatedAt string  `json:"createdAt"`
+}
+
+// AccessRecordChainIssue is a problem found in the access record hash chain.
+type AccessRecordChainIssue struct {
+	Sequence       int64  `json:"sequence"`
+	AccessRecordID string `json:"accessRecordId"`
+	Problem        string `json:"problem"`
+	Detail         string `json:"detail"`
+}
+
+// AccessRecordChainResponse is the result of verifying the access record hash chain. LastHash can
+// be kept elsewhere to detect records removed from the end of the chain later. Records up to
+// PurgedThroughSequence were purged by the retention job. Issues lists the first problems found,
+// IssueCount counts all of them.
+type AccessRecordChainResponse struct {
+	Valid                 bool                     `json:"valid"`
+	PurgedThroughSequence int64                    `json:"purgedThroughSequence"`
+	Records               int64                    `json:"records"`
+	LastSequence          int64                    `json:"lastSequence"`
+	LastHash              string                   `json:"lastHash"`
+	UnchainedRecords      int64                    `json:"unchainedRecords"`
+	IssueCount            int                      `json:"issueCount"`
+	Issues                []AccessRecordChainIssue `json:"issues"`
+	VerifiedAt            string                   `json:"verifiedAt"`
 }
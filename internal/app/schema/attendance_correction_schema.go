@@ -0,0 +1,39 @@
+package schema
+
+type AttendanceCorrectionSearchQuery struct {
+	PersonID  string `form:"personId" validate:"omitempty,uuid"`
+	Status    string `form:"status" validate:"omitempty,approvalStatus"`
+	StartDate string `form:"startDate" validate:"omitempty,date"`
+	EndDate   string `form:"endDate" validate:"omitempty,date,notBefore=StartDate"`
+	Page      int    `form:"page"`
+	Limit     int    `form:"limit"`
+}
+
+// Request
+
+// AttendanceCorrectionRequest adds a manual clock-in or clock-out. Date is the attendance date
+// ("YYYY-MM-DD") and time the moment of the punch (an RFC 3339 timestamp), which may fall on the
+// next day for a night shift.
+type AttendanceCorrectionRequest struct {
+	PersonID *string `json:"personId" validate:"required,notblank,uuid"`
+	Date     *string `json:"date" validate:"required,notblank,date"`
+	Type     *string `json:"type" validate:"required,notblank,attendanceCorrectionType"`
+	Time     *string `json:"time" validate:"required,notblank,timestamp"`
+	Reason   *string `json:"reason" validate:"required,notblank"`
+}
+
+// Response
+
+type AttendanceCorrectionResponse struct {
+	ID           string              `json:"id"`
+	Person       *PersonInfoResponse `json:"person"`
+	Date         string              `json:"date"`
+	Type         string              `json:"type"`
+	Time         string              `json:"time"`
+	Reason       string              `json:"reason"`
+	Status       string              `json:"status"`
+	RequestedBy  *string             `json:"requestedBy"`
+	DecidedBy    *string             `json:"decidedBy"`
+	DecidedAt    *string             `json:"decidedAt"`
+	DecisionNote *string             `json:"decisionNote"`
+}
@@ -0,0 +1,95 @@
+package schema
+
+type AttendanceRecordSearchQuery struct {
+	PersonID  string `form:"personId" validate:"omitempty,uuid"`
+	StartDate string `form:"startDate" validate:"omitempty,date"`
+	EndDate   string `form:"endDate" validate:"omitempty,date,notBefore=StartDate"`
+	Status    string `form:"status" validate:"omitempty,attendanceStatus"`
+	// OvertimeStatus filters on the overtime approval status (pending, approved, rejected)
+	OvertimeStatus string `form:"overtimeStatus" validate:"omitempty,approvalStatus"`
+	Page           int    `form:"page"`
+	Limit          int    `form:"limit"`
+	All            bool   `form:"all"`
+}
+
+// AttendanceSummaryQuery totals the attendance records of a date range per person.
+type AttendanceSummaryQuery struct {
+	PersonID  string `form:"personId" validate:"omitempty,uuid"`
+	StartDate string `form:"startDate" validate:"required,date"`
+	EndDate   string `form:"endDate" validate:"required,date,notBefore=StartDate"`
+}
+
+// Request
+
+// AttendanceCalculateRequest recalculates the attendance of one person, or of every person
+// with an attendance profile when PersonID is empty. Dates are "YYYY-MM-DD", both inclusive.
+type AttendanceCalculateRequest struct {
+	PersonID  *string `json:"personId" validate:"omitempty,uuid"`
+	StartDate *string `json:"startDate" validate:"required,notblank,date"`
+	EndDate   *string `json:"endDate" validate:"required,notblank,date,notBefore=StartDate"`
+}
+
+// Response
+
+type AttendanceRecordResponse struct {
+	ID                string              `json:"id"`
+	Person            *PersonInfoResponse `json:"person"`
+	Date              string              `json:"date"`
+	Status            string              `json:"status"`
+	HolidayName       *string             `json:"holidayName"`
+	ScheduleStartTime *string             `json:"scheduleStartTime"`
+	ScheduleEndTime   *string             `json:"scheduleEndTime"`
+	ShiftTemplateID   *string             `json:"shiftTemplateId"`
+	ShiftName         *string             `json:"shiftName"`
+	LeaveTypeName     *string             `json:"leaveTypeName"`
+	LeaveDays         float64             `json:"leaveDays"`
+	EndsNextDay       bool                `json:"endsNextDay"`
+	CheckInAt         *string             `json:"checkInAt"`
+	CheckOutAt        *string             `json:"checkOutAt"`
+	CheckInCorrected  bool                `json:"checkInCorrected"`
+	CheckOutCorrected bool                `json:"checkOutCorrected"`
+	LateMinutes       int                 `json:"lateMinutes"`
+	EarlyLeaveMinutes int                 `json:"earlyLeaveMinutes"`
+	WorkedMinutes     int                 `json:"workedMinutes"`
+	// Overtime is nil when the day has no overtime
+	Overtime *AttendanceOvertimeResponse `json:"overtime"`
+}
+
+// AttendanceOvertimeResponse is the overtime of an attendance record and its approval.
+type AttendanceOvertimeResponse struct {
+	Minutes         int     `json:"minutes"`
+	DayType         string  `json:"dayType"`
+	Multiplier      float64 `json:"multiplier"`
+	Status          string  `json:"status"`
+	ApprovedMinutes int     `json:"approvedMinutes"`
+	DecidedBy       *string `json:"decidedBy"`
+	DecidedAt       *string `json:"decidedAt"`
+	DecisionNote    *string `json:"decisionNote"`
+}
+
+// AttendanceSummaryResponse counts the days of each status of a person. LeaveDays includes
+// half-day leave taken on days the person also worked, per leave type in LeaveDaysByType.
+type AttendanceSummaryResponse struct {
+	Person            *PersonInfoResponse `json:"person"`
+	Days              int                 `json:"days"`
+	Present           int                 `json:"present"`
+	Late              int                 `json:"late"`
+	Incomplete        int                 `json:"incomplete"`
+	Absent            int                 `json:"absent"`
+	Leave             int                 `json:"leave"`
+	Holiday           int                 `json:"holiday"`
+	DayOff            int                 `json:"dayOff"`
+	LeaveDays         float64             `json:"leaveDays"`
+	LeaveDaysByType   map[string]float64  `json:"leaveDaysByType"`
+	LateMinutes       int                 `json:"lateMinutes"`
+	EarlyLeaveMinutes int                 `json:"earlyLeaveMinutes"`
+	WorkedMinutes     int                 `json:"workedMinutes"`
+	// CorrectedDays counts the days whose clock-in or clock-out came from an attendance correction
+	CorrectedDays int `json:"correctedDays"`
+	// OvertimeMinutes is the approved overtime, per day type in OvertimeMinutesByDayType.
+	// WeightedOvertimeHours applies the multiplier of each day to its approved overtime.
+	OvertimeMinutes          int            `json:"overtimeMinutes"`
+	PendingOvertimeMinutes   int            `json:"pendingOvertimeMinutes"`
+	OvertimeMinutesByDayType map[string]int `json:"overtimeMinutesByDayType"`
+	WeightedOvertimeHours    float64        `json:"weightedOvertimeHours"`
+}
@@ -1,5 +1,7 @@
 package schema
 
+import "encoding/json"
+
 type AttendanceSearchQuery struct {
 	Name  string `form:"name"`
 	Page  int    `form:"page"`
@@ -7,19 +9,49 @@ type AttendanceSearchQuery struct {
 }
 
 type AttendanceRequest struct {
-	Name               *string                     `form:"name" validate:"required"`
-	AttendanceSchedule []AttendanceScheduleRequest `json:"attendanceSchedules"`
+	Name               *string                     `json:"name" validate:"required,notblank"`
+	HolidayCalendarID  *string                     `json:"holidayCalendarId" validate:"omitempty,uuid"`
+	OvertimeRuleID     *string                     `json:"overtimeRuleId" validate:"omitempty,uuid"`
+	AttendanceSchedule []AttendanceScheduleRequest `json:"attendanceSchedules" validate:"dive"`
 }
 
 type AttendanceScheduleRequest struct {
-	DayOfWeek       *int    `json:"dayOfWeek"`
-	Date            *string `json:"date"`
-	StartTime       *string `json:"startTime"`
-	EndTime         *string `json:"endTime"`
-	EarlyInMinutes  *int    `json:"early_in_minutes"`
-	LateInMinutes   *int    `json:"late_in_minutes"`
-	EarlyOutMinutes *int    `json:"early_out_minutes"`
-	LateOutMinutes  *int    `json:"late_out_minutes"`
+	DayOfWeek       *int    `json:"dayOfWeek" validate:"required,dayOfWeek"`
+	Date            *string `json:"date" validate:"omitempty,date"`
+	StartTime       *string `json:"startTime" validate:"omitempty,timeOfDay"`
+	EndTime         *string `json:"endTime" validate:"omitempty,timeOfDay"`
+	EarlyInMinutes  *int    `json:"earlyInMinutes" validate:"omitempty,min=0"`
+	LateInMinutes   *int    `json:"lateInMinutes" validate:"omitempty,min=0"`
+	EarlyOutMinutes *int    `json:"earlyOutMinutes" validate:"omitempty,min=0"`
+	LateOutMinutes  *int    `json:"lateOutMinutes" validate:"omitempty,min=0"`
+}
+
+// UnmarshalJSON also accepts the snake_case minute names sent by clients written before the API
+// used camelCase throughout.
+func (r *AttendanceScheduleRequest) UnmarshalJSON(data []byte) error {
+	type scheduleRequest AttendanceScheduleRequest
+	var request struct {
+		scheduleRequest
+		LegacyEarlyInMinutes  *int `json:"early_in_minutes"`
+		LegacyLateInMinutes   *int `json:"late_in_minutes"`
+		LegacyEarlyOutMinutes *int `json:"early_out_minutes"`
+		LegacyLateOutMinutes  *int `json:"late_out_minutes"`
+	}
+	if err := json.Unmarshal(data, &request); err != nil {
+		return err
+	}
+	*r = AttendanceScheduleRequest(request.scheduleRequest)
+	for _, minutes := range []struct{ value, legacy **int }{
+		{&r.EarlyInMinutes, &request.LegacyEarlyInMinutes},
+		{&r.LateInMinutes, &request.LegacyLateInMinutes},
+		{&r.EarlyOutMinutes, &request.LegacyEarlyOutMinutes},
+		{&r.LateOutMinutes, &request.LegacyLateOutMinutes},
+	} {
+		if *minutes.value == nil {
+			*minutes.value = *minutes.legacy
+		}
+	}
+	return nil
 }
 
 type AttendanceScheduleResponse struct {
@@ -28,14 +60,16 @@ type AttendanceScheduleResponse struct {
 	Date            *string `json:"date"`
 	StartTime       string  `json:"startTime"`
 	EndTime         string  `json:"endTime"`
-	EarlyInMinutes  int     `json:"early_in_minutes"`
-	LateInMinutes   int     `json:"late_in_minutes"`
-	EarlyOutMinutes int     `json:"early_out_minutes"`
-	LateOutMinutes  int     `json:"late_out_minutes"`
+	EarlyInMinutes  int     `json:"earlyInMinutes"`
+	LateInMinutes   int     `json:"lateInMinutes"`
+	EarlyOutMinutes int     `json:"earlyOutMinutes"`
+	LateOutMinutes  int     `json:"lateOutMinutes"`
 }
 
 type AttendanceInfoResponse struct {
 	ID                  string                       `json:"id"`
 	Name                string                       `json:"name"`
+	HolidayCalendar     *HolidayCalendarInfoResponse `json:"holidayCalendar"`
+	OvertimeRule        *OvertimeRuleInfoResponse    `json:"overtimeRule"`
 	AttendanceSchedules []AttendanceScheduleResponse `json:"attendanceSchedules"`
 }
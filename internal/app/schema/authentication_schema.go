@@ -3,12 +3,21 @@ package schema
 import "github.com/golang-jwt/jwt/v5"
 
 type LoginRequest struct {
-	Username string `json:"username" binding:"required"`
-	Password string `json:"password" binding:"required"`
+	Username string `json:"username" validate:"required"`
+	Password string `json:"password" validate:"required"`
 }
 
+// TokenResponse carries the access token of a login or a tenant switch.
+type TokenResponse struct {
+	Token string `json:"token"`
+}
+
+// CustomClaims are the claims of an access token. TenantID is the tenant every request of the
+// token works in, empty only for a super admin in the global scope.
 type CustomClaims struct {
-	Username string `json:"username"`
-	Role     string `json:"role"`
+	Username   string `json:"username"`
+	Role       string `json:"role"`
+	TenantID   string `json:"tenant_id"`
+	SuperAdmin bool   `json:"super_admin,omitempty"`
 	jwt.RegisteredClaims
 }
@@ -0,0 +1,19 @@
+package schema
+
+type DataSubjectExportQuery struct {
+	Reason string `form:"reason"`
+}
+
+// DataSubjectEraseRequest erases the personal data of a person. reason is kept in the audit log.
+type DataSubjectEraseRequest struct {
+	Reason *string `json:"reason" validate:"required"`
+}
+
+// DataSubjectExportManifest describes a data subject export ZIP.
+type DataSubjectExportManifest struct {
+	PersonID   string   `json:"personId"`
+	ExportedAt string   `json:"exportedAt"`
+	ExportedBy string   `json:"exportedBy"`
+	Reason     string   `json:"reason"`
+	Files      []string `json:"files"`
+}
@@ -0,0 +1,58 @@
+package schema
+
+type HolidayCalendarSearchQuery struct {
+	Name  string `form:"name"`
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+}
+
+// Request
+
+// HolidayRequest is a holiday date range. EndDate defaults to StartDate. Leave StartTime and
+// EndTime empty to close the whole day, or set both for special hours.
+type HolidayRequest struct {
+	Name      *string `json:"name" validate:"required,notblank"`
+	StartDate *string `json:"startDate" validate:"required,notblank,date"`
+	EndDate   *string `json:"endDate" validate:"omitempty,date,notBefore=StartDate"`
+	StartTime *string `json:"startTime" validate:"omitempty,timeOfDay"`
+	EndTime   *string `json:"endTime" validate:"omitempty,timeOfDay,after=StartTime"`
+}
+
+type HolidayCalendarRequest struct {
+	Name        *string          `json:"name" validate:"required,notblank"`
+	Description *string          `json:"description"`
+	Holidays    []HolidayRequest `json:"holidays" validate:"dive"`
+}
+
+// Response
+
+type HolidayResponse struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	StartDate string  `json:"startDate"`
+	EndDate   string  `json:"endDate"`
+	StartTime *string `json:"startTime"`
+	EndTime   *string `json:"endTime"`
+}
+
+type HolidayCalendarInfoResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type HolidayCalendarResponse struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description *string           `json:"description"`
+	Holidays    []HolidayResponse `json:"holidays"`
+}
+
+// HolidayCalendarImportQuery drops the existing holidays of the calendar first with replace=true.
+type HolidayCalendarImportQuery struct {
+	Replace bool `form:"replace"`
+}
+
+type HolidayCalendarImportResponse struct {
+	Imported int                      `json:"imported"`
+	Calendar *HolidayCalendarResponse `json:"calendar"`
+}
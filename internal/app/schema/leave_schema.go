@@ -0,0 +1,96 @@
+package schema
+
+type LeaveTypeSearchQuery struct {
+	Name  string `form:"name"`
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+}
+
+type LeaveRequestSearchQuery struct {
+	PersonID    string `form:"personId" validate:"omitempty,uuid"`
+	LeaveTypeID string `form:"leaveTypeId" validate:"omitempty,uuid"`
+	Status      string `form:"status" validate:"omitempty,approvalStatus"`
+	StartDate   string `form:"startDate" validate:"omitempty,date"`
+	EndDate     string `form:"endDate" validate:"omitempty,date,notBefore=StartDate"`
+	Page        int    `form:"page"`
+	Limit       int    `form:"limit"`
+}
+
+// Request
+
+type LeaveTypeRequest struct {
+	Name         *string  `json:"name" validate:"required,notblank"`
+	Description  *string  `json:"description"`
+	DaysPerYear  *float64 `json:"daysPerYear" validate:"required,min=0"`
+	AllowHalfDay *bool    `json:"allowHalfDay"`
+}
+
+// LeaveRequestRequest asks leave for a person. Dates are "YYYY-MM-DD", both inclusive. HalfDay
+// ("morning" or "afternoon") is only allowed when startDate and endDate are the same date.
+type LeaveRequestRequest struct {
+	PersonID    *string `json:"personId" validate:"required,notblank,uuid"`
+	LeaveTypeID *string `json:"leaveTypeId" validate:"required,notblank,uuid"`
+	StartDate   *string `json:"startDate" validate:"required,notblank,date"`
+	EndDate     *string `json:"endDate" validate:"required,notblank,date,notBefore=StartDate"`
+	HalfDay     *string `json:"halfDay" validate:"omitempty,leaveHalfDay"`
+	Reason      *string `json:"reason"`
+}
+
+// DecisionRequest approves, rejects or cancels a leave request or an attendance correction
+// with an optional note.
+type DecisionRequest struct {
+	Note *string `json:"note"`
+}
+
+// LeaveBalanceQuery selects the year of the balances, the current year when empty.
+type LeaveBalanceQuery struct {
+	Year string `form:"year"`
+}
+
+type LeaveBalanceRequest struct {
+	LeaveTypeID  *string  `json:"leaveTypeId" validate:"required,notblank,uuid"`
+	Year         *int     `json:"year" validate:"required,min=1900"`
+	EntitledDays *float64 `json:"entitledDays" validate:"required,min=0"`
+}
+
+// Response
+
+type LeaveTypeInfoResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type LeaveTypeResponse struct {
+	ID           string  `json:"id"`
+	Name         string  `json:"name"`
+	Description  *string `json:"description"`
+	DaysPerYear  float64 `json:"daysPerYear"`
+	AllowHalfDay bool    `json:"allowHalfDay"`
+}
+
+type LeaveRequestResponse struct {
+	ID           string                 `json:"id"`
+	Person       *PersonInfoResponse    `json:"person"`
+	LeaveType    *LeaveTypeInfoResponse `json:"leaveType"`
+	StartDate    string                 `json:"startDate"`
+	EndDate      string                 `json:"endDate"`
+	HalfDay      *string                `json:"halfDay"`
+	Days         float64                `json:"days"`
+	Reason       *string                `json:"reason"`
+	Status       string                 `json:"status"`
+	RequestedBy  *string                `json:"requestedBy"`
+	DecidedBy    *string                `json:"decidedBy"`
+	DecidedAt    *string                `json:"decidedAt"`
+	DecisionNote *string                `json:"decisionNote"`
+}
+
+// LeaveBalanceResponse is the balance of one leave type in a year. Used counts approved
+// requests, Pending the requests still waiting for approval.
+type LeaveBalanceResponse struct {
+	LeaveType     *LeaveTypeInfoResponse `json:"leaveType"`
+	Year          int                    `json:"year"`
+	EntitledDays  float64                `json:"entitledDays"`
+	UsedDays      float64                `json:"usedDays"`
+	PendingDays   float64                `json:"pendingDays"`
+	RemainingDays float64                `json:"remainingDays"`
+}
@@ -0,0 +1,70 @@
+package schema
+
+type LocationSearchQuery struct {
+	Name     string `form:"name"`
+	Type     string `form:"type" validate:"omitempty,locationType"`
+	ParentID string `form:"parentId" validate:"omitempty,uuid"`
+	Page     int    `form:"page"`
+	Limit    int    `form:"limit"`
+}
+
+// LocationOccupancyQuery sets the start of the occupancy count as an RFC 3339 timestamp. Without it
+// the count starts at midnight today in the time zone of the location.
+type LocationOccupancyQuery struct {
+	Since string `form:"since" validate:"omitempty,timestamp"`
+}
+
+// Request
+
+// LocationRequest creates or replaces a location. A location without parentId is at the top of
+// the hierarchy.
+type LocationRequest struct {
+	Name        *string `json:"name" validate:"required,notblank"`
+	Type        *string `json:"type" validate:"required,notblank,locationType"`
+	ParentID    *string `json:"parentId" validate:"omitempty,uuid"`
+	Description *string `json:"description"`
+	TimeZone    *string `json:"timeZone" validate:"omitempty,timeZone"`
+}
+
+// Response
+
+type LocationInfoResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Path string `json:"path"`
+}
+
+type LocationResponse struct {
+	ID          string                `json:"id"`
+	Name        string                `json:"name"`
+	Type        string                `json:"type"`
+	Path        string                `json:"path"`
+	Parent      *LocationInfoResponse `json:"parent"`
+	Description *string               `json:"description"`
+	TimeZone    *string               `json:"timeZone"`
+}
+
+// LocationTreeResponse is a location with its devices and the locations below it.
+type LocationTreeResponse struct {
+	ID                   string                            `json:"id"`
+	Name                 string                            `json:"name"`
+	Type                 string                            `json:"type"`
+	AccessControlDevices []AccessControlDeviceInfoResponse `json:"accessControlDevices"`
+	Children             []LocationTreeResponse            `json:"children"`
+}
+
+// LocationOccupancyResponse lists the people whose last successful access since the start of the
+// count was an entry at a device at the location or below it.
+type LocationOccupancyResponse struct {
+	Location  LocationInfoResponse       `json:"location"`
+	Since     string                     `json:"since"`
+	Occupancy int                        `json:"occupancy"`
+	People    []LocationOccupantResponse `json:"people"`
+}
+
+type LocationOccupantResponse struct {
+	Person              *AccessRecordPersonResponse `json:"person"`
+	AccessControlDevice *AccessRecordDeviceResponse `json:"accessControlDevice"`
+	EnteredAt           string                      `json:"enteredAt"`
+}
@@ -0,0 +1,48 @@
+package schema
+
+type OvertimeRuleSearchQuery struct {
+	Name  string `form:"name"`
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+}
+
+// Request
+
+// OvertimeRuleRequest configures overtime. weekendDays is a comma separated list of days of
+// week (1 = Monday ... 7 = Sunday), "6,7" by default. dailyCapMinutes 0 means no cap.
+type OvertimeRuleRequest struct {
+	Name              *string  `json:"name" validate:"required,notblank"`
+	MinimumMinutes    *int     `json:"minimumMinutes" validate:"omitempty,min=0"`
+	RoundingMinutes   *int     `json:"roundingMinutes" validate:"omitempty,min=0"`
+	DailyCapMinutes   *int     `json:"dailyCapMinutes" validate:"omitempty,min=0"`
+	WeekendDays       *string  `json:"weekendDays"`
+	WeekdayMultiplier *float64 `json:"weekdayMultiplier" validate:"required,min=0"`
+	WeekendMultiplier *float64 `json:"weekendMultiplier" validate:"required,min=0"`
+	HolidayMultiplier *float64 `json:"holidayMultiplier" validate:"required,min=0"`
+}
+
+// OvertimeDecisionRequest approves or rejects the overtime of an attendance record. Minutes
+// approves less than the calculated overtime; it defaults to all of it.
+type OvertimeDecisionRequest struct {
+	Minutes *int    `json:"minutes" validate:"omitempty,min=0"`
+	Note    *string `json:"note"`
+}
+
+// Response
+
+type OvertimeRuleInfoResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type OvertimeRuleResponse struct {
+	ID                string  `json:"id"`
+	Name              string  `json:"name"`
+	MinimumMinutes    int     `json:"minimumMinutes"`
+	RoundingMinutes   int     `json:"roundingMinutes"`
+	DailyCapMinutes   int     `json:"dailyCapMinutes"`
+	WeekendDays       string  `json:"weekendDays"`
+	WeekdayMultiplier float64 `json:"weekdayMultiplier"`
+	WeekendMultiplier float64 `json:"weekendMultiplier"`
+	HolidayMultiplier float64 `json:"holidayMultiplier"`
+}
@@ -0,0 +1,106 @@
+package schema
+
+type PayrollExportTemplateSearchQuery struct {
+	Name    string `form:"name"`
+	Company string `form:"company"`
+	Page    int    `form:"page"`
+	Limit   int    `form:"limit"`
+}
+
+type PayrollExportSearchQuery struct {
+	TemplateID string `form:"templateId" validate:"omitempty,uuid"`
+	Company    string `form:"company"`
+	Status     string `form:"status"`
+	Page       int    `form:"page"`
+	Limit      int    `form:"limit"`
+}
+
+// Request
+
+// PayrollExportColumnRequest is one column of a template, in the order of the request. header
+// defaults to the field name. width is required for fixed-width files, align is "left" or "right"
+// and defaults to right for numbers.
+type PayrollExportColumnRequest struct {
+	Field  *string `json:"field" validate:"required,notblank,payrollField"`
+	Header *string `json:"header"`
+	Width  *int    `json:"width" validate:"omitempty,min=0"`
+	Align  *string `json:"align" validate:"omitempty,payrollAlign"`
+}
+
+// PayrollExportTemplateRequest configures a payroll file layout. format is "csv" or "fixed_width",
+// delimiter defaults to "," and dateFormat (YYYY, YY, MM and DD) to "YYYY-MM-DD".
+type PayrollExportTemplateRequest struct {
+	Name          *string                      `json:"name" validate:"required,notblank"`
+	Company       *string                      `json:"company"`
+	Format        *string                      `json:"format" validate:"required,notblank,payrollFormat"`
+	Delimiter     *string                      `json:"delimiter"`
+	DateFormat    *string                      `json:"dateFormat"`
+	IncludeHeader *bool                        `json:"includeHeader"`
+	Columns       []PayrollExportColumnRequest `json:"columns" validate:"required,min=1,dive"`
+}
+
+// PayrollExportRequest generates the payroll file of a period, "YYYY-MM-DD" both inclusive.
+// company defaults to the company of the template.
+type PayrollExportRequest struct {
+	TemplateID *string `json:"templateId" validate:"required,notblank,uuid"`
+	StartDate  *string `json:"startDate" validate:"required,notblank,date"`
+	EndDate    *string `json:"endDate" validate:"required,notblank,date,notBefore=StartDate"`
+	Company    *string `json:"company"`
+}
+
+// Response
+
+type PayrollExportColumnResponse struct {
+	Field  string `json:"field"`
+	Header string `json:"header"`
+	Width  int    `json:"width"`
+	Align  string `json:"align"`
+}
+
+type PayrollExportTemplateResponse struct {
+	ID            string                        `json:"id"`
+	Name          string                        `json:"name"`
+	Company       *string                       `json:"company"`
+	Format        string                        `json:"format"`
+	Delimiter     string                        `json:"delimiter"`
+	DateFormat    string                        `json:"dateFormat"`
+	IncludeHeader bool                          `json:"includeHeader"`
+	Columns       []PayrollExportColumnResponse `json:"columns"`
+}
+
+type PayrollExportResponse struct {
+	ID               string  `json:"id"`
+	TemplateID       string  `json:"templateId"`
+	TemplateName     string  `json:"templateName"`
+	Company          *string `json:"company"`
+	StartDate        string  `json:"startDate"`
+	EndDate          string  `json:"endDate"`
+	FileName         string  `json:"fileName"`
+	Rows             int     `json:"rows"`
+	Status           string  `json:"status"`
+	GeneratedBy      string  `json:"generatedBy"`
+	GeneratedAt      string  `json:"generatedAt"`
+	LockedBy         *string `json:"lockedBy"`
+	LockedAt         *string `json:"lockedAt"`
+	ChangedAfterLock bool    `json:"changedAfterLock"`
+	ChangedAt        *string `json:"changedAt"`
+}
+
+// PayrollTotalsResponse are the payroll totals of one person over the export period.
+type PayrollTotalsResponse struct {
+	WorkedDays            int     `json:"workedDays"`
+	WorkedMinutes         int     `json:"workedMinutes"`
+	AbsentDays            int     `json:"absentDays"`
+	LateMinutes           int     `json:"lateMinutes"`
+	EarlyLeaveMinutes     int     `json:"earlyLeaveMinutes"`
+	LeaveDays             float64 `json:"leaveDays"`
+	OvertimeMinutes       int     `json:"overtimeMinutes"`
+	WeightedOvertimeHours float64 `json:"weightedOvertimeHours"`
+}
+
+// PayrollExportChangeResponse compares what was exported for a person with the current attendance.
+type PayrollExportChangeResponse struct {
+	Person   *PersonInfoResponse    `json:"person"`
+	Exported *PayrollTotalsResponse `json:"exported"`
+	Current  *PayrollTotalsResponse `json:"current"`
+}
@@ -0,0 +1,45 @@
+package schema
+
+// Request
+
+type PersonCardRequest struct {
+	CardNumber *string `json:"cardNumber" validate:"required,notblank"`
+	ActiveAt   *string `json:"activeAt" validate:"omitempty,timestamp"`
+	ExpireAt   *string `json:"expireAt" validate:"omitempty,timestamp"`
+	Reason     *string `json:"reason"`
+}
+
+type PersonCardValidityRequest struct {
+	ActiveAt *string `json:"activeAt" validate:"omitempty,timestamp"`
+	ExpireAt *string `json:"expireAt" validate:"omitempty,timestamp"`
+}
+
+type PersonCardStatusRequest struct {
+	Status *string `json:"status" validate:"required"`
+	Reason *string `json:"reason"`
+}
+
+// Response
+
+type PersonCardResponse struct {
+	ID         string  `json:"id"`
+	CardNumber string  `json:"cardNumber"`
+	PersonID   string  `json:"personId"`
+	Status     string  `json:"status"`
+	Reason     *string `json:"reason"`
+	ActiveAt   *string `json:"activeAt"`
+	ExpireAt   *string `json:"expireAt"`
+	CreatedAt  string  `json:"createdAt"`
+}
+
+type PersonCardHistoryResponse struct {
+	ID         string  `json:"id"`
+	CardID     string  `json:"cardId"`
+	CardNumber string  `json:"cardNumber"`
+	Action     string  `json:"action"`
+	Status     string  `json:"status"`
+	Reason     *string `json:"reason"`
+	ActiveAt   *string `json:"activeAt"`
+	ExpireAt   *string `json:"expireAt"`
+	CreatedAt  string  `json:"createdAt"`
+}
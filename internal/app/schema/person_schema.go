@@ -20,13 +20,13 @@ type PersonSearchQuery struct {
 var PERSON_TYPE_LIST = []string{"employee", "visitor"}
 
 type PersonRequest struct {
-	FirstName           *string  `form:"firstName" validate:"required"`
+	FirstName           *string  `form:"firstName" validate:"required,notblank"`
 	MiddleName          *string  `form:"middleName"`
-	LastName            *string  `form:"lastName" validate:"required"`
-	PersonType          *string  `form:"personType" validate:"required,personType"`
+	LastName            *string  `form:"lastName" validate:"required,notblank"`
+	PersonType          *string  `form:"personType" validate:"required,notblank,personType"`
 	PersonID            *string  `form:"personId"`
 	Gender              *string  `form:"gender"`
-	DateOfBirth         *string  `form:"dateOfBirth"`
+	DateOfBirth         *string  `form:"dateOfBirth" validate:"omitempty,timestamp"`
 	Company             *string  `form:"company"`
 	Department          *string  `form:"department"`
 	JobPosition         *string  `form:"jobPosition"`
@@ -34,15 +34,19 @@ type PersonRequest struct {
 	MobileNumber        *string  `form:"mobileNumber"`
 	Email               *string  `form:"email"`
 	IsVerified          *bool    `form:"isVerified"`
-	ActiveAt            *string  `form:"activeAt"`
-	ExpireAt            *string  `form:"expireAt"`
+	ActiveAt            *string  `form:"activeAt" validate:"omitempty,timestamp"`
+	ExpireAt            *string  `form:"expireAt" validate:"omitempty,timestamp,notBefore=ActiveAt"`
 	CardIDs             []string `form:"cardIds"`
 	LicensePlateTexts   []string `form:"licensePlateTexts"`
-	AccessControlRuleID *string  `form:"accessControlRuleId"`
-	TimeAttendanceID    *string  `form:"timeAttendanceId"`
+	AccessControlRuleID *string  `form:"accessControlRuleId" validate:"omitempty,uuid"`
+	TimeAttendanceID    *string  `form:"timeAttendanceId" validate:"omitempty,uuid"`
 	// Face image will receive in function
 }
 
+type PersonPINRequest struct {
+	PIN *string `json:"pin" validate:"required,notblank"`
+}
+
 type PersonInfoResponse struct {
 	ID         string `json:"id"`
 	Name       string `json:"name"`
@@ -50,6 +54,13 @@ type PersonInfoResponse struct {
 	PersonID   string `json:"personId"`
 }
 
+// PersonFaceImageResponse holds the URLs of every stored variant of a person's face image.
+type PersonFaceImageResponse struct {
+	OriginalURL   string `json:"originalUrl"`
+	NormalizedURL string `json:"normalizedUrl"`
+	ThumbnailURL  string `json:"thumbnailUrl"`
+}
+
 type PersonResponse struct {
 	ID                string                         `json:"id"`
 	FirstName         string                         `json:"firstName"`
@@ -68,9 +79,95 @@ type PersonResponse struct {
 	IsVerified        bool                           `json:"isVerified"`
 	CardIDs           []string                       `json:"cardIds"`
 	LicensePlateTexts []string                       `json:"licensePlateTexts"`
-	FaceImagePath     *string                        `json:"faceImagePath"`
+	FaceImage         *PersonFaceImageResponse       `json:"faceImage"`
+	HasPIN            bool                           `json:"hasPin"`
 	ActiveAt          *time.Time                     `json:"activeAt"`
 	ExpireAt          *time.Time                     `json:"expireAt"`
 	AccessControlRule *AccessControlRuleInfoResponse `json:"accessControlRule"`
 	TimeAttendance    *AttendanceInfoResponse        `json:"timeAttendance"`
+	ErasedAt          *time.Time                     `json:"erasedAt"`
+}
+
+// PERSON_IMPORT_COLUMNS is the column order used by the people import/export file.
+// Card numbers and license plates are separated by PERSON_IMPORT_LIST_SEPARATOR.
+var PERSON_IMPORT_COLUMNS = []string{
+	"firstName",
+	"middleName",
+	"lastName",
+	"personType",
+	"personId",
+	"gender",
+	"dateOfBirth",
+	"company",
+	"department",
+	"jobPosition",
+	"address",
+	"mobileNumber",
+	"email",
+	"isVerified",
+	"activeAt",
+	"expireAt",
+	"cardIds",
+	"licensePlateTexts",
+	"accessControlRule",
+	"timeAttendance",
+}
+
+const PERSON_IMPORT_LIST_SEPARATOR = ";"
+
+type PersonImportQuery struct {
+	DryRun bool `form:"dryRun"`
+}
+
+type PersonExportQuery struct {
+	PersonSearchQuery
+	Format string `form:"format"`
+}
+
+type PersonImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type PersonImportResult struct {
+	DryRun       bool                   `json:"dryRun"`
+	TotalRows    int                    `json:"totalRows"`
+	ValidRows    int                    `json:"validRows"`
+	ImportedRows int                    `json:"importedRows"`
+	Errors       []PersonImportRowError `json:"errors"`
+}
+
+// Face image ZIP import
+
+const (
+	FACE_IMAGE_MATCH_BY_PERSON_ID = "personId"
+	FACE_IMAGE_MATCH_BY_EMAIL     = "email"
+	FACE_IMAGE_MATCH_BY_MANIFEST  = "manifest"
+)
+
+var FACE_IMAGE_MATCH_BY_LIST = []string{FACE_IMAGE_MATCH_BY_PERSON_ID, FACE_IMAGE_MATCH_BY_EMAIL, FACE_IMAGE_MATCH_BY_MANIFEST}
+
+const (
+	FACE_IMAGE_IMPORT_STATUS_IMPORTED = "imported"
+	FACE_IMAGE_IMPORT_STATUS_FAILED   = "failed"
+)
+
+type PersonFaceImageImportQuery struct {
+	MatchBy string `form:"matchBy"`
+}
+
+type PersonFaceImageImportFileResult struct {
+	FileName string              `json:"fileName"`
+	Person   *PersonInfoResponse `json:"person"`
+	Status   string              `json:"status"`
+	Message  string              `json:"message,omitempty"`
+}
+
+type PersonFaceImageImportResult struct {
+	MatchBy       string                            `json:"matchBy"`
+	TotalFiles    int                               `json:"totalFiles"`
+	ImportedFiles int                               `json:"importedFiles"`
+	FailedFiles   int                               `json:"failedFiles"`
+	Files         []PersonFaceImageImportFileResult `json:"files"`
 }
@@ -0,0 +1,58 @@
+package schema
+
+type RetentionRunSearchQuery struct {
+	Trigger string `form:"trigger"`
+	Status  string `form:"status"`
+	Page    int    `form:"page"`
+	Limit   int    `form:"limit"`
+}
+
+type AuditLogSearchQuery struct {
+	Action     string `form:"action"`
+	EntityType string `form:"entityType"`
+	EntityID   string `form:"entityId"`
+	Username   string `form:"username"`
+	Page       int    `form:"page"`
+	Limit      int    `form:"limit"`
+}
+
+// Response
+
+// RetentionPolicyResponse is the retention period of each data class in days, 0 keeps the data
+// forever.
+type RetentionPolicyResponse struct {
+	AccessRecordDays     int `json:"accessRecordDays"`
+	AttendanceRecordDays int `json:"attendanceRecordDays"`
+	FaceImageDays        int `json:"faceImageDays"`
+	AuditLogDays         int `json:"auditLogDays"`
+	VisitorDays          int `json:"visitorDays"`
+}
+
+type RetentionRunItemResponse struct {
+	DataClass  string  `json:"dataClass"`
+	Cutoff     string  `json:"cutoff"`
+	Purged     int     `json:"purged"`
+	ArchiveURL string  `json:"archiveUrl"`
+	Detail     *string `json:"detail"`
+}
+
+type RetentionRunResponse struct {
+	ID          string                     `json:"id"`
+	Trigger     string                     `json:"trigger"`
+	TriggeredBy *string                    `json:"triggeredBy"`
+	Status      string                     `json:"status"`
+	StartedAt   string                     `json:"startedAt"`
+	FinishedAt  *string                    `json:"finishedAt"`
+	Error       *string                    `json:"error"`
+	Items       []RetentionRunItemResponse `json:"items"`
+}
+
+type AuditLogResponse struct {
+	ID         string  `json:"id"`
+	Action     string  `json:"action"`
+	EntityType string  `json:"entityType"`
+	EntityID   *string `json:"entityId"`
+	Username   string  `json:"username"`
+	Detail     *string `json:"detail"`
+	CreatedAt  string  `json:"createdAt"`
+}
@@ -0,0 +1,104 @@
+package schema
+
+type ShiftTemplateSearchQuery struct {
+	Name  string `form:"name"`
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+}
+
+type ShiftRotationSearchQuery struct {
+	Name  string `form:"name"`
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+}
+
+// Request
+
+// ShiftTemplateRequest is a working shift. An endTime at or before startTime ends on the next day.
+type ShiftTemplateRequest struct {
+	Name            *string `json:"name" validate:"required,notblank"`
+	StartTime       *string `json:"startTime" validate:"required,notblank,timeOfDay"`
+	EndTime         *string `json:"endTime" validate:"required,notblank,timeOfDay"`
+	EarlyInMinutes  *int    `json:"earlyInMinutes" validate:"omitempty,min=0"`
+	LateInMinutes   *int    `json:"lateInMinutes" validate:"omitempty,min=0"`
+	EarlyOutMinutes *int    `json:"earlyOutMinutes" validate:"omitempty,min=0"`
+	LateOutMinutes  *int    `json:"lateOutMinutes" validate:"omitempty,min=0"`
+}
+
+// ShiftRotationRequest is a repeating cycle anchored on anchorDate. Days lists the shift template
+// ID of every day of the cycle in order, with null for a day off, so a 4-on-4-off cycle of shift
+// "A" is ["A", "A", "A", "A", null, null, null, null].
+type ShiftRotationRequest struct {
+	Name       *string   `json:"name" validate:"required,notblank"`
+	AnchorDate *string   `json:"anchorDate" validate:"required,notblank,date"`
+	Days       []*string `json:"days" validate:"required,min=1,dive,omitempty,uuid"`
+}
+
+type PersonShiftAssignmentRequest struct {
+	ShiftRotationID *string `json:"shiftRotationId" validate:"required,notblank,uuid"`
+	StartDate       *string `json:"startDate" validate:"required,notblank,date"`
+	EndDate         *string `json:"endDate" validate:"omitempty,date,notBefore=StartDate"`
+}
+
+// PersonShiftOverrideRequest sets the shift of a person on one date. Without shiftTemplateId
+// the date becomes a day off.
+type PersonShiftOverrideRequest struct {
+	Date            *string `json:"date" validate:"required,notblank,date"`
+	ShiftTemplateID *string `json:"shiftTemplateId" validate:"omitempty,uuid"`
+	Reason          *string `json:"reason"`
+}
+
+// Response
+
+type ShiftTemplateInfoResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	StartTime string `json:"startTime"`
+	EndTime   string `json:"endTime"`
+}
+
+type ShiftTemplateResponse struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	StartTime       string `json:"startTime"`
+	EndTime         string `json:"endTime"`
+	CrossesMidnight bool   `json:"crossesMidnight"`
+	EarlyInMinutes  int    `json:"earlyInMinutes"`
+	LateInMinutes   int    `json:"lateInMinutes"`
+	EarlyOutMinutes int    `json:"earlyOutMinutes"`
+	LateOutMinutes  int    `json:"lateOutMinutes"`
+}
+
+type ShiftRotationDayResponse struct {
+	DayIndex      int                        `json:"dayIndex"`
+	ShiftTemplate *ShiftTemplateInfoResponse `json:"shiftTemplate"`
+}
+
+type ShiftRotationInfoResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type ShiftRotationResponse struct {
+	ID         string                     `json:"id"`
+	Name       string                     `json:"name"`
+	AnchorDate string                     `json:"anchorDate"`
+	CycleDays  int                        `json:"cycleDays"`
+	Days       []ShiftRotationDayResponse `json:"days"`
+}
+
+type PersonShiftAssignmentResponse struct {
+	ID            string                     `json:"id"`
+	PersonID      string                     `json:"personId"`
+	ShiftRotation *ShiftRotationInfoResponse `json:"shiftRotation"`
+	StartDate     string                     `json:"startDate"`
+	EndDate       *string                    `json:"endDate"`
+}
+
+type PersonShiftOverrideResponse struct {
+	ID            string                     `json:"id"`
+	PersonID      string                     `json:"personId"`
+	Date          string                     `json:"date"`
+	ShiftTemplate *ShiftTemplateInfoResponse `json:"shiftTemplate"`
+	Reason        *string                    `json:"reason"`
+}
@@ -0,0 +1,33 @@
+package schema
+
+type TenantSearchQuery struct {
+	Name  string `form:"name"`
+	Code  string `form:"code"`
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+}
+
+// Request
+
+// TenantRequest creates or replaces a tenant. Code is a short unique identifier such as
+// "acme-bangkok".
+type TenantRequest struct {
+	Name     *string `json:"name" validate:"required,notblank"`
+	Code     *string `json:"code" validate:"required,notblank"`
+	IsActive *bool   `json:"isActive"`
+}
+
+// SwitchTenantRequest selects the tenant of a super admin. An empty tenantId selects the global
+// scope, which sees every tenant but cannot create tenant data.
+type SwitchTenantRequest struct {
+	TenantID *string `json:"tenantId" validate:"omitempty,uuid"`
+}
+
+// Response
+
+type TenantResponse struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Code     string `json:"code"`
+	IsActive bool   `json:"isActive"`
+}
@@ -36,8 +36,9 @@ type UserPermissionResponse struct {
 }
 
 type UserResponse struct {
-	ID         string                 `json:"id"`
-	Username   string                 `json:"username"`
-	Status     string                 `json:"status"`
-	Permission UserPermissionResponse `json:"permission"`
+	ID           string                 `json:"id"`
+	Username     string                 `json:"username"`
+	Status       string                 `json:"status"`
+	IsSuperAdmin bool                   `json:"isSuperAdmin"`
+	Permission   UserPermissionResponse `json:"permission"`
 }
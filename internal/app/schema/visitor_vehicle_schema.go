@@ -0,0 +1,17 @@
+package schema
+
+type VisitorVehicleSearchQuery struct {
+	PlateText string `form:"plateText"`
+	Page      int    `form:"page"`
+	Limit     int    `form:"limit"`
+}
+
+type VisitorVehicleResponse struct {
+	ID                    string  `json:"id"`
+	PlateText             string  `json:"plateText"`
+	FirstSeenAt           string  `json:"firstSeenAt"`
+	LastSeenAt            string  `json:"lastSeenAt"`
+	SeenCount             int     `json:"seenCount"`
+	AccessControlDeviceID *string `json:"accessControlDeviceId"`
+	LastImageURL          string  `json:"lastImageUrl"`
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
 	"github.com/putteror/access-control-management/internal/app/model"
 	"github.com/putteror/access-control-management/internal/app/repository"
 	"github.com/putteror/access-control-management/internal/app/schema"
@@ -24,13 +25,15 @@ type AccessControlDeviceService interface {
 type accessControlDeviceServiceImpl struct {
 	accessControlDeviceRepo repository.AccessControlDeviceRepository
 	accessControlServerRepo repository.AccessControlServerRepository
+	locationRepo            repository.LocationRepository
 }
 
 // NewAccessControlDeviceService creates a new instance of AccessControlDeviceService.
-func NewAccessControlDeviceService(accessControlDeviceRepo repository.AccessControlDeviceRepository, accessControlServerRepo repository.AccessControlServerRepository) AccessControlDeviceService {
+func NewAccessControlDeviceService(accessControlDeviceRepo repository.AccessControlDeviceRepository, accessControlServerRepo repository.AccessControlServerRepository, locationRepo repository.LocationRepository) AccessControlDeviceService {
 	return &accessControlDeviceServiceImpl{
 		accessControlDeviceRepo: accessControlDeviceRepo,
 		accessControlServerRepo: accessControlServerRepo,
+		locationRepo:            locationRepo,
 	}
 }
 
@@ -43,9 +46,16 @@ func (s *accessControlDeviceServiceImpl) GetAll(searchQuery schema.AccessControl
 func (s *accessControlDeviceServiceImpl) GetByID(id string) (*model.AccessControlDevice, error) {
 	id_uuid, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid ID")
+		return nil, common.NewFieldError("id", "invalid ID")
 	}
-	return s.accessControlDeviceRepo.GetByID(id_uuid)
+	device, err := s.accessControlDeviceRepo.GetByID(id_uuid)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("device with ID '%s' not found", id)
+		}
+		return nil, fmt.Errorf("failed to get device: %w", err)
+	}
+	return device, nil
 }
 
 // Save creates or updates an access control device.
@@ -75,6 +85,8 @@ func (s *accessControlDeviceServiceImpl) Create(bodyRequest *schema.AccessContro
 		AllowClockOut:         *bodyRequest.AllowClockOut,
 		Status:                *bodyRequest.Status,
 		AccessControlServerID: bodyRequest.AccessControlServerID,
+		LocationID:            emptyToNil(bodyRequest.LocationID),
+		TimeZone:              emptyToNil(bodyRequest.TimeZone),
 	}
 
 	// Update existing device
@@ -90,14 +102,14 @@ func (s *accessControlDeviceServiceImpl) Update(id string, bodyRequest *schema.A
 	// Check have item
 	id_uuid, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid ID")
+		return nil, common.NewFieldError("id", "invalid ID")
 	}
 	deviceModel, err := s.accessControlDeviceRepo.GetByID(id_uuid)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get existing device: %w", err)
 	}
 	if deviceModel == nil {
-		return nil, fmt.Errorf("device with ID '%s' not found", id)
+		return nil, common.NewNotFoundError("device with ID '%s' not found", id)
 	}
 
 	// Validate with old model
@@ -114,15 +126,17 @@ func (s *accessControlDeviceServiceImpl) Update(id string, bodyRequest *schema.A
 	deviceModel.Type = *bodyRequest.Type
 	deviceModel.HostAddress = *bodyRequest.HostAddress
 	deviceModel.Username = bodyRequest.Username
-	deviceModel.Password = bodyRequest.Password
-	deviceModel.AccessToken = bodyRequest.AccessToken
-	deviceModel.ApiToken = bodyRequest.ApiToken
+	deviceModel.Password = keepMaskedSecret(deviceModel.Password, bodyRequest.Password)
+	deviceModel.AccessToken = keepMaskedSecret(deviceModel.AccessToken, bodyRequest.AccessToken)
+	deviceModel.ApiToken = keepMaskedSecret(deviceModel.ApiToken, bodyRequest.ApiToken)
 	deviceModel.RecordScan = *bodyRequest.RecordScan
 	deviceModel.RecordAttendance = *bodyRequest.RecordAttendance
 	deviceModel.AllowClockIn = *bodyRequest.AllowClockIn
 	deviceModel.AllowClockOut = *bodyRequest.AllowClockOut
 	deviceModel.Status = *bodyRequest.Status
 	deviceModel.AccessControlServerID = bodyRequest.AccessControlServerID
+	deviceModel.LocationID = emptyToNil(bodyRequest.LocationID)
+	deviceModel.TimeZone = emptyToNil(bodyRequest.TimeZone)
 	// Update existing device
 	if err := s.accessControlDeviceRepo.Update(deviceModel); err != nil {
 		return nil, fmt.Errorf("failed to update device: %w", err)
@@ -136,14 +150,14 @@ func (s *accessControlDeviceServiceImpl) PartialUpdate(id string, bodyRequest *s
 	// Get model from id
 	id_uuid, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid ID")
+		return nil, common.NewFieldError("id", "invalid ID")
 	}
 	deviceModel, err := s.accessControlDeviceRepo.GetByID(id_uuid)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get existing device: %w", err)
 	}
 	if deviceModel == nil {
-		return nil, fmt.Errorf("device with ID '%s' not found", id)
+		return nil, common.NewNotFoundError("device with ID '%s' not found", id)
 	}
 	// Validate with old model
 	validateDuplicateErr := s.validateBodyRequest(*bodyRequest, deviceModel)
@@ -164,13 +178,13 @@ func (s *accessControlDeviceServiceImpl) PartialUpdate(id string, bodyRequest *s
 		deviceModel.Username = bodyRequest.Username
 	}
 	if bodyRequest.Password != nil {
-		deviceModel.Password = bodyRequest.Password
+		deviceModel.Password = keepMaskedSecret(deviceModel.Password, bodyRequest.Password)
 	}
 	if bodyRequest.AccessToken != nil {
-		deviceModel.AccessToken = bodyRequest.AccessToken
+		deviceModel.AccessToken = keepMaskedSecret(deviceModel.AccessToken, bodyRequest.AccessToken)
 	}
 	if bodyRequest.ApiToken != nil {
-		deviceModel.ApiToken = bodyRequest.ApiToken
+		deviceModel.ApiToken = keepMaskedSecret(deviceModel.ApiToken, bodyRequest.ApiToken)
 	}
 	if bodyRequest.RecordScan != nil {
 		deviceModel.RecordScan = *bodyRequest.RecordScan
@@ -190,6 +204,14 @@ func (s *accessControlDeviceServiceImpl) PartialUpdate(id string, bodyRequest *s
 	if bodyRequest.AccessControlServerID != nil {
 		deviceModel.AccessControlServerID = bodyRequest.AccessControlServerID
 	}
+	if bodyRequest.LocationID != nil {
+		// An empty location ID takes the device out of its location
+		deviceModel.LocationID = emptyToNil(bodyRequest.LocationID)
+	}
+	if bodyRequest.TimeZone != nil {
+		// An empty time zone falls back to the time zone of the location
+		deviceModel.TimeZone = emptyToNil(bodyRequest.TimeZone)
+	}
 	// Update existing device
 	if err := s.accessControlDeviceRepo.Update(deviceModel); err != nil {
 		return nil, fmt.Errorf("failed to update device: %w", err)
@@ -202,12 +224,12 @@ func (s *accessControlDeviceServiceImpl) PartialUpdate(id string, bodyRequest *s
 func (s *accessControlDeviceServiceImpl) Delete(id string) error {
 	id_uuid, err := uuid.Parse(id)
 	if err != nil {
-		return fmt.Errorf("invalid ID")
+		return common.NewFieldError("id", "invalid ID")
 	}
 	_, err = s.accessControlDeviceRepo.GetByID(id_uuid)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return fmt.Errorf("device with ID '%s' not found", id)
+			return common.NewNotFoundError("device with ID '%s' not found", id)
 		}
 		return fmt.Errorf("failed to get device by ID: %w", err)
 	}
@@ -239,6 +261,11 @@ func (s *accessControlDeviceServiceImpl) ConvertToResponse(deviceModel *model.Ac
 		}
 	}
 
+	locationResponse, err := getLocationInfo(s.locationRepo, deviceModel.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
 	response := &schema.AccessControlDeviceResponse{
 		ID:                  deviceModel.ID.String(),
 		Name:                deviceModel.Name,
@@ -246,27 +273,23 @@ func (s *accessControlDeviceServiceImpl) ConvertToResponse(deviceModel *model.Ac
 		HostAddress:         deviceModel.HostAddress,
 		Status:              deviceModel.Status,
 		Username:            deviceModel.Username,
+		Password:            common.MaskSecret(deviceModel.Password),
+		AccessToken:         common.MaskSecret(deviceModel.AccessToken),
+		ApiToken:            common.MaskSecret(deviceModel.ApiToken),
 		RecordScan:          deviceModel.RecordScan,
 		RecordAttendance:    deviceModel.RecordAttendance,
 		AllowClockIn:        deviceModel.AllowClockIn,
 		AllowClockOut:       deviceModel.AllowClockOut,
 		AccessControlServer: accessControlServerResponse,
+		Location:            locationResponse,
+		TimeZone:            deviceModel.TimeZone,
 	}
 
 	return response, nil
 }
 
 func (s *accessControlDeviceServiceImpl) validateAndSetDefaultValues(bodyRequest *schema.AccessControlDeviceRequest) (*schema.AccessControlDeviceRequest, error) {
-
-	if bodyRequest.Name == nil || *bodyRequest.Name == "" {
-		return nil, fmt.Errorf("device name cannot be empty")
-	}
-	if bodyRequest.HostAddress == nil || *bodyRequest.HostAddress == "" {
-		return nil, fmt.Errorf("device host address cannot be empty")
-	}
-	if bodyRequest.Type == nil || *bodyRequest.Type == "" {
-		return nil, fmt.Errorf("device type cannot be empty")
-	}
+	// The name, type and host address are checked by the validate tags of the request
 	if bodyRequest.RecordScan == nil {
 		bodyRequest.RecordScan = new(bool)
 		*bodyRequest.RecordScan = false
@@ -301,7 +324,7 @@ func (s *accessControlDeviceServiceImpl) validateBodyRequest(bodyRequest schema.
 	if bodyRequest.AccessControlServerID != nil {
 		server_uuid, err := uuid.Parse(*bodyRequest.AccessControlServerID)
 		if err != nil {
-			return fmt.Errorf("invalid access control server ID")
+			return common.NewValidationError("invalid access control server ID")
 		}
 		_, err = s.accessControlServerRepo.GetByID(server_uuid)
 		if err != nil {
@@ -309,6 +332,12 @@ func (s *accessControlDeviceServiceImpl) validateBodyRequest(bodyRequest schema.
 		}
 	}
 
+	if bodyRequest.LocationID != nil && *bodyRequest.LocationID != "" {
+		if _, err := getLocation(s.locationRepo, *bodyRequest.LocationID); err != nil {
+			return err
+		}
+	}
+
 	// Check duplicate
 	if bodyRequest.Name != nil {
 		isExistName, err := s.accessControlDeviceRepo.IsExistName(*bodyRequest.Name, excludeID)
@@ -316,7 +345,7 @@ func (s *accessControlDeviceServiceImpl) validateBodyRequest(bodyRequest schema.
 			return fmt.Errorf("failed to check device name existence: %w", err)
 		}
 		if isExistName {
-			return fmt.Errorf("device with name '%s' already exists", *bodyRequest.Name)
+			return common.NewConflictError("device with name '%s' already exists", *bodyRequest.Name)
 		}
 	}
 	if bodyRequest.HostAddress != nil {
@@ -325,7 +354,7 @@ func (s *accessControlDeviceServiceImpl) validateBodyRequest(bodyRequest schema.
 			return fmt.Errorf("failed to check device name existence: %w", err)
 		}
 		if isExistHostAddress {
-			return fmt.Errorf("device with name '%s' already exists", *bodyRequest.HostAddress)
+			return common.NewConflictError("device with name '%s' already exists", *bodyRequest.HostAddress)
 		}
 	}
 
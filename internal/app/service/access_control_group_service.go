@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
 	"github.com/putteror/access-control-management/internal/app/model"
 	"github.com/putteror/access-control-management/internal/app/repository"
 	"github.com/putteror/access-control-management/internal/app/schema"
@@ -25,14 +26,18 @@ type AccessControlGroupService interface {
 type accessControlGroupServiceImpl struct {
 	accessControlGroupRepo  repository.AccessControlGroupRepository
 	accessControlDeviceRepo repository.AccessControlDeviceRepository
+	holidayCalendarRepo     repository.HolidayCalendarRepository
+	locationRepo            repository.LocationRepository
 	db                      *gorm.DB
 }
 
 // NewAccessControlGroupService creates a new instance of AccessControlGroupService.
-func NewAccessControlGroupService(accessControlGroupRepo repository.AccessControlGroupRepository, accessControlDeviceRepo repository.AccessControlDeviceRepository, db *gorm.DB) AccessControlGroupService {
+func NewAccessControlGroupService(accessControlGroupRepo repository.AccessControlGroupRepository, accessControlDeviceRepo repository.AccessControlDeviceRepository, holidayCalendarRepo repository.HolidayCalendarRepository, locationRepo repository.LocationRepository, db *gorm.DB) AccessControlGroupService {
 	return &accessControlGroupServiceImpl{
 		accessControlGroupRepo:  accessControlGroupRepo,
 		accessControlDeviceRepo: accessControlDeviceRepo,
+		holidayCalendarRepo:     holidayCalendarRepo,
+		locationRepo:            locationRepo,
 		db:                      db,
 	}
 }
@@ -46,9 +51,16 @@ func (s *accessControlGroupServiceImpl) GetAll(searchQuery schema.AccessControlG
 func (s *accessControlGroupServiceImpl) GetByID(id string) (*model.AccessControlGroup, error) {
 	id_uuid, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid ID")
+		return nil, common.NewFieldError("id", "invalid ID")
 	}
-	return s.accessControlGroupRepo.GetByID(id_uuid)
+	group, err := s.accessControlGroupRepo.GetByID(id_uuid)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("group with ID '%s' not found", id)
+		}
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+	return group, nil
 }
 
 // Create creates a new access control group.
@@ -65,7 +77,10 @@ func (s *accessControlGroupServiceImpl) Create(bodyRequest *schema.AccessControl
 	}
 
 	groupModel := &model.AccessControlGroup{
-		Name: *bodyRequest.Name,
+		Name:                   *bodyRequest.Name,
+		AuthMode:               *bodyRequest.AuthMode,
+		TwoPersonWindowSeconds: *bodyRequest.TwoPersonWindowSeconds,
+		HolidayCalendarID:      emptyToNil(bodyRequest.HolidayCalendarID),
 	}
 
 	// ใช้ Transaction เพื่อให้แน่ใจว่าทั้ง Group และ Device ถูกสร้างหรือยกเลิกพร้อมกัน
@@ -86,6 +101,16 @@ func (s *accessControlGroupServiceImpl) Create(bodyRequest *schema.AccessControl
 				return fmt.Errorf("failed to create group devices: %w", err)
 			}
 		}
+		// Create Group Locations, every device at or below them belongs to the group
+		if len(bodyRequest.LocationIDs) > 0 {
+			groupLocations, err := s.createGroupLocationModels(groupModel.ID.String(), bodyRequest.LocationIDs)
+			if err != nil {
+				return err
+			}
+			if err := txRepo.CreateGroupLocations(groupLocations); err != nil {
+				return fmt.Errorf("failed to create group locations: %w", err)
+			}
+		}
 		// 3. Create Group Schedules (ส่วนที่เพิ่มเข้ามา)
 		if len(bodyRequest.AccessControlGroupSchedules) > 0 {
 			// A. สร้าง Model สำหรับ Schedule
@@ -113,14 +138,14 @@ func (s *accessControlGroupServiceImpl) Update(id string, bodyRequest *schema.Ac
 
 	id_uuid, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid ID")
+		return nil, common.NewFieldError("id", "invalid ID")
 	}
 	groupModel, err := s.accessControlGroupRepo.GetByID(id_uuid)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get existing group: %w", err)
 	}
 	if groupModel == nil {
-		return nil, fmt.Errorf("group with ID '%s' not found", id)
+		return nil, common.NewNotFoundError("group with ID '%s' not found", id)
 	}
 
 	// Set default value
@@ -135,6 +160,9 @@ func (s *accessControlGroupServiceImpl) Update(id string, bodyRequest *schema.Ac
 
 	// Update model
 	groupModel.Name = *bodyRequest.Name
+	groupModel.AuthMode = *bodyRequest.AuthMode
+	groupModel.TwoPersonWindowSeconds = *bodyRequest.TwoPersonWindowSeconds
+	groupModel.HolidayCalendarID = emptyToNil(bodyRequest.HolidayCalendarID)
 
 	err = s.db.Transaction(func(tx *gorm.DB) error {
 		txRepo := repository.NewAccessControlGroupRepository(tx)
@@ -156,6 +184,19 @@ func (s *accessControlGroupServiceImpl) Update(id string, bodyRequest *schema.Ac
 				return fmt.Errorf("failed to create new group devices: %w", err)
 			}
 		}
+		// Delete GroupLocation all & Recreate
+		if err := txRepo.DeleteGroupLocationsByGroupID(id_uuid, tx); err != nil {
+			return fmt.Errorf("failed to delete old group locations: %w", err)
+		}
+		if len(bodyRequest.LocationIDs) > 0 {
+			groupLocations, err := s.createGroupLocationModels(id, bodyRequest.LocationIDs)
+			if err != nil {
+				return err
+			}
+			if err := txRepo.CreateGroupLocations(groupLocations); err != nil {
+				return fmt.Errorf("failed to create new group locations: %w", err)
+			}
+		}
 		// 3. [SCHEDULES] Delete GroupSchedule all & Recreate <--- ส่วนที่เพิ่ม
 		if err := txRepo.DeleteAccessControlGroupScheduleByGroupID(id_uuid, tx); err != nil { // ต้องมี Method นี้ใน Repo
 			return fmt.Errorf("failed to delete old group schedules: %w", err)
@@ -184,14 +225,14 @@ func (s *accessControlGroupServiceImpl) PartialUpdate(id string, bodyRequest *sc
 
 	id_uuid, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid ID")
+		return nil, common.NewFieldError("id", "invalid ID")
 	}
 	groupModel, err := s.accessControlGroupRepo.GetByID(id_uuid)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get existing group: %w", err)
 	}
 	if groupModel == nil {
-		return nil, fmt.Errorf("group with ID '%s' not found", id)
+		return nil, common.NewNotFoundError("group with ID '%s' not found", id)
 	}
 
 	// Validate (check duplicates)
@@ -203,6 +244,15 @@ func (s *accessControlGroupServiceImpl) PartialUpdate(id string, bodyRequest *sc
 	if bodyRequest.Name != nil {
 		groupModel.Name = *bodyRequest.Name
 	}
+	if bodyRequest.AuthMode != nil {
+		groupModel.AuthMode = *bodyRequest.AuthMode
+	}
+	if bodyRequest.TwoPersonWindowSeconds != nil {
+		groupModel.TwoPersonWindowSeconds = *bodyRequest.TwoPersonWindowSeconds
+	}
+	if bodyRequest.HolidayCalendarID != nil {
+		groupModel.HolidayCalendarID = emptyToNil(bodyRequest.HolidayCalendarID)
+	}
 
 	err = s.db.Transaction(func(tx *gorm.DB) error {
 		txRepo := repository.NewAccessControlGroupRepository(tx)
@@ -227,6 +277,21 @@ func (s *accessControlGroupServiceImpl) PartialUpdate(id string, bodyRequest *sc
 				}
 			}
 		}
+		// Update Group Locations (เฉพาะถ้ามีการส่ง LocationIDs มา)
+		if bodyRequest.LocationIDs != nil {
+			if err := txRepo.DeleteGroupLocationsByGroupID(id_uuid, tx); err != nil {
+				return fmt.Errorf("failed to delete old group locations: %w", err)
+			}
+			if len(bodyRequest.LocationIDs) > 0 {
+				groupLocations, err := s.createGroupLocationModels(id, bodyRequest.LocationIDs)
+				if err != nil {
+					return err
+				}
+				if err := txRepo.CreateGroupLocations(groupLocations); err != nil {
+					return fmt.Errorf("failed to create new group locations: %w", err)
+				}
+			}
+		}
 		// 3. [SCHEDULES] Update Group Schedules (เฉพาะถ้ามีการส่ง AccessControlGroupSchedules มา) <--- ส่วนที่เพิ่ม
 		if bodyRequest.AccessControlGroupSchedules != nil {
 			// Delete GroupSchedule all
@@ -259,12 +324,12 @@ func (s *accessControlGroupServiceImpl) PartialUpdate(id string, bodyRequest *sc
 func (s *accessControlGroupServiceImpl) Delete(id string) error {
 	id_uuid, err := uuid.Parse(id)
 	if err != nil {
-		return fmt.Errorf("invalid ID")
+		return common.NewFieldError("id", "invalid ID")
 	}
 	_, err = s.accessControlGroupRepo.GetByID(id_uuid)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return fmt.Errorf("group with ID '%s' not found", id)
+			return common.NewNotFoundError("group with ID '%s' not found", id)
 		}
 		return fmt.Errorf("failed to get group by ID: %w", err)
 	}
@@ -288,17 +353,41 @@ func (s *accessControlGroupServiceImpl) ConvertToResponse(groupModel *model.Acce
 		return nil, err
 	}
 
+	locationIDs, err := s.accessControlGroupRepo.GetLocationIDsByGroupID(groupModel.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location IDs for group: %w", err)
+	}
+	locationResponses := []schema.LocationInfoResponse{}
+	for _, locationID := range locationIDs {
+		locationResponse, err := getLocationInfo(s.locationRepo, &locationID)
+		if err != nil {
+			return nil, err
+		}
+		if locationResponse != nil {
+			locationResponses = append(locationResponses, *locationResponse)
+		}
+	}
+
 	scheduleResponses, err := s.GetGroupScheduleInfo(groupModel.ID.String())
 	if err != nil {
 		return nil, err
 	}
 
+	holidayCalendar, err := getHolidayCalendarInfo(s.holidayCalendarRepo, groupModel.HolidayCalendarID)
+	if err != nil {
+		return nil, err
+	}
+
 	// 3. สร้าง Response
 
 	response := &schema.AccessControlGroupResponse{
 		ID:                          groupModel.ID.String(),
 		Name:                        groupModel.Name,
+		AuthMode:                    groupModel.AuthMode,
+		TwoPersonWindowSeconds:      groupModel.TwoPersonWindowSeconds,
+		HolidayCalendar:             holidayCalendar,
 		AccessControlDevices:        deviceResponses,
+		Locations:                   locationResponses,
 		AccessControlGroupSchedules: scheduleResponses,
 	}
 
@@ -366,12 +455,12 @@ func (s *accessControlGroupServiceImpl) createGroupDeviceModels(groupID string,
 		// ตรวจสอบว่า Device ID นั้นมีอยู่จริงหรือไม่ (optional แต่แนะนำ)
 		device_uuid, err := uuid.Parse(deviceID)
 		if err != nil {
-			return nil, fmt.Errorf("invalid device ID format: %s", deviceID)
+			return nil, common.NewValidationError("invalid device ID format: %s", deviceID)
 		}
 		_, err = s.accessControlDeviceRepo.GetByID(device_uuid)
 		if err != nil {
 			if err == gorm.ErrRecordNotFound {
-				return nil, fmt.Errorf("device with ID '%s' not found", deviceID)
+				return nil, common.NewNotFoundError("device with ID '%s' not found", deviceID)
 			}
 			return nil, fmt.Errorf("failed to check device existence: %w", err)
 		}
@@ -384,6 +473,21 @@ func (s *accessControlGroupServiceImpl) createGroupDeviceModels(groupID string,
 	return groupDevices, nil
 }
 
+// createGroupLocationModels converts location IDs to AccessControlGroupLocation models.
+func (s *accessControlGroupServiceImpl) createGroupLocationModels(groupID string, locationIDs []string) ([]model.AccessControlGroupLocation, error) {
+	var groupLocations []model.AccessControlGroupLocation
+	for _, locationID := range locationIDs {
+		if _, err := getLocation(s.locationRepo, locationID); err != nil {
+			return nil, err
+		}
+		groupLocations = append(groupLocations, model.AccessControlGroupLocation{
+			AccessControlGroupID: groupID,
+			LocationID:           locationID,
+		})
+	}
+	return groupLocations, nil
+}
+
 // createGroupScheduleModels converts AccessControlGroupScheduleRequest to AccessControlGroupSchedule model
 func (s *accessControlGroupServiceImpl) createGroupScheduleModels(groupID string, schedules []schema.AccessControlGroupScheduleRequest) ([]model.AccessControlGroupSchedule, error) {
 	var groupSchedules []model.AccessControlGroupSchedule
@@ -406,12 +510,20 @@ func (s *accessControlGroupServiceImpl) createGroupScheduleModels(groupID string
 
 // Validate and set default
 func (s *accessControlGroupServiceImpl) validateAndSetDefaultValues(bodyRequest *schema.AccessControlGroupRequest) (*schema.AccessControlGroupRequest, error) {
-	if bodyRequest.Name == nil || *bodyRequest.Name == "" {
-		return nil, fmt.Errorf("group name cannot be empty")
+	if bodyRequest.AuthMode == nil || *bodyRequest.AuthMode == "" {
+		authMode := common.AuthModeAny
+		bodyRequest.AuthMode = &authMode
+	}
+	if bodyRequest.TwoPersonWindowSeconds == nil {
+		windowSeconds := common.DefaultTwoPersonWindowSeconds
+		bodyRequest.TwoPersonWindowSeconds = &windowSeconds
 	}
 	if bodyRequest.AccessControlDeviceIDs == nil {
 		bodyRequest.AccessControlDeviceIDs = []string{}
 	}
+	if bodyRequest.LocationIDs == nil {
+		bodyRequest.LocationIDs = []string{}
+	}
 	if bodyRequest.AccessControlGroupSchedules == nil {
 		defaultSchedules := []schema.AccessControlGroupScheduleRequest{}
 		startTime := "00:00:00"
@@ -428,11 +540,8 @@ func (s *accessControlGroupServiceImpl) validateAndSetDefaultValues(bodyRequest
 		}
 		bodyRequest.AccessControlGroupSchedules = defaultSchedules
 	} else if len(bodyRequest.AccessControlGroupSchedules) != 0 {
-		// check data in array validate and replace if time null
+		// replace the times left out, the day of week is checked by the validate tags
 		for i, schedule := range bodyRequest.AccessControlGroupSchedules {
-			if schedule.DayOfWeek == nil || *schedule.DayOfWeek < 1 || *schedule.DayOfWeek > 7 {
-				return nil, fmt.Errorf("invalid day of week")
-			}
 			if schedule.StartTime == nil || *schedule.StartTime == "" {
 				bodyRequest.AccessControlGroupSchedules[i].StartTime = new(string)
 				*bodyRequest.AccessControlGroupSchedules[i].StartTime = "00:00:00"
@@ -462,10 +571,14 @@ func (s *accessControlGroupServiceImpl) validateBodyRequest(bodyRequest schema.A
 		}
 		if isExistName {
 			// แต่เนื่องจากเป็น Service Layer ควร return เป็น error เพื่อให้ Handler จัดการ
-			return fmt.Errorf("accessControlGroup name is already exist: %s", *bodyRequest.Name)
+			return common.NewConflictError("group name '%s' is already exist", *bodyRequest.Name)
 		}
 	}
 
+	if err := validateHolidayCalendarID(s.holidayCalendarRepo, bodyRequest.HolidayCalendarID); err != nil {
+		return err
+	}
+
 	// Note: การตรวจสอบว่า AccessControlDeviceIDs มีอยู่จริงหรือไม่ ถูกย้ายไปทำใน createGroupDeviceModels
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
 	"github.com/putteror/access-control-management/internal/app/model"
 	"github.com/putteror/access-control-management/internal/app/repository"
 	"github.com/putteror/access-control-management/internal/app/schema"
@@ -46,9 +47,16 @@ func (s *accessControlRuleServiceImpl) GetAll(searchQuery schema.AccessControlRu
 func (s *accessControlRuleServiceImpl) GetByID(id string) (*model.AccessControlRule, error) {
 	idUUID, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid ID")
+		return nil, common.NewFieldError("id", "invalid ID")
 	}
-	return s.accessControlRuleRepo.GetByID(idUUID)
+	rule, err := s.accessControlRuleRepo.GetByID(idUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("rule with ID '%s' not found", id)
+		}
+		return nil, fmt.Errorf("failed to get rule: %w", err)
+	}
+	return rule, nil
 }
 
 // Create creates a new access control rule.
@@ -100,14 +108,14 @@ func (s *accessControlRuleServiceImpl) Update(id string, bodyRequest *schema.Acc
 
 	idUUID, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid ID")
+		return nil, common.NewFieldError("id", "invalid ID")
 	}
 	ruleModel, err := s.accessControlRuleRepo.GetByID(idUUID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get existing rule: %w", err)
 	}
 	if ruleModel == nil {
-		return nil, fmt.Errorf("rule with ID '%s' not found", id)
+		return nil, common.NewNotFoundError("rule with ID '%s' not found", id)
 	}
 
 	// Set default value (เพื่อให้แน่ใจว่า GroupIDs ถูกเคลียร์ถ้าไม่ได้ส่งมา)
@@ -160,14 +168,14 @@ func (s *accessControlRuleServiceImpl) PartialUpdate(id string, bodyRequest *sch
 
 	idUUID, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid ID")
+		return nil, common.NewFieldError("id", "invalid ID")
 	}
 	ruleModel, err := s.accessControlRuleRepo.GetByID(idUUID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get existing rule: %w", err)
 	}
 	if ruleModel == nil {
-		return nil, fmt.Errorf("rule with ID '%s' not found", id)
+		return nil, common.NewNotFoundError("rule with ID '%s' not found", id)
 	}
 
 	// Validate (check duplicates)
@@ -223,12 +231,12 @@ func (s *accessControlRuleServiceImpl) PartialUpdate(id string, bodyRequest *sch
 func (s *accessControlRuleServiceImpl) Delete(id string) error {
 	idUUID, err := uuid.Parse(id)
 	if err != nil {
-		return fmt.Errorf("invalid ID")
+		return common.NewFieldError("id", "invalid ID")
 	}
 	_, err = s.accessControlRuleRepo.GetByID(idUUID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return fmt.Errorf("rule with ID '%s' not found", id)
+			return common.NewNotFoundError("rule with ID '%s' not found", id)
 		}
 	}
 	// Note: การลบ Transaction ถูกจัดการภายใน AccessControlRuleRepository.Delete แล้ว
@@ -302,12 +310,12 @@ func (s *accessControlRuleServiceImpl) createRuleGroupModels(ruleID string, grou
 		// ตรวจสอบว่า Group ID นั้นมีอยู่จริงหรือไม่ (แนะนำ)
 		groupUUID, err := uuid.Parse(groupID)
 		if err != nil {
-			return nil, fmt.Errorf("invalid group ID format: %s", groupID)
+			return nil, common.NewValidationError("invalid group ID format: %s", groupID)
 		}
 		_, err = s.accessControlGroupRepo.GetByID(groupUUID)
 		if err != nil {
 			if err == gorm.ErrRecordNotFound {
-				return nil, fmt.Errorf("group with ID '%s' not found", groupID)
+				return nil, common.NewNotFoundError("group with ID '%s' not found", groupID)
 			}
 			return nil, fmt.Errorf("failed to check group existence: %w", err)
 		}
@@ -322,11 +330,6 @@ func (s *accessControlRuleServiceImpl) createRuleGroupModels(ruleID string, grou
 
 // Validate and set default
 func (s *accessControlRuleServiceImpl) validateAndSetDefaultValues(bodyRequest *schema.AccessControlRuleRequest) (*schema.AccessControlRuleRequest, error) {
-	// Name เป็น string ธรรมดาและ required ใน Request Schema จึงไม่ต้องเช็ก nil
-	if bodyRequest.Name == "" {
-		// แม้ว่า validate:"required" จะช่วย แต่การเช็กใน service ก็ดีกว่า
-		return nil, fmt.Errorf("rule name cannot be empty")
-	}
 	// ถ้า AccessControlGroupIDs เป็น nil ให้ตั้งเป็น slice ว่าง
 	if bodyRequest.AccessControlGroupIDs == nil {
 		bodyRequest.AccessControlGroupIDs = []string{}
@@ -349,7 +352,7 @@ func (s *accessControlRuleServiceImpl) validateBodyRequest(bodyRequest schema.Ac
 		return fmt.Errorf("failed to check rule name existence: %w", err)
 	}
 	if isExistName {
-		return fmt.Errorf("accessControlRule name is already exist: %s", bodyRequest.Name)
+		return common.NewConflictError("rule name '%s' is already exist", bodyRequest.Name)
 	}
 
 	// Note: การตรวจสอบว่า AccessControlGroupIDs มีอยู่จริงหรือไม่ ถูกย้ายไปทำใน createRuleGroupModels
@@ -2,9 +2,9 @@ package service
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
 	"github.com/putteror/access-control-management/internal/app/model"
 	"github.com/putteror/access-control-management/internal/app/repository"
 	"github.com/putteror/access-control-management/internal/app/schema"
@@ -42,9 +42,16 @@ func (s *accessControlServerServiceImpl) GetAll(searchQuery schema.AccessControl
 func (s *accessControlServerServiceImpl) GetByID(id string) (*model.AccessControlServer, error) {
 	id_uuid, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid ID")
+		return nil, common.NewFieldError("id", "invalid ID")
 	}
-	return s.accessControlServerRepo.GetByID(id_uuid)
+	server, err := s.accessControlServerRepo.GetByID(id_uuid)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("server with ID '%s' not found", id)
+		}
+		return nil, fmt.Errorf("failed to get server: %w", err)
+	}
+	return server, nil
 }
 
 // Create creates a new access control server.
@@ -85,14 +92,14 @@ func (s *accessControlServerServiceImpl) Update(id string, bodyRequest *schema.A
 	// Check have item
 	id_uuid, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid ID")
+		return nil, common.NewFieldError("id", "invalid ID")
 	}
 	serverModel, err := s.accessControlServerRepo.GetByID(id_uuid)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get existing server: %w", err)
 	}
 	if serverModel == nil {
-		return nil, fmt.Errorf("server with ID '%s' not found", id)
+		return nil, common.NewNotFoundError("server with ID '%s' not found", id)
 	}
 
 	// Validate with old model
@@ -109,9 +116,9 @@ func (s *accessControlServerServiceImpl) Update(id string, bodyRequest *schema.A
 	serverModel.Type = *bodyRequest.Type
 	serverModel.HostAddress = *bodyRequest.HostAddress
 	serverModel.Username = bodyRequest.Username
-	serverModel.Password = bodyRequest.Password
-	serverModel.AccessToken = bodyRequest.AccessToken
-	serverModel.ApiToken = bodyRequest.ApiToken
+	serverModel.Password = keepMaskedSecret(serverModel.Password, bodyRequest.Password)
+	serverModel.AccessToken = keepMaskedSecret(serverModel.AccessToken, bodyRequest.AccessToken)
+	serverModel.ApiToken = keepMaskedSecret(serverModel.ApiToken, bodyRequest.ApiToken)
 	serverModel.Status = *bodyRequest.Status
 	// Update existing server
 	if err := s.accessControlServerRepo.Update(serverModel); err != nil {
@@ -127,14 +134,14 @@ func (s *accessControlServerServiceImpl) PartialUpdate(id string, bodyRequest *s
 	// Get model from id
 	id_uuid, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid ID")
+		return nil, common.NewFieldError("id", "invalid ID")
 	}
 	serverModel, err := s.accessControlServerRepo.GetByID(id_uuid)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get existing server: %w", err)
 	}
 	if serverModel == nil {
-		return nil, fmt.Errorf("server with ID '%s' not found", id)
+		return nil, common.NewNotFoundError("server with ID '%s' not found", id)
 	}
 
 	// Validate with old model
@@ -156,13 +163,13 @@ func (s *accessControlServerServiceImpl) PartialUpdate(id string, bodyRequest *s
 		serverModel.Username = bodyRequest.Username
 	}
 	if bodyRequest.Password != nil {
-		serverModel.Password = bodyRequest.Password
+		serverModel.Password = keepMaskedSecret(serverModel.Password, bodyRequest.Password)
 	}
 	if bodyRequest.AccessToken != nil {
-		serverModel.AccessToken = bodyRequest.AccessToken
+		serverModel.AccessToken = keepMaskedSecret(serverModel.AccessToken, bodyRequest.AccessToken)
 	}
 	if bodyRequest.ApiToken != nil {
-		serverModel.ApiToken = bodyRequest.ApiToken
+		serverModel.ApiToken = keepMaskedSecret(serverModel.ApiToken, bodyRequest.ApiToken)
 	}
 	if bodyRequest.Status != nil {
 		serverModel.Status = *bodyRequest.Status
@@ -179,12 +186,12 @@ func (s *accessControlServerServiceImpl) PartialUpdate(id string, bodyRequest *s
 func (s *accessControlServerServiceImpl) Delete(id string) error {
 	id_uuid, err := uuid.Parse(id)
 	if err != nil {
-		return fmt.Errorf("invalid ID")
+		return common.NewFieldError("id", "invalid ID")
 	}
 	_, err = s.accessControlServerRepo.GetByID(id_uuid)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return fmt.Errorf("server with ID '%s' not found", id)
+			return common.NewNotFoundError("server with ID '%s' not found", id)
 		}
 		return fmt.Errorf("failed to get server by ID: %w", err)
 	}
@@ -201,31 +208,26 @@ func (s *accessControlServerServiceImpl) ConvertToResponse(serverModel *model.Ac
 		HostAddress: serverModel.HostAddress,
 		Status:      serverModel.Status,
 		Username:    serverModel.Username,
-		AccessToken: serverModel.AccessToken,
-		ApiToken:    serverModel.ApiToken,
-	}
-	// Omit password from the response for security reasons
-	if serverModel.Password != nil && strings.TrimSpace(*serverModel.Password) != "" {
-		emptyPassword := ""
-		response.Password = &emptyPassword
+		Password:    common.MaskSecret(serverModel.Password),
+		AccessToken: common.MaskSecret(serverModel.AccessToken),
+		ApiToken:    common.MaskSecret(serverModel.ApiToken),
 	}
 
 	return response, nil
 }
 
-// validateAndSetDefaultValues validates request data and sets default values.
-func (s *accessControlServerServiceImpl) validateAndSetDefaultValues(bodyRequest *schema.AccessControlServerRequest) (*schema.AccessControlServerRequest, error) {
-
-	if bodyRequest.Name == nil || *bodyRequest.Name == "" {
-		return nil, fmt.Errorf("server name cannot be empty")
-	}
-	if bodyRequest.HostAddress == nil || *bodyRequest.HostAddress == "" {
-		return nil, fmt.Errorf("server host address cannot be empty")
-	}
-	if bodyRequest.Type == nil || *bodyRequest.Type == "" {
-		return nil, fmt.Errorf("server type cannot be empty")
+// keepMaskedSecret returns the stored secret when a request sends back the masked value of a
+// response, and the requested value otherwise.
+func keepMaskedSecret(stored *string, requested *string) *string {
+	if requested != nil && *requested == common.SecretMask {
+		return stored
 	}
+	return requested
+}
 
+// validateAndSetDefaultValues validates request data and sets default values.
+func (s *accessControlServerServiceImpl) validateAndSetDefaultValues(bodyRequest *schema.AccessControlServerRequest) (*schema.AccessControlServerRequest, error) {
+	// The name, type and host address are checked by the validate tags of the request
 	if bodyRequest.Status == nil {
 		bodyRequest.Status = new(string)
 		*bodyRequest.Status = "active"
@@ -248,7 +250,7 @@ func (s *accessControlServerServiceImpl) validateBodyRequest(bodyRequest schema.
 			return fmt.Errorf("failed to check server name existence: %w", err)
 		}
 		if isExistName {
-			return fmt.Errorf("server with name '%s' already exists", *bodyRequest.Name)
+			return common.NewConflictError("server with name '%s' already exists", *bodyRequest.Name)
 		}
 	}
 	// Check duplicate host address
@@ -258,7 +260,7 @@ func (s *accessControlServerServiceImpl) validateBodyRequest(bodyRequest schema.
 			return fmt.Errorf("failed to check server host address existence: %w", err)
 		}
 		if isExistHostAddress {
-			return fmt.Errorf("server with host address '%s' already exists", *bodyRequest.HostAddress)
+			return common.NewConflictError("server with host address '%s' already exists", *bodyRequest.HostAddress)
 		}
 	}
 
@@ -0,0 +1,788 @@
+package service
+
+import (
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/repository"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// AccessDecisionService decides whether a credential presented at a device opens it.
+// Every decision is stored as an access record.
+type AccessDecisionService interface {
+	Decide(bodyRequest *schema.AccessDecisionRequest) (*schema.AccessDecisionResponse, error)
+	DecideLicensePlate(bodyRequest *schema.LicensePlateEventRequest, plateImageFile *multipart.FileHeader) (*schema.AccessDecisionResponse, error)
+}
+
+type accessDecisionServiceImpl struct {
+	personRepo          repository.PersonRepository
+	personCardRepo      repository.PersonCardRepository
+	personLicenseRepo   repository.PersonLicensePlateRepository
+	visitorVehicleRepo  repository.VisitorVehicleRepository
+	deviceRepo          repository.AccessControlDeviceRepository
+	ruleRepo            repository.AccessControlRuleRepository
+	groupRepo           repository.AccessControlGroupRepository
+	accessRecordRepo    repository.AccessRecordRepository
+	scanSessionRepo     repository.AccessScanSessionRepository
+	holidayCalendarRepo repository.HolidayCalendarRepository
+	fileRepo            repository.FileRepository
+	locationRepo        repository.LocationRepository
+}
+
+// NewAccessDecisionService creates a new instance of AccessDecisionService.
+func NewAccessDecisionService(personRepo repository.PersonRepository, personCardRepo repository.PersonCardRepository, personLicenseRepo repository.PersonLicensePlateRepository, visitorVehicleRepo repository.VisitorVehicleRepository, deviceRepo repository.AccessControlDeviceRepository, ruleRepo repository.AccessControlRuleRepository, groupRepo repository.AccessControlGroupRepository, accessRecordRepo repository.AccessRecordRepository, scanSessionRepo repository.AccessScanSessionRepository, holidayCalendarRepo repository.HolidayCalendarRepository, fileRepo repository.FileRepository, locationRepo repository.LocationRepository) AccessDecisionService {
+	return &accessDecisionServiceImpl{
+		personRepo:          personRepo,
+		personCardRepo:      personCardRepo,
+		personLicenseRepo:   personLicenseRepo,
+		visitorVehicleRepo:  visitorVehicleRepo,
+		deviceRepo:          deviceRepo,
+		ruleRepo:            ruleRepo,
+		groupRepo:           groupRepo,
+		accessRecordRepo:    accessRecordRepo,
+		scanSessionRepo:     scanSessionRepo,
+		holidayCalendarRepo: holidayCalendarRepo,
+		fileRepo:            fileRepo,
+		locationRepo:        locationRepo,
+	}
+}
+
+// Decide checks the presented credentials, the person and the person's access rule for the device
+// at the access time. When the allowed groups need more than one factor, or a second person, the
+// scan stays pending in a scan session and the device sends the next credential with its ID.
+func (s *accessDecisionServiceImpl) Decide(bodyRequest *schema.AccessDecisionRequest) (*schema.AccessDecisionResponse, error) {
+	device, accessTime, err := s.parseDecisionInput(*bodyRequest.AccessControlDeviceID, bodyRequest.AccessTime)
+	if err != nil {
+		return nil, err
+	}
+
+	cardNumber := strings.TrimSpace(stringValue(bodyRequest.CardNumber))
+	facePersonID := strings.TrimSpace(stringValue(bodyRequest.FacePersonID))
+	pin := stringValue(bodyRequest.PIN)
+	if cardNumber == "" && facePersonID == "" && pin == "" {
+		return nil, common.NewValidationError("at least one credential (card number, face or PIN) is required")
+	}
+
+	session, err := s.getOpenScanSession(bodyRequest.SessionID, device.ID.String(), accessTime)
+	if err != nil {
+		return nil, err
+	}
+
+	person, factors, reason, err := s.identifyPerson(session, cardNumber, facePersonID, pin, accessTime)
+	if err != nil {
+		return nil, err
+	}
+	var twoPersonGroup *model.AccessControlGroup
+	if reason == common.AccessReasonGranted {
+		var groups []model.AccessControlGroup
+		groups, reason, err = s.getAllowedGroups(person, device.ID.String(), accessTime)
+		if err != nil {
+			return nil, err
+		}
+		if reason == common.AccessReasonGranted {
+			reason, factors, twoPersonGroup = applyAuthModes(groups, session, person, factors, accessTime)
+		}
+	}
+
+	session, err = s.saveScanSession(session, device.ID.String(), *bodyRequest.Type, person, factors, reason, twoPersonGroup, accessTime)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &model.AccessRecord{
+		AccessControlDeviceID: bodyRequest.AccessControlDeviceID,
+		Type:                  *bodyRequest.Type,
+		AccessTime:            accessTime,
+	}
+	if cardNumber != "" {
+		record.CardNumber = &cardNumber
+	}
+	if session != nil {
+		sessionID := session.ID.String()
+		record.ScanSessionID = &sessionID
+	}
+	response, err := s.recordDecision(record, person, reason, factors)
+	if err != nil {
+		return nil, err
+	}
+	response.Pending = isPendingAccessReason(reason)
+	response.SessionID = record.ScanSessionID
+	return response, nil
+}
+
+// DecideLicensePlate handles a plate read by a license plate camera. The plate is matched
+// against registered plates, tolerating common recognition mistakes, and the gate only opens
+// when the read matches a registered plate exactly, is confident enough and the owner's access
+// rule allows the camera. A read one edit away from a registered plate may be another vehicle, so
+// it is denied and recorded as a fuzzy match. Plates that match nobody are tracked as visitor
+// vehicles.
+func (s *accessDecisionServiceImpl) DecideLicensePlate(bodyRequest *schema.LicensePlateEventRequest, plateImageFile *multipart.FileHeader) (*schema.AccessDecisionResponse, error) {
+	device, accessTime, err := s.parseDecisionInput(*bodyRequest.AccessControlDeviceID, bodyRequest.AccessTime)
+	if err != nil {
+		return nil, err
+	}
+	if device.Type != common.AccessControlDeviceTypeLPRCamera {
+		return nil, common.NewValidationError("access control device '%s' is not a license plate camera", device.Name)
+	}
+
+	plateText := strings.TrimSpace(*bodyRequest.PlateText)
+	normalizedPlate := common.NormalizeLicensePlate(plateText)
+	if normalizedPlate == "" {
+		return nil, common.NewFieldError("plateText", "plate text cannot be empty")
+	}
+
+	var plateImagePath *string
+	if plateImageFile != nil {
+		savedPath, err := s.savePlateImage(plateImageFile, accessTime)
+		if err != nil {
+			return nil, err
+		}
+		plateImagePath = &savedPath
+	}
+
+	matchedPlates, match, err := s.matchLicensePlate(normalizedPlate)
+	if err != nil {
+		return nil, err
+	}
+
+	var person *model.Person
+	var matchedPlateText *string
+	var visitorVehicleID *string
+	reason := common.AccessReasonUnknownCredential
+	personIDs := uniquePlateOwners(matchedPlates)
+	switch {
+	case match == plateMatchTooMany:
+		// Too many registered plates are close to the read to compare them all
+		reason = common.AccessReasonAmbiguousPlate
+	case match == plateMatchFuzzy:
+		// A read one edit away from a registered plate may be another vehicle, it never opens the gate
+		reason = common.AccessReasonFuzzyPlate
+		if len(matchedPlates) == 1 {
+			matchedPlateText = &matchedPlates[0].LicensePlateText
+		}
+	case len(personIDs) == 0:
+		vehicle, err := s.trackVisitorVehicle(plateText, normalizedPlate, device.ID.String(), accessTime, plateImagePath)
+		if err != nil {
+			return nil, err
+		}
+		vehicleID := vehicle.ID.String()
+		visitorVehicleID = &vehicleID
+	case len(personIDs) == 1:
+		personUUID, err := uuid.Parse(personIDs[0])
+		if err != nil {
+			return nil, common.NewValidationError("license plate '%s' has an invalid person ID", matchedPlates[0].LicensePlateText)
+		}
+		person, err = s.personRepo.GetByID(personUUID)
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("failed to get person: %w", err)
+		}
+		if person != nil {
+			matchedPlateText = &matchedPlates[0].LicensePlateText
+			reason = common.AccessReasonLowConfidence
+			if *bodyRequest.Confidence >= common.LicensePlateMinConfidence {
+				reason, err = s.checkLicensePlateAccess(person, device.ID.String(), accessTime)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	default:
+		reason = common.AccessReasonAmbiguousPlate
+	}
+
+	record := &model.AccessRecord{
+		AccessControlDeviceID: bodyRequest.AccessControlDeviceID,
+		Type:                  *bodyRequest.Type,
+		AccessTime:            accessTime,
+		PlateText:             &plateText,
+		PlateConfidence:       bodyRequest.Confidence,
+		PlateImagePath:        plateImagePath,
+	}
+	response, err := s.recordDecision(record, person, reason, []string{common.AccessFactorLicensePlate})
+	if err != nil {
+		return nil, err
+	}
+	response.MatchedPlateText = matchedPlateText
+	response.VisitorVehicleID = visitorVehicleID
+	return response, nil
+}
+
+// ----------> INNER FUNCTION <-----------------------//
+
+// parseDecisionInput loads the device and parses the access time. An empty access time means now.
+// The access time is returned in the local time of the device, so schedules, holidays and dates
+// are evaluated where the device is.
+func (s *accessDecisionServiceImpl) parseDecisionInput(deviceID string, accessTimeStr *string) (*model.AccessControlDevice, time.Time, error) {
+	deviceUUID, err := uuid.Parse(deviceID)
+	if err != nil {
+		return nil, time.Time{}, common.NewValidationError("invalid access control device ID")
+	}
+	device, err := s.deviceRepo.GetByID(deviceUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, time.Time{}, common.NewNotFoundError("access control device with ID '%s' not found", deviceID)
+		}
+		return nil, time.Time{}, fmt.Errorf("failed to get access control device: %w", err)
+	}
+	loc, err := deviceTimeZone(s.locationRepo, device)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	accessTime := time.Now()
+	if accessTimeStr != nil && *accessTimeStr != "" {
+		parsedTime, err := common.ParseTimestamp(*accessTimeStr, loc)
+		if err != nil {
+			return nil, time.Time{}, common.NewValidationError("invalid access time format, expected an RFC 3339 timestamp")
+		}
+		accessTime = parsedTime
+	}
+	return device, accessTime.In(loc), nil
+}
+
+// recordDecision stores the decision as an access record and builds the response.
+// Pending scans are stored as failed with the reason telling what is still missing.
+func (s *accessDecisionServiceImpl) recordDecision(record *model.AccessRecord, person *model.Person, reason string, factors []string) (*schema.AccessDecisionResponse, error) {
+	granted := reason == common.AccessReasonGranted
+	record.Result = "failed"
+	if granted {
+		record.Result = "success"
+	}
+	record.Reason = &reason
+	if len(factors) > 0 {
+		joinedFactors := common.JoinAccessFactors(factors)
+		record.Factors = &joinedFactors
+	}
+
+	response := &schema.AccessDecisionResponse{
+		Granted: granted,
+		Reason:  reason,
+		Factors: factors,
+	}
+	if person != nil {
+		personID := person.ID.String()
+		record.PersonID = &personID
+		response.Person = convertPersonToInfoResponse(person)
+	}
+
+	if err := s.accessRecordRepo.Append(record); err != nil {
+		return nil, fmt.Errorf("failed to create access record: %w", err)
+	}
+	response.AccessRecordID = record.ID.String()
+	return response, nil
+}
+
+// plateMatch is how a read plate matched the registered plates.
+type plateMatch int
+
+const (
+	plateMatchNone plateMatch = iota
+	plateMatchExact
+	plateMatchFuzzy
+	// plateMatchTooMany means there were more fuzzy candidates than could be compared
+	plateMatchTooMany
+)
+
+// matchLicensePlate returns the registered plates matching a normalized plate and how they match.
+// Exact matches are looked up by their key and win; fuzzy matches are only used when there is no
+// exact one.
+func (s *accessDecisionServiceImpl) matchLicensePlate(normalizedPlate string) ([]model.PersonLicensePlate, plateMatch, error) {
+	exactMatches, err := s.personLicenseRepo.GetByNormalizedPlateText(normalizedPlate)
+	if err != nil {
+		return nil, plateMatchNone, err
+	}
+	if len(exactMatches) > 0 {
+		return exactMatches, plateMatchExact, nil
+	}
+
+	candidates, complete, err := s.personLicenseRepo.GetFuzzyCandidates(normalizedPlate)
+	if err != nil {
+		return nil, plateMatchNone, err
+	}
+	if !complete {
+		return nil, plateMatchTooMany, nil
+	}
+	var fuzzyMatches []model.PersonLicensePlate
+	for _, plate := range candidates {
+		if common.IsFuzzyLicensePlateMatch(plate.NormalizedPlateText, normalizedPlate) {
+			fuzzyMatches = append(fuzzyMatches, plate)
+		}
+	}
+	if len(fuzzyMatches) == 0 {
+		return nil, plateMatchNone, nil
+	}
+	return fuzzyMatches, plateMatchFuzzy, nil
+}
+
+// trackVisitorVehicle creates or refreshes the visitor vehicle record of an unregistered plate.
+func (s *accessDecisionServiceImpl) trackVisitorVehicle(plateText string, normalizedPlate string, deviceID string, seenAt time.Time, imagePath *string) (*model.VisitorVehicle, error) {
+	vehicle, err := s.visitorVehicleRepo.GetByNormalizedPlateText(normalizedPlate)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, fmt.Errorf("failed to get visitor vehicle: %w", err)
+	}
+
+	if vehicle == nil {
+		vehicle = &model.VisitorVehicle{
+			PlateText:             plateText,
+			NormalizedPlateText:   normalizedPlate,
+			FirstSeenAt:           seenAt,
+			LastSeenAt:            seenAt,
+			SeenCount:             1,
+			AccessControlDeviceID: &deviceID,
+			LastImagePath:         imagePath,
+		}
+		if err := s.visitorVehicleRepo.Create(vehicle); err != nil {
+			return nil, fmt.Errorf("failed to create visitor vehicle: %w", err)
+		}
+		return vehicle, nil
+	}
+
+	vehicle.PlateText = plateText
+	vehicle.LastSeenAt = seenAt
+	vehicle.SeenCount++
+	vehicle.AccessControlDeviceID = &deviceID
+	if imagePath != nil {
+		vehicle.LastImagePath = imagePath
+	}
+	if err := s.visitorVehicleRepo.Update(vehicle); err != nil {
+		return nil, fmt.Errorf("failed to update visitor vehicle: %w", err)
+	}
+	return vehicle, nil
+}
+
+// savePlateImage stores the camera snapshot in a folder per day.
+func (s *accessDecisionServiceImpl) savePlateImage(plateImageFile *multipart.FileHeader, accessTime time.Time) (string, error) {
+	src, err := plateImageFile.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open plate image: %w", err)
+	}
+	defer src.Close()
+
+	header := make([]byte, 512)
+	n, _ := src.Read(header)
+	if !strings.HasPrefix(http.DetectContentType(header[:n]), "image/") {
+		return "", common.NewValidationError("plate image must be an image file")
+	}
+
+	savedPath, err := s.fileRepo.Save(plateImageFile, path.Join(common.LicensePlateImagePath, accessTime.Format("2006-01-02")))
+	if err != nil {
+		return "", fmt.Errorf("failed to save plate image: %w", err)
+	}
+	return savedPath, nil
+}
+
+// uniquePlateOwners returns the distinct people owning the given plates.
+func uniquePlateOwners(plates []model.PersonLicensePlate) []string {
+	var personIDs []string
+	for _, plate := range plates {
+		if !slices.Contains(personIDs, plate.PersonID) {
+			personIDs = append(personIDs, plate.PersonID)
+		}
+	}
+	return personIDs
+}
+
+// getAllowedGroups checks the validity of the person and returns the groups of their access rule
+// that contain the device and whose schedule covers the given time. The reason is granted when
+// at least one group is returned, otherwise it tells why none was.
+func (s *accessDecisionServiceImpl) getAllowedGroups(person *model.Person, deviceID string, accessTime time.Time) ([]model.AccessControlGroup, string, error) {
+	// Person validity is stored as dates, both ends are inclusive
+	accessDate := accessTime.Format("2006-01-02")
+	if person.ActiveAt != nil && accessDate < person.ActiveAt.Format("2006-01-02") {
+		return nil, common.AccessReasonPersonNotYetValid, nil
+	}
+	if person.ExpireAt != nil && accessDate > person.ExpireAt.Format("2006-01-02") {
+		return nil, common.AccessReasonPersonExpired, nil
+	}
+
+	if person.AccessControlRuleID == nil || *person.AccessControlRuleID == "" {
+		return nil, common.AccessReasonNoAccessRule, nil
+	}
+	ruleUUID, err := uuid.Parse(*person.AccessControlRuleID)
+	if err != nil {
+		return nil, common.AccessReasonNoAccessRule, nil
+	}
+	groupIDs, err := s.ruleRepo.GetGroupIDsByRuleID(ruleUUID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get access control rule groups: %w", err)
+	}
+
+	var allowedGroups []model.AccessControlGroup
+	reason := common.AccessReasonDeviceNotAllowed
+	for _, groupID := range groupIDs {
+		groupUUID, err := uuid.Parse(groupID)
+		if err != nil {
+			continue
+		}
+		deviceIDs, err := s.groupRepo.GetMemberDeviceIDsByGroupID(groupUUID)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get access control group devices: %w", err)
+		}
+		if !slices.Contains(deviceIDs, deviceID) {
+			continue
+		}
+
+		group, err := s.groupRepo.GetByID(groupUUID)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				continue
+			}
+			return nil, "", fmt.Errorf("failed to get access control group: %w", err)
+		}
+
+		// The device is in this group, so from now on only the schedule can refuse access.
+		// On a holiday of the group's calendar the holiday replaces the schedule.
+		holiday, err := findHoliday(s.holidayCalendarRepo, group.HolidayCalendarID, accessDate)
+		if err != nil {
+			return nil, "", err
+		}
+		if holiday != nil {
+			if reason != common.AccessReasonOutsideSchedule {
+				reason = common.AccessReasonHoliday
+			}
+			if !hasSpecialHours(holiday) || !isWithinClock(*holiday.StartTime, *holiday.EndTime, accessTime) {
+				continue
+			}
+		} else {
+			reason = common.AccessReasonOutsideSchedule
+			schedules, err := s.groupRepo.GetAccessControlGroupScheduleByGroupID(groupID)
+			if err != nil {
+				return nil, "", fmt.Errorf("failed to get access control group schedules: %w", err)
+			}
+			if !slices.ContainsFunc(schedules, func(schedule model.AccessControlGroupSchedule) bool {
+				return isWithinGroupSchedule(schedule, accessTime)
+			}) {
+				continue
+			}
+		}
+		allowedGroups = append(allowedGroups, *group)
+	}
+	if len(allowedGroups) > 0 {
+		return allowedGroups, common.AccessReasonGranted, nil
+	}
+	return nil, reason, nil
+}
+
+// checkLicensePlateAccess checks the access of a plate owner. A plate read is a single factor
+// without a person at the gate, so only groups in the "any" mode open for it.
+func (s *accessDecisionServiceImpl) checkLicensePlateAccess(person *model.Person, deviceID string, accessTime time.Time) (string, error) {
+	groups, reason, err := s.getAllowedGroups(person, deviceID, accessTime)
+	if err != nil || reason != common.AccessReasonGranted {
+		return reason, err
+	}
+	for _, group := range groups {
+		if group.AuthMode == common.AuthModeAny || group.AuthMode == "" {
+			return common.AccessReasonGranted, nil
+		}
+	}
+	return common.AccessReasonAuthModeNotSupported, nil
+}
+
+// getOpenScanSession loads the scan session a credential continues. No session ID means a new scan.
+func (s *accessDecisionServiceImpl) getOpenScanSession(sessionID *string, deviceID string, accessTime time.Time) (*model.AccessScanSession, error) {
+	if sessionID == nil || *sessionID == "" {
+		return nil, nil
+	}
+	sessionUUID, err := uuid.Parse(*sessionID)
+	if err != nil {
+		return nil, common.NewValidationError("invalid scan session ID")
+	}
+	session, err := s.scanSessionRepo.GetByID(sessionUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("scan session with ID '%s' not found", *sessionID)
+		}
+		return nil, fmt.Errorf("failed to get scan session: %w", err)
+	}
+	if session.AccessControlDeviceID != deviceID {
+		return nil, common.NewConflictError("scan session belongs to another access control device")
+	}
+	if session.Status != common.AccessScanSessionStatusOpen {
+		return nil, common.NewConflictError("scan session is already closed")
+	}
+	if accessTime.After(session.ExpiresAt) {
+		return nil, common.NewConflictError("scan session has expired")
+	}
+	return session, nil
+}
+
+// identifyPerson resolves the person behind the presented credentials, together with the factors
+// presented so far in the session. Every credential must belong to the same person, and a PIN
+// alone identifies nobody.
+func (s *accessDecisionServiceImpl) identifyPerson(session *model.AccessScanSession, cardNumber string, facePersonID string, pin string, accessTime time.Time) (*model.Person, []string, string, error) {
+	var person *model.Person
+	factors := []string{}
+	if session != nil && session.PersonID != nil {
+		sessionPerson, err := s.getPerson(*session.PersonID)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		if sessionPerson == nil {
+			return nil, factors, common.AccessReasonUnknownCredential, nil
+		}
+		person = sessionPerson
+		factors = common.SplitAccessFactors(session.Factors)
+	}
+
+	if cardNumber != "" {
+		card, err := s.personCardRepo.GetByCardNumber(cardNumber)
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return nil, nil, "", fmt.Errorf("failed to get person card: %w", err)
+		}
+		if card == nil {
+			return person, factors, common.AccessReasonUnknownCredential, nil
+		}
+		cardPerson, err := s.getPerson(card.PersonID)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		if cardPerson == nil {
+			return person, factors, common.AccessReasonUnknownCredential, nil
+		}
+		if person != nil && person.ID != cardPerson.ID {
+			return person, factors, common.AccessReasonCredentialMismatch, nil
+		}
+		person = cardPerson
+		if reason := checkPersonCard(card, accessTime); reason != common.AccessReasonGranted {
+			return person, factors, reason, nil
+		}
+		factors = appendAccessFactor(factors, common.AccessFactorCard)
+	}
+
+	if facePersonID != "" {
+		facePerson, err := s.getPerson(facePersonID)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		if facePerson == nil {
+			return person, factors, common.AccessReasonUnknownCredential, nil
+		}
+		if person != nil && person.ID != facePerson.ID {
+			return person, factors, common.AccessReasonCredentialMismatch, nil
+		}
+		person = facePerson
+		factors = appendAccessFactor(factors, common.AccessFactorFace)
+	}
+
+	if pin != "" {
+		if person == nil {
+			return nil, factors, common.AccessReasonUnknownCredential, nil
+		}
+		reason, err := s.checkPIN(person, pin)
+		if err != nil || reason != common.AccessReasonGranted {
+			return person, factors, reason, err
+		}
+		factors = appendAccessFactor(factors, common.AccessFactorPIN)
+	}
+	return person, factors, common.AccessReasonGranted, nil
+}
+
+// checkPIN checks the PIN entered by a person. After common.PINMaxFailedAttempts wrong PINs in a
+// row the PIN is locked for common.PINLockoutMinutes, so a stolen card cannot be tried with every
+// PIN. The lockout runs on the server clock, not on the access time the device reports.
+func (s *accessDecisionServiceImpl) checkPIN(person *model.Person, pin string) (string, error) {
+	now := time.Now()
+	if person.PINLockedUntil != nil && now.Before(*person.PINLockedUntil) {
+		return common.AccessReasonPINLocked, nil
+	}
+	if person.PINHash == nil || !common.VerifyHashPassword(*person.PINHash, pin) {
+		lockedUntil := now.Add(common.PINLockoutMinutes * time.Minute)
+		if err := s.personRepo.RecordFailedPIN(person.ID.String(), common.PINMaxFailedAttempts, lockedUntil); err != nil {
+			return "", fmt.Errorf("failed to record wrong PIN: %w", err)
+		}
+		return common.AccessReasonInvalidPIN, nil
+	}
+	if person.PINFailedAttempts > 0 || person.PINLockedUntil != nil {
+		if err := s.personRepo.ResetFailedPINs(person.ID.String()); err != nil {
+			return "", fmt.Errorf("failed to reset wrong PINs: %w", err)
+		}
+	}
+	return common.AccessReasonGranted, nil
+}
+
+// saveScanSession moves the session on after a decision. Pending scans open a session when there
+// is none yet; final decisions close the session they belong to.
+func (s *accessDecisionServiceImpl) saveScanSession(session *model.AccessScanSession, deviceID string, accessType string, person *model.Person, factors []string, reason string, twoPersonGroup *model.AccessControlGroup, accessTime time.Time) (*model.AccessScanSession, error) {
+	if session == nil {
+		if !isPendingAccessReason(reason) {
+			return nil, nil
+		}
+		session = &model.AccessScanSession{
+			AccessControlDeviceID: deviceID,
+			Type:                  accessType,
+			Status:                common.AccessScanSessionStatusOpen,
+		}
+	}
+
+	switch reason {
+	case common.AccessReasonFactorRequired:
+		personID := person.ID.String()
+		session.PersonID = &personID
+		session.Factors = common.JoinAccessFactors(factors)
+		session.ExpiresAt = accessTime.Add(common.AccessScanSessionTimeoutSeconds * time.Second)
+	case common.AccessReasonSecondPerson:
+		// The first person is done, the session now waits for somebody else
+		personID := person.ID.String()
+		session.FirstPersonID = &personID
+		session.FirstPersonAt = &accessTime
+		session.PersonID = nil
+		session.Factors = ""
+		session.ExpiresAt = accessTime.Add(twoPersonWindow(twoPersonGroup))
+	case common.AccessReasonGranted:
+		session.Status = common.AccessScanSessionStatusGranted
+	default:
+		session.Status = common.AccessScanSessionStatusDenied
+	}
+
+	if session.ID == uuid.Nil {
+		if err := s.scanSessionRepo.Create(session); err != nil {
+			return nil, fmt.Errorf("failed to create scan session: %w", err)
+		}
+		return session, nil
+	}
+	if err := s.scanSessionRepo.Update(session); err != nil {
+		return nil, fmt.Errorf("failed to update scan session: %w", err)
+	}
+	return session, nil
+}
+
+// getPerson loads a person by a string ID. A malformed or unknown ID gives nil without error.
+func (s *accessDecisionServiceImpl) getPerson(id string) (*model.Person, error) {
+	personUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, nil
+	}
+	person, err := s.personRepo.GetByID(personUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get person: %w", err)
+	}
+	return person, nil
+}
+
+// applyAuthModes decides between the allowed groups of a person. Any group whose mode is satisfied
+// by the factors grants access. A two-person group grants when a different person already scanned
+// within its window, otherwise it waits for the second person. The two-person group in use is
+// returned so the caller can size the session window.
+func applyAuthModes(groups []model.AccessControlGroup, session *model.AccessScanSession, person *model.Person, factors []string, accessTime time.Time) (string, []string, *model.AccessControlGroup) {
+	var twoPersonGroup *model.AccessControlGroup
+	for i, group := range groups {
+		if group.AuthMode == common.AuthModeTwoPerson {
+			if twoPersonGroup == nil {
+				twoPersonGroup = &groups[i]
+			}
+			continue
+		}
+		if common.IsAuthModeSatisfied(group.AuthMode, factors) {
+			return common.AccessReasonGranted, factors, nil
+		}
+	}
+	if twoPersonGroup == nil {
+		return common.AccessReasonFactorRequired, factors, nil
+	}
+
+	if session != nil && session.FirstPersonID != nil && session.FirstPersonAt != nil &&
+		*session.FirstPersonID != person.ID.String() &&
+		!accessTime.After(session.FirstPersonAt.Add(twoPersonWindow(twoPersonGroup))) {
+		return common.AccessReasonGranted, appendAccessFactor(factors, common.AccessFactorTwoPerson), twoPersonGroup
+	}
+	return common.AccessReasonSecondPerson, factors, twoPersonGroup
+}
+
+// twoPersonWindow is how long a two-person group waits for the second person.
+func twoPersonWindow(group *model.AccessControlGroup) time.Duration {
+	seconds := common.DefaultTwoPersonWindowSeconds
+	if group != nil && group.TwoPersonWindowSeconds > 0 {
+		seconds = group.TwoPersonWindowSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// isPendingAccessReason reports whether a scan waits for another credential.
+func isPendingAccessReason(reason string) bool {
+	return reason == common.AccessReasonFactorRequired || reason == common.AccessReasonSecondPerson
+}
+
+// appendAccessFactor adds a factor once.
+func appendAccessFactor(factors []string, factor string) []string {
+	if slices.Contains(factors, factor) {
+		return factors
+	}
+	return append(factors, factor)
+}
+
+// checkPersonCard checks the status and the validity window of a card. A zero time is a date that
+// is not set, as stored for cards created before the dates became optional.
+func checkPersonCard(card *model.PersonCard, accessTime time.Time) string {
+	switch card.Status {
+	case common.PersonCardStatusLost:
+		return common.AccessReasonCardLost
+	case common.PersonCardStatusSuspended:
+		return common.AccessReasonCardSuspended
+	case common.PersonCardStatusRevoked:
+		return common.AccessReasonCardRevoked
+	}
+	if card.ActiveAt != nil && !card.ActiveAt.IsZero() && accessTime.Before(*card.ActiveAt) {
+		return common.AccessReasonCardNotYetValid
+	}
+	if card.ExpireAt != nil && !card.ExpireAt.IsZero() && accessTime.After(*card.ExpireAt) {
+		return common.AccessReasonCardExpired
+	}
+	return common.AccessReasonGranted
+}
+
+// isWithinGroupSchedule reports whether accessTime falls in a group schedule.
+// A schedule with a date only applies on that date, otherwise on its day of week (1 = Monday ... 7 = Sunday).
+func isWithinGroupSchedule(schedule model.AccessControlGroupSchedule, accessTime time.Time) bool {
+	if schedule.Date != nil && *schedule.Date != "" {
+		if *schedule.Date != accessTime.Format("2006-01-02") {
+			return false
+		}
+	} else {
+		dayOfWeek := int(accessTime.Weekday())
+		if dayOfWeek == 0 {
+			dayOfWeek = 7
+		}
+		if schedule.DayOfWeek != dayOfWeek {
+			return false
+		}
+	}
+
+	return isWithinClock(schedule.StartTime, schedule.EndTime, accessTime)
+}
+
+// isWithinClock reports whether the time of day of accessTime is between two clock strings, both inclusive.
+func isWithinClock(startTime string, endTime string, accessTime time.Time) bool {
+	clock := accessTime.Format("15:04:05")
+	return clock >= normalizeClock(startTime) && clock <= normalizeClock(endTime)
+}
+
+// normalizeClock turns "HH:MM" into "HH:MM:SS" so clock strings compare correctly.
+func normalizeClock(clock string) string {
+	if len(clock) == len("15:04") {
+		return clock + ":00"
+	}
+	return clock
+}
+
+// convertPersonToInfoResponse converts a person model to the short person schema.
+func convertPersonToInfoResponse(person *model.Person) *schema.PersonInfoResponse {
+	return &schema.PersonInfoResponse{
+		ID:         person.ID.String(),
+		Name:       strings.TrimSpace(person.FirstName + " " + person.LastName),
+		PersonType: person.PersonType,
+		PersonID:   stringValue(person.PersonID),
+	}
+}
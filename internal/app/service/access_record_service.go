@@ -2,6 +2,7 @@ package service
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/putteror/access-control-management/internal/app/common"
@@ -11,13 +12,16 @@ import (
 	"gorm.io/gorm"
 )
 
+// AccessRecordService records access events. Access records are append-only, corrections are
+// added as annotations.
 type AccessRecordService interface {
 	GetAll(searchQuery schema.AccessRecordSearchQuery) ([]model.AccessRecord, error)
 	GetByID(id string) (*model.AccessRecord, error)
 	Create(bodyRequest *schema.AccessRecordRequest) (*model.AccessRecord, error)
-	Update(id string, bodyRequest *schema.AccessRecordRequest) (*model.AccessRecord, error)
-	PartialUpdate(id string, bodyRequest *schema.AccessRecordRequest) (*model.AccessRecord, error)
-	Delete(id string) error
+	Annotate(id string, bodyRequest *schema.AccessRecordAnnotationRequest, username string) (*model.AccessRecordAnnotation, error)
+	GetAnnotations(id string) ([]model.AccessRecordAnnotation, error)
+	VerifyChain() (*schema.AccessRecordChainResponse, error)
+	ConvertAnnotationToResponse(annotation *model.AccessRecordAnnotation) schema.AccessRecordAnnotationResponse
 	ConvertToResponse(accessRecordModel *model.AccessRecord) (*schema.AccessRecordResponse, error)
 }
 
@@ -25,13 +29,17 @@ type AccessRecordServiceImpl struct {
 	accessRecordRepo repository.AccessRecordRepository
 	personRepo       repository.PersonRepository
 	deviceRepo       repository.AccessControlDeviceRepository
+	locationRepo     repository.LocationRepository
+	db               *gorm.DB
 }
 
-func NewAccessRecordService(accessRecordRepo repository.AccessRecordRepository, personRepo repository.PersonRepository, deviceRepo repository.AccessControlDeviceRepository) AccessRecordService {
+func NewAccessRecordService(accessRecordRepo repository.AccessRecordRepository, personRepo repository.PersonRepository, deviceRepo repository.AccessControlDeviceRepository, locationRepo repository.LocationRepository, db *gorm.DB) AccessRecordService {
 	return &AccessRecordServiceImpl{
 		accessRecordRepo: accessRecordRepo,
 		personRepo:       personRepo,
 		deviceRepo:       deviceRepo,
+		locationRepo:     locationRepo,
+		db:               db,
 	}
 }
 
@@ -40,23 +48,33 @@ func (s *AccessRecordServiceImpl) GetAll(searchQuery schema.AccessRecordSearchQu
 }
 
 func (s *AccessRecordServiceImpl) GetByID(id string) (*model.AccessRecord, error) {
-	idUUID, err := uuid.Parse(id)
-	if err != nil {
-		return nil, fmt.Errorf("invalid ID")
-	}
-	return s.accessRecordRepo.GetByID(idUUID)
+	return s.getAccessRecord(id)
 }
 
+// Create records an access. The type, the result and the access time are checked by the
+// validate tags of the request.
 func (s *AccessRecordServiceImpl) Create(bodyRequest *schema.AccessRecordRequest) (*model.AccessRecord, error) {
-
-	bodyRequest, err := s.validateAndSetDefaultValues(bodyRequest)
-	if err != nil {
-		return nil, err
+	// Access times without an offset are in the local time of the device
+	loc := time.Local
+	if bodyRequest.AccessControlDeviceID != nil && *bodyRequest.AccessControlDeviceID != "" {
+		device_uuid, err := uuid.Parse(*bodyRequest.AccessControlDeviceID)
+		if err != nil {
+			return nil, common.NewValidationError("invalid access control device ID")
+		}
+		deviceModel, err := s.deviceRepo.GetByID(device_uuid)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, common.NewNotFoundError("access control device with ID '%s' not found", *bodyRequest.AccessControlDeviceID)
+			}
+			return nil, fmt.Errorf("failed to get access control device: %w", err)
+		}
+		if loc, err = deviceTimeZone(s.locationRepo, deviceModel); err != nil {
+			return nil, err
+		}
 	}
-
-	access_time, err := common.ConvertTimeStrToTime(*bodyRequest.AccessTime)
+	access_time, err := common.ParseTimestamp(*bodyRequest.AccessTime, loc)
 	if err != nil {
-		return nil, err
+		return nil, common.NewValidationError("invalid access time format, expected an RFC 3339 timestamp")
 	}
 
 	// convert Time
@@ -67,114 +85,179 @@ func (s *AccessRecordServiceImpl) Create(bodyRequest *schema.AccessRecordRequest
 		Result:                *bodyRequest.Result,
 		AccessTime:            access_time,
 	}
-	err = s.accessRecordRepo.Create(accessRecordModel)
+	err = s.accessRecordRepo.Append(accessRecordModel)
 	if err != nil {
 		return nil, err
 	}
 	return accessRecordModel, nil
 }
 
-func (s *AccessRecordServiceImpl) Update(id string, bodyRequest *schema.AccessRecordRequest) (*model.AccessRecord, error) {
-
-	id_uuid, err := uuid.Parse(id)
-	if err != nil {
-		return nil, fmt.Errorf("invalid ID")
-	}
-	accessRecordModel, err := s.accessRecordRepo.GetByID(id_uuid)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get existing access record: %w", err)
-	}
-	if accessRecordModel == nil {
-		return nil, fmt.Errorf("access record with ID '%s' not found", id)
-	}
-
-	// Set default value
-	bodyRequest, err = s.validateAndSetDefaultValues(bodyRequest)
+// Annotate adds an annotation to an access record. The record itself never changes.
+func (s *AccessRecordServiceImpl) Annotate(id string, bodyRequest *schema.AccessRecordAnnotationRequest, username string) (*model.AccessRecordAnnotation, error) {
+	accessRecordModel, err := s.getAccessRecord(id)
 	if err != nil {
 		return nil, err
 	}
-	// Validate
-	if err := s.validateBodyRequest(*bodyRequest, accessRecordModel); err != nil {
-		return nil, err
+	if *bodyRequest.Note == "" {
+		return nil, common.NewFieldError("note", "note cannot be empty")
 	}
-	access_time, err := common.ConvertTimeStrToTime(*bodyRequest.AccessTime)
-	if err != nil {
+	if err := s.validateAnnotation(bodyRequest); err != nil {
 		return nil, err
 	}
 
-	// Update model
-	accessRecordModel.AccessControlDeviceID = bodyRequest.AccessControlDeviceID
-	accessRecordModel.PersonID = bodyRequest.PersonID
-	accessRecordModel.Type = *bodyRequest.Type
-	accessRecordModel.Result = *bodyRequest.Result
-	accessRecordModel.AccessTime = access_time
-
-	err = s.accessRecordRepo.Update(accessRecordModel)
-	if err != nil {
-		return nil, err
+	annotation := &model.AccessRecordAnnotation{
+		AccessRecordID: accessRecordModel.ID.String(),
+		Field:          emptyToNil(bodyRequest.Field),
+		Value:          emptyToNil(bodyRequest.Value),
+		Note:           *bodyRequest.Note,
+		CreatedBy:      username,
 	}
-
-	return accessRecordModel, nil
+	if err := s.accessRecordRepo.CreateAnnotation(annotation); err != nil {
+		return nil, fmt.Errorf("failed to create access record annotation: %w", err)
+	}
+	return annotation, nil
 }
 
-func (s *AccessRecordServiceImpl) PartialUpdate(id string, bodyRequest *schema.AccessRecordRequest) (*model.AccessRecord, error) {
-	id_uuid, err := uuid.Parse(id)
+// GetAnnotations retrieves the annotations of an access record.
+func (s *AccessRecordServiceImpl) GetAnnotations(id string) ([]model.AccessRecordAnnotation, error) {
+	accessRecordModel, err := s.getAccessRecord(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid ID")
+		return nil, err
 	}
-	accessRecordModel, err := s.accessRecordRepo.GetByID(id_uuid)
+	annotations, err := s.accessRecordRepo.GetAnnotationsByRecordID(accessRecordModel.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get existing access record: %w", err)
+		return nil, fmt.Errorf("failed to get access record annotations: %w", err)
 	}
-	if accessRecordModel == nil {
-		return nil, fmt.Errorf("access record with ID '%s' not found", id)
+	return annotations, nil
+}
+
+// VerifyChain walks the hash chain from the retention checkpoint and reports missing or repeated sequences, records whose
+// previous hash does not match, records whose fields no longer match their hash, deleted records
+// and records added outside the chain.
+//
+// In a tenant the chain of every tenant is shared, so only the records of the tenant are walked.
+// Each is checked against its own hash and linked to the hash of the record before it in the
+// chain, whichever tenant that record belongs to; a missing record before it is reported as a gap.
+func (s *AccessRecordServiceImpl) VerifyChain() (*schema.AccessRecordChainResponse, error) {
+	_, allTenants, ok := common.TenantFromContext(s.db.Statement.Context)
+	tenantScope := ok && !allTenants
+
+	response := &schema.AccessRecordChainResponse{Issues: []schema.AccessRecordChainIssue{}}
+	addIssue := func(record *model.AccessRecord, problem string, detail string) {
+		response.IssueCount++
+		if len(response.Issues) < common.AccessRecordVerifyMaxIssues {
+			issue := schema.AccessRecordChainIssue{Problem: problem, Detail: detail}
+			if record != nil {
+				issue.Sequence = record.Sequence
+				issue.AccessRecordID = record.ID.String()
+			}
+			response.Issues = append(response.Issues, issue)
+		}
 	}
 
-	if err := s.validateBodyRequest(*bodyRequest, accessRecordModel); err != nil {
+	// Records purged by the retention job are replaced by a checkpoint the chain continues from
+	prevHash := ""
+	expected := int64(1)
+	checkpoint, err := s.accessRecordRepo.GetChainCheckpoint()
+	if err != nil {
 		return nil, err
 	}
-	if bodyRequest.AccessControlDeviceID != nil {
-		accessRecordModel.AccessControlDeviceID = bodyRequest.AccessControlDeviceID
-	}
-	if bodyRequest.PersonID != nil {
-		accessRecordModel.PersonID = bodyRequest.PersonID
-	}
-	if bodyRequest.Type != nil {
-		accessRecordModel.Type = *bodyRequest.Type
-	}
-	if bodyRequest.Result != nil {
-		accessRecordModel.Result = *bodyRequest.Result
+	if checkpoint != nil {
+		prevHash = checkpoint.Hash
+		expected = checkpoint.Sequence + 1
+		response.PurgedThroughSequence = checkpoint.Sequence
 	}
-	if bodyRequest.AccessTime != nil {
-		access_time, err := common.ConvertTimeStrToTime(*bodyRequest.AccessTime)
+	for {
+		records, err := s.accessRecordRepo.GetChain(expected-1, common.AccessRecordVerifyBatchSize)
 		if err != nil {
 			return nil, err
 		}
-		accessRecordModel.AccessTime = access_time
+		if len(records) == 0 {
+			break
+		}
+		var linkedHashes map[int64]string
+		if tenantScope {
+			if linkedHashes, err = s.getLinkedHashes(records, checkpoint); err != nil {
+				return nil, err
+			}
+		}
+		for i := range records {
+			record := &records[i]
+			switch {
+			case record.Sequence < expected:
+				addIssue(record, common.AccessRecordChainDuplicate, fmt.Sprintf("sequence %d appears more than once", record.Sequence))
+			case tenantScope:
+				if linkedHash, found := linkedHashes[record.Sequence-1]; !found {
+					addIssue(record, common.AccessRecordChainGap, fmt.Sprintf("sequence %d is missing", record.Sequence-1))
+				} else if record.PrevHash != linkedHash {
+					addIssue(record, common.AccessRecordChainBrokenLink, "previous hash does not match the record before it")
+				}
+			case record.Sequence > expected:
+				addIssue(record, common.AccessRecordChainGap, fmt.Sprintf("sequences %d to %d are missing", expected, record.Sequence-1))
+			case record.PrevHash != prevHash:
+				addIssue(record, common.AccessRecordChainBrokenLink, "previous hash does not match the record before it")
+			}
+			// Hashes of older versions are not keyed, anyone could have computed them
+			if record.ChainVersion != common.AccessRecordChainVersion {
+				addIssue(record, common.AccessRecordChainTampered, fmt.Sprintf("record is sealed with chain version %d instead of %d", record.ChainVersion, common.AccessRecordChainVersion))
+			} else if common.ChainHash(record.PrevHash, record.HashPayload()) != record.Hash {
+				addIssue(record, common.AccessRecordChainTampered, "record fields do not match its hash")
+			}
+			if record.DeletedAt.Valid {
+				addIssue(record, common.AccessRecordChainDeleted, "record is marked as deleted")
+			}
+			if record.Sequence < expected {
+				continue
+			}
+
+			response.Records++
+			response.LastSequence = record.Sequence
+			response.LastHash = record.Hash
+			prevHash = record.Hash
+			expected = record.Sequence + 1
+		}
+		if len(records) < common.AccessRecordVerifyBatchSize {
+			break
+		}
 	}
 
-	err = s.accessRecordRepo.Update(accessRecordModel)
+	unchained, err := s.accessRecordRepo.CountUnchained()
 	if err != nil {
 		return nil, err
 	}
+	response.UnchainedRecords = unchained
+	if unchained > 0 {
+		addIssue(nil, common.AccessRecordChainUnchained, fmt.Sprintf("%d records were added outside the chain", unchained))
+	}
 
-	return accessRecordModel, nil
-
+	response.Valid = response.IssueCount == 0
+	response.VerifiedAt = common.FormatTimestamp(time.Now(), nil)
+	return response, nil
 }
 
-func (s *AccessRecordServiceImpl) Delete(id string) error {
-	id_uuid, err := uuid.Parse(id)
-	if err != nil {
-		return fmt.Errorf("invalid ID")
+// getLinkedHashes returns the hashes of the records before the given records in the chain, by
+// sequence. The record before the first one is the retention checkpoint or, without one, none.
+func (s *AccessRecordServiceImpl) getLinkedHashes(records []model.AccessRecord, checkpoint *model.AccessRecordChainCheckpoint) (map[int64]string, error) {
+	start := int64(0)
+	if checkpoint != nil {
+		start = checkpoint.Sequence
+	}
+	sequences := make([]int64, 0, len(records))
+	for _, record := range records {
+		if record.Sequence-1 > start {
+			sequences = append(sequences, record.Sequence-1)
+		}
 	}
-	_, err = s.accessRecordRepo.GetByID(id_uuid)
+	hashes, err := s.accessRecordRepo.GetChainHashes(sequences)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return fmt.Errorf("access record with ID '%s' not found", id)
-		}
-		return fmt.Errorf("failed to get access record by ID: %w", err)
+		return nil, err
 	}
-	return s.accessRecordRepo.Delete(id_uuid)
+	if checkpoint != nil {
+		hashes[checkpoint.Sequence] = checkpoint.Hash
+	} else {
+		hashes[0] = ""
+	}
+	return hashes, nil
 }
 
 // ConvertToResponse converts a group model to a response schema.
@@ -182,6 +265,8 @@ func (s *AccessRecordServiceImpl) ConvertToResponse(accessRecordModel *model.Acc
 
 	var personResponse *schema.AccessRecordPersonResponse
 	var deviceResponse *schema.AccessRecordDeviceResponse
+	// The access time is shown in the local time of the device
+	loc := time.Local
 
 	if accessRecordModel.PersonID != nil && *accessRecordModel.PersonID != "" {
 
@@ -220,6 +305,9 @@ func (s *AccessRecordServiceImpl) ConvertToResponse(accessRecordModel *model.Acc
 			HostAddress: deviceModel.HostAddress,
 			Type:        deviceModel.Type,
 		}
+		if loc, err = deviceTimeZone(s.locationRepo, deviceModel); err != nil {
+			return nil, err
+		}
 	}
 
 	response := &schema.AccessRecordResponse{
@@ -228,44 +316,82 @@ func (s *AccessRecordServiceImpl) ConvertToResponse(accessRecordModel *model.Acc
 		Person:              personResponse,
 		Type:                accessRecordModel.Type,
 		Result:              accessRecordModel.Result,
-		AccessTime:          accessRecordModel.AccessTime.Format("2006-01-02 15:04:05"),
+		AccessTime:          common.FormatTimestamp(accessRecordModel.AccessTime, loc),
+		CardNumber:          accessRecordModel.CardNumber,
+		PlateText:           accessRecordModel.PlateText,
+		PlateConfidence:     accessRecordModel.PlateConfidence,
+		PlateImageURL:       common.GetImageURL(common.FileURLPrefix, stringValue(accessRecordModel.PlateImagePath)),
+		Reason:              accessRecordModel.Reason,
+		Factors:             common.SplitAccessFactors(stringValue(accessRecordModel.Factors)),
+		ScanSessionID:       accessRecordModel.ScanSessionID,
+		Sequence:            accessRecordModel.Sequence,
+		Hash:                accessRecordModel.Hash,
+	}
+
+	annotations, err := s.accessRecordRepo.GetAnnotationsByRecordID(accessRecordModel.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access record annotations: %w", err)
+	}
+	response.Annotations = make([]schema.AccessRecordAnnotationResponse, len(annotations))
+	for i, annotation := range annotations {
+		response.Annotations[i] = s.ConvertAnnotationToResponse(&annotation)
 	}
 	return response, nil
 }
 
+// ConvertAnnotationToResponse converts an access record annotation to a response schema.
+func (s *AccessRecordServiceImpl) ConvertAnnotationToResponse(annotation *model.AccessRecordAnnotation) schema.AccessRecordAnnotationResponse {
+	return schema.AccessRecordAnnotationResponse{
+		ID:        annotation.ID.String(),
+		Field:     annotation.Field,
+		Value:     annotation.Value,
+		Note:      annotation.Note,
+		CreatedBy: annotation.CreatedBy,
+		CreatedAt: common.FormatTimestamp(annotation.CreatedAt, nil),
+	}
+}
+
 // ----------> INNER FUNCTION <-----------------------//
 
 // Validate for pass whole requestBody to model
-func (s *AccessRecordServiceImpl) validateAndSetDefaultValues(bodyRequest *schema.AccessRecordRequest) (*schema.AccessRecordRequest, error) {
-
-	if bodyRequest.Type == nil || *bodyRequest.Type == "" {
-		return nil, fmt.Errorf("type cannot be empty")
-	}
-
-	if bodyRequest.Result == nil || *bodyRequest.Result == "" {
-		return nil, fmt.Errorf("result cannot be empty")
+// getAccessRecord loads an access record by its ID.
+func (s *AccessRecordServiceImpl) getAccessRecord(id string) (*model.AccessRecord, error) {
+	idUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, common.NewFieldError("id", "invalid ID")
 	}
-
-	if bodyRequest.AccessTime == nil || *bodyRequest.AccessTime == "" {
-		return nil, fmt.Errorf("access time cannot be empty")
+	accessRecordModel, err := s.accessRecordRepo.GetByID(idUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("access record with ID '%s' not found", id)
+		}
+		return nil, fmt.Errorf("failed to get access record by ID: %w", err)
 	}
-
-	return bodyRequest, nil
+	return accessRecordModel, nil
 }
 
-func (s *AccessRecordServiceImpl) validateBodyRequest(bodyRequest schema.AccessRecordRequest, accessRecordModel *model.AccessRecord) error {
-
-	if bodyRequest.Type != nil && *bodyRequest.Type != "" {
-		if !common.ValidateAccessRecordType(*bodyRequest.Type) {
-			return fmt.Errorf("type must be 'in' or 'out'")
+// validateAnnotation checks the corrected field and value of an annotation.
+func (s *AccessRecordServiceImpl) validateAnnotation(bodyRequest *schema.AccessRecordAnnotationRequest) error {
+	if bodyRequest.Field == nil || *bodyRequest.Field == "" {
+		if bodyRequest.Value != nil && *bodyRequest.Value != "" {
+			return common.NewValidationError("field is required when a value is given")
 		}
+		return nil
 	}
-
-	if bodyRequest.Result != nil && *bodyRequest.Result != "" {
-		if !common.ValidateAccessRecordResult(*bodyRequest.Result) {
-			return fmt.Errorf("result must be 'success' or 'failed' or 'unknown'")
+	value := stringValue(bodyRequest.Value)
+	switch *bodyRequest.Field {
+	case "type":
+		if !common.ValidateAccessRecordType(value) {
+			return common.NewFieldError("type", "type must be 'in' or 'out'")
+		}
+	case "result":
+		if !common.ValidateAccessRecordResult(value) {
+			return common.NewFieldError("result", "result must be 'success' or 'failed' or 'unknown'")
+		}
+	case "accessTime":
+		if _, err := common.ParseTimestamp(value, nil); err != nil {
+			return common.NewValidationError("invalid access time format, expected an RFC 3339 timestamp")
 		}
 	}
-
 	return nil
 }
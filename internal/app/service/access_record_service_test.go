@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/repository"
+	"gorm.io/gorm"
+)
+
+// chainRepository serves an access record chain from memory. Only the hash chain methods are
+// implemented.
+type chainRepository struct {
+	repository.AccessRecordRepository
+	// records are the records of the scope, chain is the whole chain across the tenants
+	records    []model.AccessRecord
+	chain      []model.AccessRecord
+	checkpoint *model.AccessRecordChainCheckpoint
+	unchained  int64
+}
+
+func (r *chainRepository) GetChain(afterSequence int64, limit int) ([]model.AccessRecord, error) {
+	var chain []model.AccessRecord
+	for _, record := range r.records {
+		if record.Sequence > afterSequence && len(chain) < limit {
+			chain = append(chain, record)
+		}
+	}
+	return chain, nil
+}
+
+func (r *chainRepository) GetChainHashes(sequences []int64) (map[int64]string, error) {
+	hashes := make(map[int64]string)
+	for _, sequence := range sequences {
+		for _, record := range r.chain {
+			if record.Sequence == sequence {
+				hashes[sequence] = record.Hash
+			}
+		}
+	}
+	return hashes, nil
+}
+
+func (r *chainRepository) GetChainCheckpoint() (*model.AccessRecordChainCheckpoint, error) {
+	return r.checkpoint, nil
+}
+
+func (r *chainRepository) CountUnchained() (int64, error) {
+	return r.unchained, nil
+}
+
+// sealedChain returns a chain of records of the given tenants sealed with the current chain
+// version, starting after a record with the given hash.
+func sealedChain(prevHash string, firstSequence int64, tenants ...string) []model.AccessRecord {
+	records := make([]model.AccessRecord, len(tenants))
+	for i, tenantID := range tenants {
+		record := &records[i]
+		record.ID = uuid.New()
+		record.TenantID = tenantID
+		record.Type = "in"
+		record.Result = "success"
+		record.AccessTime = time.Date(2026, 1, 1, 8, i, 0, 0, time.UTC)
+		record.ChainVersion = common.AccessRecordChainVersion
+		record.Sequence = firstSequence + int64(i)
+		record.PrevHash = prevHash
+		record.Hash = common.ChainHash(record.PrevHash, record.HashPayload())
+		prevHash = record.Hash
+	}
+	return records
+}
+
+// resealWithoutKey hashes a record again after a change, as someone with access to the database
+// but without the chain key would.
+func resealWithoutKey(record *model.AccessRecord) {
+	record.Hash = common.LegacyChainHash(record.PrevHash, record.HashPayload())
+}
+
+func TestVerifyChain(t *testing.T) {
+	previous := common.AccessRecordChainKey
+	common.AccessRecordChainKey = []byte("0123456789abcdef0123456789abcdef")
+	t.Cleanup(func() { common.AccessRecordChainKey = previous })
+
+	tests := []struct {
+		name string
+		// tenantID verifies as a tenant, empty verifies every tenant
+		tenantID   string
+		checkpoint *model.AccessRecordChainCheckpoint
+		// change changes the chain of tenants a, b, a, a after it was sealed
+		change      func(repo *chainRepository)
+		wantRecords int64
+		wantIssues  []string
+	}{
+		{
+			name:        "intact chain",
+			change:      func(repo *chainRepository) {},
+			wantRecords: 4,
+		},
+		{
+			name:        "changed field",
+			change:      func(repo *chainRepository) { repo.records[1].Result = "failed" },
+			wantRecords: 4,
+			wantIssues:  []string{common.AccessRecordChainTampered},
+		},
+		{
+			name: "changed field sealed again without the key",
+			change: func(repo *chainRepository) {
+				repo.records[1].Result = "failed"
+				resealWithoutKey(&repo.records[1])
+			},
+			wantRecords: 4,
+			wantIssues:  []string{common.AccessRecordChainTampered, common.AccessRecordChainBrokenLink},
+		},
+		{
+			name: "older chain version",
+			change: func(repo *chainRepository) {
+				repo.records[3].ChainVersion = 2
+				resealWithoutKey(&repo.records[3])
+			},
+			wantRecords: 4,
+			wantIssues:  []string{common.AccessRecordChainTampered},
+		},
+		{
+			name:        "deleted record",
+			change:      func(repo *chainRepository) { repo.records = append(repo.records[:1], repo.records[2:]...) },
+			wantRecords: 3,
+			wantIssues:  []string{common.AccessRecordChainGap},
+		},
+		{
+			name:        "soft deleted record",
+			change:      func(repo *chainRepository) { repo.records[2].DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true} },
+			wantRecords: 4,
+			wantIssues:  []string{common.AccessRecordChainDeleted},
+		},
+		{
+			name: "duplicate sequence",
+			change: func(repo *chainRepository) {
+				duplicate := repo.records[1]
+				duplicate.ID = uuid.New()
+				repo.records = append(repo.records[:2], append([]model.AccessRecord{duplicate}, repo.records[2:]...)...)
+			},
+			wantRecords: 4,
+			wantIssues:  []string{common.AccessRecordChainDuplicate, common.AccessRecordChainTampered},
+		},
+		{
+			name:        "records outside the chain",
+			change:      func(repo *chainRepository) { repo.unchained = 2 },
+			wantRecords: 4,
+			wantIssues:  []string{common.AccessRecordChainUnchained},
+		},
+		{
+			name:        "chain after a retention checkpoint",
+			checkpoint:  &model.AccessRecordChainCheckpoint{Sequence: 10, Hash: "checkpoint"},
+			change:      func(repo *chainRepository) {},
+			wantRecords: 4,
+		},
+		{
+			name:        "broken link to the retention checkpoint",
+			checkpoint:  &model.AccessRecordChainCheckpoint{Sequence: 10, Hash: "other checkpoint"},
+			change:      func(repo *chainRepository) {},
+			wantRecords: 4,
+			wantIssues:  []string{common.AccessRecordChainBrokenLink},
+		},
+		{
+			name:        "tenant links to the records of other tenants",
+			tenantID:    "a",
+			change:      func(repo *chainRepository) {},
+			wantRecords: 3,
+		},
+		{
+			name:        "tenant sees a changed record of another tenant as a broken link",
+			tenantID:    "a",
+			change:      func(repo *chainRepository) { repo.records[1].Hash = "forged" },
+			wantRecords: 3,
+			wantIssues:  []string{common.AccessRecordChainBrokenLink},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prevHash, firstSequence := "", int64(1)
+			if tt.checkpoint != nil {
+				prevHash, firstSequence = "checkpoint", tt.checkpoint.Sequence+1
+			}
+			repo := &chainRepository{records: sealedChain(prevHash, firstSequence, "a", "b", "a", "a"), checkpoint: tt.checkpoint}
+			tt.change(repo)
+			repo.chain = repo.records
+
+			// The repository of a tenant only returns the records of the tenant
+			ctx := common.WithAllTenants(context.Background())
+			if tt.tenantID != "" {
+				ctx = common.WithTenant(context.Background(), tt.tenantID)
+				var records []model.AccessRecord
+				for _, record := range repo.chain {
+					if record.TenantID == tt.tenantID {
+						records = append(records, record)
+					}
+				}
+				repo.records = records
+			}
+			db := &gorm.DB{Statement: &gorm.Statement{Context: ctx}}
+			s := NewAccessRecordService(repo, nil, nil, nil, db)
+
+			response, err := s.VerifyChain()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var problems []string
+			for _, issue := range response.Issues {
+				problems = append(problems, issue.Problem)
+			}
+			if !slices.Equal(problems, tt.wantIssues) {
+				t.Errorf("issues = %v, want %v", problems, tt.wantIssues)
+			}
+			if response.Valid != (len(tt.wantIssues) == 0) {
+				t.Errorf("valid = %v with issues %v", response.Valid, problems)
+			}
+			if response.Records != tt.wantRecords {
+				t.Errorf("records = %d, want %d", response.Records, tt.wantRecords)
+			}
+		})
+	}
+}
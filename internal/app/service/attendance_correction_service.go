@@ -0,0 +1,242 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/repository"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// AttendanceCorrectionService defines the interface for manual attendance corrections and their approval.
+type AttendanceCorrectionService interface {
+	GetAll(searchQuery schema.AttendanceCorrectionSearchQuery) ([]model.AttendanceCorrection, error)
+	GetByID(id string) (*model.AttendanceCorrection, error)
+	Create(bodyRequest *schema.AttendanceCorrectionRequest, username string) (*model.AttendanceCorrection, error)
+	Approve(id string, bodyRequest *schema.DecisionRequest, username string) (*model.AttendanceCorrection, error)
+	Reject(id string, bodyRequest *schema.DecisionRequest, username string) (*model.AttendanceCorrection, error)
+	Cancel(id string, bodyRequest *schema.DecisionRequest, username string) (*model.AttendanceCorrection, error)
+	ConvertToResponse(correction *model.AttendanceCorrection) (*schema.AttendanceCorrectionResponse, error)
+}
+
+type attendanceCorrectionServiceImpl struct {
+	attendanceCorrectionRepo repository.AttendanceCorrectionRepository
+	personRepo               repository.PersonRepository
+	userRepo                 repository.UserRepository
+	attendanceRecordService  AttendanceRecordService
+}
+
+// NewAttendanceCorrectionService creates a new instance of AttendanceCorrectionService.
+func NewAttendanceCorrectionService(attendanceCorrectionRepo repository.AttendanceCorrectionRepository, personRepo repository.PersonRepository, userRepo repository.UserRepository, attendanceRecordService AttendanceRecordService) AttendanceCorrectionService {
+	return &attendanceCorrectionServiceImpl{
+		attendanceCorrectionRepo: attendanceCorrectionRepo,
+		personRepo:               personRepo,
+		userRepo:                 userRepo,
+		attendanceRecordService:  attendanceRecordService,
+	}
+}
+
+// GetAll retrieves attendance corrections.
+func (s *attendanceCorrectionServiceImpl) GetAll(searchQuery schema.AttendanceCorrectionSearchQuery) ([]model.AttendanceCorrection, error) {
+	return s.attendanceCorrectionRepo.GetAll(searchQuery)
+}
+
+// GetByID retrieves an attendance correction by its ID.
+func (s *attendanceCorrectionServiceImpl) GetByID(id string) (*model.AttendanceCorrection, error) {
+	idUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, common.NewFieldError("id", "invalid ID")
+	}
+	correction, err := s.attendanceCorrectionRepo.GetByID(idUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("attendance correction with ID '%s' not found", id)
+		}
+		return nil, fmt.Errorf("failed to get attendance correction: %w", err)
+	}
+	return correction, nil
+}
+
+// Create files a pending correction. A person can have one open clock-in and one open clock-out
+// correction per date.
+func (s *attendanceCorrectionServiceImpl) Create(bodyRequest *schema.AttendanceCorrectionRequest, username string) (*model.AttendanceCorrection, error) {
+	personUUID, err := uuid.Parse(*bodyRequest.PersonID)
+	if err != nil {
+		return nil, common.NewValidationError("invalid person ID")
+	}
+	if _, err := s.personRepo.GetByID(personUUID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("person with ID '%s' not found", *bodyRequest.PersonID)
+		}
+		return nil, fmt.Errorf("failed to get person: %w", err)
+	}
+
+	date, err := time.ParseInLocation(common.DateLayout, *bodyRequest.Date, time.Local)
+	if err != nil {
+		return nil, common.NewValidationError("invalid date format, expected YYYY-MM-DD")
+	}
+	correctionTime, err := common.ParseTimestamp(*bodyRequest.Time, nil)
+	if err != nil {
+		return nil, common.NewValidationError("invalid time format, expected an RFC 3339 timestamp")
+	}
+	// Punches of an attendance date can start the evening before and end the morning after
+	if correctionTime.Before(date.AddDate(0, 0, -1)) || !correctionTime.Before(date.AddDate(0, 0, 2)) {
+		return nil, common.NewValidationError("time must be within a day of the attendance date")
+	}
+
+	isExistOpen, err := s.attendanceCorrectionRepo.IsExistOpen(*bodyRequest.PersonID, *bodyRequest.Date, *bodyRequest.Type)
+	if err != nil {
+		return nil, err
+	}
+	if isExistOpen {
+		return nil, common.NewConflictError("a %s correction for %s is already pending or approved", *bodyRequest.Type, *bodyRequest.Date)
+	}
+
+	correction := &model.AttendanceCorrection{
+		PersonID:    *bodyRequest.PersonID,
+		Date:        *bodyRequest.Date,
+		Type:        *bodyRequest.Type,
+		Time:        correctionTime,
+		Reason:      *bodyRequest.Reason,
+		Status:      common.ApprovalStatusPending,
+		RequestedBy: emptyToNil(&username),
+	}
+	if err := s.attendanceCorrectionRepo.Create(correction); err != nil {
+		return nil, fmt.Errorf("failed to create attendance correction: %w", err)
+	}
+	return correction, nil
+}
+
+// Approve approves a pending correction and recalculates the attendance of its date.
+func (s *attendanceCorrectionServiceImpl) Approve(id string, bodyRequest *schema.DecisionRequest, username string) (*model.AttendanceCorrection, error) {
+	correction, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if correction.Status != common.ApprovalStatusPending {
+		return nil, common.NewConflictError("only pending attendance corrections can be approved, this one is %s", correction.Status)
+	}
+	approver, err := getAttendanceApprover(s.userRepo, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.decide(correction, common.ApprovalStatusApproved, approver, bodyRequest); err != nil {
+		return nil, err
+	}
+	if err := recalculateAttendance(s.personRepo, s.attendanceRecordService, correction.PersonID, correction.Date, correction.Date); err != nil {
+		return nil, err
+	}
+	return correction, nil
+}
+
+// Reject rejects a pending correction.
+func (s *attendanceCorrectionServiceImpl) Reject(id string, bodyRequest *schema.DecisionRequest, username string) (*model.AttendanceCorrection, error) {
+	correction, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if correction.Status != common.ApprovalStatusPending {
+		return nil, common.NewConflictError("only pending attendance corrections can be rejected, this one is %s", correction.Status)
+	}
+	approver, err := getAttendanceApprover(s.userRepo, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.decide(correction, common.ApprovalStatusRejected, approver, bodyRequest); err != nil {
+		return nil, err
+	}
+	return correction, nil
+}
+
+// Cancel withdraws a pending or approved correction. Withdrawing an approved correction needs the
+// same permission as approving and recalculates the attendance of its date.
+func (s *attendanceCorrectionServiceImpl) Cancel(id string, bodyRequest *schema.DecisionRequest, username string) (*model.AttendanceCorrection, error) {
+	correction, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var user *model.User
+	switch correction.Status {
+	case common.ApprovalStatusPending:
+		user, err = s.userRepo.GetByUsername(username)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, common.NewNotFoundError("user '%s' not found", username)
+			}
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+	case common.ApprovalStatusApproved:
+		user, err = getAttendanceApprover(s.userRepo, username)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, common.NewConflictError("only pending or approved attendance corrections can be cancelled, this one is %s", correction.Status)
+	}
+
+	wasApproved := correction.Status == common.ApprovalStatusApproved
+	if err := s.decide(correction, common.ApprovalStatusCancelled, user, bodyRequest); err != nil {
+		return nil, err
+	}
+	if wasApproved {
+		if err := recalculateAttendance(s.personRepo, s.attendanceRecordService, correction.PersonID, correction.Date, correction.Date); err != nil {
+			return nil, err
+		}
+	}
+	return correction, nil
+}
+
+// ConvertToResponse converts an attendance correction model to a response schema.
+func (s *attendanceCorrectionServiceImpl) ConvertToResponse(correction *model.AttendanceCorrection) (*schema.AttendanceCorrectionResponse, error) {
+	response := &schema.AttendanceCorrectionResponse{
+		ID:           correction.ID.String(),
+		Date:         correction.Date,
+		Type:         correction.Type,
+		Time:         common.FormatTimestamp(correction.Time, nil),
+		Reason:       correction.Reason,
+		Status:       correction.Status,
+		RequestedBy:  correction.RequestedBy,
+		DecidedAt:    formatOptionalTime(correction.DecidedAt),
+		DecisionNote: correction.DecisionNote,
+	}
+
+	if personUUID, err := uuid.Parse(correction.PersonID); err == nil {
+		person, err := s.personRepo.GetByID(personUUID)
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("failed to get person: %w", err)
+		}
+		if person != nil {
+			response.Person = convertPersonToInfoResponse(person)
+		}
+	}
+
+	decidedBy, err := getUsername(s.userRepo, correction.DecidedByUserID)
+	if err != nil {
+		return nil, err
+	}
+	response.DecidedBy = decidedBy
+	return response, nil
+}
+
+// ----------> INNER FUNCTION <-----------------------//
+
+// decide stores the new status of a correction with who decided it and when.
+func (s *attendanceCorrectionServiceImpl) decide(correction *model.AttendanceCorrection, status string, user *model.User, bodyRequest *schema.DecisionRequest) error {
+	now := time.Now()
+	userID := user.ID.String()
+	correction.Status = status
+	correction.DecidedByUserID = &userID
+	correction.DecidedAt = &now
+	correction.DecisionNote = emptyToNil(bodyRequest.Note)
+	if err := s.attendanceCorrectionRepo.Update(correction); err != nil {
+		return fmt.Errorf("failed to update attendance correction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,879 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/repository"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// AttendanceRecordService calculates daily attendance from the access records of attendance devices.
+type AttendanceRecordService interface {
+	GetAll(searchQuery schema.AttendanceRecordSearchQuery) ([]model.AttendanceRecord, error)
+	Calculate(bodyRequest *schema.AttendanceCalculateRequest) ([]model.AttendanceRecord, error)
+	Summarize(searchQuery schema.AttendanceSummaryQuery) ([]schema.AttendanceSummaryResponse, error)
+	ApproveOvertime(id string, bodyRequest *schema.OvertimeDecisionRequest, username string) (*model.AttendanceRecord, error)
+	RejectOvertime(id string, bodyRequest *schema.OvertimeDecisionRequest, username string) (*model.AttendanceRecord, error)
+	ConvertToResponse(record *model.AttendanceRecord) (*schema.AttendanceRecordResponse, error)
+}
+
+type attendanceRecordServiceImpl struct {
+	attendanceRecordRepo repository.AttendanceRecordRepository
+	attendanceRepo       repository.AttendanceRepository
+	personRepo           repository.PersonRepository
+	accessRecordRepo     repository.AccessRecordRepository
+	holidayCalendarRepo  repository.HolidayCalendarRepository
+	personShiftRepo      repository.PersonShiftRepository
+	shiftRotationRepo    repository.ShiftRotationRepository
+	shiftTemplateRepo    repository.ShiftTemplateRepository
+	leaveRequestRepo     repository.LeaveRequestRepository
+	leaveTypeRepo        repository.LeaveTypeRepository
+	correctionRepo       repository.AttendanceCorrectionRepository
+	overtimeRuleRepo     repository.OvertimeRuleRepository
+	userRepo             repository.UserRepository
+	payrollExportRepo    repository.PayrollExportRepository
+	deviceRepo           repository.AccessControlDeviceRepository
+	locationRepo         repository.LocationRepository
+}
+
+// attendanceProfile is an attendance profile with its schedules, loaded once per calculation.
+type attendanceProfile struct {
+	attendance *model.Attendance
+	schedules  []model.AttendanceSchedule
+}
+
+// NewAttendanceRecordService creates a new instance of AttendanceRecordService.
+func NewAttendanceRecordService(attendanceRecordRepo repository.AttendanceRecordRepository, attendanceRepo repository.AttendanceRepository, personRepo repository.PersonRepository, accessRecordRepo repository.AccessRecordRepository, holidayCalendarRepo repository.HolidayCalendarRepository, personShiftRepo repository.PersonShiftRepository, shiftRotationRepo repository.ShiftRotationRepository, shiftTemplateRepo repository.ShiftTemplateRepository, leaveRequestRepo repository.LeaveRequestRepository, leaveTypeRepo repository.LeaveTypeRepository, correctionRepo repository.AttendanceCorrectionRepository, overtimeRuleRepo repository.OvertimeRuleRepository, userRepo repository.UserRepository, payrollExportRepo repository.PayrollExportRepository, deviceRepo repository.AccessControlDeviceRepository, locationRepo repository.LocationRepository) AttendanceRecordService {
+	return &attendanceRecordServiceImpl{
+		attendanceRecordRepo: attendanceRecordRepo,
+		attendanceRepo:       attendanceRepo,
+		personRepo:           personRepo,
+		accessRecordRepo:     accessRecordRepo,
+		holidayCalendarRepo:  holidayCalendarRepo,
+		personShiftRepo:      personShiftRepo,
+		shiftRotationRepo:    shiftRotationRepo,
+		shiftTemplateRepo:    shiftTemplateRepo,
+		leaveRequestRepo:     leaveRequestRepo,
+		leaveTypeRepo:        leaveTypeRepo,
+		correctionRepo:       correctionRepo,
+		overtimeRuleRepo:     overtimeRuleRepo,
+		userRepo:             userRepo,
+		payrollExportRepo:    payrollExportRepo,
+		deviceRepo:           deviceRepo,
+		locationRepo:         locationRepo,
+	}
+}
+
+// GetAll retrieves calculated attendance records.
+func (s *attendanceRecordServiceImpl) GetAll(searchQuery schema.AttendanceRecordSearchQuery) ([]model.AttendanceRecord, error) {
+	return s.attendanceRecordRepo.GetAll(searchQuery)
+}
+
+// Calculate (re)calculates the attendance of a person, or of everybody with an attendance profile,
+// for every date of the range. Existing records of those dates are replaced.
+func (s *attendanceRecordServiceImpl) Calculate(bodyRequest *schema.AttendanceCalculateRequest) ([]model.AttendanceRecord, error) {
+	rangeStart, rangeEnd, err := parseAttendanceDateRange(*bodyRequest.StartDate, *bodyRequest.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	people, err := s.getPeopleToCalculate(bodyRequest.PersonID)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := map[string]*attendanceProfile{}
+	planner := newShiftPlanner(s.personShiftRepo, s.shiftRotationRepo, s.shiftTemplateRepo, s.holidayCalendarRepo)
+	leaveTypeNames := map[string]string{}
+	overtimeRules := map[string]*model.OvertimeRule{}
+	var records []model.AttendanceRecord
+	for _, person := range people {
+		profile, err := getAttendanceProfile(s.attendanceRepo, *person.TimeAttendanceID, profiles)
+		if err != nil {
+			return nil, err
+		}
+		if profile == nil {
+			// The attendance profile was deleted, nothing to calculate against
+			continue
+		}
+
+		overtimeRule, err := s.getOvertimeRule(profile.attendance.OvertimeRuleID, overtimeRules)
+		if err != nil {
+			return nil, err
+		}
+		// Shift hours are clock times in the local time of the devices the person punches at
+		loc, err := s.getAttendanceTimeZone(person.ID.String(), rangeEnd.AddDate(0, 0, 2))
+		if err != nil {
+			return nil, err
+		}
+		startDate := dateInLocation(rangeStart, loc)
+		endDate := dateInLocation(rangeEnd, loc)
+		if err := planner.loadPerson(person.ID.String()); err != nil {
+			return nil, err
+		}
+		leaves, err := s.getApprovedLeaves(person.ID.String(), startDate, endDate, leaveTypeNames)
+		if err != nil {
+			return nil, err
+		}
+		corrections, err := s.getApprovedCorrections(person.ID.String(), startDate, endDate)
+		if err != nil {
+			return nil, err
+		}
+
+		// Plans of the day before and after the range decide where the first and last punch
+		// windows end, so overnight shifts are matched to the date they started on.
+		prev, err := planner.plan(profile, startDate.AddDate(0, 0, -1))
+		if err != nil {
+			return nil, err
+		}
+		current, err := planner.plan(profile, startDate)
+		if err != nil {
+			return nil, err
+		}
+		for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+			next, err := planner.plan(profile, date.AddDate(0, 0, 1))
+			if err != nil {
+				return nil, err
+			}
+			dateStr := date.Format(common.DateLayout)
+			record, err := s.calculateDay(&person, current, leaves[dateStr], corrections[dateStr], punchWindowBoundary(prev, current), punchWindowBoundary(current, next))
+			if err != nil {
+				return nil, err
+			}
+			applyOvertime(record, current, overtimeRule)
+			prev, current = current, next
+			if err := s.saveAttendanceRecord(record); err != nil {
+				return nil, err
+			}
+			records = append(records, *record)
+		}
+	}
+	return records, nil
+}
+
+// Summarize totals the calculated attendance of a date range per person, in order of first record.
+func (s *attendanceRecordServiceImpl) Summarize(searchQuery schema.AttendanceSummaryQuery) ([]schema.AttendanceSummaryResponse, error) {
+	if _, _, err := parseAttendanceDateRange(searchQuery.StartDate, searchQuery.EndDate); err != nil {
+		return nil, err
+	}
+	records, err := s.attendanceRecordRepo.GetAll(schema.AttendanceRecordSearchQuery{
+		PersonID:  searchQuery.PersonID,
+		StartDate: searchQuery.StartDate,
+		EndDate:   searchQuery.EndDate,
+		All:       true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := map[string]*schema.AttendanceSummaryResponse{}
+	var personIDs []string
+	for _, record := range records {
+		summary, ok := summaries[record.PersonID]
+		if !ok {
+			summary = &schema.AttendanceSummaryResponse{LeaveDaysByType: map[string]float64{}, OvertimeMinutesByDayType: map[string]int{}}
+			summaries[record.PersonID] = summary
+			personIDs = append(personIDs, record.PersonID)
+		}
+
+		summary.Days++
+		switch record.Status {
+		case common.AttendanceStatusPresent:
+			summary.Present++
+		case common.AttendanceStatusLate:
+			summary.Late++
+		case common.AttendanceStatusIncomplete:
+			summary.Incomplete++
+		case common.AttendanceStatusAbsent:
+			summary.Absent++
+		case common.AttendanceStatusLeave:
+			summary.Leave++
+		case common.AttendanceStatusHoliday:
+			summary.Holiday++
+		case common.AttendanceStatusDayOff:
+			summary.DayOff++
+		}
+		if record.LeaveDays > 0 {
+			summary.LeaveDays += record.LeaveDays
+			summary.LeaveDaysByType[stringValue(record.LeaveTypeName)] += record.LeaveDays
+		}
+		summary.LateMinutes += record.LateMinutes
+		summary.EarlyLeaveMinutes += record.EarlyLeaveMinutes
+		summary.WorkedMinutes += record.WorkedMinutes
+		if record.CheckInCorrectionID != nil || record.CheckOutCorrectionID != nil {
+			summary.CorrectedDays++
+		}
+		switch stringValue(record.OvertimeStatus) {
+		case common.ApprovalStatusApproved:
+			summary.OvertimeMinutes += record.ApprovedOvertimeMinutes
+			summary.OvertimeMinutesByDayType[stringValue(record.OvertimeDayType)] += record.ApprovedOvertimeMinutes
+			summary.WeightedOvertimeHours += float64(record.ApprovedOvertimeMinutes) * record.OvertimeMultiplier / 60
+		case common.ApprovalStatusPending:
+			summary.PendingOvertimeMinutes += record.OvertimeMinutes
+		}
+	}
+
+	responses := make([]schema.AttendanceSummaryResponse, 0, len(personIDs))
+	for _, personID := range personIDs {
+		summary := summaries[personID]
+		summary.WeightedOvertimeHours = math.Round(summary.WeightedOvertimeHours*100) / 100
+		if personUUID, err := uuid.Parse(personID); err == nil {
+			person, err := s.personRepo.GetByID(personUUID)
+			if err != nil && err != gorm.ErrRecordNotFound {
+				return nil, fmt.Errorf("failed to get person: %w", err)
+			}
+			if person != nil {
+				summary.Person = convertPersonToInfoResponse(person)
+			}
+		}
+		responses = append(responses, *summary)
+	}
+	return responses, nil
+}
+
+// ApproveOvertime approves the pending overtime of a record, all of it unless fewer minutes are
+// given. Only users with the time attendance permission may approve.
+func (s *attendanceRecordServiceImpl) ApproveOvertime(id string, bodyRequest *schema.OvertimeDecisionRequest, username string) (*model.AttendanceRecord, error) {
+	record, err := s.getPendingOvertime(id, "approved")
+	if err != nil {
+		return nil, err
+	}
+	approvedMinutes := record.OvertimeMinutes
+	if bodyRequest.Minutes != nil {
+		if *bodyRequest.Minutes > record.OvertimeMinutes {
+			return nil, common.NewValidationError("approved minutes cannot be more than the %d minutes of overtime", record.OvertimeMinutes)
+		}
+		approvedMinutes = *bodyRequest.Minutes
+	}
+	approver, err := getAttendanceApprover(s.userRepo, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.decideOvertime(record, common.ApprovalStatusApproved, approvedMinutes, approver, bodyRequest); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// RejectOvertime rejects the pending overtime of a record.
+func (s *attendanceRecordServiceImpl) RejectOvertime(id string, bodyRequest *schema.OvertimeDecisionRequest, username string) (*model.AttendanceRecord, error) {
+	record, err := s.getPendingOvertime(id, "rejected")
+	if err != nil {
+		return nil, err
+	}
+	approver, err := getAttendanceApprover(s.userRepo, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.decideOvertime(record, common.ApprovalStatusRejected, 0, approver, bodyRequest); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// ConvertToResponse converts an attendance record model to a response schema.
+func (s *attendanceRecordServiceImpl) ConvertToResponse(record *model.AttendanceRecord) (*schema.AttendanceRecordResponse, error) {
+	response := &schema.AttendanceRecordResponse{
+		ID:                record.ID.String(),
+		Date:              record.Date,
+		Status:            record.Status,
+		HolidayName:       record.HolidayName,
+		ScheduleStartTime: record.ScheduleStartTime,
+		ScheduleEndTime:   record.ScheduleEndTime,
+		ShiftTemplateID:   record.ShiftTemplateID,
+		ShiftName:         record.ShiftName,
+		LeaveTypeName:     record.LeaveTypeName,
+		LeaveDays:         record.LeaveDays,
+		EndsNextDay:       record.EndsNextDay,
+		CheckInAt:         formatOptionalTime(record.CheckInAt),
+		CheckOutAt:        formatOptionalTime(record.CheckOutAt),
+		CheckInCorrected:  record.CheckInCorrectionID != nil,
+		CheckOutCorrected: record.CheckOutCorrectionID != nil,
+		LateMinutes:       record.LateMinutes,
+		EarlyLeaveMinutes: record.EarlyLeaveMinutes,
+		WorkedMinutes:     record.WorkedMinutes,
+	}
+	if record.OvertimeStatus != nil {
+		decidedBy, err := getUsername(s.userRepo, record.OvertimeDecidedByUserID)
+		if err != nil {
+			return nil, err
+		}
+		response.Overtime = &schema.AttendanceOvertimeResponse{
+			Minutes:         record.OvertimeMinutes,
+			DayType:         stringValue(record.OvertimeDayType),
+			Multiplier:      record.OvertimeMultiplier,
+			Status:          *record.OvertimeStatus,
+			ApprovedMinutes: record.ApprovedOvertimeMinutes,
+			DecidedBy:       decidedBy,
+			DecidedAt:       formatOptionalTime(record.OvertimeDecidedAt),
+			DecisionNote:    record.OvertimeDecisionNote,
+		}
+	}
+
+	if personUUID, err := uuid.Parse(record.PersonID); err == nil {
+		person, err := s.personRepo.GetByID(personUUID)
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("failed to get person: %w", err)
+		}
+		if person != nil {
+			response.Person = convertPersonToInfoResponse(person)
+		}
+	}
+	return response, nil
+}
+
+// ----------> INNER FUNCTION <-----------------------//
+
+// getPeopleToCalculate returns the requested person, or everybody with an attendance profile.
+func (s *attendanceRecordServiceImpl) getPeopleToCalculate(personID *string) ([]model.Person, error) {
+	if personID == nil || *personID == "" {
+		people, err := s.personRepo.GetWithTimeAttendance()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get people: %w", err)
+		}
+		return people, nil
+	}
+
+	personUUID, err := uuid.Parse(*personID)
+	if err != nil {
+		return nil, common.NewValidationError("invalid person ID")
+	}
+	person, err := s.personRepo.GetByID(personUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("person with ID '%s' not found", *personID)
+		}
+		return nil, fmt.Errorf("failed to get person: %w", err)
+	}
+	if person.TimeAttendanceID == nil || *person.TimeAttendanceID == "" {
+		return nil, common.NewValidationError("person has no attendance profile")
+	}
+	return []model.Person{*person}, nil
+}
+
+// getAttendanceProfile loads an attendance profile and its schedules, using the cache of the
+// current calculation. A missing profile gives nil.
+func getAttendanceProfile(attendanceRepo repository.AttendanceRepository, attendanceID string, profiles map[string]*attendanceProfile) (*attendanceProfile, error) {
+	if profile, ok := profiles[attendanceID]; ok {
+		return profile, nil
+	}
+
+	var profile *attendanceProfile
+	attendanceUUID, err := uuid.Parse(attendanceID)
+	if err == nil {
+		attendance, err := attendanceRepo.GetByID(attendanceUUID)
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("failed to get attendance: %w", err)
+		}
+		if attendance != nil {
+			schedules, err := attendanceRepo.GetSchedulesByAttendanceID(attendanceUUID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get attendance schedules: %w", err)
+			}
+			profile = &attendanceProfile{attendance: attendance, schedules: schedules}
+		}
+	}
+	profiles[attendanceID] = profile
+	return profile, nil
+}
+
+// calculateDay builds the attendance record of a person from the planned shift of a date. The
+// first attendance punch inside [windowStart, windowEnd) is the clock-in and the last one the
+// clock-out, so the check-out of a night shift on the next morning still counts for the date the
+// shift started. Approved corrections replace the clock-in or clock-out taken from punches. Holidays are not working days unless they keep special hours, so they are never
+// counted as absences, and approved leave on a working day turns an absence into leave.
+func (s *attendanceRecordServiceImpl) calculateDay(person *model.Person, planned *plannedShift, leave *approvedLeave, corrections *dayCorrections, windowStart time.Time, windowEnd time.Time) (*model.AttendanceRecord, error) {
+	record := &model.AttendanceRecord{
+		PersonID: person.ID.String(),
+		Date:     planned.date.Format(common.DateLayout),
+	}
+	if planned.schedule != nil {
+		record.AttendanceScheduleID = planned.schedule.ID.String()
+	}
+	if planned.shiftTemplate != nil {
+		shiftTemplateID := planned.shiftTemplate.ID.String()
+		record.ShiftTemplateID = &shiftTemplateID
+		record.ShiftName = &planned.shiftTemplate.Name
+	}
+	if planned.holiday != nil {
+		holidayID := planned.holiday.ID.String()
+		record.HolidayID = &holidayID
+		record.HolidayName = &planned.holiday.Name
+	}
+
+	punches, err := s.accessRecordRepo.GetAttendancePunches(record.PersonID, windowStart, windowEnd)
+	if err != nil {
+		return nil, err
+	}
+	applyPunches(record, punches, corrections)
+
+	if planned.start == nil {
+		record.Status = common.AttendanceStatusDayOff
+		if planned.holiday != nil {
+			record.Status = common.AttendanceStatusHoliday
+		}
+		return record, nil
+	}
+
+	startTime, endTime := planned.start.Format(common.ClockLayout), planned.end.Format(common.ClockLayout)
+	record.ScheduleStartTime, record.ScheduleEndTime = &startTime, &endTime
+	record.EndsNextDay = !planned.end.Before(planned.date.AddDate(0, 0, 1))
+
+	halfDay := ""
+	if leave != nil {
+		leaveRequestID := leave.request.ID.String()
+		record.LeaveRequestID = &leaveRequestID
+		record.LeaveTypeName = &leave.typeName
+		record.LeaveDays = 1
+		if leave.request.HalfDay != nil {
+			halfDay = *leave.request.HalfDay
+			record.LeaveDays = 0.5
+		}
+	}
+	if record.CheckInAt == nil || (leave != nil && halfDay == "") {
+		record.Status = common.AttendanceStatusAbsent
+		if leave != nil {
+			record.Status = common.AttendanceStatusLeave
+		}
+		return record, nil
+	}
+
+	// Half-day leave excuses the late arrival after a morning off or leaving early for an afternoon off
+	if halfDay != common.LeaveHalfDayMorning && record.CheckInAt.After(planned.start.Add(time.Duration(planned.lateIn)*time.Minute)) {
+		record.LateMinutes = int(record.CheckInAt.Sub(*planned.start).Minutes())
+	}
+	if halfDay != common.LeaveHalfDayAfternoon && record.CheckOutAt != nil && record.CheckOutAt.Before(planned.end.Add(-time.Duration(planned.earlyOut)*time.Minute)) {
+		record.EarlyLeaveMinutes = int(planned.end.Sub(*record.CheckOutAt).Minutes())
+	}
+
+	switch {
+	case record.CheckOutAt == nil:
+		record.Status = common.AttendanceStatusIncomplete
+	case record.LateMinutes > 0:
+		record.Status = common.AttendanceStatusLate
+	default:
+		record.Status = common.AttendanceStatusPresent
+	}
+	return record, nil
+}
+
+// getOvertimeRule loads the overtime rule of an attendance profile, using the cache of the current
+// calculation. Profiles without a rule give nil.
+func (s *attendanceRecordServiceImpl) getOvertimeRule(overtimeRuleID *string, rules map[string]*model.OvertimeRule) (*model.OvertimeRule, error) {
+	if overtimeRuleID == nil {
+		return nil, nil
+	}
+	if rule, ok := rules[*overtimeRuleID]; ok {
+		return rule, nil
+	}
+	rule, err := findOvertimeRule(s.overtimeRuleRepo, overtimeRuleID)
+	if err != nil {
+		return nil, err
+	}
+	rules[*overtimeRuleID] = rule
+	return rule, nil
+}
+
+// applyOvertime sets the overtime of a calculated record. On a working day the time from the end
+// of the shift to the clock-out is overtime once it passes the late-out tolerance; on a day off
+// or a holiday without special hours all worked time is. Leave days have no overtime.
+func applyOvertime(record *model.AttendanceRecord, planned *plannedShift, rule *model.OvertimeRule) {
+	if rule == nil || record.CheckInAt == nil || record.CheckOutAt == nil || record.Status == common.AttendanceStatusLeave {
+		return
+	}
+
+	rawMinutes := 0
+	switch {
+	case planned.start == nil:
+		rawMinutes = record.WorkedMinutes
+	case record.CheckOutAt.After(planned.end.Add(time.Duration(planned.lateOut) * time.Minute)):
+		rawMinutes = int(record.CheckOutAt.Sub(*planned.end).Minutes())
+	}
+	minutes := roundOvertime(rule, rawMinutes)
+	if minutes == 0 {
+		return
+	}
+
+	dayType, multiplier := common.OvertimeDayWeekday, rule.WeekdayMultiplier
+	dayOfWeek := int(planned.date.Weekday())
+	if dayOfWeek == 0 {
+		dayOfWeek = 7
+	}
+	weekendDays, _ := common.ParseWeekendDays(rule.WeekendDays)
+	switch {
+	case planned.holiday != nil:
+		dayType, multiplier = common.OvertimeDayHoliday, rule.HolidayMultiplier
+	case slices.Contains(weekendDays, dayOfWeek):
+		dayType, multiplier = common.OvertimeDayWeekend, rule.WeekendMultiplier
+	}
+
+	status := common.ApprovalStatusPending
+	record.OvertimeMinutes = minutes
+	record.OvertimeDayType = &dayType
+	record.OvertimeMultiplier = multiplier
+	record.OvertimeStatus = &status
+}
+
+// roundOvertime drops overtime below the minimum block, rounds it down to the rounding block and
+// caps it at the daily cap.
+func roundOvertime(rule *model.OvertimeRule, minutes int) int {
+	if minutes <= 0 || minutes < rule.MinimumMinutes {
+		return 0
+	}
+	if rule.RoundingMinutes > 0 {
+		minutes -= minutes % rule.RoundingMinutes
+	}
+	if rule.DailyCapMinutes > 0 {
+		minutes = min(minutes, rule.DailyCapMinutes)
+	}
+	return minutes
+}
+
+// getPendingOvertime loads an attendance record whose overtime waits for a decision.
+func (s *attendanceRecordServiceImpl) getPendingOvertime(id string, decision string) (*model.AttendanceRecord, error) {
+	idUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, common.NewFieldError("id", "invalid ID")
+	}
+	record, err := s.attendanceRecordRepo.GetByID(idUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("attendance record with ID '%s' not found", id)
+		}
+		return nil, fmt.Errorf("failed to get attendance record: %w", err)
+	}
+	if record.OvertimeStatus == nil {
+		return nil, common.NewValidationError("attendance record has no overtime")
+	}
+	if *record.OvertimeStatus != common.ApprovalStatusPending {
+		return nil, common.NewConflictError("only pending overtime can be %s, this one is %s", decision, *record.OvertimeStatus)
+	}
+	return record, nil
+}
+
+// decideOvertime stores the decision on the overtime of a record.
+func (s *attendanceRecordServiceImpl) decideOvertime(record *model.AttendanceRecord, status string, approvedMinutes int, user *model.User, bodyRequest *schema.OvertimeDecisionRequest) error {
+	now := time.Now()
+	userID := user.ID.String()
+	record.OvertimeStatus = &status
+	record.ApprovedOvertimeMinutes = approvedMinutes
+	record.OvertimeDecidedByUserID = &userID
+	record.OvertimeDecidedAt = &now
+	record.OvertimeDecisionNote = emptyToNil(bodyRequest.Note)
+	if err := s.attendanceRecordRepo.Update(record); err != nil {
+		return fmt.Errorf("failed to update attendance record: %w", err)
+	}
+	if status == common.ApprovalStatusApproved {
+		return s.payrollExportRepo.FlagChangedAfterLock(record.PersonID, record.Date, now)
+	}
+	return nil
+}
+
+// dayCorrections are the approved corrections of one attendance date.
+type dayCorrections struct {
+	clockIn  *model.AttendanceCorrection
+	clockOut *model.AttendanceCorrection
+}
+
+// getAttendanceTimeZone returns the time zone the attendance of a person is evaluated in: the time
+// zone of the device of the last attendance punch before a time, the time zone of the server
+// when the person never punched.
+func (s *attendanceRecordServiceImpl) getAttendanceTimeZone(personID string, before time.Time) (*time.Location, error) {
+	punch, err := s.accessRecordRepo.GetLastAttendancePunch(personID, before)
+	if err != nil {
+		return nil, err
+	}
+	if punch == nil || punch.AccessControlDeviceID == nil {
+		return time.Local, nil
+	}
+	deviceUUID, err := uuid.Parse(*punch.AccessControlDeviceID)
+	if err != nil {
+		return time.Local, nil
+	}
+	device, err := s.deviceRepo.GetByID(deviceUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return time.Local, nil
+		}
+		return nil, fmt.Errorf("failed to get access control device: %w", err)
+	}
+	return deviceTimeZone(s.locationRepo, device)
+}
+
+// getApprovedCorrections maps attendance dates to the approved corrections of a person.
+func (s *attendanceRecordServiceImpl) getApprovedCorrections(personID string, startDate time.Time, endDate time.Time) (map[string]*dayCorrections, error) {
+	approved, err := s.correctionRepo.GetApprovedByPersonAndRange(personID, startDate.Format(common.DateLayout), endDate.Format(common.DateLayout))
+	if err != nil {
+		return nil, err
+	}
+
+	corrections := map[string]*dayCorrections{}
+	for i, correction := range approved {
+		day, ok := corrections[correction.Date]
+		if !ok {
+			day = &dayCorrections{}
+			corrections[correction.Date] = day
+		}
+		if correction.Type == common.AttendanceCorrectionClockIn {
+			day.clockIn = &approved[i]
+		} else {
+			day.clockOut = &approved[i]
+		}
+	}
+	return corrections, nil
+}
+
+// applyPunches sets the clock-in and clock-out of a record. The first punch is the clock-in and
+// the last later punch the clock-out, unless an approved correction gives either of them.
+func applyPunches(record *model.AttendanceRecord, punches []model.AccessRecord, corrections *dayCorrections) {
+	if corrections == nil {
+		corrections = &dayCorrections{}
+	}
+
+	checkOutCandidates := punches
+	switch {
+	case corrections.clockIn != nil:
+		correctionID := corrections.clockIn.ID.String()
+		record.CheckInAt = &corrections.clockIn.Time
+		record.CheckInCorrectionID = &correctionID
+	case len(punches) > 0:
+		record.AccessRecordId = punches[0].ID.String()
+		record.CheckInAt = &punches[0].AccessTime
+		checkOutCandidates = punches[1:]
+	}
+
+	switch {
+	case corrections.clockOut != nil:
+		correctionID := corrections.clockOut.ID.String()
+		record.CheckOutAt = &corrections.clockOut.Time
+		record.CheckOutCorrectionID = &correctionID
+	case len(checkOutCandidates) > 0:
+		checkOut := checkOutCandidates[len(checkOutCandidates)-1]
+		if record.CheckInAt == nil || checkOut.AccessTime.After(*record.CheckInAt) {
+			checkOutID := checkOut.ID.String()
+			record.CheckOutAt = &checkOut.AccessTime
+			record.CheckOutAccessRecordID = &checkOutID
+		}
+	}
+
+	if record.CheckInAt != nil && record.CheckOutAt != nil && record.CheckOutAt.After(*record.CheckInAt) {
+		record.WorkedMinutes = int(record.CheckOutAt.Sub(*record.CheckInAt).Minutes())
+	}
+}
+
+// approvedLeave is an approved leave request with the name of its leave type.
+type approvedLeave struct {
+	request  model.LeaveRequest
+	typeName string
+}
+
+// getApprovedLeaves maps every date of the range to the approved leave of a person on that date.
+// Leave type names are cached in typeNames for the whole calculation.
+func (s *attendanceRecordServiceImpl) getApprovedLeaves(personID string, startDate time.Time, endDate time.Time, typeNames map[string]string) (map[string]*approvedLeave, error) {
+	requests, err := s.leaveRequestRepo.GetApprovedByPersonAndRange(personID, startDate.Format(common.DateLayout), endDate.Format(common.DateLayout))
+	if err != nil {
+		return nil, err
+	}
+
+	leaves := map[string]*approvedLeave{}
+	for _, request := range requests {
+		typeName, ok := typeNames[request.LeaveTypeID]
+		if !ok {
+			leaveType, err := getLeaveTypeInfo(s.leaveTypeRepo, request.LeaveTypeID)
+			if err != nil {
+				return nil, err
+			}
+			if leaveType != nil {
+				typeName = leaveType.Name
+			}
+			typeNames[request.LeaveTypeID] = typeName
+		}
+
+		leave := &approvedLeave{request: request, typeName: typeName}
+		for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+			dateStr := date.Format(common.DateLayout)
+			if request.StartDate <= dateStr && dateStr <= request.EndDate {
+				leaves[dateStr] = leave
+			}
+		}
+	}
+	return leaves, nil
+}
+
+// saveAttendanceRecord replaces the record of the same person and date, or creates it. A decision
+// on the overtime is kept while the recalculated overtime is unchanged, otherwise it is pending again.
+// A change to what payroll is paid on flags the locked payroll exports of the date.
+func (s *attendanceRecordServiceImpl) saveAttendanceRecord(record *model.AttendanceRecord) error {
+	existing, err := s.attendanceRecordRepo.GetByPersonAndDate(record.PersonID, record.Date)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return fmt.Errorf("failed to get attendance record: %w", err)
+	}
+	if existing == nil {
+		if err := s.attendanceRecordRepo.Create(record); err != nil {
+			return fmt.Errorf("failed to create attendance record: %w", err)
+		}
+		existing = &model.AttendanceRecord{}
+	} else {
+		record.ID = existing.ID
+		record.CreatedAt = existing.CreatedAt
+		if record.OvertimeStatus != nil && existing.OvertimeStatus != nil && existing.OvertimeMinutes == record.OvertimeMinutes {
+			record.OvertimeStatus = existing.OvertimeStatus
+			record.ApprovedOvertimeMinutes = existing.ApprovedOvertimeMinutes
+			record.OvertimeDecidedByUserID = existing.OvertimeDecidedByUserID
+			record.OvertimeDecidedAt = existing.OvertimeDecidedAt
+			record.OvertimeDecisionNote = existing.OvertimeDecisionNote
+		}
+		if err := s.attendanceRecordRepo.Update(record); err != nil {
+			return fmt.Errorf("failed to update attendance record: %w", err)
+		}
+	}
+
+	if payrollTotalsChanged(existing, record) {
+		return s.payrollExportRepo.FlagChangedAfterLock(record.PersonID, record.Date, time.Now())
+	}
+	return nil
+}
+
+// payrollTotalsChanged reports whether a recalculated record changes the payroll totals of its person.
+func payrollTotalsChanged(existing *model.AttendanceRecord, record *model.AttendanceRecord) bool {
+	before, after := &model.PayrollExportLine{}, &model.PayrollExportLine{}
+	addPayrollRecord(before, existing)
+	addPayrollRecord(after, record)
+	return !payrollTotalsEqual(before, after)
+}
+
+// pickAttendanceSchedule returns the schedule of a date: a schedule for that exact date wins
+// over the schedule of its day of week (1 = Monday ... 7 = Sunday).
+func pickAttendanceSchedule(schedules []model.AttendanceSchedule, date time.Time) *model.AttendanceSchedule {
+	dateStr := date.Format(common.DateLayout)
+	dayOfWeek := int(date.Weekday())
+	if dayOfWeek == 0 {
+		dayOfWeek = 7
+	}
+
+	var weekly *model.AttendanceSchedule
+	for i, schedule := range schedules {
+		if schedule.Date != nil && *schedule.Date != "" {
+			if *schedule.Date == dateStr {
+				return &schedules[i]
+			}
+			continue
+		}
+		if weekly == nil && schedule.DayOfWeek == dayOfWeek {
+			weekly = &schedules[i]
+		}
+	}
+	return weekly
+}
+
+// parseAttendanceDateRange parses an inclusive "YYYY-MM-DD" date range.
+func parseAttendanceDateRange(startDateStr string, endDateStr string) (time.Time, time.Time, error) {
+	startDate, err := time.Parse(common.DateLayout, startDateStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, common.NewValidationError("invalid start date format, expected YYYY-MM-DD")
+	}
+	endDate, err := time.Parse(common.DateLayout, endDateStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, common.NewValidationError("invalid end date format, expected YYYY-MM-DD")
+	}
+	if endDate.Before(startDate) {
+		return time.Time{}, time.Time{}, common.NewValidationError("end date must be on or after start date")
+	}
+	if endDate.Sub(startDate) >= common.AttendanceCalculateMaxDays*24*time.Hour {
+		return time.Time{}, time.Time{}, common.NewValidationError("date range cannot be longer than %d days", common.AttendanceCalculateMaxDays)
+	}
+	return startDate, endDate, nil
+}
+
+// dateInLocation returns the midnight starting a date in a time zone.
+func dateInLocation(date time.Time, loc *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+}
+
+// clockOnDate returns the time of a "HH:MM:SS" clock on a date.
+func clockOnDate(date time.Time, clock string) (time.Time, error) {
+	parsedClock, err := time.Parse(common.ClockLayout, normalizeClock(clock))
+	if err != nil {
+		return time.Time{}, common.NewValidationError("invalid schedule time '%s'", clock)
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), parsedClock.Hour(), parsedClock.Minute(), parsedClock.Second(), 0, date.Location()), nil
+}
+
+// getAttendanceApprover loads an active user with the time attendance permission, who may decide
+// on leave requests and attendance corrections.
+func getAttendanceApprover(userRepo repository.UserRepository, username string) (*model.User, error) {
+	user, err := userRepo.GetByUsername(username)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("user '%s' not found", username)
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	permission, err := userRepo.GetPermissionByID(user.PermissionID)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, fmt.Errorf("failed to get user permission: %w", err)
+	}
+	if user.Status != "active" || permission == nil || !permission.TimeAttendancePermission {
+		return nil, common.NewForbiddenError("user '%s' is not allowed to decide on attendance requests", username)
+	}
+	return user, nil
+}
+
+// getUsername returns the username of a user ID, nil when there is no such user.
+func getUsername(userRepo repository.UserRepository, userID *string) (*string, error) {
+	if userID == nil {
+		return nil, nil
+	}
+	userUUID, err := uuid.Parse(*userID)
+	if err != nil {
+		return nil, nil
+	}
+	user, err := userRepo.GetByID(userUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return &user.Username, nil
+}
+
+// recalculateAttendance refreshes the calculated attendance of a person from startDate to endDate,
+// up to today. People without an attendance profile have nothing to recalculate.
+func recalculateAttendance(personRepo repository.PersonRepository, attendanceRecordService AttendanceRecordService, personID string, startDate string, endDate string) error {
+	personUUID, err := uuid.Parse(personID)
+	if err != nil {
+		return common.NewValidationError("invalid person ID")
+	}
+	person, err := personRepo.GetByID(personUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		return fmt.Errorf("failed to get person: %w", err)
+	}
+	if person.TimeAttendanceID == nil || *person.TimeAttendanceID == "" {
+		return nil
+	}
+
+	today := time.Now().Format(common.DateLayout)
+	if startDate > today {
+		return nil
+	}
+	endDate = min(endDate, today)
+	_, err = attendanceRecordService.Calculate(&schema.AttendanceCalculateRequest{
+		PersonID:  &personID,
+		StartDate: &startDate,
+		EndDate:   &endDate,
+	})
+	return err
+}
@@ -23,15 +23,19 @@ type AttendanceService interface {
 }
 
 type attendanceServiceImpl struct {
-	attendanceRepo repository.AttendanceRepository
-	db             *gorm.DB
+	attendanceRepo      repository.AttendanceRepository
+	holidayCalendarRepo repository.HolidayCalendarRepository
+	overtimeRuleRepo    repository.OvertimeRuleRepository
+	db                  *gorm.DB
 }
 
 // NewAttendanceService creates a new instance of AttendanceService.
-func NewAttendanceService(attendanceRepo repository.AttendanceRepository, db *gorm.DB) AttendanceService {
+func NewAttendanceService(attendanceRepo repository.AttendanceRepository, holidayCalendarRepo repository.HolidayCalendarRepository, overtimeRuleRepo repository.OvertimeRuleRepository, db *gorm.DB) AttendanceService {
 	return &attendanceServiceImpl{
-		attendanceRepo: attendanceRepo,
-		db:             db,
+		attendanceRepo:      attendanceRepo,
+		holidayCalendarRepo: holidayCalendarRepo,
+		overtimeRuleRepo:    overtimeRuleRepo,
+		db:                  db,
 	}
 }
 
@@ -46,9 +50,16 @@ func (s *attendanceServiceImpl) GetAll(searchQuery schema.AttendanceSearchQuery)
 func (s *attendanceServiceImpl) GetByID(id string) (*model.Attendance, error) {
 	idUUID, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid ID")
+		return nil, common.NewFieldError("id", "invalid ID")
 	}
-	return s.attendanceRepo.GetByID(idUUID)
+	attendance, err := s.attendanceRepo.GetByID(idUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("attendance with ID '%s' not found", id)
+		}
+		return nil, fmt.Errorf("failed to get attendance: %w", err)
+	}
+	return attendance, nil
 }
 
 // Create creates a new attendance record.
@@ -65,7 +76,9 @@ func (s *attendanceServiceImpl) Create(bodyRequest *schema.AttendanceRequest) (*
 	}
 
 	attendanceModel := &model.Attendance{
-		Name: *bodyRequest.Name,
+		Name:              *bodyRequest.Name,
+		HolidayCalendarID: emptyToNil(bodyRequest.HolidayCalendarID),
+		OvertimeRuleID:    emptyToNil(bodyRequest.OvertimeRuleID),
 	}
 
 	// ใช้ Transaction
@@ -99,14 +112,14 @@ func (s *attendanceServiceImpl) Update(id string, bodyRequest *schema.Attendance
 
 	idUUID, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid ID")
+		return nil, common.NewFieldError("id", "invalid ID")
 	}
 	attendanceModel, err := s.attendanceRepo.GetByID(idUUID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get existing attendance: %w", err)
 	}
 	if attendanceModel == nil {
-		return nil, fmt.Errorf("attendance with ID '%s' not found", id)
+		return nil, common.NewNotFoundError("attendance with ID '%s' not found", id)
 	}
 
 	// Set default value
@@ -121,6 +134,8 @@ func (s *attendanceServiceImpl) Update(id string, bodyRequest *schema.Attendance
 
 	// Update model
 	attendanceModel.Name = *bodyRequest.Name
+	attendanceModel.HolidayCalendarID = emptyToNil(bodyRequest.HolidayCalendarID)
+	attendanceModel.OvertimeRuleID = emptyToNil(bodyRequest.OvertimeRuleID)
 
 	err = s.db.Transaction(func(tx *gorm.DB) error {
 		txRepo := repository.NewAttendanceRepository(tx)
@@ -158,14 +173,14 @@ func (s *attendanceServiceImpl) PartialUpdate(id string, bodyRequest *schema.Att
 
 	idUUID, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid ID")
+		return nil, common.NewFieldError("id", "invalid ID")
 	}
 	attendanceModel, err := s.attendanceRepo.GetByID(idUUID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get existing attendance: %w", err)
 	}
 	if attendanceModel == nil {
-		return nil, fmt.Errorf("attendance with ID '%s' not found", id)
+		return nil, common.NewNotFoundError("attendance with ID '%s' not found", id)
 	}
 
 	// Validate (check duplicates)
@@ -178,6 +193,12 @@ func (s *attendanceServiceImpl) PartialUpdate(id string, bodyRequest *schema.Att
 	if bodyRequest.Name != nil && *bodyRequest.Name != "" {
 		attendanceModel.Name = *bodyRequest.Name
 	}
+	if bodyRequest.HolidayCalendarID != nil {
+		attendanceModel.HolidayCalendarID = emptyToNil(bodyRequest.HolidayCalendarID)
+	}
+	if bodyRequest.OvertimeRuleID != nil {
+		attendanceModel.OvertimeRuleID = emptyToNil(bodyRequest.OvertimeRuleID)
+	}
 
 	err = s.db.Transaction(func(tx *gorm.DB) error {
 		txRepo := repository.NewAttendanceRepository(tx)
@@ -226,14 +247,14 @@ func (s *attendanceServiceImpl) PartialUpdate(id string, bodyRequest *schema.Att
 func (s *attendanceServiceImpl) Delete(id string) error {
 	idUUID, err := uuid.Parse(id)
 	if err != nil {
-		return fmt.Errorf("invalid ID")
+		return common.NewFieldError("id", "invalid ID")
 	}
 
 	// ตรวจสอบการมีอยู่ก่อนลบ (ตาม pattern ของ AccessControlGroupService)
 	_, err = s.attendanceRepo.GetByID(idUUID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return fmt.Errorf("attendance with ID '%s' not found", id)
+			return common.NewNotFoundError("attendance with ID '%s' not found", id)
 		}
 		return fmt.Errorf("failed to get attendance by ID: %w", err)
 	}
@@ -266,10 +287,21 @@ func (s *attendanceServiceImpl) ConvertToResponse(attendanceModel *model.Attenda
 		})
 	}
 
+	holidayCalendar, err := getHolidayCalendarInfo(s.holidayCalendarRepo, attendanceModel.HolidayCalendarID)
+	if err != nil {
+		return nil, err
+	}
+	overtimeRule, err := getOvertimeRuleInfo(s.overtimeRuleRepo, attendanceModel.OvertimeRuleID)
+	if err != nil {
+		return nil, err
+	}
+
 	// 2. สร้าง Response
 	response := &schema.AttendanceInfoResponse{
 		ID:                  attendanceModel.ID.String(),
 		Name:                attendanceModel.Name,
+		HolidayCalendar:     holidayCalendar,
+		OvertimeRule:        overtimeRule,
 		AttendanceSchedules: scheduleResponses,
 
 		// Note: หากมีการเพิ่มฟิลด์ AttendanceSchedules ใน schema.AttendanceInfoResponse ให้เพิ่มการ Map ที่นี่
@@ -340,12 +372,7 @@ func (s *attendanceServiceImpl) validateAndCleanSchedules(schedules []schema.Att
 	defaultZero := 0 // สำหรับสร้าง pointer ไปยัง 0
 
 	for i, schedule := range cleanedSchedules {
-		// 1. ตรวจสอบ DayOfWeek (required)
-		if schedule.DayOfWeek == nil || *schedule.DayOfWeek < 1 || *schedule.DayOfWeek > 7 {
-			return nil, fmt.Errorf("invalid day of week for schedule index %d", i)
-		}
-
-		// 2. ตรวจสอบ StartTime/EndTime (required แต่ตั้งค่า default ได้)
+		// 1. ตั้งค่า Default สำหรับ StartTime/EndTime, DayOfWeek ถูกตรวจสอบโดย validate tag แล้ว
 		if schedule.StartTime == nil || *schedule.StartTime == "" {
 			cleanedSchedules[i].StartTime = &defaultStartTime
 		}
@@ -353,7 +380,7 @@ func (s *attendanceServiceImpl) validateAndCleanSchedules(schedules []schema.Att
 			cleanedSchedules[i].EndTime = &defaultEndTime
 		}
 
-		// 3. ตั้งค่า Default สำหรับ Minutes (0 นาที ถ้าเป็น nil)
+		// 2. ตั้งค่า Default สำหรับ Minutes (0 นาที ถ้าเป็น nil)
 		if schedule.EarlyInMinutes == nil {
 			cleanedSchedules[i].EarlyInMinutes = &defaultZero
 		}
@@ -372,12 +399,7 @@ func (s *attendanceServiceImpl) validateAndCleanSchedules(schedules []schema.Att
 
 // Validate and set default values
 func (s *attendanceServiceImpl) validateAndSetDefaultValues(bodyRequest *schema.AttendanceRequest) (*schema.AttendanceRequest, error) {
-	// 1. ตรวจสอบ Name
-	if bodyRequest.Name == nil || *bodyRequest.Name == "" {
-		return nil, fmt.Errorf("attendance name cannot be empty")
-	}
-
-	// 2. ตั้งค่า Default Schedules (24/7) หากไม่ได้ส่งมา
+	// 1. ตั้งค่า Default Schedules (24/7) หากไม่ได้ส่งมา
 	if bodyRequest.AttendanceSchedule == nil {
 		defaultSchedules := []schema.AttendanceScheduleRequest{}
 		startTime := common.DefaultAttendanceStartTime
@@ -402,7 +424,7 @@ func (s *attendanceServiceImpl) validateAndSetDefaultValues(bodyRequest *schema.
 		}
 		bodyRequest.AttendanceSchedule = defaultSchedules
 	} else {
-		// 3. ถ้ามีการส่ง Schedules มา ให้ตรวจสอบและตั้งค่า Default ภายใน Array
+		// 2. ถ้ามีการส่ง Schedules มา ให้ตรวจสอบและตั้งค่า Default ภายใน Array
 		validatedSchedules, err := s.validateAndCleanSchedules(bodyRequest.AttendanceSchedule)
 		if err != nil {
 			return nil, err
@@ -421,13 +443,23 @@ func (s *attendanceServiceImpl) validateBodyRequest(bodyRequest schema.Attendanc
 		excludeID = attendanceModel.ID
 	}
 
+	if err := validateHolidayCalendarID(s.holidayCalendarRepo, bodyRequest.HolidayCalendarID); err != nil {
+		return err
+	}
+	if err := validateOvertimeRuleID(s.overtimeRuleRepo, bodyRequest.OvertimeRuleID); err != nil {
+		return err
+	}
+
 	// Check duplicate name
+	if bodyRequest.Name == nil {
+		return nil
+	}
 	isExistName, err := s.attendanceRepo.IsExistName(*bodyRequest.Name, excludeID)
 	if err != nil {
 		return fmt.Errorf("failed to check attendance name existence: %w", err)
 	}
 	if isExistName {
-		return fmt.Errorf("attendance name is already exist")
+		return common.NewConflictError("attendance name is already exist")
 	}
 
 	return nil
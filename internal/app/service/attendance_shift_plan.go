@@ -0,0 +1,269 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/repository"
+	"gorm.io/gorm"
+)
+
+// plannedShift is what a person was expected to work on one date. A nil start means a day off.
+type plannedShift struct {
+	date          time.Time
+	schedule      *model.AttendanceSchedule
+	shiftTemplate *model.ShiftTemplate
+	holiday       *model.Holiday
+	start         *time.Time
+	end           *time.Time
+	lateIn        int
+	earlyOut      int
+	earlyIn       int
+	lateOut       int
+}
+
+// shiftPlanner resolves the planned shift of people per date. Templates and rotations are
+// cached for one calculation, overrides and assignments are loaded once per person.
+type shiftPlanner struct {
+	personShiftRepo     repository.PersonShiftRepository
+	shiftRotationRepo   repository.ShiftRotationRepository
+	shiftTemplateRepo   repository.ShiftTemplateRepository
+	holidayCalendarRepo repository.HolidayCalendarRepository
+
+	templates    map[string]*model.ShiftTemplate
+	rotations    map[string]*model.ShiftRotation
+	rotationDays map[string]map[int]string
+
+	overrides   map[string]*model.PersonShiftOverride
+	assignments []model.PersonShiftAssignment
+}
+
+func newShiftPlanner(personShiftRepo repository.PersonShiftRepository, shiftRotationRepo repository.ShiftRotationRepository, shiftTemplateRepo repository.ShiftTemplateRepository, holidayCalendarRepo repository.HolidayCalendarRepository) *shiftPlanner {
+	return &shiftPlanner{
+		personShiftRepo:     personShiftRepo,
+		shiftRotationRepo:   shiftRotationRepo,
+		shiftTemplateRepo:   shiftTemplateRepo,
+		holidayCalendarRepo: holidayCalendarRepo,
+		templates:           map[string]*model.ShiftTemplate{},
+		rotations:           map[string]*model.ShiftRotation{},
+		rotationDays:        map[string]map[int]string{},
+	}
+}
+
+// loadPerson loads the shift overrides and rotation assignments of the next person to plan.
+func (p *shiftPlanner) loadPerson(personID string) error {
+	overrides, err := p.personShiftRepo.GetOverridesByPersonID(personID)
+	if err != nil {
+		return fmt.Errorf("failed to get shift overrides: %w", err)
+	}
+	p.overrides = make(map[string]*model.PersonShiftOverride, len(overrides))
+	for i := range overrides {
+		p.overrides[overrides[i].Date] = &overrides[i]
+	}
+
+	p.assignments, err = p.personShiftRepo.GetAssignmentsByPersonID(personID)
+	if err != nil {
+		return fmt.Errorf("failed to get shift assignments: %w", err)
+	}
+	return nil
+}
+
+// plan resolves the shift of the loaded person on a date. A shift override wins, then a holiday
+// of the profile's calendar, then the rotation the person is assigned to and finally the
+// schedule of the attendance profile.
+func (p *shiftPlanner) plan(profile *attendanceProfile, date time.Time) (*plannedShift, error) {
+	dateStr := date.Format(common.DateLayout)
+	planned := &plannedShift{date: date}
+
+	if override, ok := p.overrides[dateStr]; ok {
+		if override.ShiftTemplateID != nil {
+			template, err := p.getTemplate(*override.ShiftTemplateID)
+			if err != nil {
+				return nil, err
+			}
+			planned.applyTemplate(template)
+		}
+		return planned, nil
+	}
+
+	holiday, err := findHoliday(p.holidayCalendarRepo, profile.attendance.HolidayCalendarID, dateStr)
+	if err != nil {
+		return nil, err
+	}
+	if holiday != nil {
+		planned.holiday = holiday
+		if hasSpecialHours(holiday) {
+			if err := planned.applyHours(*holiday.StartTime, *holiday.EndTime); err != nil {
+				return nil, err
+			}
+		}
+		return planned, nil
+	}
+
+	if assignment := p.findAssignment(dateStr); assignment != nil {
+		templateID, err := p.getRotationShift(assignment.ShiftRotationID, date)
+		if err != nil {
+			return nil, err
+		}
+		if templateID != "" {
+			template, err := p.getTemplate(templateID)
+			if err != nil {
+				return nil, err
+			}
+			planned.applyTemplate(template)
+		}
+		return planned, nil
+	}
+
+	if schedule := pickAttendanceSchedule(profile.schedules, date); schedule != nil {
+		planned.schedule = schedule
+		if err := planned.applyHours(schedule.StartTime, schedule.EndTime); err != nil {
+			return nil, err
+		}
+		planned.earlyIn, planned.lateIn = schedule.EarlyInMinutes, schedule.LateInMinutes
+		planned.earlyOut, planned.lateOut = schedule.EarlyOutMinutes, schedule.LateOutMinutes
+	}
+	return planned, nil
+}
+
+// findAssignment returns the rotation assignment covering a date.
+func (p *shiftPlanner) findAssignment(dateStr string) *model.PersonShiftAssignment {
+	for i, assignment := range p.assignments {
+		if assignment.StartDate <= dateStr && (assignment.EndDate == nil || dateStr <= *assignment.EndDate) {
+			return &p.assignments[i]
+		}
+	}
+	return nil
+}
+
+// getRotationShift returns the shift template ID of a rotation on a date, or "" on a day off.
+func (p *shiftPlanner) getRotationShift(rotationID string, date time.Time) (string, error) {
+	rotation, ok := p.rotations[rotationID]
+	if !ok {
+		rotationUUID, err := uuid.Parse(rotationID)
+		if err != nil {
+			return "", common.NewValidationError("invalid shift rotation ID '%s'", rotationID)
+		}
+		rotation, err = p.shiftRotationRepo.GetByID(rotationUUID)
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return "", fmt.Errorf("failed to get shift rotation: %w", err)
+		}
+		days := map[int]string{}
+		if rotation != nil {
+			rotationDays, err := p.shiftRotationRepo.GetDaysByRotationID(rotationUUID)
+			if err != nil {
+				return "", fmt.Errorf("failed to get shift rotation days: %w", err)
+			}
+			for _, day := range rotationDays {
+				days[day.DayIndex] = day.ShiftTemplateID
+			}
+		}
+		p.rotations[rotationID] = rotation
+		p.rotationDays[rotationID] = days
+	}
+	if rotation == nil || rotation.CycleDays <= 0 {
+		return "", nil
+	}
+
+	anchor, err := time.ParseInLocation(common.DateLayout, rotation.AnchorDate, date.Location())
+	if err != nil {
+		return "", common.NewValidationError("invalid anchor date of shift rotation '%s'", rotation.Name)
+	}
+	// Dates before the anchor run the cycle backwards, rounding keeps days shortened or
+	// lengthened by daylight saving time whole
+	days := int(math.Round(date.Sub(anchor).Hours() / 24))
+	dayIndex := (days%rotation.CycleDays + rotation.CycleDays) % rotation.CycleDays
+	return p.rotationDays[rotationID][dayIndex], nil
+}
+
+// getTemplate returns a cached shift template.
+func (p *shiftPlanner) getTemplate(templateID string) (*model.ShiftTemplate, error) {
+	if template, ok := p.templates[templateID]; ok {
+		return template, nil
+	}
+	template, err := getExistingShiftTemplate(p.shiftTemplateRepo, templateID)
+	if err != nil {
+		return nil, err
+	}
+	p.templates[templateID] = template
+	return template, nil
+}
+
+// applyTemplate plans the hours and tolerances of a shift template.
+func (s *plannedShift) applyTemplate(template *model.ShiftTemplate) {
+	s.shiftTemplate = template
+	// Template clocks are normalized when saved
+	_ = s.applyHours(template.StartTime, template.EndTime)
+	s.earlyIn, s.lateIn = template.EarlyInMinutes, template.LateInMinutes
+	s.earlyOut, s.lateOut = template.EarlyOutMinutes, template.LateOutMinutes
+}
+
+// applyHours plans a shift from startTime to endTime, ending on the next day when it crosses midnight.
+func (s *plannedShift) applyHours(startTime string, endTime string) error {
+	start, err := clockOnDate(s.date, startTime)
+	if err != nil {
+		return err
+	}
+	end, err := clockOnDate(s.date, endTime)
+	if err != nil {
+		return err
+	}
+	if common.ShiftCrossesMidnight(normalizeClock(startTime), normalizeClock(endTime)) {
+		end = end.AddDate(0, 0, 1)
+	}
+	s.start, s.end = &start, &end
+	return nil
+}
+
+// windowStart is where the punches of the date start when no neighbouring day claims them:
+// the start of the calendar day, or earlier for a shift starting just after midnight.
+func (s *plannedShift) windowStart() time.Time {
+	if s.start == nil {
+		return s.date
+	}
+	start := s.start.Add(-time.Duration(max(s.earlyIn, common.AttendancePunchWindowMinutes)) * time.Minute)
+	if start.After(s.date) {
+		return s.date
+	}
+	return start
+}
+
+// windowEnd is where the punches of the date end when no neighbouring day claims them: the end
+// of the calendar day, or later for a shift running past midnight.
+func (s *plannedShift) windowEnd() time.Time {
+	nextDay := s.date.AddDate(0, 0, 1)
+	if s.end == nil {
+		return nextDay
+	}
+	end := s.end.Add(time.Duration(max(s.lateOut, common.AttendancePunchWindowMinutes)) * time.Minute)
+	if end.Before(nextDay) {
+		return nextDay
+	}
+	return end
+}
+
+// punchWindowBoundary is the moment punches stop belonging to prev and start belonging to next,
+// the plans of two consecutive dates. Overlapping windows are split halfway between the two
+// shifts, or given to the day that has a shift, so a night shift keeps its morning check-out.
+func punchWindowBoundary(prev *plannedShift, next *plannedShift) time.Time {
+	prevEnd, nextStart := prev.windowEnd(), next.windowStart()
+	if !prevEnd.After(nextStart) {
+		return next.date
+	}
+	switch {
+	case prev.end != nil && next.start != nil:
+		if !next.start.After(*prev.end) {
+			return *next.start
+		}
+		return prev.end.Add(next.start.Sub(*prev.end) / 2)
+	case prev.end != nil:
+		return prevEnd
+	case next.start != nil:
+		return nextStart
+	}
+	return next.date
+}
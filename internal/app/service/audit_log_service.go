@@ -0,0 +1,86 @@
+package service
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/repository"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// auditSystemUsername is the username of audit logs written by background jobs.
+const auditSystemUsername = "system"
+
+// AuditLogService defines the interface for audit log business logic.
+type AuditLogService interface {
+	GetAll(searchQuery schema.AuditLogSearchQuery) ([]model.AuditLog, error)
+	ConvertToResponse(auditLog *model.AuditLog) schema.AuditLogResponse
+}
+
+// auditLogServiceImpl is the implementation of AuditLogService.
+type auditLogServiceImpl struct {
+	auditLogRepo repository.AuditLogRepository
+}
+
+// NewAuditLogService creates a new instance of AuditLogService.
+func NewAuditLogService(auditLogRepo repository.AuditLogRepository) AuditLogService {
+	return &auditLogServiceImpl{auditLogRepo: auditLogRepo}
+}
+
+// GetAll retrieves audit logs, newest first.
+func (s *auditLogServiceImpl) GetAll(searchQuery schema.AuditLogSearchQuery) ([]model.AuditLog, error) {
+	return s.auditLogRepo.GetAll(searchQuery)
+}
+
+// ConvertToResponse converts an audit log model to a response schema.
+func (s *auditLogServiceImpl) ConvertToResponse(auditLog *model.AuditLog) schema.AuditLogResponse {
+	return schema.AuditLogResponse{
+		ID:         auditLog.ID.String(),
+		Action:     auditLog.Action,
+		EntityType: auditLog.EntityType,
+		EntityID:   auditLog.EntityID,
+		Username:   auditLog.Username,
+		Detail:     auditLog.Detail,
+		CreatedAt:  common.FormatTimestamp(auditLog.CreatedAt, nil),
+	}
+}
+
+// writeAuditLog records an action. The action already happened, so a failure is only logged.
+func writeAuditLog(auditLogRepo repository.AuditLogRepository, action string, entityType string, entityID string, username string, detail string) {
+	if username == "" {
+		username = auditSystemUsername
+	}
+	auditLog := &model.AuditLog{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   emptyToNil(&entityID),
+		Username:   username,
+		Detail:     emptyToNil(&detail),
+	}
+	if err := auditLogRepo.Create(auditLog); err != nil {
+		log.Printf("failed to write audit log '%s' of %s '%s': %v", action, entityType, entityID, err)
+	}
+}
+
+// getSystemLogUser returns the active user with the system log permission, which is required to
+// purge, export or erase personal data.
+func getSystemLogUser(userRepo repository.UserRepository, username string) (*model.User, error) {
+	user, err := userRepo.GetByUsername(username)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("user '%s' not found", username)
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	permission, err := userRepo.GetPermissionByID(user.PermissionID)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, fmt.Errorf("failed to get user permission: %w", err)
+	}
+	if user.Status != "active" || permission == nil || !permission.SystemLogPermission {
+		return nil, common.NewForbiddenError("user '%s' is not allowed to manage personal data", username)
+	}
+	return user, nil
+}
@@ -5,7 +5,9 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
 	"github.com/putteror/access-control-management/internal/app/repository"
 	"github.com/putteror/access-control-management/internal/app/schema"
 )
@@ -13,15 +15,17 @@ import (
 // AuthService handles authentication logic and JWT token management.
 type AuthService interface {
 	Login(username, password string) (string, error)
+	SwitchTenant(username string, tenantID *string) (string, error)
 }
 
 type authServiceImpl struct {
-	userRepo repository.UserRepository
+	userRepo   repository.UserRepository
+	tenantRepo repository.TenantRepository
 }
 
 // NewAuthService creates a new instance of AuthService.
-func NewAuthService(userRepo repository.UserRepository) AuthService {
-	return &authServiceImpl{userRepo: userRepo}
+func NewAuthService(userRepo repository.UserRepository, tenantRepo repository.TenantRepository) AuthService {
+	return &authServiceImpl{userRepo: userRepo, tenantRepo: tenantRepo}
 }
 
 // Login authenticates a user and generates a JWT token.
@@ -37,28 +41,80 @@ func (s *authServiceImpl) Login(username, password string) (string, error) {
 		return "", errors.New("invalid credentials")
 	}
 	if userModel.Status != "active" {
-		return "", errors.New("user is not active")
+		return "", common.NewForbiddenError("user is not active")
 	}
 	is_verified := common.VerifyHashPassword(userModel.PasswordHash, password)
 	if !is_verified {
 		return "", errors.New("invalid credentials")
 	}
+	if _, err := s.getActiveTenant(userModel.TenantID); err != nil {
+		return "", err
+	}
+
+	return s.signToken(userModel, userModel.TenantID)
+}
+
+// SwitchTenant issues a new token of a super admin for another tenant, or for the global scope
+// when tenantID is empty.
+func (s *authServiceImpl) SwitchTenant(username string, tenantID *string) (string, error) {
+	userModel, err := s.userRepo.GetByUsername(username)
+	if err != nil {
+		return "", common.NewNotFoundError("user '%s' not found", username)
+	}
+	if !userModel.IsSuperAdmin {
+		return "", common.NewForbiddenError("user '%s' is not allowed to switch tenants", username)
+	}
+	if userModel.Status != "active" {
+		return "", common.NewForbiddenError("user is not active")
+	}
+
+	if tenantID == nil || *tenantID == "" {
+		return s.signToken(userModel, "")
+	}
+	tenant, err := s.getActiveTenant(*tenantID)
+	if err != nil {
+		return "", err
+	}
+	return s.signToken(userModel, tenant.ID.String())
+}
+
+// ----------> INNER FUNCTION <-----------------------//
+
+// getActiveTenant returns a tenant that exists and is active.
+func (s *authServiceImpl) getActiveTenant(tenantID string) (*model.Tenant, error) {
+	tenantUUID, err := uuid.Parse(tenantID)
+	if err != nil {
+		return nil, common.NewNotFoundError("tenant with ID '%s' not found", tenantID)
+	}
+	tenant, err := s.tenantRepo.GetByID(tenantUUID)
+	if err != nil {
+		return nil, common.NewNotFoundError("tenant with ID '%s' not found", tenantID)
+	}
+	if !tenant.IsActive {
+		return nil, common.NewForbiddenError("tenant '%s' is not active", tenant.Name)
+	}
+	return tenant, nil
+}
 
-	// 2. Define custom claims for the JWT.
+// signToken issues a token of a user working in a tenant, the global scope when tenantID is empty.
+func (s *authServiceImpl) signToken(userModel *model.User, tenantID string) (string, error) {
+	// Define custom claims for the JWT.
 	// You can include any data you want to store in the token (e.g., user ID, roles).
 	claims := &schema.CustomClaims{
-		Username: username,
-		Role:     "admin",
+		Username:   userModel.Username,
+		Role:       "admin",
+		TenantID:   tenantID,
+		SuperAdmin: userModel.IsSuperAdmin,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 24)), // Token expires in 24 hours
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(common.JwtExpiration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	// 3. Create a new token with the defined claims.
+	// Create a new token with the defined claims.
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
-	// 4. Sign the token with the secret key.
+	// Sign the token with the secret key.
 	signedToken, err := token.SignedString(common.JwtSecret)
 	if err != nil {
 		return "", errors.New("could not sign the token")
@@ -0,0 +1,326 @@
+package service
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/repository"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// DataSubjectService handles the requests of people about their personal data: an export of
+// everything stored about them and the erasure of it. Both are written to the audit log and need
+// a user with the system log permission.
+type DataSubjectService interface {
+	Export(id string, reason string, username string, w io.Writer) error
+	Erase(id string, bodyRequest *schema.DataSubjectEraseRequest, username string) (*model.Person, error)
+	ConvertToResponse(person *model.Person) (*schema.PersonResponse, error)
+}
+
+// dataSubjectServiceImpl is the implementation of DataSubjectService.
+type dataSubjectServiceImpl struct {
+	personService            PersonService
+	personRepo               repository.PersonRepository
+	personCardRepo           repository.PersonCardRepository
+	personLicenseRepo        repository.PersonLicensePlateRepository
+	formAnswerRepo           repository.RegisterFormFieldAnswerRepository
+	accessRecordRepo         repository.AccessRecordRepository
+	attendanceRecordRepo     repository.AttendanceRecordRepository
+	leaveRequestRepo         repository.LeaveRequestRepository
+	attendanceCorrectionRepo repository.AttendanceCorrectionRepository
+	visitorVehicleRepo       repository.VisitorVehicleRepository
+	auditLogRepo             repository.AuditLogRepository
+	userRepo                 repository.UserRepository
+	fileRepo                 repository.FileRepository
+}
+
+// NewDataSubjectService creates a new instance of DataSubjectService.
+func NewDataSubjectService(
+	personService PersonService,
+	personRepo repository.PersonRepository,
+	personCardRepo repository.PersonCardRepository,
+	personLicenseRepo repository.PersonLicensePlateRepository,
+	formAnswerRepo repository.RegisterFormFieldAnswerRepository,
+	accessRecordRepo repository.AccessRecordRepository,
+	attendanceRecordRepo repository.AttendanceRecordRepository,
+	leaveRequestRepo repository.LeaveRequestRepository,
+	attendanceCorrectionRepo repository.AttendanceCorrectionRepository,
+	visitorVehicleRepo repository.VisitorVehicleRepository,
+	auditLogRepo repository.AuditLogRepository,
+	userRepo repository.UserRepository,
+	fileRepo repository.FileRepository,
+) DataSubjectService {
+	return &dataSubjectServiceImpl{
+		personService:            personService,
+		personRepo:               personRepo,
+		personCardRepo:           personCardRepo,
+		personLicenseRepo:        personLicenseRepo,
+		formAnswerRepo:           formAnswerRepo,
+		accessRecordRepo:         accessRecordRepo,
+		attendanceRecordRepo:     attendanceRecordRepo,
+		leaveRequestRepo:         leaveRequestRepo,
+		attendanceCorrectionRepo: attendanceCorrectionRepo,
+		visitorVehicleRepo:       visitorVehicleRepo,
+		auditLogRepo:             auditLogRepo,
+		userRepo:                 userRepo,
+		fileRepo:                 fileRepo,
+	}
+}
+
+// Export writes a ZIP with the profile, cards, plates, form answers, access and attendance
+// history, leave requests, corrections and images of a person to w.
+func (s *dataSubjectServiceImpl) Export(id string, reason string, username string, w io.Writer) error {
+	if _, err := getSystemLogUser(s.userRepo, username); err != nil {
+		return err
+	}
+	person, err := s.getPerson(id)
+	if err != nil {
+		return err
+	}
+	personID := person.ID.String()
+
+	profile, err := s.personService.ConvertToResponse(person)
+	if err != nil {
+		return err
+	}
+	cards, err := s.personCardRepo.GetByPersonID(personID)
+	if err != nil {
+		return fmt.Errorf("failed to get person cards: %w", err)
+	}
+	cardHistory, err := s.personCardRepo.GetHistoryByPersonID(personID)
+	if err != nil {
+		return err
+	}
+	licensePlates, err := s.personLicenseRepo.GetLicensePlateTextsByPersonID(personID)
+	if err != nil {
+		return fmt.Errorf("failed to get license plate texts: %w", err)
+	}
+	formAnswers, err := s.formAnswerRepo.GetByPersonID(personID)
+	if err != nil {
+		return err
+	}
+	accessRecords, err := s.getAccessRecords(personID)
+	if err != nil {
+		return err
+	}
+	attendanceRecords, err := s.attendanceRecordRepo.GetAll(schema.AttendanceRecordSearchQuery{PersonID: personID, All: true})
+	if err != nil {
+		return err
+	}
+	leaveRequests, err := s.leaveRequestRepo.GetByPersonID(personID)
+	if err != nil {
+		return err
+	}
+	corrections, err := s.attendanceCorrectionRepo.GetByPersonID(personID)
+	if err != nil {
+		return err
+	}
+
+	zipWriter := zip.NewWriter(w)
+	var files []string
+	writeJSON := func(name string, value interface{}) error {
+		entry, err := zipWriter.Create(name)
+		if err != nil {
+			return fmt.Errorf("failed to write data export: %w", err)
+		}
+		files = append(files, name)
+		encoder := json.NewEncoder(entry)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(value); err != nil {
+			return fmt.Errorf("failed to write data export: %w", err)
+		}
+		return nil
+	}
+
+	entries := []struct {
+		name  string
+		value interface{}
+	}{
+		{"profile.json", profile},
+		{"cards.json", cards},
+		{"card_history.json", cardHistory},
+		{"license_plates.json", licensePlates},
+		{"form_answers.json", formAnswers},
+		{"access_records.json", accessRecords},
+		{"attendance_records.json", attendanceRecords},
+		{"leave_requests.json", leaveRequests},
+		{"attendance_corrections.json", corrections},
+	}
+	for _, entry := range entries {
+		if err := writeJSON(entry.name, entry.value); err != nil {
+			return err
+		}
+	}
+
+	type image struct {
+		name     string
+		filePath *string
+	}
+	images := []image{
+		{"face_image/original" + path.Ext(stringValue(person.FaceImagePath)), person.FaceImagePath},
+		{"face_image/" + common.FaceImageNormalizedFileName, person.FaceImageNormalizedPath},
+		{"face_image/" + common.FaceImageThumbnailFileName, person.FaceImageThumbnailPath},
+	}
+	for _, record := range accessRecords {
+		if record.PlateImagePath != nil {
+			images = append(images, image{"plate_images/" + record.ID.String() + path.Ext(*record.PlateImagePath), record.PlateImagePath})
+		}
+	}
+	for _, image := range images {
+		if image.filePath == nil || *image.filePath == "" {
+			continue
+		}
+		copied, err := s.copyFile(zipWriter, image.name, *image.filePath)
+		if err != nil {
+			return err
+		}
+		if copied {
+			files = append(files, image.name)
+		}
+	}
+
+	manifest := schema.DataSubjectExportManifest{
+		PersonID:   personID,
+		ExportedAt: common.FormatTimestamp(time.Now(), nil),
+		ExportedBy: username,
+		Reason:     reason,
+		Files:      files,
+	}
+	if err := writeJSON("manifest.json", manifest); err != nil {
+		return err
+	}
+	if err := zipWriter.Close(); err != nil {
+		return fmt.Errorf("failed to write data export: %w", err)
+	}
+
+	writeAuditLog(s.auditLogRepo, common.AuditActionDataSubjectExport, common.AuditEntityPerson, personID, username, reason)
+	return nil
+}
+
+// Erase anonymizes a person. Identifying data, credentials and images are removed, while the
+// person row with its company and department, the access records and the attendance records stay
+// so statistics are unchanged. Access records are append-only, so card numbers and plates they
+// hold remain until the retention job purges them.
+//
+// The retention archives are not rewritten: records of the person purged before the erasure stay
+// in the NDJSON files under common.RetentionArchivePath, where the purged access records must keep
+// the fields their hashes cover. The audit log entry of the erasure says so.
+func (s *dataSubjectServiceImpl) Erase(id string, bodyRequest *schema.DataSubjectEraseRequest, username string) (*model.Person, error) {
+	if _, err := getSystemLogUser(s.userRepo, username); err != nil {
+		return nil, err
+	}
+	person, err := s.getPerson(id)
+	if err != nil {
+		return nil, err
+	}
+	if person.ErasedAt != nil {
+		return nil, common.NewConflictError("person is already erased")
+	}
+	reason := strings.TrimSpace(*bodyRequest.Reason)
+	if reason == "" {
+		return nil, common.NewFieldError("reason", "reason cannot be empty")
+	}
+
+	accessRecords, err := s.accessRecordRepo.GetByPersonID(person.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.personRepo.Erase(person.ID, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to erase person: %w", err)
+	}
+
+	// Images are only removed once the erasure is committed
+	for _, imagePath := range faceImagePaths(person) {
+		deleteStoredFile(s.fileRepo, imagePath)
+	}
+	var plateImagePaths []string
+	for _, record := range accessRecords {
+		if record.PlateImagePath != nil && *record.PlateImagePath != "" {
+			plateImagePaths = append(plateImagePaths, *record.PlateImagePath)
+		}
+	}
+	deletePlateImages(s.fileRepo, s.visitorVehicleRepo, plateImagePaths)
+
+	detail := fmt.Sprintf("%s (%d access records kept; records purged before the erasure remain in the retention archives under %s)", reason, len(accessRecords), common.RetentionArchivePath)
+	writeAuditLog(s.auditLogRepo, common.AuditActionDataSubjectErase, common.AuditEntityPerson, person.ID.String(), username, detail)
+
+	return s.personRepo.GetByID(person.ID)
+}
+
+// ConvertToResponse converts a person model to a response schema.
+func (s *dataSubjectServiceImpl) ConvertToResponse(person *model.Person) (*schema.PersonResponse, error) {
+	return s.personService.ConvertToResponse(person)
+}
+
+func (s *dataSubjectServiceImpl) getPerson(id string) (*model.Person, error) {
+	idUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, common.NewFieldError("id", "invalid ID")
+	}
+	person, err := s.personRepo.GetByID(idUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("person with ID '%s' not found", id)
+		}
+		return nil, fmt.Errorf("failed to get person: %w", err)
+	}
+	return person, nil
+}
+
+// getAccessRecords returns the access records of a person with their annotations.
+func (s *dataSubjectServiceImpl) getAccessRecords(personID string) ([]accessRecordWithAnnotations, error) {
+	records, err := s.accessRecordRepo.GetByPersonID(personID)
+	if err != nil {
+		return nil, err
+	}
+	recordIDs := make([]string, len(records))
+	for i := range records {
+		recordIDs[i] = records[i].ID.String()
+	}
+	annotations, err := s.accessRecordRepo.GetAnnotationsByRecordIDs(recordIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access record annotations: %w", err)
+	}
+	annotationsByRecord := make(map[string][]model.AccessRecordAnnotation)
+	for _, annotation := range annotations {
+		annotationsByRecord[annotation.AccessRecordID] = append(annotationsByRecord[annotation.AccessRecordID], annotation)
+	}
+
+	result := make([]accessRecordWithAnnotations, len(records))
+	for i, record := range records {
+		result[i] = accessRecordWithAnnotations{AccessRecord: record, Annotations: annotationsByRecord[record.ID.String()]}
+	}
+	return result, nil
+}
+
+// copyFile copies a stored file into the ZIP. A file that no longer exists is skipped.
+func (s *dataSubjectServiceImpl) copyFile(zipWriter *zip.Writer, name string, filePath string) (bool, error) {
+	file, err := s.fileRepo.Open(filePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to open file '%s': %w", filePath, err)
+	}
+	defer file.Close()
+
+	entry, err := zipWriter.Create(name)
+	if err != nil {
+		return false, fmt.Errorf("failed to write data export: %w", err)
+	}
+	if _, err := io.Copy(entry, file); err != nil {
+		return false, fmt.Errorf("failed to write data export: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/repository"
+	"gorm.io/gorm"
+)
+
+// FileService defines the interface for reading stored files.
+type FileService interface {
+	Open(filePath string) (io.ReadCloser, error)
+}
+
+type fileServiceImpl struct {
+	fileRepo           repository.FileRepository
+	personRepo         repository.PersonRepository
+	accessRecordRepo   repository.AccessRecordRepository
+	visitorVehicleRepo repository.VisitorVehicleRepository
+	db                 *gorm.DB
+}
+
+// NewFileService creates a new instance of FileService.
+func NewFileService(fileRepo repository.FileRepository, personRepo repository.PersonRepository, accessRecordRepo repository.AccessRecordRepository, visitorVehicleRepo repository.VisitorVehicleRepository, db *gorm.DB) FileService {
+	return &fileServiceImpl{
+		fileRepo:           fileRepo,
+		personRepo:         personRepo,
+		accessRecordRepo:   accessRecordRepo,
+		visitorVehicleRepo: visitorVehicleRepo,
+		db:                 db,
+	}
+}
+
+// servedFilePrefixes are the storage folders that can be downloaded through the API.
+var servedFilePrefixes = []string{"/images/"}
+
+// Open opens a stored file. Only files under servedFilePrefixes can be opened, in the global scope
+// those of every tenant and in a tenant only its own, see isTenantFile.
+func (s *fileServiceImpl) Open(filePath string) (io.ReadCloser, error) {
+	cleanPath := path.Clean("/" + filePath)
+
+	servedPath := cleanPath
+	if _, tenantPath, ok := common.SplitTenantFilePath(cleanPath); ok {
+		servedPath = tenantPath
+	}
+	allowed := false
+	for _, prefix := range servedFilePrefixes {
+		if strings.HasPrefix(servedPath, prefix) {
+			allowed = true
+			break
+		}
+	}
+	if allowed {
+		var err error
+		if allowed, err = s.isTenantFile(cleanPath); err != nil {
+			return nil, err
+		}
+	}
+	if !allowed {
+		return nil, common.NewNotFoundError("file '%s' not found", filePath)
+	}
+
+	file, err := s.fileRepo.Open(cleanPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, common.NewNotFoundError("file '%s' not found", filePath)
+		}
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	return file, nil
+}
+
+// isTenantFile reports whether a file belongs to the tenant of the session. Files are stored in
+// the folder of their tenant; files stored before tenants existed belong to the tenant of the
+// person, access record or visitor vehicle that shows them.
+func (s *fileServiceImpl) isTenantFile(filePath string) (bool, error) {
+	tenantID, allTenants, ok := common.TenantFromContext(s.db.Statement.Context)
+	if !ok {
+		return false, nil
+	}
+	if allTenants {
+		return true, nil
+	}
+	if fileTenantID, _, ok := common.SplitTenantFilePath(filePath); ok {
+		return fileTenantID == tenantID, nil
+	}
+
+	for _, isExist := range []func(string) (bool, error){
+		s.personRepo.IsExistFaceImagePath,
+		s.accessRecordRepo.IsExistPlateImagePath,
+		s.visitorVehicleRepo.IsExistImagePath,
+	} {
+		exists, err := isExist(filePath)
+		if err != nil || exists {
+			return exists, err
+		}
+	}
+	return false, nil
+}
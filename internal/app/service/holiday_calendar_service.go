@@ -0,0 +1,331 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/repository"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// HolidayCalendarService defines the interface for holiday calendar business logic.
+type HolidayCalendarService interface {
+	GetAll(searchQuery schema.HolidayCalendarSearchQuery) ([]model.HolidayCalendar, error)
+	GetByID(id string) (*model.HolidayCalendar, error)
+	Create(bodyRequest *schema.HolidayCalendarRequest) (*model.HolidayCalendar, error)
+	Update(id string, bodyRequest *schema.HolidayCalendarRequest) (*model.HolidayCalendar, error)
+	Delete(id string) error
+	Import(id string, icsFile *multipart.FileHeader, replace bool) (int, error)
+	ConvertToResponse(calendarModel *model.HolidayCalendar) (*schema.HolidayCalendarResponse, error)
+}
+
+type holidayCalendarServiceImpl struct {
+	holidayCalendarRepo repository.HolidayCalendarRepository
+	db                  *gorm.DB
+}
+
+// NewHolidayCalendarService creates a new instance of HolidayCalendarService.
+func NewHolidayCalendarService(holidayCalendarRepo repository.HolidayCalendarRepository, db *gorm.DB) HolidayCalendarService {
+	return &holidayCalendarServiceImpl{
+		holidayCalendarRepo: holidayCalendarRepo,
+		db:                  db,
+	}
+}
+
+// GetAll retrieves holiday calendars.
+func (s *holidayCalendarServiceImpl) GetAll(searchQuery schema.HolidayCalendarSearchQuery) ([]model.HolidayCalendar, error) {
+	return s.holidayCalendarRepo.GetAll(searchQuery)
+}
+
+// GetByID retrieves a holiday calendar by its ID.
+func (s *holidayCalendarServiceImpl) GetByID(id string) (*model.HolidayCalendar, error) {
+	idUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, common.NewFieldError("id", "invalid ID")
+	}
+	calendar, err := s.holidayCalendarRepo.GetByID(idUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("holiday calendar with ID '%s' not found", id)
+		}
+		return nil, fmt.Errorf("failed to get holiday calendar: %w", err)
+	}
+	return calendar, nil
+}
+
+// Create creates a holiday calendar with its holidays.
+func (s *holidayCalendarServiceImpl) Create(bodyRequest *schema.HolidayCalendarRequest) (*model.HolidayCalendar, error) {
+	if err := s.validateBodyRequest(bodyRequest, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	calendarModel := &model.HolidayCalendar{
+		Name:        *bodyRequest.Name,
+		Description: bodyRequest.Description,
+	}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		txRepo := repository.NewHolidayCalendarRepository(tx)
+		if err := txRepo.Create(calendarModel); err != nil {
+			return fmt.Errorf("failed to create holiday calendar: %w", err)
+		}
+		if err := txRepo.CreateHolidays(createHolidayModels(calendarModel.ID.String(), bodyRequest.Holidays)); err != nil {
+			return fmt.Errorf("failed to create holidays: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return calendarModel, nil
+}
+
+// Update replaces a holiday calendar and all of its holidays.
+func (s *holidayCalendarServiceImpl) Update(id string, bodyRequest *schema.HolidayCalendarRequest) (*model.HolidayCalendar, error) {
+	calendarModel, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateBodyRequest(bodyRequest, calendarModel.ID); err != nil {
+		return nil, err
+	}
+
+	calendarModel.Name = *bodyRequest.Name
+	calendarModel.Description = bodyRequest.Description
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		txRepo := repository.NewHolidayCalendarRepository(tx)
+		if err := txRepo.Update(calendarModel); err != nil {
+			return fmt.Errorf("failed to update holiday calendar: %w", err)
+		}
+		if err := txRepo.DeleteHolidaysByCalendarID(calendarModel.ID, tx); err != nil {
+			return fmt.Errorf("failed to delete old holidays: %w", err)
+		}
+		if err := txRepo.CreateHolidays(createHolidayModels(id, bodyRequest.Holidays)); err != nil {
+			return fmt.Errorf("failed to create holidays: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return calendarModel, nil
+}
+
+// Delete deletes a holiday calendar. Groups and attendance profiles using it are detached.
+func (s *holidayCalendarServiceImpl) Delete(id string) error {
+	calendarModel, err := s.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if err := s.holidayCalendarRepo.Delete(calendarModel.ID); err != nil {
+		return fmt.Errorf("failed to delete holiday calendar: %w", err)
+	}
+	return nil
+}
+
+// Import adds the events of an iCalendar (.ics) file as holidays. Events already in the
+// calendar (same name and start date) are skipped, unless replace drops the existing holidays first.
+// It returns the number of holidays added.
+func (s *holidayCalendarServiceImpl) Import(id string, icsFile *multipart.FileHeader, replace bool) (int, error) {
+	calendarModel, err := s.GetByID(id)
+	if err != nil {
+		return 0, err
+	}
+
+	src, err := icsFile.Open()
+	if err != nil {
+		return 0, fmt.Errorf("failed to open calendar file: %w", err)
+	}
+	defer src.Close()
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read calendar file: %w", err)
+	}
+	events, err := common.ParseICalendar(data)
+	if err != nil {
+		return 0, common.NewValidationError("invalid calendar file: %w", err)
+	}
+
+	existing := map[string]bool{}
+	if !replace {
+		holidays, err := s.holidayCalendarRepo.GetHolidaysByCalendarID(calendarModel.ID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get holidays: %w", err)
+		}
+		for _, holiday := range holidays {
+			existing[holiday.Name+"|"+holiday.StartDate] = true
+		}
+	}
+
+	var holidays []model.Holiday
+	for _, event := range events {
+		name := event.Summary
+		if name == "" {
+			name = "Holiday"
+		}
+		key := name + "|" + event.StartDate
+		if existing[key] {
+			continue
+		}
+		existing[key] = true
+		holidays = append(holidays, model.Holiday{
+			HolidayCalendarID: id,
+			Name:              name,
+			StartDate:         event.StartDate,
+			EndDate:           event.EndDate,
+		})
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		txRepo := repository.NewHolidayCalendarRepository(tx)
+		if replace {
+			if err := txRepo.DeleteHolidaysByCalendarID(calendarModel.ID, tx); err != nil {
+				return fmt.Errorf("failed to delete old holidays: %w", err)
+			}
+		}
+		if err := txRepo.CreateHolidays(holidays); err != nil {
+			return fmt.Errorf("failed to create holidays: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(holidays), nil
+}
+
+// ConvertToResponse converts a holiday calendar model to a response schema.
+func (s *holidayCalendarServiceImpl) ConvertToResponse(calendarModel *model.HolidayCalendar) (*schema.HolidayCalendarResponse, error) {
+	holidays, err := s.holidayCalendarRepo.GetHolidaysByCalendarID(calendarModel.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get holidays: %w", err)
+	}
+
+	holidayResponses := make([]schema.HolidayResponse, len(holidays))
+	for i, holiday := range holidays {
+		holidayResponses[i] = schema.HolidayResponse{
+			ID:        holiday.ID.String(),
+			Name:      holiday.Name,
+			StartDate: holiday.StartDate,
+			EndDate:   holiday.EndDate,
+			StartTime: holiday.StartTime,
+			EndTime:   holiday.EndTime,
+		}
+	}
+
+	return &schema.HolidayCalendarResponse{
+		ID:          calendarModel.ID.String(),
+		Name:        calendarModel.Name,
+		Description: calendarModel.Description,
+		Holidays:    holidayResponses,
+	}, nil
+}
+
+// ----------> INNER FUNCTION <-----------------------//
+
+// validateBodyRequest checks the calendar name and every holiday, and fills holiday defaults. The
+// formats and the order of the dates and times are checked by the validate tags of the request.
+func (s *holidayCalendarServiceImpl) validateBodyRequest(bodyRequest *schema.HolidayCalendarRequest, excludeID uuid.UUID) error {
+	isExistName, err := s.holidayCalendarRepo.IsExistName(*bodyRequest.Name, excludeID)
+	if err != nil {
+		return err
+	}
+	if isExistName {
+		return common.NewConflictError("holiday calendar name is already exist")
+	}
+
+	for i, holiday := range bodyRequest.Holidays {
+		if holiday.EndDate == nil || *holiday.EndDate == "" {
+			bodyRequest.Holidays[i].EndDate = holiday.StartDate
+		}
+
+		hasStartTime := holiday.StartTime != nil && *holiday.StartTime != ""
+		hasEndTime := holiday.EndTime != nil && *holiday.EndTime != ""
+		if hasStartTime != hasEndTime {
+			return common.NewValidationError("holiday index %d must have both start time and end time, or neither", i)
+		}
+		if !hasStartTime {
+			bodyRequest.Holidays[i].StartTime, bodyRequest.Holidays[i].EndTime = nil, nil
+		}
+	}
+	return nil
+}
+
+// validateHolidayCalendarID checks that the holiday calendar attached to a group or an
+// attendance profile exists. An empty ID detaches the calendar.
+func validateHolidayCalendarID(holidayCalendarRepo repository.HolidayCalendarRepository, holidayCalendarID *string) error {
+	if holidayCalendarID == nil || *holidayCalendarID == "" {
+		return nil
+	}
+	calendarUUID, err := uuid.Parse(*holidayCalendarID)
+	if err != nil {
+		return common.NewValidationError("invalid holiday calendar ID")
+	}
+	if _, err := holidayCalendarRepo.GetByID(calendarUUID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return common.NewValidationError("holiday calendar with ID '%s' does not exist", *holidayCalendarID)
+		}
+		return fmt.Errorf("failed to get holiday calendar: %w", err)
+	}
+	return nil
+}
+
+// getHolidayCalendarInfo returns the short response of an attached holiday calendar, nil when none is attached.
+func getHolidayCalendarInfo(holidayCalendarRepo repository.HolidayCalendarRepository, holidayCalendarID *string) (*schema.HolidayCalendarInfoResponse, error) {
+	if holidayCalendarID == nil || *holidayCalendarID == "" {
+		return nil, nil
+	}
+	calendarUUID, err := uuid.Parse(*holidayCalendarID)
+	if err != nil {
+		return nil, nil
+	}
+	calendar, err := holidayCalendarRepo.GetByID(calendarUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get holiday calendar: %w", err)
+	}
+	return &schema.HolidayCalendarInfoResponse{ID: calendar.ID.String(), Name: calendar.Name}, nil
+}
+
+// findHoliday returns the holiday of a calendar covering a date ("2006-01-02"), nil when there is
+// no calendar or the date is a normal day.
+func findHoliday(holidayCalendarRepo repository.HolidayCalendarRepository, holidayCalendarID *string, date string) (*model.Holiday, error) {
+	if holidayCalendarID == nil || *holidayCalendarID == "" {
+		return nil, nil
+	}
+	holiday, err := holidayCalendarRepo.GetHolidayByDate(*holidayCalendarID, date)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get holiday: %w", err)
+	}
+	return holiday, nil
+}
+
+// hasSpecialHours reports whether a holiday keeps special hours instead of closing the whole day.
+func hasSpecialHours(holiday *model.Holiday) bool {
+	return holiday.StartTime != nil && *holiday.StartTime != "" && holiday.EndTime != nil && *holiday.EndTime != ""
+}
+
+// createHolidayModels converts holiday requests into holiday models of a calendar.
+func createHolidayModels(calendarID string, holidays []schema.HolidayRequest) []model.Holiday {
+	holidayModels := make([]model.Holiday, len(holidays))
+	for i, holiday := range holidays {
+		holidayModels[i] = model.Holiday{
+			HolidayCalendarID: calendarID,
+			Name:              *holiday.Name,
+			StartDate:         *holiday.StartDate,
+			EndDate:           *holiday.EndDate,
+			StartTime:         holiday.StartTime,
+			EndTime:           holiday.EndTime,
+		}
+	}
+	return holidayModels
+}
@@ -0,0 +1,484 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/repository"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// LeaveRequestService defines the interface for leave requests, their approval and leave balances.
+type LeaveRequestService interface {
+	GetAll(searchQuery schema.LeaveRequestSearchQuery) ([]model.LeaveRequest, error)
+	GetByID(id string) (*model.LeaveRequest, error)
+	Create(bodyRequest *schema.LeaveRequestRequest, username string) (*model.LeaveRequest, error)
+	Approve(id string, bodyRequest *schema.DecisionRequest, username string) (*model.LeaveRequest, error)
+	Reject(id string, bodyRequest *schema.DecisionRequest, username string) (*model.LeaveRequest, error)
+	Cancel(id string, bodyRequest *schema.DecisionRequest, username string) (*model.LeaveRequest, error)
+	GetBalances(personID string, year string) ([]schema.LeaveBalanceResponse, error)
+	SaveBalance(personID string, bodyRequest *schema.LeaveBalanceRequest) (*schema.LeaveBalanceResponse, error)
+	ConvertToResponse(leaveRequest *model.LeaveRequest) (*schema.LeaveRequestResponse, error)
+}
+
+type leaveRequestServiceImpl struct {
+	leaveRequestRepo        repository.LeaveRequestRepository
+	leaveTypeRepo           repository.LeaveTypeRepository
+	personRepo              repository.PersonRepository
+	userRepo                repository.UserRepository
+	attendanceRepo          repository.AttendanceRepository
+	personShiftRepo         repository.PersonShiftRepository
+	shiftRotationRepo       repository.ShiftRotationRepository
+	shiftTemplateRepo       repository.ShiftTemplateRepository
+	holidayCalendarRepo     repository.HolidayCalendarRepository
+	attendanceRecordService AttendanceRecordService
+}
+
+// NewLeaveRequestService creates a new instance of LeaveRequestService.
+func NewLeaveRequestService(leaveRequestRepo repository.LeaveRequestRepository, leaveTypeRepo repository.LeaveTypeRepository, personRepo repository.PersonRepository, userRepo repository.UserRepository, attendanceRepo repository.AttendanceRepository, personShiftRepo repository.PersonShiftRepository, shiftRotationRepo repository.ShiftRotationRepository, shiftTemplateRepo repository.ShiftTemplateRepository, holidayCalendarRepo repository.HolidayCalendarRepository, attendanceRecordService AttendanceRecordService) LeaveRequestService {
+	return &leaveRequestServiceImpl{
+		leaveRequestRepo:        leaveRequestRepo,
+		leaveTypeRepo:           leaveTypeRepo,
+		personRepo:              personRepo,
+		userRepo:                userRepo,
+		attendanceRepo:          attendanceRepo,
+		personShiftRepo:         personShiftRepo,
+		shiftRotationRepo:       shiftRotationRepo,
+		shiftTemplateRepo:       shiftTemplateRepo,
+		holidayCalendarRepo:     holidayCalendarRepo,
+		attendanceRecordService: attendanceRecordService,
+	}
+}
+
+// GetAll retrieves leave requests.
+func (s *leaveRequestServiceImpl) GetAll(searchQuery schema.LeaveRequestSearchQuery) ([]model.LeaveRequest, error) {
+	return s.leaveRequestRepo.GetAll(searchQuery)
+}
+
+// GetByID retrieves a leave request by its ID.
+func (s *leaveRequestServiceImpl) GetByID(id string) (*model.LeaveRequest, error) {
+	idUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, common.NewFieldError("id", "invalid ID")
+	}
+	leaveRequest, err := s.leaveRequestRepo.GetByID(idUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("leave request with ID '%s' not found", id)
+		}
+		return nil, fmt.Errorf("failed to get leave request: %w", err)
+	}
+	return leaveRequest, nil
+}
+
+// Create files a pending leave request. The days it takes are the working days of the person in
+// the range, and the days of each calendar year must fit in the remaining balance of that year.
+func (s *leaveRequestServiceImpl) Create(bodyRequest *schema.LeaveRequestRequest, username string) (*model.LeaveRequest, error) {
+	person, err := s.getPerson(*bodyRequest.PersonID)
+	if err != nil {
+		return nil, err
+	}
+	leaveType, err := s.getLeaveType(*bodyRequest.LeaveTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	halfDay := emptyToNil(bodyRequest.HalfDay)
+	if halfDay != nil {
+		if !leaveType.AllowHalfDay {
+			return nil, common.NewValidationError("leave type '%s' cannot be taken as half day", leaveType.Name)
+		}
+		if *bodyRequest.StartDate != *bodyRequest.EndDate {
+			return nil, common.NewValidationError("half day leave must start and end on the same date")
+		}
+	}
+
+	overlapping, err := s.leaveRequestRepo.GetOverlapping(*bodyRequest.PersonID, *bodyRequest.StartDate, *bodyRequest.EndDate, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(overlapping) > 0 {
+		return nil, common.NewConflictError("leave request overlaps the leave from %s to %s", overlapping[0].StartDate, overlapping[0].EndDate)
+	}
+
+	years, err := s.countLeaveDays(person, *bodyRequest.StartDate, *bodyRequest.EndDate, halfDay != nil)
+	if err != nil {
+		return nil, err
+	}
+	var days float64
+	for _, year := range years {
+		days += year.Days
+	}
+	if days == 0 {
+		return nil, common.NewValidationError("leave request does not cover any working day")
+	}
+
+	leaveRequest := &model.LeaveRequest{
+		PersonID:    *bodyRequest.PersonID,
+		LeaveTypeID: *bodyRequest.LeaveTypeID,
+		StartDate:   *bodyRequest.StartDate,
+		EndDate:     *bodyRequest.EndDate,
+		HalfDay:     halfDay,
+		Days:        days,
+		Reason:      emptyToNil(bodyRequest.Reason),
+		Status:      common.ApprovalStatusPending,
+		RequestedBy: emptyToNil(&username),
+	}
+	if err := s.checkBalance(leaveRequest, years, leaveType, []string{common.ApprovalStatusApproved, common.ApprovalStatusPending}); err != nil {
+		return nil, err
+	}
+	if err := s.leaveRequestRepo.Create(leaveRequest, years); err != nil {
+		return nil, fmt.Errorf("failed to create leave request: %w", err)
+	}
+	return leaveRequest, nil
+}
+
+// Approve approves a pending leave request and recalculates the attendance it covers.
+func (s *leaveRequestServiceImpl) Approve(id string, bodyRequest *schema.DecisionRequest, username string) (*model.LeaveRequest, error) {
+	leaveRequest, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if leaveRequest.Status != common.ApprovalStatusPending {
+		return nil, common.NewConflictError("only pending leave requests can be approved, this one is %s", leaveRequest.Status)
+	}
+	approver, err := getAttendanceApprover(s.userRepo, username)
+	if err != nil {
+		return nil, err
+	}
+	leaveType, err := s.getLeaveType(leaveRequest.LeaveTypeID)
+	if err != nil {
+		return nil, err
+	}
+	years, err := s.leaveRequestRepo.GetYears(leaveRequest.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkBalance(leaveRequest, years, leaveType, []string{common.ApprovalStatusApproved}); err != nil {
+		return nil, err
+	}
+
+	if err := s.decide(leaveRequest, common.ApprovalStatusApproved, approver, bodyRequest); err != nil {
+		return nil, err
+	}
+	if err := recalculateAttendance(s.personRepo, s.attendanceRecordService, leaveRequest.PersonID, leaveRequest.StartDate, leaveRequest.EndDate); err != nil {
+		return nil, err
+	}
+	return leaveRequest, nil
+}
+
+// Reject rejects a pending leave request.
+func (s *leaveRequestServiceImpl) Reject(id string, bodyRequest *schema.DecisionRequest, username string) (*model.LeaveRequest, error) {
+	leaveRequest, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if leaveRequest.Status != common.ApprovalStatusPending {
+		return nil, common.NewConflictError("only pending leave requests can be rejected, this one is %s", leaveRequest.Status)
+	}
+	approver, err := getAttendanceApprover(s.userRepo, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.decide(leaveRequest, common.ApprovalStatusRejected, approver, bodyRequest); err != nil {
+		return nil, err
+	}
+	return leaveRequest, nil
+}
+
+// Cancel withdraws a pending or approved leave request. Cancelling approved leave gives the days
+// back to the balance, needs the same permission as approving and recalculates the attendance.
+func (s *leaveRequestServiceImpl) Cancel(id string, bodyRequest *schema.DecisionRequest, username string) (*model.LeaveRequest, error) {
+	leaveRequest, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var user *model.User
+	switch leaveRequest.Status {
+	case common.ApprovalStatusPending:
+		user, err = s.userRepo.GetByUsername(username)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, common.NewNotFoundError("user '%s' not found", username)
+			}
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+	case common.ApprovalStatusApproved:
+		user, err = getAttendanceApprover(s.userRepo, username)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, common.NewConflictError("only pending or approved leave requests can be cancelled, this one is %s", leaveRequest.Status)
+	}
+
+	wasApproved := leaveRequest.Status == common.ApprovalStatusApproved
+	if err := s.decide(leaveRequest, common.ApprovalStatusCancelled, user, bodyRequest); err != nil {
+		return nil, err
+	}
+	if wasApproved {
+		if err := recalculateAttendance(s.personRepo, s.attendanceRecordService, leaveRequest.PersonID, leaveRequest.StartDate, leaveRequest.EndDate); err != nil {
+			return nil, err
+		}
+	}
+	return leaveRequest, nil
+}
+
+// GetBalances returns the balance of every leave type of a person in a year, the current year by default.
+func (s *leaveRequestServiceImpl) GetBalances(personID string, year string) ([]schema.LeaveBalanceResponse, error) {
+	if _, err := s.getPerson(personID); err != nil {
+		return nil, err
+	}
+	balanceYear := time.Now().Year()
+	if year != "" {
+		parsedYear, err := strconv.Atoi(year)
+		if err != nil || parsedYear < 1900 {
+			return nil, common.NewValidationError("invalid year")
+		}
+		balanceYear = parsedYear
+	}
+
+	leaveTypes, err := s.leaveTypeRepo.GetAll(schema.LeaveTypeSearchQuery{Page: 1, Limit: -1})
+	if err != nil {
+		return nil, err
+	}
+	balances, err := s.leaveRequestRepo.GetBalancesByPersonAndYear(personID, balanceYear)
+	if err != nil {
+		return nil, err
+	}
+	entitled := make(map[string]float64, len(balances))
+	for _, balance := range balances {
+		entitled[balance.LeaveTypeID] = balance.EntitledDays
+	}
+
+	responses := make([]schema.LeaveBalanceResponse, 0, len(leaveTypes))
+	for _, leaveType := range leaveTypes {
+		entitledDays, ok := entitled[leaveType.ID.String()]
+		if !ok {
+			entitledDays = leaveType.DaysPerYear
+		}
+		response, err := s.buildBalanceResponse(personID, &leaveType, balanceYear, entitledDays)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, *response)
+	}
+	return responses, nil
+}
+
+// SaveBalance sets the entitlement of a person for a leave type in a year.
+func (s *leaveRequestServiceImpl) SaveBalance(personID string, bodyRequest *schema.LeaveBalanceRequest) (*schema.LeaveBalanceResponse, error) {
+	if _, err := s.getPerson(personID); err != nil {
+		return nil, err
+	}
+	leaveType, err := s.getLeaveType(*bodyRequest.LeaveTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := s.leaveRequestRepo.GetBalance(personID, *bodyRequest.LeaveTypeID, *bodyRequest.Year)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, fmt.Errorf("failed to get leave balance: %w", err)
+	}
+	if balance == nil {
+		balance = &model.LeaveBalance{PersonID: personID, LeaveTypeID: *bodyRequest.LeaveTypeID, Year: *bodyRequest.Year}
+	}
+	balance.EntitledDays = *bodyRequest.EntitledDays
+	if err := s.leaveRequestRepo.SaveBalance(balance); err != nil {
+		return nil, fmt.Errorf("failed to save leave balance: %w", err)
+	}
+	return s.buildBalanceResponse(personID, leaveType, balance.Year, balance.EntitledDays)
+}
+
+// ConvertToResponse converts a leave request model to a response schema.
+func (s *leaveRequestServiceImpl) ConvertToResponse(leaveRequest *model.LeaveRequest) (*schema.LeaveRequestResponse, error) {
+	response := &schema.LeaveRequestResponse{
+		ID:           leaveRequest.ID.String(),
+		StartDate:    leaveRequest.StartDate,
+		EndDate:      leaveRequest.EndDate,
+		HalfDay:      leaveRequest.HalfDay,
+		Days:         leaveRequest.Days,
+		Reason:       leaveRequest.Reason,
+		Status:       leaveRequest.Status,
+		RequestedBy:  leaveRequest.RequestedBy,
+		DecidedAt:    formatOptionalTime(leaveRequest.DecidedAt),
+		DecisionNote: leaveRequest.DecisionNote,
+	}
+
+	if personUUID, err := uuid.Parse(leaveRequest.PersonID); err == nil {
+		person, err := s.personRepo.GetByID(personUUID)
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("failed to get person: %w", err)
+		}
+		if person != nil {
+			response.Person = convertPersonToInfoResponse(person)
+		}
+	}
+
+	leaveType, err := getLeaveTypeInfo(s.leaveTypeRepo, leaveRequest.LeaveTypeID)
+	if err != nil {
+		return nil, err
+	}
+	response.LeaveType = leaveType
+
+	decidedBy, err := getUsername(s.userRepo, leaveRequest.DecidedByUserID)
+	if err != nil {
+		return nil, err
+	}
+	response.DecidedBy = decidedBy
+	return response, nil
+}
+
+// ----------> INNER FUNCTION <-----------------------//
+
+// getPerson loads the person of a leave request.
+func (s *leaveRequestServiceImpl) getPerson(personID string) (*model.Person, error) {
+	personUUID, err := uuid.Parse(personID)
+	if err != nil {
+		return nil, common.NewValidationError("invalid person ID")
+	}
+	person, err := s.personRepo.GetByID(personUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("person with ID '%s' not found", personID)
+		}
+		return nil, fmt.Errorf("failed to get person: %w", err)
+	}
+	return person, nil
+}
+
+// getLeaveType loads the leave type referenced by a request.
+func (s *leaveRequestServiceImpl) getLeaveType(leaveTypeID string) (*model.LeaveType, error) {
+	leaveTypeUUID, err := uuid.Parse(leaveTypeID)
+	if err != nil {
+		return nil, common.NewValidationError("invalid leave type ID")
+	}
+	leaveType, err := s.leaveTypeRepo.GetByID(leaveTypeUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewValidationError("leave type with ID '%s' does not exist", leaveTypeID)
+		}
+		return nil, fmt.Errorf("failed to get leave type: %w", err)
+	}
+	return leaveType, nil
+}
+
+// decide stores the new status of a leave request with who decided it and when.
+func (s *leaveRequestServiceImpl) decide(leaveRequest *model.LeaveRequest, status string, user *model.User, bodyRequest *schema.DecisionRequest) error {
+	now := time.Now()
+	userID := user.ID.String()
+	leaveRequest.Status = status
+	leaveRequest.DecidedByUserID = &userID
+	leaveRequest.DecidedAt = &now
+	leaveRequest.DecisionNote = emptyToNil(bodyRequest.Note)
+	if err := s.leaveRequestRepo.Update(leaveRequest); err != nil {
+		return fmt.Errorf("failed to update leave request: %w", err)
+	}
+	return nil
+}
+
+// checkBalance makes sure the days of a request in each year fit in the remaining balance of
+// that year, counting the other requests in the given statuses.
+func (s *leaveRequestServiceImpl) checkBalance(leaveRequest *model.LeaveRequest, years []model.LeaveRequestYear, leaveType *model.LeaveType, statuses []string) error {
+	for _, year := range years {
+		entitledDays, err := s.getEntitledDays(leaveRequest.PersonID, leaveType, year.Year)
+		if err != nil {
+			return err
+		}
+		takenDays, err := s.leaveRequestRepo.SumDays(leaveRequest.PersonID, leaveRequest.LeaveTypeID, year.Year, statuses, leaveRequest.ID)
+		if err != nil {
+			return err
+		}
+		if takenDays+year.Days > entitledDays {
+			return common.NewConflictError("not enough %s balance: %.1f of %.1f days remaining in %d", leaveType.Name, max(entitledDays-takenDays, 0), entitledDays, year.Year)
+		}
+	}
+	return nil
+}
+
+// getEntitledDays returns the entitlement of a person, falling back to the leave type's default.
+func (s *leaveRequestServiceImpl) getEntitledDays(personID string, leaveType *model.LeaveType, year int) (float64, error) {
+	balance, err := s.leaveRequestRepo.GetBalance(personID, leaveType.ID.String(), year)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return 0, fmt.Errorf("failed to get leave balance: %w", err)
+	}
+	if balance == nil {
+		return leaveType.DaysPerYear, nil
+	}
+	return balance.EntitledDays, nil
+}
+
+// buildBalanceResponse totals the used and pending days of a leave type in a year.
+func (s *leaveRequestServiceImpl) buildBalanceResponse(personID string, leaveType *model.LeaveType, year int, entitledDays float64) (*schema.LeaveBalanceResponse, error) {
+	usedDays, err := s.leaveRequestRepo.SumDays(personID, leaveType.ID.String(), year, []string{common.ApprovalStatusApproved}, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	pendingDays, err := s.leaveRequestRepo.SumDays(personID, leaveType.ID.String(), year, []string{common.ApprovalStatusPending}, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.LeaveBalanceResponse{
+		LeaveType:     &schema.LeaveTypeInfoResponse{ID: leaveType.ID.String(), Name: leaveType.Name},
+		Year:          year,
+		EntitledDays:  entitledDays,
+		UsedDays:      usedDays,
+		PendingDays:   pendingDays,
+		RemainingDays: entitledDays - usedDays,
+	}, nil
+}
+
+// countLeaveDays counts the days a leave takes by calendar year, in year order: the dates the
+// person is planned to work, by shift or attendance schedule. People without an attendance profile
+// count every date.
+func (s *leaveRequestServiceImpl) countLeaveDays(person *model.Person, startDateStr string, endDateStr string, halfDay bool) ([]model.LeaveRequestYear, error) {
+	startDate, endDate, err := parseAttendanceDateRange(startDateStr, endDateStr)
+	if err != nil {
+		return nil, err
+	}
+
+	var profile *attendanceProfile
+	if person.TimeAttendanceID != nil && *person.TimeAttendanceID != "" {
+		profile, err = getAttendanceProfile(s.attendanceRepo, *person.TimeAttendanceID, map[string]*attendanceProfile{})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	planner := newShiftPlanner(s.personShiftRepo, s.shiftRotationRepo, s.shiftTemplateRepo, s.holidayCalendarRepo)
+	if profile != nil {
+		if err := planner.loadPerson(person.ID.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	var years []model.LeaveRequestYear
+	for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+		if profile != nil {
+			planned, err := planner.plan(profile, date)
+			if err != nil {
+				return nil, err
+			}
+			if planned.start == nil {
+				continue
+			}
+		}
+		if len(years) == 0 || years[len(years)-1].Year != date.Year() {
+			years = append(years, model.LeaveRequestYear{Year: date.Year()})
+		}
+		years[len(years)-1].Days++
+	}
+	if halfDay {
+		for i := range years {
+			years[i].Days /= 2
+		}
+	}
+	return years, nil
+}
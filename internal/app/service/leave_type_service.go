@@ -0,0 +1,153 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/repository"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// LeaveTypeService defines the interface for leave type business logic.
+type LeaveTypeService interface {
+	GetAll(searchQuery schema.LeaveTypeSearchQuery) ([]model.LeaveType, error)
+	GetByID(id string) (*model.LeaveType, error)
+	Create(bodyRequest *schema.LeaveTypeRequest) (*model.LeaveType, error)
+	Update(id string, bodyRequest *schema.LeaveTypeRequest) (*model.LeaveType, error)
+	Delete(id string) error
+	ConvertToResponse(leaveTypeModel *model.LeaveType) *schema.LeaveTypeResponse
+}
+
+type leaveTypeServiceImpl struct {
+	leaveTypeRepo repository.LeaveTypeRepository
+}
+
+// NewLeaveTypeService creates a new instance of LeaveTypeService.
+func NewLeaveTypeService(leaveTypeRepo repository.LeaveTypeRepository) LeaveTypeService {
+	return &leaveTypeServiceImpl{leaveTypeRepo: leaveTypeRepo}
+}
+
+// GetAll retrieves leave types.
+func (s *leaveTypeServiceImpl) GetAll(searchQuery schema.LeaveTypeSearchQuery) ([]model.LeaveType, error) {
+	return s.leaveTypeRepo.GetAll(searchQuery)
+}
+
+// GetByID retrieves a leave type by its ID.
+func (s *leaveTypeServiceImpl) GetByID(id string) (*model.LeaveType, error) {
+	idUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, common.NewFieldError("id", "invalid ID")
+	}
+	leaveType, err := s.leaveTypeRepo.GetByID(idUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("leave type with ID '%s' not found", id)
+		}
+		return nil, fmt.Errorf("failed to get leave type: %w", err)
+	}
+	return leaveType, nil
+}
+
+// Create creates a leave type.
+func (s *leaveTypeServiceImpl) Create(bodyRequest *schema.LeaveTypeRequest) (*model.LeaveType, error) {
+	if err := s.validateBodyRequest(bodyRequest, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	leaveTypeModel := &model.LeaveType{AllowHalfDay: true}
+	applyLeaveTypeRequest(leaveTypeModel, bodyRequest)
+	if err := s.leaveTypeRepo.Create(leaveTypeModel); err != nil {
+		return nil, fmt.Errorf("failed to create leave type: %w", err)
+	}
+	return leaveTypeModel, nil
+}
+
+// Update replaces a leave type. Balances set per person are kept.
+func (s *leaveTypeServiceImpl) Update(id string, bodyRequest *schema.LeaveTypeRequest) (*model.LeaveType, error) {
+	leaveTypeModel, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateBodyRequest(bodyRequest, leaveTypeModel.ID); err != nil {
+		return nil, err
+	}
+
+	applyLeaveTypeRequest(leaveTypeModel, bodyRequest)
+	if err := s.leaveTypeRepo.Update(leaveTypeModel); err != nil {
+		return nil, fmt.Errorf("failed to update leave type: %w", err)
+	}
+	return leaveTypeModel, nil
+}
+
+// Delete deletes a leave type no leave was requested for.
+func (s *leaveTypeServiceImpl) Delete(id string) error {
+	leaveTypeModel, err := s.GetByID(id)
+	if err != nil {
+		return err
+	}
+	isInUse, err := s.leaveTypeRepo.IsInUse(leaveTypeModel.ID)
+	if err != nil {
+		return err
+	}
+	if isInUse {
+		return common.NewConflictError("leave type '%s' is used by leave requests", leaveTypeModel.Name)
+	}
+	if err := s.leaveTypeRepo.Delete(leaveTypeModel.ID); err != nil {
+		return fmt.Errorf("failed to delete leave type: %w", err)
+	}
+	return nil
+}
+
+// ConvertToResponse converts a leave type model to a response schema.
+func (s *leaveTypeServiceImpl) ConvertToResponse(leaveTypeModel *model.LeaveType) *schema.LeaveTypeResponse {
+	return &schema.LeaveTypeResponse{
+		ID:           leaveTypeModel.ID.String(),
+		Name:         leaveTypeModel.Name,
+		Description:  leaveTypeModel.Description,
+		DaysPerYear:  leaveTypeModel.DaysPerYear,
+		AllowHalfDay: leaveTypeModel.AllowHalfDay,
+	}
+}
+
+// ----------> INNER FUNCTION <-----------------------//
+
+// validateBodyRequest checks the name of a leave type.
+func (s *leaveTypeServiceImpl) validateBodyRequest(bodyRequest *schema.LeaveTypeRequest, excludeID uuid.UUID) error {
+	isExistName, err := s.leaveTypeRepo.IsExistName(*bodyRequest.Name, excludeID)
+	if err != nil {
+		return err
+	}
+	if isExistName {
+		return common.NewConflictError("leave type name is already exist")
+	}
+	return nil
+}
+
+// applyLeaveTypeRequest copies a validated request into a leave type model.
+func applyLeaveTypeRequest(leaveTypeModel *model.LeaveType, bodyRequest *schema.LeaveTypeRequest) {
+	leaveTypeModel.Name = *bodyRequest.Name
+	leaveTypeModel.Description = emptyToNil(bodyRequest.Description)
+	leaveTypeModel.DaysPerYear = *bodyRequest.DaysPerYear
+	if bodyRequest.AllowHalfDay != nil {
+		leaveTypeModel.AllowHalfDay = *bodyRequest.AllowHalfDay
+	}
+}
+
+// getLeaveTypeInfo returns the short response of a leave type, nil when it no longer exists.
+func getLeaveTypeInfo(leaveTypeRepo repository.LeaveTypeRepository, leaveTypeID string) (*schema.LeaveTypeInfoResponse, error) {
+	leaveTypeUUID, err := uuid.Parse(leaveTypeID)
+	if err != nil {
+		return nil, nil
+	}
+	leaveType, err := leaveTypeRepo.GetByID(leaveTypeUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get leave type: %w", err)
+	}
+	return &schema.LeaveTypeInfoResponse{ID: leaveType.ID.String(), Name: leaveType.Name}, nil
+}
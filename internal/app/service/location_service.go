@@ -0,0 +1,439 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/repository"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// LocationService defines the interface for the location hierarchy of sites, buildings, floors,
+// zones and doors that devices are placed at.
+type LocationService interface {
+	GetAll(searchQuery schema.LocationSearchQuery) ([]model.Location, error)
+	GetByID(id string) (*model.Location, error)
+	Create(bodyRequest *schema.LocationRequest) (*model.Location, error)
+	Update(id string, bodyRequest *schema.LocationRequest) (*model.Location, error)
+	Delete(id string) error
+	GetTree() ([]schema.LocationTreeResponse, error)
+	GetOccupancy(id string, query schema.LocationOccupancyQuery) (*schema.LocationOccupancyResponse, error)
+	ConvertToResponse(locationModel *model.Location) (*schema.LocationResponse, error)
+}
+
+type locationServiceImpl struct {
+	locationRepo            repository.LocationRepository
+	accessControlDeviceRepo repository.AccessControlDeviceRepository
+	accessRecordRepo        repository.AccessRecordRepository
+	personRepo              repository.PersonRepository
+}
+
+// NewLocationService creates a new instance of LocationService.
+func NewLocationService(locationRepo repository.LocationRepository, accessControlDeviceRepo repository.AccessControlDeviceRepository, accessRecordRepo repository.AccessRecordRepository, personRepo repository.PersonRepository) LocationService {
+	return &locationServiceImpl{
+		locationRepo:            locationRepo,
+		accessControlDeviceRepo: accessControlDeviceRepo,
+		accessRecordRepo:        accessRecordRepo,
+		personRepo:              personRepo,
+	}
+}
+
+// GetAll retrieves locations.
+func (s *locationServiceImpl) GetAll(searchQuery schema.LocationSearchQuery) ([]model.Location, error) {
+	return s.locationRepo.GetAll(searchQuery)
+}
+
+// GetByID retrieves a location by its ID.
+func (s *locationServiceImpl) GetByID(id string) (*model.Location, error) {
+	return getLocation(s.locationRepo, id)
+}
+
+// Create creates a location.
+func (s *locationServiceImpl) Create(bodyRequest *schema.LocationRequest) (*model.Location, error) {
+	if err := s.validateBodyRequest(bodyRequest, nil); err != nil {
+		return nil, err
+	}
+
+	locationModel := &model.Location{}
+	applyLocationRequest(locationModel, bodyRequest)
+	if err := s.locationRepo.Create(locationModel); err != nil {
+		return nil, fmt.Errorf("failed to create location: %w", err)
+	}
+	return locationModel, nil
+}
+
+// Update replaces a location, moving it with everything below it when the parent changes.
+func (s *locationServiceImpl) Update(id string, bodyRequest *schema.LocationRequest) (*model.Location, error) {
+	locationModel, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateBodyRequest(bodyRequest, locationModel); err != nil {
+		return nil, err
+	}
+
+	applyLocationRequest(locationModel, bodyRequest)
+	if err := s.locationRepo.Update(locationModel); err != nil {
+		return nil, fmt.Errorf("failed to update location: %w", err)
+	}
+	return locationModel, nil
+}
+
+// Delete deletes a location without child locations, devices or access control groups.
+func (s *locationServiceImpl) Delete(id string) error {
+	locationModel, err := s.GetByID(id)
+	if err != nil {
+		return err
+	}
+	isInUse, err := s.locationRepo.IsInUse(locationModel.ID)
+	if err != nil {
+		return err
+	}
+	if isInUse {
+		return common.NewConflictError("location '%s' still has locations, devices or access control groups", locationModel.Name)
+	}
+	if err := s.locationRepo.Delete(locationModel.ID); err != nil {
+		return fmt.Errorf("failed to delete location: %w", err)
+	}
+	return nil
+}
+
+// GetTree returns every location nested under its parent with the devices placed at it.
+func (s *locationServiceImpl) GetTree() ([]schema.LocationTreeResponse, error) {
+	locations, err := s.locationRepo.GetAllLocations()
+	if err != nil {
+		return nil, err
+	}
+	locationIDs := make([]string, len(locations))
+	children := make(map[string][]model.Location)
+	var roots []model.Location
+	for i, location := range locations {
+		locationIDs[i] = location.ID.String()
+		if location.ParentID == nil {
+			roots = append(roots, location)
+			continue
+		}
+		children[*location.ParentID] = append(children[*location.ParentID], location)
+	}
+
+	devices, err := s.accessControlDeviceRepo.GetByLocationIDs(locationIDs)
+	if err != nil {
+		return nil, err
+	}
+	devicesByLocation := make(map[string][]schema.AccessControlDeviceInfoResponse)
+	for _, device := range devices {
+		devicesByLocation[*device.LocationID] = append(devicesByLocation[*device.LocationID], schema.AccessControlDeviceInfoResponse{
+			ID:          device.ID.String(),
+			Name:        device.Name,
+			HostAddress: device.HostAddress,
+		})
+	}
+
+	var buildNodes func(locations []model.Location) []schema.LocationTreeResponse
+	buildNodes = func(locations []model.Location) []schema.LocationTreeResponse {
+		nodes := make([]schema.LocationTreeResponse, len(locations))
+		for i, location := range locations {
+			nodeDevices := devicesByLocation[location.ID.String()]
+			if nodeDevices == nil {
+				nodeDevices = []schema.AccessControlDeviceInfoResponse{}
+			}
+			nodes[i] = schema.LocationTreeResponse{
+				ID:                   location.ID.String(),
+				Name:                 location.Name,
+				Type:                 location.Type,
+				AccessControlDevices: nodeDevices,
+				Children:             buildNodes(children[location.ID.String()]),
+			}
+		}
+		return nodes
+	}
+	return buildNodes(roots), nil
+}
+
+// GetOccupancy counts the people inside a location: people whose last successful access since
+// the start of the count was an entry at a device at the location or below it. Leaving through
+// any device, also one outside the location, ends the stay.
+func (s *locationServiceImpl) GetOccupancy(id string, query schema.LocationOccupancyQuery) (*schema.LocationOccupancyResponse, error) {
+	locationModel, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := locationTimeZone(s.locationRepo, &id)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().In(loc)
+	since := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if query.Since != "" {
+		since, err = common.ParseTimestamp(query.Since, loc)
+		if err != nil {
+			return nil, common.NewValidationError("invalid since '%s', expected an RFC 3339 timestamp", query.Since)
+		}
+	}
+
+	locationIDs, err := s.locationRepo.GetSubtreeIDs([]string{locationModel.ID.String()})
+	if err != nil {
+		return nil, err
+	}
+	devices, err := s.accessControlDeviceRepo.GetByLocationIDs(locationIDs)
+	if err != nil {
+		return nil, err
+	}
+	devicesByID := make(map[string]model.AccessControlDevice, len(devices))
+	for _, device := range devices {
+		devicesByID[device.ID.String()] = device
+	}
+
+	latestRecords, err := s.accessRecordRepo.GetLatestByPerson(since)
+	if err != nil {
+		return nil, err
+	}
+	people := []schema.LocationOccupantResponse{}
+	for _, record := range latestRecords {
+		if record.Type != "in" || record.AccessControlDeviceID == nil {
+			continue
+		}
+		device, ok := devicesByID[*record.AccessControlDeviceID]
+		if !ok {
+			continue
+		}
+		personUUID, err := uuid.Parse(*record.PersonID)
+		if err != nil {
+			continue
+		}
+		person, err := s.personRepo.GetByID(personUUID)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get person: %w", err)
+		}
+		people = append(people, schema.LocationOccupantResponse{
+			Person: &schema.AccessRecordPersonResponse{
+				ID:          person.ID.String(),
+				FirstName:   person.FirstName,
+				LastName:    person.LastName,
+				Company:     person.Company,
+				Department:  person.Department,
+				JobPosition: person.JobPosition,
+			},
+			AccessControlDevice: &schema.AccessRecordDeviceResponse{
+				ID:          device.ID.String(),
+				Name:        device.Name,
+				HostAddress: device.HostAddress,
+				Type:        device.Type,
+			},
+			EnteredAt: common.FormatTimestamp(record.AccessTime, loc),
+		})
+	}
+
+	locationInfo, err := getLocationInfo(s.locationRepo, &id)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.LocationOccupancyResponse{
+		Location:  *locationInfo,
+		Since:     common.FormatTimestamp(since, loc),
+		Occupancy: len(people),
+		People:    people,
+	}, nil
+}
+
+// ConvertToResponse converts a location model to a response schema.
+func (s *locationServiceImpl) ConvertToResponse(locationModel *model.Location) (*schema.LocationResponse, error) {
+	path, err := locationPath(s.locationRepo, locationModel)
+	if err != nil {
+		return nil, err
+	}
+	parent, err := getLocationInfo(s.locationRepo, locationModel.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.LocationResponse{
+		ID:          locationModel.ID.String(),
+		Name:        locationModel.Name,
+		Type:        locationModel.Type,
+		Path:        path,
+		Parent:      parent,
+		Description: locationModel.Description,
+		TimeZone:    locationModel.TimeZone,
+	}, nil
+}
+
+// ----------> INNER FUNCTION <-----------------------//
+
+// validateBodyRequest checks the type, the parent and the name of a location. locationModel is
+// the location being updated, nil on create.
+func (s *locationServiceImpl) validateBodyRequest(bodyRequest *schema.LocationRequest, locationModel *model.Location) error {
+	level := common.LocationTypeLevel(*bodyRequest.Type)
+
+	parentID := emptyToNil(bodyRequest.ParentID)
+	if parentID != nil {
+		parent, err := getLocation(s.locationRepo, *parentID)
+		if err != nil {
+			return err
+		}
+		if common.LocationTypeLevel(parent.Type) >= level {
+			return common.NewValidationError("a %s cannot be placed in a %s", *bodyRequest.Type, parent.Type)
+		}
+	}
+
+	excludeID := uuid.Nil
+	if locationModel != nil {
+		excludeID = locationModel.ID
+		subtreeIDs, err := s.locationRepo.GetSubtreeIDs([]string{locationModel.ID.String()})
+		if err != nil {
+			return err
+		}
+		if parentID != nil && slices.Contains(subtreeIDs, *parentID) {
+			return common.NewValidationError("location cannot be moved into itself or a location below it")
+		}
+		children, err := s.locationRepo.GetAll(schema.LocationSearchQuery{ParentID: locationModel.ID.String(), Page: 1, Limit: -1})
+		if err != nil {
+			return err
+		}
+		for _, child := range children {
+			if common.LocationTypeLevel(child.Type) <= level {
+				return common.NewConflictError("location '%s' has a %s below it and cannot become a %s", locationModel.Name, child.Type, *bodyRequest.Type)
+			}
+		}
+	}
+
+	name := strings.TrimSpace(*bodyRequest.Name)
+	isExist, err := s.locationRepo.IsExistName(name, parentID, excludeID)
+	if err != nil {
+		return err
+	}
+	if isExist {
+		return common.NewConflictError("location with name '%s' already exists at this level", name)
+	}
+	return nil
+}
+
+// applyLocationRequest copies a request onto a location model.
+func applyLocationRequest(locationModel *model.Location, bodyRequest *schema.LocationRequest) {
+	locationModel.Name = strings.TrimSpace(*bodyRequest.Name)
+	locationModel.Type = *bodyRequest.Type
+	locationModel.ParentID = emptyToNil(bodyRequest.ParentID)
+	locationModel.Description = bodyRequest.Description
+	locationModel.TimeZone = emptyToNil(bodyRequest.TimeZone)
+}
+
+// getLocation retrieves a location by its ID for the services that reference locations.
+func getLocation(locationRepo repository.LocationRepository, id string) (*model.Location, error) {
+	locationUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, common.NewValidationError("invalid location ID")
+	}
+	location, err := locationRepo.GetByID(locationUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("location with ID '%s' not found", id)
+		}
+		return nil, fmt.Errorf("failed to get location: %w", err)
+	}
+	return location, nil
+}
+
+// getLocationInfo returns the short response of an attached location, nil when none is attached.
+func getLocationInfo(locationRepo repository.LocationRepository, locationID *string) (*schema.LocationInfoResponse, error) {
+	if locationID == nil || *locationID == "" {
+		return nil, nil
+	}
+	locationUUID, err := uuid.Parse(*locationID)
+	if err != nil {
+		return nil, nil
+	}
+	location, err := locationRepo.GetByID(locationUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get location: %w", err)
+	}
+	path, err := locationPath(locationRepo, location)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.LocationInfoResponse{
+		ID:   location.ID.String(),
+		Name: location.Name,
+		Type: location.Type,
+		Path: path,
+	}, nil
+}
+
+// locationPath returns the names of a location and its parents from the top, such as
+// "HQ / Tower A / Floor 3".
+func locationPath(locationRepo repository.LocationRepository, location *model.Location) (string, error) {
+	names := []string{location.Name}
+	seen := map[uuid.UUID]bool{location.ID: true}
+	for parentID := location.ParentID; parentID != nil; {
+		parentUUID, err := uuid.Parse(*parentID)
+		if err != nil {
+			break
+		}
+		parent, err := locationRepo.GetByID(parentUUID)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				break
+			}
+			return "", fmt.Errorf("failed to get location: %w", err)
+		}
+		if seen[parent.ID] {
+			break
+		}
+		seen[parent.ID] = true
+		names = append([]string{parent.Name}, names...)
+		parentID = parent.ParentID
+	}
+	return strings.Join(names, common.LocationPathSeparator), nil
+}
+
+// deviceTimeZone returns the time zone schedules are evaluated in at a device: its own, else the
+// nearest one of its location and the locations above it, else the time zone of the server.
+func deviceTimeZone(locationRepo repository.LocationRepository, device *model.AccessControlDevice) (*time.Location, error) {
+	if device.TimeZone != nil && *device.TimeZone != "" {
+		loc, err := common.LoadTimeZone(device.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load time zone of device '%s': %w", device.Name, err)
+		}
+		return loc, nil
+	}
+	return locationTimeZone(locationRepo, device.LocationID)
+}
+
+// locationTimeZone returns the nearest time zone of a location and the locations above it, the
+// time zone of the server when none of them has one.
+func locationTimeZone(locationRepo repository.LocationRepository, locationID *string) (*time.Location, error) {
+	seen := map[string]bool{}
+	for locationID != nil && *locationID != "" && !seen[*locationID] {
+		seen[*locationID] = true
+		locationUUID, err := uuid.Parse(*locationID)
+		if err != nil {
+			break
+		}
+		location, err := locationRepo.GetByID(locationUUID)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				break
+			}
+			return nil, fmt.Errorf("failed to get location: %w", err)
+		}
+		if location.TimeZone != nil && *location.TimeZone != "" {
+			loc, err := common.LoadTimeZone(location.TimeZone)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load time zone of location '%s': %w", location.Name, err)
+			}
+			return loc, nil
+		}
+		locationID = location.ParentID
+	}
+	return time.Local, nil
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/repository"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// OvertimeRuleService defines the interface for overtime rule business logic.
+type OvertimeRuleService interface {
+	GetAll(searchQuery schema.OvertimeRuleSearchQuery) ([]model.OvertimeRule, error)
+	GetByID(id string) (*model.OvertimeRule, error)
+	Create(bodyRequest *schema.OvertimeRuleRequest) (*model.OvertimeRule, error)
+	Update(id string, bodyRequest *schema.OvertimeRuleRequest) (*model.OvertimeRule, error)
+	Delete(id string) error
+	ConvertToResponse(ruleModel *model.OvertimeRule) *schema.OvertimeRuleResponse
+}
+
+type overtimeRuleServiceImpl struct {
+	overtimeRuleRepo repository.OvertimeRuleRepository
+}
+
+// NewOvertimeRuleService creates a new instance of OvertimeRuleService.
+func NewOvertimeRuleService(overtimeRuleRepo repository.OvertimeRuleRepository) OvertimeRuleService {
+	return &overtimeRuleServiceImpl{overtimeRuleRepo: overtimeRuleRepo}
+}
+
+// GetAll retrieves overtime rules.
+func (s *overtimeRuleServiceImpl) GetAll(searchQuery schema.OvertimeRuleSearchQuery) ([]model.OvertimeRule, error) {
+	return s.overtimeRuleRepo.GetAll(searchQuery)
+}
+
+// GetByID retrieves an overtime rule by its ID.
+func (s *overtimeRuleServiceImpl) GetByID(id string) (*model.OvertimeRule, error) {
+	idUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, common.NewFieldError("id", "invalid ID")
+	}
+	rule, err := s.overtimeRuleRepo.GetByID(idUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("overtime rule with ID '%s' not found", id)
+		}
+		return nil, fmt.Errorf("failed to get overtime rule: %w", err)
+	}
+	return rule, nil
+}
+
+// Create creates an overtime rule.
+func (s *overtimeRuleServiceImpl) Create(bodyRequest *schema.OvertimeRuleRequest) (*model.OvertimeRule, error) {
+	if err := s.validateBodyRequest(bodyRequest, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	ruleModel := &model.OvertimeRule{}
+	applyOvertimeRuleRequest(ruleModel, bodyRequest)
+	if err := s.overtimeRuleRepo.Create(ruleModel); err != nil {
+		return nil, fmt.Errorf("failed to create overtime rule: %w", err)
+	}
+	return ruleModel, nil
+}
+
+// Update replaces an overtime rule. Attendance already calculated keeps its overtime until it is recalculated.
+func (s *overtimeRuleServiceImpl) Update(id string, bodyRequest *schema.OvertimeRuleRequest) (*model.OvertimeRule, error) {
+	ruleModel, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateBodyRequest(bodyRequest, ruleModel.ID); err != nil {
+		return nil, err
+	}
+
+	applyOvertimeRuleRequest(ruleModel, bodyRequest)
+	if err := s.overtimeRuleRepo.Update(ruleModel); err != nil {
+		return nil, fmt.Errorf("failed to update overtime rule: %w", err)
+	}
+	return ruleModel, nil
+}
+
+// Delete deletes an overtime rule. Attendance profiles using it stop calculating overtime.
+func (s *overtimeRuleServiceImpl) Delete(id string) error {
+	ruleModel, err := s.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if err := s.overtimeRuleRepo.Delete(ruleModel.ID); err != nil {
+		return fmt.Errorf("failed to delete overtime rule: %w", err)
+	}
+	return nil
+}
+
+// ConvertToResponse converts an overtime rule model to a response schema.
+func (s *overtimeRuleServiceImpl) ConvertToResponse(ruleModel *model.OvertimeRule) *schema.OvertimeRuleResponse {
+	return &schema.OvertimeRuleResponse{
+		ID:                ruleModel.ID.String(),
+		Name:              ruleModel.Name,
+		MinimumMinutes:    ruleModel.MinimumMinutes,
+		RoundingMinutes:   ruleModel.RoundingMinutes,
+		DailyCapMinutes:   ruleModel.DailyCapMinutes,
+		WeekendDays:       ruleModel.WeekendDays,
+		WeekdayMultiplier: ruleModel.WeekdayMultiplier,
+		WeekendMultiplier: ruleModel.WeekendMultiplier,
+		HolidayMultiplier: ruleModel.HolidayMultiplier,
+	}
+}
+
+// ----------> INNER FUNCTION <-----------------------//
+
+// validateBodyRequest checks the name and the weekend days of an overtime rule.
+func (s *overtimeRuleServiceImpl) validateBodyRequest(bodyRequest *schema.OvertimeRuleRequest, excludeID uuid.UUID) error {
+	isExistName, err := s.overtimeRuleRepo.IsExistName(*bodyRequest.Name, excludeID)
+	if err != nil {
+		return err
+	}
+	if isExistName {
+		return common.NewConflictError("overtime rule name is already exist")
+	}
+	if bodyRequest.WeekendDays != nil {
+		if _, ok := common.ParseWeekendDays(*bodyRequest.WeekendDays); !ok {
+			return common.NewValidationError("weekend days must be a comma separated list of days from 1 (Monday) to 7 (Sunday)")
+		}
+	}
+	return nil
+}
+
+// applyOvertimeRuleRequest copies a validated request into an overtime rule model.
+func applyOvertimeRuleRequest(ruleModel *model.OvertimeRule, bodyRequest *schema.OvertimeRuleRequest) {
+	ruleModel.Name = *bodyRequest.Name
+	ruleModel.MinimumMinutes = intValue(bodyRequest.MinimumMinutes)
+	ruleModel.RoundingMinutes = intValue(bodyRequest.RoundingMinutes)
+	ruleModel.DailyCapMinutes = intValue(bodyRequest.DailyCapMinutes)
+	ruleModel.WeekendDays = common.DefaultOvertimeWeekendDays
+	if bodyRequest.WeekendDays != nil {
+		ruleModel.WeekendDays = *bodyRequest.WeekendDays
+	}
+	ruleModel.WeekdayMultiplier = *bodyRequest.WeekdayMultiplier
+	ruleModel.WeekendMultiplier = *bodyRequest.WeekendMultiplier
+	ruleModel.HolidayMultiplier = *bodyRequest.HolidayMultiplier
+}
+
+// validateOvertimeRuleID checks that an overtime rule referenced by a request exists.
+func validateOvertimeRuleID(overtimeRuleRepo repository.OvertimeRuleRepository, overtimeRuleID *string) error {
+	if overtimeRuleID == nil || *overtimeRuleID == "" {
+		return nil
+	}
+	ruleUUID, err := uuid.Parse(*overtimeRuleID)
+	if err != nil {
+		return common.NewValidationError("invalid overtime rule ID")
+	}
+	if _, err := overtimeRuleRepo.GetByID(ruleUUID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return common.NewValidationError("overtime rule with ID '%s' does not exist", *overtimeRuleID)
+		}
+		return fmt.Errorf("failed to get overtime rule: %w", err)
+	}
+	return nil
+}
+
+// getOvertimeRuleInfo returns the short response of an attached overtime rule, nil when none is attached.
+func getOvertimeRuleInfo(overtimeRuleRepo repository.OvertimeRuleRepository, overtimeRuleID *string) (*schema.OvertimeRuleInfoResponse, error) {
+	rule, err := findOvertimeRule(overtimeRuleRepo, overtimeRuleID)
+	if err != nil || rule == nil {
+		return nil, err
+	}
+	return &schema.OvertimeRuleInfoResponse{ID: rule.ID.String(), Name: rule.Name}, nil
+}
+
+// findOvertimeRule loads an attached overtime rule, nil when none is attached or it no longer exists.
+func findOvertimeRule(overtimeRuleRepo repository.OvertimeRuleRepository, overtimeRuleID *string) (*model.OvertimeRule, error) {
+	if overtimeRuleID == nil || *overtimeRuleID == "" {
+		return nil, nil
+	}
+	ruleUUID, err := uuid.Parse(*overtimeRuleID)
+	if err != nil {
+		return nil, nil
+	}
+	rule, err := overtimeRuleRepo.GetByID(ruleUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get overtime rule: %w", err)
+	}
+	return rule, nil
+}
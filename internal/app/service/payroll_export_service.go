@@ -0,0 +1,499 @@
+package service
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/repository"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// PayrollExportService generates payroll input files from calculated attendance and locks their periods.
+type PayrollExportService interface {
+	GetAll(searchQuery schema.PayrollExportSearchQuery) ([]model.PayrollExport, error)
+	GetByID(id string) (*model.PayrollExport, error)
+	Generate(bodyRequest *schema.PayrollExportRequest, username string) (*model.PayrollExport, error)
+	Open(id string) (*model.PayrollExport, io.ReadCloser, error)
+	Lock(id string, username string) (*model.PayrollExport, error)
+	Delete(id string) error
+	GetChanges(id string) ([]schema.PayrollExportChangeResponse, error)
+	ConvertToResponse(export *model.PayrollExport) (*schema.PayrollExportResponse, error)
+}
+
+type payrollExportServiceImpl struct {
+	payrollExportRepo         repository.PayrollExportRepository
+	payrollExportTemplateRepo repository.PayrollExportTemplateRepository
+	attendanceRecordRepo      repository.AttendanceRecordRepository
+	personRepo                repository.PersonRepository
+	userRepo                  repository.UserRepository
+	fileRepo                  repository.FileRepository
+	db                        *gorm.DB
+}
+
+// payrollPerson is a person with the payroll totals of the export period.
+type payrollPerson struct {
+	person *model.Person
+	totals *model.PayrollExportLine
+}
+
+// NewPayrollExportService creates a new instance of PayrollExportService.
+func NewPayrollExportService(payrollExportRepo repository.PayrollExportRepository, payrollExportTemplateRepo repository.PayrollExportTemplateRepository, attendanceRecordRepo repository.AttendanceRecordRepository, personRepo repository.PersonRepository, userRepo repository.UserRepository, fileRepo repository.FileRepository, db *gorm.DB) PayrollExportService {
+	return &payrollExportServiceImpl{
+		payrollExportRepo:         payrollExportRepo,
+		payrollExportTemplateRepo: payrollExportTemplateRepo,
+		attendanceRecordRepo:      attendanceRecordRepo,
+		personRepo:                personRepo,
+		userRepo:                  userRepo,
+		fileRepo:                  fileRepo,
+		db:                        db,
+	}
+}
+
+// GetAll retrieves generated payroll exports.
+func (s *payrollExportServiceImpl) GetAll(searchQuery schema.PayrollExportSearchQuery) ([]model.PayrollExport, error) {
+	return s.payrollExportRepo.GetAll(searchQuery)
+}
+
+// GetByID retrieves a payroll export by its ID.
+func (s *payrollExportServiceImpl) GetByID(id string) (*model.PayrollExport, error) {
+	idUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, common.NewFieldError("id", "invalid ID")
+	}
+	export, err := s.payrollExportRepo.GetByID(idUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("payroll export with ID '%s' not found", id)
+		}
+		return nil, fmt.Errorf("failed to get payroll export: %w", err)
+	}
+	return export, nil
+}
+
+// Generate writes the payroll file of a period with the layout of a template, one row per person
+// of the company with calculated attendance in the period. The totals of every row are kept to
+// detect later changes. A period that overlaps a locked export of the same template is closed.
+func (s *payrollExportServiceImpl) Generate(bodyRequest *schema.PayrollExportRequest, username string) (*model.PayrollExport, error) {
+	template, err := getPayrollExportTemplate(s.payrollExportTemplateRepo, *bodyRequest.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := s.payrollExportTemplateRepo.GetColumnsByTemplateID(template.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payroll export columns: %w", err)
+	}
+	if _, _, err := parseAttendanceDateRange(*bodyRequest.StartDate, *bodyRequest.EndDate); err != nil {
+		return nil, err
+	}
+	company := template.Company
+	if emptyToNil(bodyRequest.Company) != nil {
+		company = bodyRequest.Company
+	}
+
+	locked, err := s.payrollExportRepo.GetLockedOverlapping(template.ID.String(), company, *bodyRequest.StartDate, *bodyRequest.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	if locked != nil {
+		return nil, common.NewConflictError("period overlaps the locked payroll export of %s to %s", locked.StartDate, locked.EndDate)
+	}
+
+	people, err := s.getPayrollPeople(*bodyRequest.StartDate, *bodyRequest.EndDate, company)
+	if err != nil {
+		return nil, err
+	}
+	content, err := renderPayrollFile(template, columns, people, *bodyRequest.StartDate, *bodyRequest.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	extension := ".csv"
+	if template.Format == common.PayrollFormatFixedWidth {
+		extension = ".txt"
+	}
+	fileName := fmt.Sprintf("payroll_%s_%s%s", *bodyRequest.StartDate, *bodyRequest.EndDate, extension)
+	filePath, err := s.fileRepo.SaveReader(bytes.NewReader(content), fileName, path.Join(common.PayrollExportPath, *bodyRequest.StartDate))
+	if err != nil {
+		return nil, fmt.Errorf("failed to save payroll file: %w", err)
+	}
+
+	export := &model.PayrollExport{
+		TemplateID:   template.ID.String(),
+		TemplateName: template.Name,
+		Company:      company,
+		StartDate:    *bodyRequest.StartDate,
+		EndDate:      *bodyRequest.EndDate,
+		FileName:     fileName,
+		FilePath:     filePath,
+		Rows:         len(people),
+		Status:       common.PayrollExportStatusOpen,
+		GeneratedBy:  username,
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		txRepo := repository.NewPayrollExportRepository(tx)
+		if err := txRepo.Create(export); err != nil {
+			return fmt.Errorf("failed to create payroll export: %w", err)
+		}
+		lines := make([]model.PayrollExportLine, len(people))
+		for i, person := range people {
+			lines[i] = *person.totals
+			lines[i].ExportID = export.ID.String()
+		}
+		if err := txRepo.CreateLines(lines); err != nil {
+			return fmt.Errorf("failed to create payroll export lines: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		s.deleteFile(filePath)
+		return nil, err
+	}
+	return export, nil
+}
+
+// Open opens the generated file of a payroll export.
+func (s *payrollExportServiceImpl) Open(id string) (*model.PayrollExport, io.ReadCloser, error) {
+	export, err := s.GetByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	file, err := s.fileRepo.Open(export.FilePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil, common.NewNotFoundError("payroll file of export '%s' not found", id)
+		}
+		return nil, nil, fmt.Errorf("failed to open payroll file: %w", err)
+	}
+	return export, file, nil
+}
+
+// Lock closes the period of an export. Only users with the time attendance permission may lock.
+func (s *payrollExportServiceImpl) Lock(id string, username string) (*model.PayrollExport, error) {
+	export, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if export.Status != common.PayrollExportStatusOpen {
+		return nil, common.NewConflictError("payroll export is already locked")
+	}
+	user, err := getAttendanceApprover(s.userRepo, username)
+	if err != nil {
+		return nil, err
+	}
+	locked, err := s.payrollExportRepo.GetLockedOverlapping(export.TemplateID, export.Company, export.StartDate, export.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	if locked != nil {
+		return nil, common.NewConflictError("period overlaps the locked payroll export of %s to %s", locked.StartDate, locked.EndDate)
+	}
+
+	now := time.Now()
+	userID := user.ID.String()
+	export.Status = common.PayrollExportStatusLocked
+	export.LockedByUserID = &userID
+	export.LockedAt = &now
+	if err := s.payrollExportRepo.Update(export); err != nil {
+		return nil, fmt.Errorf("failed to update payroll export: %w", err)
+	}
+	return export, nil
+}
+
+// Delete deletes an open export and its file. Locked exports are kept.
+func (s *payrollExportServiceImpl) Delete(id string) error {
+	export, err := s.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if export.Status == common.PayrollExportStatusLocked {
+		return common.NewConflictError("locked payroll exports cannot be deleted")
+	}
+	if err := s.payrollExportRepo.Delete(export.ID); err != nil {
+		return fmt.Errorf("failed to delete payroll export: %w", err)
+	}
+	s.deleteFile(export.FilePath)
+	return nil
+}
+
+// GetChanges compares the exported totals with the current attendance of the period, listing
+// every person whose totals differ, including people who were not exported at all.
+func (s *payrollExportServiceImpl) GetChanges(id string) ([]schema.PayrollExportChangeResponse, error) {
+	export, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := s.payrollExportRepo.GetLinesByExportID(export.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payroll export lines: %w", err)
+	}
+	people, err := s.getPayrollPeople(export.StartDate, export.EndDate, export.Company)
+	if err != nil {
+		return nil, err
+	}
+
+	current := map[string]*payrollPerson{}
+	for i := range people {
+		current[people[i].totals.PersonID] = &people[i]
+	}
+	changes := []schema.PayrollExportChangeResponse{}
+	for i := range lines {
+		line := &lines[i]
+		now, ok := current[line.PersonID]
+		delete(current, line.PersonID)
+		if ok && payrollTotalsEqual(line, now.totals) {
+			continue
+		}
+		change := schema.PayrollExportChangeResponse{Exported: convertPayrollTotalsToResponse(line)}
+		if ok {
+			change.Person = convertPersonToInfoResponse(now.person)
+			change.Current = convertPayrollTotalsToResponse(now.totals)
+		} else if personUUID, err := uuid.Parse(line.PersonID); err == nil {
+			person, err := s.personRepo.GetByID(personUUID)
+			if err != nil && err != gorm.ErrRecordNotFound {
+				return nil, fmt.Errorf("failed to get person: %w", err)
+			}
+			if person != nil {
+				change.Person = convertPersonToInfoResponse(person)
+			}
+		}
+		changes = append(changes, change)
+	}
+	for _, person := range people {
+		if _, ok := current[person.totals.PersonID]; ok {
+			changes = append(changes, schema.PayrollExportChangeResponse{
+				Person:  convertPersonToInfoResponse(person.person),
+				Current: convertPayrollTotalsToResponse(person.totals),
+			})
+		}
+	}
+	return changes, nil
+}
+
+// ConvertToResponse converts a payroll export model to a response schema.
+func (s *payrollExportServiceImpl) ConvertToResponse(export *model.PayrollExport) (*schema.PayrollExportResponse, error) {
+	lockedBy, err := getUsername(s.userRepo, export.LockedByUserID)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.PayrollExportResponse{
+		ID:               export.ID.String(),
+		TemplateID:       export.TemplateID,
+		TemplateName:     export.TemplateName,
+		Company:          export.Company,
+		StartDate:        export.StartDate,
+		EndDate:          export.EndDate,
+		FileName:         export.FileName,
+		Rows:             export.Rows,
+		Status:           export.Status,
+		GeneratedBy:      export.GeneratedBy,
+		GeneratedAt:      common.FormatTimestamp(export.CreatedAt, nil),
+		LockedBy:         lockedBy,
+		LockedAt:         formatOptionalTime(export.LockedAt),
+		ChangedAfterLock: export.ChangedAfterLock,
+		ChangedAt:        formatOptionalTime(export.ChangedAt),
+	}, nil
+}
+
+// ----------> INNER FUNCTION <-----------------------//
+
+// getPayrollPeople totals the calculated attendance of a period per person, limited to the people
+// of a company when one is given, ordered by employee ID and name.
+func (s *payrollExportServiceImpl) getPayrollPeople(startDate string, endDate string, company *string) ([]payrollPerson, error) {
+	records, err := s.attendanceRecordRepo.GetAll(schema.AttendanceRecordSearchQuery{
+		StartDate: startDate,
+		EndDate:   endDate,
+		All:       true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totals := map[string]*model.PayrollExportLine{}
+	var personIDs []string
+	for _, record := range records {
+		line, ok := totals[record.PersonID]
+		if !ok {
+			line = &model.PayrollExportLine{PersonID: record.PersonID}
+			totals[record.PersonID] = line
+			personIDs = append(personIDs, record.PersonID)
+		}
+		addPayrollRecord(line, &record)
+	}
+
+	people := make([]payrollPerson, 0, len(personIDs))
+	for _, personID := range personIDs {
+		personUUID, err := uuid.Parse(personID)
+		if err != nil {
+			continue
+		}
+		person, err := s.personRepo.GetByID(personUUID)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get person: %w", err)
+		}
+		if company != nil && stringValue(person.Company) != *company {
+			continue
+		}
+		line := totals[personID]
+		line.WeightedOvertimeHours = math.Round(line.WeightedOvertimeHours*100) / 100
+		people = append(people, payrollPerson{person: person, totals: line})
+	}
+
+	slices.SortFunc(people, func(a, b payrollPerson) int {
+		return cmp.Or(
+			cmp.Compare(stringValue(a.person.PersonID), stringValue(b.person.PersonID)),
+			cmp.Compare(a.person.FirstName, b.person.FirstName),
+			cmp.Compare(a.person.LastName, b.person.LastName),
+		)
+	})
+	return people, nil
+}
+
+// addPayrollRecord adds one attendance record to the payroll totals of its person. Only approved
+// overtime is paid.
+func addPayrollRecord(line *model.PayrollExportLine, record *model.AttendanceRecord) {
+	if record.CheckInAt != nil {
+		line.WorkedDays++
+	}
+	if record.Status == common.AttendanceStatusAbsent {
+		line.AbsentDays++
+	}
+	line.WorkedMinutes += record.WorkedMinutes
+	line.LateMinutes += record.LateMinutes
+	line.EarlyLeaveMinutes += record.EarlyLeaveMinutes
+	line.LeaveDays += record.LeaveDays
+	if stringValue(record.OvertimeStatus) == common.ApprovalStatusApproved {
+		line.OvertimeMinutes += record.ApprovedOvertimeMinutes
+		line.WeightedOvertimeHours += float64(record.ApprovedOvertimeMinutes) * record.OvertimeMultiplier / 60
+	}
+}
+
+// renderPayrollFile writes the rows of a payroll file in the layout of a template.
+func renderPayrollFile(template *model.PayrollExportTemplate, columns []model.PayrollExportColumn, people []payrollPerson, startDate string, endDate string) ([]byte, error) {
+	dateLayout, ok := common.ConvertPayrollDateFormat(template.DateFormat)
+	if !ok {
+		dateLayout = common.DateLayout
+	}
+	periodStart, _ := time.Parse(common.DateLayout, startDate)
+	periodEnd, _ := time.Parse(common.DateLayout, endDate)
+
+	var rows [][]string
+	if template.IncludeHeader {
+		header := make([]string, len(columns))
+		for i, column := range columns {
+			header[i] = column.Header
+		}
+		rows = append(rows, header)
+	}
+	for _, person := range people {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			row[i] = payrollFieldValue(column.Field, &person, periodStart.Format(dateLayout), periodEnd.Format(dateLayout))
+		}
+		rows = append(rows, row)
+	}
+
+	var buffer bytes.Buffer
+	if template.Format == common.PayrollFormatFixedWidth {
+		layout := make([]common.PayrollColumn, len(columns))
+		for i, column := range columns {
+			layout[i] = common.PayrollColumn{Header: column.Header, Width: column.Width, Align: column.Align}
+		}
+		if err := common.WritePayrollFixedWidth(&buffer, layout, rows); err != nil {
+			return nil, err
+		}
+		return buffer.Bytes(), nil
+	}
+	if err := common.WritePayrollCSV(&buffer, template.Delimiter, rows); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// payrollFieldValue formats one field of a payroll row. Hours have two decimals.
+func payrollFieldValue(field string, person *payrollPerson, periodStart string, periodEnd string) string {
+	totals := person.totals
+	switch field {
+	case common.PayrollFieldEmployeeID:
+		return stringValue(person.person.PersonID)
+	case common.PayrollFieldFirstName:
+		return person.person.FirstName
+	case common.PayrollFieldLastName:
+		return person.person.LastName
+	case common.PayrollFieldCompany:
+		return stringValue(person.person.Company)
+	case common.PayrollFieldDepartment:
+		return stringValue(person.person.Department)
+	case common.PayrollFieldPeriodStart:
+		return periodStart
+	case common.PayrollFieldPeriodEnd:
+		return periodEnd
+	case common.PayrollFieldWorkedDays:
+		return strconv.Itoa(totals.WorkedDays)
+	case common.PayrollFieldWorkedHours:
+		return strconv.FormatFloat(float64(totals.WorkedMinutes)/60, 'f', 2, 64)
+	case common.PayrollFieldAbsentDays:
+		return strconv.Itoa(totals.AbsentDays)
+	case common.PayrollFieldLateMinutes:
+		return strconv.Itoa(totals.LateMinutes)
+	case common.PayrollFieldEarlyLeaveMinutes:
+		return strconv.Itoa(totals.EarlyLeaveMinutes)
+	case common.PayrollFieldLeaveDays:
+		return strconv.FormatFloat(totals.LeaveDays, 'f', -1, 64)
+	case common.PayrollFieldOvertimeHours:
+		return strconv.FormatFloat(float64(totals.OvertimeMinutes)/60, 'f', 2, 64)
+	case common.PayrollFieldWeightedOvertimeHours:
+		return strconv.FormatFloat(totals.WeightedOvertimeHours, 'f', 2, 64)
+	}
+	return ""
+}
+
+// payrollTotalsEqual reports whether two payroll totals of a person are the same.
+func payrollTotalsEqual(a *model.PayrollExportLine, b *model.PayrollExportLine) bool {
+	return a.WorkedDays == b.WorkedDays &&
+		a.WorkedMinutes == b.WorkedMinutes &&
+		a.AbsentDays == b.AbsentDays &&
+		a.LateMinutes == b.LateMinutes &&
+		a.EarlyLeaveMinutes == b.EarlyLeaveMinutes &&
+		a.LeaveDays == b.LeaveDays &&
+		a.OvertimeMinutes == b.OvertimeMinutes &&
+		a.WeightedOvertimeHours == b.WeightedOvertimeHours
+}
+
+// convertPayrollTotalsToResponse converts payroll totals to a response schema.
+func convertPayrollTotalsToResponse(line *model.PayrollExportLine) *schema.PayrollTotalsResponse {
+	return &schema.PayrollTotalsResponse{
+		WorkedDays:            line.WorkedDays,
+		WorkedMinutes:         line.WorkedMinutes,
+		AbsentDays:            line.AbsentDays,
+		LateMinutes:           line.LateMinutes,
+		EarlyLeaveMinutes:     line.EarlyLeaveMinutes,
+		LeaveDays:             line.LeaveDays,
+		OvertimeMinutes:       line.OvertimeMinutes,
+		WeightedOvertimeHours: line.WeightedOvertimeHours,
+	}
+}
+
+// deleteFile removes a stored payroll file. Failures are only logged because the database no
+// longer references the file.
+func (s *payrollExportServiceImpl) deleteFile(filePath string) {
+	if err := s.fileRepo.Delete(filePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("failed to delete payroll file '%s': %v", filePath, err)
+	}
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/repository"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// PayrollExportTemplateService defines the interface for payroll export template business logic.
+type PayrollExportTemplateService interface {
+	GetAll(searchQuery schema.PayrollExportTemplateSearchQuery) ([]model.PayrollExportTemplate, error)
+	GetByID(id string) (*model.PayrollExportTemplate, error)
+	Create(bodyRequest *schema.PayrollExportTemplateRequest) (*model.PayrollExportTemplate, error)
+	Update(id string, bodyRequest *schema.PayrollExportTemplateRequest) (*model.PayrollExportTemplate, error)
+	Delete(id string) error
+	ConvertToResponse(templateModel *model.PayrollExportTemplate) (*schema.PayrollExportTemplateResponse, error)
+}
+
+type payrollExportTemplateServiceImpl struct {
+	payrollExportTemplateRepo repository.PayrollExportTemplateRepository
+	db                        *gorm.DB
+}
+
+// NewPayrollExportTemplateService creates a new instance of PayrollExportTemplateService.
+func NewPayrollExportTemplateService(payrollExportTemplateRepo repository.PayrollExportTemplateRepository, db *gorm.DB) PayrollExportTemplateService {
+	return &payrollExportTemplateServiceImpl{
+		payrollExportTemplateRepo: payrollExportTemplateRepo,
+		db:                        db,
+	}
+}
+
+// GetAll retrieves payroll export templates.
+func (s *payrollExportTemplateServiceImpl) GetAll(searchQuery schema.PayrollExportTemplateSearchQuery) ([]model.PayrollExportTemplate, error) {
+	return s.payrollExportTemplateRepo.GetAll(searchQuery)
+}
+
+// GetByID retrieves a payroll export template by its ID.
+func (s *payrollExportTemplateServiceImpl) GetByID(id string) (*model.PayrollExportTemplate, error) {
+	return getPayrollExportTemplate(s.payrollExportTemplateRepo, id)
+}
+
+// Create creates a payroll export template with its columns.
+func (s *payrollExportTemplateServiceImpl) Create(bodyRequest *schema.PayrollExportTemplateRequest) (*model.PayrollExportTemplate, error) {
+	if err := s.validateBodyRequest(bodyRequest, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	templateModel := &model.PayrollExportTemplate{}
+	applyPayrollExportTemplateRequest(templateModel, bodyRequest)
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		txRepo := repository.NewPayrollExportTemplateRepository(tx)
+		if err := txRepo.Create(templateModel); err != nil {
+			return fmt.Errorf("failed to create payroll export template: %w", err)
+		}
+		if err := txRepo.CreateColumns(createPayrollExportColumnModels(templateModel.ID.String(), bodyRequest.Columns)); err != nil {
+			return fmt.Errorf("failed to create payroll export columns: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return templateModel, nil
+}
+
+// Update replaces a payroll export template and its columns. Files already generated keep their layout.
+func (s *payrollExportTemplateServiceImpl) Update(id string, bodyRequest *schema.PayrollExportTemplateRequest) (*model.PayrollExportTemplate, error) {
+	templateModel, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateBodyRequest(bodyRequest, templateModel.ID); err != nil {
+		return nil, err
+	}
+
+	applyPayrollExportTemplateRequest(templateModel, bodyRequest)
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		txRepo := repository.NewPayrollExportTemplateRepository(tx)
+		if err := txRepo.Update(templateModel); err != nil {
+			return fmt.Errorf("failed to update payroll export template: %w", err)
+		}
+		if err := txRepo.DeleteColumnsByTemplateID(templateModel.ID, tx); err != nil {
+			return fmt.Errorf("failed to delete old payroll export columns: %w", err)
+		}
+		if err := txRepo.CreateColumns(createPayrollExportColumnModels(id, bodyRequest.Columns)); err != nil {
+			return fmt.Errorf("failed to create payroll export columns: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return templateModel, nil
+}
+
+// Delete deletes a payroll export template.
+func (s *payrollExportTemplateServiceImpl) Delete(id string) error {
+	templateModel, err := s.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if err := s.payrollExportTemplateRepo.Delete(templateModel.ID); err != nil {
+		return fmt.Errorf("failed to delete payroll export template: %w", err)
+	}
+	return nil
+}
+
+// ConvertToResponse converts a payroll export template model to a response schema with its columns.
+func (s *payrollExportTemplateServiceImpl) ConvertToResponse(templateModel *model.PayrollExportTemplate) (*schema.PayrollExportTemplateResponse, error) {
+	columns, err := s.payrollExportTemplateRepo.GetColumnsByTemplateID(templateModel.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payroll export columns: %w", err)
+	}
+
+	columnResponses := make([]schema.PayrollExportColumnResponse, len(columns))
+	for i, column := range columns {
+		columnResponses[i] = schema.PayrollExportColumnResponse{
+			Field:  column.Field,
+			Header: column.Header,
+			Width:  column.Width,
+			Align:  column.Align,
+		}
+	}
+
+	return &schema.PayrollExportTemplateResponse{
+		ID:            templateModel.ID.String(),
+		Name:          templateModel.Name,
+		Company:       templateModel.Company,
+		Format:        templateModel.Format,
+		Delimiter:     templateModel.Delimiter,
+		DateFormat:    templateModel.DateFormat,
+		IncludeHeader: templateModel.IncludeHeader,
+		Columns:       columnResponses,
+	}, nil
+}
+
+// ----------> INNER FUNCTION <-----------------------//
+
+// validateBodyRequest checks the name per company, the file layout and every column. The format,
+// the fields and the alignments are checked by the validate tags of the request.
+func (s *payrollExportTemplateServiceImpl) validateBodyRequest(bodyRequest *schema.PayrollExportTemplateRequest, excludeID uuid.UUID) error {
+	bodyRequest.Company = emptyToNil(bodyRequest.Company)
+	isExistName, err := s.payrollExportTemplateRepo.IsExistName(*bodyRequest.Name, bodyRequest.Company, excludeID)
+	if err != nil {
+		return err
+	}
+	if isExistName {
+		return common.NewConflictError("payroll export template name is already exist")
+	}
+
+	if bodyRequest.Delimiter != nil && *bodyRequest.Delimiter != "" && !common.ValidatePayrollDelimiter(*bodyRequest.Delimiter) {
+		return common.NewFieldError("delimiter", "delimiter must be a single character other than a quote or a line break")
+	}
+	if bodyRequest.DateFormat != nil && *bodyRequest.DateFormat != "" {
+		if _, ok := common.ConvertPayrollDateFormat(*bodyRequest.DateFormat); !ok {
+			return common.NewValidationError("date format must combine YYYY or YY, MM and DD with '-', '/', '.' or spaces")
+		}
+	}
+
+	for i, column := range bodyRequest.Columns {
+		if *bodyRequest.Format == common.PayrollFormatFixedWidth && intValue(column.Width) <= 0 {
+			return common.NewFieldError(fmt.Sprintf("columns[%d].width", i), "width of column %d is required for fixed-width files", i+1)
+		}
+	}
+	return nil
+}
+
+// applyPayrollExportTemplateRequest copies a validated request into a template model, with defaults.
+func applyPayrollExportTemplateRequest(templateModel *model.PayrollExportTemplate, bodyRequest *schema.PayrollExportTemplateRequest) {
+	templateModel.Name = *bodyRequest.Name
+	templateModel.Company = bodyRequest.Company
+	templateModel.Format = *bodyRequest.Format
+	templateModel.Delimiter = common.DefaultPayrollDelimiter
+	if bodyRequest.Delimiter != nil && *bodyRequest.Delimiter != "" {
+		templateModel.Delimiter = *bodyRequest.Delimiter
+	}
+	templateModel.DateFormat = common.DefaultPayrollDateFormat
+	if bodyRequest.DateFormat != nil && *bodyRequest.DateFormat != "" {
+		templateModel.DateFormat = *bodyRequest.DateFormat
+	}
+	templateModel.IncludeHeader = bodyRequest.IncludeHeader == nil || *bodyRequest.IncludeHeader
+}
+
+// createPayrollExportColumnModels builds the column models of a template in request order.
+func createPayrollExportColumnModels(templateID string, columns []schema.PayrollExportColumnRequest) []model.PayrollExportColumn {
+	columnModels := make([]model.PayrollExportColumn, len(columns))
+	for i, column := range columns {
+		header := *column.Field
+		if column.Header != nil && *column.Header != "" {
+			header = *column.Header
+		}
+		align := common.PayrollAlignLeft
+		if common.IsPayrollNumericField(*column.Field) {
+			align = common.PayrollAlignRight
+		}
+		if column.Align != nil && *column.Align != "" {
+			align = *column.Align
+		}
+		columnModels[i] = model.PayrollExportColumn{
+			TemplateID: templateID,
+			Position:   i,
+			Field:      *column.Field,
+			Header:     header,
+			Width:      intValue(column.Width),
+			Align:      align,
+		}
+	}
+	return columnModels
+}
+
+// getPayrollExportTemplate loads a payroll export template by its ID.
+func getPayrollExportTemplate(payrollExportTemplateRepo repository.PayrollExportTemplateRepository, id string) (*model.PayrollExportTemplate, error) {
+	idUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, common.NewFieldError("id", "invalid ID")
+	}
+	template, err := payrollExportTemplateRepo.GetByID(idUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("payroll export template with ID '%s' not found", id)
+		}
+		return nil, fmt.Errorf("failed to get payroll export template: %w", err)
+	}
+	return template, nil
+}
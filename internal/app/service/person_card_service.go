@@ -0,0 +1,317 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/repository"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// PersonCardService defines the interface for the card lifecycle of a person.
+type PersonCardService interface {
+	GetAll(personID string) ([]model.PersonCard, error)
+	GetByID(personID string, cardID string) (*model.PersonCard, error)
+	Create(personID string, bodyRequest *schema.PersonCardRequest) (*model.PersonCard, error)
+	UpdateValidity(personID string, cardID string, bodyRequest *schema.PersonCardValidityRequest) (*model.PersonCard, error)
+	ChangeStatus(personID string, cardID string, bodyRequest *schema.PersonCardStatusRequest) (*model.PersonCard, error)
+	Delete(personID string, cardID string) error
+	GetHistory(personID string, cardID string) ([]model.PersonCardHistory, error)
+	ConvertToResponse(cardModel *model.PersonCard) *schema.PersonCardResponse
+	ConvertHistoryToResponse(historyModel *model.PersonCardHistory) *schema.PersonCardHistoryResponse
+}
+
+type personCardServiceImpl struct {
+	personCardRepo repository.PersonCardRepository
+	personRepo     repository.PersonRepository
+	db             *gorm.DB
+}
+
+// NewPersonCardService creates a new instance of PersonCardService.
+func NewPersonCardService(personCardRepo repository.PersonCardRepository, personRepo repository.PersonRepository, db *gorm.DB) PersonCardService {
+	return &personCardServiceImpl{
+		personCardRepo: personCardRepo,
+		personRepo:     personRepo,
+		db:             db,
+	}
+}
+
+// GetAll retrieves every card of a person.
+func (s *personCardServiceImpl) GetAll(personID string) ([]model.PersonCard, error) {
+	if _, err := s.getPerson(personID); err != nil {
+		return nil, err
+	}
+	return s.personCardRepo.GetByPersonID(personID)
+}
+
+// GetByID retrieves a card of a person.
+func (s *personCardServiceImpl) GetByID(personID string, cardID string) (*model.PersonCard, error) {
+	if _, err := s.getPerson(personID); err != nil {
+		return nil, err
+	}
+	return s.getCard(personID, cardID)
+}
+
+// Create issues a new active card to a person.
+func (s *personCardServiceImpl) Create(personID string, bodyRequest *schema.PersonCardRequest) (*model.PersonCard, error) {
+	if _, err := s.getPerson(personID); err != nil {
+		return nil, err
+	}
+
+	cardNumber := strings.TrimSpace(*bodyRequest.CardNumber)
+	if cardNumber == "" {
+		return nil, common.NewFieldError("cardNumber", "card number cannot be empty")
+	}
+	isExist, err := s.personCardRepo.IsExistCardNumber(cardNumber, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	if isExist {
+		return nil, common.NewConflictError("card number '%s' is already exist", cardNumber)
+	}
+
+	activeAt, expireAt, err := parsePersonCardValidity(bodyRequest.ActiveAt, bodyRequest.ExpireAt)
+	if err != nil {
+		return nil, err
+	}
+
+	card := &model.PersonCard{
+		CardNumber: cardNumber,
+		PersonID:   personID,
+		Status:     common.PersonCardStatusActive,
+		Reason:     bodyRequest.Reason,
+		ActiveAt:   activeAt,
+		ExpireAt:   expireAt,
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		txCardRepo := repository.NewPersonCardRepository(tx)
+		cards := []model.PersonCard{*card}
+		if err := txCardRepo.Create(cards); err != nil {
+			return fmt.Errorf("failed to create person card: %w", err)
+		}
+		card = &cards[0]
+		return txCardRepo.CreateHistory([]model.PersonCardHistory{newPersonCardHistory(card, common.PersonCardActionIssued)})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return card, nil
+}
+
+// UpdateValidity changes the validity window of a card.
+func (s *personCardServiceImpl) UpdateValidity(personID string, cardID string, bodyRequest *schema.PersonCardValidityRequest) (*model.PersonCard, error) {
+	if _, err := s.getPerson(personID); err != nil {
+		return nil, err
+	}
+	card, err := s.getCard(personID, cardID)
+	if err != nil {
+		return nil, err
+	}
+	if card.Status == common.PersonCardStatusRevoked {
+		return nil, common.NewConflictError("card '%s' is revoked and cannot be changed", card.CardNumber)
+	}
+
+	activeAt, expireAt, err := parsePersonCardValidity(bodyRequest.ActiveAt, bodyRequest.ExpireAt)
+	if err != nil {
+		return nil, err
+	}
+	card.ActiveAt = activeAt
+	card.ExpireAt = expireAt
+
+	return card, s.saveWithHistory(card, common.PersonCardActionUpdated)
+}
+
+// ChangeStatus sets the status of a card. Lost, suspended and revoked cards are refused by
+// access decisions from the moment the change is saved. Revoked is final.
+func (s *personCardServiceImpl) ChangeStatus(personID string, cardID string, bodyRequest *schema.PersonCardStatusRequest) (*model.PersonCard, error) {
+	if _, err := s.getPerson(personID); err != nil {
+		return nil, err
+	}
+	card, err := s.getCard(personID, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := strings.ToLower(*bodyRequest.Status)
+	if !common.ValidatePersonCardStatus(status) {
+		return nil, common.NewFieldError("status", "status must be one of %s", strings.Join(common.PERSON_CARD_STATUS, ", "))
+	}
+	if card.Status == common.PersonCardStatusRevoked {
+		return nil, common.NewConflictError("card '%s' is revoked and cannot be changed", card.CardNumber)
+	}
+	if status != common.PersonCardStatusActive && (bodyRequest.Reason == nil || strings.TrimSpace(*bodyRequest.Reason) == "") {
+		return nil, common.NewValidationError("reason is required when status is '%s'", status)
+	}
+
+	card.Status = status
+	card.Reason = bodyRequest.Reason
+
+	return card, s.saveWithHistory(card, common.PersonCardActionStatusChanged)
+}
+
+// Delete removes a card from a person. Its history is kept.
+func (s *personCardServiceImpl) Delete(personID string, cardID string) error {
+	if _, err := s.getPerson(personID); err != nil {
+		return err
+	}
+	card, err := s.getCard(personID, cardID)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		txCardRepo := repository.NewPersonCardRepository(tx)
+		if err := txCardRepo.Delete(card.ID); err != nil {
+			return fmt.Errorf("failed to delete person card: %w", err)
+		}
+		return txCardRepo.CreateHistory([]model.PersonCardHistory{newPersonCardHistory(card, common.PersonCardActionRemoved)})
+	})
+}
+
+// GetHistory retrieves the card history of a person, or of a single card when cardID is set.
+// The history of a removed card can still be read with its former ID.
+func (s *personCardServiceImpl) GetHistory(personID string, cardID string) ([]model.PersonCardHistory, error) {
+	if _, err := s.getPerson(personID); err != nil {
+		return nil, err
+	}
+	if cardID == "" {
+		return s.personCardRepo.GetHistoryByPersonID(personID)
+	}
+
+	if _, err := uuid.Parse(cardID); err != nil {
+		return nil, common.NewValidationError("invalid card ID")
+	}
+	histories, err := s.personCardRepo.GetHistoryByCardID(cardID)
+	if err != nil {
+		return nil, err
+	}
+	if len(histories) == 0 || histories[0].PersonID != personID {
+		return nil, common.NewNotFoundError("card with ID '%s' not found", cardID)
+	}
+	return histories, nil
+}
+
+// ConvertToResponse converts a person card model to a response schema.
+func (s *personCardServiceImpl) ConvertToResponse(cardModel *model.PersonCard) *schema.PersonCardResponse {
+	return &schema.PersonCardResponse{
+		ID:         cardModel.ID.String(),
+		CardNumber: cardModel.CardNumber,
+		PersonID:   cardModel.PersonID,
+		Status:     cardModel.Status,
+		Reason:     cardModel.Reason,
+		ActiveAt:   formatOptionalTime(cardModel.ActiveAt),
+		ExpireAt:   formatOptionalTime(cardModel.ExpireAt),
+		CreatedAt:  common.FormatTimestamp(cardModel.CreatedAt, nil),
+	}
+}
+
+// ConvertHistoryToResponse converts a person card history model to a response schema.
+func (s *personCardServiceImpl) ConvertHistoryToResponse(historyModel *model.PersonCardHistory) *schema.PersonCardHistoryResponse {
+	return &schema.PersonCardHistoryResponse{
+		ID:         historyModel.ID.String(),
+		CardID:     historyModel.PersonCardID,
+		CardNumber: historyModel.CardNumber,
+		Action:     historyModel.Action,
+		Status:     historyModel.Status,
+		Reason:     historyModel.Reason,
+		ActiveAt:   formatOptionalTime(historyModel.ActiveAt),
+		ExpireAt:   formatOptionalTime(historyModel.ExpireAt),
+		CreatedAt:  common.FormatTimestamp(historyModel.CreatedAt, nil),
+	}
+}
+
+// ----------> INNER FUNCTION <-----------------------//
+
+func (s *personCardServiceImpl) getPerson(personID string) (*model.Person, error) {
+	personUUID, err := uuid.Parse(personID)
+	if err != nil {
+		return nil, common.NewValidationError("invalid person ID")
+	}
+	person, err := s.personRepo.GetByID(personUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("person with ID '%s' not found", personID)
+		}
+		return nil, fmt.Errorf("failed to get person by ID: %w", err)
+	}
+	return person, nil
+}
+
+// getCard retrieves a card and makes sure it belongs to the person.
+func (s *personCardServiceImpl) getCard(personID string, cardID string) (*model.PersonCard, error) {
+	cardUUID, err := uuid.Parse(cardID)
+	if err != nil {
+		return nil, common.NewValidationError("invalid card ID")
+	}
+	card, err := s.personCardRepo.GetByID(cardUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("card with ID '%s' not found", cardID)
+		}
+		return nil, fmt.Errorf("failed to get person card by ID: %w", err)
+	}
+	if card.PersonID != personID {
+		return nil, common.NewNotFoundError("card with ID '%s' not found", cardID)
+	}
+	return card, nil
+}
+
+func (s *personCardServiceImpl) saveWithHistory(card *model.PersonCard, action string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		txCardRepo := repository.NewPersonCardRepository(tx)
+		if err := txCardRepo.Update(card); err != nil {
+			return fmt.Errorf("failed to update person card: %w", err)
+		}
+		return txCardRepo.CreateHistory([]model.PersonCardHistory{newPersonCardHistory(card, action)})
+	})
+}
+
+// newPersonCardHistory snapshots the current state of a card as a history entry.
+func newPersonCardHistory(card *model.PersonCard, action string) model.PersonCardHistory {
+	return model.PersonCardHistory{
+		PersonCardID: card.ID.String(),
+		PersonID:     card.PersonID,
+		CardNumber:   card.CardNumber,
+		Action:       action,
+		Status:       card.Status,
+		Reason:       card.Reason,
+		ActiveAt:     card.ActiveAt,
+		ExpireAt:     card.ExpireAt,
+	}
+}
+
+// parsePersonCardValidity parses the optional validity window of a card.
+func parsePersonCardValidity(activeAtStr *string, expireAtStr *string) (*time.Time, *time.Time, error) {
+	var activeAt, expireAt *time.Time
+	if activeAtStr != nil && *activeAtStr != "" {
+		parsed, err := common.ParseTimestamp(*activeAtStr, nil)
+		if err != nil {
+			return nil, nil, common.NewValidationError("invalid active time format, expected an RFC 3339 timestamp")
+		}
+		activeAt = &parsed
+	}
+	if expireAtStr != nil && *expireAtStr != "" {
+		parsed, err := common.ParseTimestamp(*expireAtStr, nil)
+		if err != nil {
+			return nil, nil, common.NewValidationError("invalid expire time format, expected an RFC 3339 timestamp")
+		}
+		expireAt = &parsed
+	}
+	if activeAt != nil && expireAt != nil && expireAt.Before(*activeAt) {
+		return nil, nil, common.NewValidationError("expire time must be after active time")
+	}
+	return activeAt, expireAt, nil
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	formatted := common.FormatTimestamp(*t, nil)
+	return &formatted
+}
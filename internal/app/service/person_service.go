@@ -1,8 +1,21 @@
 package service
 
 import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
+	"log"
 	"mime/multipart"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/putteror/access-control-management/internal/app/common"
@@ -20,6 +33,11 @@ type PersonService interface {
 	PartialUpdate(id string, person *model.Person, faceImageFile *multipart.FileHeader, cardIDs []string, licensePlateTexts []string) error
 	Delete(id string) error
 	ConvertToResponse(personModel *model.Person) (*schema.PersonResponse, error)
+	Import(fileHeader *multipart.FileHeader, dryRun bool) (*schema.PersonImportResult, error)
+	Export(searchQuery schema.PersonSearchQuery) ([][]string, error)
+	ImportFaceImages(fileHeader *multipart.FileHeader, matchBy string) (*schema.PersonFaceImageImportResult, error)
+	SetPIN(id string, pin string) error
+	ClearPIN(id string) error
 }
 
 type personServiceImpl struct {
@@ -28,17 +46,21 @@ type personServiceImpl struct {
 	personLicenseRepo  repository.PersonLicensePlateRepository
 	accessRuleRepo     repository.AccessControlRuleRepository
 	timeAttendanceRepo repository.AttendanceRepository
+	fileRepo           repository.FileRepository
+	faceImageOptions   common.FaceImageOptions
 	db                 *gorm.DB
 }
 
 // NewPersonService creates a new instance of PersonService.
-func NewPersonService(personRepo repository.PersonRepository, personCardRepo repository.PersonCardRepository, personLicenseRepo repository.PersonLicensePlateRepository, accessRuleRepo repository.AccessControlRuleRepository, timeAttendanceRepo repository.AttendanceRepository, db *gorm.DB) PersonService {
+func NewPersonService(personRepo repository.PersonRepository, personCardRepo repository.PersonCardRepository, personLicenseRepo repository.PersonLicensePlateRepository, accessRuleRepo repository.AccessControlRuleRepository, timeAttendanceRepo repository.AttendanceRepository, fileRepo repository.FileRepository, faceImageOptions common.FaceImageOptions, db *gorm.DB) PersonService {
 	return &personServiceImpl{
 		personRepo:         personRepo,
 		personCardRepo:     personCardRepo,
 		personLicenseRepo:  personLicenseRepo,
 		accessRuleRepo:     accessRuleRepo,
 		timeAttendanceRepo: timeAttendanceRepo,
+		fileRepo:           fileRepo,
+		faceImageOptions:   faceImageOptions,
 		db:                 db,
 	}
 }
@@ -52,61 +74,27 @@ func (s *personServiceImpl) GetAll(searchQuery schema.PersonSearchQuery) ([]mode
 func (s *personServiceImpl) GetByID(id string) (*model.Person, error) {
 	idUUID, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid ID")
+		return nil, common.NewFieldError("id", "invalid ID")
 	}
-	return s.personRepo.GetByID(idUUID)
+	person, err := s.personRepo.GetByID(idUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("person with ID '%s' not found", id)
+		}
+		return nil, fmt.Errorf("failed to get person: %w", err)
+	}
+	return person, nil
 }
 
 // Save creates or updates a person.
 func (s *personServiceImpl) Save(id string, person *model.Person, faceImageFile *multipart.FileHeader, cardIDs []string, licensePlateTexts []string) error {
-	isCreate := id == ""
-
-	// Validate if PersonID and PersonName exist
-	if err := s.validatePerson(isCreate, person); err != nil {
-		return err
-	}
-
+	var faceImage faceImageChange
 	err := s.db.Transaction(func(tx *gorm.DB) error {
-		txPersonRepo := repository.NewPersonRepository(tx)
-		txCardRepo := repository.NewPersonCardRepository(tx)
-		txLicenseRepo := repository.NewPersonLicensePlateRepository(tx)
-
-		if isCreate {
-			if err := txPersonRepo.Create(person); err != nil {
-				return fmt.Errorf("failed to create person: %w", err)
-			}
-		} else {
-			existingPerson, err := txPersonRepo.GetByID(uuid.MustParse(id))
-			if err != nil {
-				return fmt.Errorf("person with ID '%s' not found: %w", id, err)
-			}
-			if err := txPersonRepo.Update(id, person); err != nil {
-				return fmt.Errorf("failed to update person: %w", err)
-			}
-			if faceImageFile != nil && existingPerson.FaceImagePath != nil && *existingPerson.FaceImagePath != "" {
-				// TODO: Add logic to delete old image file from storage
-				fmt.Printf("Deleting old image at path: %s\n", *existingPerson.FaceImagePath)
-			}
-		}
-
-		// Handle relationships
-		if err := s.handleRelationships(txCardRepo, txLicenseRepo, person.ID.String(), cardIDs, licensePlateTexts); err != nil {
-			return err
-		}
-
-		if faceImageFile != nil {
-			filePath, err := common.UploadFile(faceImageFile, person.ID.String())
-			if err != nil {
-				return fmt.Errorf("failed to upload face image: %w", err)
-			}
-			person.FaceImagePath = &filePath
-			if err := txPersonRepo.Update(person.ID.String(), person); err != nil {
-				return fmt.Errorf("failed to update person face image path: %w", err)
-			}
-		}
-
-		return nil
+		var err error
+		faceImage, err = s.saveWithTx(tx, id, person, faceImageFile, cardIDs, licensePlateTexts)
+		return err
 	})
+	s.finishFaceImageChange(faceImage, err)
 	return err
 }
 
@@ -114,19 +102,22 @@ func (s *personServiceImpl) Save(id string, person *model.Person, faceImageFile
 func (s *personServiceImpl) PartialUpdate(id string, person *model.Person, faceImageFile *multipart.FileHeader, cardIDs []string, licensePlateTexts []string) error {
 	idUUID, err := uuid.Parse(id)
 	if err != nil {
-		return fmt.Errorf("invalid ID format")
+		return common.NewValidationError("invalid ID format")
 	}
 
 	existingPerson, err := s.personRepo.GetByID(idUUID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return fmt.Errorf("person with ID '%s' not found", id)
+			return common.NewNotFoundError("person with ID '%s' not found", id)
 		}
 		return fmt.Errorf("failed to get existing person: %w", err)
 	}
+	if existingPerson.ErasedAt != nil {
+		return common.NewConflictError("person is erased and cannot be changed")
+	}
 
 	// Validate
-	if err := s.validatePerson(false, person); err != nil {
+	if err := s.validatePerson(s.personRepo, false, person); err != nil {
 		return err
 	}
 
@@ -186,20 +177,25 @@ func (s *personServiceImpl) PartialUpdate(id string, person *model.Person, faceI
 		existingPerson.TimeAttendanceID = person.TimeAttendanceID
 	}
 
+	var faceImage faceImageChange
 	err = s.db.Transaction(func(tx *gorm.DB) error {
 		txPersonRepo := repository.NewPersonRepository(tx)
 		txCardRepo := repository.NewPersonCardRepository(tx)
 		txLicenseRepo := repository.NewPersonLicensePlateRepository(tx)
 
-		// Handle face image upload/update
-		if faceImageFile != nil {
-			// TODO: Add logic to upload new image and set person.FaceImagePath
-		}
-
 		if err := txPersonRepo.Update(id, existingPerson); err != nil {
 			return fmt.Errorf("failed to partial update person: %w", err)
 		}
 
+		// Handle face image upload/update
+		if faceImageFile != nil {
+			var err error
+			faceImage, err = s.storeUploadedFaceImage(txPersonRepo, existingPerson, faceImageFile)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Handle relationships
 		if cardIDs != nil || licensePlateTexts != nil {
 			if err := s.handleRelationships(txCardRepo, txLicenseRepo, id, cardIDs, licensePlateTexts); err != nil {
@@ -208,7 +204,7 @@ func (s *personServiceImpl) PartialUpdate(id string, person *model.Person, faceI
 		}
 		return nil
 	})
-
+	s.finishFaceImageChange(faceImage, err)
 	return err
 }
 
@@ -216,18 +212,53 @@ func (s *personServiceImpl) PartialUpdate(id string, person *model.Person, faceI
 func (s *personServiceImpl) Delete(id string) error {
 	idUUID, err := uuid.Parse(id)
 	if err != nil {
-		return fmt.Errorf("invalid ID")
+		return common.NewFieldError("id", "invalid ID")
 	}
 
-	_, err = s.personRepo.GetByID(idUUID)
+	person, err := s.personRepo.GetByID(idUUID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return fmt.Errorf("person with ID '%s' not found", id)
+			return common.NewNotFoundError("person with ID '%s' not found", id)
 		}
 		return fmt.Errorf("failed to get person by ID: %w", err)
 	}
 
-	return s.personRepo.Delete(idUUID)
+	if err := s.personRepo.Delete(idUUID); err != nil {
+		return err
+	}
+
+	s.deleteFaceImageFiles(faceImagePaths(person)...)
+	return nil
+}
+
+// SetPIN sets the PIN a person enters at devices that require card and PIN. Only its hash is stored.
+func (s *personServiceImpl) SetPIN(id string, pin string) error {
+	if _, err := s.GetByID(id); err != nil {
+		return err
+	}
+	if !common.ValidatePIN(pin) {
+		return common.NewFieldError("pin", "PIN must be 4 to 8 digits")
+	}
+
+	pinHash, err := common.HashPassword(pin)
+	if err != nil {
+		return err
+	}
+	if err := s.personRepo.UpdatePINHash(id, &pinHash); err != nil {
+		return fmt.Errorf("failed to update person PIN: %w", err)
+	}
+	return nil
+}
+
+// ClearPIN removes the PIN of a person.
+func (s *personServiceImpl) ClearPIN(id string) error {
+	if _, err := s.GetByID(id); err != nil {
+		return err
+	}
+	if err := s.personRepo.UpdatePINHash(id, nil); err != nil {
+		return fmt.Errorf("failed to clear person PIN: %w", err)
+	}
+	return nil
 }
 
 // ConvertToResponse converts a person model to a response schema.
@@ -284,24 +315,764 @@ func (s *personServiceImpl) ConvertToResponse(personModel *model.Person) (*schem
 		IsVerified:        personModel.IsVerified,
 		CardIDs:           cardIDs,
 		LicensePlateTexts: licensePlateTexts,
-		FaceImagePath:     personModel.FaceImagePath,
+		FaceImage:         convertFaceImageToResponse(personModel),
+		HasPIN:            personModel.PINHash != nil,
 		ActiveAt:          personModel.ActiveAt,
 		ExpireAt:          personModel.ExpireAt,
 		AccessControlRule: accessRule,
 		TimeAttendance:    timeAttendance,
+		ErasedAt:          personModel.ErasedAt,
 	}, nil
 }
 
+// Import creates people from the rows of a CSV or XLSX file laid out as schema.PERSON_IMPORT_COLUMNS.
+// Every row goes through the same validation as Save. The import is all-or-nothing: when dryRun is
+// true or any row fails, the transaction is rolled back and only the per-row report is returned.
+func (s *personServiceImpl) Import(fileHeader *multipart.FileHeader, dryRun bool) (*schema.PersonImportResult, error) {
+	rows, err := common.ReadSpreadsheet(fileHeader)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) < 2 {
+		return nil, common.NewValidationError("import file has no data rows")
+	}
+
+	columns, err := mapPersonImportColumns(rows[0])
+	if err != nil {
+		return nil, err
+	}
+
+	result := &schema.PersonImportResult{
+		DryRun: dryRun,
+		Errors: []schema.PersonImportRowError{},
+	}
+	ruleIDs := map[string]string{}
+	attendanceIDs := map[string]string{}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		for i, row := range rows[1:] {
+			rowNumber := i + 2 // 1-based and after the header row
+			if isEmptyImportRow(row) {
+				continue
+			}
+			result.TotalRows++
+
+			person, cardIDs, licensePlateTexts, rowErrors := s.parseImportRow(row, columns, rowNumber, ruleIDs, attendanceIDs)
+			if len(rowErrors) > 0 {
+				result.Errors = append(result.Errors, rowErrors...)
+				continue
+			}
+
+			// Each row runs in its own savepoint so a failed row does not abort the whole transaction
+			err := tx.Transaction(func(rowTx *gorm.DB) error {
+				_, err := s.saveWithTx(rowTx, "", person, nil, cardIDs, licensePlateTexts)
+				return err
+			})
+			if err != nil {
+				result.Errors = append(result.Errors, schema.PersonImportRowError{Row: rowNumber, Message: err.Error()})
+				continue
+			}
+			result.ValidRows++
+		}
+
+		if dryRun || len(result.Errors) > 0 {
+			return errImportRollback
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errImportRollback) {
+		return nil, err
+	}
+
+	if !dryRun && len(result.Errors) == 0 {
+		result.ImportedRows = result.ValidRows
+	}
+	return result, nil
+}
+
+// Export returns the people matching searchQuery as rows in the same layout as Import, header first.
+func (s *personServiceImpl) Export(searchQuery schema.PersonSearchQuery) ([][]string, error) {
+	searchQuery.All = true
+	persons, err := s.personRepo.GetAll(searchQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleNames := map[string]string{}
+	attendanceNames := map[string]string{}
+
+	rows := make([][]string, 0, len(persons)+1)
+	rows = append(rows, schema.PERSON_IMPORT_COLUMNS)
+	for _, person := range persons {
+		cardIDs, err := s.personCardRepo.GetCardNumbersByPersonID(person.ID.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get card numbers: %w", err)
+		}
+		licensePlateTexts, err := s.personLicenseRepo.GetLicensePlateTextsByPersonID(person.ID.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get license plate texts: %w", err)
+		}
+
+		ruleName := ""
+		if person.AccessControlRuleID != nil && *person.AccessControlRuleID != "" {
+			name, ok := ruleNames[*person.AccessControlRuleID]
+			if !ok {
+				if ruleID, err := uuid.Parse(*person.AccessControlRuleID); err == nil {
+					if rule, err := s.accessRuleRepo.GetByID(ruleID); err == nil {
+						name = rule.Name
+					}
+				}
+				ruleNames[*person.AccessControlRuleID] = name
+			}
+			ruleName = name
+		}
+
+		attendanceName := ""
+		if person.TimeAttendanceID != nil && *person.TimeAttendanceID != "" {
+			name, ok := attendanceNames[*person.TimeAttendanceID]
+			if !ok {
+				if attendanceID, err := uuid.Parse(*person.TimeAttendanceID); err == nil {
+					if attendance, err := s.timeAttendanceRepo.GetByID(attendanceID); err == nil {
+						name = attendance.Name
+					}
+				}
+				attendanceNames[*person.TimeAttendanceID] = name
+			}
+			attendanceName = name
+		}
+
+		rows = append(rows, []string{
+			person.FirstName,
+			stringValue(person.MiddleName),
+			person.LastName,
+			person.PersonType,
+			stringValue(person.PersonID),
+			stringValue(person.Gender),
+			dateValue(person.DateOfBirth),
+			stringValue(person.Company),
+			stringValue(person.Department),
+			stringValue(person.JobPosition),
+			stringValue(person.Address),
+			stringValue(person.MobileNumber),
+			stringValue(person.Email),
+			strconv.FormatBool(person.IsVerified),
+			dateValue(person.ActiveAt),
+			dateValue(person.ExpireAt),
+			strings.Join(cardIDs, schema.PERSON_IMPORT_LIST_SEPARATOR),
+			strings.Join(licensePlateTexts, schema.PERSON_IMPORT_LIST_SEPARATOR),
+			ruleName,
+			attendanceName,
+		})
+	}
+	return rows, nil
+}
+
+// ImportFaceImages replaces face images of people with the images found in a ZIP archive.
+// Images are matched by file name (PersonID or email without extension) or through a
+// manifest.csv file with the columns fileName and personId or email.
+// Each file is handled on its own and reported back, a failed file does not stop the import.
+func (s *personServiceImpl) ImportFaceImages(fileHeader *multipart.FileHeader, matchBy string) (*schema.PersonFaceImageImportResult, error) {
+	if !strings.EqualFold(filepath.Ext(fileHeader.Filename), ".zip") {
+		return nil, common.NewValidationError("face image import file must be a .zip archive")
+	}
+	if fileHeader.Size > common.FaceImageImportMaxBytes {
+		return nil, common.NewValidationError("face image import file is larger than %d MB", common.FaceImageImportMaxBytes>>20)
+	}
+	if matchBy != "" && !slices.Contains(schema.FACE_IMAGE_MATCH_BY_LIST, matchBy) {
+		return nil, common.NewFieldError("matchBy", "matchBy must be one of %s", strings.Join(schema.FACE_IMAGE_MATCH_BY_LIST, ", "))
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer file.Close()
+
+	archive, err := zip.NewReader(file, fileHeader.Size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read zip archive: %w", err)
+	}
+
+	manifestFile := findFaceImageManifest(archive)
+	if matchBy == "" {
+		matchBy = schema.FACE_IMAGE_MATCH_BY_PERSON_ID
+		if manifestFile != nil {
+			matchBy = schema.FACE_IMAGE_MATCH_BY_MANIFEST
+		}
+	}
+
+	var manifest map[string]faceImageManifestEntry
+	if matchBy == schema.FACE_IMAGE_MATCH_BY_MANIFEST {
+		if manifestFile == nil {
+			return nil, common.NewValidationError("zip archive has no %s", common.FaceImageManifestName)
+		}
+		manifest, err = readFaceImageManifest(manifestFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &schema.PersonFaceImageImportResult{
+		MatchBy: matchBy,
+		Files:   []schema.PersonFaceImageImportFileResult{},
+	}
+	for _, entry := range archive.File {
+		if !isFaceImageArchiveEntry(entry) || entry == manifestFile {
+			continue
+		}
+		result.TotalFiles++
+
+		fileResult := schema.PersonFaceImageImportFileResult{FileName: entry.Name}
+		person, err := s.matchFaceImagePerson(entry.Name, matchBy, manifest)
+		if err == nil {
+			fileResult.Person = convertPersonToInfoResponse(person)
+			err = s.importFaceImageEntry(entry, person)
+		}
+
+		if err != nil {
+			fileResult.Status = schema.FACE_IMAGE_IMPORT_STATUS_FAILED
+			fileResult.Message = err.Error()
+			result.FailedFiles++
+		} else {
+			fileResult.Status = schema.FACE_IMAGE_IMPORT_STATUS_IMPORTED
+			result.ImportedFiles++
+		}
+		result.Files = append(result.Files, fileResult)
+	}
+
+	return result, nil
+}
+
 // ----------> INNER FUNCTION <-----------------------//
 
-func (s *personServiceImpl) validatePerson(isCreate bool, person *model.Person) error {
+var errImportRollback = errors.New("import rolled back")
+
+// saveWithTx creates or updates a person using the given transaction.
+// It returns the face images it stored and replaced, see finishFaceImageChange.
+func (s *personServiceImpl) saveWithTx(tx *gorm.DB, id string, person *model.Person, faceImageFile *multipart.FileHeader, cardIDs []string, licensePlateTexts []string) (faceImageChange, error) {
+	isCreate := id == ""
+
+	txPersonRepo := repository.NewPersonRepository(tx)
+	txCardRepo := repository.NewPersonCardRepository(tx)
+	txLicenseRepo := repository.NewPersonLicensePlateRepository(tx)
+
+	var existingPerson *model.Person
+	if !isCreate {
+		idUUID, err := uuid.Parse(id)
+		if err != nil {
+			return faceImageChange{}, common.NewFieldError("id", "invalid ID")
+		}
+		existingPerson, err = txPersonRepo.GetByID(idUUID)
+		if err != nil {
+			return faceImageChange{}, common.NewNotFoundError("person with ID '%s' not found: %w", id, err)
+		}
+		if existingPerson.ErasedAt != nil {
+			return faceImageChange{}, common.NewConflictError("person is erased and cannot be changed")
+		}
+		person.ID = idUUID
+	}
+
+	// Validate if PersonID and PersonName exist
+	if err := s.validatePerson(txPersonRepo, isCreate, person); err != nil {
+		return faceImageChange{}, err
+	}
+
+	if isCreate {
+		if err := txPersonRepo.Create(person); err != nil {
+			return faceImageChange{}, fmt.Errorf("failed to create person: %w", err)
+		}
+	} else {
+		// Keep the stored images unless a new one is uploaded
+		person.FaceImagePath = existingPerson.FaceImagePath
+		person.FaceImageNormalizedPath = existingPerson.FaceImageNormalizedPath
+		person.FaceImageThumbnailPath = existingPerson.FaceImageThumbnailPath
+		if err := txPersonRepo.Update(id, person); err != nil {
+			return faceImageChange{}, fmt.Errorf("failed to update person: %w", err)
+		}
+	}
+
+	// Handle relationships
+	if err := s.handleRelationships(txCardRepo, txLicenseRepo, person.ID.String(), cardIDs, licensePlateTexts); err != nil {
+		return faceImageChange{}, err
+	}
+
+	if faceImageFile == nil {
+		return faceImageChange{}, nil
+	}
+	return s.storeUploadedFaceImage(txPersonRepo, person, faceImageFile)
+}
+
+// parseImportRow converts one import row to a person model and validates it the same way the
+// person form is validated. Access rule and attendance are looked up by name and cached.
+func (s *personServiceImpl) parseImportRow(row []string, columns map[string]int, rowNumber int, ruleIDs map[string]string, attendanceIDs map[string]string) (*model.Person, []string, []string, []schema.PersonImportRowError) {
+	var rowErrors []schema.PersonImportRowError
+	addError := func(field string, message string) {
+		rowErrors = append(rowErrors, schema.PersonImportRowError{Row: rowNumber, Field: field, Message: message})
+	}
+
+	value := func(column string) string {
+		index, ok := columns[column]
+		if !ok || index >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[index])
+	}
+	optional := func(column string) *string {
+		v := value(column)
+		if v == "" {
+			return nil
+		}
+		return &v
+	}
+	date := func(column string) *time.Time {
+		v := value(column)
+		if v == "" {
+			return nil
+		}
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			addError(column, "invalid date format, expected YYYY-MM-DD")
+			return nil
+		}
+		return &t
+	}
+	list := func(column string) []string {
+		var items []string
+		for _, item := range strings.Split(value(column), schema.PERSON_IMPORT_LIST_SEPARATOR) {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items
+	}
+
+	person := &model.Person{
+		FirstName:    value("firstName"),
+		MiddleName:   optional("middleName"),
+		LastName:     value("lastName"),
+		PersonType:   strings.ToLower(value("personType")),
+		PersonID:     optional("personId"),
+		Gender:       optional("gender"),
+		DateOfBirth:  date("dateOfBirth"),
+		Company:      optional("company"),
+		Department:   optional("department"),
+		JobPosition:  optional("jobPosition"),
+		Address:      optional("address"),
+		MobileNumber: optional("mobileNumber"),
+		Email:        optional("email"),
+		ActiveAt:     date("activeAt"),
+		ExpireAt:     date("expireAt"),
+	}
+
+	if person.FirstName == "" {
+		addError("firstName", "first name is required")
+	}
+	if person.LastName == "" {
+		addError("lastName", "last name is required")
+	}
+	if person.PersonType == "" {
+		addError("personType", "person type is required")
+	} else if !slices.Contains(schema.PERSON_TYPE_LIST, person.PersonType) {
+		addError("personType", fmt.Sprintf("person type must be one of %s", strings.Join(schema.PERSON_TYPE_LIST, ", ")))
+	}
+	if isVerified := value("isVerified"); isVerified != "" {
+		verified, err := strconv.ParseBool(isVerified)
+		if err != nil {
+			addError("isVerified", "isVerified must be true or false")
+		}
+		person.IsVerified = verified
+	}
+	if person.ActiveAt != nil && person.ExpireAt != nil && person.ExpireAt.Before(*person.ActiveAt) {
+		addError("expireAt", "expire date must be after active date")
+	}
+
+	if ruleName := value("accessControlRule"); ruleName != "" {
+		ruleID, ok := ruleIDs[ruleName]
+		if !ok {
+			if rule, err := s.accessRuleRepo.GetByName(ruleName); err == nil {
+				ruleID = rule.ID.String()
+			}
+			ruleIDs[ruleName] = ruleID
+		}
+		if ruleID == "" {
+			addError("accessControlRule", fmt.Sprintf("access control rule '%s' not found", ruleName))
+		} else {
+			person.AccessControlRuleID = &ruleID
+		}
+	}
+
+	if attendanceName := value("timeAttendance"); attendanceName != "" {
+		attendanceID, ok := attendanceIDs[attendanceName]
+		if !ok {
+			if attendance, err := s.timeAttendanceRepo.GetByName(attendanceName); err == nil {
+				attendanceID = attendance.ID.String()
+			}
+			attendanceIDs[attendanceName] = attendanceID
+		}
+		if attendanceID == "" {
+			addError("timeAttendance", fmt.Sprintf("attendance '%s' not found", attendanceName))
+		} else {
+			person.TimeAttendanceID = &attendanceID
+		}
+	}
+
+	return person, list("cardIds"), list("licensePlateTexts"), rowErrors
+}
+
+// mapPersonImportColumns maps each known column name (case-insensitive) to its index in the header row.
+func mapPersonImportColumns(header []string) (map[string]int, error) {
+	known := make(map[string]string, len(schema.PERSON_IMPORT_COLUMNS))
+	for _, column := range schema.PERSON_IMPORT_COLUMNS {
+		known[strings.ToLower(column)] = column
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		column, ok := known[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown column '%s'", name)
+		}
+		columns[column] = i
+	}
+
+	for _, required := range []string{"firstName", "lastName", "personType"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing required column '%s'", required)
+		}
+	}
+	return columns, nil
+}
+
+type faceImageManifestEntry struct {
+	personID string
+	email    string
+}
+
+// matchFaceImagePerson finds the person a face image in the archive belongs to.
+func (s *personServiceImpl) matchFaceImagePerson(entryName string, matchBy string, manifest map[string]faceImageManifestEntry) (*model.Person, error) {
+	baseName := path.Base(entryName)
+	key := strings.TrimSuffix(baseName, path.Ext(baseName))
+
+	switch matchBy {
+	case schema.FACE_IMAGE_MATCH_BY_EMAIL:
+		return s.findFaceImagePerson("", key)
+	case schema.FACE_IMAGE_MATCH_BY_MANIFEST:
+		entry, ok := manifest[entryName]
+		if !ok {
+			entry, ok = manifest[baseName]
+		}
+		if !ok {
+			return nil, common.NewValidationError("file is not listed in %s", common.FaceImageManifestName)
+		}
+		return s.findFaceImagePerson(entry.personID, entry.email)
+	default:
+		return s.findFaceImagePerson(key, "")
+	}
+}
+
+func (s *personServiceImpl) findFaceImagePerson(personID string, email string) (*model.Person, error) {
+	var person *model.Person
+	var err error
+	if personID != "" {
+		person, err = s.personRepo.GetByPersonID(personID)
+	} else if email != "" {
+		person, err = s.personRepo.GetByEmail(email)
+	} else {
+		return nil, common.NewValidationError("no personId or email to match")
+	}
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if personID != "" {
+				return nil, common.NewNotFoundError("person with personId '%s' not found", personID)
+			}
+			return nil, common.NewNotFoundError("person with email '%s' not found", email)
+		}
+		return nil, fmt.Errorf("failed to find person: %w", err)
+	}
+	return person, nil
+}
+
+// importFaceImageEntry validates one image of the archive and sets it as the person's face image.
+func (s *personServiceImpl) importFaceImageEntry(entry *zip.File, person *model.Person) error {
+	if entry.UncompressedSize64 > common.FaceImageMaxBytes {
+		return common.NewValidationError("image is larger than %d MB", common.FaceImageMaxBytes>>20)
+	}
+
+	src, err := entry.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open file in archive: %w", err)
+	}
+	defer src.Close()
+
+	// Limit the read in case the declared size in the archive is wrong
+	data, err := io.ReadAll(io.LimitReader(src, common.FaceImageMaxBytes+1))
+	if err != nil {
+		return fmt.Errorf("failed to read file in archive: %w", err)
+	}
+
+	faceImage, err := s.storeFaceImage(s.personRepo, person, data)
+	s.finishFaceImageChange(faceImage, err)
+	return err
+}
+
+// storeUploadedFaceImage reads an uploaded face image and stores it with storeFaceImage.
+func (s *personServiceImpl) storeUploadedFaceImage(personRepo repository.PersonRepository, person *model.Person, faceImageFile *multipart.FileHeader) (faceImageChange, error) {
+	if faceImageFile.Size > common.FaceImageMaxBytes {
+		return faceImageChange{}, common.NewValidationError("invalid face image: image is larger than %d MB", common.FaceImageMaxBytes>>20)
+	}
+
+	src, err := faceImageFile.Open()
+	if err != nil {
+		return faceImageChange{}, fmt.Errorf("failed to open face image: %w", err)
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(io.LimitReader(src, common.FaceImageMaxBytes+1))
+	if err != nil {
+		return faceImageChange{}, fmt.Errorf("failed to read face image: %w", err)
+	}
+	return s.storeFaceImage(personRepo, person, data)
+}
+
+// storeFaceImage validates and processes a face image, stores the original, normalized and
+// thumbnail variants and points the person to them. It returns the paths of the new and the
+// previous variants. When anything fails the new files are removed again so no orphan is left in
+// storage; when the transaction of personRepo is rolled back later, finishFaceImageChange does so.
+func (s *personServiceImpl) storeFaceImage(personRepo repository.PersonRepository, person *model.Person, data []byte) (faceImageChange, error) {
+	processed, err := common.ProcessFaceImage(data, s.faceImageOptions)
+	if err != nil {
+		return faceImageChange{}, common.NewValidationError("invalid face image: %w", err)
+	}
+
+	variants := []struct {
+		data     []byte
+		fileName string
+	}{
+		{processed.Original, "original" + processed.OriginalExtension},
+		{processed.Normalized, common.FaceImageNormalizedFileName},
+		{processed.Thumbnail, common.FaceImageThumbnailFileName},
+	}
+	newPaths := make([]string, 0, len(variants))
+	for _, variant := range variants {
+		newPath, err := s.fileRepo.SaveReader(bytes.NewReader(variant.data), variant.fileName, faceImageFolder(person))
+		if err != nil {
+			s.deleteFaceImageFiles(newPaths...)
+			return faceImageChange{}, fmt.Errorf("failed to save face image: %w", err)
+		}
+		newPaths = append(newPaths, newPath)
+	}
+
+	update := &model.Person{
+		FaceImagePath:           &newPaths[0],
+		FaceImageNormalizedPath: &newPaths[1],
+		FaceImageThumbnailPath:  &newPaths[2],
+	}
+	if err := personRepo.Update(person.ID.String(), update); err != nil {
+		s.deleteFaceImageFiles(newPaths...)
+		return faceImageChange{}, fmt.Errorf("failed to update person face image path: %w", err)
+	}
+
+	oldPaths := faceImagePaths(person)
+	person.FaceImagePath = update.FaceImagePath
+	person.FaceImageNormalizedPath = update.FaceImageNormalizedPath
+	person.FaceImageThumbnailPath = update.FaceImageThumbnailPath
+	return faceImageChange{newPaths: newPaths, oldPaths: oldPaths}, nil
+}
+
+// faceImageChange holds the face image files a change of a person stored and the ones it
+// replaced.
+type faceImageChange struct {
+	newPaths []string
+	oldPaths []string
+}
+
+// finishFaceImageChange removes the files a change of a person left behind once its transaction
+// ended: the replaced images when it committed, the new ones when it failed or was rolled back.
+func (s *personServiceImpl) finishFaceImageChange(change faceImageChange, err error) {
+	if err != nil {
+		s.deleteFaceImageFiles(change.newPaths...)
+		return
+	}
+	s.deleteFaceImageFiles(change.oldPaths...)
+}
+
+// deleteFaceImageFiles removes face images from storage. Failures are only logged because
+// the database no longer references the files.
+func (s *personServiceImpl) deleteFaceImageFiles(filePaths ...string) {
+	for _, filePath := range filePaths {
+		if filePath == "" {
+			continue
+		}
+		if err := s.fileRepo.Delete(filePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Printf("failed to delete face image '%s': %v", filePath, err)
+		}
+	}
+}
+
+// faceImagePaths returns the stored paths of every face image variant of a person.
+func faceImagePaths(person *model.Person) []string {
+	var paths []string
+	for _, filePath := range []*string{person.FaceImagePath, person.FaceImageNormalizedPath, person.FaceImageThumbnailPath} {
+		if filePath != nil && *filePath != "" {
+			paths = append(paths, *filePath)
+		}
+	}
+	return paths
+}
+
+// convertFaceImageToResponse returns the URLs of a person's face image variants.
+// People whose image was stored before variants existed fall back to the original for every variant.
+func convertFaceImageToResponse(person *model.Person) *schema.PersonFaceImageResponse {
+	if person.FaceImagePath == nil || *person.FaceImagePath == "" {
+		return nil
+	}
+
+	originalURL := common.GetImageURL(common.FileURLPrefix, *person.FaceImagePath)
+	response := &schema.PersonFaceImageResponse{
+		OriginalURL:   originalURL,
+		NormalizedURL: originalURL,
+		ThumbnailURL:  originalURL,
+	}
+	if person.FaceImageNormalizedPath != nil && *person.FaceImageNormalizedPath != "" {
+		response.NormalizedURL = common.GetImageURL(common.FileURLPrefix, *person.FaceImageNormalizedPath)
+	}
+	if person.FaceImageThumbnailPath != nil && *person.FaceImageThumbnailPath != "" {
+		response.ThumbnailURL = common.GetImageURL(common.FileURLPrefix, *person.FaceImageThumbnailPath)
+	}
+	return response
+}
+
+// faceImageFolder is the storage folder of a person's face images.
+func faceImageFolder(person *model.Person) string {
+	return path.Join(common.FaceImagePath, person.ID.String())
+}
+
+// findFaceImageManifest returns the manifest file at the root of the archive, if any.
+func findFaceImageManifest(archive *zip.Reader) *zip.File {
+	for _, entry := range archive.File {
+		if strings.EqualFold(entry.Name, common.FaceImageManifestName) {
+			return entry
+		}
+	}
+	return nil
+}
+
+// readFaceImageManifest reads manifest.csv into a map of file name to person reference.
+func readFaceImageManifest(manifestFile *zip.File) (map[string]faceImageManifestEntry, error) {
+	src, err := manifestFile.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", common.FaceImageManifestName, err)
+	}
+	defer src.Close()
+
+	reader := csv.NewReader(src)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", common.FaceImageManifestName, err)
+	}
+	if len(rows) == 0 {
+		return nil, common.NewValidationError("%s is empty", common.FaceImageManifestName)
+	}
+
+	columns := map[string]int{}
+	for i, name := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	fileNameIndex, ok := columns["filename"]
+	if !ok {
+		return nil, common.NewValidationError("%s must have a fileName column", common.FaceImageManifestName)
+	}
+	personIDIndex, hasPersonID := columns["personid"]
+	emailIndex, hasEmail := columns["email"]
+	if !hasPersonID && !hasEmail {
+		return nil, common.NewValidationError("%s must have a personId or email column", common.FaceImageManifestName)
+	}
+
+	value := func(row []string, index int, ok bool) string {
+		if !ok || index >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[index])
+	}
+
+	manifest := make(map[string]faceImageManifestEntry, len(rows)-1)
+	for _, row := range rows[1:] {
+		fileName := value(row, fileNameIndex, true)
+		if fileName == "" {
+			continue
+		}
+		manifest[fileName] = faceImageManifestEntry{
+			personID: value(row, personIDIndex, hasPersonID),
+			email:    value(row, emailIndex, hasEmail),
+		}
+	}
+	return manifest, nil
+}
+
+// isFaceImageArchiveEntry skips folders and the hidden files added by macOS archivers.
+func isFaceImageArchiveEntry(entry *zip.File) bool {
+	if entry.FileInfo().IsDir() {
+		return false
+	}
+	if strings.HasPrefix(entry.Name, "__MACOSX/") || strings.HasPrefix(path.Base(entry.Name), ".") {
+		return false
+	}
+	return true
+}
+
+func isEmptyImportRow(row []string) bool {
+	for _, value := range row {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func intValue(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
+// emptyToNil treats an empty string like a missing value, for optional references.
+func emptyToNil(value *string) *string {
+	if value == nil || *value == "" {
+		return nil
+	}
+	return value
+}
+
+func dateValue(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return value.Format("2006-01-02")
+}
+
+func (s *personServiceImpl) validatePerson(personRepo repository.PersonRepository, isCreate bool, person *model.Person) error {
 	if person.PersonID != nil && *person.PersonID != "" {
-		isExist, err := s.personRepo.IsExistPersonID(*person.PersonID, person.ID)
+		isExist, err := personRepo.IsExistPersonID(*person.PersonID, person.ID)
 		if err != nil {
 			return fmt.Errorf("failed to check person ID existence: %w", err)
 		}
 		if isExist {
-			return fmt.Errorf("person ID already exists")
+			return common.NewConflictError("person ID already exists")
 		}
 	}
 
@@ -309,12 +1080,12 @@ func (s *personServiceImpl) validatePerson(isCreate bool, person *model.Person)
 	if !isCreate {
 		excludeID = person.ID
 	}
-	isExistName, err := s.personRepo.IsExistName(person.FirstName, person.LastName, excludeID)
+	isExistName, err := personRepo.IsExistName(person.FirstName, person.LastName, excludeID)
 	if err != nil {
 		return fmt.Errorf("failed to check person name existence: %w", err)
 	}
 	if isExistName {
-		return fmt.Errorf("person with the same name already exists")
+		return common.NewConflictError("person with the same name already exists")
 	}
 
 	return nil
@@ -328,30 +1099,20 @@ func (s *personServiceImpl) handleRelationships(
 	cardIDs []string,
 	licensePlateTexts []string,
 ) error {
-	// 1. Delete old cards
-	if err := txCardRepo.DeleteByPersonID(personID); err != nil {
-		return fmt.Errorf("failed to delete old person cards: %w", err)
-	}
-	// 2. Create new cards
-	if len(cardIDs) > 0 {
-		cards := make([]model.PersonCard, len(cardIDs))
-		for i, cardID := range cardIDs {
-			cards[i] = model.PersonCard{CardNumber: cardID, PersonID: personID}
-		}
-		if err := txCardRepo.Create(cards); err != nil {
-			return fmt.Errorf("failed to create new person cards: %w", err)
-		}
+	// 1. Sync cards: keep cards that are still listed so their status, validity and history survive
+	if err := s.syncPersonCards(txCardRepo, personID, cardIDs); err != nil {
+		return err
 	}
 
-	// 3. Delete old license plates
+	// 2. Delete old license plates
 	if err := txLicenseRepo.DeleteByPersonID(personID); err != nil {
 		return fmt.Errorf("failed to delete old person license plates: %w", err)
 	}
-	// 4. Create new license plates
+	// 3. Create new license plates
 	if len(licensePlateTexts) > 0 {
 		plates := make([]model.PersonLicensePlate, len(licensePlateTexts))
 		for i, text := range licensePlateTexts {
-			plates[i] = model.PersonLicensePlate{LicensePlateText: text, PersonID: personID}
+			plates[i] = model.PersonLicensePlate{LicensePlateText: text, NormalizedPlateText: common.NormalizeLicensePlate(text), PersonID: personID}
 		}
 		if err := txLicenseRepo.Create(plates); err != nil {
 			return fmt.Errorf("failed to create new person license plates: %w", err)
@@ -360,3 +1121,66 @@ func (s *personServiceImpl) handleRelationships(
 
 	return nil
 }
+
+// syncPersonCards makes the cards of a person match the given card numbers.
+// New numbers are issued as active cards, missing numbers are removed, and every change is
+// written to the card history.
+func (s *personServiceImpl) syncPersonCards(txCardRepo repository.PersonCardRepository, personID string, cardNumbers []string) error {
+	existingCards, err := txCardRepo.GetByPersonID(personID)
+	if err != nil {
+		return err
+	}
+
+	requested := make(map[string]bool, len(cardNumbers))
+	for _, cardNumber := range cardNumbers {
+		if cardNumber = strings.TrimSpace(cardNumber); cardNumber != "" {
+			requested[cardNumber] = true
+		}
+	}
+
+	var histories []model.PersonCardHistory
+	for _, card := range existingCards {
+		if requested[card.CardNumber] {
+			delete(requested, card.CardNumber)
+			continue
+		}
+		if err := txCardRepo.Delete(card.ID); err != nil {
+			return fmt.Errorf("failed to delete old person card: %w", err)
+		}
+		histories = append(histories, newPersonCardHistory(&card, common.PersonCardActionRemoved))
+	}
+
+	// Keep the request order for the new cards
+	var newCards []model.PersonCard
+	for _, cardNumber := range cardNumbers {
+		cardNumber = strings.TrimSpace(cardNumber)
+		if !requested[cardNumber] {
+			continue
+		}
+		delete(requested, cardNumber)
+
+		isExist, err := txCardRepo.IsExistCardNumber(cardNumber, uuid.Nil)
+		if err != nil {
+			return err
+		}
+		if isExist {
+			return common.NewConflictError("card number '%s' is already exist", cardNumber)
+		}
+		newCards = append(newCards, model.PersonCard{
+			CardNumber: cardNumber,
+			PersonID:   personID,
+			Status:     common.PersonCardStatusActive,
+		})
+	}
+	if err := txCardRepo.Create(newCards); err != nil {
+		return fmt.Errorf("failed to create new person cards: %w", err)
+	}
+	for i := range newCards {
+		histories = append(histories, newPersonCardHistory(&newCards[i], common.PersonCardActionIssued))
+	}
+
+	if err := txCardRepo.CreateHistory(histories); err != nil {
+		return fmt.Errorf("failed to create person card history: %w", err)
+	}
+	return nil
+}
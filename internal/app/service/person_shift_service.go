@@ -0,0 +1,231 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/repository"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// PersonShiftService defines the interface for the shift assignments and overrides of a person.
+type PersonShiftService interface {
+	GetAssignments(personID string) ([]model.PersonShiftAssignment, error)
+	CreateAssignment(personID string, bodyRequest *schema.PersonShiftAssignmentRequest) (*model.PersonShiftAssignment, error)
+	DeleteAssignment(personID string, assignmentID string) error
+	GetOverrides(personID string) ([]model.PersonShiftOverride, error)
+	SaveOverride(personID string, bodyRequest *schema.PersonShiftOverrideRequest) (*model.PersonShiftOverride, error)
+	DeleteOverride(personID string, overrideID string) error
+	ConvertAssignmentToResponse(assignmentModel *model.PersonShiftAssignment) (*schema.PersonShiftAssignmentResponse, error)
+	ConvertOverrideToResponse(overrideModel *model.PersonShiftOverride) (*schema.PersonShiftOverrideResponse, error)
+}
+
+type personShiftServiceImpl struct {
+	personShiftRepo   repository.PersonShiftRepository
+	shiftRotationRepo repository.ShiftRotationRepository
+	shiftTemplateRepo repository.ShiftTemplateRepository
+	personRepo        repository.PersonRepository
+}
+
+// NewPersonShiftService creates a new instance of PersonShiftService.
+func NewPersonShiftService(personShiftRepo repository.PersonShiftRepository, shiftRotationRepo repository.ShiftRotationRepository, shiftTemplateRepo repository.ShiftTemplateRepository, personRepo repository.PersonRepository) PersonShiftService {
+	return &personShiftServiceImpl{
+		personShiftRepo:   personShiftRepo,
+		shiftRotationRepo: shiftRotationRepo,
+		shiftTemplateRepo: shiftTemplateRepo,
+		personRepo:        personRepo,
+	}
+}
+
+// GetAssignments retrieves the rotation assignments of a person.
+func (s *personShiftServiceImpl) GetAssignments(personID string) ([]model.PersonShiftAssignment, error) {
+	if err := s.checkPerson(personID); err != nil {
+		return nil, err
+	}
+	return s.personShiftRepo.GetAssignmentsByPersonID(personID)
+}
+
+// CreateAssignment puts a person on a rotation. Assignments of a person cannot overlap.
+func (s *personShiftServiceImpl) CreateAssignment(personID string, bodyRequest *schema.PersonShiftAssignmentRequest) (*model.PersonShiftAssignment, error) {
+	if err := s.checkPerson(personID); err != nil {
+		return nil, err
+	}
+
+	rotationUUID, err := uuid.Parse(*bodyRequest.ShiftRotationID)
+	if err != nil {
+		return nil, common.NewValidationError("invalid shift rotation ID")
+	}
+	if _, err := s.shiftRotationRepo.GetByID(rotationUUID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewValidationError("shift rotation with ID '%s' does not exist", *bodyRequest.ShiftRotationID)
+		}
+		return nil, fmt.Errorf("failed to get shift rotation: %w", err)
+	}
+
+	endDate := emptyToNil(bodyRequest.EndDate)
+
+	assignments, err := s.personShiftRepo.GetAssignmentsByPersonID(personID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shift assignments: %w", err)
+	}
+	for _, assignment := range assignments {
+		if dateRangesOverlap(assignment.StartDate, assignment.EndDate, *bodyRequest.StartDate, endDate) {
+			return nil, common.NewConflictError("shift assignment overlaps the assignment starting %s", assignment.StartDate)
+		}
+	}
+
+	assignmentModel := &model.PersonShiftAssignment{
+		PersonID:        personID,
+		ShiftRotationID: *bodyRequest.ShiftRotationID,
+		StartDate:       *bodyRequest.StartDate,
+		EndDate:         endDate,
+	}
+	if err := s.personShiftRepo.CreateAssignment(assignmentModel); err != nil {
+		return nil, fmt.Errorf("failed to create shift assignment: %w", err)
+	}
+	return assignmentModel, nil
+}
+
+// DeleteAssignment removes a rotation assignment of a person.
+func (s *personShiftServiceImpl) DeleteAssignment(personID string, assignmentID string) error {
+	if err := s.checkPerson(personID); err != nil {
+		return err
+	}
+	assignmentUUID, err := uuid.Parse(assignmentID)
+	if err != nil {
+		return common.NewValidationError("invalid shift assignment ID")
+	}
+	assignment, err := s.personShiftRepo.GetAssignmentByID(assignmentUUID)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return fmt.Errorf("failed to get shift assignment: %w", err)
+	}
+	if assignment == nil || assignment.PersonID != personID {
+		return common.NewNotFoundError("shift assignment with ID '%s' not found", assignmentID)
+	}
+	if err := s.personShiftRepo.DeleteAssignment(assignmentUUID); err != nil {
+		return fmt.Errorf("failed to delete shift assignment: %w", err)
+	}
+	return nil
+}
+
+// GetOverrides retrieves the shift overrides of a person.
+func (s *personShiftServiceImpl) GetOverrides(personID string) ([]model.PersonShiftOverride, error) {
+	if err := s.checkPerson(personID); err != nil {
+		return nil, err
+	}
+	return s.personShiftRepo.GetOverridesByPersonID(personID)
+}
+
+// SaveOverride sets the shift of a person on a date, replacing an earlier override of that date.
+func (s *personShiftServiceImpl) SaveOverride(personID string, bodyRequest *schema.PersonShiftOverrideRequest) (*model.PersonShiftOverride, error) {
+	if err := s.checkPerson(personID); err != nil {
+		return nil, err
+	}
+	shiftTemplateID := emptyToNil(bodyRequest.ShiftTemplateID)
+	if shiftTemplateID != nil {
+		if _, err := getExistingShiftTemplate(s.shiftTemplateRepo, *shiftTemplateID); err != nil {
+			return nil, err
+		}
+	}
+
+	overrideModel, err := s.personShiftRepo.GetOverrideByPersonAndDate(personID, *bodyRequest.Date)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, fmt.Errorf("failed to get shift override: %w", err)
+	}
+	if overrideModel == nil {
+		overrideModel = &model.PersonShiftOverride{PersonID: personID, Date: *bodyRequest.Date}
+	}
+	overrideModel.ShiftTemplateID = shiftTemplateID
+	overrideModel.Reason = bodyRequest.Reason
+	if err := s.personShiftRepo.SaveOverride(overrideModel); err != nil {
+		return nil, fmt.Errorf("failed to save shift override: %w", err)
+	}
+	return overrideModel, nil
+}
+
+// DeleteOverride removes a shift override of a person.
+func (s *personShiftServiceImpl) DeleteOverride(personID string, overrideID string) error {
+	if err := s.checkPerson(personID); err != nil {
+		return err
+	}
+	overrideUUID, err := uuid.Parse(overrideID)
+	if err != nil {
+		return common.NewValidationError("invalid shift override ID")
+	}
+	override, err := s.personShiftRepo.GetOverrideByID(overrideUUID)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return fmt.Errorf("failed to get shift override: %w", err)
+	}
+	if override == nil || override.PersonID != personID {
+		return common.NewNotFoundError("shift override with ID '%s' not found", overrideID)
+	}
+	if err := s.personShiftRepo.DeleteOverride(overrideUUID); err != nil {
+		return fmt.Errorf("failed to delete shift override: %w", err)
+	}
+	return nil
+}
+
+// ConvertAssignmentToResponse converts a rotation assignment model to a response schema.
+func (s *personShiftServiceImpl) ConvertAssignmentToResponse(assignmentModel *model.PersonShiftAssignment) (*schema.PersonShiftAssignmentResponse, error) {
+	response := &schema.PersonShiftAssignmentResponse{
+		ID:        assignmentModel.ID.String(),
+		PersonID:  assignmentModel.PersonID,
+		StartDate: assignmentModel.StartDate,
+		EndDate:   assignmentModel.EndDate,
+	}
+	if rotationUUID, err := uuid.Parse(assignmentModel.ShiftRotationID); err == nil {
+		rotation, err := s.shiftRotationRepo.GetByID(rotationUUID)
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("failed to get shift rotation: %w", err)
+		}
+		if rotation != nil {
+			response.ShiftRotation = &schema.ShiftRotationInfoResponse{ID: rotation.ID.String(), Name: rotation.Name}
+		}
+	}
+	return response, nil
+}
+
+// ConvertOverrideToResponse converts a shift override model to a response schema.
+func (s *personShiftServiceImpl) ConvertOverrideToResponse(overrideModel *model.PersonShiftOverride) (*schema.PersonShiftOverrideResponse, error) {
+	response := &schema.PersonShiftOverrideResponse{
+		ID:       overrideModel.ID.String(),
+		PersonID: overrideModel.PersonID,
+		Date:     overrideModel.Date,
+		Reason:   overrideModel.Reason,
+	}
+	if overrideModel.ShiftTemplateID != nil {
+		template, err := getShiftTemplateInfo(s.shiftTemplateRepo, *overrideModel.ShiftTemplateID)
+		if err != nil {
+			return nil, err
+		}
+		response.ShiftTemplate = template
+	}
+	return response, nil
+}
+
+// ----------> INNER FUNCTION <-----------------------//
+
+// checkPerson checks that a person exists.
+func (s *personShiftServiceImpl) checkPerson(personID string) error {
+	personUUID, err := uuid.Parse(personID)
+	if err != nil {
+		return common.NewValidationError("invalid person ID")
+	}
+	if _, err := s.personRepo.GetByID(personUUID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return common.NewNotFoundError("person with ID '%s' not found", personID)
+		}
+		return fmt.Errorf("failed to get person: %w", err)
+	}
+	return nil
+}
+
+// dateRangesOverlap reports whether two inclusive "YYYY-MM-DD" ranges share a date. A nil end is open.
+func dateRangesOverlap(startA string, endA *string, startB string, endB *string) bool {
+	aEndsBeforeB := endA != nil && *endA < startB
+	bEndsBeforeA := endB != nil && *endB < startA
+	return !aEndsBeforeB && !bEndsBeforeA
+}
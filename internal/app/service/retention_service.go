@@ -0,0 +1,457 @@
+package service
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/repository"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// RetentionService purges data older than the retention policy. Access records, attendance
+// records and audit logs are archived to compressed NDJSON files before they are deleted, face
+// images and visitors are deleted without an archive.
+type RetentionService interface {
+	GetPolicy() schema.RetentionPolicyResponse
+	Run(trigger string, username string) (*model.RetentionRun, error)
+	GetAllRuns(searchQuery schema.RetentionRunSearchQuery) ([]model.RetentionRun, error)
+	GetRunByID(id string) (*model.RetentionRun, error)
+	ConvertRunToResponse(run *model.RetentionRun) (*schema.RetentionRunResponse, error)
+}
+
+// retentionServiceImpl is the implementation of RetentionService.
+type retentionServiceImpl struct {
+	retentionRunRepo     repository.RetentionRunRepository
+	accessRecordRepo     repository.AccessRecordRepository
+	attendanceRecordRepo repository.AttendanceRecordRepository
+	auditLogRepo         repository.AuditLogRepository
+	personRepo           repository.PersonRepository
+	visitorVehicleRepo   repository.VisitorVehicleRepository
+	userRepo             repository.UserRepository
+	fileRepo             repository.FileRepository
+	policy               common.RetentionPolicy
+	// running keeps a manual run from overlapping the scheduled one
+	running sync.Mutex
+}
+
+// NewRetentionService creates a new instance of RetentionService.
+func NewRetentionService(
+	retentionRunRepo repository.RetentionRunRepository,
+	accessRecordRepo repository.AccessRecordRepository,
+	attendanceRecordRepo repository.AttendanceRecordRepository,
+	auditLogRepo repository.AuditLogRepository,
+	personRepo repository.PersonRepository,
+	visitorVehicleRepo repository.VisitorVehicleRepository,
+	userRepo repository.UserRepository,
+	fileRepo repository.FileRepository,
+	policy common.RetentionPolicy,
+) RetentionService {
+	return &retentionServiceImpl{
+		retentionRunRepo:     retentionRunRepo,
+		accessRecordRepo:     accessRecordRepo,
+		attendanceRecordRepo: attendanceRecordRepo,
+		auditLogRepo:         auditLogRepo,
+		personRepo:           personRepo,
+		visitorVehicleRepo:   visitorVehicleRepo,
+		userRepo:             userRepo,
+		fileRepo:             fileRepo,
+		policy:               policy,
+	}
+}
+
+// accessRecordWithAnnotations is an access record with its annotations, as written to archives
+// and data exports.
+type accessRecordWithAnnotations struct {
+	model.AccessRecord
+	Annotations []model.AccessRecordAnnotation `json:"annotations,omitempty"`
+}
+
+// GetPolicy returns the configured retention periods.
+func (s *retentionServiceImpl) GetPolicy() schema.RetentionPolicyResponse {
+	return schema.RetentionPolicyResponse{
+		AccessRecordDays:     s.policy.AccessRecordDays,
+		AttendanceRecordDays: s.policy.AttendanceRecordDays,
+		FaceImageDays:        s.policy.FaceImageDays,
+		AuditLogDays:         s.policy.AuditLogDays,
+		VisitorDays:          s.policy.VisitorDays,
+	}
+}
+
+// Run purges every data class that has a retention period and records what was purged. A data
+// class that fails does not stop the others, the run is then marked failed with the errors.
+// Manual runs need a user with the system log permission.
+func (s *retentionServiceImpl) Run(trigger string, username string) (*model.RetentionRun, error) {
+	if !s.policy.Enabled() {
+		return nil, common.NewValidationError("no retention period is configured")
+	}
+	var triggeredBy *string
+	if trigger == common.RetentionTriggerManual {
+		if _, err := getSystemLogUser(s.userRepo, username); err != nil {
+			return nil, err
+		}
+		triggeredBy = &username
+	}
+	if !s.running.TryLock() {
+		return nil, common.NewConflictError("a retention run is already in progress")
+	}
+	defer s.running.Unlock()
+
+	run := &model.RetentionRun{
+		Trigger:     trigger,
+		TriggeredBy: triggeredBy,
+		Status:      common.RetentionRunStatusRunning,
+		StartedAt:   time.Now(),
+	}
+	if err := s.retentionRunRepo.Create(run); err != nil {
+		return nil, fmt.Errorf("failed to create retention run: %w", err)
+	}
+
+	// Visitors go before face images so their images are removed with them
+	steps := []struct {
+		dataClass string
+		days      int
+		purge     func(item *model.RetentionRunItem) error
+	}{
+		{common.RetentionAccessRecords, s.policy.AccessRecordDays, s.purgeAccessRecords},
+		{common.RetentionAttendanceRecords, s.policy.AttendanceRecordDays, s.purgeAttendanceRecords},
+		{common.RetentionVisitors, s.policy.VisitorDays, s.purgeVisitors},
+		{common.RetentionFaceImages, s.policy.FaceImageDays, s.purgeFaceImages},
+		{common.RetentionAuditLogs, s.policy.AuditLogDays, s.purgeAuditLogs},
+	}
+	var failures, summary []string
+	for _, step := range steps {
+		if step.days <= 0 {
+			continue
+		}
+		item := &model.RetentionRunItem{
+			RunID:     run.ID.String(),
+			DataClass: step.dataClass,
+			Cutoff:    run.StartedAt.AddDate(0, 0, -step.days),
+		}
+		err := step.purge(item)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", step.dataClass, err))
+		}
+		if err == nil || item.Purged > 0 {
+			if err := s.retentionRunRepo.CreateItem(item); err != nil {
+				failures = append(failures, fmt.Sprintf("%s: failed to create retention run item: %v", step.dataClass, err))
+			}
+			summary = append(summary, fmt.Sprintf("%s: %d", step.dataClass, item.Purged))
+		}
+	}
+
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	run.Status = common.RetentionRunStatusCompleted
+	if len(failures) > 0 {
+		runError := strings.Join(failures, "; ")
+		run.Status = common.RetentionRunStatusFailed
+		run.Error = &runError
+	}
+	if err := s.retentionRunRepo.Update(run); err != nil {
+		return nil, fmt.Errorf("failed to update retention run: %w", err)
+	}
+
+	writeAuditLog(s.auditLogRepo, common.AuditActionRetentionPurge, common.AuditEntityRetentionRun, run.ID.String(), username, strings.Join(summary, ", "))
+	return run, nil
+}
+
+func (s *retentionServiceImpl) GetAllRuns(searchQuery schema.RetentionRunSearchQuery) ([]model.RetentionRun, error) {
+	return s.retentionRunRepo.GetAll(searchQuery)
+}
+
+func (s *retentionServiceImpl) GetRunByID(id string) (*model.RetentionRun, error) {
+	idUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, common.NewFieldError("id", "invalid ID")
+	}
+	run, err := s.retentionRunRepo.GetByID(idUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("retention run with ID '%s' not found", id)
+		}
+		return nil, fmt.Errorf("failed to get retention run: %w", err)
+	}
+	return run, nil
+}
+
+// ConvertRunToResponse converts a retention run model to a response schema with what it purged.
+func (s *retentionServiceImpl) ConvertRunToResponse(run *model.RetentionRun) (*schema.RetentionRunResponse, error) {
+	items, err := s.retentionRunRepo.GetItemsByRunID(run.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get retention run items: %w", err)
+	}
+
+	itemResponses := make([]schema.RetentionRunItemResponse, len(items))
+	for i, item := range items {
+		itemResponses[i] = schema.RetentionRunItemResponse{
+			DataClass:  item.DataClass,
+			Cutoff:     common.FormatTimestamp(item.Cutoff, nil),
+			Purged:     item.Purged,
+			ArchiveURL: common.GetImageURL(common.FileURLPrefix, stringValue(item.ArchivePath)),
+			Detail:     item.Detail,
+		}
+	}
+
+	return &schema.RetentionRunResponse{
+		ID:          run.ID.String(),
+		Trigger:     run.Trigger,
+		TriggeredBy: run.TriggeredBy,
+		Status:      run.Status,
+		StartedAt:   common.FormatTimestamp(run.StartedAt, nil),
+		FinishedAt:  formatOptionalTime(run.FinishedAt),
+		Error:       run.Error,
+		Items:       itemResponses,
+	}, nil
+}
+
+// purgeAccessRecords archives and deletes the start of the hash chain older than the cutoff, with
+// the annotations and plate snapshots of the records. The chain continues from a checkpoint.
+func (s *retentionServiceImpl) purgeAccessRecords(item *model.RetentionRunItem) error {
+	through, err := s.accessRecordRepo.GetPurgeBoundary(item.Cutoff)
+	if err != nil {
+		return err
+	}
+	checkpoint, err := s.accessRecordRepo.GetChainCheckpoint()
+	if err != nil {
+		return err
+	}
+	first := int64(1)
+	if checkpoint != nil {
+		first = checkpoint.Sequence + 1
+	}
+	if through < first {
+		return nil
+	}
+
+	var plateImagePaths []string
+	archivePath, err := writeArchive(s.fileRepo, item.DataClass, func(encoder *json.Encoder) error {
+		after := first - 1
+		for after < through {
+			records, err := s.accessRecordRepo.GetChain(after, common.RetentionBatchSize)
+			if err != nil || len(records) == 0 {
+				return err
+			}
+			recordIDs := make([]string, len(records))
+			for i := range records {
+				recordIDs[i] = records[i].ID.String()
+			}
+			annotations, err := s.accessRecordRepo.GetAnnotationsByRecordIDs(recordIDs)
+			if err != nil {
+				return fmt.Errorf("failed to get access record annotations: %w", err)
+			}
+			annotationsByRecord := make(map[string][]model.AccessRecordAnnotation)
+			for _, annotation := range annotations {
+				annotationsByRecord[annotation.AccessRecordID] = append(annotationsByRecord[annotation.AccessRecordID], annotation)
+			}
+
+			for _, record := range records {
+				if record.Sequence > through {
+					return nil
+				}
+				if err := encoder.Encode(accessRecordWithAnnotations{AccessRecord: record, Annotations: annotationsByRecord[record.ID.String()]}); err != nil {
+					return err
+				}
+				if record.PlateImagePath != nil && *record.PlateImagePath != "" {
+					plateImagePaths = append(plateImagePaths, *record.PlateImagePath)
+				}
+				after = record.Sequence
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	purged, err := s.accessRecordRepo.PurgeChain(through, item.RunID)
+	if err != nil {
+		deleteStoredFile(s.fileRepo, archivePath)
+		return err
+	}
+	detail := fmt.Sprintf("sequences %d to %d", first, through)
+	item.Purged = purged
+	item.ArchivePath = &archivePath
+	item.Detail = &detail
+	deletePlateImages(s.fileRepo, s.visitorVehicleRepo, plateImagePaths)
+	return nil
+}
+
+// purgeAttendanceRecords archives and deletes the attendance records of dates before the cutoff.
+func (s *retentionServiceImpl) purgeAttendanceRecords(item *model.RetentionRunItem) error {
+	date := item.Cutoff.Format("2006-01-02")
+	archivePath, ids, err := archiveBatches(s.fileRepo, item.DataClass, func(afterID uuid.UUID) ([]model.AttendanceRecord, error) {
+		return s.attendanceRecordRepo.GetBefore(date, afterID, common.RetentionBatchSize)
+	}, func(record *model.AttendanceRecord) uuid.UUID {
+		return record.ID
+	})
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+
+	purged, err := deleteInBatches(ids, s.attendanceRecordRepo.DeleteByIDs)
+	item.Purged = purged
+	item.ArchivePath = &archivePath
+	return err
+}
+
+// purgeAuditLogs archives and deletes the audit logs created before the cutoff.
+func (s *retentionServiceImpl) purgeAuditLogs(item *model.RetentionRunItem) error {
+	archivePath, ids, err := archiveBatches(s.fileRepo, item.DataClass, func(afterID uuid.UUID) ([]model.AuditLog, error) {
+		return s.auditLogRepo.GetBefore(item.Cutoff, afterID, common.RetentionBatchSize)
+	}, func(auditLog *model.AuditLog) uuid.UUID {
+		return auditLog.ID
+	})
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+
+	purged, err := deleteInBatches(ids, s.auditLogRepo.DeleteByIDs)
+	item.Purged = purged
+	item.ArchivePath = &archivePath
+	return err
+}
+
+// purgeVisitors deletes the visitors that expired before the cutoff with their cards, plates,
+// shifts and face images. Their access records are kept until they expire themselves.
+func (s *retentionServiceImpl) purgeVisitors(item *model.RetentionRunItem) error {
+	visitors, err := s.personRepo.GetExpired(common.PersonTypeVisitor, item.Cutoff)
+	if err != nil {
+		return err
+	}
+	for i := range visitors {
+		if err := s.personRepo.Delete(visitors[i].ID); err != nil {
+			return fmt.Errorf("failed to delete visitor '%s': %w", visitors[i].ID, err)
+		}
+		for _, imagePath := range faceImagePaths(&visitors[i]) {
+			deleteStoredFile(s.fileRepo, imagePath)
+		}
+		item.Purged++
+	}
+	return nil
+}
+
+// purgeFaceImages deletes the face images of people that expired before the cutoff. The people
+// themselves are kept.
+func (s *retentionServiceImpl) purgeFaceImages(item *model.RetentionRunItem) error {
+	people, err := s.personRepo.GetExpiredWithFaceImage(item.Cutoff)
+	if err != nil {
+		return err
+	}
+	for i := range people {
+		if err := s.personRepo.ClearFaceImage(people[i].ID.String()); err != nil {
+			return fmt.Errorf("failed to clear face image of person '%s': %w", people[i].ID, err)
+		}
+		for _, imagePath := range faceImagePaths(&people[i]) {
+			deleteStoredFile(s.fileRepo, imagePath)
+		}
+		item.Purged++
+	}
+	return nil
+}
+
+// deleteStoredFile deletes a stored file of purged or erased data. Failures are only logged, the
+// rows are already gone.
+func deleteStoredFile(fileRepo repository.FileRepository, filePath string) {
+	if err := fileRepo.Delete(filePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("failed to delete file '%s': %v", filePath, err)
+	}
+}
+
+// deletePlateImages deletes the plate snapshots of access records, except the ones a visitor
+// vehicle still shows as its last snapshot.
+func deletePlateImages(fileRepo repository.FileRepository, visitorVehicleRepo repository.VisitorVehicleRepository, imagePaths []string) {
+	for _, imagePath := range imagePaths {
+		inUse, err := visitorVehicleRepo.IsExistImagePath(imagePath)
+		if err != nil {
+			log.Printf("failed to check plate image '%s': %v", imagePath, err)
+			continue
+		}
+		if !inUse {
+			deleteStoredFile(fileRepo, imagePath)
+		}
+	}
+}
+
+// writeArchive streams the lines written by write into a gzip compressed NDJSON file of the data
+// class and returns its path. Archives are kept in one folder per day.
+func writeArchive(fileRepo repository.FileRepository, dataClass string, write func(encoder *json.Encoder) error) (string, error) {
+	reader, writer := io.Pipe()
+	go func() {
+		gzipWriter := gzip.NewWriter(writer)
+		err := write(json.NewEncoder(gzipWriter))
+		if closeErr := gzipWriter.Close(); err == nil {
+			err = closeErr
+		}
+		writer.CloseWithError(err)
+	}()
+
+	folder := path.Join(common.RetentionArchivePath, time.Now().Format("2006-01-02"))
+	archivePath, err := fileRepo.SaveReader(reader, dataClass+common.RetentionArchiveExt, folder)
+	// Stops the writer when the storage gave up before the end
+	reader.Close()
+	if err != nil {
+		return "", fmt.Errorf("failed to archive %s: %w", dataClass, err)
+	}
+	return archivePath, nil
+}
+
+// archiveBatches archives the rows returned batch by batch by next, which gets the ID of the last
+// archived row, and returns the archive path and the IDs of the archived rows. Nothing is written
+// when there are no rows.
+func archiveBatches[T any](fileRepo repository.FileRepository, dataClass string, next func(afterID uuid.UUID) ([]T, error), idOf func(row *T) uuid.UUID) (string, []uuid.UUID, error) {
+	batch, err := next(uuid.Nil)
+	if err != nil || len(batch) == 0 {
+		return "", nil, err
+	}
+
+	var ids []uuid.UUID
+	archivePath, err := writeArchive(fileRepo, dataClass, func(encoder *json.Encoder) error {
+		for len(batch) > 0 {
+			for i := range batch {
+				if err := encoder.Encode(&batch[i]); err != nil {
+					return err
+				}
+				ids = append(ids, idOf(&batch[i]))
+			}
+			if len(batch) < common.RetentionBatchSize {
+				return nil
+			}
+			var err error
+			if batch, err = next(ids[len(ids)-1]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return archivePath, ids, nil
+}
+
+// deleteInBatches deletes rows by ID in batches and returns how many were deleted.
+func deleteInBatches(ids []uuid.UUID, deleteByIDs func(ids []uuid.UUID) (int, error)) (int, error) {
+	deleted := 0
+	for start := 0; start < len(ids); start += common.RetentionBatchSize {
+		count, err := deleteByIDs(ids[start:min(start+common.RetentionBatchSize, len(ids))])
+		deleted += count
+		if err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/repository"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// ShiftRotationService defines the interface for shift rotation business logic.
+type ShiftRotationService interface {
+	GetAll(searchQuery schema.ShiftRotationSearchQuery) ([]model.ShiftRotation, error)
+	GetByID(id string) (*model.ShiftRotation, error)
+	Create(bodyRequest *schema.ShiftRotationRequest) (*model.ShiftRotation, error)
+	Update(id string, bodyRequest *schema.ShiftRotationRequest) (*model.ShiftRotation, error)
+	Delete(id string) error
+	ConvertToResponse(rotationModel *model.ShiftRotation) (*schema.ShiftRotationResponse, error)
+}
+
+type shiftRotationServiceImpl struct {
+	shiftRotationRepo repository.ShiftRotationRepository
+	shiftTemplateRepo repository.ShiftTemplateRepository
+	db                *gorm.DB
+}
+
+// NewShiftRotationService creates a new instance of ShiftRotationService.
+func NewShiftRotationService(shiftRotationRepo repository.ShiftRotationRepository, shiftTemplateRepo repository.ShiftTemplateRepository, db *gorm.DB) ShiftRotationService {
+	return &shiftRotationServiceImpl{
+		shiftRotationRepo: shiftRotationRepo,
+		shiftTemplateRepo: shiftTemplateRepo,
+		db:                db,
+	}
+}
+
+// GetAll retrieves shift rotations.
+func (s *shiftRotationServiceImpl) GetAll(searchQuery schema.ShiftRotationSearchQuery) ([]model.ShiftRotation, error) {
+	return s.shiftRotationRepo.GetAll(searchQuery)
+}
+
+// GetByID retrieves a shift rotation by its ID.
+func (s *shiftRotationServiceImpl) GetByID(id string) (*model.ShiftRotation, error) {
+	idUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, common.NewFieldError("id", "invalid ID")
+	}
+	rotation, err := s.shiftRotationRepo.GetByID(idUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("shift rotation with ID '%s' not found", id)
+		}
+		return nil, fmt.Errorf("failed to get shift rotation: %w", err)
+	}
+	return rotation, nil
+}
+
+// Create creates a shift rotation with its cycle.
+func (s *shiftRotationServiceImpl) Create(bodyRequest *schema.ShiftRotationRequest) (*model.ShiftRotation, error) {
+	if err := s.validateBodyRequest(bodyRequest, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	rotationModel := &model.ShiftRotation{
+		Name:       *bodyRequest.Name,
+		AnchorDate: *bodyRequest.AnchorDate,
+		CycleDays:  len(bodyRequest.Days),
+	}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		txRepo := repository.NewShiftRotationRepository(tx)
+		if err := txRepo.Create(rotationModel); err != nil {
+			return fmt.Errorf("failed to create shift rotation: %w", err)
+		}
+		if err := txRepo.CreateDays(createShiftRotationDayModels(rotationModel.ID.String(), bodyRequest.Days)); err != nil {
+			return fmt.Errorf("failed to create shift rotation days: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rotationModel, nil
+}
+
+// Update replaces a shift rotation and its cycle.
+func (s *shiftRotationServiceImpl) Update(id string, bodyRequest *schema.ShiftRotationRequest) (*model.ShiftRotation, error) {
+	rotationModel, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateBodyRequest(bodyRequest, rotationModel.ID); err != nil {
+		return nil, err
+	}
+
+	rotationModel.Name = *bodyRequest.Name
+	rotationModel.AnchorDate = *bodyRequest.AnchorDate
+	rotationModel.CycleDays = len(bodyRequest.Days)
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		txRepo := repository.NewShiftRotationRepository(tx)
+		if err := txRepo.Update(rotationModel); err != nil {
+			return fmt.Errorf("failed to update shift rotation: %w", err)
+		}
+		if err := txRepo.DeleteDaysByRotationID(rotationModel.ID, tx); err != nil {
+			return fmt.Errorf("failed to delete old shift rotation days: %w", err)
+		}
+		if err := txRepo.CreateDays(createShiftRotationDayModels(id, bodyRequest.Days)); err != nil {
+			return fmt.Errorf("failed to create shift rotation days: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rotationModel, nil
+}
+
+// Delete deletes a shift rotation that is not assigned to anybody.
+func (s *shiftRotationServiceImpl) Delete(id string) error {
+	rotationModel, err := s.GetByID(id)
+	if err != nil {
+		return err
+	}
+	isInUse, err := s.shiftRotationRepo.IsInUse(rotationModel.ID)
+	if err != nil {
+		return err
+	}
+	if isInUse {
+		return common.NewConflictError("shift rotation '%s' is assigned to people", rotationModel.Name)
+	}
+	if err := s.shiftRotationRepo.Delete(rotationModel.ID); err != nil {
+		return fmt.Errorf("failed to delete shift rotation: %w", err)
+	}
+	return nil
+}
+
+// ConvertToResponse converts a shift rotation model to a response schema listing every day of the cycle.
+func (s *shiftRotationServiceImpl) ConvertToResponse(rotationModel *model.ShiftRotation) (*schema.ShiftRotationResponse, error) {
+	days, err := s.shiftRotationRepo.GetDaysByRotationID(rotationModel.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shift rotation days: %w", err)
+	}
+
+	dayResponses := make([]schema.ShiftRotationDayResponse, rotationModel.CycleDays)
+	for i := range dayResponses {
+		dayResponses[i].DayIndex = i
+	}
+	templates := map[string]*schema.ShiftTemplateInfoResponse{}
+	for _, day := range days {
+		if day.DayIndex < 0 || day.DayIndex >= rotationModel.CycleDays {
+			continue
+		}
+		template, ok := templates[day.ShiftTemplateID]
+		if !ok {
+			template, err = getShiftTemplateInfo(s.shiftTemplateRepo, day.ShiftTemplateID)
+			if err != nil {
+				return nil, err
+			}
+			templates[day.ShiftTemplateID] = template
+		}
+		dayResponses[day.DayIndex].ShiftTemplate = template
+	}
+
+	return &schema.ShiftRotationResponse{
+		ID:         rotationModel.ID.String(),
+		Name:       rotationModel.Name,
+		AnchorDate: rotationModel.AnchorDate,
+		CycleDays:  rotationModel.CycleDays,
+		Days:       dayResponses,
+	}, nil
+}
+
+// ----------> INNER FUNCTION <-----------------------//
+
+// validateBodyRequest checks the name, the anchor date and the shift templates of the cycle.
+func (s *shiftRotationServiceImpl) validateBodyRequest(bodyRequest *schema.ShiftRotationRequest, excludeID uuid.UUID) error {
+	if *bodyRequest.Name == "" {
+		return common.NewFieldError("name", "shift rotation name cannot be empty")
+	}
+	isExistName, err := s.shiftRotationRepo.IsExistName(*bodyRequest.Name, excludeID)
+	if err != nil {
+		return err
+	}
+	if isExistName {
+		return common.NewConflictError("shift rotation name is already exist")
+	}
+
+	hasShift := false
+	checked := map[string]bool{}
+	for i, shiftTemplateID := range bodyRequest.Days {
+		if shiftTemplateID == nil || *shiftTemplateID == "" {
+			bodyRequest.Days[i] = nil
+			continue
+		}
+		hasShift = true
+		if checked[*shiftTemplateID] {
+			continue
+		}
+		if _, err := getExistingShiftTemplate(s.shiftTemplateRepo, *shiftTemplateID); err != nil {
+			return err
+		}
+		checked[*shiftTemplateID] = true
+	}
+	if !hasShift {
+		return common.NewValidationError("shift rotation must have at least one working day")
+	}
+	return nil
+}
+
+// createShiftRotationDayModels converts the cycle of a request into rotation days. Days off have no row.
+func createShiftRotationDayModels(rotationID string, days []*string) []model.ShiftRotationDay {
+	var dayModels []model.ShiftRotationDay
+	for i, shiftTemplateID := range days {
+		if shiftTemplateID == nil {
+			continue
+		}
+		dayModels = append(dayModels, model.ShiftRotationDay{
+			ShiftRotationID: rotationID,
+			DayIndex:        i,
+			ShiftTemplateID: *shiftTemplateID,
+		})
+	}
+	return dayModels
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/repository"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// ShiftTemplateService defines the interface for shift template business logic.
+type ShiftTemplateService interface {
+	GetAll(searchQuery schema.ShiftTemplateSearchQuery) ([]model.ShiftTemplate, error)
+	GetByID(id string) (*model.ShiftTemplate, error)
+	Create(bodyRequest *schema.ShiftTemplateRequest) (*model.ShiftTemplate, error)
+	Update(id string, bodyRequest *schema.ShiftTemplateRequest) (*model.ShiftTemplate, error)
+	Delete(id string) error
+	ConvertToResponse(templateModel *model.ShiftTemplate) *schema.ShiftTemplateResponse
+}
+
+type shiftTemplateServiceImpl struct {
+	shiftTemplateRepo repository.ShiftTemplateRepository
+}
+
+// NewShiftTemplateService creates a new instance of ShiftTemplateService.
+func NewShiftTemplateService(shiftTemplateRepo repository.ShiftTemplateRepository) ShiftTemplateService {
+	return &shiftTemplateServiceImpl{shiftTemplateRepo: shiftTemplateRepo}
+}
+
+// GetAll retrieves shift templates.
+func (s *shiftTemplateServiceImpl) GetAll(searchQuery schema.ShiftTemplateSearchQuery) ([]model.ShiftTemplate, error) {
+	return s.shiftTemplateRepo.GetAll(searchQuery)
+}
+
+// GetByID retrieves a shift template by its ID.
+func (s *shiftTemplateServiceImpl) GetByID(id string) (*model.ShiftTemplate, error) {
+	idUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, common.NewFieldError("id", "invalid ID")
+	}
+	template, err := s.shiftTemplateRepo.GetByID(idUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("shift template with ID '%s' not found", id)
+		}
+		return nil, fmt.Errorf("failed to get shift template: %w", err)
+	}
+	return template, nil
+}
+
+// Create creates a shift template.
+func (s *shiftTemplateServiceImpl) Create(bodyRequest *schema.ShiftTemplateRequest) (*model.ShiftTemplate, error) {
+	if err := s.validateBodyRequest(bodyRequest, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	templateModel := &model.ShiftTemplate{}
+	applyShiftTemplateRequest(templateModel, bodyRequest)
+	if err := s.shiftTemplateRepo.Create(templateModel); err != nil {
+		return nil, fmt.Errorf("failed to create shift template: %w", err)
+	}
+	return templateModel, nil
+}
+
+// Update replaces a shift template. Attendance already calculated keeps the old hours until it is recalculated.
+func (s *shiftTemplateServiceImpl) Update(id string, bodyRequest *schema.ShiftTemplateRequest) (*model.ShiftTemplate, error) {
+	templateModel, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateBodyRequest(bodyRequest, templateModel.ID); err != nil {
+		return nil, err
+	}
+
+	applyShiftTemplateRequest(templateModel, bodyRequest)
+	if err := s.shiftTemplateRepo.Update(templateModel); err != nil {
+		return nil, fmt.Errorf("failed to update shift template: %w", err)
+	}
+	return templateModel, nil
+}
+
+// Delete deletes a shift template that no rotation or override uses.
+func (s *shiftTemplateServiceImpl) Delete(id string) error {
+	templateModel, err := s.GetByID(id)
+	if err != nil {
+		return err
+	}
+	isInUse, err := s.shiftTemplateRepo.IsInUse(templateModel.ID)
+	if err != nil {
+		return err
+	}
+	if isInUse {
+		return common.NewConflictError("shift template '%s' is used by a shift rotation or a shift override", templateModel.Name)
+	}
+	if err := s.shiftTemplateRepo.Delete(templateModel.ID); err != nil {
+		return fmt.Errorf("failed to delete shift template: %w", err)
+	}
+	return nil
+}
+
+// ConvertToResponse converts a shift template model to a response schema.
+func (s *shiftTemplateServiceImpl) ConvertToResponse(templateModel *model.ShiftTemplate) *schema.ShiftTemplateResponse {
+	return &schema.ShiftTemplateResponse{
+		ID:              templateModel.ID.String(),
+		Name:            templateModel.Name,
+		StartTime:       templateModel.StartTime,
+		EndTime:         templateModel.EndTime,
+		CrossesMidnight: common.ShiftCrossesMidnight(templateModel.StartTime, templateModel.EndTime),
+		EarlyInMinutes:  templateModel.EarlyInMinutes,
+		LateInMinutes:   templateModel.LateInMinutes,
+		EarlyOutMinutes: templateModel.EarlyOutMinutes,
+		LateOutMinutes:  templateModel.LateOutMinutes,
+	}
+}
+
+// ----------> INNER FUNCTION <-----------------------//
+
+// validateBodyRequest checks the name and the shift hours.
+func (s *shiftTemplateServiceImpl) validateBodyRequest(bodyRequest *schema.ShiftTemplateRequest, excludeID uuid.UUID) error {
+	isExistName, err := s.shiftTemplateRepo.IsExistName(*bodyRequest.Name, excludeID)
+	if err != nil {
+		return err
+	}
+	if isExistName {
+		return common.NewConflictError("shift template name is already exist")
+	}
+	if normalizeClock(*bodyRequest.StartTime) == normalizeClock(*bodyRequest.EndTime) {
+		return common.NewValidationError("shift start time and end time cannot be the same")
+	}
+	return nil
+}
+
+// applyShiftTemplateRequest copies a validated request into a shift template model.
+func applyShiftTemplateRequest(templateModel *model.ShiftTemplate, bodyRequest *schema.ShiftTemplateRequest) {
+	templateModel.Name = *bodyRequest.Name
+	templateModel.StartTime = normalizeClock(*bodyRequest.StartTime)
+	templateModel.EndTime = normalizeClock(*bodyRequest.EndTime)
+	templateModel.EarlyInMinutes = intValue(bodyRequest.EarlyInMinutes)
+	templateModel.LateInMinutes = intValue(bodyRequest.LateInMinutes)
+	templateModel.EarlyOutMinutes = intValue(bodyRequest.EarlyOutMinutes)
+	templateModel.LateOutMinutes = intValue(bodyRequest.LateOutMinutes)
+}
+
+// getShiftTemplateInfo returns the short response of a shift template, nil when it no longer exists.
+func getShiftTemplateInfo(shiftTemplateRepo repository.ShiftTemplateRepository, shiftTemplateID string) (*schema.ShiftTemplateInfoResponse, error) {
+	templateUUID, err := uuid.Parse(shiftTemplateID)
+	if err != nil {
+		return nil, nil
+	}
+	template, err := shiftTemplateRepo.GetByID(templateUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get shift template: %w", err)
+	}
+	return &schema.ShiftTemplateInfoResponse{
+		ID:        template.ID.String(),
+		Name:      template.Name,
+		StartTime: template.StartTime,
+		EndTime:   template.EndTime,
+	}, nil
+}
+
+// getExistingShiftTemplate loads a shift template referenced by a request.
+func getExistingShiftTemplate(shiftTemplateRepo repository.ShiftTemplateRepository, shiftTemplateID string) (*model.ShiftTemplate, error) {
+	templateUUID, err := uuid.Parse(shiftTemplateID)
+	if err != nil {
+		return nil, common.NewValidationError("invalid shift template ID '%s'", shiftTemplateID)
+	}
+	template, err := shiftTemplateRepo.GetByID(templateUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewValidationError("shift template with ID '%s' does not exist", shiftTemplateID)
+		}
+		return nil, fmt.Errorf("failed to get shift template: %w", err)
+	}
+	return template, nil
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/repository"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+var tenantCodePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// TenantService defines the interface for tenant business logic. Only super admins manage
+// tenants, see the tenant routes.
+type TenantService interface {
+	GetAll(searchQuery schema.TenantSearchQuery) ([]model.Tenant, error)
+	GetByID(id string) (*model.Tenant, error)
+	Create(bodyRequest *schema.TenantRequest) (*model.Tenant, error)
+	Update(id string, bodyRequest *schema.TenantRequest) (*model.Tenant, error)
+	Delete(id string) error
+	ConvertToResponse(tenantModel *model.Tenant) *schema.TenantResponse
+}
+
+type tenantServiceImpl struct {
+	tenantRepo repository.TenantRepository
+	// onTenantChanged is called with the ID of a tenant after it is updated or deleted, so what
+	// is kept of it in memory, such as its router, is dropped
+	onTenantChanged func(tenantID string)
+}
+
+// NewTenantService creates a new instance of TenantService.
+func NewTenantService(tenantRepo repository.TenantRepository, onTenantChanged func(tenantID string)) TenantService {
+	return &tenantServiceImpl{tenantRepo: tenantRepo, onTenantChanged: onTenantChanged}
+}
+
+// GetAll retrieves tenants.
+func (s *tenantServiceImpl) GetAll(searchQuery schema.TenantSearchQuery) ([]model.Tenant, error) {
+	return s.tenantRepo.GetAll(searchQuery)
+}
+
+// GetByID retrieves a tenant by its ID.
+func (s *tenantServiceImpl) GetByID(id string) (*model.Tenant, error) {
+	idUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, common.NewFieldError("id", "invalid ID")
+	}
+	tenant, err := s.tenantRepo.GetByID(idUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("tenant with ID '%s' not found", id)
+		}
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+	return tenant, nil
+}
+
+// Create creates a tenant.
+func (s *tenantServiceImpl) Create(bodyRequest *schema.TenantRequest) (*model.Tenant, error) {
+	if err := s.validateBodyRequest(bodyRequest, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	tenantModel := &model.Tenant{IsActive: true}
+	applyTenantRequest(tenantModel, bodyRequest)
+	if err := s.tenantRepo.Create(tenantModel); err != nil {
+		return nil, fmt.Errorf("failed to create tenant: %w", err)
+	}
+	return tenantModel, nil
+}
+
+// Update replaces a tenant. Deactivating a tenant rejects the logins and tokens of its users.
+func (s *tenantServiceImpl) Update(id string, bodyRequest *schema.TenantRequest) (*model.Tenant, error) {
+	tenantModel, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateBodyRequest(bodyRequest, tenantModel.ID); err != nil {
+		return nil, err
+	}
+
+	applyTenantRequest(tenantModel, bodyRequest)
+	if err := s.tenantRepo.Update(tenantModel); err != nil {
+		return nil, fmt.Errorf("failed to update tenant: %w", err)
+	}
+	s.onTenantChanged(tenantModel.ID.String())
+	return tenantModel, nil
+}
+
+// Delete deletes a tenant without users or people.
+func (s *tenantServiceImpl) Delete(id string) error {
+	tenantModel, err := s.GetByID(id)
+	if err != nil {
+		return err
+	}
+	isInUse, err := s.tenantRepo.IsInUse(tenantModel.ID)
+	if err != nil {
+		return err
+	}
+	if isInUse {
+		return common.NewConflictError("tenant '%s' still has users or people, deactivate it instead", tenantModel.Name)
+	}
+	if err := s.tenantRepo.Delete(tenantModel.ID); err != nil {
+		return fmt.Errorf("failed to delete tenant: %w", err)
+	}
+	s.onTenantChanged(tenantModel.ID.String())
+	return nil
+}
+
+// ConvertToResponse converts a tenant model to a response schema.
+func (s *tenantServiceImpl) ConvertToResponse(tenantModel *model.Tenant) *schema.TenantResponse {
+	return &schema.TenantResponse{
+		ID:       tenantModel.ID.String(),
+		Name:     tenantModel.Name,
+		Code:     tenantModel.Code,
+		IsActive: tenantModel.IsActive,
+	}
+}
+
+// ----------> INNER FUNCTION <-----------------------//
+
+// validateBodyRequest checks the code of a tenant.
+func (s *tenantServiceImpl) validateBodyRequest(bodyRequest *schema.TenantRequest, excludeID uuid.UUID) error {
+	code := strings.TrimSpace(*bodyRequest.Code)
+	if !tenantCodePattern.MatchString(code) {
+		return common.NewFieldError("code", "tenant code '%s' must be lowercase letters, digits and dashes", code)
+	}
+	isExist, err := s.tenantRepo.IsExistCode(code, excludeID)
+	if err != nil {
+		return err
+	}
+	if isExist {
+		return common.NewConflictError("tenant code '%s' already exists", code)
+	}
+	return nil
+}
+
+// applyTenantRequest copies a request onto a tenant model.
+func applyTenantRequest(tenantModel *model.Tenant, bodyRequest *schema.TenantRequest) {
+	tenantModel.Name = strings.TrimSpace(*bodyRequest.Name)
+	tenantModel.Code = strings.TrimSpace(*bodyRequest.Code)
+	if bodyRequest.IsActive != nil {
+		tenantModel.IsActive = *bodyRequest.IsActive
+	}
+}
@@ -48,13 +48,13 @@ func (s *userServiceImpl) GetAll(searchQuery schema.UserSearchQuery) ([]model.Us
 func (s *userServiceImpl) GetByID(id string) (*model.User, error) {
 	idUUID, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid ID")
+		return nil, common.NewFieldError("id", "invalid ID")
 	}
 
 	userModel, err := s.userRepo.GetByID(idUUID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("user with ID '%s' not found", id)
+			return nil, common.NewNotFoundError("user with ID '%s' not found", id)
 		}
 		return nil, err
 	}
@@ -103,14 +103,14 @@ func (s *userServiceImpl) Create(bodyRequest *schema.UserRequest) (*model.User,
 func (s *userServiceImpl) Update(id string, bodyRequest *schema.UserRequest) (*model.User, error) {
 	idUUID, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid ID")
+		return nil, common.NewFieldError("id", "invalid ID")
 	}
 
 	// 1. Get existing user model (Preloaded with Permission)
 	userModel, err := s.userRepo.GetByID(idUUID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("user with ID '%s' not found", id)
+			return nil, common.NewNotFoundError("user with ID '%s' not found", id)
 		}
 		return nil, fmt.Errorf("failed to get existing user: %w", err)
 	}
@@ -154,14 +154,14 @@ func (s *userServiceImpl) Update(id string, bodyRequest *schema.UserRequest) (*m
 func (s *userServiceImpl) PartialUpdate(id string, bodyRequest *schema.UserRequest) (*model.User, error) {
 	idUUID, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid ID")
+		return nil, common.NewFieldError("id", "invalid ID")
 	}
 
 	// 1. Get existing user model (Preloaded with Permission)
 	userModel, err := s.userRepo.GetByID(idUUID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("user with ID '%s' not found", id)
+			return nil, common.NewNotFoundError("user with ID '%s' not found", id)
 		}
 		return nil, fmt.Errorf("failed to get existing user: %w", err)
 	}
@@ -210,14 +210,14 @@ func (s *userServiceImpl) PartialUpdate(id string, bodyRequest *schema.UserReque
 func (s *userServiceImpl) Delete(id string) error {
 	idUUID, err := uuid.Parse(id)
 	if err != nil {
-		return fmt.Errorf("invalid ID")
+		return common.NewFieldError("id", "invalid ID")
 	}
 
 	// ตรวจสอบการมีอยู่ก่อนลบ
 	_, err = s.userRepo.GetByID(idUUID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return fmt.Errorf("user with ID '%s' not found", id)
+			return common.NewNotFoundError("user with ID '%s' not found", id)
 		}
 		return fmt.Errorf("failed to get user by ID: %w", err)
 	}
@@ -243,10 +243,11 @@ func (s *userServiceImpl) ConvertToResponse(userModel *model.User) (*schema.User
 
 	// สร้าง UserResponse
 	response := &schema.UserResponse{
-		ID:         userModel.ID.String(),
-		Username:   userModel.Username,
-		Status:     userModel.Status,
-		Permission: permissionResponse,
+		ID:           userModel.ID.String(),
+		Username:     userModel.Username,
+		Status:       userModel.Status,
+		IsSuperAdmin: userModel.IsSuperAdmin,
+		Permission:   permissionResponse,
 	}
 
 	return response, nil
@@ -312,7 +313,7 @@ func (s *userServiceImpl) mapPermissionRequestToModel(request *schema.UserPermis
 // validateMandatoryFields checks for required fields for Create/Update.
 func (s *userServiceImpl) validateMandatoryFields(bodyRequest *schema.UserRequest) error {
 	if bodyRequest.Username == nil || *bodyRequest.Username == "" {
-		return fmt.Errorf("username cannot be empty")
+		return common.NewFieldError("username", "username cannot be empty")
 	}
 
 	// สำหรับ Create, Password ต้องมี
@@ -321,7 +322,7 @@ func (s *userServiceImpl) validateMandatoryFields(bodyRequest *schema.UserReques
 	}
 
 	if bodyRequest.Permission == nil {
-		return fmt.Errorf("permission configuration is mandatory")
+		return common.NewValidationError("permission configuration is mandatory")
 	}
 	return nil
 }
@@ -337,8 +338,7 @@ func (s *userServiceImpl) validateBodyRequest(bodyRequest *schema.UserRequest, e
 		}
 
 		if isExistUsername {
-			// ใช้ข้อความที่บันทึกไว้ตามคำขอของผู้ใช้ (2025-07-05)
-			return fmt.Errorf("ApplicationForm name is already exist")
+			return common.NewConflictError("user name is already exist")
 		}
 	}
 
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/model"
+	"github.com/putteror/access-control-management/internal/app/repository"
+	"github.com/putteror/access-control-management/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// VisitorVehicleService defines the interface for unregistered vehicles seen by license plate cameras.
+type VisitorVehicleService interface {
+	GetAll(searchQuery schema.VisitorVehicleSearchQuery) ([]model.VisitorVehicle, error)
+	GetByID(id string) (*model.VisitorVehicle, error)
+	Delete(id string) error
+	ConvertToResponse(vehicleModel *model.VisitorVehicle) *schema.VisitorVehicleResponse
+}
+
+type visitorVehicleServiceImpl struct {
+	visitorVehicleRepo repository.VisitorVehicleRepository
+}
+
+// NewVisitorVehicleService creates a new instance of VisitorVehicleService.
+func NewVisitorVehicleService(visitorVehicleRepo repository.VisitorVehicleRepository) VisitorVehicleService {
+	return &visitorVehicleServiceImpl{visitorVehicleRepo: visitorVehicleRepo}
+}
+
+// GetAll retrieves visitor vehicles.
+func (s *visitorVehicleServiceImpl) GetAll(searchQuery schema.VisitorVehicleSearchQuery) ([]model.VisitorVehicle, error) {
+	return s.visitorVehicleRepo.GetAll(searchQuery)
+}
+
+// GetByID retrieves a visitor vehicle by its ID.
+func (s *visitorVehicleServiceImpl) GetByID(id string) (*model.VisitorVehicle, error) {
+	idUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, common.NewFieldError("id", "invalid ID")
+	}
+	vehicle, err := s.visitorVehicleRepo.GetByID(idUUID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewNotFoundError("visitor vehicle with ID '%s' not found", id)
+		}
+		return nil, fmt.Errorf("failed to get visitor vehicle: %w", err)
+	}
+	return vehicle, nil
+}
+
+// Delete deletes a visitor vehicle by its ID.
+func (s *visitorVehicleServiceImpl) Delete(id string) error {
+	idUUID, err := uuid.Parse(id)
+	if err != nil {
+		return common.NewFieldError("id", "invalid ID")
+	}
+	if _, err := s.visitorVehicleRepo.GetByID(idUUID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return common.NewNotFoundError("visitor vehicle with ID '%s' not found", id)
+		}
+		return fmt.Errorf("failed to get visitor vehicle by ID: %w", err)
+	}
+	return s.visitorVehicleRepo.Delete(idUUID)
+}
+
+// ConvertToResponse converts a visitor vehicle model to a response schema.
+func (s *visitorVehicleServiceImpl) ConvertToResponse(vehicleModel *model.VisitorVehicle) *schema.VisitorVehicleResponse {
+	return &schema.VisitorVehicleResponse{
+		ID:                    vehicleModel.ID.String(),
+		PlateText:             vehicleModel.PlateText,
+		FirstSeenAt:           common.FormatTimestamp(vehicleModel.FirstSeenAt, nil),
+		LastSeenAt:            common.FormatTimestamp(vehicleModel.LastSeenAt, nil),
+		SeenCount:             vehicleModel.SeenCount,
+		AccessControlDeviceID: vehicleModel.AccessControlDeviceID,
+		LastImageURL:          common.GetImageURL(common.FileURLPrefix, stringValue(vehicleModel.LastImagePath)),
+	}
+}
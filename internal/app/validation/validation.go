@@ -0,0 +1,321 @@
+// Package validation validates requests by the validate tags of their schemas. It is the one
+// validator of the API: every handler validates with Struct or Partial, and every error is a
+// *common.ValidationError listing the invalid fields by their JSON or query names.
+//
+// Besides the built-in tags of github.com/go-playground/validator it has:
+//
+//	notblank            not empty or only spaces
+//	uuid                a UUID reference to another record
+//	date                a date as YYYY-MM-DD
+//	timeOfDay           a time of day as HH:MM:SS, HH:MM is still accepted
+//	timestamp           an RFC 3339 timestamp, or one in common.LegacyTimestampLayout
+//	timeZone            an IANA time zone such as Asia/Bangkok
+//	dayOfWeek           a day of week from 1 (Monday) to 7 (Sunday)
+//	after=Field         after the value of another field, e.g. an end time after its start time
+//	notBefore=Field     on or after the value of another field, e.g. an end date or an expiry
+//	hostAddress         a host name or IP address with an optional port, or an http(s) URL
+//	accessRecordType    and the other enums of the enums map
+//
+// The services read an empty string as a value that is not set, so the string tags above accept
+// it. Fields that must have a value are tagged required,notblank, as required alone accepts a
+// pointer to an empty string.
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/schema"
+)
+
+// enums are the tags of the fields that only take one of a list of values.
+var enums = map[string][]string{
+	"accessRecordType":            common.ACCESS_RECORD_TYPE,
+	"accessRecordResult":          common.ACCESS_RECORD_RESULT,
+	"accessRecordAnnotationField": common.ACCESS_RECORD_ANNOTATION_FIELD_LIST,
+	"authMode":                    common.AUTH_MODE_LIST,
+	"locationType":                common.LOCATION_TYPE_LIST,
+	"approvalStatus":              common.APPROVAL_STATUS_LIST,
+	"attendanceStatus":            common.ATTENDANCE_STATUS_LIST,
+	"attendanceCorrectionType":    common.ATTENDANCE_CORRECTION_TYPE_LIST,
+	"leaveHalfDay":                common.LEAVE_HALF_DAY_LIST,
+	"payrollFormat":               common.PAYROLL_FORMAT_LIST,
+	"payrollField":                common.PAYROLL_FIELD_LIST,
+	"payrollAlign":                {common.PayrollAlignLeft, common.PayrollAlignRight},
+	"personType":                  schema.PERSON_TYPE_LIST,
+}
+
+var hostnamePattern = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)*[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	// Report fields by the names clients send them with
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form"} {
+			name := strings.Split(field.Tag.Get(tag), ",")[0]
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return field.Name
+	})
+
+	mustRegister(v, "notblank", func(fl validator.FieldLevel) bool {
+		return fl.Field().Kind() != reflect.String || strings.TrimSpace(fl.Field().String()) != ""
+	})
+	// The built-in uuid tag only accepts the hyphenated lower case form, uuid.Parse accepts what
+	// the services accept
+	mustRegister(v, "uuid", optional(func(value string) bool {
+		_, err := uuid.Parse(value)
+		return err == nil
+	}))
+	mustRegister(v, "date", optional(common.ValidateDateStr))
+	mustRegister(v, "timeOfDay", optional(common.ValidateClockStr))
+	mustRegister(v, "timestamp", optional(func(value string) bool {
+		_, err := common.ParseTimestamp(value, time.UTC)
+		return err == nil
+	}))
+	mustRegister(v, "timeZone", optional(common.ValidateTimeZone))
+	mustRegister(v, "dayOfWeek", func(fl validator.FieldLevel) bool {
+		day := fl.Field().Int()
+		return day >= 1 && day <= 7
+	})
+	mustRegister(v, "after", func(fl validator.FieldLevel) bool {
+		order, ok := compareFields(fl)
+		return !ok || order > 0
+	})
+	mustRegister(v, "notBefore", func(fl validator.FieldLevel) bool {
+		order, ok := compareFields(fl)
+		return !ok || order >= 0
+	})
+	mustRegister(v, "hostAddress", optional(isHostAddress))
+	for tag, values := range enums {
+		values := values
+		mustRegister(v, tag, optional(func(value string) bool {
+			return slices.Contains(values, value)
+		}))
+	}
+	return v
+}
+
+// optional turns a check of a string into a validation that also accepts an empty string.
+func optional(valid func(value string) bool) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		value := fl.Field().String()
+		return value == "" || valid(value)
+	}
+}
+
+func mustRegister(v *validator.Validate, tag string, fn validator.Func) {
+	if err := v.RegisterValidation(tag, fn); err != nil {
+		panic(fmt.Sprintf("failed to register validation %s: %v", tag, err))
+	}
+}
+
+// compareFields compares the value of a field with the field named by the param of its tag, as
+// strings.Compare does. Timestamps are compared as instants, since their offsets may differ. Dates
+// as YYYY-MM-DD and times as HH:MM:SS are in the same order as their strings, so they are compared
+// as strings once a time as HH:MM has its seconds. ok is false when either is not set, then there
+// is nothing to compare.
+func compareFields(fl validator.FieldLevel) (int, bool) {
+	field, kind, _, found := fl.GetStructFieldOKAdvanced2(fl.Parent(), fl.Param())
+	if !found || kind != reflect.String || field.String() == "" || fl.Field().String() == "" {
+		return 0, false
+	}
+	value, other := fl.Field().String(), field.String()
+	if valueTime, err := common.ParseTimestamp(value, nil); err == nil {
+		if otherTime, err := common.ParseTimestamp(other, nil); err == nil {
+			return valueTime.Compare(otherTime), true
+		}
+	}
+	return strings.Compare(withSeconds(value), withSeconds(other)), true
+}
+
+func withSeconds(value string) string {
+	if len(value) == len("15:04") && value[2] == ':' {
+		return value + ":00"
+	}
+	return value
+}
+
+// isHostAddress reports whether an address is a host name or an IP address with an optional
+// port, or an http(s) URL of one.
+func isHostAddress(address string) bool {
+	if strings.Contains(address, "://") {
+		parsed, err := url.Parse(address)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			return false
+		}
+		address = parsed.Host
+	}
+	host := address
+	if h, port, err := net.SplitHostPort(address); err == nil {
+		number, err := strconv.Atoi(port)
+		if err != nil || number < 1 || number > 65535 {
+			return false
+		}
+		host = h
+	}
+	return net.ParseIP(host) != nil || (len(host) <= 253 && hostnamePattern.MatchString(host))
+}
+
+// Struct validates a request.
+func Struct(request interface{}) error {
+	return fieldErrors(validate.Struct(request), false)
+}
+
+// Partial validates the request of a partial update. Its fields may be left out, even the
+// required ones, but the fields that are sent must be valid.
+func Partial(request interface{}) error {
+	return fieldErrors(validate.Struct(request), true)
+}
+
+// fieldErrors turns the errors of the validator into a *common.ValidationError.
+func fieldErrors(err error, partial bool) error {
+	if err == nil {
+		return nil
+	}
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+
+	var fields []common.FieldError
+	for _, fieldError := range validationErrors {
+		// The namespace starts with the name of the request type
+		namespace := fieldError.Namespace()
+		if i := strings.Index(namespace, "."); i >= 0 {
+			namespace = namespace[i+1:]
+		}
+		// Fields left out of a partial update are empty, unlike the fields of its nested objects
+		if partial && (fieldError.Tag() == "required" || fieldError.Tag() == "notblank") && !strings.ContainsAny(namespace, ".[") {
+			continue
+		}
+		fields = append(fields, common.FieldError{Field: namespace, Message: message(namespace, fieldError)})
+	}
+	return newValidationError(fields)
+}
+
+func newValidationError(fields []common.FieldError) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	messages := make([]string, len(fields))
+	for i, field := range fields {
+		messages[i] = field.Message
+	}
+	return &common.ValidationError{Message: strings.Join(messages, "; "), Fields: fields}
+}
+
+// message describes the error of a field.
+func message(field string, fieldError validator.FieldError) string {
+	param := fieldError.Param()
+	switch fieldError.Tag() {
+	case "required":
+		return fmt.Sprintf("%s is required", field)
+	case "notblank":
+		return fmt.Sprintf("%s cannot be empty", field)
+	case "min":
+		switch fieldError.Kind() {
+		case reflect.Slice:
+			return fmt.Sprintf("%s must have at least %s items", field, param)
+		case reflect.String:
+			return fmt.Sprintf("%s must have at least %s characters", field, param)
+		}
+		return fmt.Sprintf("%s must be at least %s", field, param)
+	case "max":
+		switch fieldError.Kind() {
+		case reflect.Slice:
+			return fmt.Sprintf("%s must have at most %s items", field, param)
+		case reflect.String:
+			return fmt.Sprintf("%s must have at most %s characters", field, param)
+		}
+		return fmt.Sprintf("%s must be at most %s", field, param)
+	case "uuid":
+		return fmt.Sprintf("%s must be a UUID", field)
+	case "date":
+		return fmt.Sprintf("%s must be a date as YYYY-MM-DD", field)
+	case "timeOfDay":
+		return fmt.Sprintf("%s must be a time as HH:MM:SS", field)
+	case "timestamp":
+		return fmt.Sprintf("%s must be an RFC 3339 timestamp, e.g. 2024-05-01T08:30:00+07:00", field)
+	case "timeZone":
+		return fmt.Sprintf("%s must be an IANA time zone, e.g. Asia/Bangkok", field)
+	case "dayOfWeek":
+		return fmt.Sprintf("%s must be a day of week from 1 (Monday) to 7 (Sunday)", field)
+	case "after":
+		return fmt.Sprintf("%s must be after %s", field, lowerFirst(param))
+	case "notBefore":
+		return fmt.Sprintf("%s must be on or after %s", field, lowerFirst(param))
+	case "hostAddress":
+		return fmt.Sprintf("%s must be a host name or IP address with an optional port, or an http(s) URL", field)
+	}
+	if values, ok := enums[fieldError.Tag()]; ok {
+		return fmt.Sprintf("%s must be one of %s", field, strings.Join(values, ", "))
+	}
+	return fmt.Sprintf("%s is invalid (%s)", field, fieldError.Tag())
+}
+
+// Enum returns the values of an enum tag.
+func Enum(tag string) ([]string, bool) {
+	values, ok := enums[tag]
+	return values, ok
+}
+
+// lowerFirst turns the Go name of a field of a tag param into its JSON name, e.g. StartTime to
+// startTime.
+func lowerFirst(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+// BindError turns an error of binding a request body or query into a *common.ValidationError,
+// naming the field of a value of the wrong type.
+func BindError(err error) error {
+	var typeError *json.UnmarshalTypeError
+	if errors.As(err, &typeError) && typeError.Field != "" {
+		return common.NewFieldError(typeError.Field, "%s must be a %s", typeError.Field, jsonTypeName(typeError.Type))
+	}
+	var numError *strconv.NumError
+	if errors.As(err, &numError) {
+		return common.NewValidationError("invalid number '%s'", numError.Num)
+	}
+	return common.NewValidationError("invalid request: %v", err)
+}
+
+// jsonTypeName returns the JSON name of a Go type.
+func jsonTypeName(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int32, reflect.Int64, reflect.Float64:
+		return "number"
+	case reflect.Slice:
+		return "array"
+	}
+	return "object"
+}
@@ -0,0 +1,58 @@
+package validation
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/schema"
+)
+
+func TestPersonRequestDates(t *testing.T) {
+	tests := []struct {
+		name        string
+		dateOfBirth string
+		activeAt    string
+		expireAt    string
+		wantFields  []string
+	}{
+		{"not set", "", "", "", nil},
+		{"timestamps", "1990-05-01T00:00:00+07:00", "2026-01-01T08:00:00Z", "2026-12-31T17:00:00Z", nil},
+		{"timestamps without an offset", "", "2026-01-01 08:00:00", "2026-01-01 08:00:00", nil},
+		{"date only", "1990-05-01", "", "", []string{"dateOfBirth"}},
+		{"invalid timestamps", "", "tomorrow", "2026-13-01T00:00:00Z", []string{"activeAt", "expireAt"}},
+		{"expiry before activation", "", "2026-06-01T00:00:00Z", "2026-05-31T23:59:59Z", []string{"expireAt"}},
+		// 08:00 in Bangkok is 01:00 UTC, the strings are in the opposite order of the instants
+		{"expiry after activation in another offset", "", "2026-06-01T08:00:00+07:00", "2026-06-01T02:00:00Z", nil},
+		{"expiry before activation in another offset", "", "2026-06-01T02:00:00Z", "2026-06-01T08:00:00+07:00", []string{"expireAt"}},
+		{"expiry without activation", "", "", "2026-05-31T00:00:00Z", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, last, personType := "Ada", "Lovelace", "employee"
+			request := schema.PersonRequest{
+				FirstName:   &first,
+				LastName:    &last,
+				PersonType:  &personType,
+				DateOfBirth: &tt.dateOfBirth,
+				ActiveAt:    &tt.activeAt,
+				ExpireAt:    &tt.expireAt,
+			}
+
+			var fields []string
+			if err := Struct(request); err != nil {
+				var validationErr *common.ValidationError
+				if !errors.As(err, &validationErr) {
+					t.Fatalf("Struct() = %v, want a ValidationError", err)
+				}
+				for _, field := range validationErr.Fields {
+					fields = append(fields, field.Field)
+				}
+			}
+			if !slices.Equal(fields, tt.wantFields) {
+				t.Errorf("invalid fields = %v, want %v", fields, tt.wantFields)
+			}
+		})
+	}
+}
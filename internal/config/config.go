@@ -1,35 +1,288 @@
 package config
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"strings"
 
 	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
 )
 
+// DefaultConfigFile is read when no config file is given and it exists.
+const DefaultConfigFile = "config.yaml"
+
 type Config struct {
-	Port   string
-	DBHost string
-	DBUser string
-	DBPass string
-	DBName string
-	DBPort string
+	Server     ServerConfig     `yaml:"server"`
+	Database   DatabaseConfig   `yaml:"database"`
+	JWT        JWTConfig        `yaml:"jwt"`
+	Storage    StorageConfig    `yaml:"storage"`
+	FaceImage  FaceImageConfig  `yaml:"face_image"`
+	Retention  RetentionConfig  `yaml:"retention"`
+	Encryption EncryptionConfig `yaml:"encryption"`
+	CORS       CORSConfig       `yaml:"cors"`
+	Jobs       JobsConfig       `yaml:"jobs"`
+	// TimeZone is the IANA time zone of the server and the database session
+	TimeZone string `yaml:"time_zone"`
+}
+
+// ServerConfig configures the HTTP server. A timeout of 0 disables it, the write timeout is off by
+// default because exports are streamed.
+type ServerConfig struct {
+	Port                int       `yaml:"port"`
+	ReadTimeoutSeconds  int       `yaml:"read_timeout_seconds"`
+	WriteTimeoutSeconds int       `yaml:"write_timeout_seconds"`
+	TLS                 TLSConfig `yaml:"tls"`
+}
+
+type TLSConfig struct {
+	Enabled  bool   `yaml:"enabled"`
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+}
+
+type DatabaseConfig struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	Name     string `yaml:"name"`
+	SSLMode  string `yaml:"ssl_mode"`
+	// Connection pool, 0 leaves the limit of database/sql
+	MaxOpenConns           int `yaml:"max_open_conns"`
+	MaxIdleConns           int `yaml:"max_idle_conns"`
+	ConnMaxLifetimeMinutes int `yaml:"conn_max_lifetime_minutes"`
+	// AutoMigrate creates the schema from the models with AutoMigrate instead of requiring the
+	// versioned migrations. It is meant for development only.
+	AutoMigrate bool `yaml:"auto_migrate"`
+}
+
+type JWTConfig struct {
+	Secret          string `yaml:"secret"`
+	ExpirationHours int    `yaml:"expiration_hours"`
+}
+
+// StorageConfig selects the file storage: "local" or "s3".
+type StorageConfig struct {
+	Driver    string   `yaml:"driver"`
+	LocalPath string   `yaml:"local_path"`
+	S3        S3Config `yaml:"s3"`
+}
+
+type S3Config struct {
+	Endpoint  string `yaml:"endpoint"`
+	Region    string `yaml:"region"`
+	Bucket    string `yaml:"bucket"`
+	AccessKey string `yaml:"access_key"`
+	SecretKey string `yaml:"secret_key"`
+	UseSSL    bool   `yaml:"use_ssl"`
+}
+
+// FaceImageConfig is the longest side in pixels of the normalized face image and the thumbnail.
+type FaceImageConfig struct {
+	Size          int `yaml:"size"`
+	ThumbnailSize int `yaml:"thumbnail_size"`
+}
+
+// RetentionConfig is the retention in days per data class, 0 keeps the data forever.
+type RetentionConfig struct {
+	AccessRecordDays     int `yaml:"access_record_days"`
+	AttendanceRecordDays int `yaml:"attendance_record_days"`
+	FaceImageDays        int `yaml:"face_image_days"`
+	AuditLogDays         int `yaml:"audit_log_days"`
+	VisitorDays          int `yaml:"visitor_days"`
+}
+
+// EncryptionConfig configures the encryption of sensitive columns. Key is a base64 encoded 32 byte
+// key used for new values, OldKeys maps the IDs of rotated keys to their base64 keys so older
+// values can still be read until they are re-encrypted. IndexKey and ChainKey are base64 encoded
+// 32 byte keys for the blind indexes of encrypted columns and the hashes of the access record
+// chain; unlike Key they cannot be rotated.
+type EncryptionConfig struct {
+	KeyID    string            `yaml:"key_id"`
+	Key      string            `yaml:"key"`
+	OldKeys  map[string]string `yaml:"old_keys"`
+	IndexKey string            `yaml:"index_key"`
+	ChainKey string            `yaml:"chain_key"`
+}
+
+// CORSConfig configures cross-origin requests. No allowed origins disables CORS, "*" allows every
+// origin.
+type CORSConfig struct {
+	AllowedOrigins   []string `yaml:"allowed_origins"`
+	AllowedMethods   []string `yaml:"allowed_methods"`
+	AllowedHeaders   []string `yaml:"allowed_headers"`
+	AllowCredentials bool     `yaml:"allow_credentials"`
+	MaxAgeSeconds    int      `yaml:"max_age_seconds"`
+}
+
+// JobsConfig configures the background jobs. An interval of 0 disables the job.
+type JobsConfig struct {
+	RetentionIntervalHours int `yaml:"retention_interval_hours"`
+}
+
+// Default returns the configuration used for every setting that is not given.
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Port:               8080,
+			ReadTimeoutSeconds: 30,
+		},
+		Database: DatabaseConfig{
+			Host:                   "localhost",
+			Port:                   5432,
+			SSLMode:                "disable",
+			MaxOpenConns:           25,
+			MaxIdleConns:           5,
+			ConnMaxLifetimeMinutes: 30,
+		},
+		JWT: JWTConfig{ExpirationHours: 24},
+		Storage: StorageConfig{
+			Driver: "local",
+			S3:     S3Config{Region: "us-east-1"},
+		},
+		FaceImage:  FaceImageConfig{Size: 640, ThumbnailSize: 160},
+		Encryption: EncryptionConfig{KeyID: "1", OldKeys: map[string]string{}},
+		CORS: CORSConfig{
+			AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+			AllowedHeaders: []string{"Authorization", "Content-Type"},
+			MaxAgeSeconds:  600,
+		},
+		Jobs:     JobsConfig{RetentionIntervalHours: 24},
+		TimeZone: "Asia/Bangkok",
+	}
 }
 
-func LoadConfig() (*Config, error) {
-	err := godotenv.Load()
+// LoadConfig reads the configuration from, in increasing precedence, the defaults, a YAML file,
+// the environment and command line flags, and returns the arguments left after the flags. The YAML
+// file is given with --config or CONFIG_FILE, otherwise config.yaml is read if it exists. A .env
+// file is loaded into the environment if it exists. The result is not validated, see Validate.
+func LoadConfig(args []string) (*Config, []string, error) {
+	cfg := Default()
+	settings := cfg.settings()
+
+	flags := flag.NewFlagSet("api", flag.ContinueOnError)
+	configFile := flags.String("config", "", "path of the YAML config file")
+	flagValues := make(map[int]string)
+	for i, s := range settings {
+		set := func(value string) error {
+			flagValues[i] = value
+			return nil
+		}
+		usage := fmt.Sprintf("%s (env %s)", s.key, s.env)
+		if _, ok := s.value.(*bool); ok {
+			flags.BoolFunc(s.flagName(), usage, set)
+		} else {
+			flags.Func(s.flagName(), usage, set)
+		}
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, fmt.Errorf("error loading .env file: %w", err)
+	}
+
+	path := *configFile
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+	if err := cfg.loadYAML(path); err != nil {
+		return nil, nil, err
+	}
+
+	for _, s := range settings {
+		if value := os.Getenv(s.env); value != "" {
+			if err := s.set(value); err != nil {
+				return nil, nil, fmt.Errorf("environment variable %s: %w", s.env, err)
+			}
+		}
+	}
+	for i, s := range settings {
+		if value, ok := flagValues[i]; ok {
+			if err := s.set(value); err != nil {
+				return nil, nil, fmt.Errorf("flag --%s: %w", s.flagName(), err)
+			}
+		}
+	}
+
+	return cfg, flags.Args(), nil
+}
+
+// loadYAML reads the YAML file at path over the config. Without a path the default file is read
+// when it exists.
+func (c *Config) loadYAML(path string) error {
+	required := path != ""
+	if path == "" {
+		path = DefaultConfigFile
+	}
+	file, err := os.Open(path)
 	if err != nil {
-		return nil, fmt.Errorf("error loading .env file: %w", err)
+		if !required && errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("error opening config file: %w", err)
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("error reading config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// WriteYAML writes the config as YAML with its secrets redacted.
+func (c *Config) WriteYAML(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(c.Redacted()); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
 	}
+	return encoder.Close()
+}
 
-	cfg := &Config{
-		Port:   os.Getenv("PORT"),
-		DBHost: os.Getenv("DB_HOST"),
-		DBUser: os.Getenv("DB_USER"),
-		DBPass: os.Getenv("DB_PASSWORD"),
-		DBName: os.Getenv("DB_NAME"),
-		DBPort: os.Getenv("DB_PORT"),
+// Redacted returns a copy of the config with every secret that is set replaced.
+func (c *Config) Redacted() *Config {
+	redacted := *c
+	for _, s := range redacted.settings() {
+		if !s.secret {
+			continue
+		}
+		switch value := s.value.(type) {
+		case *string:
+			if *value != "" {
+				*value = redactedValue
+			}
+		case *map[string]string:
+			keys := make(map[string]string, len(*value))
+			for id := range *value {
+				keys[id] = redactedValue
+			}
+			*value = keys
+		}
 	}
+	return &redacted
+}
 
-	return cfg, nil
+// Address returns the listen address of the HTTP server.
+func (c *Config) Address() string {
+	return fmt.Sprintf(":%d", c.Server.Port)
+}
+
+// splitList splits a comma separated list and drops empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
@@ -0,0 +1,239 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// isolate runs the test in an empty directory without any of the environment variables of the
+// config set, so neither the .env file, config.yaml nor the environment of the machine leak in.
+func isolate(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Chdir(dir)
+	for _, s := range Default().settings() {
+		t.Setenv(s.env, "")
+		os.Unsetenv(s.env)
+	}
+	t.Setenv("CONFIG_FILE", "")
+	os.Unsetenv("CONFIG_FILE")
+	return dir
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadConfigPrecedence(t *testing.T) {
+	tests := []struct {
+		name string
+		// files are written to the working directory
+		files    map[string]string
+		env      map[string]string
+		args     []string
+		wantPort int
+		wantHost string
+		wantArgs []string
+	}{
+		{
+			name:     "defaults",
+			wantPort: 8080,
+			wantHost: "localhost",
+		},
+		{
+			name:     "config.yaml over the defaults",
+			files:    map[string]string{"config.yaml": "server:\n  port: 9000\n"},
+			wantPort: 9000,
+			wantHost: "localhost",
+		},
+		{
+			name:     "environment over the YAML file",
+			files:    map[string]string{"config.yaml": "server:\n  port: 9000\ndatabase:\n  host: yaml\n"},
+			env:      map[string]string{"PORT": "9100"},
+			wantPort: 9100,
+			wantHost: "yaml",
+		},
+		{
+			name:     "flags over the environment",
+			files:    map[string]string{"config.yaml": "server:\n  port: 9000\n"},
+			env:      map[string]string{"PORT": "9100", "DB_HOST": "env"},
+			args:     []string{"--server-port", "9200"},
+			wantPort: 9200,
+			wantHost: "env",
+		},
+		{
+			name:     "empty environment variable is not set",
+			files:    map[string]string{"config.yaml": "server:\n  port: 9000\n"},
+			env:      map[string]string{"PORT": ""},
+			wantPort: 9000,
+			wantHost: "localhost",
+		},
+		{
+			name:     ".env file",
+			files:    map[string]string{".env": "PORT=9300\nDB_HOST=dotenv\n"},
+			env:      map[string]string{"DB_HOST": "env"},
+			wantPort: 9300,
+			wantHost: "env",
+		},
+		{
+			name:     "CONFIG_FILE instead of config.yaml",
+			files:    map[string]string{"config.yaml": "server:\n  port: 9000\n", "other.yaml": "server:\n  port: 9400\n"},
+			env:      map[string]string{"CONFIG_FILE": "other.yaml"},
+			wantPort: 9400,
+			wantHost: "localhost",
+		},
+		{
+			name:     "--config over CONFIG_FILE",
+			files:    map[string]string{"other.yaml": "server:\n  port: 9400\n", "flag.yaml": "server:\n  port: 9500\n"},
+			env:      map[string]string{"CONFIG_FILE": "other.yaml"},
+			args:     []string{"--config", "flag.yaml"},
+			wantPort: 9500,
+			wantHost: "localhost",
+		},
+		{
+			name:     "arguments after the flags",
+			args:     []string{"--database-host", "flag", "migrate", "up"},
+			wantPort: 8080,
+			wantHost: "flag",
+			wantArgs: []string{"migrate", "up"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := isolate(t)
+			for name, content := range tt.files {
+				writeFile(t, filepath.Join(dir, name), content)
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, args, err := LoadConfig(tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.Server.Port != tt.wantPort {
+				t.Errorf("server.port = %d, want %d", cfg.Server.Port, tt.wantPort)
+			}
+			if cfg.Database.Host != tt.wantHost {
+				t.Errorf("database.host = %q, want %q", cfg.Database.Host, tt.wantHost)
+			}
+			if !slices.Equal(args, tt.wantArgs) {
+				t.Errorf("args = %q, want %q", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestLoadConfigValues(t *testing.T) {
+	isolate(t)
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://a.example.com, ,https://b.example.com")
+	t.Setenv("DB_AUTO_MIGRATE", "true")
+	cfg, _, err := LoadConfig([]string{"--server-tls-enabled", "--encryption-old-keys", "1:first, 2:second"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"https://a.example.com", "https://b.example.com"}; !reflect.DeepEqual(cfg.CORS.AllowedOrigins, want) {
+		t.Errorf("cors.allowed_origins = %q, want %q", cfg.CORS.AllowedOrigins, want)
+	}
+	if !cfg.Database.AutoMigrate {
+		t.Error("database.auto_migrate = false, want true")
+	}
+	if !cfg.Server.TLS.Enabled {
+		t.Error("server.tls.enabled = false, want true")
+	}
+	if want := map[string]string{"1": "first", "2": "second"}; !reflect.DeepEqual(cfg.Encryption.OldKeys, want) {
+		t.Errorf("encryption.old_keys = %v, want %v", cfg.Encryption.OldKeys, want)
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		env     map[string]string
+		args    []string
+		wantErr string
+	}{
+		{"integer environment variable", nil, map[string]string{"PORT": "eighty"}, nil, "environment variable PORT: must be an integer"},
+		{"boolean environment variable", nil, map[string]string{"TLS_ENABLED": "yes please"}, nil, "environment variable TLS_ENABLED: must be true or false"},
+		{"map flag", nil, nil, []string{"--encryption-old-keys", "first"}, "flag --encryption-old-keys: must be a comma separated list"},
+		{"unknown flag", nil, nil, []string{"--no-such-setting", "1"}, "no-such-setting"},
+		{"unknown YAML key", map[string]string{"config.yaml": "server:\n  prot: 9000\n"}, nil, nil, "field prot not found"},
+		{"missing config file", nil, nil, []string{"--config", "missing.yaml"}, "error opening config file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := isolate(t)
+			for name, content := range tt.files {
+				writeFile(t, filepath.Join(dir, name), content)
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			_, _, err := LoadConfig(tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadConfig() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := Default()
+	cfg.Database.User = "acm"
+	cfg.Database.Password = "db password"
+	cfg.JWT.Secret = "jwt secret"
+	cfg.Storage.S3.AccessKey = "access key"
+	cfg.Encryption.Key = "current key"
+	cfg.Encryption.OldKeys = map[string]string{"1": "old key"}
+	cfg.Encryption.IndexKey = "index key"
+
+	redacted := cfg.Redacted()
+
+	tests := []struct {
+		key  string
+		got  string
+		want string
+	}{
+		{"database.password", redacted.Database.Password, redactedValue},
+		{"jwt.secret", redacted.JWT.Secret, redactedValue},
+		{"encryption.key", redacted.Encryption.Key, redactedValue},
+		{"encryption.old_keys.1", redacted.Encryption.OldKeys["1"], redactedValue},
+		{"encryption.index_key", redacted.Encryption.IndexKey, redactedValue},
+		// A secret that is not set stays empty so it reads as missing
+		{"encryption.chain_key", redacted.Encryption.ChainKey, ""},
+		{"storage.s3.secret_key", redacted.Storage.S3.SecretKey, ""},
+		{"database.user", redacted.Database.User, "acm"},
+		{"storage.s3.access_key", redacted.Storage.S3.AccessKey, "access key"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Redacted() %s = %q, want %q", tt.key, tt.got, tt.want)
+		}
+	}
+
+	if cfg.Database.Password != "db password" || cfg.Encryption.OldKeys["1"] != "old key" {
+		t.Error("Redacted() changed the secrets of the original config")
+	}
+
+	var out bytes.Buffer
+	if err := cfg.WriteYAML(&out); err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"db password", "jwt secret", "current key", "old key", "index key"} {
+		if strings.Contains(out.String(), secret) {
+			t.Errorf("WriteYAML() wrote the secret %q", secret)
+		}
+	}
+}
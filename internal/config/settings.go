@@ -0,0 +1,120 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// redactedValue replaces secrets when the config is printed.
+const redactedValue = "<redacted>"
+
+// setting is a config value that can also be set by an environment variable and a flag. key is
+// its path in the YAML file, the flag name is derived from it.
+type setting struct {
+	key    string
+	env    string
+	secret bool
+	value  interface{}
+}
+
+// settings lists every value of the config with its environment variable.
+func (c *Config) settings() []setting {
+	return []setting{
+		{"server.port", "PORT", false, &c.Server.Port},
+		{"server.read_timeout_seconds", "SERVER_READ_TIMEOUT_SECONDS", false, &c.Server.ReadTimeoutSeconds},
+		{"server.write_timeout_seconds", "SERVER_WRITE_TIMEOUT_SECONDS", false, &c.Server.WriteTimeoutSeconds},
+		{"server.tls.enabled", "TLS_ENABLED", false, &c.Server.TLS.Enabled},
+		{"server.tls.cert_file", "TLS_CERT_FILE", false, &c.Server.TLS.CertFile},
+		{"server.tls.key_file", "TLS_KEY_FILE", false, &c.Server.TLS.KeyFile},
+
+		{"database.host", "DB_HOST", false, &c.Database.Host},
+		{"database.port", "DB_PORT", false, &c.Database.Port},
+		{"database.user", "DB_USER", false, &c.Database.User},
+		{"database.password", "DB_PASSWORD", true, &c.Database.Password},
+		{"database.name", "DB_NAME", false, &c.Database.Name},
+		{"database.ssl_mode", "DB_SSL_MODE", false, &c.Database.SSLMode},
+		{"database.max_open_conns", "DB_MAX_OPEN_CONNS", false, &c.Database.MaxOpenConns},
+		{"database.max_idle_conns", "DB_MAX_IDLE_CONNS", false, &c.Database.MaxIdleConns},
+		{"database.conn_max_lifetime_minutes", "DB_CONN_MAX_LIFETIME_MINUTES", false, &c.Database.ConnMaxLifetimeMinutes},
+		{"database.auto_migrate", "DB_AUTO_MIGRATE", false, &c.Database.AutoMigrate},
+
+		{"jwt.secret", "JWT_SECRET", true, &c.JWT.Secret},
+		{"jwt.expiration_hours", "JWT_EXPIRATION_HOURS", false, &c.JWT.ExpirationHours},
+
+		{"storage.driver", "STORAGE_DRIVER", false, &c.Storage.Driver},
+		{"storage.local_path", "STORAGE_LOCAL_PATH", false, &c.Storage.LocalPath},
+		{"storage.s3.endpoint", "S3_ENDPOINT", false, &c.Storage.S3.Endpoint},
+		{"storage.s3.region", "S3_REGION", false, &c.Storage.S3.Region},
+		{"storage.s3.bucket", "S3_BUCKET", false, &c.Storage.S3.Bucket},
+		{"storage.s3.access_key", "S3_ACCESS_KEY", false, &c.Storage.S3.AccessKey},
+		{"storage.s3.secret_key", "S3_SECRET_KEY", true, &c.Storage.S3.SecretKey},
+		{"storage.s3.use_ssl", "S3_USE_SSL", false, &c.Storage.S3.UseSSL},
+
+		{"face_image.size", "FACE_IMAGE_SIZE", false, &c.FaceImage.Size},
+		{"face_image.thumbnail_size", "FACE_IMAGE_THUMBNAIL_SIZE", false, &c.FaceImage.ThumbnailSize},
+
+		{"retention.access_record_days", "RETENTION_ACCESS_RECORD_DAYS", false, &c.Retention.AccessRecordDays},
+		{"retention.attendance_record_days", "RETENTION_ATTENDANCE_RECORD_DAYS", false, &c.Retention.AttendanceRecordDays},
+		{"retention.face_image_days", "RETENTION_FACE_IMAGE_DAYS", false, &c.Retention.FaceImageDays},
+		{"retention.audit_log_days", "RETENTION_AUDIT_LOG_DAYS", false, &c.Retention.AuditLogDays},
+		{"retention.visitor_days", "RETENTION_VISITOR_DAYS", false, &c.Retention.VisitorDays},
+
+		{"encryption.key_id", "ENCRYPTION_KEY_ID", false, &c.Encryption.KeyID},
+		{"encryption.key", "ENCRYPTION_KEY", true, &c.Encryption.Key},
+		{"encryption.old_keys", "ENCRYPTION_OLD_KEYS", true, &c.Encryption.OldKeys},
+		{"encryption.index_key", "ENCRYPTION_INDEX_KEY", true, &c.Encryption.IndexKey},
+		{"encryption.chain_key", "ENCRYPTION_CHAIN_KEY", true, &c.Encryption.ChainKey},
+
+		{"cors.allowed_origins", "CORS_ALLOWED_ORIGINS", false, &c.CORS.AllowedOrigins},
+		{"cors.allowed_methods", "CORS_ALLOWED_METHODS", false, &c.CORS.AllowedMethods},
+		{"cors.allowed_headers", "CORS_ALLOWED_HEADERS", false, &c.CORS.AllowedHeaders},
+		{"cors.allow_credentials", "CORS_ALLOW_CREDENTIALS", false, &c.CORS.AllowCredentials},
+		{"cors.max_age_seconds", "CORS_MAX_AGE_SECONDS", false, &c.CORS.MaxAgeSeconds},
+
+		{"jobs.retention_interval_hours", "RETENTION_INTERVAL_HOURS", false, &c.Jobs.RetentionIntervalHours},
+
+		{"time_zone", "TIME_ZONE", false, &c.TimeZone},
+	}
+}
+
+// flagName returns the flag of a setting, for example --database-max-open-conns.
+func (s setting) flagName() string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(s.key)
+}
+
+// set parses a value given as text. Lists are comma separated, maps are comma separated
+// <key>:<value> pairs.
+func (s setting) set(text string) error {
+	switch value := s.value.(type) {
+	case *string:
+		*value = strings.TrimSpace(text)
+	case *int:
+		number, err := strconv.Atoi(strings.TrimSpace(text))
+		if err != nil {
+			return fmt.Errorf("must be an integer, got '%s'", text)
+		}
+		*value = number
+	case *bool:
+		enabled, err := strconv.ParseBool(strings.TrimSpace(text))
+		if err != nil {
+			return fmt.Errorf("must be true or false, got '%s'", text)
+		}
+		*value = enabled
+	case *[]string:
+		*value = splitList(text)
+	case *map[string]string:
+		entries := make(map[string]string)
+		for _, entry := range splitList(text) {
+			key, item, ok := strings.Cut(entry, ":")
+			if !ok || strings.TrimSpace(key) == "" || strings.TrimSpace(item) == "" {
+				return fmt.Errorf("must be a comma separated list of <key>:<value>")
+			}
+			entries[strings.TrimSpace(key)] = strings.TrimSpace(item)
+		}
+		*value = entries
+	default:
+		return fmt.Errorf("unsupported setting type %T", s.value)
+	}
+	return nil
+}
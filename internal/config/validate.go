@@ -0,0 +1,138 @@
+package config
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	minJWTSecretLength = 32
+	encryptionKeySize  = 32
+)
+
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// Validate checks every setting and returns all problems found, each prefixed with the YAML key of
+// the setting.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, key string, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+		}
+	}
+
+	check(c.Server.Port >= 1 && c.Server.Port <= 65535, "server.port", "must be between 1 and 65535, got %d", c.Server.Port)
+	check(c.Server.ReadTimeoutSeconds >= 0, "server.read_timeout_seconds", "cannot be negative")
+	check(c.Server.WriteTimeoutSeconds >= 0, "server.write_timeout_seconds", "cannot be negative")
+	if c.Server.TLS.Enabled {
+		check(c.Server.TLS.CertFile != "", "server.tls.cert_file", "is required when TLS is enabled")
+		check(c.Server.TLS.KeyFile != "", "server.tls.key_file", "is required when TLS is enabled")
+	}
+
+	check(c.Database.Host != "", "database.host", "is required")
+	check(c.Database.Port >= 1 && c.Database.Port <= 65535, "database.port", "must be between 1 and 65535, got %d", c.Database.Port)
+	check(c.Database.User != "", "database.user", "is required")
+	check(c.Database.Name != "", "database.name", "is required")
+	check(slices.Contains(sslModes, c.Database.SSLMode), "database.ssl_mode", "must be one of %s, got '%s'", strings.Join(sslModes, ", "), c.Database.SSLMode)
+	check(c.Database.MaxOpenConns >= 0, "database.max_open_conns", "cannot be negative")
+	check(c.Database.MaxIdleConns >= 0, "database.max_idle_conns", "cannot be negative")
+	check(c.Database.MaxOpenConns == 0 || c.Database.MaxIdleConns <= c.Database.MaxOpenConns, "database.max_idle_conns", "cannot be more than database.max_open_conns")
+	check(c.Database.ConnMaxLifetimeMinutes >= 0, "database.conn_max_lifetime_minutes", "cannot be negative")
+
+	check(len(c.JWT.Secret) >= minJWTSecretLength, "jwt.secret", "must be at least %d characters", minJWTSecretLength)
+	check(c.JWT.ExpirationHours > 0, "jwt.expiration_hours", "must be a positive integer")
+
+	switch c.Storage.Driver {
+	case "local":
+	case "s3":
+		check(c.Storage.S3.Endpoint != "", "storage.s3.endpoint", "is required for the s3 driver")
+		check(c.Storage.S3.Bucket != "", "storage.s3.bucket", "is required for the s3 driver")
+		check(c.Storage.S3.AccessKey != "", "storage.s3.access_key", "is required for the s3 driver")
+		check(c.Storage.S3.SecretKey != "", "storage.s3.secret_key", "is required for the s3 driver")
+	default:
+		check(false, "storage.driver", "must be local or s3, got '%s'", c.Storage.Driver)
+	}
+
+	check(c.FaceImage.Size > 0, "face_image.size", "must be a positive integer")
+	check(c.FaceImage.ThumbnailSize > 0, "face_image.thumbnail_size", "must be a positive integer")
+
+	retentionDays := []struct {
+		key  string
+		days int
+	}{
+		{"retention.access_record_days", c.Retention.AccessRecordDays},
+		{"retention.attendance_record_days", c.Retention.AttendanceRecordDays},
+		{"retention.face_image_days", c.Retention.FaceImageDays},
+		{"retention.audit_log_days", c.Retention.AuditLogDays},
+		{"retention.visitor_days", c.Retention.VisitorDays},
+	}
+	for _, retention := range retentionDays {
+		check(retention.days >= 0, retention.key, "cannot be negative")
+	}
+
+	check(c.Encryption.KeyID != "" && !strings.Contains(c.Encryption.KeyID, ":"), "encryption.key_id", "is required and cannot contain ':'")
+	if c.Encryption.Key == "" {
+		check(false, "encryption.key", "is required")
+	} else if err := checkEncryptionKey(c.Encryption.Key); err != nil {
+		check(false, "encryption.key", "%v", err)
+	}
+	for _, id := range slices.Sorted(maps.Keys(c.Encryption.OldKeys)) {
+		if err := checkEncryptionKey(c.Encryption.OldKeys[id]); err != nil {
+			check(false, "encryption.old_keys."+id, "%v", err)
+		}
+	}
+	requiredKeys := []struct {
+		key   string
+		value string
+	}{
+		{"encryption.index_key", c.Encryption.IndexKey},
+		{"encryption.chain_key", c.Encryption.ChainKey},
+	}
+	for _, required := range requiredKeys {
+		if required.value == "" {
+			check(false, required.key, "is required")
+		} else if err := checkEncryptionKey(required.value); err != nil {
+			check(false, required.key, "%v", err)
+		}
+	}
+
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" {
+			check(!c.CORS.AllowCredentials, "cors.allowed_origins", "cannot be '*' when cors.allow_credentials is enabled")
+			continue
+		}
+		parsed, err := url.Parse(origin)
+		check(err == nil && parsed.Scheme != "" && parsed.Host != "" && parsed.Path == "", "cors.allowed_origins", "'%s' must be '*' or a scheme and host such as https://example.com", origin)
+	}
+	check(c.CORS.MaxAgeSeconds >= 0, "cors.max_age_seconds", "cannot be negative")
+
+	check(c.Jobs.RetentionIntervalHours >= 0, "jobs.retention_interval_hours", "cannot be negative")
+
+	if _, err := time.LoadLocation(c.TimeZone); err != nil || c.TimeZone == "" {
+		check(false, "time_zone", "'%s' is not a valid IANA time zone", c.TimeZone)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid config:\n%w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// checkEncryptionKey checks that a key is base64 encoded and 32 bytes long.
+func checkEncryptionKey(encoded string) error {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("must be base64 encoded")
+	}
+	if len(key) != encryptionKeySize {
+		return fmt.Errorf("must be %d bytes, got %d", encryptionKeySize, len(key))
+	}
+	return nil
+}
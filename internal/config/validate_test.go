@@ -0,0 +1,95 @@
+package config
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+// validConfig returns the defaults with every required setting set.
+func validConfig() *Config {
+	key := func(b byte) string {
+		return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(b), encryptionKeySize)))
+	}
+	cfg := Default()
+	cfg.Database.User = "acm"
+	cfg.Database.Name = "acm"
+	cfg.JWT.Secret = strings.Repeat("s", minJWTSecretLength)
+	cfg.Encryption.Key = key('k')
+	cfg.Encryption.IndexKey = key('i')
+	cfg.Encryption.ChainKey = key('c')
+	return cfg
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(cfg *Config)
+		// wantKeys are the settings reported, none for a valid config
+		wantKeys []string
+	}{
+		{"valid", func(cfg *Config) {}, nil},
+		{"port out of range", func(cfg *Config) { cfg.Server.Port = 70000 }, []string{"server.port"}},
+		{"TLS without files", func(cfg *Config) { cfg.Server.TLS.Enabled = true }, []string{"server.tls.cert_file", "server.tls.key_file"}},
+		{"unknown SSL mode", func(cfg *Config) { cfg.Database.SSLMode = "sometimes" }, []string{"database.ssl_mode"}},
+		{"more idle than open connections", func(cfg *Config) {
+			cfg.Database.MaxOpenConns = 2
+			cfg.Database.MaxIdleConns = 3
+		}, []string{"database.max_idle_conns"}},
+		{"unlimited open connections", func(cfg *Config) {
+			cfg.Database.MaxOpenConns = 0
+			cfg.Database.MaxIdleConns = 3
+		}, nil},
+		{"short JWT secret", func(cfg *Config) { cfg.JWT.Secret = "secret" }, []string{"jwt.secret"}},
+		{"s3 without settings", func(cfg *Config) { cfg.Storage.Driver = "s3" }, []string{
+			"storage.s3.endpoint", "storage.s3.bucket", "storage.s3.access_key", "storage.s3.secret_key",
+		}},
+		{"unknown storage driver", func(cfg *Config) { cfg.Storage.Driver = "ftp" }, []string{"storage.driver"}},
+		{"negative retention", func(cfg *Config) { cfg.Retention.VisitorDays = -1 }, []string{"retention.visitor_days"}},
+		{"key ID with a colon", func(cfg *Config) { cfg.Encryption.KeyID = "1:2" }, []string{"encryption.key_id"}},
+		{"missing keys", func(cfg *Config) {
+			cfg.Encryption.Key = ""
+			cfg.Encryption.IndexKey = ""
+			cfg.Encryption.ChainKey = ""
+		}, []string{"encryption.key", "encryption.index_key", "encryption.chain_key"}},
+		{"short key", func(cfg *Config) { cfg.Encryption.ChainKey = base64.StdEncoding.EncodeToString([]byte("short")) }, []string{"encryption.chain_key"}},
+		{"old key not base64", func(cfg *Config) { cfg.Encryption.OldKeys = map[string]string{"0": "not base64!"} }, []string{"encryption.old_keys.0"}},
+		{"any origin with credentials", func(cfg *Config) {
+			cfg.CORS.AllowedOrigins = []string{"*"}
+			cfg.CORS.AllowCredentials = true
+		}, []string{"cors.allowed_origins"}},
+		{"origin with a path", func(cfg *Config) { cfg.CORS.AllowedOrigins = []string{"https://example.com/app"} }, []string{"cors.allowed_origins"}},
+		{"unknown time zone", func(cfg *Config) { cfg.TimeZone = "Mars/Olympus_Mons" }, []string{"time_zone"}},
+		{"every problem at once", func(cfg *Config) {
+			cfg.Server.Port = 0
+			cfg.Database.Host = ""
+			cfg.FaceImage.Size = 0
+		}, []string{"server.port", "database.host", "face_image.size"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.change(cfg)
+
+			err := cfg.Validate()
+			if len(tt.wantKeys) == 0 {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Validate() = nil, want errors for %v", tt.wantKeys)
+			}
+			lines := strings.Split(err.Error(), "\n")[1:]
+			if len(lines) != len(tt.wantKeys) {
+				t.Errorf("Validate() = %v, want %d errors", err, len(tt.wantKeys))
+			}
+			for _, key := range tt.wantKeys {
+				if !strings.Contains(err.Error(), "\n"+key+": ") {
+					t.Errorf("Validate() = %v, want an error for %s", err, key)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package database
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"gorm.io/gorm"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// MigrationsTable holds the applied schema version. It is not the default schema_migrations so the
+// version written by the old standalone migration tool is not mistaken for this baseline.
+const MigrationsTable = "schema_versions"
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration is a versioned schema change with its up and down script.
+type Migration struct {
+	Version uint
+	Name    string
+}
+
+// MigrationStatus is the schema version of the database compared to the migrations of this build.
+type MigrationStatus struct {
+	Version    uint
+	Dirty      bool
+	Latest     uint
+	Migrations []Migration
+}
+
+// LoadMigrations returns the embedded migrations in order. Every file must be named
+// <version>_<name>.up.sql or .down.sql, every version needs both scripts and versions must run
+// from 1 without gaps.
+func LoadMigrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	type scripts struct {
+		name     string
+		up, down bool
+	}
+	byVersion := make(map[uint]*scripts)
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migration file '%s' must be named <version>_<name>.up.sql or <version>_<name>.down.sql", entry.Name())
+		}
+		version, err := strconv.ParseUint(match[1], 10, 32)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("migration file '%s' has an invalid version", entry.Name())
+		}
+		current, ok := byVersion[uint(version)]
+		if !ok {
+			current = &scripts{name: match[2]}
+			byVersion[uint(version)] = current
+		}
+		if current.name != match[2] {
+			return nil, fmt.Errorf("migration version %d is used by '%s' and '%s'", version, current.name, match[2])
+		}
+		if match[3] == "up" {
+			current.up = true
+		} else {
+			current.down = true
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for version, current := range byVersion {
+		if !current.up || !current.down {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down script", version, current.name)
+		}
+		migrations = append(migrations, Migration{Version: version, Name: current.name})
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	for i, migration := range migrations {
+		if migration.Version != uint(i+1) {
+			return nil, fmt.Errorf("migration version %d is missing", i+1)
+		}
+	}
+	return migrations, nil
+}
+
+// GetMigrationStatus returns the schema version of the database. A database without the
+// migrations table is at version 0.
+func GetMigrationStatus(db *gorm.DB) (*MigrationStatus, error) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	status := &MigrationStatus{Migrations: migrations}
+	if len(migrations) > 0 {
+		status.Latest = migrations[len(migrations)-1].Version
+	}
+
+	var table *string
+	if err := db.Raw("SELECT to_regclass(?)::text", MigrationsTable).Scan(&table).Error; err != nil {
+		return nil, fmt.Errorf("failed to check migrations table: %w", err)
+	}
+	if table == nil {
+		return status, nil
+	}
+	var rows []struct {
+		Version int64
+		Dirty   bool
+	}
+	if err := db.Raw("SELECT version, dirty FROM " + MigrationsTable + " LIMIT 1").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get schema version: %w", err)
+	}
+	if len(rows) > 0 && rows[0].Version > 0 {
+		status.Version = uint(rows[0].Version)
+		status.Dirty = rows[0].Dirty
+	}
+	return status, nil
+}
+
+// CheckSchemaVersion refuses a database whose schema is not at the latest version of this build.
+func CheckSchemaVersion(db *gorm.DB) error {
+	status, err := GetMigrationStatus(db)
+	if err != nil {
+		return err
+	}
+	switch {
+	case status.Dirty:
+		return fmt.Errorf("schema version %d is dirty after a failed migration, fix it and run 'migrate to %d'", status.Version, status.Version)
+	case status.Version < status.Latest:
+		return fmt.Errorf("schema is at version %d but this build needs version %d, run 'migrate up'", status.Version, status.Latest)
+	case status.Version > status.Latest:
+		return fmt.Errorf("schema version %d is newer than the latest version %d of this build", status.Version, status.Latest)
+	}
+	return nil
+}
+
+// DataMigration is Go code that runs once right after the migration of its version is applied, for
+// changes SQL cannot make such as hashing rows with a key from the config.
+type DataMigration func(db *gorm.DB) error
+
+// MigrateUp applies every pending migration and their data migrations.
+func MigrateUp(db *gorm.DB, dataMigrations map[uint]DataMigration) error {
+	status, err := GetMigrationStatus(db)
+	if err != nil {
+		return err
+	}
+	return runMigrate(db, func(m *migrate.Migrate) error {
+		return migrateUp(m, db, status.Latest, dataMigrations)
+	})
+}
+
+// MigrateDown reverts the given number of applied migrations.
+func MigrateDown(db *gorm.DB, steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("steps must be a positive integer")
+	}
+	return runMigrate(db, func(m *migrate.Migrate) error { return m.Steps(-steps) })
+}
+
+// MigrateTo migrates up or down to a version, 0 reverts every migration. On a dirty schema the
+// version is forced instead, after the failed migration was fixed by hand.
+func MigrateTo(db *gorm.DB, version uint, dataMigrations map[uint]DataMigration) error {
+	status, err := GetMigrationStatus(db)
+	if err != nil {
+		return err
+	}
+	if version > status.Latest {
+		return fmt.Errorf("version %d does not exist, the latest version is %d", version, status.Latest)
+	}
+	return runMigrate(db, func(m *migrate.Migrate) error {
+		if status.Dirty {
+			if version == 0 {
+				return m.Force(-1)
+			}
+			return m.Force(int(version))
+		}
+		if version == 0 {
+			return m.Down()
+		}
+		if version > status.Version {
+			return migrateUp(m, db, version, dataMigrations)
+		}
+		return m.Migrate(version)
+	})
+}
+
+// migrateUp applies the migrations up to a version one at a time, running the data migration of a
+// version right after its migration. When a data migration fails its migration is reverted, so the
+// next run applies both again.
+func migrateUp(m *migrate.Migrate, db *gorm.DB, target uint, dataMigrations map[uint]DataMigration) error {
+	for {
+		current, _, err := m.Version()
+		if errors.Is(err, migrate.ErrNilVersion) {
+			current = 0
+		} else if err != nil {
+			return err
+		}
+		if current >= target {
+			return nil
+		}
+		if err := m.Steps(1); err != nil {
+			return err
+		}
+		dataMigration, ok := dataMigrations[current+1]
+		if !ok {
+			continue
+		}
+		if err := dataMigration(db); err != nil {
+			if revertErr := m.Steps(-1); revertErr != nil {
+				return fmt.Errorf("data migration %d failed: %w, reverting its migration failed too: %v", current+1, err, revertErr)
+			}
+			return fmt.Errorf("data migration %d failed and its migration was reverted: %w", current+1, err)
+		}
+	}
+}
+
+// runMigrate runs fn on a migrate instance using its own connection of db.
+func runMigrate(db *gorm.DB, fn func(m *migrate.Migrate) error) error {
+	if _, err := LoadMigrations(); err != nil {
+		return err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+	ctx := context.Background()
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+	driver, err := postgres.WithConnection(ctx, conn, &postgres.Config{MigrationsTable: MigrationsTable})
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to create migration driver: %w", err)
+	}
+	source, err := iofs.New(migrationFiles, "migrations")
+	if err != nil {
+		driver.Close()
+		return fmt.Errorf("failed to read migrations: %w", err)
+	}
+	m, err := migrate.NewWithInstance("iofs", source, "postgres", driver)
+	if err != nil {
+		driver.Close()
+		return fmt.Errorf("failed to create migrator: %w", err)
+	}
+	defer m.Close()
+
+	if err := fn(m); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("migration failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+DROP TABLE IF EXISTS retention_run_items;
+DROP TABLE IF EXISTS retention_runs;
+DROP TABLE IF EXISTS audit_logs;
+DROP TABLE IF EXISTS payroll_export_lines;
+DROP TABLE IF EXISTS payroll_exports;
+DROP TABLE IF EXISTS payroll_export_columns;
+DROP TABLE IF EXISTS payroll_export_templates;
+DROP TABLE IF EXISTS overtime_rules;
+DROP TABLE IF EXISTS attendance_corrections;
+DROP TABLE IF EXISTS leave_balances;
+DROP TABLE IF EXISTS leave_requests;
+DROP TABLE IF EXISTS leave_types;
+DROP TABLE IF EXISTS person_shift_overrides;
+DROP TABLE IF EXISTS person_shift_assignments;
+DROP TABLE IF EXISTS shift_rotation_days;
+DROP TABLE IF EXISTS shift_rotations;
+DROP TABLE IF EXISTS shift_templates;
+DROP TABLE IF EXISTS holidays;
+DROP TABLE IF EXISTS holiday_calendars;
+DROP TABLE IF EXISTS access_scan_sessions;
+DROP TABLE IF EXISTS visitor_vehicles;
+DROP TABLE IF EXISTS access_record_chain_checkpoints;
+DROP TABLE IF EXISTS access_record_annotations;
+DROP TABLE IF EXISTS access_records;
+DROP TABLE IF EXISTS attendance_records;
+DROP TABLE IF EXISTS attendance_schedules;
+DROP TABLE IF EXISTS attendances;
+DROP TABLE IF EXISTS register_form_field_answers;
+DROP TABLE IF EXISTS register_form_fields;
+DROP TABLE IF EXISTS register_forms;
+DROP TABLE IF EXISTS person_license_plates;
+DROP TABLE IF EXISTS person_card_histories;
+DROP TABLE IF EXISTS person_cards;
+DROP TABLE IF EXISTS access_control_rule_groups;
+DROP TABLE IF EXISTS access_control_group_schedules;
+DROP TABLE IF EXISTS access_control_group_devices;
+DROP TABLE IF EXISTS access_control_rules;
+DROP TABLE IF EXISTS access_control_groups;
+DROP TABLE IF EXISTS access_control_servers;
+DROP TABLE IF EXISTS access_control_devices;
+DROP TABLE IF EXISTS people;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS user_permissions;
@@ -0,0 +1,1200 @@
+-- Baseline schema. Tables, columns and indexes use IF NOT EXISTS so a database created by
+-- AutoMigrate before versioned migrations existed can be brought under version control with
+-- migrate up: a table created by an older version gets the columns added to it since. Constraints
+-- are only created with new tables, AutoMigrate created them with the columns they cover.
+
+CREATE TABLE IF NOT EXISTS user_permissions (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    people_permission boolean,
+    device_permission boolean,
+    rule_permission boolean,
+    time_attendance_permission boolean,
+    report_permission boolean,
+    notification_permission boolean,
+    system_log_permission boolean,
+    PRIMARY KEY (id)
+);
+ALTER TABLE user_permissions
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS people_permission boolean,
+    ADD COLUMN IF NOT EXISTS device_permission boolean,
+    ADD COLUMN IF NOT EXISTS rule_permission boolean,
+    ADD COLUMN IF NOT EXISTS time_attendance_permission boolean,
+    ADD COLUMN IF NOT EXISTS report_permission boolean,
+    ADD COLUMN IF NOT EXISTS notification_permission boolean,
+    ADD COLUMN IF NOT EXISTS system_log_permission boolean;
+CREATE INDEX IF NOT EXISTS idx_user_permissions_deleted_at ON user_permissions (deleted_at);
+
+CREATE TABLE IF NOT EXISTS users (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    username text,
+    password_hash text,
+    permission_id uuid,
+    status text,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_users_permission FOREIGN KEY (permission_id) REFERENCES user_permissions(id),
+    CONSTRAINT uni_users_username UNIQUE (username)
+);
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS username text,
+    ADD COLUMN IF NOT EXISTS password_hash text,
+    ADD COLUMN IF NOT EXISTS permission_id uuid,
+    ADD COLUMN IF NOT EXISTS status text;
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+
+CREATE TABLE IF NOT EXISTS people (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    first_name text,
+    middle_name text,
+    last_name text,
+    person_type text,
+    person_id text,
+    gender text,
+    date_of_birth timestamptz,
+    company text,
+    department text,
+    job_position text,
+    address text,
+    mobile_number text,
+    email text,
+    face_image_path text,
+    face_image_normalized_path text,
+    face_image_thumbnail_path text,
+    pin_hash text,
+    is_verified boolean DEFAULT false,
+    active_at timestamptz,
+    expire_at timestamptz,
+    access_control_rule_id text,
+    time_attendance_id text,
+    person_id_index text,
+    email_index text,
+    mobile_number_index text,
+    erased_at timestamptz,
+    PRIMARY KEY (id)
+);
+ALTER TABLE people
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS first_name text,
+    ADD COLUMN IF NOT EXISTS middle_name text,
+    ADD COLUMN IF NOT EXISTS last_name text,
+    ADD COLUMN IF NOT EXISTS person_type text,
+    ADD COLUMN IF NOT EXISTS person_id text,
+    ADD COLUMN IF NOT EXISTS gender text,
+    ADD COLUMN IF NOT EXISTS date_of_birth timestamptz,
+    ADD COLUMN IF NOT EXISTS company text,
+    ADD COLUMN IF NOT EXISTS department text,
+    ADD COLUMN IF NOT EXISTS job_position text,
+    ADD COLUMN IF NOT EXISTS address text,
+    ADD COLUMN IF NOT EXISTS mobile_number text,
+    ADD COLUMN IF NOT EXISTS email text,
+    ADD COLUMN IF NOT EXISTS face_image_path text,
+    ADD COLUMN IF NOT EXISTS face_image_normalized_path text,
+    ADD COLUMN IF NOT EXISTS face_image_thumbnail_path text,
+    ADD COLUMN IF NOT EXISTS pin_hash text,
+    ADD COLUMN IF NOT EXISTS is_verified boolean DEFAULT false,
+    ADD COLUMN IF NOT EXISTS active_at timestamptz,
+    ADD COLUMN IF NOT EXISTS expire_at timestamptz,
+    ADD COLUMN IF NOT EXISTS access_control_rule_id text,
+    ADD COLUMN IF NOT EXISTS time_attendance_id text,
+    ADD COLUMN IF NOT EXISTS person_id_index text,
+    ADD COLUMN IF NOT EXISTS email_index text,
+    ADD COLUMN IF NOT EXISTS mobile_number_index text,
+    ADD COLUMN IF NOT EXISTS erased_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_people_deleted_at ON people (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_people_email_index ON people (email_index);
+CREATE INDEX IF NOT EXISTS idx_people_mobile_number_index ON people (mobile_number_index);
+CREATE INDEX IF NOT EXISTS idx_people_person_id_index ON people (person_id_index);
+
+CREATE TABLE IF NOT EXISTS access_control_devices (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name text,
+    type text,
+    host_address text,
+    username text,
+    password text,
+    access_token text,
+    api_token text,
+    access_control_server_id text,
+    record_scan boolean,
+    record_attendance boolean,
+    allow_clock_in boolean,
+    allow_clock_out boolean,
+    status text,
+    PRIMARY KEY (id)
+);
+ALTER TABLE access_control_devices
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS name text,
+    ADD COLUMN IF NOT EXISTS type text,
+    ADD COLUMN IF NOT EXISTS host_address text,
+    ADD COLUMN IF NOT EXISTS username text,
+    ADD COLUMN IF NOT EXISTS password text,
+    ADD COLUMN IF NOT EXISTS access_token text,
+    ADD COLUMN IF NOT EXISTS api_token text,
+    ADD COLUMN IF NOT EXISTS access_control_server_id text,
+    ADD COLUMN IF NOT EXISTS record_scan boolean,
+    ADD COLUMN IF NOT EXISTS record_attendance boolean,
+    ADD COLUMN IF NOT EXISTS allow_clock_in boolean,
+    ADD COLUMN IF NOT EXISTS allow_clock_out boolean,
+    ADD COLUMN IF NOT EXISTS status text;
+CREATE INDEX IF NOT EXISTS idx_access_control_devices_deleted_at ON access_control_devices (deleted_at);
+
+CREATE TABLE IF NOT EXISTS access_control_servers (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name text,
+    type text,
+    host_address text,
+    username text,
+    password text,
+    access_token text,
+    api_token text,
+    status text,
+    last_sync_at timestamptz,
+    PRIMARY KEY (id)
+);
+ALTER TABLE access_control_servers
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS name text,
+    ADD COLUMN IF NOT EXISTS type text,
+    ADD COLUMN IF NOT EXISTS host_address text,
+    ADD COLUMN IF NOT EXISTS username text,
+    ADD COLUMN IF NOT EXISTS password text,
+    ADD COLUMN IF NOT EXISTS access_token text,
+    ADD COLUMN IF NOT EXISTS api_token text,
+    ADD COLUMN IF NOT EXISTS status text,
+    ADD COLUMN IF NOT EXISTS last_sync_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_access_control_servers_deleted_at ON access_control_servers (deleted_at);
+
+CREATE TABLE IF NOT EXISTS access_control_groups (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name text,
+    auth_mode text DEFAULT 'any',
+    two_person_window_seconds bigint DEFAULT 30,
+    holiday_calendar_id text,
+    PRIMARY KEY (id)
+);
+ALTER TABLE access_control_groups
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS name text,
+    ADD COLUMN IF NOT EXISTS auth_mode text DEFAULT 'any',
+    ADD COLUMN IF NOT EXISTS two_person_window_seconds bigint DEFAULT 30,
+    ADD COLUMN IF NOT EXISTS holiday_calendar_id text;
+CREATE INDEX IF NOT EXISTS idx_access_control_groups_deleted_at ON access_control_groups (deleted_at);
+
+CREATE TABLE IF NOT EXISTS access_control_rules (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name text,
+    PRIMARY KEY (id)
+);
+ALTER TABLE access_control_rules
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS name text;
+CREATE INDEX IF NOT EXISTS idx_access_control_rules_deleted_at ON access_control_rules (deleted_at);
+
+CREATE TABLE IF NOT EXISTS access_control_group_devices (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    access_control_group_id text,
+    access_control_device_id text,
+    PRIMARY KEY (id)
+);
+ALTER TABLE access_control_group_devices
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS access_control_group_id text,
+    ADD COLUMN IF NOT EXISTS access_control_device_id text;
+CREATE INDEX IF NOT EXISTS idx_access_control_group_devices_deleted_at ON access_control_group_devices (deleted_at);
+
+CREATE TABLE IF NOT EXISTS access_control_group_schedules (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    access_control_group_id text,
+    day_of_week bigint,
+    date text,
+    start_time text,
+    end_time text,
+    PRIMARY KEY (id)
+);
+ALTER TABLE access_control_group_schedules
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS access_control_group_id text,
+    ADD COLUMN IF NOT EXISTS day_of_week bigint,
+    ADD COLUMN IF NOT EXISTS date text,
+    ADD COLUMN IF NOT EXISTS start_time text,
+    ADD COLUMN IF NOT EXISTS end_time text;
+CREATE INDEX IF NOT EXISTS idx_access_control_group_schedules_deleted_at ON access_control_group_schedules (deleted_at);
+
+CREATE TABLE IF NOT EXISTS access_control_rule_groups (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    access_control_group_id text,
+    access_control_rule_id text,
+    PRIMARY KEY (id)
+);
+ALTER TABLE access_control_rule_groups
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS access_control_group_id text,
+    ADD COLUMN IF NOT EXISTS access_control_rule_id text;
+CREATE INDEX IF NOT EXISTS idx_access_control_rule_groups_deleted_at ON access_control_rule_groups (deleted_at);
+
+CREATE TABLE IF NOT EXISTS person_cards (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    card_number text,
+    person_id text,
+    status text DEFAULT 'active',
+    reason text,
+    active_at timestamptz,
+    expire_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT uni_person_cards_card_number UNIQUE (card_number)
+);
+ALTER TABLE person_cards
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS card_number text,
+    ADD COLUMN IF NOT EXISTS person_id text,
+    ADD COLUMN IF NOT EXISTS status text DEFAULT 'active',
+    ADD COLUMN IF NOT EXISTS reason text,
+    ADD COLUMN IF NOT EXISTS active_at timestamptz,
+    ADD COLUMN IF NOT EXISTS expire_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_person_cards_deleted_at ON person_cards (deleted_at);
+
+CREATE TABLE IF NOT EXISTS person_card_histories (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    person_card_id text,
+    person_id text,
+    card_number text,
+    action text,
+    status text,
+    reason text,
+    active_at timestamptz,
+    expire_at timestamptz,
+    PRIMARY KEY (id)
+);
+ALTER TABLE person_card_histories
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS person_card_id text,
+    ADD COLUMN IF NOT EXISTS person_id text,
+    ADD COLUMN IF NOT EXISTS card_number text,
+    ADD COLUMN IF NOT EXISTS action text,
+    ADD COLUMN IF NOT EXISTS status text,
+    ADD COLUMN IF NOT EXISTS reason text,
+    ADD COLUMN IF NOT EXISTS active_at timestamptz,
+    ADD COLUMN IF NOT EXISTS expire_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_person_card_histories_deleted_at ON person_card_histories (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_person_card_histories_person_card_id ON person_card_histories (person_card_id);
+CREATE INDEX IF NOT EXISTS idx_person_card_histories_person_id ON person_card_histories (person_id);
+
+CREATE TABLE IF NOT EXISTS person_license_plates (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    license_plate_text text,
+    person_id text,
+    PRIMARY KEY (id),
+    CONSTRAINT uni_person_license_plates_license_plate_text UNIQUE (license_plate_text)
+);
+ALTER TABLE person_license_plates
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS license_plate_text text,
+    ADD COLUMN IF NOT EXISTS person_id text;
+CREATE INDEX IF NOT EXISTS idx_person_license_plates_deleted_at ON person_license_plates (deleted_at);
+
+CREATE TABLE IF NOT EXISTS register_forms (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name text,
+    PRIMARY KEY (id)
+);
+ALTER TABLE register_forms
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS name text;
+CREATE INDEX IF NOT EXISTS idx_register_forms_deleted_at ON register_forms (deleted_at);
+
+CREATE TABLE IF NOT EXISTS register_form_fields (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name text,
+    register_form_id text,
+    field_type text,
+    input_type text,
+    placeholder text,
+    label text,
+    help_text text,
+    is_required boolean,
+    default_value text,
+    PRIMARY KEY (id)
+);
+ALTER TABLE register_form_fields
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS name text,
+    ADD COLUMN IF NOT EXISTS register_form_id text,
+    ADD COLUMN IF NOT EXISTS field_type text,
+    ADD COLUMN IF NOT EXISTS input_type text,
+    ADD COLUMN IF NOT EXISTS placeholder text,
+    ADD COLUMN IF NOT EXISTS label text,
+    ADD COLUMN IF NOT EXISTS help_text text,
+    ADD COLUMN IF NOT EXISTS is_required boolean,
+    ADD COLUMN IF NOT EXISTS default_value text;
+CREATE INDEX IF NOT EXISTS idx_register_form_fields_deleted_at ON register_form_fields (deleted_at);
+
+CREATE TABLE IF NOT EXISTS register_form_field_answers (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name text,
+    register_form_id text,
+    register_form_field_id text,
+    person_id text,
+    answer text,
+    PRIMARY KEY (id)
+);
+ALTER TABLE register_form_field_answers
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS name text,
+    ADD COLUMN IF NOT EXISTS register_form_id text,
+    ADD COLUMN IF NOT EXISTS register_form_field_id text,
+    ADD COLUMN IF NOT EXISTS person_id text,
+    ADD COLUMN IF NOT EXISTS answer text;
+CREATE INDEX IF NOT EXISTS idx_register_form_field_answers_deleted_at ON register_form_field_answers (deleted_at);
+
+CREATE TABLE IF NOT EXISTS attendances (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name text,
+    holiday_calendar_id text,
+    overtime_rule_id text,
+    PRIMARY KEY (id)
+);
+ALTER TABLE attendances
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS name text,
+    ADD COLUMN IF NOT EXISTS holiday_calendar_id text,
+    ADD COLUMN IF NOT EXISTS overtime_rule_id text;
+CREATE INDEX IF NOT EXISTS idx_attendances_deleted_at ON attendances (deleted_at);
+
+CREATE TABLE IF NOT EXISTS attendance_schedules (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    attendance_id text,
+    day_of_week bigint,
+    date text,
+    start_time text,
+    end_time text,
+    early_in_minutes bigint,
+    late_in_minutes bigint,
+    early_out_minutes bigint,
+    late_out_minutes bigint,
+    PRIMARY KEY (id)
+);
+ALTER TABLE attendance_schedules
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS attendance_id text,
+    ADD COLUMN IF NOT EXISTS day_of_week bigint,
+    ADD COLUMN IF NOT EXISTS date text,
+    ADD COLUMN IF NOT EXISTS start_time text,
+    ADD COLUMN IF NOT EXISTS end_time text,
+    ADD COLUMN IF NOT EXISTS early_in_minutes bigint,
+    ADD COLUMN IF NOT EXISTS late_in_minutes bigint,
+    ADD COLUMN IF NOT EXISTS early_out_minutes bigint,
+    ADD COLUMN IF NOT EXISTS late_out_minutes bigint;
+CREATE INDEX IF NOT EXISTS idx_attendance_schedules_deleted_at ON attendance_schedules (deleted_at);
+
+CREATE TABLE IF NOT EXISTS attendance_records (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    person_id text,
+    attendance_schedule_id text,
+    access_record_id text,
+    date text,
+    status text,
+    holiday_id text,
+    holiday_name text,
+    shift_template_id text,
+    shift_name text,
+    leave_request_id text,
+    leave_type_name text,
+    leave_days decimal,
+    schedule_start_time text,
+    schedule_end_time text,
+    ends_next_day boolean,
+    check_in_at timestamptz,
+    check_out_at timestamptz,
+    check_out_access_record_id text,
+    check_in_correction_id text,
+    check_out_correction_id text,
+    late_minutes bigint,
+    early_leave_minutes bigint,
+    worked_minutes bigint,
+    overtime_minutes bigint,
+    overtime_day_type text,
+    overtime_multiplier decimal,
+    overtime_status text,
+    approved_overtime_minutes bigint,
+    overtime_decided_by_user_id text,
+    overtime_decided_at timestamptz,
+    overtime_decision_note text,
+    PRIMARY KEY (id)
+);
+ALTER TABLE attendance_records
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS person_id text,
+    ADD COLUMN IF NOT EXISTS attendance_schedule_id text,
+    ADD COLUMN IF NOT EXISTS access_record_id text,
+    ADD COLUMN IF NOT EXISTS date text,
+    ADD COLUMN IF NOT EXISTS status text,
+    ADD COLUMN IF NOT EXISTS holiday_id text,
+    ADD COLUMN IF NOT EXISTS holiday_name text,
+    ADD COLUMN IF NOT EXISTS shift_template_id text,
+    ADD COLUMN IF NOT EXISTS shift_name text,
+    ADD COLUMN IF NOT EXISTS leave_request_id text,
+    ADD COLUMN IF NOT EXISTS leave_type_name text,
+    ADD COLUMN IF NOT EXISTS leave_days decimal,
+    ADD COLUMN IF NOT EXISTS schedule_start_time text,
+    ADD COLUMN IF NOT EXISTS schedule_end_time text,
+    ADD COLUMN IF NOT EXISTS ends_next_day boolean,
+    ADD COLUMN IF NOT EXISTS check_in_at timestamptz,
+    ADD COLUMN IF NOT EXISTS check_out_at timestamptz,
+    ADD COLUMN IF NOT EXISTS check_out_access_record_id text,
+    ADD COLUMN IF NOT EXISTS check_in_correction_id text,
+    ADD COLUMN IF NOT EXISTS check_out_correction_id text,
+    ADD COLUMN IF NOT EXISTS late_minutes bigint,
+    ADD COLUMN IF NOT EXISTS early_leave_minutes bigint,
+    ADD COLUMN IF NOT EXISTS worked_minutes bigint,
+    ADD COLUMN IF NOT EXISTS overtime_minutes bigint,
+    ADD COLUMN IF NOT EXISTS overtime_day_type text,
+    ADD COLUMN IF NOT EXISTS overtime_multiplier decimal,
+    ADD COLUMN IF NOT EXISTS overtime_status text,
+    ADD COLUMN IF NOT EXISTS approved_overtime_minutes bigint,
+    ADD COLUMN IF NOT EXISTS overtime_decided_by_user_id text,
+    ADD COLUMN IF NOT EXISTS overtime_decided_at timestamptz,
+    ADD COLUMN IF NOT EXISTS overtime_decision_note text;
+CREATE INDEX IF NOT EXISTS idx_attendance_record_person_date ON attendance_records (person_id,date);
+CREATE INDEX IF NOT EXISTS idx_attendance_records_deleted_at ON attendance_records (deleted_at);
+
+CREATE TABLE IF NOT EXISTS access_records (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    person_id text,
+    access_control_device_id text,
+    type text,
+    result text,
+    access_time timestamptz,
+    card_number text,
+    plate_text text,
+    plate_confidence decimal,
+    plate_image_path text,
+    reason text,
+    factors text,
+    scan_session_id text,
+    sequence bigint,
+    prev_hash text,
+    hash text,
+    PRIMARY KEY (id)
+);
+ALTER TABLE access_records
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS person_id text,
+    ADD COLUMN IF NOT EXISTS access_control_device_id text,
+    ADD COLUMN IF NOT EXISTS type text,
+    ADD COLUMN IF NOT EXISTS result text,
+    ADD COLUMN IF NOT EXISTS access_time timestamptz,
+    ADD COLUMN IF NOT EXISTS card_number text,
+    ADD COLUMN IF NOT EXISTS plate_text text,
+    ADD COLUMN IF NOT EXISTS plate_confidence decimal,
+    ADD COLUMN IF NOT EXISTS plate_image_path text,
+    ADD COLUMN IF NOT EXISTS reason text,
+    ADD COLUMN IF NOT EXISTS factors text,
+    ADD COLUMN IF NOT EXISTS scan_session_id text,
+    ADD COLUMN IF NOT EXISTS sequence bigint,
+    ADD COLUMN IF NOT EXISTS prev_hash text,
+    ADD COLUMN IF NOT EXISTS hash text;
+CREATE INDEX IF NOT EXISTS idx_access_records_deleted_at ON access_records (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_access_records_sequence ON access_records (sequence);
+
+CREATE TABLE IF NOT EXISTS access_record_annotations (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    access_record_id text,
+    field text,
+    value text,
+    note text,
+    created_by text,
+    PRIMARY KEY (id)
+);
+ALTER TABLE access_record_annotations
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS access_record_id text,
+    ADD COLUMN IF NOT EXISTS field text,
+    ADD COLUMN IF NOT EXISTS value text,
+    ADD COLUMN IF NOT EXISTS note text,
+    ADD COLUMN IF NOT EXISTS created_by text;
+CREATE INDEX IF NOT EXISTS idx_access_record_annotations_access_record_id ON access_record_annotations (access_record_id);
+CREATE INDEX IF NOT EXISTS idx_access_record_annotations_deleted_at ON access_record_annotations (deleted_at);
+
+CREATE TABLE IF NOT EXISTS access_record_chain_checkpoints (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    sequence bigint,
+    hash text,
+    retention_run_id text,
+    PRIMARY KEY (id)
+);
+ALTER TABLE access_record_chain_checkpoints
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS sequence bigint,
+    ADD COLUMN IF NOT EXISTS hash text,
+    ADD COLUMN IF NOT EXISTS retention_run_id text;
+CREATE INDEX IF NOT EXISTS idx_access_record_chain_checkpoints_deleted_at ON access_record_chain_checkpoints (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_access_record_chain_checkpoints_sequence ON access_record_chain_checkpoints (sequence);
+
+CREATE TABLE IF NOT EXISTS visitor_vehicles (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    plate_text text,
+    normalized_plate_text text,
+    first_seen_at timestamptz,
+    last_seen_at timestamptz,
+    seen_count bigint,
+    access_control_device_id text,
+    last_image_path text,
+    PRIMARY KEY (id)
+);
+ALTER TABLE visitor_vehicles
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS plate_text text,
+    ADD COLUMN IF NOT EXISTS normalized_plate_text text,
+    ADD COLUMN IF NOT EXISTS first_seen_at timestamptz,
+    ADD COLUMN IF NOT EXISTS last_seen_at timestamptz,
+    ADD COLUMN IF NOT EXISTS seen_count bigint,
+    ADD COLUMN IF NOT EXISTS access_control_device_id text,
+    ADD COLUMN IF NOT EXISTS last_image_path text;
+CREATE INDEX IF NOT EXISTS idx_visitor_vehicles_deleted_at ON visitor_vehicles (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_visitor_vehicles_normalized_plate_text ON visitor_vehicles (normalized_plate_text);
+
+CREATE TABLE IF NOT EXISTS access_scan_sessions (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    access_control_device_id text,
+    type text,
+    status text DEFAULT 'open',
+    expires_at timestamptz,
+    person_id text,
+    factors text,
+    first_person_id text,
+    first_person_at timestamptz,
+    PRIMARY KEY (id)
+);
+ALTER TABLE access_scan_sessions
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS access_control_device_id text,
+    ADD COLUMN IF NOT EXISTS type text,
+    ADD COLUMN IF NOT EXISTS status text DEFAULT 'open',
+    ADD COLUMN IF NOT EXISTS expires_at timestamptz,
+    ADD COLUMN IF NOT EXISTS person_id text,
+    ADD COLUMN IF NOT EXISTS factors text,
+    ADD COLUMN IF NOT EXISTS first_person_id text,
+    ADD COLUMN IF NOT EXISTS first_person_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_access_scan_sessions_deleted_at ON access_scan_sessions (deleted_at);
+
+CREATE TABLE IF NOT EXISTS holiday_calendars (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name text,
+    description text,
+    PRIMARY KEY (id)
+);
+ALTER TABLE holiday_calendars
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS name text,
+    ADD COLUMN IF NOT EXISTS description text;
+CREATE INDEX IF NOT EXISTS idx_holiday_calendars_deleted_at ON holiday_calendars (deleted_at);
+
+CREATE TABLE IF NOT EXISTS holidays (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    holiday_calendar_id text,
+    name text,
+    start_date text,
+    end_date text,
+    start_time text,
+    end_time text,
+    PRIMARY KEY (id)
+);
+ALTER TABLE holidays
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS holiday_calendar_id text,
+    ADD COLUMN IF NOT EXISTS name text,
+    ADD COLUMN IF NOT EXISTS start_date text,
+    ADD COLUMN IF NOT EXISTS end_date text,
+    ADD COLUMN IF NOT EXISTS start_time text,
+    ADD COLUMN IF NOT EXISTS end_time text;
+CREATE INDEX IF NOT EXISTS idx_holidays_deleted_at ON holidays (deleted_at);
+
+CREATE TABLE IF NOT EXISTS shift_templates (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name text,
+    start_time text,
+    end_time text,
+    early_in_minutes bigint,
+    late_in_minutes bigint,
+    early_out_minutes bigint,
+    late_out_minutes bigint,
+    PRIMARY KEY (id)
+);
+ALTER TABLE shift_templates
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS name text,
+    ADD COLUMN IF NOT EXISTS start_time text,
+    ADD COLUMN IF NOT EXISTS end_time text,
+    ADD COLUMN IF NOT EXISTS early_in_minutes bigint,
+    ADD COLUMN IF NOT EXISTS late_in_minutes bigint,
+    ADD COLUMN IF NOT EXISTS early_out_minutes bigint,
+    ADD COLUMN IF NOT EXISTS late_out_minutes bigint;
+CREATE INDEX IF NOT EXISTS idx_shift_templates_deleted_at ON shift_templates (deleted_at);
+
+CREATE TABLE IF NOT EXISTS shift_rotations (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name text,
+    anchor_date text,
+    cycle_days bigint,
+    PRIMARY KEY (id)
+);
+ALTER TABLE shift_rotations
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS name text,
+    ADD COLUMN IF NOT EXISTS anchor_date text,
+    ADD COLUMN IF NOT EXISTS cycle_days bigint;
+CREATE INDEX IF NOT EXISTS idx_shift_rotations_deleted_at ON shift_rotations (deleted_at);
+
+CREATE TABLE IF NOT EXISTS shift_rotation_days (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    shift_rotation_id text,
+    day_index bigint,
+    shift_template_id text,
+    PRIMARY KEY (id)
+);
+ALTER TABLE shift_rotation_days
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS shift_rotation_id text,
+    ADD COLUMN IF NOT EXISTS day_index bigint,
+    ADD COLUMN IF NOT EXISTS shift_template_id text;
+CREATE INDEX IF NOT EXISTS idx_shift_rotation_days_deleted_at ON shift_rotation_days (deleted_at);
+
+CREATE TABLE IF NOT EXISTS person_shift_assignments (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    person_id text,
+    shift_rotation_id text,
+    start_date text,
+    end_date text,
+    PRIMARY KEY (id)
+);
+ALTER TABLE person_shift_assignments
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS person_id text,
+    ADD COLUMN IF NOT EXISTS shift_rotation_id text,
+    ADD COLUMN IF NOT EXISTS start_date text,
+    ADD COLUMN IF NOT EXISTS end_date text;
+CREATE INDEX IF NOT EXISTS idx_person_shift_assignments_deleted_at ON person_shift_assignments (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_person_shift_assignments_person_id ON person_shift_assignments (person_id);
+
+CREATE TABLE IF NOT EXISTS person_shift_overrides (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    person_id text,
+    date text,
+    shift_template_id text,
+    reason text,
+    PRIMARY KEY (id)
+);
+ALTER TABLE person_shift_overrides
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS person_id text,
+    ADD COLUMN IF NOT EXISTS date text,
+    ADD COLUMN IF NOT EXISTS shift_template_id text,
+    ADD COLUMN IF NOT EXISTS reason text;
+CREATE INDEX IF NOT EXISTS idx_person_shift_overrides_deleted_at ON person_shift_overrides (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_person_shift_overrides_person_id ON person_shift_overrides (person_id);
+
+CREATE TABLE IF NOT EXISTS leave_types (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name text,
+    description text,
+    days_per_year decimal,
+    allow_half_day boolean DEFAULT true,
+    PRIMARY KEY (id)
+);
+ALTER TABLE leave_types
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS name text,
+    ADD COLUMN IF NOT EXISTS description text,
+    ADD COLUMN IF NOT EXISTS days_per_year decimal,
+    ADD COLUMN IF NOT EXISTS allow_half_day boolean DEFAULT true;
+CREATE INDEX IF NOT EXISTS idx_leave_types_deleted_at ON leave_types (deleted_at);
+
+CREATE TABLE IF NOT EXISTS leave_requests (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    person_id text,
+    leave_type_id text,
+    start_date text,
+    end_date text,
+    half_day text,
+    days decimal,
+    reason text,
+    status text DEFAULT 'pending',
+    requested_by text,
+    decided_by_user_id text,
+    decided_at timestamptz,
+    decision_note text,
+    PRIMARY KEY (id)
+);
+ALTER TABLE leave_requests
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS person_id text,
+    ADD COLUMN IF NOT EXISTS leave_type_id text,
+    ADD COLUMN IF NOT EXISTS start_date text,
+    ADD COLUMN IF NOT EXISTS end_date text,
+    ADD COLUMN IF NOT EXISTS half_day text,
+    ADD COLUMN IF NOT EXISTS days decimal,
+    ADD COLUMN IF NOT EXISTS reason text,
+    ADD COLUMN IF NOT EXISTS status text DEFAULT 'pending',
+    ADD COLUMN IF NOT EXISTS requested_by text,
+    ADD COLUMN IF NOT EXISTS decided_by_user_id text,
+    ADD COLUMN IF NOT EXISTS decided_at timestamptz,
+    ADD COLUMN IF NOT EXISTS decision_note text;
+CREATE INDEX IF NOT EXISTS idx_leave_requests_deleted_at ON leave_requests (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_leave_requests_leave_type_id ON leave_requests (leave_type_id);
+CREATE INDEX IF NOT EXISTS idx_leave_requests_person_id ON leave_requests (person_id);
+
+CREATE TABLE IF NOT EXISTS leave_balances (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    person_id text,
+    leave_type_id text,
+    year bigint,
+    entitled_days decimal,
+    PRIMARY KEY (id)
+);
+ALTER TABLE leave_balances
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS person_id text,
+    ADD COLUMN IF NOT EXISTS leave_type_id text,
+    ADD COLUMN IF NOT EXISTS year bigint,
+    ADD COLUMN IF NOT EXISTS entitled_days decimal;
+CREATE INDEX IF NOT EXISTS idx_leave_balances_deleted_at ON leave_balances (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_leave_balances_person_id ON leave_balances (person_id);
+
+CREATE TABLE IF NOT EXISTS attendance_corrections (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    person_id text,
+    date text,
+    type text,
+    time timestamptz,
+    reason text,
+    status text DEFAULT 'pending',
+    requested_by text,
+    decided_by_user_id text,
+    decided_at timestamptz,
+    decision_note text,
+    PRIMARY KEY (id)
+);
+ALTER TABLE attendance_corrections
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS person_id text,
+    ADD COLUMN IF NOT EXISTS date text,
+    ADD COLUMN IF NOT EXISTS type text,
+    ADD COLUMN IF NOT EXISTS time timestamptz,
+    ADD COLUMN IF NOT EXISTS reason text,
+    ADD COLUMN IF NOT EXISTS status text DEFAULT 'pending',
+    ADD COLUMN IF NOT EXISTS requested_by text,
+    ADD COLUMN IF NOT EXISTS decided_by_user_id text,
+    ADD COLUMN IF NOT EXISTS decided_at timestamptz,
+    ADD COLUMN IF NOT EXISTS decision_note text;
+CREATE INDEX IF NOT EXISTS idx_attendance_corrections_deleted_at ON attendance_corrections (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_attendance_corrections_person_id ON attendance_corrections (person_id);
+
+CREATE TABLE IF NOT EXISTS overtime_rules (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name text,
+    minimum_minutes bigint,
+    rounding_minutes bigint,
+    daily_cap_minutes bigint,
+    weekend_days text DEFAULT '6,7',
+    weekday_multiplier decimal DEFAULT 1.5,
+    weekend_multiplier decimal DEFAULT 2,
+    holiday_multiplier decimal DEFAULT 3,
+    PRIMARY KEY (id)
+);
+ALTER TABLE overtime_rules
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS name text,
+    ADD COLUMN IF NOT EXISTS minimum_minutes bigint,
+    ADD COLUMN IF NOT EXISTS rounding_minutes bigint,
+    ADD COLUMN IF NOT EXISTS daily_cap_minutes bigint,
+    ADD COLUMN IF NOT EXISTS weekend_days text DEFAULT '6,7',
+    ADD COLUMN IF NOT EXISTS weekday_multiplier decimal DEFAULT 1.5,
+    ADD COLUMN IF NOT EXISTS weekend_multiplier decimal DEFAULT 2,
+    ADD COLUMN IF NOT EXISTS holiday_multiplier decimal DEFAULT 3;
+CREATE INDEX IF NOT EXISTS idx_overtime_rules_deleted_at ON overtime_rules (deleted_at);
+
+CREATE TABLE IF NOT EXISTS payroll_export_templates (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name text,
+    company text,
+    format text,
+    delimiter text DEFAULT ',',
+    date_format text DEFAULT 'YYYY-MM-DD',
+    include_header boolean,
+    PRIMARY KEY (id)
+);
+ALTER TABLE payroll_export_templates
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS name text,
+    ADD COLUMN IF NOT EXISTS company text,
+    ADD COLUMN IF NOT EXISTS format text,
+    ADD COLUMN IF NOT EXISTS delimiter text DEFAULT ',',
+    ADD COLUMN IF NOT EXISTS date_format text DEFAULT 'YYYY-MM-DD',
+    ADD COLUMN IF NOT EXISTS include_header boolean;
+CREATE INDEX IF NOT EXISTS idx_payroll_export_templates_deleted_at ON payroll_export_templates (deleted_at);
+
+CREATE TABLE IF NOT EXISTS payroll_export_columns (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    template_id text,
+    position bigint,
+    field text,
+    header text,
+    width bigint,
+    align text,
+    PRIMARY KEY (id)
+);
+ALTER TABLE payroll_export_columns
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS template_id text,
+    ADD COLUMN IF NOT EXISTS position bigint,
+    ADD COLUMN IF NOT EXISTS field text,
+    ADD COLUMN IF NOT EXISTS header text,
+    ADD COLUMN IF NOT EXISTS width bigint,
+    ADD COLUMN IF NOT EXISTS align text;
+CREATE INDEX IF NOT EXISTS idx_payroll_export_columns_deleted_at ON payroll_export_columns (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_payroll_export_columns_template_id ON payroll_export_columns (template_id);
+
+CREATE TABLE IF NOT EXISTS payroll_exports (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    template_id text,
+    template_name text,
+    company text,
+    start_date text,
+    end_date text,
+    file_name text,
+    file_path text,
+    rows bigint,
+    status text DEFAULT 'open',
+    generated_by text,
+    locked_by_user_id text,
+    locked_at timestamptz,
+    changed_after_lock boolean,
+    changed_at timestamptz,
+    PRIMARY KEY (id)
+);
+ALTER TABLE payroll_exports
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS template_id text,
+    ADD COLUMN IF NOT EXISTS template_name text,
+    ADD COLUMN IF NOT EXISTS company text,
+    ADD COLUMN IF NOT EXISTS start_date text,
+    ADD COLUMN IF NOT EXISTS end_date text,
+    ADD COLUMN IF NOT EXISTS file_name text,
+    ADD COLUMN IF NOT EXISTS file_path text,
+    ADD COLUMN IF NOT EXISTS rows bigint,
+    ADD COLUMN IF NOT EXISTS status text DEFAULT 'open',
+    ADD COLUMN IF NOT EXISTS generated_by text,
+    ADD COLUMN IF NOT EXISTS locked_by_user_id text,
+    ADD COLUMN IF NOT EXISTS locked_at timestamptz,
+    ADD COLUMN IF NOT EXISTS changed_after_lock boolean,
+    ADD COLUMN IF NOT EXISTS changed_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_payroll_exports_deleted_at ON payroll_exports (deleted_at);
+
+CREATE TABLE IF NOT EXISTS payroll_export_lines (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    export_id text,
+    person_id text,
+    worked_days bigint,
+    worked_minutes bigint,
+    absent_days bigint,
+    late_minutes bigint,
+    early_leave_minutes bigint,
+    leave_days decimal,
+    overtime_minutes bigint,
+    weighted_overtime_hours decimal,
+    PRIMARY KEY (id)
+);
+ALTER TABLE payroll_export_lines
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS export_id text,
+    ADD COLUMN IF NOT EXISTS person_id text,
+    ADD COLUMN IF NOT EXISTS worked_days bigint,
+    ADD COLUMN IF NOT EXISTS worked_minutes bigint,
+    ADD COLUMN IF NOT EXISTS absent_days bigint,
+    ADD COLUMN IF NOT EXISTS late_minutes bigint,
+    ADD COLUMN IF NOT EXISTS early_leave_minutes bigint,
+    ADD COLUMN IF NOT EXISTS leave_days decimal,
+    ADD COLUMN IF NOT EXISTS overtime_minutes bigint,
+    ADD COLUMN IF NOT EXISTS weighted_overtime_hours decimal;
+CREATE INDEX IF NOT EXISTS idx_payroll_export_lines_deleted_at ON payroll_export_lines (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_payroll_export_lines_export_id ON payroll_export_lines (export_id);
+CREATE INDEX IF NOT EXISTS idx_payroll_export_lines_person_id ON payroll_export_lines (person_id);
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    action text,
+    entity_type text,
+    entity_id text,
+    username text,
+    detail text,
+    PRIMARY KEY (id)
+);
+ALTER TABLE audit_logs
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS action text,
+    ADD COLUMN IF NOT EXISTS entity_type text,
+    ADD COLUMN IF NOT EXISTS entity_id text,
+    ADD COLUMN IF NOT EXISTS username text,
+    ADD COLUMN IF NOT EXISTS detail text;
+CREATE INDEX IF NOT EXISTS idx_audit_logs_action ON audit_logs (action);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_deleted_at ON audit_logs (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity_id ON audit_logs (entity_id);
+
+CREATE TABLE IF NOT EXISTS retention_runs (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    trigger text,
+    triggered_by text,
+    status text DEFAULT 'running',
+    started_at timestamptz,
+    finished_at timestamptz,
+    error text,
+    PRIMARY KEY (id)
+);
+ALTER TABLE retention_runs
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS trigger text,
+    ADD COLUMN IF NOT EXISTS triggered_by text,
+    ADD COLUMN IF NOT EXISTS status text DEFAULT 'running',
+    ADD COLUMN IF NOT EXISTS started_at timestamptz,
+    ADD COLUMN IF NOT EXISTS finished_at timestamptz,
+    ADD COLUMN IF NOT EXISTS error text;
+CREATE INDEX IF NOT EXISTS idx_retention_runs_deleted_at ON retention_runs (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_retention_runs_started_at ON retention_runs (started_at);
+
+CREATE TABLE IF NOT EXISTS retention_run_items (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    run_id text,
+    data_class text,
+    cutoff timestamptz,
+    purged bigint,
+    archive_path text,
+    detail text,
+    PRIMARY KEY (id)
+);
+ALTER TABLE retention_run_items
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS run_id text,
+    ADD COLUMN IF NOT EXISTS data_class text,
+    ADD COLUMN IF NOT EXISTS cutoff timestamptz,
+    ADD COLUMN IF NOT EXISTS purged bigint,
+    ADD COLUMN IF NOT EXISTS archive_path text,
+    ADD COLUMN IF NOT EXISTS detail text;
+CREATE INDEX IF NOT EXISTS idx_retention_run_items_deleted_at ON retention_run_items (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_retention_run_items_run_id ON retention_run_items (run_id);
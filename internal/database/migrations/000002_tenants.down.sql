@@ -0,0 +1,53 @@
+-- Reverting fails while card numbers or license plates are used by more than one tenant.
+
+ALTER TABLE users DROP COLUMN IF EXISTS is_super_admin;
+
+DROP INDEX IF EXISTS idx_visitor_vehicles_tenant_plate;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_visitor_vehicles_normalized_plate_text ON visitor_vehicles (normalized_plate_text);
+DROP INDEX IF EXISTS idx_person_license_plates_tenant_text;
+ALTER TABLE person_license_plates ADD CONSTRAINT uni_person_license_plates_license_plate_text UNIQUE (license_plate_text);
+DROP INDEX IF EXISTS idx_person_cards_tenant_card_number;
+ALTER TABLE person_cards ADD CONSTRAINT uni_person_cards_card_number UNIQUE (card_number);
+
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE payroll_export_lines DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE payroll_exports DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE payroll_export_columns DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE payroll_export_templates DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE overtime_rules DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE attendance_corrections DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE leave_balances DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE leave_requests DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE leave_types DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE person_shift_overrides DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE person_shift_assignments DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE shift_rotation_days DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE shift_rotations DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE shift_templates DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE holidays DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE holiday_calendars DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE access_scan_sessions DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE visitor_vehicles DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE access_record_annotations DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE access_records DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE attendance_records DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE attendance_schedules DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE attendances DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE register_form_field_answers DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE register_form_fields DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE register_forms DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE person_license_plates DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE person_card_histories DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE person_cards DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE access_control_rule_groups DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE access_control_group_schedules DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE access_control_group_devices DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE access_control_rules DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE access_control_groups DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE access_control_servers DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE access_control_devices DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE people DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE users DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE user_permissions DROP COLUMN IF EXISTS tenant_id;
+
+DROP TABLE IF EXISTS tenants;
@@ -0,0 +1,187 @@
+-- Tenants. Every existing row is moved to a default tenant, and existing users become super admins
+-- because they administered the whole deployment before.
+
+CREATE TABLE IF NOT EXISTS tenants (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name text,
+    code text,
+    is_active boolean,
+    PRIMARY KEY (id),
+    CONSTRAINT uni_tenants_code UNIQUE (code)
+);
+CREATE INDEX IF NOT EXISTS idx_tenants_deleted_at ON tenants (deleted_at);
+
+INSERT INTO tenants (created_at, updated_at, name, code, is_active)
+VALUES (now(), now(), 'Default', 'default', true)
+ON CONFLICT (code) DO NOTHING;
+
+ALTER TABLE user_permissions ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE user_permissions SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_user_permissions_tenant_id ON user_permissions (tenant_id);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE users SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_users_tenant_id ON users (tenant_id);
+
+ALTER TABLE people ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE people SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_people_tenant_id ON people (tenant_id);
+
+ALTER TABLE access_control_devices ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE access_control_devices SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_access_control_devices_tenant_id ON access_control_devices (tenant_id);
+
+ALTER TABLE access_control_servers ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE access_control_servers SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_access_control_servers_tenant_id ON access_control_servers (tenant_id);
+
+ALTER TABLE access_control_groups ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE access_control_groups SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_access_control_groups_tenant_id ON access_control_groups (tenant_id);
+
+ALTER TABLE access_control_rules ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE access_control_rules SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_access_control_rules_tenant_id ON access_control_rules (tenant_id);
+
+ALTER TABLE access_control_group_devices ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE access_control_group_devices SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_access_control_group_devices_tenant_id ON access_control_group_devices (tenant_id);
+
+ALTER TABLE access_control_group_schedules ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE access_control_group_schedules SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_access_control_group_schedules_tenant_id ON access_control_group_schedules (tenant_id);
+
+ALTER TABLE access_control_rule_groups ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE access_control_rule_groups SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_access_control_rule_groups_tenant_id ON access_control_rule_groups (tenant_id);
+
+ALTER TABLE person_cards ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE person_cards SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+
+ALTER TABLE person_card_histories ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE person_card_histories SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_person_card_histories_tenant_id ON person_card_histories (tenant_id);
+
+ALTER TABLE person_license_plates ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE person_license_plates SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+
+ALTER TABLE register_forms ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE register_forms SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_register_forms_tenant_id ON register_forms (tenant_id);
+
+ALTER TABLE register_form_fields ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE register_form_fields SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_register_form_fields_tenant_id ON register_form_fields (tenant_id);
+
+ALTER TABLE register_form_field_answers ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE register_form_field_answers SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_register_form_field_answers_tenant_id ON register_form_field_answers (tenant_id);
+
+ALTER TABLE attendances ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE attendances SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_attendances_tenant_id ON attendances (tenant_id);
+
+ALTER TABLE attendance_schedules ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE attendance_schedules SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_attendance_schedules_tenant_id ON attendance_schedules (tenant_id);
+
+ALTER TABLE attendance_records ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE attendance_records SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_attendance_records_tenant_id ON attendance_records (tenant_id);
+
+ALTER TABLE access_records ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE access_records SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_access_records_tenant_id ON access_records (tenant_id);
+
+ALTER TABLE access_record_annotations ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE access_record_annotations SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_access_record_annotations_tenant_id ON access_record_annotations (tenant_id);
+
+ALTER TABLE visitor_vehicles ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE visitor_vehicles SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+
+ALTER TABLE access_scan_sessions ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE access_scan_sessions SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_access_scan_sessions_tenant_id ON access_scan_sessions (tenant_id);
+
+ALTER TABLE holiday_calendars ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE holiday_calendars SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_holiday_calendars_tenant_id ON holiday_calendars (tenant_id);
+
+ALTER TABLE holidays ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE holidays SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_holidays_tenant_id ON holidays (tenant_id);
+
+ALTER TABLE shift_templates ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE shift_templates SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_shift_templates_tenant_id ON shift_templates (tenant_id);
+
+ALTER TABLE shift_rotations ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE shift_rotations SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_shift_rotations_tenant_id ON shift_rotations (tenant_id);
+
+ALTER TABLE shift_rotation_days ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE shift_rotation_days SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_shift_rotation_days_tenant_id ON shift_rotation_days (tenant_id);
+
+ALTER TABLE person_shift_assignments ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE person_shift_assignments SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_person_shift_assignments_tenant_id ON person_shift_assignments (tenant_id);
+
+ALTER TABLE person_shift_overrides ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE person_shift_overrides SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_person_shift_overrides_tenant_id ON person_shift_overrides (tenant_id);
+
+ALTER TABLE leave_types ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE leave_types SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_leave_types_tenant_id ON leave_types (tenant_id);
+
+ALTER TABLE leave_requests ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE leave_requests SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_leave_requests_tenant_id ON leave_requests (tenant_id);
+
+ALTER TABLE leave_balances ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE leave_balances SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_leave_balances_tenant_id ON leave_balances (tenant_id);
+
+ALTER TABLE attendance_corrections ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE attendance_corrections SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_attendance_corrections_tenant_id ON attendance_corrections (tenant_id);
+
+ALTER TABLE overtime_rules ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE overtime_rules SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_overtime_rules_tenant_id ON overtime_rules (tenant_id);
+
+ALTER TABLE payroll_export_templates ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE payroll_export_templates SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_payroll_export_templates_tenant_id ON payroll_export_templates (tenant_id);
+
+ALTER TABLE payroll_export_columns ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE payroll_export_columns SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_payroll_export_columns_tenant_id ON payroll_export_columns (tenant_id);
+
+ALTER TABLE payroll_exports ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE payroll_exports SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_payroll_exports_tenant_id ON payroll_exports (tenant_id);
+
+ALTER TABLE payroll_export_lines ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE payroll_export_lines SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_payroll_export_lines_tenant_id ON payroll_export_lines (tenant_id);
+
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS tenant_id text;
+UPDATE audit_logs SET tenant_id = (SELECT id::text FROM tenants WHERE code = 'default') WHERE tenant_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_audit_logs_tenant_id ON audit_logs (tenant_id);
+
+-- Card numbers and license plates are unique per tenant
+ALTER TABLE person_cards DROP CONSTRAINT IF EXISTS uni_person_cards_card_number;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_person_cards_tenant_card_number ON person_cards (tenant_id, card_number);
+ALTER TABLE person_license_plates DROP CONSTRAINT IF EXISTS uni_person_license_plates_license_plate_text;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_person_license_plates_tenant_text ON person_license_plates (tenant_id, license_plate_text);
+DROP INDEX IF EXISTS idx_visitor_vehicles_normalized_plate_text;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_visitor_vehicles_tenant_plate ON visitor_vehicles (tenant_id, normalized_plate_text);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_super_admin boolean DEFAULT false;
+UPDATE users SET is_super_admin = true;
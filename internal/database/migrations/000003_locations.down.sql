@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_access_control_devices_location_id;
+ALTER TABLE access_control_devices DROP COLUMN IF EXISTS location_id;
+
+DROP TABLE IF EXISTS access_control_group_locations;
+DROP TABLE IF EXISTS locations;
@@ -0,0 +1,35 @@
+-- Location hierarchy of devices, and groups defined by location.
+
+CREATE TABLE IF NOT EXISTS locations (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    tenant_id text,
+    name text,
+    type text,
+    parent_id text,
+    description text,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_locations_deleted_at ON locations (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_locations_tenant_id ON locations (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_locations_parent_id ON locations (parent_id);
+
+CREATE TABLE IF NOT EXISTS access_control_group_locations (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    tenant_id text,
+    access_control_group_id text,
+    location_id text,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_access_control_group_locations_deleted_at ON access_control_group_locations (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_access_control_group_locations_tenant_id ON access_control_group_locations (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_access_control_group_locations_access_control_group_id ON access_control_group_locations (access_control_group_id);
+CREATE INDEX IF NOT EXISTS idx_access_control_group_locations_location_id ON access_control_group_locations (location_id);
+
+ALTER TABLE access_control_devices ADD COLUMN IF NOT EXISTS location_id text;
+CREATE INDEX IF NOT EXISTS idx_access_control_devices_location_id ON access_control_devices (location_id);
@@ -0,0 +1,2 @@
+ALTER TABLE access_control_devices DROP COLUMN IF EXISTS time_zone;
+ALTER TABLE locations DROP COLUMN IF EXISTS time_zone;
@@ -0,0 +1,11 @@
+-- Time zones of locations and devices. Both are optional: a device without a time zone uses the
+-- nearest time zone of its location and the locations above it, and the configured time_zone of
+-- the server when none is set, so existing devices keep evaluating their schedules as before.
+--
+-- Stored timestamps are timestamptz and keep their meaning. Timestamps sent without an offset
+-- ("YYYY-MM-DD HH:MM:SS") used to be read as UTC and are now read in the local time of the device
+-- or the server; send RFC 3339 timestamps with an offset to be unambiguous. Attendance used to
+-- plan shift hours in UTC, recalculate attendance after upgrading to plan them in local time.
+
+ALTER TABLE locations ADD COLUMN IF NOT EXISTS time_zone text;
+ALTER TABLE access_control_devices ADD COLUMN IF NOT EXISTS time_zone text;
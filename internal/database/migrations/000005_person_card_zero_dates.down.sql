@@ -0,0 +1,2 @@
+-- NULL is how a card without a validity date is stored from now on, there is nothing to revert.
+SELECT 1;
@@ -0,0 +1,6 @@
+-- Cards created before active_at and expire_at became optional stored the zero time
+-- (0001-01-01) for a date that was not set, which now reads as a card that expired in year 1.
+-- Store NULL for them instead.
+
+UPDATE person_cards SET active_at = NULL WHERE active_at = '0001-01-01 00:00:00+00';
+UPDATE person_cards SET expire_at = NULL WHERE expire_at = '0001-01-01 00:00:00+00';
@@ -0,0 +1,4 @@
+-- The keys of visitor vehicles stay normalized the new way, older servers only create a second
+-- record for a vehicle whose plate folds differently.
+DROP INDEX IF EXISTS idx_person_license_plates_tenant_normalized;
+ALTER TABLE person_license_plates DROP COLUMN IF EXISTS normalized_plate_text;
@@ -0,0 +1,11 @@
+-- Registered license plates keep their comparison key (see common.NormalizeLicensePlate) so a
+-- plate read by a camera is looked up by index instead of comparing every registered plate.
+--
+-- The key no longer folds S/5, B/8, G/6, Z/2, D/0, Q/0 and L/1, only O/0 and I/1. The keys of
+-- visitor vehicles are cleared and, like the new keys of registered plates, set by the server on
+-- its next start. The old keys were coarser, so vehicles that had distinct keys keep them distinct.
+
+ALTER TABLE person_license_plates ADD COLUMN IF NOT EXISTS normalized_plate_text text;
+CREATE INDEX IF NOT EXISTS idx_person_license_plates_tenant_normalized ON person_license_plates (tenant_id, normalized_plate_text);
+
+UPDATE visitor_vehicles SET normalized_plate_text = NULL;
@@ -0,0 +1,2 @@
+-- Older servers hash the version 1 payload, they report re-sealed records as tampered.
+ALTER TABLE access_records DROP COLUMN IF EXISTS chain_version;
@@ -0,0 +1,4 @@
+-- Access records keep the version of their hash payload. Version 2 covers the tenant of a record;
+-- records of version 1 are re-sealed after 000010_access_record_chain_key.
+
+ALTER TABLE access_records ADD COLUMN IF NOT EXISTS chain_version bigint NOT NULL DEFAULT 1;
@@ -0,0 +1,2 @@
+ALTER TABLE people DROP COLUMN IF EXISTS pin_locked_until;
+ALTER TABLE people DROP COLUMN IF EXISTS pin_failed_attempts;
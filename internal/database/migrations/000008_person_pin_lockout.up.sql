@@ -0,0 +1,4 @@
+-- Wrong PINs entered in a row are counted per person; too many lock the PIN for a while.
+
+ALTER TABLE people ADD COLUMN IF NOT EXISTS pin_failed_attempts bigint NOT NULL DEFAULT 0;
+ALTER TABLE people ADD COLUMN IF NOT EXISTS pin_locked_until timestamptz;
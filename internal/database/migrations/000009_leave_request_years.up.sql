@@ -0,0 +1,23 @@
+-- The days of a leave request by calendar year, so a leave over New Year takes from the balance
+-- of each year. The working days of existing requests cannot be recounted here, they stay
+-- charged to the year they start in as before.
+
+CREATE TABLE IF NOT EXISTS leave_request_years (
+    id uuid DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    tenant_id text,
+    leave_request_id text,
+    year bigint,
+    days decimal,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_leave_request_years_deleted_at ON leave_request_years (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_leave_request_years_tenant_id ON leave_request_years (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_leave_request_years_leave_request_id ON leave_request_years (leave_request_id);
+
+INSERT INTO leave_request_years (created_at, updated_at, tenant_id, leave_request_id, year, days)
+SELECT now(), now(), tenant_id, id::text, CAST(substring(start_date FROM 1 FOR 4) AS bigint), days
+FROM leave_requests
+WHERE NOT EXISTS (SELECT 1 FROM leave_request_years WHERE leave_request_years.leave_request_id = leave_requests.id::text);
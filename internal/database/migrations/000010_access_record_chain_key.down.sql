@@ -0,0 +1,3 @@
+-- Re-sealed access records keep their keyed hashes, they cannot be turned back into the old ones.
+
+COMMENT ON COLUMN access_records.hash IS NULL;
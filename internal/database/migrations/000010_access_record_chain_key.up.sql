@@ -0,0 +1,6 @@
+-- Access record hashes become HMACs keyed with encryption.chain_key (chain version 3), so a row
+-- written to the table directly cannot be sealed without the key. Right after this script
+-- "migrate up" re-seals the chain with the key and seals the records stored before the chain
+-- existed; this is the only time records are sealed outside of appending them.
+
+COMMENT ON COLUMN access_records.hash IS 'HMAC-SHA256 keyed with encryption.chain_key from chain version 3 on';
@@ -0,0 +1,5 @@
+-- The indexes stay keyed with encryption.index_key, older servers cannot look people up by them.
+
+COMMENT ON COLUMN people.person_id_index IS NULL;
+COMMENT ON COLUMN people.email_index IS NULL;
+COMMENT ON COLUMN people.mobile_number_index IS NULL;
@@ -0,0 +1,7 @@
+-- Blind indexes are keyed with encryption.index_key instead of a key derived from the current
+-- encryption key, so rotating the encryption key no longer changes them. Right after this script
+-- "migrate up" recomputes the indexes of every person with the new key.
+
+COMMENT ON COLUMN people.person_id_index IS 'HMAC-SHA256 keyed with encryption.index_key';
+COMMENT ON COLUMN people.email_index IS 'HMAC-SHA256 keyed with encryption.index_key';
+COMMENT ON COLUMN people.mobile_number_index IS 'HMAC-SHA256 keyed with encryption.index_key';
@@ -0,0 +1,2 @@
+-- The defaults stay fixed, there is nothing to revert.
+SELECT 1;
@@ -0,0 +1,8 @@
+-- The baseline created overtime_rules.weekend_days and payroll_export_templates.delimiter with a
+-- default broken over two lines, so rows inserted without them got a newline in their value.
+
+ALTER TABLE overtime_rules ALTER COLUMN weekend_days SET DEFAULT '6,7';
+UPDATE overtime_rules SET weekend_days = '6,7' WHERE weekend_days = E'6,\n    7';
+
+ALTER TABLE payroll_export_templates ALTER COLUMN delimiter SET DEFAULT ',';
+UPDATE payroll_export_templates SET delimiter = ',' WHERE delimiter = E',\n    ';
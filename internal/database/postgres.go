@@ -3,6 +3,10 @@ package database
 import (
 	"fmt"
 	"log"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/putteror/access-control-management/internal/app/model"
 	"github.com/putteror/access-control-management/internal/config"
@@ -11,21 +15,42 @@ import (
 	"gorm.io/gorm"
 )
 
+// NewPostgresDB connects to the database and applies the pool settings of the config.
 func NewPostgresDB(cfg *config.Config) (*gorm.DB, error) {
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Bangkok",
-		cfg.DBHost, cfg.DBUser, cfg.DBPass, cfg.DBName, cfg.DBPort)
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(cfg.Database.User, cfg.Database.Password),
+		Host:     net.JoinHostPort(cfg.Database.Host, strconv.Itoa(cfg.Database.Port)),
+		Path:     cfg.Database.Name,
+		RawQuery: url.Values{"sslmode": {cfg.Database.SSLMode}, "TimeZone": {cfg.TimeZone}}.Encode(),
+	}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(dsn.String()), &gorm.Config{TranslateError: true})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
+	if err := RegisterTenantScope(db); err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection pool: %w", err)
+	}
+	sqlDB.SetMaxOpenConns(cfg.Database.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.Database.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(time.Duration(cfg.Database.ConnMaxLifetimeMinutes) * time.Minute)
 
 	log.Println("Database connection established")
 	return db, nil
 }
 
-func AutoMigrate(db *gorm.DB) {
-	db.AutoMigrate(
+// Models returns the models stored in the database, in the order AutoMigrate creates their tables.
+func Models() []interface{} {
+	return []interface{}{
+		&model.Tenant{},
+		&model.UserPermission{},
+		&model.User{},
 		&model.Person{},
 		&model.AccessControlDevice{},
 		&model.AccessControlServer{},
@@ -34,7 +59,10 @@ func AutoMigrate(db *gorm.DB) {
 		&model.AccessControlGroupDevice{},
 		&model.AccessControlGroupSchedule{},
 		&model.AccessControlRuleGroup{},
+		&model.Location{},
+		&model.AccessControlGroupLocation{},
 		&model.PersonCard{},
+		&model.PersonCardHistory{},
 		&model.PersonLicensePlate{},
 		&model.RegisterForm{},
 		&model.RegisterFormField{},
@@ -43,5 +71,33 @@ func AutoMigrate(db *gorm.DB) {
 		&model.AttendanceSchedule{},
 		&model.AttendanceRecord{},
 		&model.AccessRecord{},
-	)
+		&model.AccessRecordAnnotation{},
+		&model.AccessRecordChainCheckpoint{},
+		&model.VisitorVehicle{},
+		&model.AccessScanSession{},
+		&model.HolidayCalendar{},
+		&model.Holiday{},
+		&model.ShiftTemplate{},
+		&model.ShiftRotation{},
+		&model.ShiftRotationDay{},
+		&model.PersonShiftAssignment{},
+		&model.PersonShiftOverride{},
+		&model.LeaveType{},
+		&model.LeaveRequest{},
+		&model.LeaveRequestYear{},
+		&model.LeaveBalance{},
+		&model.AttendanceCorrection{},
+		&model.OvertimeRule{},
+		&model.PayrollExportTemplate{},
+		&model.PayrollExportColumn{},
+		&model.PayrollExport{},
+		&model.PayrollExportLine{},
+		&model.AuditLog{},
+		&model.RetentionRun{},
+		&model.RetentionRunItem{},
+	}
+}
+
+func AutoMigrate(db *gorm.DB) {
+	db.AutoMigrate(Models()...)
 }
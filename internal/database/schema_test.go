@@ -0,0 +1,195 @@
+package database
+
+import (
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// column is a column of the schema built by the migrations.
+type column struct {
+	dataType     string
+	defaultValue string
+}
+
+var (
+	createTable    = regexp.MustCompile(`(?is)^CREATE TABLE IF NOT EXISTS (\w+) \((.*)\)$`)
+	alterTable     = regexp.MustCompile(`(?is)^ALTER TABLE (\w+) (.*)$`)
+	dropTable      = regexp.MustCompile(`(?i)^DROP TABLE IF EXISTS (\w+)`)
+	addColumn      = regexp.MustCompile(`(?is)^ADD COLUMN IF NOT EXISTS (\w+) (.*)$`)
+	dropColumn     = regexp.MustCompile(`(?i)^DROP COLUMN IF EXISTS (\w+)$`)
+	setDefault     = regexp.MustCompile(`(?is)^ALTER COLUMN (\w+) SET DEFAULT (.*)$`)
+	columnKeywords = regexp.MustCompile(`(?i)^(DEFAULT|NOT|NULL|PRIMARY|UNIQUE|REFERENCES)$`)
+)
+
+// splitTopLevel splits s at sep outside of quotes and parentheses.
+func splitTopLevel(s string, sep rune) []string {
+	var parts []string
+	depth, quoted, start := 0, false, 0
+	for i, r := range s {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case quoted:
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case r == sep && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// parseColumn reads the type and the default of a column definition such as
+// "bigint NOT NULL DEFAULT 1".
+func parseColumn(definition string) column {
+	var c column
+	words := splitTopLevel(strings.TrimSpace(definition), ' ')
+	i := 0
+	for ; i < len(words) && !columnKeywords.MatchString(words[i]); i++ {
+		c.dataType = strings.TrimSpace(c.dataType + " " + words[i])
+	}
+	for ; i < len(words); i++ {
+		if strings.EqualFold(words[i], "DEFAULT") && i+1 < len(words) {
+			c.defaultValue = words[i+1]
+		}
+	}
+	c.dataType = strings.ToLower(c.dataType)
+	return c
+}
+
+// migratedSchema applies the up scripts of every migration to an empty schema, keeping the columns
+// of every table. Statements that do not change columns, such as indexes and data, are skipped.
+func migratedSchema(t *testing.T) map[string]map[string]column {
+	t.Helper()
+	migrations, err := LoadMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tables := make(map[string]map[string]column)
+	for _, migration := range migrations {
+		name := fmt.Sprintf("migrations/%06d_%s.up.sql", migration.Version, migration.Name)
+		script, err := fs.ReadFile(migrationFiles, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var lines []string
+		for _, line := range strings.Split(string(script), "\n") {
+			if !strings.HasPrefix(strings.TrimSpace(line), "--") {
+				lines = append(lines, line)
+			}
+		}
+		for _, statement := range splitTopLevel(strings.Join(lines, "\n"), ';') {
+			statement = strings.Join(strings.Fields(statement), " ")
+			if match := createTable.FindStringSubmatch(statement); match != nil {
+				if _, ok := tables[match[1]]; ok {
+					continue
+				}
+				columns := make(map[string]column)
+				for _, definition := range splitTopLevel(match[2], ',') {
+					definition = strings.TrimSpace(definition)
+					if strings.HasPrefix(definition, "PRIMARY KEY") || strings.HasPrefix(definition, "CONSTRAINT") {
+						continue
+					}
+					name, rest, _ := strings.Cut(definition, " ")
+					columns[name] = parseColumn(rest)
+				}
+				tables[match[1]] = columns
+			} else if match := dropTable.FindStringSubmatch(statement); match != nil {
+				delete(tables, match[1])
+			} else if match := alterTable.FindStringSubmatch(statement); match != nil {
+				columns, ok := tables[match[1]]
+				if !ok {
+					t.Errorf("%s alters table %s before it is created", name, match[1])
+					continue
+				}
+				for _, action := range splitTopLevel(match[2], ',') {
+					action = strings.TrimSpace(action)
+					if match := addColumn.FindStringSubmatch(action); match != nil {
+						if _, ok := columns[match[1]]; !ok {
+							columns[match[1]] = parseColumn(match[2])
+						}
+					} else if match := dropColumn.FindStringSubmatch(action); match != nil {
+						delete(columns, match[1])
+					} else if match := setDefault.FindStringSubmatch(action); match != nil {
+						c := columns[match[1]]
+						c.defaultValue = match[2]
+						columns[match[1]] = c
+					}
+				}
+			}
+		}
+	}
+	return tables
+}
+
+// TestMigrationsMatchModels checks that migrating up builds the tables, columns, types and
+// defaults AutoMigrate creates from the models, so a model change without a migration is caught.
+func TestMigrationsMatchModels(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DisableAutomaticPing: true, DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tables := migratedSchema(t)
+
+	modelTables := make(map[string]bool)
+	cache := &sync.Map{}
+	for _, model := range Models() {
+		s, err := schema.Parse(model, cache, db.NamingStrategy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		modelTables[s.Table] = true
+		columns, ok := tables[s.Table]
+		if !ok {
+			t.Errorf("table %s of %s is not created by a migration", s.Table, s.Name)
+			continue
+		}
+
+		fields := make(map[string]bool)
+		for _, field := range s.Fields {
+			if field.DBName == "" || field.IgnoreMigration {
+				continue
+			}
+			fields[field.DBName] = true
+			c, ok := columns[field.DBName]
+			if !ok {
+				t.Errorf("column %s.%s is not created by a migration", s.Table, field.DBName)
+				continue
+			}
+			if dataType := strings.ToLower(db.Dialector.DataTypeOf(field)); c.dataType != dataType {
+				t.Errorf("column %s.%s is %s, want %s", s.Table, field.DBName, c.dataType, dataType)
+			}
+			// The default of a text field is kept without its quotes
+			if defaultValue := strings.Trim(c.defaultValue, "'"); defaultValue != field.DefaultValue {
+				t.Errorf("column %s.%s has default %q, want %q", s.Table, field.DBName, defaultValue, field.DefaultValue)
+			}
+		}
+		var extra []string
+		for name := range columns {
+			if !fields[name] {
+				extra = append(extra, name)
+			}
+		}
+		sort.Strings(extra)
+		for _, name := range extra {
+			t.Errorf("column %s.%s is not a field of %s", s.Table, name, s.Name)
+		}
+	}
+	for table := range tables {
+		if !modelTables[table] {
+			t.Errorf("table %s is not a model", table)
+		}
+	}
+}
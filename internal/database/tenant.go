@@ -0,0 +1,172 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/putteror/access-control-management/internal/app/common"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	tenantField  = "TenantID"
+	tenantColumn = "tenant_id"
+	// tenantScopedSetting marks a statement as filtered, a statement reused by a chain such as
+	// Count and then Find would otherwise get the filter twice
+	tenantScopedSetting = "tenant:scoped"
+)
+
+// ErrNoTenantScope is returned for queries on tenant data made without a tenant in the context of
+// the session, so a missing scope never leaks the data of another tenant.
+var ErrNoTenantScope = errors.New("no tenant scope for tenant data")
+
+// RegisterTenantScope isolates the tenants of models with a TenantID field. The tenant comes from
+// the context of the session, see common.WithTenant: creates get its ID and every query, update
+// and delete is filtered by it. Sessions with common.WithAllTenants see every tenant and must set
+// TenantID themselves on create.
+//
+// Raw SQL (db.Raw and db.Exec) and queries with a hand-written SQL statement bypass the filter.
+// They may only touch tables without tenants, like the advisory locks of the access record chain,
+// or must filter by tenant_id themselves with the tenant of common.TenantFromContext.
+func RegisterTenantScope(db *gorm.DB) error {
+	callbacks := []struct {
+		name     string
+		register func() error
+	}{
+		{"create", func() error {
+			return db.Callback().Create().Before("gorm:create").Register("tenant:create", setTenant)
+		}},
+		{"query", func() error {
+			return db.Callback().Query().Before("gorm:query").Register("tenant:query", scopeTenant)
+		}},
+		{"update", func() error {
+			return db.Callback().Update().Before("gorm:update").Register("tenant:update", updateTenant)
+		}},
+		{"delete", func() error {
+			return db.Callback().Delete().Before("gorm:delete").Register("tenant:delete", scopeTenant)
+		}},
+		{"row", func() error {
+			return db.Callback().Row().Before("gorm:row").Register("tenant:row", scopeTenant)
+		}},
+	}
+	for _, callback := range callbacks {
+		if err := callback.register(); err != nil {
+			return fmt.Errorf("failed to register tenant %s callback: %w", callback.name, err)
+		}
+	}
+	return nil
+}
+
+// tenantOf returns the TenantID field of the model of a statement, nil when the model does not
+// belong to a tenant.
+func tenantOf(db *gorm.DB) *schema.Field {
+	if db.Statement.Schema == nil {
+		return nil
+	}
+	return db.Statement.Schema.LookUpField(tenantField)
+}
+
+// setTenant sets the tenant of created records and keeps an upsert from taking over a record of
+// another tenant.
+func setTenant(db *gorm.DB) {
+	field := tenantOf(db)
+	if field == nil || db.Error != nil {
+		return
+	}
+	tenantID, allTenants, ok := common.TenantFromContext(db.Statement.Context)
+	if !ok {
+		db.AddError(ErrNoTenantScope)
+		return
+	}
+	if allTenants {
+		// Records created for every tenant at once must name their tenant, only optional
+		// tenants such as audit log entries may be left empty
+		if field.FieldType.Kind() == reflect.Ptr {
+			return
+		}
+		eachRecord(db, func(record reflect.Value) {
+			if _, isZero := field.ValueOf(db.Statement.Context, record); isZero {
+				db.AddError(fmt.Errorf("%s must be created in a tenant", db.Statement.Schema.Name))
+			}
+		})
+		return
+	}
+
+	setTenantField(db, field, tenantID)
+	if c, ok := db.Statement.Clauses["ON CONFLICT"]; ok {
+		if onConflict, ok := c.Expression.(clause.OnConflict); ok && (onConflict.UpdateAll || len(onConflict.DoUpdates) > 0) {
+			onConflict.Where.Exprs = append(onConflict.Where.Exprs, clause.Eq{
+				Column: clause.Column{Table: db.Statement.Table, Name: tenantColumn},
+				Value:  tenantID,
+			})
+			c.Expression = onConflict
+			db.Statement.Clauses["ON CONFLICT"] = c
+		}
+	}
+}
+
+// updateTenant filters an update by the tenant and keeps saved records in it.
+func updateTenant(db *gorm.DB) {
+	field := tenantOf(db)
+	if field == nil || db.Error != nil {
+		return
+	}
+	if tenantID, allTenants, ok := common.TenantFromContext(db.Statement.Context); ok && !allTenants {
+		setTenantField(db, field, tenantID)
+	}
+	scopeTenant(db)
+}
+
+// scopeTenant adds the tenant filter to a query, update or delete.
+func scopeTenant(db *gorm.DB) {
+	field := tenantOf(db)
+	if field == nil || db.Error != nil || db.Statement.SQL.Len() > 0 {
+		return
+	}
+	tenantID, allTenants, ok := common.TenantFromContext(db.Statement.Context)
+	if !ok {
+		db.AddError(ErrNoTenantScope)
+		return
+	}
+	if allTenants {
+		return
+	}
+	if _, scoped := db.Statement.Settings.LoadOrStore(tenantScopedSetting, true); scoped {
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: tenantColumn}, Value: tenantID},
+	}})
+}
+
+// setTenantField sets the tenant of every record of a statement.
+func setTenantField(db *gorm.DB, field *schema.Field, tenantID string) {
+	eachRecord(db, func(record reflect.Value) {
+		value := interface{}(tenantID)
+		if field.FieldType.Kind() == reflect.Ptr {
+			value = &tenantID
+		}
+		if err := field.Set(db.Statement.Context, record, value); err != nil {
+			db.AddError(fmt.Errorf("failed to set tenant: %w", err))
+		}
+	})
+}
+
+// eachRecord calls fn for the struct or every element of the slice a statement writes.
+func eachRecord(db *gorm.DB, fn func(record reflect.Value)) {
+	value := db.Statement.ReflectValue
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			record := reflect.Indirect(value.Index(i))
+			if record.Kind() == reflect.Struct {
+				fn(record)
+			}
+		}
+	case reflect.Struct:
+		fn(value)
+	}
+}
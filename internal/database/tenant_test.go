@@ -0,0 +1,211 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/putteror/access-control-management/internal/app/common"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// tenantItem belongs to a tenant, sharedItem is seen by every tenant.
+type tenantItem struct {
+	ID       uint
+	TenantID string
+	Name     string
+}
+
+type sharedItem struct {
+	ID   uint
+	Name string
+}
+
+// dryRunDB returns a session with the tenant scope that builds the SQL of statements without a
+// database.
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DisableAutomaticPing:   true,
+		DryRun:                 true,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterTenantScope(db); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+const tenantFilter = `"tenant_items"."tenant_id" = $`
+
+func TestTenantScope(t *testing.T) {
+	tenant := common.WithTenant(context.Background(), "tenant-1")
+	allTenants := common.WithAllTenants(context.Background())
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		run  func(db *gorm.DB) *gorm.DB
+		// wantFilters is how often the SQL filters by the tenant
+		wantFilters int
+		wantErr     error
+	}{
+		{
+			name:        "query",
+			ctx:         tenant,
+			run:         func(db *gorm.DB) *gorm.DB { return db.Where("name = ?", "a").Find(&[]tenantItem{}) },
+			wantFilters: 1,
+		},
+		{
+			name: "count and find on the same statement",
+			ctx:  tenant,
+			run: func(db *gorm.DB) *gorm.DB {
+				var count int64
+				query := db.Model(&tenantItem{})
+				query.Count(&count)
+				return query.Find(&[]tenantItem{})
+			},
+			wantFilters: 1,
+		},
+		{
+			name:        "update",
+			ctx:         tenant,
+			run:         func(db *gorm.DB) *gorm.DB { return db.Model(&tenantItem{ID: 1}).Update("name", "b") },
+			wantFilters: 1,
+		},
+		{
+			name:        "delete",
+			ctx:         tenant,
+			run:         func(db *gorm.DB) *gorm.DB { return db.Delete(&tenantItem{ID: 1}) },
+			wantFilters: 1,
+		},
+		{
+			name: "row",
+			ctx:  tenant,
+			run: func(db *gorm.DB) *gorm.DB {
+				query := db.Model(&tenantItem{}).Select("name")
+				query.Row()
+				return query
+			},
+			wantFilters: 1,
+		},
+		{
+			name:        "model without tenant",
+			ctx:         tenant,
+			run:         func(db *gorm.DB) *gorm.DB { return db.Find(&[]sharedItem{}) },
+			wantFilters: 0,
+		},
+		{
+			name: "upsert only updates a conflicting record of the tenant",
+			ctx:  tenant,
+			run: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&tenantItem{ID: 1, Name: "a"})
+			},
+			wantFilters: 1,
+		},
+		{
+			name: "upsert that does nothing on conflict",
+			ctx:  tenant,
+			run: func(db *gorm.DB) *gorm.DB {
+				return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&tenantItem{ID: 1, Name: "a"})
+			},
+			wantFilters: 0,
+		},
+		{
+			name:        "all tenants",
+			ctx:         allTenants,
+			run:         func(db *gorm.DB) *gorm.DB { return db.Find(&[]tenantItem{}) },
+			wantFilters: 0,
+		},
+		{
+			name:    "query without a tenant scope",
+			ctx:     context.Background(),
+			run:     func(db *gorm.DB) *gorm.DB { return db.Find(&[]tenantItem{}) },
+			wantErr: ErrNoTenantScope,
+		},
+		{
+			name:    "update without a tenant scope",
+			ctx:     context.Background(),
+			run:     func(db *gorm.DB) *gorm.DB { return db.Model(&tenantItem{ID: 1}).Update("name", "b") },
+			wantErr: ErrNoTenantScope,
+		},
+		{
+			name:    "delete without a tenant scope",
+			ctx:     context.Background(),
+			run:     func(db *gorm.DB) *gorm.DB { return db.Delete(&tenantItem{ID: 1}) },
+			wantErr: ErrNoTenantScope,
+		},
+		{
+			name:    "create without a tenant scope",
+			ctx:     context.Background(),
+			run:     func(db *gorm.DB) *gorm.DB { return db.Create(&tenantItem{Name: "a"}) },
+			wantErr: ErrNoTenantScope,
+		},
+		{
+			name:        "raw SQL is not filtered",
+			ctx:         context.Background(),
+			run:         func(db *gorm.DB) *gorm.DB { return db.Raw("SELECT * FROM tenant_items").Find(&[]tenantItem{}) },
+			wantFilters: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.run(dryRunDB(t).WithContext(tt.ctx))
+			if !errors.Is(result.Error, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", result.Error, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			sql := result.Statement.SQL.String()
+			if filters := strings.Count(sql, tenantFilter); filters != tt.wantFilters {
+				t.Errorf("SQL %q filters by tenant %d times, want %d", sql, filters, tt.wantFilters)
+			}
+			if tt.wantFilters > 0 && !containsVar(result.Statement.Vars, "tenant-1") {
+				t.Errorf("SQL %q has vars %v, want the tenant", sql, result.Statement.Vars)
+			}
+		})
+	}
+}
+
+func TestTenantScopeCreate(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		item       tenantItem
+		wantTenant string
+		wantErr    bool
+	}{
+		{"sets the tenant", common.WithTenant(context.Background(), "tenant-1"), tenantItem{Name: "a"}, "tenant-1", false},
+		{"replaces another tenant", common.WithTenant(context.Background(), "tenant-1"), tenantItem{TenantID: "tenant-2"}, "tenant-1", false},
+		{"all tenants keep the given tenant", common.WithAllTenants(context.Background()), tenantItem{TenantID: "tenant-2"}, "tenant-2", false},
+		{"all tenants need a tenant", common.WithAllTenants(context.Background()), tenantItem{Name: "a"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := tt.item
+			err := dryRunDB(t).WithContext(tt.ctx).Create(&item).Error
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Create() error = %v, want error %v", err, tt.wantErr)
+			}
+			if item.TenantID != tt.wantTenant {
+				t.Errorf("TenantID = %q, want %q", item.TenantID, tt.wantTenant)
+			}
+		})
+	}
+}
+
+func containsVar(vars []interface{}, want string) bool {
+	for _, v := range vars {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
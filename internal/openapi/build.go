@@ -0,0 +1,242 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/putteror/access-control-management/internal/app/common"
+)
+
+const bearerAuth = "bearerAuth"
+
+// pageResponse is the page of every list response.
+var pageResponse = common.PageResponse{}
+
+// errorCodes are the codes of the error responses.
+var errorCodes = []string{
+	common.ErrorCodeBadRequest,
+	common.ErrorCodeValidation,
+	common.ErrorCodeUnauthorized,
+	common.ErrorCodeForbidden,
+	common.ErrorCodeNotFound,
+	common.ErrorCodeConflict,
+	common.ErrorCodeInternal,
+}
+
+// Build returns the OpenAPI document of Routes. See CheckRoutes for keeping Routes in line with
+// the router.
+func Build() (*Document, error) {
+	builder := newSchemaBuilder()
+	if _, err := builder.schema(reflect.TypeOf(pageResponse)); err != nil {
+		return nil, err
+	}
+	fieldError, err := builder.schema(reflect.TypeOf(common.FieldError{}))
+	if err != nil {
+		return nil, err
+	}
+	builder.schemas["ErrorResponse"] = &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"success": {Type: "boolean"},
+			"message": {Type: "string"},
+			"code":    {Type: "string", Enum: errorCodes},
+			"fields":  {Type: "array", Items: fieldError},
+		},
+		Required: []string{"success", "message", "code"},
+	}
+
+	document := &Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:       "Access Control Management API",
+			Description: "Send the token of POST /login as a bearer token. Timestamps are RFC 3339.",
+			Version:     "1.0.0",
+		},
+		Paths: make(map[string]PathItem),
+		Components: Components{
+			Schemas: builder.schemas,
+			SecuritySchemes: map[string]*SecurityScheme{
+				bearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+		Security: []SecurityRequirement{{bearerAuth: {}}},
+	}
+	tags := make(map[string]bool)
+	for _, route := range Routes {
+		operation, err := builder.operation(route)
+		if err != nil {
+			return nil, fmt.Errorf("failed to document %s %s: %w", route.Method, route.Path, err)
+		}
+		path := openAPIPath(route.Path)
+		if document.Paths[path] == nil {
+			document.Paths[path] = make(PathItem)
+		}
+		document.Paths[path][strings.ToLower(route.Method)] = operation
+		if !tags[route.Tag] {
+			tags[route.Tag] = true
+			document.Tags = append(document.Tags, Tag{Name: route.Tag})
+		}
+	}
+	return document, nil
+}
+
+// CheckRoutes compares the routes registered on a router with Routes. Every registered route must
+// be in Routes with the handler serving it and every route of Routes must be registered, so the
+// document cannot drift from the router.
+func CheckRoutes(registered gin.RoutesInfo) error {
+	documented := make(map[string]Route, len(Routes))
+	operationIDs := make(map[string]bool, len(Routes))
+	for _, route := range Routes {
+		key := route.Method + " " + route.Path
+		if _, ok := documented[key]; ok {
+			return fmt.Errorf("route %s is documented twice", key)
+		}
+		if operationIDs[route.ID] {
+			return fmt.Errorf("operation ID %s of route %s is not unique", route.ID, key)
+		}
+		documented[key] = route
+		operationIDs[route.ID] = true
+	}
+
+	for _, info := range registered {
+		key := info.Method + " " + info.Path
+		route, ok := documented[key]
+		if !ok {
+			return fmt.Errorf("route %s is not documented, add it to openapi.Routes", key)
+		}
+		if !route.servedBy(info.Handler) {
+			return fmt.Errorf("route %s is served by %s but documented as %s", key, info.Handler, route.Handler)
+		}
+		delete(documented, key)
+	}
+	for _, route := range Routes {
+		if _, ok := documented[route.Method+" "+route.Path]; ok {
+			return fmt.Errorf("documented route %s %s is not registered", route.Method, route.Path)
+		}
+	}
+	return nil
+}
+
+// operation documents a route.
+func (b *schemaBuilder) operation(route Route) (*Operation, error) {
+	operation := &Operation{
+		OperationID: lowerFirst(route.ID),
+		Summary:     route.Summary,
+		Description: route.Description,
+		Tags:        []string{route.Tag},
+	}
+	for _, name := range pathParams(route.Path) {
+		operation.Parameters = append(operation.Parameters, Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+	if route.Query != nil {
+		parameters, err := b.parameters(reflect.TypeOf(route.Query))
+		if err != nil {
+			return nil, err
+		}
+		operation.Parameters = append(operation.Parameters, parameters...)
+	}
+
+	switch {
+	case route.Body != nil:
+		body, err := b.schema(reflect.TypeOf(route.Body))
+		if err != nil {
+			return nil, err
+		}
+		operation.RequestBody = &RequestBody{
+			Required: !route.OptionalBody,
+			Content:  map[string]MediaType{"application/json": {Schema: body}},
+		}
+	case route.Form != nil || len(route.Files) > 0:
+		form, err := b.multipart(route)
+		if err != nil {
+			return nil, err
+		}
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"multipart/form-data": {Schema: form}},
+		}
+	}
+	if route.Public {
+		operation.Security = &[]SecurityRequirement{}
+	}
+
+	response, err := b.response(route)
+	if err != nil {
+		return nil, err
+	}
+	operation.Responses = map[string]*Response{
+		"200": response,
+		"default": {
+			Description: "Error",
+			Content:     map[string]MediaType{"application/json": {Schema: &Schema{Ref: "#/components/schemas/ErrorResponse"}}},
+		},
+	}
+	return operation, nil
+}
+
+// response documents the successful response of a route.
+func (b *schemaBuilder) response(route Route) (*Response, error) {
+	if route.Kind == ResponseFile {
+		contentType := route.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		return &Response{
+			Description: "File",
+			Content:     map[string]MediaType{contentType: {Schema: &Schema{Type: "string", Format: "binary"}}},
+		}, nil
+	}
+
+	var data *Schema
+	if route.Response != nil {
+		var err error
+		if data, err = b.schema(reflect.TypeOf(route.Response)); err != nil {
+			return nil, err
+		}
+	}
+	var body *Schema
+	switch route.Kind {
+	case ResponseJSON:
+		body = data
+		if body == nil {
+			body = &Schema{Type: "object"}
+		}
+	case ResponseList:
+		body = envelope(&Schema{Type: "array", Items: data}, true)
+	default:
+		body = envelope(data, false)
+	}
+	return &Response{
+		Description: "Success",
+		Content:     map[string]MediaType{"application/json": {Schema: body}},
+	}, nil
+}
+
+// envelope is the schema of common.APIResponse holding data.
+func envelope(data *Schema, paged bool) *Schema {
+	schema := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"success": {Type: "boolean"},
+			"message": {Type: "string"},
+		},
+		Required: []string{"success", "message"},
+	}
+	if data != nil {
+		schema.Properties["data"] = data
+	}
+	if paged {
+		schema.Properties["page"] = &Schema{Ref: "#/components/schemas/PageResponse"}
+	}
+	return schema
+}
+
+// lowerFirst lower cases the first letter of a name, e.g. GetPerson to getPerson.
+func lowerFirst(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
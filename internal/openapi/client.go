@@ -0,0 +1,274 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GenerateClient returns the Go source of the types and methods of the client of Routes. The
+// hand written part of the client, such as the request helpers the methods call, is in
+// pkg/client/client.go.
+func GenerateClient(packageName string) ([]byte, error) {
+	generator := &clientGenerator{types: make(map[string]clientType)}
+	var methods bytes.Buffer
+	for _, route := range Routes {
+		if err := generator.method(&methods, route); err != nil {
+			return nil, fmt.Errorf("failed to generate the client of %s %s: %w", route.Method, route.Path, err)
+		}
+	}
+	var types bytes.Buffer
+	if err := generator.declarations(&types); err != nil {
+		return nil, err
+	}
+
+	var source bytes.Buffer
+	fmt.Fprintf(&source, "// Code generated by cmd/openapi. DO NOT EDIT.\n\npackage %s\n\nimport (\n\t\"context\"\n", packageName)
+	if generator.usesJSON {
+		source.WriteString("\t\"encoding/json\"\n")
+	}
+	source.WriteString("\t\"net/http\"\n\t\"net/url\"\n")
+	if generator.usesTime {
+		source.WriteString("\t\"time\"\n")
+	}
+	source.WriteString(")\n\n")
+	source.Write(types.Bytes())
+	source.Write(methods.Bytes())
+
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format the client: %w", err)
+	}
+	return formatted, nil
+}
+
+// clientType is a struct of the client. Form structs are sent as query parameters or multipart
+// fields instead of JSON.
+type clientType struct {
+	t    reflect.Type
+	form bool
+}
+
+type clientGenerator struct {
+	types    map[string]clientType
+	usesJSON bool
+	usesTime bool
+}
+
+// goType returns the client type of a Go type and adds the structs it uses to the client.
+func (g *clientGenerator) goType(t reflect.Type, form bool) (string, error) {
+	switch t.Kind() {
+	case reflect.Ptr:
+		elem, err := g.goType(t.Elem(), form)
+		return "*" + elem, err
+	case reflect.Slice:
+		elem, err := g.goType(t.Elem(), form)
+		return "[]" + elem, err
+	case reflect.Map:
+		elem, err := g.goType(t.Elem(), form)
+		return "map[string]" + elem, err
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int32, reflect.Int64, reflect.Float64:
+		return t.Kind().String(), nil
+	case reflect.Struct:
+		if t == timeType {
+			g.usesTime = true
+			return "time.Time", nil
+		}
+		name := t.Name()
+		if known, ok := g.types[name]; ok {
+			if known.t != t {
+				return "", fmt.Errorf("types %s and %s are both named %s", known.t, t, name)
+			}
+			if known.form != form {
+				return "", fmt.Errorf("type %s is sent both as JSON and as a form", name)
+			}
+			return name, nil
+		}
+		g.types[name] = clientType{t: t, form: form}
+		return name, nil
+	}
+	return "", fmt.Errorf("type %s cannot be used by the client", t)
+}
+
+// declarations writes the structs of the client in order of name. Declaring a struct may add the
+// structs of its fields, so it goes on until every struct is declared.
+func (g *clientGenerator) declarations(buffer *bytes.Buffer) error {
+	declared := make(map[string]bool)
+	for {
+		var names []string
+		for name := range g.types {
+			if !declared[name] {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			return nil
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if err := g.declaration(buffer, name, g.types[name]); err != nil {
+				return err
+			}
+			declared[name] = true
+		}
+	}
+}
+
+// declaration writes one struct, and the values method of a form struct.
+func (g *clientGenerator) declaration(buffer *bytes.Buffer, name string, declared clientType) error {
+	tag := "json"
+	if declared.form {
+		tag = "form"
+	}
+	var fields, values bytes.Buffer
+	err := eachField(declared.t, tag, func(field reflect.StructField, fieldName string) error {
+		fieldType, err := g.goType(field.Type, declared.form)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", name, field.Name, err)
+		}
+		fmt.Fprintf(&fields, "\t%s %s `%s:%s`\n", field.Name, fieldType, tag, strconv.Quote(field.Tag.Get(tag)))
+		fmt.Fprintf(&values, "\taddValue(values, %q, q.%s)\n", fieldName, field.Name)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(buffer, "type %s struct {\n%s}\n\n", name, fields.String())
+	if declared.form {
+		fmt.Fprintf(buffer, "func (q *%s) values() url.Values {\n\tvalues := url.Values{}\n\tif q == nil {\n\t\treturn values\n\t}\n%s\treturn values\n}\n\n", name, values.String())
+	}
+	return nil
+}
+
+// method writes the client method of a route.
+func (g *clientGenerator) method(buffer *bytes.Buffer, route Route) error {
+	params := []string{"ctx context.Context"}
+	for _, name := range pathParams(route.Path) {
+		params = append(params, goParamName(name)+" string")
+	}
+	method := "http.Method" + route.Method[:1] + strings.ToLower(route.Method[1:])
+	request := fmt.Sprintf("&request{method: %s, path: %s", method, pathExpression(route.Path))
+	if route.Query != nil {
+		queryType, err := g.goType(reflect.TypeOf(route.Query), true)
+		if err != nil {
+			return err
+		}
+		params = append(params, "query *"+queryType)
+		request += ", query: query.values()"
+	}
+	if route.Form != nil {
+		formType, err := g.goType(reflect.TypeOf(route.Form), true)
+		if err != nil {
+			return err
+		}
+		params = append(params, "form *"+formType)
+		request += ", form: form.values()"
+	}
+	if len(route.Files) > 0 {
+		var files []string
+		for _, file := range route.Files {
+			params = append(params, goParamName(file.Name)+" *File")
+			files = append(files, fmt.Sprintf("%q: %s", file.Name, goParamName(file.Name)))
+		}
+		request += ", files: map[string]*File{" + strings.Join(files, ", ") + "}"
+	}
+	request += "}"
+	setBody := ""
+	if route.Body != nil {
+		bodyType, err := g.goType(reflect.TypeOf(route.Body), false)
+		if err != nil {
+			return err
+		}
+		params = append(params, "body *"+bodyType)
+		setBody = "\tif body != nil {\n\t\treq.body = body\n\t}\n"
+	}
+
+	results, call, err := g.methodResults(route)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(buffer, "// %s calls %s %s to %s.\n", route.ID, route.Method, route.Path, lowerFirst(route.Summary))
+	if route.Description != "" {
+		fmt.Fprintf(buffer, "// %s\n", route.Description)
+	}
+	fmt.Fprintf(buffer, "func (c *Client) %s(%s) %s {\n\treq := %s\n%s%s}\n\n", route.ID, strings.Join(params, ", "), results, request, setBody, call)
+	return nil
+}
+
+// methodResults returns the results of the client method of a route and the code returning them.
+func (g *clientGenerator) methodResults(route Route) (string, string, error) {
+	if route.Kind == ResponseFile {
+		return "(*File, error)", "\treturn c.download(ctx, req)\n", nil
+	}
+	if route.Response == nil {
+		if route.Kind == ResponseJSON {
+			g.usesJSON = true
+			return "(json.RawMessage, error)", "\tvar data json.RawMessage\n\terr := c.doJSON(ctx, req, &data)\n\treturn data, err\n", nil
+		}
+		return "error", "\t_, err := c.do(ctx, req, nil)\n\treturn err\n", nil
+	}
+
+	t := reflect.TypeOf(route.Response)
+	dataType, err := g.goType(t, false)
+	if err != nil {
+		return "", "", err
+	}
+	switch {
+	case route.Kind == ResponseList:
+		if _, err := g.goType(reflect.TypeOf(pageResponse), false); err != nil {
+			return "", "", err
+		}
+		return fmt.Sprintf("([]%s, *PageResponse, error)", dataType),
+			fmt.Sprintf("\tvar data []%s\n\tpage, err := c.do(ctx, req, &data)\n\treturn data, page, err\n", dataType), nil
+	case route.Kind == ResponseJSON:
+		return fmt.Sprintf("(*%s, error)", dataType),
+			fmt.Sprintf("\tdata := new(%s)\n\tif err := c.doJSON(ctx, req, data); err != nil {\n\t\treturn nil, err\n\t}\n\treturn data, nil\n", dataType), nil
+	case t.Kind() == reflect.Slice:
+		return fmt.Sprintf("(%s, error)", dataType),
+			fmt.Sprintf("\tvar data %s\n\t_, err := c.do(ctx, req, &data)\n\treturn data, err\n", dataType), nil
+	}
+	return fmt.Sprintf("(*%s, error)", dataType),
+		fmt.Sprintf("\tdata := new(%s)\n\tif _, err := c.do(ctx, req, data); err != nil {\n\t\treturn nil, err\n\t}\n\treturn data, nil\n", dataType), nil
+}
+
+// pathExpression returns the Go expression of the path of a route with its escaped parameters,
+// e.g. "/api/people/" + url.PathEscape(id).
+func pathExpression(path string) string {
+	var parts []string
+	literal := ""
+	for i, segment := range strings.Split(path, "/") {
+		if i > 0 {
+			literal += "/"
+		}
+		name, ok := pathParam(segment)
+		if !ok {
+			literal += segment
+			continue
+		}
+		parts = append(parts, strconv.Quote(literal))
+		literal = ""
+		if strings.HasPrefix(segment, "*") {
+			// A catch-all parameter holds a path of several segments
+			parts = append(parts, "escapePath("+goParamName(name)+")")
+		} else {
+			parts = append(parts, "url.PathEscape("+goParamName(name)+")")
+		}
+	}
+	if literal != "" {
+		parts = append(parts, strconv.Quote(literal))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// goParamName returns the Go name of a parameter, e.g. cardId to cardID.
+func goParamName(name string) string {
+	if strings.HasSuffix(name, "Id") {
+		return strings.TrimSuffix(name, "Id") + "ID"
+	}
+	return name
+}
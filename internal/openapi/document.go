@@ -0,0 +1,157 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Version is the OpenAPI version of the document.
+const Version = "3.0.3"
+
+// Document is an OpenAPI 3 document. Only the parts used by the API are modelled.
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Tags       []Tag                 `json:"tags,omitempty"`
+	Paths      map[string]PathItem   `json:"paths"`
+	Components Components            `json:"components"`
+	Security   []SecurityRequirement `json:"security"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Tag struct {
+	Name string `json:"name"`
+}
+
+// PathItem holds the operations of a path by lower case HTTP method.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+	// Security is empty for public operations, nil uses the security of the document
+	Security *[]SecurityRequirement `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema is a JSON schema. Ref points to a schema of the components.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// SecurityRequirement names the security schemes an operation needs.
+type SecurityRequirement map[string][]string
+
+// Spec serves the document of Routes. The document is built on its first request.
+type Spec struct {
+	once     sync.Once
+	document []byte
+	err      error
+}
+
+// load builds and encodes the document.
+func (s *Spec) load() {
+	document, err := Build()
+	if err != nil {
+		s.err = err
+		return
+	}
+	if s.document, err = json.MarshalIndent(document, "", "  "); err != nil {
+		s.err = fmt.Errorf("failed to encode OpenAPI document: %w", err)
+	}
+}
+
+// Serve writes the OpenAPI document.
+func (s *Spec) Serve(c *gin.Context) {
+	s.once.Do(s.load)
+	if s.err != nil {
+		c.Error(s.err)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", s.document)
+}
+
+// openAPIPath converts a gin path to an OpenAPI path, e.g. /api/people/:id to /api/people/{id}.
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if name, ok := pathParam(segment); ok {
+			segments[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// pathParams returns the names of the parameters of a gin path in order.
+func pathParams(path string) []string {
+	var names []string
+	for _, segment := range strings.Split(path, "/") {
+		if name, ok := pathParam(segment); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// pathParam returns the name of a :name or *name path segment.
+func pathParam(segment string) (string, bool) {
+	if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+		return segment[1:], true
+	}
+	return "", false
+}
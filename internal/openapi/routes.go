@@ -0,0 +1,670 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/putteror/access-control-management/internal/app/schema"
+)
+
+// ResponseKind is the shape of the body of a successful response.
+type ResponseKind int
+
+const (
+	// ResponseData wraps the response in common.APIResponse, with the data when there is any
+	ResponseData ResponseKind = iota
+	// ResponseList wraps a list of the response and its page in common.APIResponse
+	ResponseList
+	// ResponseJSON is the response itself without a wrapper
+	ResponseJSON
+	// ResponseFile is a file download
+	ResponseFile
+)
+
+// FormFile is a file field of a multipart body.
+type FormFile struct {
+	Name     string
+	Required bool
+}
+
+// Route documents one route of router.NewRouter. Query, Body, Form and Response hold a zero value
+// of the schema type the handler binds or returns, nil when there is none.
+type Route struct {
+	Method string
+	// Path is the gin path, e.g. /api/people/:id
+	Path string
+	// Handler is the type and method serving the route, e.g. PersonHandler.GetByID
+	Handler string
+	// ID is the operation ID and the method name of the Go client
+	ID          string
+	Summary     string
+	Description string
+	Tag         string
+	Query       interface{}
+	Body        interface{}
+	// OptionalBody allows an empty JSON body
+	OptionalBody bool
+	// Form and Files are the fields of a multipart body
+	Form     interface{}
+	Files    []FormFile
+	Response interface{}
+	Kind     ResponseKind
+	// ContentType is the type of a file download
+	ContentType string
+	// Public routes do not need a token
+	Public bool
+}
+
+// servedBy reports whether a gin handler name, e.g.
+// github.com/.../handler.(*PersonHandler).GetByID-fm, is the handler of the route.
+func (r Route) servedBy(handlerName string) bool {
+	typeName, method, ok := strings.Cut(r.Handler, ".")
+	return ok && strings.HasSuffix(handlerName, fmt.Sprintf(".(*%s).%s-fm", typeName, method))
+}
+
+// resource describes the usual routes of a resource, see list, get, create, update, patch and
+// delete.
+type resource struct {
+	tag     string
+	path    string
+	handler string
+	// name and plural are the Go names of an item and of the items, e.g. Location and Locations
+	name     string
+	plural   string
+	query    interface{}
+	request  interface{}
+	response interface{}
+}
+
+func (r resource) list() Route {
+	return Route{
+		Method: http.MethodGet, Path: r.path + "/", Handler: r.handler + ".GetAll", ID: "List" + r.plural,
+		Summary: "List " + words(r.plural), Tag: r.tag, Query: r.query, Response: r.response, Kind: ResponseList,
+	}
+}
+
+func (r resource) get() Route {
+	return Route{
+		Method: http.MethodGet, Path: r.path + "/:id", Handler: r.handler + ".GetByID", ID: "Get" + r.name,
+		Summary: "Get " + article(words(r.name)), Tag: r.tag, Response: r.response,
+	}
+}
+
+func (r resource) create() Route {
+	return Route{
+		Method: http.MethodPost, Path: r.path + "/", Handler: r.handler + ".Create", ID: "Create" + r.name,
+		Summary: "Create " + article(words(r.name)), Tag: r.tag, Body: r.request, Response: r.response,
+	}
+}
+
+func (r resource) update() Route {
+	return Route{
+		Method: http.MethodPut, Path: r.path + "/:id", Handler: r.handler + ".Update", ID: "Update" + r.name,
+		Summary: "Replace " + article(words(r.name)), Tag: r.tag, Body: r.request, Response: r.response,
+	}
+}
+
+func (r resource) patch() Route {
+	return Route{
+		Method: http.MethodPatch, Path: r.path + "/:id", Handler: r.handler + ".PartialUpdate", ID: "Patch" + r.name,
+		Summary: "Update the given fields of " + article(words(r.name)), Tag: r.tag, Body: r.request, Response: r.response,
+	}
+}
+
+func (r resource) delete() Route {
+	return Route{
+		Method: http.MethodDelete, Path: r.path + "/:id", Handler: r.handler + ".Delete", ID: "Delete" + r.name,
+		Summary: "Delete " + article(words(r.name)), Tag: r.tag,
+	}
+}
+
+// words splits a Go name into lower case words, e.g. AccessControlDevices to
+// "access control devices".
+func words(name string) string {
+	var builder strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) && i > 0 {
+			builder.WriteByte(' ')
+		}
+		builder.WriteRune(unicode.ToLower(r))
+	}
+	return builder.String()
+}
+
+// article puts "a" or "an" before a noun.
+func article(noun string) string {
+	if strings.ContainsRune("aeiou", rune(noun[0])) {
+		return "an " + noun
+	}
+	return "a " + noun
+}
+
+var (
+	accessControlDevices = resource{
+		tag: "Access control devices", path: "/api/access-control-devices", handler: "AccessControlDeviceHandler",
+		name: "AccessControlDevice", plural: "AccessControlDevices",
+		query: schema.AccessControlDeviceSearchQuery{}, request: schema.AccessControlDeviceRequest{}, response: schema.AccessControlDeviceResponse{},
+	}
+	accessControlGroups = resource{
+		tag: "Access control groups", path: "/api/access-control-groups", handler: "AccessControlGroupHandler",
+		name: "AccessControlGroup", plural: "AccessControlGroups",
+		query: schema.AccessControlGroupSearchQuery{}, request: schema.AccessControlGroupRequest{}, response: schema.AccessControlGroupResponse{},
+	}
+	accessControlRules = resource{
+		tag: "Access control rules", path: "/api/access-control-rules", handler: "AccessControlRuleHandler",
+		name: "AccessControlRule", plural: "AccessControlRules",
+		query: schema.AccessControlRuleSearchQuery{}, request: schema.AccessControlRuleRequest{}, response: schema.AccessControlRuleResponse{},
+	}
+	accessControlServers = resource{
+		tag: "Access control servers", path: "/api/access-control-servers", handler: "AccessControlServerHandler",
+		name: "AccessControlServer", plural: "AccessControlServers",
+		query: schema.AccessControlServerSearchQuery{}, request: schema.AccessControlServerRequest{}, response: schema.AccessControlServerResponse{},
+	}
+	accessRecords = resource{
+		tag: "Access records", path: "/api/access-records", handler: "AccessRecordHandler",
+		name: "AccessRecord", plural: "AccessRecords",
+		query: schema.AccessRecordSearchQuery{}, request: schema.AccessRecordRequest{}, response: schema.AccessRecordResponse{},
+	}
+	attendances = resource{
+		tag: "Attendances", path: "/api/attendances", handler: "AttendanceHandler",
+		name: "Attendance", plural: "Attendances",
+		query: schema.AttendanceSearchQuery{}, request: schema.AttendanceRequest{}, response: schema.AttendanceInfoResponse{},
+	}
+	attendanceCorrections = resource{
+		tag: "Attendance corrections", path: "/api/attendance-corrections", handler: "AttendanceCorrectionHandler",
+		name: "AttendanceCorrection", plural: "AttendanceCorrections",
+		query: schema.AttendanceCorrectionSearchQuery{}, request: schema.AttendanceCorrectionRequest{}, response: schema.AttendanceCorrectionResponse{},
+	}
+	attendanceRecords = resource{
+		tag: "Attendance records", path: "/api/attendance-records", handler: "AttendanceRecordHandler",
+		name: "AttendanceRecord", plural: "AttendanceRecords",
+		query: schema.AttendanceRecordSearchQuery{}, response: schema.AttendanceRecordResponse{},
+	}
+	holidayCalendars = resource{
+		tag: "Holiday calendars", path: "/api/holiday-calendars", handler: "HolidayCalendarHandler",
+		name: "HolidayCalendar", plural: "HolidayCalendars",
+		query: schema.HolidayCalendarSearchQuery{}, request: schema.HolidayCalendarRequest{}, response: schema.HolidayCalendarResponse{},
+	}
+	leaveRequests = resource{
+		tag: "Leave requests", path: "/api/leave-requests", handler: "LeaveRequestHandler",
+		name: "LeaveRequest", plural: "LeaveRequests",
+		query: schema.LeaveRequestSearchQuery{}, request: schema.LeaveRequestRequest{}, response: schema.LeaveRequestResponse{},
+	}
+	leaveTypes = resource{
+		tag: "Leave types", path: "/api/leave-types", handler: "LeaveTypeHandler",
+		name: "LeaveType", plural: "LeaveTypes",
+		query: schema.LeaveTypeSearchQuery{}, request: schema.LeaveTypeRequest{}, response: schema.LeaveTypeResponse{},
+	}
+	locations = resource{
+		tag: "Locations", path: "/api/locations", handler: "LocationHandler",
+		name: "Location", plural: "Locations",
+		query: schema.LocationSearchQuery{}, request: schema.LocationRequest{}, response: schema.LocationResponse{},
+	}
+	overtimeRules = resource{
+		tag: "Overtime rules", path: "/api/overtime-rules", handler: "OvertimeRuleHandler",
+		name: "OvertimeRule", plural: "OvertimeRules",
+		query: schema.OvertimeRuleSearchQuery{}, request: schema.OvertimeRuleRequest{}, response: schema.OvertimeRuleResponse{},
+	}
+	payrollExportTemplates = resource{
+		tag: "Payroll export templates", path: "/api/payroll-export-templates", handler: "PayrollExportTemplateHandler",
+		name: "PayrollExportTemplate", plural: "PayrollExportTemplates",
+		query: schema.PayrollExportTemplateSearchQuery{}, request: schema.PayrollExportTemplateRequest{}, response: schema.PayrollExportTemplateResponse{},
+	}
+	payrollExports = resource{
+		tag: "Payroll exports", path: "/api/payroll-exports", handler: "PayrollExportHandler",
+		name: "PayrollExport", plural: "PayrollExports",
+		query: schema.PayrollExportSearchQuery{}, request: schema.PayrollExportRequest{}, response: schema.PayrollExportResponse{},
+	}
+	people = resource{
+		tag: "People", path: "/api/people", handler: "PersonHandler",
+		name: "Person", plural: "People",
+		query: schema.PersonSearchQuery{}, response: schema.PersonResponse{},
+	}
+	retentionRuns = resource{
+		tag: "Retention", path: "/api/retention", handler: "RetentionHandler",
+		name: "RetentionRun", plural: "RetentionRuns",
+		query: schema.RetentionRunSearchQuery{}, response: schema.RetentionRunResponse{},
+	}
+	shiftRotations = resource{
+		tag: "Shift rotations", path: "/api/shift-rotations", handler: "ShiftRotationHandler",
+		name: "ShiftRotation", plural: "ShiftRotations",
+		query: schema.ShiftRotationSearchQuery{}, request: schema.ShiftRotationRequest{}, response: schema.ShiftRotationResponse{},
+	}
+	shiftTemplates = resource{
+		tag: "Shift templates", path: "/api/shift-templates", handler: "ShiftTemplateHandler",
+		name: "ShiftTemplate", plural: "ShiftTemplates",
+		query: schema.ShiftTemplateSearchQuery{}, request: schema.ShiftTemplateRequest{}, response: schema.ShiftTemplateResponse{},
+	}
+	tenants = resource{
+		tag: "Tenants", path: "/api/tenants", handler: "TenantHandler",
+		name: "Tenant", plural: "Tenants",
+		query: schema.TenantSearchQuery{}, request: schema.TenantRequest{}, response: schema.TenantResponse{},
+	}
+	users = resource{
+		tag: "Users", path: "/api/users", handler: "UserHandler",
+		name: "User", plural: "Users",
+		query: schema.UserSearchQuery{}, request: schema.UserRequest{}, response: schema.UserResponse{},
+	}
+	visitorVehicles = resource{
+		tag: "Visitor vehicles", path: "/api/visitor-vehicles", handler: "VisitorVehicleHandler",
+		name: "VisitorVehicle", plural: "VisitorVehicles",
+		query: schema.VisitorVehicleSearchQuery{}, response: schema.VisitorVehicleResponse{},
+	}
+)
+
+const (
+	globalScopeDescription = "Only super admins in the global scope, without a tenant, may use this route."
+	superAdminDescription  = "Only super admins may use this route."
+)
+
+// Routes documents every route of router.NewRouter in the order of the router. The tests of this
+// package check the table against the registered routes and the handlers, so a route added to the
+// router without being documented here fails them.
+var Routes = withTenantRoutes([]Route{
+	{
+		Method: http.MethodGet, Path: "/openapi.json", Handler: "Spec.Serve", ID: "GetOpenAPIDocument",
+		Summary: "Get this OpenAPI document", Tag: "Documentation", Kind: ResponseJSON, Public: true,
+	},
+	{
+		Method: http.MethodPost, Path: "/login", Handler: "AuthHandler.Login", ID: "Login",
+		Summary: "Log in and get an access token", Tag: "Authentication",
+		Body: schema.LoginRequest{}, Response: schema.TokenResponse{}, Kind: ResponseJSON, Public: true,
+	},
+
+	accessControlDevices.list(),
+	accessControlDevices.get(),
+	accessControlDevices.create(),
+	accessControlDevices.update(),
+	accessControlDevices.patch(),
+	accessControlDevices.delete(),
+
+	accessControlGroups.list(),
+	accessControlGroups.get(),
+	accessControlGroups.create(),
+	accessControlGroups.update(),
+	accessControlGroups.patch(),
+	accessControlGroups.delete(),
+
+	accessControlRules.list(),
+	accessControlRules.get(),
+	accessControlRules.create(),
+	accessControlRules.update(),
+	accessControlRules.patch(),
+	accessControlRules.delete(),
+
+	accessControlServers.list(),
+	accessControlServers.get(),
+	accessControlServers.create(),
+	accessControlServers.update(),
+	accessControlServers.patch(),
+	accessControlServers.delete(),
+
+	{
+		Method: http.MethodPost, Path: "/api/access-decisions", Handler: "AccessDecisionHandler.Decide", ID: "DecideAccess",
+		Summary: "Decide whether the credentials presented at a device grant access", Tag: "Access decisions",
+		Body: schema.AccessDecisionRequest{}, Response: schema.AccessDecisionResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/lpr-events", Handler: "AccessDecisionHandler.DecideLicensePlate", ID: "DecideLicensePlate",
+		Summary: "Decide whether a license plate read at a device grants access", Tag: "Access decisions",
+		Form: schema.LicensePlateEventRequest{}, Files: []FormFile{{Name: "image"}}, Response: schema.AccessDecisionResponse{},
+	},
+
+	accessRecords.list(),
+	{
+		Method: http.MethodGet, Path: accessRecords.path + "/verify", Handler: "AccessRecordHandler.Verify", ID: "VerifyAccessRecords",
+		Summary: "Verify the hash chain of the access records", Tag: accessRecords.tag, Description: "In a tenant only the records " +
+			"of the tenant are verified, each against its own hash and the hash of the record before it in the chain.",
+		Response: schema.AccessRecordChainResponse{},
+	},
+	accessRecords.get(),
+	accessRecords.create(),
+	{
+		Method: http.MethodGet, Path: accessRecords.path + "/:id/annotations", Handler: "AccessRecordHandler.GetAnnotations", ID: "ListAccessRecordAnnotations",
+		Summary: "List the annotations of an access record", Tag: accessRecords.tag,
+		Response: []schema.AccessRecordAnnotationResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: accessRecords.path + "/:id/annotations", Handler: "AccessRecordHandler.Annotate", ID: "AnnotateAccessRecord",
+		Summary: "Correct or explain an access record", Tag: accessRecords.tag,
+		Body: schema.AccessRecordAnnotationRequest{}, Response: schema.AccessRecordAnnotationResponse{},
+	},
+
+	attendances.list(),
+	attendances.get(),
+	attendances.create(),
+	attendances.update(),
+	attendances.patch(),
+	attendances.delete(),
+
+	attendanceCorrections.list(),
+	attendanceCorrections.get(),
+	attendanceCorrections.create(),
+	{
+		Method: http.MethodPost, Path: attendanceCorrections.path + "/:id/approve", Handler: "AttendanceCorrectionHandler.Approve", ID: "ApproveAttendanceCorrection",
+		Summary: "Approve an attendance correction", Tag: attendanceCorrections.tag,
+		Body: schema.DecisionRequest{}, OptionalBody: true, Response: schema.AttendanceCorrectionResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: attendanceCorrections.path + "/:id/reject", Handler: "AttendanceCorrectionHandler.Reject", ID: "RejectAttendanceCorrection",
+		Summary: "Reject an attendance correction", Tag: attendanceCorrections.tag,
+		Body: schema.DecisionRequest{}, OptionalBody: true, Response: schema.AttendanceCorrectionResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: attendanceCorrections.path + "/:id/cancel", Handler: "AttendanceCorrectionHandler.Cancel", ID: "CancelAttendanceCorrection",
+		Summary: "Cancel an attendance correction", Tag: attendanceCorrections.tag,
+		Body: schema.DecisionRequest{}, OptionalBody: true, Response: schema.AttendanceCorrectionResponse{},
+	},
+
+	attendanceRecords.list(),
+	{
+		Method: http.MethodGet, Path: attendanceRecords.path + "/summary", Handler: "AttendanceRecordHandler.Summary", ID: "SummarizeAttendanceRecords",
+		Summary: "Total the attendance of a date range per person", Tag: attendanceRecords.tag,
+		Query: schema.AttendanceSummaryQuery{}, Response: []schema.AttendanceSummaryResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: attendanceRecords.path + "/calculate", Handler: "AttendanceRecordHandler.Calculate", ID: "CalculateAttendanceRecords",
+		Summary: "Calculate the attendance of a date range", Tag: attendanceRecords.tag,
+		Body: schema.AttendanceCalculateRequest{}, Response: []schema.AttendanceRecordResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: attendanceRecords.path + "/:id/overtime/approve", Handler: "AttendanceRecordHandler.ApproveOvertime", ID: "ApproveOvertime",
+		Summary: "Approve the overtime of an attendance record", Tag: attendanceRecords.tag,
+		Body: schema.OvertimeDecisionRequest{}, OptionalBody: true, Response: schema.AttendanceRecordResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: attendanceRecords.path + "/:id/overtime/reject", Handler: "AttendanceRecordHandler.RejectOvertime", ID: "RejectOvertime",
+		Summary: "Reject the overtime of an attendance record", Tag: attendanceRecords.tag,
+		Body: schema.OvertimeDecisionRequest{}, OptionalBody: true, Response: schema.AttendanceRecordResponse{},
+	},
+
+	{
+		Method: http.MethodGet, Path: "/api/audit-logs", Handler: "AuditLogHandler.GetAll", ID: "ListAuditLogs",
+		Summary: "List audit logs", Tag: "Audit logs",
+		Query: schema.AuditLogSearchQuery{}, Response: schema.AuditLogResponse{}, Kind: ResponseList,
+	},
+
+	{
+		Method: http.MethodGet, Path: "/api/files/*filepath", Handler: "FileHandler.Get", ID: "GetFile",
+		Summary: "Download a stored file such as a face image", Tag: "Files", Kind: ResponseFile,
+	},
+
+	holidayCalendars.list(),
+	holidayCalendars.get(),
+	holidayCalendars.create(),
+	holidayCalendars.update(),
+	holidayCalendars.delete(),
+	{
+		Method: http.MethodPost, Path: holidayCalendars.path + "/:id/import", Handler: "HolidayCalendarHandler.Import", ID: "ImportHolidayCalendar",
+		Summary: "Add the holidays of an iCalendar file to a holiday calendar", Tag: holidayCalendars.tag,
+		Query: schema.HolidayCalendarImportQuery{}, Files: []FormFile{{Name: "file", Required: true}}, Response: schema.HolidayCalendarImportResponse{},
+	},
+
+	leaveRequests.list(),
+	leaveRequests.get(),
+	leaveRequests.create(),
+	{
+		Method: http.MethodPost, Path: leaveRequests.path + "/:id/approve", Handler: "LeaveRequestHandler.Approve", ID: "ApproveLeaveRequest",
+		Summary: "Approve a leave request", Tag: leaveRequests.tag,
+		Body: schema.DecisionRequest{}, OptionalBody: true, Response: schema.LeaveRequestResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: leaveRequests.path + "/:id/reject", Handler: "LeaveRequestHandler.Reject", ID: "RejectLeaveRequest",
+		Summary: "Reject a leave request", Tag: leaveRequests.tag,
+		Body: schema.DecisionRequest{}, OptionalBody: true, Response: schema.LeaveRequestResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: leaveRequests.path + "/:id/cancel", Handler: "LeaveRequestHandler.Cancel", ID: "CancelLeaveRequest",
+		Summary: "Cancel a leave request", Tag: leaveRequests.tag,
+		Body: schema.DecisionRequest{}, OptionalBody: true, Response: schema.LeaveRequestResponse{},
+	},
+
+	leaveTypes.list(),
+	leaveTypes.get(),
+	leaveTypes.create(),
+	leaveTypes.update(),
+	leaveTypes.delete(),
+
+	locations.list(),
+	{
+		Method: http.MethodGet, Path: locations.path + "/tree", Handler: "LocationHandler.GetTree", ID: "GetLocationTree",
+		Summary: "Get the location hierarchy with the devices of every location", Tag: locations.tag,
+		Response: []schema.LocationTreeResponse{},
+	},
+	locations.get(),
+	{
+		Method: http.MethodGet, Path: locations.path + "/:id/occupancy", Handler: "LocationHandler.GetOccupancy", ID: "GetLocationOccupancy",
+		Summary: "Get the people last seen entering a location and the locations below it", Tag: locations.tag,
+		Query: schema.LocationOccupancyQuery{}, Response: schema.LocationOccupancyResponse{},
+	},
+	locations.create(),
+	locations.update(),
+	locations.delete(),
+
+	overtimeRules.list(),
+	overtimeRules.get(),
+	overtimeRules.create(),
+	overtimeRules.update(),
+	overtimeRules.delete(),
+
+	payrollExportTemplates.list(),
+	payrollExportTemplates.get(),
+	payrollExportTemplates.create(),
+	payrollExportTemplates.update(),
+	payrollExportTemplates.delete(),
+
+	payrollExports.list(),
+	payrollExports.get(),
+	{
+		Method: http.MethodPost, Path: payrollExports.path + "/", Handler: "PayrollExportHandler.Generate", ID: "GeneratePayrollExport",
+		Summary: "Generate a payroll export of a period", Tag: payrollExports.tag,
+		Body: schema.PayrollExportRequest{}, Response: schema.PayrollExportResponse{},
+	},
+	payrollExports.delete(),
+	{
+		Method: http.MethodGet, Path: payrollExports.path + "/:id/download", Handler: "PayrollExportHandler.Download", ID: "DownloadPayrollExport",
+		Summary: "Download the file of a payroll export", Tag: payrollExports.tag, Kind: ResponseFile,
+	},
+	{
+		Method: http.MethodGet, Path: payrollExports.path + "/:id/changes", Handler: "PayrollExportHandler.GetChanges", ID: "ListPayrollExportChanges",
+		Summary: "List the attendance changed since a payroll export was generated", Tag: payrollExports.tag,
+		Response: []schema.PayrollExportChangeResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: payrollExports.path + "/:id/lock", Handler: "PayrollExportHandler.Lock", ID: "LockPayrollExport",
+		Summary: "Lock the period of a payroll export", Tag: payrollExports.tag,
+		Response: schema.PayrollExportResponse{},
+	},
+
+	people.list(),
+	{
+		Method: http.MethodGet, Path: people.path + "/export", Handler: "PersonHandler.Export", ID: "ExportPeople",
+		Summary: "Export people as a CSV or XLSX file", Tag: people.tag,
+		Query: schema.PersonExportQuery{}, Kind: ResponseFile,
+	},
+	{
+		Method: http.MethodPost, Path: people.path + "/import", Handler: "PersonHandler.Import", ID: "ImportPeople",
+		Summary: "Import people from a CSV or XLSX file", Tag: people.tag,
+		Query: schema.PersonImportQuery{}, Files: []FormFile{{Name: "file", Required: true}}, Response: schema.PersonImportResult{},
+	},
+	{
+		Method: http.MethodPost, Path: people.path + "/face-images/import", Handler: "PersonHandler.ImportFaceImages", ID: "ImportFaceImages",
+		Summary: "Import the face images of people from a ZIP file", Tag: people.tag,
+		Query: schema.PersonFaceImageImportQuery{}, Files: []FormFile{{Name: "file", Required: true}}, Response: schema.PersonFaceImageImportResult{},
+	},
+	people.get(),
+	{
+		Method: http.MethodPost, Path: people.path + "/", Handler: "PersonHandler.Create", ID: "CreatePerson",
+		Summary: "Create a person", Tag: people.tag,
+		Form: schema.PersonRequest{}, Files: []FormFile{{Name: "faceImage"}}, Response: schema.PersonResponse{},
+	},
+	{
+		Method: http.MethodPut, Path: people.path + "/:id", Handler: "PersonHandler.Update", ID: "UpdatePerson",
+		Summary: "Replace a person", Tag: people.tag,
+		Form: schema.PersonRequest{}, Files: []FormFile{{Name: "faceImage"}}, Response: schema.PersonResponse{},
+	},
+	people.delete(),
+	{
+		Method: http.MethodPut, Path: people.path + "/:id/pin", Handler: "PersonHandler.SetPIN", ID: "SetPersonPIN",
+		Summary: "Set the PIN of a person", Tag: people.tag,
+		Body: schema.PersonPINRequest{},
+	},
+	{
+		Method: http.MethodDelete, Path: people.path + "/:id/pin", Handler: "PersonHandler.ClearPIN", ID: "ClearPersonPIN",
+		Summary: "Clear the PIN of a person", Tag: people.tag,
+	},
+	{
+		Method: http.MethodGet, Path: people.path + "/:id/data-export", Handler: "DataSubjectHandler.Export", ID: "ExportPersonData",
+		Summary: "Export every personal data of a person as a ZIP file", Tag: people.tag,
+		Query: schema.DataSubjectExportQuery{}, Kind: ResponseFile, ContentType: "application/zip",
+	},
+	{
+		Method: http.MethodPost, Path: people.path + "/:id/erase", Handler: "DataSubjectHandler.Erase", ID: "ErasePersonData",
+		Summary: "Anonymize a person and remove their personal data", Tag: people.tag,
+		Body: schema.DataSubjectEraseRequest{}, Response: schema.PersonResponse{},
+	},
+	{
+		Method: http.MethodGet, Path: people.path + "/:id/cards", Handler: "PersonCardHandler.GetAll", ID: "ListPersonCards",
+		Summary: "List the cards of a person", Tag: people.tag,
+		Response: []schema.PersonCardResponse{},
+	},
+	{
+		Method: http.MethodGet, Path: people.path + "/:id/cards/history", Handler: "PersonCardHandler.GetHistory", ID: "ListPersonCardsHistory",
+		Summary: "List the history of every card of a person", Tag: people.tag,
+		Response: []schema.PersonCardHistoryResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: people.path + "/:id/cards", Handler: "PersonCardHandler.Create", ID: "CreatePersonCard",
+		Summary: "Issue a card to a person", Tag: people.tag,
+		Body: schema.PersonCardRequest{}, Response: schema.PersonCardResponse{},
+	},
+	{
+		Method: http.MethodGet, Path: people.path + "/:id/cards/:cardId", Handler: "PersonCardHandler.GetByID", ID: "GetPersonCard",
+		Summary: "Get a card of a person", Tag: people.tag,
+		Response: schema.PersonCardResponse{},
+	},
+	{
+		Method: http.MethodPatch, Path: people.path + "/:id/cards/:cardId", Handler: "PersonCardHandler.UpdateValidity", ID: "UpdatePersonCardValidity",
+		Summary: "Change the validity period of a card", Tag: people.tag,
+		Body: schema.PersonCardValidityRequest{}, Response: schema.PersonCardResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: people.path + "/:id/cards/:cardId/status", Handler: "PersonCardHandler.ChangeStatus", ID: "ChangePersonCardStatus",
+		Summary: "Change the status of a card", Tag: people.tag,
+		Body: schema.PersonCardStatusRequest{}, Response: schema.PersonCardResponse{},
+	},
+	{
+		Method: http.MethodGet, Path: people.path + "/:id/cards/:cardId/history", Handler: "PersonCardHandler.GetHistory", ID: "ListPersonCardHistory",
+		Summary: "List the history of a card", Tag: people.tag,
+		Response: []schema.PersonCardHistoryResponse{},
+	},
+	{
+		Method: http.MethodDelete, Path: people.path + "/:id/cards/:cardId", Handler: "PersonCardHandler.Delete", ID: "DeletePersonCard",
+		Summary: "Delete a card of a person", Tag: people.tag,
+	},
+	{
+		Method: http.MethodGet, Path: people.path + "/:id/leave-balances", Handler: "LeaveRequestHandler.GetBalances", ID: "ListLeaveBalances",
+		Summary: "List the leave balances of a person in a year", Tag: people.tag,
+		Query: schema.LeaveBalanceQuery{}, Response: []schema.LeaveBalanceResponse{},
+	},
+	{
+		Method: http.MethodPut, Path: people.path + "/:id/leave-balances", Handler: "LeaveRequestHandler.SaveBalance", ID: "SaveLeaveBalance",
+		Summary: "Set the entitlement of a person for a leave type in a year", Tag: people.tag,
+		Body: schema.LeaveBalanceRequest{}, Response: schema.LeaveBalanceResponse{},
+	},
+	{
+		Method: http.MethodGet, Path: people.path + "/:id/shift-assignments", Handler: "PersonShiftHandler.GetAssignments", ID: "ListShiftAssignments",
+		Summary: "List the shift rotation assignments of a person", Tag: people.tag,
+		Response: []schema.PersonShiftAssignmentResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: people.path + "/:id/shift-assignments", Handler: "PersonShiftHandler.CreateAssignment", ID: "CreateShiftAssignment",
+		Summary: "Assign a shift rotation to a person", Tag: people.tag,
+		Body: schema.PersonShiftAssignmentRequest{}, Response: schema.PersonShiftAssignmentResponse{},
+	},
+	{
+		Method: http.MethodDelete, Path: people.path + "/:id/shift-assignments/:assignmentId", Handler: "PersonShiftHandler.DeleteAssignment", ID: "DeleteShiftAssignment",
+		Summary: "Delete a shift rotation assignment of a person", Tag: people.tag,
+	},
+	{
+		Method: http.MethodGet, Path: people.path + "/:id/shift-overrides", Handler: "PersonShiftHandler.GetOverrides", ID: "ListShiftOverrides",
+		Summary: "List the shift overrides of a person", Tag: people.tag,
+		Response: []schema.PersonShiftOverrideResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: people.path + "/:id/shift-overrides", Handler: "PersonShiftHandler.SaveOverride", ID: "SaveShiftOverride",
+		Summary: "Set the shift of a person on a date", Tag: people.tag,
+		Body: schema.PersonShiftOverrideRequest{}, Response: schema.PersonShiftOverrideResponse{},
+	},
+	{
+		Method: http.MethodDelete, Path: people.path + "/:id/shift-overrides/:overrideId", Handler: "PersonShiftHandler.DeleteOverride", ID: "DeleteShiftOverride",
+		Summary: "Delete a shift override of a person", Tag: people.tag,
+	},
+
+	{
+		Method: http.MethodGet, Path: retentionRuns.path + "/policy", Handler: "RetentionHandler.GetPolicy", ID: "GetRetentionPolicy",
+		Summary: "Get the retention policy", Description: globalScopeDescription, Tag: retentionRuns.tag,
+		Response: schema.RetentionPolicyResponse{},
+	},
+	{
+		Method: http.MethodGet, Path: retentionRuns.path + "/runs", Handler: "RetentionHandler.GetAllRuns", ID: "ListRetentionRuns",
+		Summary: "List retention runs", Description: globalScopeDescription, Tag: retentionRuns.tag,
+		Query: retentionRuns.query, Response: retentionRuns.response, Kind: ResponseList,
+	},
+	{
+		Method: http.MethodGet, Path: retentionRuns.path + "/runs/:id", Handler: "RetentionHandler.GetRunByID", ID: "GetRetentionRun",
+		Summary: "Get a retention run", Description: globalScopeDescription, Tag: retentionRuns.tag,
+		Response: retentionRuns.response,
+	},
+	{
+		Method: http.MethodPost, Path: retentionRuns.path + "/runs", Handler: "RetentionHandler.Run", ID: "RunRetention",
+		Summary: "Purge the data past its retention now", Description: globalScopeDescription, Tag: retentionRuns.tag,
+		Response: retentionRuns.response,
+	},
+
+	shiftRotations.list(),
+	shiftRotations.get(),
+	shiftRotations.create(),
+	shiftRotations.update(),
+	shiftRotations.delete(),
+
+	shiftTemplates.list(),
+	shiftTemplates.get(),
+	shiftTemplates.create(),
+	shiftTemplates.update(),
+	shiftTemplates.delete(),
+
+	tenants.list(),
+	tenants.get(),
+	tenants.create(),
+	tenants.update(),
+	tenants.delete(),
+	{
+		Method: http.MethodPost, Path: tenants.path + "/switch", Handler: "AuthHandler.SwitchTenant", ID: "SwitchTenant",
+		Summary: "Get a token for another tenant or the global scope", Tag: tenants.tag,
+		Body: schema.SwitchTenantRequest{}, Response: schema.TokenResponse{}, Kind: ResponseJSON,
+	},
+
+	users.list(),
+	users.get(),
+	users.create(),
+	users.update(),
+	users.patch(),
+	users.delete(),
+
+	visitorVehicles.list(),
+	visitorVehicles.get(),
+	visitorVehicles.delete(),
+})
+
+// withTenantRoutes describes the super admin only tenant routes.
+func withTenantRoutes(routes []Route) []Route {
+	for i := range routes {
+		if routes[i].Tag == tenants.tag {
+			routes[i].Description = superAdminDescription
+		}
+	}
+	return routes
+}
@@ -0,0 +1,263 @@
+package openapi_test
+
+import (
+	"bytes"
+	"go/ast"
+	"go/constant"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/putteror/access-control-management/internal/openapi"
+	"github.com/putteror/access-control-management/internal/router"
+)
+
+const (
+	handlerDir     = "../app/handler"
+	handlerPackage = "github.com/putteror/access-control-management/internal/app/handler"
+	commonPackage  = "github.com/putteror/access-control-management/internal/app/common"
+	ginPackage     = "github.com/gin-gonic/gin"
+	clientGenFile  = "../../pkg/client/client_gen.go"
+)
+
+func TestRoutesMatchRouter(t *testing.T) {
+	if err := openapi.CheckRoutes(router.Routes()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	document, err := openapi.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	operations := 0
+	for _, pathItem := range document.Paths {
+		operations += len(pathItem)
+	}
+	if operations != len(openapi.Routes) {
+		t.Errorf("document has %d operations, want one for each of the %d routes", operations, len(openapi.Routes))
+	}
+}
+
+func TestClientIsGenerated(t *testing.T) {
+	generated, err := openapi.GenerateClient("client")
+	if err != nil {
+		t.Fatal(err)
+	}
+	committed, err := os.ReadFile(clientGenFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(generated, committed) {
+		t.Errorf("%s is out of date with openapi.Routes, run go generate ./pkg/client", clientGenFile)
+	}
+}
+
+// handlerTypes are the schema types a handler binds and responds with, as Go type strings such as
+// schema.PersonRequest. An empty string stands for a nil response.
+type handlerTypes struct {
+	query    []string
+	body     []string
+	response []string
+}
+
+// TestRouteTypesMatchHandlers type checks the handlers and compares the query, body and response
+// types of every route with the types its handler binds and responds with.
+func TestRouteTypesMatchHandlers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("type checking the handlers from source takes a while")
+	}
+	handlers := loadHandlerTypes(t)
+
+	for _, route := range openapi.Routes {
+		if route.Handler == "Spec.Serve" {
+			continue
+		}
+		key := route.Method + " " + route.Path
+		found, ok := handlers[route.Handler]
+		if !ok {
+			t.Errorf("%s: handler %s not found in %s", key, route.Handler, handlerDir)
+			continue
+		}
+
+		body := route.Body
+		if body == nil {
+			body = route.Form
+		}
+		checkTypes(t, key, "query", typeName(route.Query), found.query)
+		checkTypes(t, key, "body", typeName(body), found.body)
+		if route.Kind == openapi.ResponseFile {
+			if len(found.response) > 0 {
+				t.Errorf("%s: documented as a file download, handler responds with %s", key, strings.Join(found.response, ", "))
+			}
+			continue
+		}
+		response := typeName(route.Response)
+		if route.Kind == openapi.ResponseList {
+			response = "[]" + response
+		}
+		checkTypes(t, key, "response", response, found.response)
+	}
+}
+
+// checkTypes reports handler types that differ from the documented type. A documented type needs
+// a handler type.
+func checkTypes(t *testing.T, route string, part string, documented string, found []string) {
+	t.Helper()
+	if len(found) == 0 {
+		if documented != "" {
+			t.Errorf("%s: %s is documented as %s, handler has none", route, part, documented)
+		}
+		return
+	}
+	for _, name := range found {
+		if name != documented {
+			t.Errorf("%s: %s is documented as %q, handler uses %q", route, part, documented, name)
+		}
+	}
+}
+
+// typeName returns the Go type string of a zero value of Routes, empty for nil.
+func typeName(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return reflect.TypeOf(value).String()
+}
+
+// loadHandlerTypes type checks the handler package and returns the types of its methods by
+// handler name, e.g. PersonHandler.GetByID. The types of the helpers of the package a method
+// calls, such as a shared bind function, count as the types of the method.
+func loadHandlerTypes(t *testing.T) map[string]*handlerTypes {
+	t.Helper()
+	fset := token.NewFileSet()
+	packages, err := parser.ParseDir(fset, handlerDir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var files []*ast.File
+	for _, file := range packages["handler"].Files {
+		files = append(files, file)
+	}
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	config := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := config.Check(handlerPackage, fset, files, info); err != nil {
+		t.Fatal(err)
+	}
+
+	bodies := make(map[types.Object]*ast.BlockStmt)
+	methods := make(map[string]types.Object)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			function, ok := decl.(*ast.FuncDecl)
+			if !ok || function.Body == nil {
+				continue
+			}
+			object := info.Defs[function.Name]
+			bodies[object] = function.Body
+			if function.Recv == nil {
+				continue
+			}
+			receiver := function.Recv.List[0].Type
+			if star, ok := receiver.(*ast.StarExpr); ok {
+				receiver = star.X
+			}
+			if receiverName, ok := receiver.(*ast.Ident); ok {
+				methods[receiverName.Name+"."+function.Name.Name] = object
+			}
+		}
+	}
+
+	handlers := make(map[string]*handlerTypes)
+	for name, object := range methods {
+		found := &handlerTypes{}
+		found.inspect(info, bodies, object, make(map[types.Object]bool))
+		handlers[name] = found
+	}
+	return handlers
+}
+
+// inspect records the types of the calls of a function of the handler package and of the
+// functions of the package it calls.
+func (h *handlerTypes) inspect(info *types.Info, bodies map[types.Object]*ast.BlockStmt, function types.Object, visited map[types.Object]bool) {
+	if visited[function] {
+		return
+	}
+	visited[function] = true
+	ast.Inspect(bodies[function], func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		var name *ast.Ident
+		switch fun := call.Fun.(type) {
+		case *ast.Ident:
+			name = fun
+		case *ast.SelectorExpr:
+			name = fun.Sel
+		default:
+			return true
+		}
+		if callee, ok := info.Uses[name].(*types.Func); ok && bodies[callee] != nil {
+			h.inspect(info, bodies, callee, visited)
+			return true
+		}
+		h.add(info, name, call)
+		return true
+	})
+}
+
+// add records the type a call binds or responds with.
+func (h *handlerTypes) add(info *types.Info, name *ast.Ident, call *ast.CallExpr) {
+	function, ok := info.Uses[name].(*types.Func)
+	if !ok || function.Pkg() == nil {
+		return
+	}
+	switch function.Pkg().Path() + "." + function.Name() {
+	case ginPackage + ".ShouldBindQuery":
+		h.query = append(h.query, argumentType(info, call.Args[0]))
+	case ginPackage + ".ShouldBindJSON", ginPackage + ".ShouldBind":
+		h.body = append(h.body, argumentType(info, call.Args[0]))
+	case commonPackage + ".SuccessResponse", commonPackage + ".GetDataListResponse":
+		h.response = append(h.response, argumentType(info, call.Args[2]))
+	case ginPackage + ".JSON":
+		// Other statuses are errors, which are documented for every route alike
+		if status := info.Types[call.Args[0]].Value; status != nil && status.Kind() == constant.Int {
+			if code, _ := constant.Int64Val(status); code == http.StatusOK {
+				h.response = append(h.response, argumentType(info, call.Args[1]))
+			}
+		}
+	}
+}
+
+// argumentType returns the type of an argument as the type string of Routes, without pointers.
+func argumentType(info *types.Info, argument ast.Expr) string {
+	argumentType := info.Types[argument].Type
+	if argumentType == nil {
+		return ""
+	}
+	if basic, ok := argumentType.(*types.Basic); ok && basic.Kind() == types.UntypedNil {
+		return ""
+	}
+	for {
+		pointer, ok := argumentType.(*types.Pointer)
+		if !ok {
+			break
+		}
+		argumentType = pointer.Elem()
+	}
+	return types.TypeString(argumentType, func(pkg *types.Package) string { return pkg.Name() })
+}
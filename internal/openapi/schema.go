@@ -0,0 +1,253 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/putteror/access-control-management/internal/app/validation"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaBuilder turns Go types into schemas. Named structs become schemas of the components, so
+// a type is described once however many routes use it.
+type schemaBuilder struct {
+	schemas map[string]*Schema
+	types   map[string]reflect.Type
+}
+
+func newSchemaBuilder() *schemaBuilder {
+	return &schemaBuilder{schemas: make(map[string]*Schema), types: make(map[string]reflect.Type)}
+}
+
+// schema returns the schema of the JSON encoding of a Go type.
+func (b *schemaBuilder) schema(t reflect.Type) (*Schema, error) {
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema, err := b.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		// Siblings of a reference are ignored, so a nullable reference wraps it
+		if schema.Ref != "" {
+			return &Schema{AllOf: []*Schema{schema}, Nullable: true}, nil
+		}
+		schema.Nullable = true
+		return schema, nil
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int32:
+		return &Schema{Type: "integer"}, nil
+	case reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}, nil
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}, nil
+	case reflect.Slice:
+		items, err := b.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("map %s must have string keys", t)
+		}
+		values, err := b.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		if t == timeType {
+			return &Schema{Type: "string", Format: "date-time"}, nil
+		}
+		return b.component(t)
+	}
+	return nil, fmt.Errorf("type %s has no JSON schema", t)
+}
+
+// component adds the schema of a named struct to the components and returns a reference to it.
+func (b *schemaBuilder) component(t reflect.Type) (*Schema, error) {
+	name := t.Name()
+	if name == "" {
+		return nil, fmt.Errorf("anonymous struct %s cannot be documented", t)
+	}
+	ref := &Schema{Ref: "#/components/schemas/" + name}
+	if known, ok := b.types[name]; ok {
+		if known != t {
+			return nil, fmt.Errorf("types %s and %s are both named %s", known, t, name)
+		}
+		return ref, nil
+	}
+
+	// Registered before the fields, so recursive types such as location trees refer to themselves
+	object := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	b.types[name] = t
+	b.schemas[name] = object
+	err := eachField(t, "json", func(field reflect.StructField, fieldName string) error {
+		schema, err := b.schema(field.Type)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", name, field.Name, err)
+		}
+		describeRules(schema, field)
+		object.Properties[fieldName] = schema
+		if isRequired(field) {
+			object.Required = append(object.Required, fieldName)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ref, nil
+}
+
+// parameters returns the query parameters bound to a struct with form tags.
+func (b *schemaBuilder) parameters(t reflect.Type) ([]Parameter, error) {
+	var parameters []Parameter
+	err := eachField(t, "form", func(field reflect.StructField, name string) error {
+		schema, err := formSchema(field.Type)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err)
+		}
+		describeRules(schema, field)
+		parameters = append(parameters, Parameter{Name: name, In: "query", Required: isRequired(field), Schema: schema})
+		return nil
+	})
+	return parameters, err
+}
+
+// multipart returns the schema of a multipart body with the fields of a struct with form tags
+// and the files of a route.
+func (b *schemaBuilder) multipart(route Route) (*Schema, error) {
+	object := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	if route.Form != nil {
+		t := reflect.TypeOf(route.Form)
+		err := eachField(t, "form", func(field reflect.StructField, name string) error {
+			schema, err := formSchema(field.Type)
+			if err != nil {
+				return fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err)
+			}
+			describeRules(schema, field)
+			object.Properties[name] = schema
+			if isRequired(field) {
+				object.Required = append(object.Required, name)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, file := range route.Files {
+		object.Properties[file.Name] = &Schema{Type: "string", Format: "binary"}
+		if file.Required {
+			object.Required = append(object.Required, file.Name)
+		}
+	}
+	return object, nil
+}
+
+// formSchema returns the schema of a form field, which holds scalars or a list of strings.
+func formSchema(t reflect.Type) (*Schema, error) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int:
+		return &Schema{Type: "integer"}, nil
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}, nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.String {
+			return &Schema{Type: "array", Items: &Schema{Type: "string"}}, nil
+		}
+	}
+	return nil, fmt.Errorf("type %s cannot be sent in a form", t)
+}
+
+// eachField calls fn for the exported fields of a struct with their name in the given tag.
+// Embedded structs are flattened as encoding/json and gin do. Fields without the tag are an
+// error, because clients could not know their name.
+func eachField(t reflect.Type, tag string, fn func(field reflect.StructField, name string) error) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := eachField(field.Type, tag, fn); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		value, ok := field.Tag.Lookup(tag)
+		if !ok {
+			return fmt.Errorf("field %s.%s has no %s tag", t.Name(), field.Name, tag)
+		}
+		name, _, _ := strings.Cut(value, ",")
+		if name == "-" {
+			continue
+		}
+		if err := fn(field, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ruleFormats are the formats of the strings of the validate tags.
+var ruleFormats = map[string]string{
+	"uuid":      "uuid",
+	"date":      "date",
+	"timeOfDay": "time",
+	"timestamp": "date-time",
+}
+
+// isRequired reports whether the validator requires a field.
+func isRequired(field reflect.StructField) bool {
+	rules, _ := validateRules(field)
+	return slices.Contains(rules, "required")
+}
+
+// validateRules returns the rules of the validate tag of a field, and the rules of its items
+// after a dive.
+func validateRules(field reflect.StructField) ([]string, []string) {
+	rules := strings.Split(field.Tag.Get("validate"), ",")
+	if i := slices.Index(rules, "dive"); i >= 0 {
+		return rules[:i], rules[i+1:]
+	}
+	return rules, nil
+}
+
+// describeRules adds the formats and the enums of the validate tag of a field to its schema.
+func describeRules(schema *Schema, field reflect.StructField) {
+	rules, itemRules := validateRules(field)
+	describeStringRules(schema, rules)
+	if schema.Items != nil {
+		describeStringRules(schema.Items, itemRules)
+	}
+}
+
+func describeStringRules(schema *Schema, rules []string) {
+	if schema.Type != "string" {
+		return
+	}
+	for _, rule := range rules {
+		if format, ok := ruleFormats[rule]; ok {
+			schema.Format = format
+		}
+		if values, ok := validation.Enum(rule); ok {
+			schema.Enum = values
+		}
+	}
+}
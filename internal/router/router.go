@@ -1,24 +1,54 @@
 package router
 
 import (
+	"reflect"
+
 	"github.com/putteror/access-control-management/internal/app/handler"
 	"github.com/putteror/access-control-management/internal/app/middleware"
+	"github.com/putteror/access-control-management/internal/config"
+	"github.com/putteror/access-control-management/internal/openapi"
 
 	"github.com/gin-gonic/gin"
 )
 
 func NewRouter(
+	corsConfig config.CORSConfig,
 	accessControlDeviceHandler *handler.AccessControlDeviceHandler,
 	accessControlGroupHandler *handler.AccessControlGroupHandler,
 	accessControlRuleHandler *handler.AccessControlRuleHandler,
 	accessControlServerHandler *handler.AccessControlServerHandler,
+	accessDecisionHandler *handler.AccessDecisionHandler,
 	accessRecordHandler *handler.AccessRecordHandler,
 	attendanceHandler *handler.AttendanceHandler,
+	attendanceCorrectionHandler *handler.AttendanceCorrectionHandler,
+	attendanceRecordHandler *handler.AttendanceRecordHandler,
+	auditLogHandler *handler.AuditLogHandler,
 	authHandler *handler.AuthHandler,
+	dataSubjectHandler *handler.DataSubjectHandler,
+	fileHandler *handler.FileHandler,
+	holidayCalendarHandler *handler.HolidayCalendarHandler,
+	leaveRequestHandler *handler.LeaveRequestHandler,
+	leaveTypeHandler *handler.LeaveTypeHandler,
+	locationHandler *handler.LocationHandler,
+	overtimeRuleHandler *handler.OvertimeRuleHandler,
+	payrollExportHandler *handler.PayrollExportHandler,
+	payrollExportTemplateHandler *handler.PayrollExportTemplateHandler,
 	peopleHandler *handler.PersonHandler,
+	personCardHandler *handler.PersonCardHandler,
+	personShiftHandler *handler.PersonShiftHandler,
+	retentionHandler *handler.RetentionHandler,
+	shiftRotationHandler *handler.ShiftRotationHandler,
+	shiftTemplateHandler *handler.ShiftTemplateHandler,
+	tenantHandler *handler.TenantHandler,
 	userHandler *handler.UserHandler,
+	visitorVehicleHandler *handler.VisitorVehicleHandler,
 ) *gin.Engine {
 	router := gin.Default()
+	if len(corsConfig.AllowedOrigins) > 0 {
+		router.Use(middleware.CORSMiddleware(corsConfig))
+	}
+	router.Use(middleware.ErrorMiddleware())
+	router.GET("/openapi.json", (&openapi.Spec{}).Serve)
 	router.POST("/login", authHandler.Login)
 
 	api := router.Group("/api")
@@ -69,15 +99,19 @@ func NewRouter(
 			accessControlServer.DELETE("/:id", accessControlServerHandler.Delete)
 		}
 
+		// Access decision endpoints
+		api.POST("/access-decisions", accessDecisionHandler.Decide)
+		api.POST("/lpr-events", accessDecisionHandler.DecideLicensePlate)
+
 		// Access record endpoints
 		accessRecord := api.Group("/access-records")
 		{
 			accessRecord.GET("/", accessRecordHandler.GetAll)
+			accessRecord.GET("/verify", accessRecordHandler.Verify)
 			accessRecord.GET("/:id", accessRecordHandler.GetByID)
 			accessRecord.POST("/", accessRecordHandler.Create)
-			accessRecord.PUT("/:id", accessRecordHandler.Update)
-			accessRecord.PATCH("/:id", accessRecordHandler.PartialUpdate)
-			accessRecord.DELETE("/:id", accessRecordHandler.Delete)
+			accessRecord.GET("/:id/annotations", accessRecordHandler.GetAnnotations)
+			accessRecord.POST("/:id/annotations", accessRecordHandler.Annotate)
 		}
 
 		// Attendance endpoints
@@ -91,14 +125,188 @@ func NewRouter(
 			attendance.DELETE("/:id", attendanceHandler.Delete)
 		}
 
+		// Attendance correction endpoints
+		attendanceCorrection := api.Group("/attendance-corrections")
+		{
+			attendanceCorrection.GET("/", attendanceCorrectionHandler.GetAll)
+			attendanceCorrection.GET("/:id", attendanceCorrectionHandler.GetByID)
+			attendanceCorrection.POST("/", attendanceCorrectionHandler.Create)
+			attendanceCorrection.POST("/:id/approve", attendanceCorrectionHandler.Approve)
+			attendanceCorrection.POST("/:id/reject", attendanceCorrectionHandler.Reject)
+			attendanceCorrection.POST("/:id/cancel", attendanceCorrectionHandler.Cancel)
+		}
+
+		// Attendance record endpoints
+		attendanceRecord := api.Group("/attendance-records")
+		{
+			attendanceRecord.GET("/", attendanceRecordHandler.GetAll)
+			attendanceRecord.GET("/summary", attendanceRecordHandler.Summary)
+			attendanceRecord.POST("/calculate", attendanceRecordHandler.Calculate)
+			attendanceRecord.POST("/:id/overtime/approve", attendanceRecordHandler.ApproveOvertime)
+			attendanceRecord.POST("/:id/overtime/reject", attendanceRecordHandler.RejectOvertime)
+		}
+
+		// Audit log endpoints
+		api.GET("/audit-logs", auditLogHandler.GetAll)
+
+		// File endpoints
+		api.GET("/files/*filepath", fileHandler.Get)
+
+		// Holiday calendar endpoints
+		holidayCalendar := api.Group("/holiday-calendars")
+		{
+			holidayCalendar.GET("/", holidayCalendarHandler.GetAll)
+			holidayCalendar.GET("/:id", holidayCalendarHandler.GetByID)
+			holidayCalendar.POST("/", holidayCalendarHandler.Create)
+			holidayCalendar.PUT("/:id", holidayCalendarHandler.Update)
+			holidayCalendar.DELETE("/:id", holidayCalendarHandler.Delete)
+			holidayCalendar.POST("/:id/import", holidayCalendarHandler.Import)
+		}
+
+		// Leave request endpoints
+		leaveRequest := api.Group("/leave-requests")
+		{
+			leaveRequest.GET("/", leaveRequestHandler.GetAll)
+			leaveRequest.GET("/:id", leaveRequestHandler.GetByID)
+			leaveRequest.POST("/", leaveRequestHandler.Create)
+			leaveRequest.POST("/:id/approve", leaveRequestHandler.Approve)
+			leaveRequest.POST("/:id/reject", leaveRequestHandler.Reject)
+			leaveRequest.POST("/:id/cancel", leaveRequestHandler.Cancel)
+		}
+
+		// Leave type endpoints
+		leaveType := api.Group("/leave-types")
+		{
+			leaveType.GET("/", leaveTypeHandler.GetAll)
+			leaveType.GET("/:id", leaveTypeHandler.GetByID)
+			leaveType.POST("/", leaveTypeHandler.Create)
+			leaveType.PUT("/:id", leaveTypeHandler.Update)
+			leaveType.DELETE("/:id", leaveTypeHandler.Delete)
+		}
+
+		// Location endpoints
+		location := api.Group("/locations")
+		{
+			location.GET("/", locationHandler.GetAll)
+			location.GET("/tree", locationHandler.GetTree)
+			location.GET("/:id", locationHandler.GetByID)
+			location.GET("/:id/occupancy", locationHandler.GetOccupancy)
+			location.POST("/", locationHandler.Create)
+			location.PUT("/:id", locationHandler.Update)
+			location.DELETE("/:id", locationHandler.Delete)
+		}
+
+		// Overtime rule endpoints
+		overtimeRule := api.Group("/overtime-rules")
+		{
+			overtimeRule.GET("/", overtimeRuleHandler.GetAll)
+			overtimeRule.GET("/:id", overtimeRuleHandler.GetByID)
+			overtimeRule.POST("/", overtimeRuleHandler.Create)
+			overtimeRule.PUT("/:id", overtimeRuleHandler.Update)
+			overtimeRule.DELETE("/:id", overtimeRuleHandler.Delete)
+		}
+
+		// Payroll export template endpoints
+		payrollExportTemplate := api.Group("/payroll-export-templates")
+		{
+			payrollExportTemplate.GET("/", payrollExportTemplateHandler.GetAll)
+			payrollExportTemplate.GET("/:id", payrollExportTemplateHandler.GetByID)
+			payrollExportTemplate.POST("/", payrollExportTemplateHandler.Create)
+			payrollExportTemplate.PUT("/:id", payrollExportTemplateHandler.Update)
+			payrollExportTemplate.DELETE("/:id", payrollExportTemplateHandler.Delete)
+		}
+
+		// Payroll export endpoints
+		payrollExport := api.Group("/payroll-exports")
+		{
+			payrollExport.GET("/", payrollExportHandler.GetAll)
+			payrollExport.GET("/:id", payrollExportHandler.GetByID)
+			payrollExport.POST("/", payrollExportHandler.Generate)
+			payrollExport.DELETE("/:id", payrollExportHandler.Delete)
+			payrollExport.GET("/:id/download", payrollExportHandler.Download)
+			payrollExport.GET("/:id/changes", payrollExportHandler.GetChanges)
+			payrollExport.POST("/:id/lock", payrollExportHandler.Lock)
+		}
+
 		// People endpoints
 		people := api.Group("/people")
 		{
 			people.GET("/", peopleHandler.GetAll)
+			people.GET("/export", peopleHandler.Export)
+			people.POST("/import", peopleHandler.Import)
+			people.POST("/face-images/import", peopleHandler.ImportFaceImages)
 			people.GET("/:id", peopleHandler.GetByID)
 			people.POST("/", peopleHandler.Create)
 			people.PUT("/:id", peopleHandler.Update)
 			people.DELETE("/:id", peopleHandler.Delete)
+			people.PUT("/:id/pin", peopleHandler.SetPIN)
+			people.DELETE("/:id/pin", peopleHandler.ClearPIN)
+
+			// Person data subject endpoints
+			people.GET("/:id/data-export", dataSubjectHandler.Export)
+			people.POST("/:id/erase", dataSubjectHandler.Erase)
+
+			// Person card endpoints
+			people.GET("/:id/cards", personCardHandler.GetAll)
+			people.GET("/:id/cards/history", personCardHandler.GetHistory)
+			people.POST("/:id/cards", personCardHandler.Create)
+			people.GET("/:id/cards/:cardId", personCardHandler.GetByID)
+			people.PATCH("/:id/cards/:cardId", personCardHandler.UpdateValidity)
+			people.POST("/:id/cards/:cardId/status", personCardHandler.ChangeStatus)
+			people.GET("/:id/cards/:cardId/history", personCardHandler.GetHistory)
+			people.DELETE("/:id/cards/:cardId", personCardHandler.Delete)
+
+			// Person leave balance endpoints
+			people.GET("/:id/leave-balances", leaveRequestHandler.GetBalances)
+			people.PUT("/:id/leave-balances", leaveRequestHandler.SaveBalance)
+
+			// Person shift endpoints
+			people.GET("/:id/shift-assignments", personShiftHandler.GetAssignments)
+			people.POST("/:id/shift-assignments", personShiftHandler.CreateAssignment)
+			people.DELETE("/:id/shift-assignments/:assignmentId", personShiftHandler.DeleteAssignment)
+			people.GET("/:id/shift-overrides", personShiftHandler.GetOverrides)
+			people.POST("/:id/shift-overrides", personShiftHandler.SaveOverride)
+			people.DELETE("/:id/shift-overrides/:overrideId", personShiftHandler.DeleteOverride)
+		}
+
+		// Retention endpoints
+		retention := api.Group("/retention", middleware.RequireGlobalScope())
+		{
+			retention.GET("/policy", retentionHandler.GetPolicy)
+			retention.GET("/runs", retentionHandler.GetAllRuns)
+			retention.GET("/runs/:id", retentionHandler.GetRunByID)
+			retention.POST("/runs", retentionHandler.Run)
+		}
+
+		// Shift rotation endpoints
+		shiftRotation := api.Group("/shift-rotations")
+		{
+			shiftRotation.GET("/", shiftRotationHandler.GetAll)
+			shiftRotation.GET("/:id", shiftRotationHandler.GetByID)
+			shiftRotation.POST("/", shiftRotationHandler.Create)
+			shiftRotation.PUT("/:id", shiftRotationHandler.Update)
+			shiftRotation.DELETE("/:id", shiftRotationHandler.Delete)
+		}
+
+		// Shift template endpoints
+		shiftTemplate := api.Group("/shift-templates")
+		{
+			shiftTemplate.GET("/", shiftTemplateHandler.GetAll)
+			shiftTemplate.GET("/:id", shiftTemplateHandler.GetByID)
+			shiftTemplate.POST("/", shiftTemplateHandler.Create)
+			shiftTemplate.PUT("/:id", shiftTemplateHandler.Update)
+			shiftTemplate.DELETE("/:id", shiftTemplateHandler.Delete)
+		}
+
+		// Tenant endpoints
+		tenant := api.Group("/tenants", middleware.RequireSuperAdmin())
+		{
+			tenant.GET("/", tenantHandler.GetAll)
+			tenant.GET("/:id", tenantHandler.GetByID)
+			tenant.POST("/", tenantHandler.Create)
+			tenant.PUT("/:id", tenantHandler.Update)
+			tenant.DELETE("/:id", tenantHandler.Delete)
+			tenant.POST("/switch", authHandler.SwitchTenant)
 		}
 
 		// User
@@ -112,7 +320,27 @@ func NewRouter(
 			user.DELETE("/:id", userHandler.Delete)
 		}
 
+		// Visitor vehicle endpoints
+		visitorVehicle := api.Group("/visitor-vehicles")
+		{
+			visitorVehicle.GET("/", visitorVehicleHandler.GetAll)
+			visitorVehicle.GET("/:id", visitorVehicleHandler.GetByID)
+			visitorVehicle.DELETE("/:id", visitorVehicleHandler.Delete)
+		}
+
 	}
 
 	return router
 }
+
+// Routes builds the router without services, only to list its routes. The handlers are never
+// called, so every argument of NewRouter can be left empty.
+func Routes() gin.RoutesInfo {
+	gin.SetMode(gin.ReleaseMode)
+	newRouter := reflect.ValueOf(NewRouter)
+	args := make([]reflect.Value, newRouter.Type().NumIn())
+	for i := range args {
+		args[i] = reflect.Zero(newRouter.Type().In(i))
+	}
+	return newRouter.Call(args)[0].Interface().(*gin.Engine).Routes()
+}
@@ -0,0 +1,40 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/putteror/access-control-management/internal/app/common"
+	"github.com/putteror/access-control-management/internal/app/middleware"
+)
+
+// TenantResolver returns the router serving a tenant, an error when the tenant cannot be used.
+type TenantResolver func(tenantID string) (http.Handler, error)
+
+// NewTenantRouter sends every request to the router of the tenant in its token, so the handlers
+// of a tenant only see its data. Requests without a valid token, such as logins, and requests of
+// super admins in the global scope go to the global router, whose JWT middleware rejects the
+// invalid tokens.
+func NewTenantRouter(globalRouter http.Handler, resolve TenantResolver) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeader := r.Header.Get("Authorization")
+		if authHeader == "" {
+			globalRouter.ServeHTTP(w, r)
+			return
+		}
+		claims, err := middleware.ParseToken(authHeader)
+		if err != nil || claims.TenantID == "" {
+			globalRouter.ServeHTTP(w, r)
+			return
+		}
+
+		tenantRouter, err := resolve(claims.TenantID)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(common.ErrorBody{Success: false, Message: err.Error(), Code: common.ErrorCodeForbidden})
+			return
+		}
+		tenantRouter.ServeHTTP(w, r)
+	})
+}
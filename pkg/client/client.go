@@ -0,0 +1,273 @@
+// Package client is a Go client of the access control management API. The types and methods of
+// client_gen.go are generated from the routes the server documents at /openapi.json, run
+// go generate after changing a route or a schema.
+//
+//	api := client.New("https://acm.example.com")
+//	token, err := api.Login(ctx, &client.LoginRequest{Username: "admin", Password: password})
+//	api.Token = token.Token
+//	people, page, err := api.ListPeople(ctx, &client.PersonSearchQuery{Company: "Example"})
+package client
+
+//go:generate go run ../../cmd/openapi -o client_gen.go client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Client calls the API of one server.
+type Client struct {
+	// BaseURL is the address of the server, e.g. https://acm.example.com
+	BaseURL string
+	// Token is sent as the bearer token of every request, see Login
+	Token      string
+	HTTPClient *http.Client
+}
+
+// New returns a client of the server at baseURL.
+func New(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimSuffix(baseURL, "/"), HTTPClient: http.DefaultClient}
+}
+
+// File is a file to upload or a downloaded file.
+type File struct {
+	Name        string
+	ContentType string
+	Content     io.Reader
+}
+
+// Error is an error response of the API. Code is one of the codes the API documents, such as
+// NOT_FOUND or VALIDATION_FAILED, and Fields lists the invalid fields of a validation error.
+type Error struct {
+	StatusCode int          `json:"-"`
+	Code       string       `json:"code"`
+	Message    string       `json:"message"`
+	Fields     []FieldError `json:"fields"`
+}
+
+// FieldError is the error of one field of a request.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("access control API: %s (status %d, %s)", e.Message, e.StatusCode, e.Code)
+}
+
+// request is a request of a generated method. body is sent as JSON, form and files as a
+// multipart body.
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	body   interface{}
+	form   url.Values
+	files  map[string]*File
+}
+
+// envelope is the body of the usual responses of the API.
+type envelope struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+	Page    *PageResponse   `json:"page"`
+}
+
+// do sends a request and decodes the data of its response into data, which may be nil. The page
+// is only set for lists.
+func (c *Client) do(ctx context.Context, req *request, data interface{}) (*PageResponse, error) {
+	var response envelope
+	if err := c.doJSON(ctx, req, &response); err != nil {
+		return nil, err
+	}
+	if data != nil && len(response.Data) > 0 {
+		if err := json.Unmarshal(response.Data, data); err != nil {
+			return nil, fmt.Errorf("failed to decode response data: %w", err)
+		}
+	}
+	return response.Page, nil
+}
+
+// doJSON sends a request and decodes its whole response into out.
+func (c *Client) doJSON(ctx context.Context, req *request, out interface{}) error {
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// download sends a request and reads the file of its response.
+func (c *Client) download(ctx context.Context, req *request) (*File, error) {
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	file := &File{ContentType: resp.Header.Get("Content-Type"), Content: bytes.NewReader(content)}
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		file.Name = params["filename"]
+	}
+	return file, nil
+}
+
+// send sends a request and returns its response, an *Error for an error status.
+func (c *Client) send(ctx context.Context, req *request) (*http.Response, error) {
+	target := c.BaseURL + req.path
+	if len(req.query) > 0 {
+		target += "?" + req.query.Encode()
+	}
+
+	var body io.Reader
+	contentType := ""
+	switch {
+	case req.body != nil:
+		encoded, err := json.Marshal(req.body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(encoded)
+		contentType = "application/json"
+	case req.form != nil || req.files != nil:
+		buffer := &bytes.Buffer{}
+		writer := multipart.NewWriter(buffer)
+		if err := writeMultipart(writer, req.form, req.files); err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = buffer
+		contentType = writer.FormDataContentType()
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if contentType != "" {
+		httpReq.Header.Set("Content-Type", contentType)
+	}
+	if c.Token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		defer resp.Body.Close()
+		apiErr := &Error{StatusCode: resp.StatusCode}
+		json.NewDecoder(resp.Body).Decode(apiErr)
+		if apiErr.Message == "" {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+		return nil, apiErr
+	}
+	return resp, nil
+}
+
+// writeMultipart writes the fields and files of a multipart body and closes it.
+func writeMultipart(writer *multipart.Writer, form url.Values, files map[string]*File) error {
+	for key, values := range form {
+		for _, value := range values {
+			if err := writer.WriteField(key, value); err != nil {
+				return err
+			}
+		}
+	}
+	for field, file := range files {
+		if file == nil {
+			continue
+		}
+		contentType := file.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{"name": field, "filename": file.Name}))
+		header.Set("Content-Type", contentType)
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(part, file.Content); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+// addValue adds a query parameter or form field, leaving out empty values as the server treats
+// them as not set.
+func addValue(values url.Values, key string, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		if v != "" {
+			values.Add(key, v)
+		}
+	case *string:
+		if v != nil {
+			values.Add(key, *v)
+		}
+	case int:
+		if v != 0 {
+			values.Add(key, strconv.Itoa(v))
+		}
+	case *int:
+		if v != nil {
+			values.Add(key, strconv.Itoa(*v))
+		}
+	case bool:
+		if v {
+			values.Add(key, "true")
+		}
+	case *bool:
+		if v != nil {
+			values.Add(key, strconv.FormatBool(*v))
+		}
+	case float64:
+		if v != 0 {
+			values.Add(key, strconv.FormatFloat(v, 'f', -1, 64))
+		}
+	case *float64:
+		if v != nil {
+			values.Add(key, strconv.FormatFloat(*v, 'f', -1, 64))
+		}
+	case []string:
+		for _, item := range v {
+			values.Add(key, item)
+		}
+	}
+}
+
+// escapePath escapes every segment of a path sent in a catch-all parameter.
+func escapePath(path string) string {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,3154 @@
+// Code generated by cmd/openapi. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type AccessControlDeviceRequest struct {
+	Name                  *string `json:"name"`
+	Type                  *string `json:"type"`
+	HostAddress           *string `json:"hostAddress"`
+	Username              *string `json:"username"`
+	Password              *string `json:"password"`
+	AccessToken           *string `json:"accessToken"`
+	ApiToken              *string `json:"apiToken"`
+	RecordScan            *bool   `json:"recordScan"`
+	RecordAttendance      *bool   `json:"recordAttendance"`
+	AllowClockIn          *bool   `json:"allowClockIn"`
+	AllowClockOut         *bool   `json:"allowClockOut"`
+	Status                *string `json:"status"`
+	AccessControlServerID *string `json:"accessControlServerId"`
+	LocationID            *string `json:"locationId"`
+	TimeZone              *string `json:"timeZone"`
+}
+
+type AccessControlDeviceResponse struct {
+	ID                  string                           `json:"id"`
+	Name                string                           `json:"name"`
+	HostAddress         string                           `json:"hostAddress"`
+	Type                string                           `json:"type"`
+	Status              string                           `json:"status"`
+	Username            *string                          `json:"username"`
+	Password            *string                          `json:"password"`
+	AccessToken         *string                          `json:"accessToken"`
+	ApiToken            *string                          `json:"apiToken"`
+	RecordScan          bool                             `json:"recordScan"`
+	RecordAttendance    bool                             `json:"recordAttendance"`
+	AllowClockIn        bool                             `json:"allowClockIn"`
+	AllowClockOut       bool                             `json:"allowClockOut"`
+	AccessControlServer *AccessControlServerInfoResponse `json:"accessControlServer"`
+	Location            *LocationInfoResponse            `json:"location"`
+	TimeZone            *string                          `json:"timeZone"`
+}
+
+type AccessControlDeviceSearchQuery struct {
+	Name        string `form:"name"`
+	Type        string `form:"type"`
+	HostAddress string `form:"hostAddress"`
+	LocationID  string `form:"locationId"`
+	Page        int    `form:"page"`
+	Limit       int    `form:"limit"`
+}
+
+func (q *AccessControlDeviceSearchQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "name", q.Name)
+	addValue(values, "type", q.Type)
+	addValue(values, "hostAddress", q.HostAddress)
+	addValue(values, "locationId", q.LocationID)
+	addValue(values, "page", q.Page)
+	addValue(values, "limit", q.Limit)
+	return values
+}
+
+type AccessControlGroupRequest struct {
+	Name                        *string                             `json:"name"`
+	AuthMode                    *string                             `json:"authMode"`
+	TwoPersonWindowSeconds      *int                                `json:"twoPersonWindowSeconds"`
+	HolidayCalendarID           *string                             `json:"holidayCalendarId"`
+	AccessControlDeviceIDs      []string                            `json:"accessControlDeviceIds"`
+	LocationIDs                 []string                            `json:"locationIds"`
+	AccessControlGroupSchedules []AccessControlGroupScheduleRequest `json:"accessControlSchedules"`
+}
+
+type AccessControlGroupResponse struct {
+	ID                          string                               `json:"id"`
+	Name                        string                               `json:"name"`
+	AuthMode                    string                               `json:"authMode"`
+	TwoPersonWindowSeconds      int                                  `json:"twoPersonWindowSeconds"`
+	HolidayCalendar             *HolidayCalendarInfoResponse         `json:"holidayCalendar"`
+	AccessControlDevices        []AccessControlDeviceInfoResponse    `json:"accessControlDevices"`
+	Locations                   []LocationInfoResponse               `json:"locations"`
+	AccessControlGroupSchedules []AccessControlGroupScheduleResponse `json:"accessControlSchedules"`
+}
+
+type AccessControlGroupSearchQuery struct {
+	Name  string `form:"name"`
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+}
+
+func (q *AccessControlGroupSearchQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "name", q.Name)
+	addValue(values, "page", q.Page)
+	addValue(values, "limit", q.Limit)
+	return values
+}
+
+type AccessControlRuleRequest struct {
+	Name                  string   `json:"name"`
+	AccessControlGroupIDs []string `json:"accessControlGroupIds"`
+}
+
+type AccessControlRuleResponse struct {
+	ID                  string                           `json:"id"`
+	Name                string                           `json:"name"`
+	AccessControlGroups []AccessControlGroupInfoResponse `json:"accessControlGroups"`
+}
+
+type AccessControlRuleSearchQuery struct {
+	Name  string `form:"name"`
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+	All   bool   `form:"all"`
+}
+
+func (q *AccessControlRuleSearchQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "name", q.Name)
+	addValue(values, "page", q.Page)
+	addValue(values, "limit", q.Limit)
+	addValue(values, "all", q.All)
+	return values
+}
+
+type AccessControlServerRequest struct {
+	Name        *string `json:"name"`
+	Type        *string `json:"type"`
+	HostAddress *string `json:"hostAddress"`
+	Username    *string `json:"username"`
+	Password    *string `json:"password"`
+	AccessToken *string `json:"accessToken"`
+	ApiToken    *string `json:"apiToken"`
+	Status      *string `json:"status"`
+}
+
+type AccessControlServerResponse struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Type        string  `json:"type"`
+	HostAddress string  `json:"hostAddress"`
+	Username    *string `json:"username"`
+	Password    *string `json:"password"`
+	AccessToken *string `json:"accessToken"`
+	ApiToken    *string `json:"apiToken"`
+	Status      string  `json:"status"`
+}
+
+type AccessControlServerSearchQuery struct {
+	Name        string `form:"name"`
+	Type        string `form:"type"`
+	HostAddress string `form:"hostAddress"`
+	Page        int    `form:"page"`
+	Limit       int    `form:"limit"`
+}
+
+func (q *AccessControlServerSearchQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "name", q.Name)
+	addValue(values, "type", q.Type)
+	addValue(values, "hostAddress", q.HostAddress)
+	addValue(values, "page", q.Page)
+	addValue(values, "limit", q.Limit)
+	return values
+}
+
+type AccessDecisionRequest struct {
+	AccessControlDeviceID *string `json:"accessControlDeviceId"`
+	Type                  *string `json:"type"`
+	SessionID             *string `json:"sessionId"`
+	CardNumber            *string `json:"cardNumber"`
+	FacePersonID          *string `json:"facePersonId"`
+	PIN                   *string `json:"pin"`
+	AccessTime            *string `json:"accessTime"`
+}
+
+type AccessDecisionResponse struct {
+	Granted          bool                `json:"granted"`
+	Reason           string              `json:"reason"`
+	Person           *PersonInfoResponse `json:"person"`
+	AccessRecordID   string              `json:"accessRecordId"`
+	Pending          bool                `json:"pending"`
+	SessionID        *string             `json:"sessionId,omitempty"`
+	Factors          []string            `json:"factors"`
+	MatchedPlateText *string             `json:"matchedPlateText,omitempty"`
+	VisitorVehicleID *string             `json:"visitorVehicleId,omitempty"`
+}
+
+type AccessRecordAnnotationRequest struct {
+	Field *string `json:"field"`
+	Value *string `json:"value"`
+	Note  *string `json:"note"`
+}
+
+type AccessRecordAnnotationResponse struct {
+	ID        string  `json:"id"`
+	Field     *string `json:"field"`
+	Value     *string `json:"value"`
+	Note      string  `json:"note"`
+	CreatedBy string  `json:"createdBy"`
+	CreatedAt string  `json:"createdAt"`
+}
+
+type AccessRecordChainResponse struct {
+	Valid                 bool                     `json:"valid"`
+	PurgedThroughSequence int64                    `json:"purgedThroughSequence"`
+	Records               int64                    `json:"records"`
+	LastSequence          int64                    `json:"lastSequence"`
+	LastHash              string                   `json:"lastHash"`
+	UnchainedRecords      int64                    `json:"unchainedRecords"`
+	IssueCount            int                      `json:"issueCount"`
+	Issues                []AccessRecordChainIssue `json:"issues"`
+	VerifiedAt            string                   `json:"verifiedAt"`
+}
+
+type AccessRecordRequest struct {
+	PersonID              *string `json:"personId"`
+	AccessControlDeviceID *string `json:"accessControlDeviceId"`
+	Type                  *string `json:"type"`
+	Result                *string `json:"result"`
+	AccessTime            *string `json:"accessTime"`
+}
+
+type AccessRecordResponse struct {
+	ID                  string                           `json:"id"`
+	Person              *AccessRecordPersonResponse      `json:"person"`
+	AccessControlDevice *AccessRecordDeviceResponse      `json:"accessControlDevice"`
+	Type                string                           `json:"type"`
+	Result              string                           `json:"result"`
+	AccessTime          string                           `json:"accessTime"`
+	CardNumber          *string                          `json:"cardNumber"`
+	PlateText           *string                          `json:"plateText"`
+	PlateConfidence     *float64                         `json:"plateConfidence"`
+	PlateImageURL       string                           `json:"plateImageUrl"`
+	Reason              *string                          `json:"reason"`
+	Factors             []string                         `json:"factors"`
+	ScanSessionID       *string                          `json:"scanSessionId"`
+	Sequence            int64                            `json:"sequence"`
+	Hash                string                           `json:"hash"`
+	Annotations         []AccessRecordAnnotationResponse `json:"annotations"`
+}
+
+type AccessRecordSearchQuery struct {
+	PersonID              string `form:"personId"`
+	AccessControlDeviceID string `form:"accessControlDeviceId"`
+	Type                  string `form:"type"`
+	Result                string `form:"result"`
+	AccessTime            string `form:"accessTime"`
+	LocationID            string `form:"locationId"`
+	Page                  int    `form:"page"`
+	Limit                 int    `form:"limit"`
+}
+
+func (q *AccessRecordSearchQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "personId", q.PersonID)
+	addValue(values, "accessControlDeviceId", q.AccessControlDeviceID)
+	addValue(values, "type", q.Type)
+	addValue(values, "result", q.Result)
+	addValue(values, "accessTime", q.AccessTime)
+	addValue(values, "locationId", q.LocationID)
+	addValue(values, "page", q.Page)
+	addValue(values, "limit", q.Limit)
+	return values
+}
+
+type AttendanceCalculateRequest struct {
+	PersonID  *string `json:"personId"`
+	StartDate *string `json:"startDate"`
+	EndDate   *string `json:"endDate"`
+}
+
+type AttendanceCorrectionRequest struct {
+	PersonID *string `json:"personId"`
+	Date     *string `json:"date"`
+	Type     *string `json:"type"`
+	Time     *string `json:"time"`
+	Reason   *string `json:"reason"`
+}
+
+type AttendanceCorrectionResponse struct {
+	ID           string              `json:"id"`
+	Person       *PersonInfoResponse `json:"person"`
+	Date         string              `json:"date"`
+	Type         string              `json:"type"`
+	Time         string              `json:"time"`
+	Reason       string              `json:"reason"`
+	Status       string              `json:"status"`
+	RequestedBy  *string             `json:"requestedBy"`
+	DecidedBy    *string             `json:"decidedBy"`
+	DecidedAt    *string             `json:"decidedAt"`
+	DecisionNote *string             `json:"decisionNote"`
+}
+
+type AttendanceCorrectionSearchQuery struct {
+	PersonID  string `form:"personId"`
+	Status    string `form:"status"`
+	StartDate string `form:"startDate"`
+	EndDate   string `form:"endDate"`
+	Page      int    `form:"page"`
+	Limit     int    `form:"limit"`
+}
+
+func (q *AttendanceCorrectionSearchQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "personId", q.PersonID)
+	addValue(values, "status", q.Status)
+	addValue(values, "startDate", q.StartDate)
+	addValue(values, "endDate", q.EndDate)
+	addValue(values, "page", q.Page)
+	addValue(values, "limit", q.Limit)
+	return values
+}
+
+type AttendanceInfoResponse struct {
+	ID                  string                       `json:"id"`
+	Name                string                       `json:"name"`
+	HolidayCalendar     *HolidayCalendarInfoResponse `json:"holidayCalendar"`
+	OvertimeRule        *OvertimeRuleInfoResponse    `json:"overtimeRule"`
+	AttendanceSchedules []AttendanceScheduleResponse `json:"attendanceSchedules"`
+}
+
+type AttendanceRecordResponse struct {
+	ID                string                      `json:"id"`
+	Person            *PersonInfoResponse         `json:"person"`
+	Date              string                      `json:"date"`
+	Status            string                      `json:"status"`
+	HolidayName       *string                     `json:"holidayName"`
+	ScheduleStartTime *string                     `json:"scheduleStartTime"`
+	ScheduleEndTime   *string                     `json:"scheduleEndTime"`
+	ShiftTemplateID   *string                     `json:"shiftTemplateId"`
+	ShiftName         *string                     `json:"shiftName"`
+	LeaveTypeName     *string                     `json:"leaveTypeName"`
+	LeaveDays         float64                     `json:"leaveDays"`
+	EndsNextDay       bool                        `json:"endsNextDay"`
+	CheckInAt         *string                     `json:"checkInAt"`
+	CheckOutAt        *string                     `json:"checkOutAt"`
+	CheckInCorrected  bool                        `json:"checkInCorrected"`
+	CheckOutCorrected bool                        `json:"checkOutCorrected"`
+	LateMinutes       int                         `json:"lateMinutes"`
+	EarlyLeaveMinutes int                         `json:"earlyLeaveMinutes"`
+	WorkedMinutes     int                         `json:"workedMinutes"`
+	Overtime          *AttendanceOvertimeResponse `json:"overtime"`
+}
+
+type AttendanceRecordSearchQuery struct {
+	PersonID       string `form:"personId"`
+	StartDate      string `form:"startDate"`
+	EndDate        string `form:"endDate"`
+	Status         string `form:"status"`
+	OvertimeStatus string `form:"overtimeStatus"`
+	Page           int    `form:"page"`
+	Limit          int    `form:"limit"`
+	All            bool   `form:"all"`
+}
+
+func (q *AttendanceRecordSearchQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "personId", q.PersonID)
+	addValue(values, "startDate", q.StartDate)
+	addValue(values, "endDate", q.EndDate)
+	addValue(values, "status", q.Status)
+	addValue(values, "overtimeStatus", q.OvertimeStatus)
+	addValue(values, "page", q.Page)
+	addValue(values, "limit", q.Limit)
+	addValue(values, "all", q.All)
+	return values
+}
+
+type AttendanceRequest struct {
+	Name               *string                     `json:"name"`
+	HolidayCalendarID  *string                     `json:"holidayCalendarId"`
+	OvertimeRuleID     *string                     `json:"overtimeRuleId"`
+	AttendanceSchedule []AttendanceScheduleRequest `json:"attendanceSchedules"`
+}
+
+type AttendanceSearchQuery struct {
+	Name  string `form:"name"`
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+}
+
+func (q *AttendanceSearchQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "name", q.Name)
+	addValue(values, "page", q.Page)
+	addValue(values, "limit", q.Limit)
+	return values
+}
+
+type AttendanceSummaryQuery struct {
+	PersonID  string `form:"personId"`
+	StartDate string `form:"startDate"`
+	EndDate   string `form:"endDate"`
+}
+
+func (q *AttendanceSummaryQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "personId", q.PersonID)
+	addValue(values, "startDate", q.StartDate)
+	addValue(values, "endDate", q.EndDate)
+	return values
+}
+
+type AttendanceSummaryResponse struct {
+	Person                   *PersonInfoResponse `json:"person"`
+	Days                     int                 `json:"days"`
+	Present                  int                 `json:"present"`
+	Late                     int                 `json:"late"`
+	Incomplete               int                 `json:"incomplete"`
+	Absent                   int                 `json:"absent"`
+	Leave                    int                 `json:"leave"`
+	Holiday                  int                 `json:"holiday"`
+	DayOff                   int                 `json:"dayOff"`
+	LeaveDays                float64             `json:"leaveDays"`
+	LeaveDaysByType          map[string]float64  `json:"leaveDaysByType"`
+	LateMinutes              int                 `json:"lateMinutes"`
+	EarlyLeaveMinutes        int                 `json:"earlyLeaveMinutes"`
+	WorkedMinutes            int                 `json:"workedMinutes"`
+	CorrectedDays            int                 `json:"correctedDays"`
+	OvertimeMinutes          int                 `json:"overtimeMinutes"`
+	PendingOvertimeMinutes   int                 `json:"pendingOvertimeMinutes"`
+	OvertimeMinutesByDayType map[string]int      `json:"overtimeMinutesByDayType"`
+	WeightedOvertimeHours    float64             `json:"weightedOvertimeHours"`
+}
+
+type AuditLogResponse struct {
+	ID         string  `json:"id"`
+	Action     string  `json:"action"`
+	EntityType string  `json:"entityType"`
+	EntityID   *string `json:"entityId"`
+	Username   string  `json:"username"`
+	Detail     *string `json:"detail"`
+	CreatedAt  string  `json:"createdAt"`
+}
+
+type AuditLogSearchQuery struct {
+	Action     string `form:"action"`
+	EntityType string `form:"entityType"`
+	EntityID   string `form:"entityId"`
+	Username   string `form:"username"`
+	Page       int    `form:"page"`
+	Limit      int    `form:"limit"`
+}
+
+func (q *AuditLogSearchQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "action", q.Action)
+	addValue(values, "entityType", q.EntityType)
+	addValue(values, "entityId", q.EntityID)
+	addValue(values, "username", q.Username)
+	addValue(values, "page", q.Page)
+	addValue(values, "limit", q.Limit)
+	return values
+}
+
+type DataSubjectEraseRequest struct {
+	Reason *string `json:"reason"`
+}
+
+type DataSubjectExportQuery struct {
+	Reason string `form:"reason"`
+}
+
+func (q *DataSubjectExportQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "reason", q.Reason)
+	return values
+}
+
+type DecisionRequest struct {
+	Note *string `json:"note"`
+}
+
+type HolidayCalendarImportQuery struct {
+	Replace bool `form:"replace"`
+}
+
+func (q *HolidayCalendarImportQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "replace", q.Replace)
+	return values
+}
+
+type HolidayCalendarImportResponse struct {
+	Imported int                      `json:"imported"`
+	Calendar *HolidayCalendarResponse `json:"calendar"`
+}
+
+type HolidayCalendarRequest struct {
+	Name        *string          `json:"name"`
+	Description *string          `json:"description"`
+	Holidays    []HolidayRequest `json:"holidays"`
+}
+
+type HolidayCalendarResponse struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description *string           `json:"description"`
+	Holidays    []HolidayResponse `json:"holidays"`
+}
+
+type HolidayCalendarSearchQuery struct {
+	Name  string `form:"name"`
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+}
+
+func (q *HolidayCalendarSearchQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "name", q.Name)
+	addValue(values, "page", q.Page)
+	addValue(values, "limit", q.Limit)
+	return values
+}
+
+type LeaveBalanceQuery struct {
+	Year string `form:"year"`
+}
+
+func (q *LeaveBalanceQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "year", q.Year)
+	return values
+}
+
+type LeaveBalanceRequest struct {
+	LeaveTypeID  *string  `json:"leaveTypeId"`
+	Year         *int     `json:"year"`
+	EntitledDays *float64 `json:"entitledDays"`
+}
+
+type LeaveBalanceResponse struct {
+	LeaveType     *LeaveTypeInfoResponse `json:"leaveType"`
+	Year          int                    `json:"year"`
+	EntitledDays  float64                `json:"entitledDays"`
+	UsedDays      float64                `json:"usedDays"`
+	PendingDays   float64                `json:"pendingDays"`
+	RemainingDays float64                `json:"remainingDays"`
+}
+
+type LeaveRequestRequest struct {
+	PersonID    *string `json:"personId"`
+	LeaveTypeID *string `json:"leaveTypeId"`
+	StartDate   *string `json:"startDate"`
+	EndDate     *string `json:"endDate"`
+	HalfDay     *string `json:"halfDay"`
+	Reason      *string `json:"reason"`
+}
+
+type LeaveRequestResponse struct {
+	ID           string                 `json:"id"`
+	Person       *PersonInfoResponse    `json:"person"`
+	LeaveType    *LeaveTypeInfoResponse `json:"leaveType"`
+	StartDate    string                 `json:"startDate"`
+	EndDate      string                 `json:"endDate"`
+	HalfDay      *string                `json:"halfDay"`
+	Days         float64                `json:"days"`
+	Reason       *string                `json:"reason"`
+	Status       string                 `json:"status"`
+	RequestedBy  *string                `json:"requestedBy"`
+	DecidedBy    *string                `json:"decidedBy"`
+	DecidedAt    *string                `json:"decidedAt"`
+	DecisionNote *string                `json:"decisionNote"`
+}
+
+type LeaveRequestSearchQuery struct {
+	PersonID    string `form:"personId"`
+	LeaveTypeID string `form:"leaveTypeId"`
+	Status      string `form:"status"`
+	StartDate   string `form:"startDate"`
+	EndDate     string `form:"endDate"`
+	Page        int    `form:"page"`
+	Limit       int    `form:"limit"`
+}
+
+func (q *LeaveRequestSearchQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "personId", q.PersonID)
+	addValue(values, "leaveTypeId", q.LeaveTypeID)
+	addValue(values, "status", q.Status)
+	addValue(values, "startDate", q.StartDate)
+	addValue(values, "endDate", q.EndDate)
+	addValue(values, "page", q.Page)
+	addValue(values, "limit", q.Limit)
+	return values
+}
+
+type LeaveTypeRequest struct {
+	Name         *string  `json:"name"`
+	Description  *string  `json:"description"`
+	DaysPerYear  *float64 `json:"daysPerYear"`
+	AllowHalfDay *bool    `json:"allowHalfDay"`
+}
+
+type LeaveTypeResponse struct {
+	ID           string  `json:"id"`
+	Name         string  `json:"name"`
+	Description  *string `json:"description"`
+	DaysPerYear  float64 `json:"daysPerYear"`
+	AllowHalfDay bool    `json:"allowHalfDay"`
+}
+
+type LeaveTypeSearchQuery struct {
+	Name  string `form:"name"`
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+}
+
+func (q *LeaveTypeSearchQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "name", q.Name)
+	addValue(values, "page", q.Page)
+	addValue(values, "limit", q.Limit)
+	return values
+}
+
+type LicensePlateEventRequest struct {
+	AccessControlDeviceID *string  `form:"accessControlDeviceId"`
+	PlateText             *string  `form:"plateText"`
+	Confidence            *float64 `form:"confidence"`
+	Type                  *string  `form:"type"`
+	AccessTime            *string  `form:"accessTime"`
+}
+
+func (q *LicensePlateEventRequest) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "accessControlDeviceId", q.AccessControlDeviceID)
+	addValue(values, "plateText", q.PlateText)
+	addValue(values, "confidence", q.Confidence)
+	addValue(values, "type", q.Type)
+	addValue(values, "accessTime", q.AccessTime)
+	return values
+}
+
+type LocationOccupancyQuery struct {
+	Since string `form:"since"`
+}
+
+func (q *LocationOccupancyQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "since", q.Since)
+	return values
+}
+
+type LocationOccupancyResponse struct {
+	Location  LocationInfoResponse       `json:"location"`
+	Since     string                     `json:"since"`
+	Occupancy int                        `json:"occupancy"`
+	People    []LocationOccupantResponse `json:"people"`
+}
+
+type LocationRequest struct {
+	Name        *string `json:"name"`
+	Type        *string `json:"type"`
+	ParentID    *string `json:"parentId"`
+	Description *string `json:"description"`
+	TimeZone    *string `json:"timeZone"`
+}
+
+type LocationResponse struct {
+	ID          string                `json:"id"`
+	Name        string                `json:"name"`
+	Type        string                `json:"type"`
+	Path        string                `json:"path"`
+	Parent      *LocationInfoResponse `json:"parent"`
+	Description *string               `json:"description"`
+	TimeZone    *string               `json:"timeZone"`
+}
+
+type LocationSearchQuery struct {
+	Name     string `form:"name"`
+	Type     string `form:"type"`
+	ParentID string `form:"parentId"`
+	Page     int    `form:"page"`
+	Limit    int    `form:"limit"`
+}
+
+func (q *LocationSearchQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "name", q.Name)
+	addValue(values, "type", q.Type)
+	addValue(values, "parentId", q.ParentID)
+	addValue(values, "page", q.Page)
+	addValue(values, "limit", q.Limit)
+	return values
+}
+
+type LocationTreeResponse struct {
+	ID                   string                            `json:"id"`
+	Name                 string                            `json:"name"`
+	Type                 string                            `json:"type"`
+	AccessControlDevices []AccessControlDeviceInfoResponse `json:"accessControlDevices"`
+	Children             []LocationTreeResponse            `json:"children"`
+}
+
+type LoginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type OvertimeDecisionRequest struct {
+	Minutes *int    `json:"minutes"`
+	Note    *string `json:"note"`
+}
+
+type OvertimeRuleRequest struct {
+	Name              *string  `json:"name"`
+	MinimumMinutes    *int     `json:"minimumMinutes"`
+	RoundingMinutes   *int     `json:"roundingMinutes"`
+	DailyCapMinutes   *int     `json:"dailyCapMinutes"`
+	WeekendDays       *string  `json:"weekendDays"`
+	WeekdayMultiplier *float64 `json:"weekdayMultiplier"`
+	WeekendMultiplier *float64 `json:"weekendMultiplier"`
+	HolidayMultiplier *float64 `json:"holidayMultiplier"`
+}
+
+type OvertimeRuleResponse struct {
+	ID                string  `json:"id"`
+	Name              string  `json:"name"`
+	MinimumMinutes    int     `json:"minimumMinutes"`
+	RoundingMinutes   int     `json:"roundingMinutes"`
+	DailyCapMinutes   int     `json:"dailyCapMinutes"`
+	WeekendDays       string  `json:"weekendDays"`
+	WeekdayMultiplier float64 `json:"weekdayMultiplier"`
+	WeekendMultiplier float64 `json:"weekendMultiplier"`
+	HolidayMultiplier float64 `json:"holidayMultiplier"`
+}
+
+type OvertimeRuleSearchQuery struct {
+	Name  string `form:"name"`
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+}
+
+func (q *OvertimeRuleSearchQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "name", q.Name)
+	addValue(values, "page", q.Page)
+	addValue(values, "limit", q.Limit)
+	return values
+}
+
+type PageResponse struct {
+	Page      int `json:"page"`
+	Size      int `json:"size"`
+	Total     int `json:"total"`
+	TotalPage int `json:"totalPage"`
+}
+
+type PayrollExportChangeResponse struct {
+	Person   *PersonInfoResponse    `json:"person"`
+	Exported *PayrollTotalsResponse `json:"exported"`
+	Current  *PayrollTotalsResponse `json:"current"`
+}
+
+type PayrollExportRequest struct {
+	TemplateID *string `json:"templateId"`
+	StartDate  *string `json:"startDate"`
+	EndDate    *string `json:"endDate"`
+	Company    *string `json:"company"`
+}
+
+type PayrollExportResponse struct {
+	ID               string  `json:"id"`
+	TemplateID       string  `json:"templateId"`
+	TemplateName     string  `json:"templateName"`
+	Company          *string `json:"company"`
+	StartDate        string  `json:"startDate"`
+	EndDate          string  `json:"endDate"`
+	FileName         string  `json:"fileName"`
+	Rows             int     `json:"rows"`
+	Status           string  `json:"status"`
+	GeneratedBy      string  `json:"generatedBy"`
+	GeneratedAt      string  `json:"generatedAt"`
+	LockedBy         *string `json:"lockedBy"`
+	LockedAt         *string `json:"lockedAt"`
+	ChangedAfterLock bool    `json:"changedAfterLock"`
+	ChangedAt        *string `json:"changedAt"`
+}
+
+type PayrollExportSearchQuery struct {
+	TemplateID string `form:"templateId"`
+	Company    string `form:"company"`
+	Status     string `form:"status"`
+	Page       int    `form:"page"`
+	Limit      int    `form:"limit"`
+}
+
+func (q *PayrollExportSearchQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "templateId", q.TemplateID)
+	addValue(values, "company", q.Company)
+	addValue(values, "status", q.Status)
+	addValue(values, "page", q.Page)
+	addValue(values, "limit", q.Limit)
+	return values
+}
+
+type PayrollExportTemplateRequest struct {
+	Name          *string                      `json:"name"`
+	Company       *string                      `json:"company"`
+	Format        *string                      `json:"format"`
+	Delimiter     *string                      `json:"delimiter"`
+	DateFormat    *string                      `json:"dateFormat"`
+	IncludeHeader *bool                        `json:"includeHeader"`
+	Columns       []PayrollExportColumnRequest `json:"columns"`
+}
+
+type PayrollExportTemplateResponse struct {
+	ID            string                        `json:"id"`
+	Name          string                        `json:"name"`
+	Company       *string                       `json:"company"`
+	Format        string                        `json:"format"`
+	Delimiter     string                        `json:"delimiter"`
+	DateFormat    string                        `json:"dateFormat"`
+	IncludeHeader bool                          `json:"includeHeader"`
+	Columns       []PayrollExportColumnResponse `json:"columns"`
+}
+
+type PayrollExportTemplateSearchQuery struct {
+	Name    string `form:"name"`
+	Company string `form:"company"`
+	Page    int    `form:"page"`
+	Limit   int    `form:"limit"`
+}
+
+func (q *PayrollExportTemplateSearchQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "name", q.Name)
+	addValue(values, "company", q.Company)
+	addValue(values, "page", q.Page)
+	addValue(values, "limit", q.Limit)
+	return values
+}
+
+type PersonCardHistoryResponse struct {
+	ID         string  `json:"id"`
+	CardID     string  `json:"cardId"`
+	CardNumber string  `json:"cardNumber"`
+	Action     string  `json:"action"`
+	Status     string  `json:"status"`
+	Reason     *string `json:"reason"`
+	ActiveAt   *string `json:"activeAt"`
+	ExpireAt   *string `json:"expireAt"`
+	CreatedAt  string  `json:"createdAt"`
+}
+
+type PersonCardRequest struct {
+	CardNumber *string `json:"cardNumber"`
+	ActiveAt   *string `json:"activeAt"`
+	ExpireAt   *string `json:"expireAt"`
+	Reason     *string `json:"reason"`
+}
+
+type PersonCardResponse struct {
+	ID         string  `json:"id"`
+	CardNumber string  `json:"cardNumber"`
+	PersonID   string  `json:"personId"`
+	Status     string  `json:"status"`
+	Reason     *string `json:"reason"`
+	ActiveAt   *string `json:"activeAt"`
+	ExpireAt   *string `json:"expireAt"`
+	CreatedAt  string  `json:"createdAt"`
+}
+
+type PersonCardStatusRequest struct {
+	Status *string `json:"status"`
+	Reason *string `json:"reason"`
+}
+
+type PersonCardValidityRequest struct {
+	ActiveAt *string `json:"activeAt"`
+	ExpireAt *string `json:"expireAt"`
+}
+
+type PersonExportQuery struct {
+	FirstName    string `form:"firstName"`
+	LastName     string `form:"lastName"`
+	Company      string `form:"company"`
+	Department   string `form:"department"`
+	JobPosition  string `form:"jobPosition"`
+	MobileNumber string `form:"mobileNumber"`
+	Email        string `form:"email"`
+	Page         int    `form:"page"`
+	Limit        int    `form:"limit"`
+	All          bool   `form:"all"`
+	Format       string `form:"format"`
+}
+
+func (q *PersonExportQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "firstName", q.FirstName)
+	addValue(values, "lastName", q.LastName)
+	addValue(values, "company", q.Company)
+	addValue(values, "department", q.Department)
+	addValue(values, "jobPosition", q.JobPosition)
+	addValue(values, "mobileNumber", q.MobileNumber)
+	addValue(values, "email", q.Email)
+	addValue(values, "page", q.Page)
+	addValue(values, "limit", q.Limit)
+	addValue(values, "all", q.All)
+	addValue(values, "format", q.Format)
+	return values
+}
+
+type PersonFaceImageImportQuery struct {
+	MatchBy string `form:"matchBy"`
+}
+
+func (q *PersonFaceImageImportQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "matchBy", q.MatchBy)
+	return values
+}
+
+type PersonFaceImageImportResult struct {
+	MatchBy       string                            `json:"matchBy"`
+	TotalFiles    int                               `json:"totalFiles"`
+	ImportedFiles int                               `json:"importedFiles"`
+	FailedFiles   int                               `json:"failedFiles"`
+	Files         []PersonFaceImageImportFileResult `json:"files"`
+}
+
+type PersonImportQuery struct {
+	DryRun bool `form:"dryRun"`
+}
+
+func (q *PersonImportQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "dryRun", q.DryRun)
+	return values
+}
+
+type PersonImportResult struct {
+	DryRun       bool                   `json:"dryRun"`
+	TotalRows    int                    `json:"totalRows"`
+	ValidRows    int                    `json:"validRows"`
+	ImportedRows int                    `json:"importedRows"`
+	Errors       []PersonImportRowError `json:"errors"`
+}
+
+type PersonPINRequest struct {
+	PIN *string `json:"pin"`
+}
+
+type PersonRequest struct {
+	FirstName           *string  `form:"firstName"`
+	MiddleName          *string  `form:"middleName"`
+	LastName            *string  `form:"lastName"`
+	PersonType          *string  `form:"personType"`
+	PersonID            *string  `form:"personId"`
+	Gender              *string  `form:"gender"`
+	DateOfBirth         *string  `form:"dateOfBirth"`
+	Company             *string  `form:"company"`
+	Department          *string  `form:"department"`
+	JobPosition         *string  `form:"jobPosition"`
+	Address             *string  `form:"address"`
+	MobileNumber        *string  `form:"mobileNumber"`
+	Email               *string  `form:"email"`
+	IsVerified          *bool    `form:"isVerified"`
+	ActiveAt            *string  `form:"activeAt"`
+	ExpireAt            *string  `form:"expireAt"`
+	CardIDs             []string `form:"cardIds"`
+	LicensePlateTexts   []string `form:"licensePlateTexts"`
+	AccessControlRuleID *string  `form:"accessControlRuleId"`
+	TimeAttendanceID    *string  `form:"timeAttendanceId"`
+}
+
+func (q *PersonRequest) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "firstName", q.FirstName)
+	addValue(values, "middleName", q.MiddleName)
+	addValue(values, "lastName", q.LastName)
+	addValue(values, "personType", q.PersonType)
+	addValue(values, "personId", q.PersonID)
+	addValue(values, "gender", q.Gender)
+	addValue(values, "dateOfBirth", q.DateOfBirth)
+	addValue(values, "company", q.Company)
+	addValue(values, "department", q.Department)
+	addValue(values, "jobPosition", q.JobPosition)
+	addValue(values, "address", q.Address)
+	addValue(values, "mobileNumber", q.MobileNumber)
+	addValue(values, "email", q.Email)
+	addValue(values, "isVerified", q.IsVerified)
+	addValue(values, "activeAt", q.ActiveAt)
+	addValue(values, "expireAt", q.ExpireAt)
+	addValue(values, "cardIds", q.CardIDs)
+	addValue(values, "licensePlateTexts", q.LicensePlateTexts)
+	addValue(values, "accessControlRuleId", q.AccessControlRuleID)
+	addValue(values, "timeAttendanceId", q.TimeAttendanceID)
+	return values
+}
+
+type PersonResponse struct {
+	ID                string                         `json:"id"`
+	FirstName         string                         `json:"firstName"`
+	MiddleName        *string                        `json:"middleName"`
+	LastName          string                         `json:"lastName"`
+	PersonType        string                         `json:"personType"`
+	PersonID          *string                        `json:"personId"`
+	Gender            *string                        `json:"gender"`
+	DateOfBirth       *time.Time                     `json:"dateOfBirth"`
+	Company           *string                        `json:"company"`
+	Department        *string                        `json:"department"`
+	JobPosition       *string                        `json:"jobPosition"`
+	Address           *string                        `json:"address"`
+	MobileNumber      *string                        `json:"mobileNumber"`
+	Email             *string                        `json:"email"`
+	IsVerified        bool                           `json:"isVerified"`
+	CardIDs           []string                       `json:"cardIds"`
+	LicensePlateTexts []string                       `json:"licensePlateTexts"`
+	FaceImage         *PersonFaceImageResponse       `json:"faceImage"`
+	HasPIN            bool                           `json:"hasPin"`
+	ActiveAt          *time.Time                     `json:"activeAt"`
+	ExpireAt          *time.Time                     `json:"expireAt"`
+	AccessControlRule *AccessControlRuleInfoResponse `json:"accessControlRule"`
+	TimeAttendance    *AttendanceInfoResponse        `json:"timeAttendance"`
+	ErasedAt          *time.Time                     `json:"erasedAt"`
+}
+
+type PersonSearchQuery struct {
+	FirstName    string `form:"firstName"`
+	LastName     string `form:"lastName"`
+	Company      string `form:"company"`
+	Department   string `form:"department"`
+	JobPosition  string `form:"jobPosition"`
+	MobileNumber string `form:"mobileNumber"`
+	Email        string `form:"email"`
+	Page         int    `form:"page"`
+	Limit        int    `form:"limit"`
+	All          bool   `form:"all"`
+}
+
+func (q *PersonSearchQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "firstName", q.FirstName)
+	addValue(values, "lastName", q.LastName)
+	addValue(values, "company", q.Company)
+	addValue(values, "department", q.Department)
+	addValue(values, "jobPosition", q.JobPosition)
+	addValue(values, "mobileNumber", q.MobileNumber)
+	addValue(values, "email", q.Email)
+	addValue(values, "page", q.Page)
+	addValue(values, "limit", q.Limit)
+	addValue(values, "all", q.All)
+	return values
+}
+
+type PersonShiftAssignmentRequest struct {
+	ShiftRotationID *string `json:"shiftRotationId"`
+	StartDate       *string `json:"startDate"`
+	EndDate         *string `json:"endDate"`
+}
+
+type PersonShiftAssignmentResponse struct {
+	ID            string                     `json:"id"`
+	PersonID      string                     `json:"personId"`
+	ShiftRotation *ShiftRotationInfoResponse `json:"shiftRotation"`
+	StartDate     string                     `json:"startDate"`
+	EndDate       *string                    `json:"endDate"`
+}
+
+type PersonShiftOverrideRequest struct {
+	Date            *string `json:"date"`
+	ShiftTemplateID *string `json:"shiftTemplateId"`
+	Reason          *string `json:"reason"`
+}
+
+type PersonShiftOverrideResponse struct {
+	ID            string                     `json:"id"`
+	PersonID      string                     `json:"personId"`
+	Date          string                     `json:"date"`
+	ShiftTemplate *ShiftTemplateInfoResponse `json:"shiftTemplate"`
+	Reason        *string                    `json:"reason"`
+}
+
+type RetentionPolicyResponse struct {
+	AccessRecordDays     int `json:"accessRecordDays"`
+	AttendanceRecordDays int `json:"attendanceRecordDays"`
+	FaceImageDays        int `json:"faceImageDays"`
+	AuditLogDays         int `json:"auditLogDays"`
+	VisitorDays          int `json:"visitorDays"`
+}
+
+type RetentionRunResponse struct {
+	ID          string                     `json:"id"`
+	Trigger     string                     `json:"trigger"`
+	TriggeredBy *string                    `json:"triggeredBy"`
+	Status      string                     `json:"status"`
+	StartedAt   string                     `json:"startedAt"`
+	FinishedAt  *string                    `json:"finishedAt"`
+	Error       *string                    `json:"error"`
+	Items       []RetentionRunItemResponse `json:"items"`
+}
+
+type RetentionRunSearchQuery struct {
+	Trigger string `form:"trigger"`
+	Status  string `form:"status"`
+	Page    int    `form:"page"`
+	Limit   int    `form:"limit"`
+}
+
+func (q *RetentionRunSearchQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "trigger", q.Trigger)
+	addValue(values, "status", q.Status)
+	addValue(values, "page", q.Page)
+	addValue(values, "limit", q.Limit)
+	return values
+}
+
+type ShiftRotationRequest struct {
+	Name       *string   `json:"name"`
+	AnchorDate *string   `json:"anchorDate"`
+	Days       []*string `json:"days"`
+}
+
+type ShiftRotationResponse struct {
+	ID         string                     `json:"id"`
+	Name       string                     `json:"name"`
+	AnchorDate string                     `json:"anchorDate"`
+	CycleDays  int                        `json:"cycleDays"`
+	Days       []ShiftRotationDayResponse `json:"days"`
+}
+
+type ShiftRotationSearchQuery struct {
+	Name  string `form:"name"`
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+}
+
+func (q *ShiftRotationSearchQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "name", q.Name)
+	addValue(values, "page", q.Page)
+	addValue(values, "limit", q.Limit)
+	return values
+}
+
+type ShiftTemplateRequest struct {
+	Name            *string `json:"name"`
+	StartTime       *string `json:"startTime"`
+	EndTime         *string `json:"endTime"`
+	EarlyInMinutes  *int    `json:"earlyInMinutes"`
+	LateInMinutes   *int    `json:"lateInMinutes"`
+	EarlyOutMinutes *int    `json:"earlyOutMinutes"`
+	LateOutMinutes  *int    `json:"lateOutMinutes"`
+}
+
+type ShiftTemplateResponse struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	StartTime       string `json:"startTime"`
+	EndTime         string `json:"endTime"`
+	CrossesMidnight bool   `json:"crossesMidnight"`
+	EarlyInMinutes  int    `json:"earlyInMinutes"`
+	LateInMinutes   int    `json:"lateInMinutes"`
+	EarlyOutMinutes int    `json:"earlyOutMinutes"`
+	LateOutMinutes  int    `json:"lateOutMinutes"`
+}
+
+type ShiftTemplateSearchQuery struct {
+	Name  string `form:"name"`
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+}
+
+func (q *ShiftTemplateSearchQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "name", q.Name)
+	addValue(values, "page", q.Page)
+	addValue(values, "limit", q.Limit)
+	return values
+}
+
+type SwitchTenantRequest struct {
+	TenantID *string `json:"tenantId"`
+}
+
+type TenantRequest struct {
+	Name     *string `json:"name"`
+	Code     *string `json:"code"`
+	IsActive *bool   `json:"isActive"`
+}
+
+type TenantResponse struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Code     string `json:"code"`
+	IsActive bool   `json:"isActive"`
+}
+
+type TenantSearchQuery struct {
+	Name  string `form:"name"`
+	Code  string `form:"code"`
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+}
+
+func (q *TenantSearchQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "name", q.Name)
+	addValue(values, "code", q.Code)
+	addValue(values, "page", q.Page)
+	addValue(values, "limit", q.Limit)
+	return values
+}
+
+type TokenResponse struct {
+	Token string `json:"token"`
+}
+
+type UserRequest struct {
+	Username   *string                `json:"username"`
+	Password   *string                `json:"password"`
+	Status     *string                `json:"status"`
+	Permission *UserPermissionRequest `json:"permission"`
+}
+
+type UserResponse struct {
+	ID           string                 `json:"id"`
+	Username     string                 `json:"username"`
+	Status       string                 `json:"status"`
+	IsSuperAdmin bool                   `json:"isSuperAdmin"`
+	Permission   UserPermissionResponse `json:"permission"`
+}
+
+type UserSearchQuery struct {
+	Username string `form:"username"`
+	Status   string `form:"status"`
+	Page     int    `form:"page"`
+	Limit    int    `form:"limit"`
+}
+
+func (q *UserSearchQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "username", q.Username)
+	addValue(values, "status", q.Status)
+	addValue(values, "page", q.Page)
+	addValue(values, "limit", q.Limit)
+	return values
+}
+
+type VisitorVehicleResponse struct {
+	ID                    string  `json:"id"`
+	PlateText             string  `json:"plateText"`
+	FirstSeenAt           string  `json:"firstSeenAt"`
+	LastSeenAt            string  `json:"lastSeenAt"`
+	SeenCount             int     `json:"seenCount"`
+	AccessControlDeviceID *string `json:"accessControlDeviceId"`
+	LastImageURL          string  `json:"lastImageUrl"`
+}
+
+type VisitorVehicleSearchQuery struct {
+	PlateText string `form:"plateText"`
+	Page      int    `form:"page"`
+	Limit     int    `form:"limit"`
+}
+
+func (q *VisitorVehicleSearchQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	addValue(values, "plateText", q.PlateText)
+	addValue(values, "page", q.Page)
+	addValue(values, "limit", q.Limit)
+	return values
+}
+
+type AccessControlDeviceInfoResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	HostAddress string `json:"hostAddress"`
+}
+
+type AccessControlGroupInfoResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type AccessControlGroupScheduleRequest struct {
+	DayOfWeek *int    `json:"dayOfWeek"`
+	Date      *string `json:"date"`
+	StartTime *string `json:"startTime"`
+	EndTime   *string `json:"endTime"`
+}
+
+type AccessControlGroupScheduleResponse struct {
+	DayOfWeek int     `json:"dayOfWeek"`
+	Date      *string `json:"date"`
+	StartTime string  `json:"startTime"`
+	EndTime   string  `json:"endTime"`
+}
+
+type AccessControlRuleInfoResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type AccessControlServerInfoResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	HostAddress string `json:"hostAddress"`
+}
+
+type AccessRecordChainIssue struct {
+	Sequence       int64  `json:"sequence"`
+	AccessRecordID string `json:"accessRecordId"`
+	Problem        string `json:"problem"`
+	Detail         string `json:"detail"`
+}
+
+type AccessRecordDeviceResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	HostAddress string `json:"hostAddress"`
+	Type        string `json:"type"`
+}
+
+type AccessRecordPersonResponse struct {
+	ID          string  `json:"id"`
+	FirstName   string  `json:"firstName"`
+	LastName    string  `json:"lastName"`
+	Company     *string `json:"company"`
+	Department  *string `json:"department"`
+	JobPosition *string `json:"jobPosition"`
+}
+
+type AttendanceOvertimeResponse struct {
+	Minutes         int     `json:"minutes"`
+	DayType         string  `json:"dayType"`
+	Multiplier      float64 `json:"multiplier"`
+	Status          string  `json:"status"`
+	ApprovedMinutes int     `json:"approvedMinutes"`
+	DecidedBy       *string `json:"decidedBy"`
+	DecidedAt       *string `json:"decidedAt"`
+	DecisionNote    *string `json:"decisionNote"`
+}
+
+type AttendanceScheduleRequest struct {
+	DayOfWeek       *int    `json:"dayOfWeek"`
+	Date            *string `json:"date"`
+	StartTime       *string `json:"startTime"`
+	EndTime         *string `json:"endTime"`
+	EarlyInMinutes  *int    `json:"earlyInMinutes"`
+	LateInMinutes   *int    `json:"lateInMinutes"`
+	EarlyOutMinutes *int    `json:"earlyOutMinutes"`
+	LateOutMinutes  *int    `json:"lateOutMinutes"`
+}
+
+type AttendanceScheduleResponse struct {
+	ID              string  `json:"id"`
+	DayOfWeek       int     `json:"dayOfWeek"`
+	Date            *string `json:"date"`
+	StartTime       string  `json:"startTime"`
+	EndTime         string  `json:"endTime"`
+	EarlyInMinutes  int     `json:"earlyInMinutes"`
+	LateInMinutes   int     `json:"lateInMinutes"`
+	EarlyOutMinutes int     `json:"earlyOutMinutes"`
+	LateOutMinutes  int     `json:"lateOutMinutes"`
+}
+
+type HolidayCalendarInfoResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type HolidayRequest struct {
+	Name      *string `json:"name"`
+	StartDate *string `json:"startDate"`
+	EndDate   *string `json:"endDate"`
+	StartTime *string `json:"startTime"`
+	EndTime   *string `json:"endTime"`
+}
+
+type HolidayResponse struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	StartDate string  `json:"startDate"`
+	EndDate   string  `json:"endDate"`
+	StartTime *string `json:"startTime"`
+	EndTime   *string `json:"endTime"`
+}
+
+type LeaveTypeInfoResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type LocationInfoResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Path string `json:"path"`
+}
+
+type LocationOccupantResponse struct {
+	Person              *AccessRecordPersonResponse `json:"person"`
+	AccessControlDevice *AccessRecordDeviceResponse `json:"accessControlDevice"`
+	EnteredAt           string                      `json:"enteredAt"`
+}
+
+type OvertimeRuleInfoResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type PayrollExportColumnRequest struct {
+	Field  *string `json:"field"`
+	Header *string `json:"header"`
+	Width  *int    `json:"width"`
+	Align  *string `json:"align"`
+}
+
+type PayrollExportColumnResponse struct {
+	Field  string `json:"field"`
+	Header string `json:"header"`
+	Width  int    `json:"width"`
+	Align  string `json:"align"`
+}
+
+type PayrollTotalsResponse struct {
+	WorkedDays            int     `json:"workedDays"`
+	WorkedMinutes         int     `json:"workedMinutes"`
+	AbsentDays            int     `json:"absentDays"`
+	LateMinutes           int     `json:"lateMinutes"`
+	EarlyLeaveMinutes     int     `json:"earlyLeaveMinutes"`
+	LeaveDays             float64 `json:"leaveDays"`
+	OvertimeMinutes       int     `json:"overtimeMinutes"`
+	WeightedOvertimeHours float64 `json:"weightedOvertimeHours"`
+}
+
+type PersonFaceImageImportFileResult struct {
+	FileName string              `json:"fileName"`
+	Person   *PersonInfoResponse `json:"person"`
+	Status   string              `json:"status"`
+	Message  string              `json:"message,omitempty"`
+}
+
+type PersonFaceImageResponse struct {
+	OriginalURL   string `json:"originalUrl"`
+	NormalizedURL string `json:"normalizedUrl"`
+	ThumbnailURL  string `json:"thumbnailUrl"`
+}
+
+type PersonImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type PersonInfoResponse struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	PersonType string `json:"personType"`
+	PersonID   string `json:"personId"`
+}
+
+type RetentionRunItemResponse struct {
+	DataClass  string  `json:"dataClass"`
+	Cutoff     string  `json:"cutoff"`
+	Purged     int     `json:"purged"`
+	ArchiveURL string  `json:"archiveUrl"`
+	Detail     *string `json:"detail"`
+}
+
+type ShiftRotationDayResponse struct {
+	DayIndex      int                        `json:"dayIndex"`
+	ShiftTemplate *ShiftTemplateInfoResponse `json:"shiftTemplate"`
+}
+
+type ShiftRotationInfoResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type ShiftTemplateInfoResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	StartTime string `json:"startTime"`
+	EndTime   string `json:"endTime"`
+}
+
+type UserPermissionRequest struct {
+	PeoplePermission         *bool `json:"peoplePermission"`
+	DevicePermission         *bool `json:"devicePermission"`
+	RulePermission           *bool `json:"rulePermission"`
+	TimeAttendancePermission *bool `json:"timeAttendancePermission"`
+	ReportPermission         *bool `json:"reportPermission"`
+	NotificationPermission   *bool `json:"notificationPermission"`
+	SystemLogPermission      *bool `json:"systemLogPermission"`
+}
+
+type UserPermissionResponse struct {
+	ID                       string `json:"id"`
+	PeoplePermission         bool   `json:"peoplePermission"`
+	DevicePermission         bool   `json:"devicePermission"`
+	RulePermission           bool   `json:"rulePermission"`
+	TimeAttendancePermission bool   `json:"timeAttendancePermission"`
+	ReportPermission         bool   `json:"reportPermission"`
+	NotificationPermission   bool   `json:"notificationPermission"`
+	SystemLogPermission      bool   `json:"systemLogPermission"`
+}
+
+// GetOpenAPIDocument calls GET /openapi.json to get this OpenAPI document.
+func (c *Client) GetOpenAPIDocument(ctx context.Context) (json.RawMessage, error) {
+	req := &request{method: http.MethodGet, path: "/openapi.json"}
+	var data json.RawMessage
+	err := c.doJSON(ctx, req, &data)
+	return data, err
+}
+
+// Login calls POST /login to log in and get an access token.
+func (c *Client) Login(ctx context.Context, body *LoginRequest) (*TokenResponse, error) {
+	req := &request{method: http.MethodPost, path: "/login"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(TokenResponse)
+	if err := c.doJSON(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ListAccessControlDevices calls GET /api/access-control-devices/ to list access control devices.
+func (c *Client) ListAccessControlDevices(ctx context.Context, query *AccessControlDeviceSearchQuery) ([]AccessControlDeviceResponse, *PageResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/access-control-devices/", query: query.values()}
+	var data []AccessControlDeviceResponse
+	page, err := c.do(ctx, req, &data)
+	return data, page, err
+}
+
+// GetAccessControlDevice calls GET /api/access-control-devices/:id to get an access control device.
+func (c *Client) GetAccessControlDevice(ctx context.Context, id string) (*AccessControlDeviceResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/access-control-devices/" + url.PathEscape(id)}
+	data := new(AccessControlDeviceResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// CreateAccessControlDevice calls POST /api/access-control-devices/ to create an access control device.
+func (c *Client) CreateAccessControlDevice(ctx context.Context, body *AccessControlDeviceRequest) (*AccessControlDeviceResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/access-control-devices/"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(AccessControlDeviceResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// UpdateAccessControlDevice calls PUT /api/access-control-devices/:id to replace an access control device.
+func (c *Client) UpdateAccessControlDevice(ctx context.Context, id string, body *AccessControlDeviceRequest) (*AccessControlDeviceResponse, error) {
+	req := &request{method: http.MethodPut, path: "/api/access-control-devices/" + url.PathEscape(id)}
+	if body != nil {
+		req.body = body
+	}
+	data := new(AccessControlDeviceResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// PatchAccessControlDevice calls PATCH /api/access-control-devices/:id to update the given fields of an access control device.
+func (c *Client) PatchAccessControlDevice(ctx context.Context, id string, body *AccessControlDeviceRequest) (*AccessControlDeviceResponse, error) {
+	req := &request{method: http.MethodPatch, path: "/api/access-control-devices/" + url.PathEscape(id)}
+	if body != nil {
+		req.body = body
+	}
+	data := new(AccessControlDeviceResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// DeleteAccessControlDevice calls DELETE /api/access-control-devices/:id to delete an access control device.
+func (c *Client) DeleteAccessControlDevice(ctx context.Context, id string) error {
+	req := &request{method: http.MethodDelete, path: "/api/access-control-devices/" + url.PathEscape(id)}
+	_, err := c.do(ctx, req, nil)
+	return err
+}
+
+// ListAccessControlGroups calls GET /api/access-control-groups/ to list access control groups.
+func (c *Client) ListAccessControlGroups(ctx context.Context, query *AccessControlGroupSearchQuery) ([]AccessControlGroupResponse, *PageResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/access-control-groups/", query: query.values()}
+	var data []AccessControlGroupResponse
+	page, err := c.do(ctx, req, &data)
+	return data, page, err
+}
+
+// GetAccessControlGroup calls GET /api/access-control-groups/:id to get an access control group.
+func (c *Client) GetAccessControlGroup(ctx context.Context, id string) (*AccessControlGroupResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/access-control-groups/" + url.PathEscape(id)}
+	data := new(AccessControlGroupResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// CreateAccessControlGroup calls POST /api/access-control-groups/ to create an access control group.
+func (c *Client) CreateAccessControlGroup(ctx context.Context, body *AccessControlGroupRequest) (*AccessControlGroupResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/access-control-groups/"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(AccessControlGroupResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// UpdateAccessControlGroup calls PUT /api/access-control-groups/:id to replace an access control group.
+func (c *Client) UpdateAccessControlGroup(ctx context.Context, id string, body *AccessControlGroupRequest) (*AccessControlGroupResponse, error) {
+	req := &request{method: http.MethodPut, path: "/api/access-control-groups/" + url.PathEscape(id)}
+	if body != nil {
+		req.body = body
+	}
+	data := new(AccessControlGroupResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// PatchAccessControlGroup calls PATCH /api/access-control-groups/:id to update the given fields of an access control group.
+func (c *Client) PatchAccessControlGroup(ctx context.Context, id string, body *AccessControlGroupRequest) (*AccessControlGroupResponse, error) {
+	req := &request{method: http.MethodPatch, path: "/api/access-control-groups/" + url.PathEscape(id)}
+	if body != nil {
+		req.body = body
+	}
+	data := new(AccessControlGroupResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// DeleteAccessControlGroup calls DELETE /api/access-control-groups/:id to delete an access control group.
+func (c *Client) DeleteAccessControlGroup(ctx context.Context, id string) error {
+	req := &request{method: http.MethodDelete, path: "/api/access-control-groups/" + url.PathEscape(id)}
+	_, err := c.do(ctx, req, nil)
+	return err
+}
+
+// ListAccessControlRules calls GET /api/access-control-rules/ to list access control rules.
+func (c *Client) ListAccessControlRules(ctx context.Context, query *AccessControlRuleSearchQuery) ([]AccessControlRuleResponse, *PageResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/access-control-rules/", query: query.values()}
+	var data []AccessControlRuleResponse
+	page, err := c.do(ctx, req, &data)
+	return data, page, err
+}
+
+// GetAccessControlRule calls GET /api/access-control-rules/:id to get an access control rule.
+func (c *Client) GetAccessControlRule(ctx context.Context, id string) (*AccessControlRuleResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/access-control-rules/" + url.PathEscape(id)}
+	data := new(AccessControlRuleResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// CreateAccessControlRule calls POST /api/access-control-rules/ to create an access control rule.
+func (c *Client) CreateAccessControlRule(ctx context.Context, body *AccessControlRuleRequest) (*AccessControlRuleResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/access-control-rules/"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(AccessControlRuleResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// UpdateAccessControlRule calls PUT /api/access-control-rules/:id to replace an access control rule.
+func (c *Client) UpdateAccessControlRule(ctx context.Context, id string, body *AccessControlRuleRequest) (*AccessControlRuleResponse, error) {
+	req := &request{method: http.MethodPut, path: "/api/access-control-rules/" + url.PathEscape(id)}
+	if body != nil {
+		req.body = body
+	}
+	data := new(AccessControlRuleResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// PatchAccessControlRule calls PATCH /api/access-control-rules/:id to update the given fields of an access control rule.
+func (c *Client) PatchAccessControlRule(ctx context.Context, id string, body *AccessControlRuleRequest) (*AccessControlRuleResponse, error) {
+	req := &request{method: http.MethodPatch, path: "/api/access-control-rules/" + url.PathEscape(id)}
+	if body != nil {
+		req.body = body
+	}
+	data := new(AccessControlRuleResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// DeleteAccessControlRule calls DELETE /api/access-control-rules/:id to delete an access control rule.
+func (c *Client) DeleteAccessControlRule(ctx context.Context, id string) error {
+	req := &request{method: http.MethodDelete, path: "/api/access-control-rules/" + url.PathEscape(id)}
+	_, err := c.do(ctx, req, nil)
+	return err
+}
+
+// ListAccessControlServers calls GET /api/access-control-servers/ to list access control servers.
+func (c *Client) ListAccessControlServers(ctx context.Context, query *AccessControlServerSearchQuery) ([]AccessControlServerResponse, *PageResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/access-control-servers/", query: query.values()}
+	var data []AccessControlServerResponse
+	page, err := c.do(ctx, req, &data)
+	return data, page, err
+}
+
+// GetAccessControlServer calls GET /api/access-control-servers/:id to get an access control server.
+func (c *Client) GetAccessControlServer(ctx context.Context, id string) (*AccessControlServerResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/access-control-servers/" + url.PathEscape(id)}
+	data := new(AccessControlServerResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// CreateAccessControlServer calls POST /api/access-control-servers/ to create an access control server.
+func (c *Client) CreateAccessControlServer(ctx context.Context, body *AccessControlServerRequest) (*AccessControlServerResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/access-control-servers/"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(AccessControlServerResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// UpdateAccessControlServer calls PUT /api/access-control-servers/:id to replace an access control server.
+func (c *Client) UpdateAccessControlServer(ctx context.Context, id string, body *AccessControlServerRequest) (*AccessControlServerResponse, error) {
+	req := &request{method: http.MethodPut, path: "/api/access-control-servers/" + url.PathEscape(id)}
+	if body != nil {
+		req.body = body
+	}
+	data := new(AccessControlServerResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// PatchAccessControlServer calls PATCH /api/access-control-servers/:id to update the given fields of an access control server.
+func (c *Client) PatchAccessControlServer(ctx context.Context, id string, body *AccessControlServerRequest) (*AccessControlServerResponse, error) {
+	req := &request{method: http.MethodPatch, path: "/api/access-control-servers/" + url.PathEscape(id)}
+	if body != nil {
+		req.body = body
+	}
+	data := new(AccessControlServerResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// DeleteAccessControlServer calls DELETE /api/access-control-servers/:id to delete an access control server.
+func (c *Client) DeleteAccessControlServer(ctx context.Context, id string) error {
+	req := &request{method: http.MethodDelete, path: "/api/access-control-servers/" + url.PathEscape(id)}
+	_, err := c.do(ctx, req, nil)
+	return err
+}
+
+// DecideAccess calls POST /api/access-decisions to decide whether the credentials presented at a device grant access.
+func (c *Client) DecideAccess(ctx context.Context, body *AccessDecisionRequest) (*AccessDecisionResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/access-decisions"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(AccessDecisionResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// DecideLicensePlate calls POST /api/lpr-events to decide whether a license plate read at a device grants access.
+func (c *Client) DecideLicensePlate(ctx context.Context, form *LicensePlateEventRequest, image *File) (*AccessDecisionResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/lpr-events", form: form.values(), files: map[string]*File{"image": image}}
+	data := new(AccessDecisionResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ListAccessRecords calls GET /api/access-records/ to list access records.
+func (c *Client) ListAccessRecords(ctx context.Context, query *AccessRecordSearchQuery) ([]AccessRecordResponse, *PageResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/access-records/", query: query.values()}
+	var data []AccessRecordResponse
+	page, err := c.do(ctx, req, &data)
+	return data, page, err
+}
+
+// VerifyAccessRecords calls GET /api/access-records/verify to verify the hash chain of the access records.
+// In a tenant only the records of the tenant are verified, each against its own hash and the hash of the record before it in the chain.
+func (c *Client) VerifyAccessRecords(ctx context.Context) (*AccessRecordChainResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/access-records/verify"}
+	data := new(AccessRecordChainResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GetAccessRecord calls GET /api/access-records/:id to get an access record.
+func (c *Client) GetAccessRecord(ctx context.Context, id string) (*AccessRecordResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/access-records/" + url.PathEscape(id)}
+	data := new(AccessRecordResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// CreateAccessRecord calls POST /api/access-records/ to create an access record.
+func (c *Client) CreateAccessRecord(ctx context.Context, body *AccessRecordRequest) (*AccessRecordResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/access-records/"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(AccessRecordResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ListAccessRecordAnnotations calls GET /api/access-records/:id/annotations to list the annotations of an access record.
+func (c *Client) ListAccessRecordAnnotations(ctx context.Context, id string) ([]AccessRecordAnnotationResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/access-records/" + url.PathEscape(id) + "/annotations"}
+	var data []AccessRecordAnnotationResponse
+	_, err := c.do(ctx, req, &data)
+	return data, err
+}
+
+// AnnotateAccessRecord calls POST /api/access-records/:id/annotations to correct or explain an access record.
+func (c *Client) AnnotateAccessRecord(ctx context.Context, id string, body *AccessRecordAnnotationRequest) (*AccessRecordAnnotationResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/access-records/" + url.PathEscape(id) + "/annotations"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(AccessRecordAnnotationResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ListAttendances calls GET /api/attendances/ to list attendances.
+func (c *Client) ListAttendances(ctx context.Context, query *AttendanceSearchQuery) ([]AttendanceInfoResponse, *PageResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/attendances/", query: query.values()}
+	var data []AttendanceInfoResponse
+	page, err := c.do(ctx, req, &data)
+	return data, page, err
+}
+
+// GetAttendance calls GET /api/attendances/:id to get an attendance.
+func (c *Client) GetAttendance(ctx context.Context, id string) (*AttendanceInfoResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/attendances/" + url.PathEscape(id)}
+	data := new(AttendanceInfoResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// CreateAttendance calls POST /api/attendances/ to create an attendance.
+func (c *Client) CreateAttendance(ctx context.Context, body *AttendanceRequest) (*AttendanceInfoResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/attendances/"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(AttendanceInfoResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// UpdateAttendance calls PUT /api/attendances/:id to replace an attendance.
+func (c *Client) UpdateAttendance(ctx context.Context, id string, body *AttendanceRequest) (*AttendanceInfoResponse, error) {
+	req := &request{method: http.MethodPut, path: "/api/attendances/" + url.PathEscape(id)}
+	if body != nil {
+		req.body = body
+	}
+	data := new(AttendanceInfoResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// PatchAttendance calls PATCH /api/attendances/:id to update the given fields of an attendance.
+func (c *Client) PatchAttendance(ctx context.Context, id string, body *AttendanceRequest) (*AttendanceInfoResponse, error) {
+	req := &request{method: http.MethodPatch, path: "/api/attendances/" + url.PathEscape(id)}
+	if body != nil {
+		req.body = body
+	}
+	data := new(AttendanceInfoResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// DeleteAttendance calls DELETE /api/attendances/:id to delete an attendance.
+func (c *Client) DeleteAttendance(ctx context.Context, id string) error {
+	req := &request{method: http.MethodDelete, path: "/api/attendances/" + url.PathEscape(id)}
+	_, err := c.do(ctx, req, nil)
+	return err
+}
+
+// ListAttendanceCorrections calls GET /api/attendance-corrections/ to list attendance corrections.
+func (c *Client) ListAttendanceCorrections(ctx context.Context, query *AttendanceCorrectionSearchQuery) ([]AttendanceCorrectionResponse, *PageResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/attendance-corrections/", query: query.values()}
+	var data []AttendanceCorrectionResponse
+	page, err := c.do(ctx, req, &data)
+	return data, page, err
+}
+
+// GetAttendanceCorrection calls GET /api/attendance-corrections/:id to get an attendance correction.
+func (c *Client) GetAttendanceCorrection(ctx context.Context, id string) (*AttendanceCorrectionResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/attendance-corrections/" + url.PathEscape(id)}
+	data := new(AttendanceCorrectionResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// CreateAttendanceCorrection calls POST /api/attendance-corrections/ to create an attendance correction.
+func (c *Client) CreateAttendanceCorrection(ctx context.Context, body *AttendanceCorrectionRequest) (*AttendanceCorrectionResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/attendance-corrections/"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(AttendanceCorrectionResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ApproveAttendanceCorrection calls POST /api/attendance-corrections/:id/approve to approve an attendance correction.
+func (c *Client) ApproveAttendanceCorrection(ctx context.Context, id string, body *DecisionRequest) (*AttendanceCorrectionResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/attendance-corrections/" + url.PathEscape(id) + "/approve"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(AttendanceCorrectionResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// RejectAttendanceCorrection calls POST /api/attendance-corrections/:id/reject to reject an attendance correction.
+func (c *Client) RejectAttendanceCorrection(ctx context.Context, id string, body *DecisionRequest) (*AttendanceCorrectionResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/attendance-corrections/" + url.PathEscape(id) + "/reject"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(AttendanceCorrectionResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// CancelAttendanceCorrection calls POST /api/attendance-corrections/:id/cancel to cancel an attendance correction.
+func (c *Client) CancelAttendanceCorrection(ctx context.Context, id string, body *DecisionRequest) (*AttendanceCorrectionResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/attendance-corrections/" + url.PathEscape(id) + "/cancel"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(AttendanceCorrectionResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ListAttendanceRecords calls GET /api/attendance-records/ to list attendance records.
+func (c *Client) ListAttendanceRecords(ctx context.Context, query *AttendanceRecordSearchQuery) ([]AttendanceRecordResponse, *PageResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/attendance-records/", query: query.values()}
+	var data []AttendanceRecordResponse
+	page, err := c.do(ctx, req, &data)
+	return data, page, err
+}
+
+// SummarizeAttendanceRecords calls GET /api/attendance-records/summary to total the attendance of a date range per person.
+func (c *Client) SummarizeAttendanceRecords(ctx context.Context, query *AttendanceSummaryQuery) ([]AttendanceSummaryResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/attendance-records/summary", query: query.values()}
+	var data []AttendanceSummaryResponse
+	_, err := c.do(ctx, req, &data)
+	return data, err
+}
+
+// CalculateAttendanceRecords calls POST /api/attendance-records/calculate to calculate the attendance of a date range.
+func (c *Client) CalculateAttendanceRecords(ctx context.Context, body *AttendanceCalculateRequest) ([]AttendanceRecordResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/attendance-records/calculate"}
+	if body != nil {
+		req.body = body
+	}
+	var data []AttendanceRecordResponse
+	_, err := c.do(ctx, req, &data)
+	return data, err
+}
+
+// ApproveOvertime calls POST /api/attendance-records/:id/overtime/approve to approve the overtime of an attendance record.
+func (c *Client) ApproveOvertime(ctx context.Context, id string, body *OvertimeDecisionRequest) (*AttendanceRecordResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/attendance-records/" + url.PathEscape(id) + "/overtime/approve"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(AttendanceRecordResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// RejectOvertime calls POST /api/attendance-records/:id/overtime/reject to reject the overtime of an attendance record.
+func (c *Client) RejectOvertime(ctx context.Context, id string, body *OvertimeDecisionRequest) (*AttendanceRecordResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/attendance-records/" + url.PathEscape(id) + "/overtime/reject"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(AttendanceRecordResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ListAuditLogs calls GET /api/audit-logs to list audit logs.
+func (c *Client) ListAuditLogs(ctx context.Context, query *AuditLogSearchQuery) ([]AuditLogResponse, *PageResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/audit-logs", query: query.values()}
+	var data []AuditLogResponse
+	page, err := c.do(ctx, req, &data)
+	return data, page, err
+}
+
+// GetFile calls GET /api/files/*filepath to download a stored file such as a face image.
+func (c *Client) GetFile(ctx context.Context, filepath string) (*File, error) {
+	req := &request{method: http.MethodGet, path: "/api/files/" + escapePath(filepath)}
+	return c.download(ctx, req)
+}
+
+// ListHolidayCalendars calls GET /api/holiday-calendars/ to list holiday calendars.
+func (c *Client) ListHolidayCalendars(ctx context.Context, query *HolidayCalendarSearchQuery) ([]HolidayCalendarResponse, *PageResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/holiday-calendars/", query: query.values()}
+	var data []HolidayCalendarResponse
+	page, err := c.do(ctx, req, &data)
+	return data, page, err
+}
+
+// GetHolidayCalendar calls GET /api/holiday-calendars/:id to get a holiday calendar.
+func (c *Client) GetHolidayCalendar(ctx context.Context, id string) (*HolidayCalendarResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/holiday-calendars/" + url.PathEscape(id)}
+	data := new(HolidayCalendarResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// CreateHolidayCalendar calls POST /api/holiday-calendars/ to create a holiday calendar.
+func (c *Client) CreateHolidayCalendar(ctx context.Context, body *HolidayCalendarRequest) (*HolidayCalendarResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/holiday-calendars/"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(HolidayCalendarResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// UpdateHolidayCalendar calls PUT /api/holiday-calendars/:id to replace a holiday calendar.
+func (c *Client) UpdateHolidayCalendar(ctx context.Context, id string, body *HolidayCalendarRequest) (*HolidayCalendarResponse, error) {
+	req := &request{method: http.MethodPut, path: "/api/holiday-calendars/" + url.PathEscape(id)}
+	if body != nil {
+		req.body = body
+	}
+	data := new(HolidayCalendarResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// DeleteHolidayCalendar calls DELETE /api/holiday-calendars/:id to delete a holiday calendar.
+func (c *Client) DeleteHolidayCalendar(ctx context.Context, id string) error {
+	req := &request{method: http.MethodDelete, path: "/api/holiday-calendars/" + url.PathEscape(id)}
+	_, err := c.do(ctx, req, nil)
+	return err
+}
+
+// ImportHolidayCalendar calls POST /api/holiday-calendars/:id/import to add the holidays of an iCalendar file to a holiday calendar.
+func (c *Client) ImportHolidayCalendar(ctx context.Context, id string, query *HolidayCalendarImportQuery, file *File) (*HolidayCalendarImportResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/holiday-calendars/" + url.PathEscape(id) + "/import", query: query.values(), files: map[string]*File{"file": file}}
+	data := new(HolidayCalendarImportResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ListLeaveRequests calls GET /api/leave-requests/ to list leave requests.
+func (c *Client) ListLeaveRequests(ctx context.Context, query *LeaveRequestSearchQuery) ([]LeaveRequestResponse, *PageResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/leave-requests/", query: query.values()}
+	var data []LeaveRequestResponse
+	page, err := c.do(ctx, req, &data)
+	return data, page, err
+}
+
+// GetLeaveRequest calls GET /api/leave-requests/:id to get a leave request.
+func (c *Client) GetLeaveRequest(ctx context.Context, id string) (*LeaveRequestResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/leave-requests/" + url.PathEscape(id)}
+	data := new(LeaveRequestResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// CreateLeaveRequest calls POST /api/leave-requests/ to create a leave request.
+func (c *Client) CreateLeaveRequest(ctx context.Context, body *LeaveRequestRequest) (*LeaveRequestResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/leave-requests/"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(LeaveRequestResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ApproveLeaveRequest calls POST /api/leave-requests/:id/approve to approve a leave request.
+func (c *Client) ApproveLeaveRequest(ctx context.Context, id string, body *DecisionRequest) (*LeaveRequestResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/leave-requests/" + url.PathEscape(id) + "/approve"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(LeaveRequestResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// RejectLeaveRequest calls POST /api/leave-requests/:id/reject to reject a leave request.
+func (c *Client) RejectLeaveRequest(ctx context.Context, id string, body *DecisionRequest) (*LeaveRequestResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/leave-requests/" + url.PathEscape(id) + "/reject"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(LeaveRequestResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// CancelLeaveRequest calls POST /api/leave-requests/:id/cancel to cancel a leave request.
+func (c *Client) CancelLeaveRequest(ctx context.Context, id string, body *DecisionRequest) (*LeaveRequestResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/leave-requests/" + url.PathEscape(id) + "/cancel"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(LeaveRequestResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ListLeaveTypes calls GET /api/leave-types/ to list leave types.
+func (c *Client) ListLeaveTypes(ctx context.Context, query *LeaveTypeSearchQuery) ([]LeaveTypeResponse, *PageResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/leave-types/", query: query.values()}
+	var data []LeaveTypeResponse
+	page, err := c.do(ctx, req, &data)
+	return data, page, err
+}
+
+// GetLeaveType calls GET /api/leave-types/:id to get a leave type.
+func (c *Client) GetLeaveType(ctx context.Context, id string) (*LeaveTypeResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/leave-types/" + url.PathEscape(id)}
+	data := new(LeaveTypeResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// CreateLeaveType calls POST /api/leave-types/ to create a leave type.
+func (c *Client) CreateLeaveType(ctx context.Context, body *LeaveTypeRequest) (*LeaveTypeResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/leave-types/"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(LeaveTypeResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// UpdateLeaveType calls PUT /api/leave-types/:id to replace a leave type.
+func (c *Client) UpdateLeaveType(ctx context.Context, id string, body *LeaveTypeRequest) (*LeaveTypeResponse, error) {
+	req := &request{method: http.MethodPut, path: "/api/leave-types/" + url.PathEscape(id)}
+	if body != nil {
+		req.body = body
+	}
+	data := new(LeaveTypeResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// DeleteLeaveType calls DELETE /api/leave-types/:id to delete a leave type.
+func (c *Client) DeleteLeaveType(ctx context.Context, id string) error {
+	req := &request{method: http.MethodDelete, path: "/api/leave-types/" + url.PathEscape(id)}
+	_, err := c.do(ctx, req, nil)
+	return err
+}
+
+// ListLocations calls GET /api/locations/ to list locations.
+func (c *Client) ListLocations(ctx context.Context, query *LocationSearchQuery) ([]LocationResponse, *PageResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/locations/", query: query.values()}
+	var data []LocationResponse
+	page, err := c.do(ctx, req, &data)
+	return data, page, err
+}
+
+// GetLocationTree calls GET /api/locations/tree to get the location hierarchy with the devices of every location.
+func (c *Client) GetLocationTree(ctx context.Context) ([]LocationTreeResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/locations/tree"}
+	var data []LocationTreeResponse
+	_, err := c.do(ctx, req, &data)
+	return data, err
+}
+
+// GetLocation calls GET /api/locations/:id to get a location.
+func (c *Client) GetLocation(ctx context.Context, id string) (*LocationResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/locations/" + url.PathEscape(id)}
+	data := new(LocationResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GetLocationOccupancy calls GET /api/locations/:id/occupancy to get the people last seen entering a location and the locations below it.
+func (c *Client) GetLocationOccupancy(ctx context.Context, id string, query *LocationOccupancyQuery) (*LocationOccupancyResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/locations/" + url.PathEscape(id) + "/occupancy", query: query.values()}
+	data := new(LocationOccupancyResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// CreateLocation calls POST /api/locations/ to create a location.
+func (c *Client) CreateLocation(ctx context.Context, body *LocationRequest) (*LocationResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/locations/"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(LocationResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// UpdateLocation calls PUT /api/locations/:id to replace a location.
+func (c *Client) UpdateLocation(ctx context.Context, id string, body *LocationRequest) (*LocationResponse, error) {
+	req := &request{method: http.MethodPut, path: "/api/locations/" + url.PathEscape(id)}
+	if body != nil {
+		req.body = body
+	}
+	data := new(LocationResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// DeleteLocation calls DELETE /api/locations/:id to delete a location.
+func (c *Client) DeleteLocation(ctx context.Context, id string) error {
+	req := &request{method: http.MethodDelete, path: "/api/locations/" + url.PathEscape(id)}
+	_, err := c.do(ctx, req, nil)
+	return err
+}
+
+// ListOvertimeRules calls GET /api/overtime-rules/ to list overtime rules.
+func (c *Client) ListOvertimeRules(ctx context.Context, query *OvertimeRuleSearchQuery) ([]OvertimeRuleResponse, *PageResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/overtime-rules/", query: query.values()}
+	var data []OvertimeRuleResponse
+	page, err := c.do(ctx, req, &data)
+	return data, page, err
+}
+
+// GetOvertimeRule calls GET /api/overtime-rules/:id to get an overtime rule.
+func (c *Client) GetOvertimeRule(ctx context.Context, id string) (*OvertimeRuleResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/overtime-rules/" + url.PathEscape(id)}
+	data := new(OvertimeRuleResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// CreateOvertimeRule calls POST /api/overtime-rules/ to create an overtime rule.
+func (c *Client) CreateOvertimeRule(ctx context.Context, body *OvertimeRuleRequest) (*OvertimeRuleResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/overtime-rules/"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(OvertimeRuleResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// UpdateOvertimeRule calls PUT /api/overtime-rules/:id to replace an overtime rule.
+func (c *Client) UpdateOvertimeRule(ctx context.Context, id string, body *OvertimeRuleRequest) (*OvertimeRuleResponse, error) {
+	req := &request{method: http.MethodPut, path: "/api/overtime-rules/" + url.PathEscape(id)}
+	if body != nil {
+		req.body = body
+	}
+	data := new(OvertimeRuleResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// DeleteOvertimeRule calls DELETE /api/overtime-rules/:id to delete an overtime rule.
+func (c *Client) DeleteOvertimeRule(ctx context.Context, id string) error {
+	req := &request{method: http.MethodDelete, path: "/api/overtime-rules/" + url.PathEscape(id)}
+	_, err := c.do(ctx, req, nil)
+	return err
+}
+
+// ListPayrollExportTemplates calls GET /api/payroll-export-templates/ to list payroll export templates.
+func (c *Client) ListPayrollExportTemplates(ctx context.Context, query *PayrollExportTemplateSearchQuery) ([]PayrollExportTemplateResponse, *PageResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/payroll-export-templates/", query: query.values()}
+	var data []PayrollExportTemplateResponse
+	page, err := c.do(ctx, req, &data)
+	return data, page, err
+}
+
+// GetPayrollExportTemplate calls GET /api/payroll-export-templates/:id to get a payroll export template.
+func (c *Client) GetPayrollExportTemplate(ctx context.Context, id string) (*PayrollExportTemplateResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/payroll-export-templates/" + url.PathEscape(id)}
+	data := new(PayrollExportTemplateResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// CreatePayrollExportTemplate calls POST /api/payroll-export-templates/ to create a payroll export template.
+func (c *Client) CreatePayrollExportTemplate(ctx context.Context, body *PayrollExportTemplateRequest) (*PayrollExportTemplateResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/payroll-export-templates/"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(PayrollExportTemplateResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// UpdatePayrollExportTemplate calls PUT /api/payroll-export-templates/:id to replace a payroll export template.
+func (c *Client) UpdatePayrollExportTemplate(ctx context.Context, id string, body *PayrollExportTemplateRequest) (*PayrollExportTemplateResponse, error) {
+	req := &request{method: http.MethodPut, path: "/api/payroll-export-templates/" + url.PathEscape(id)}
+	if body != nil {
+		req.body = body
+	}
+	data := new(PayrollExportTemplateResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// DeletePayrollExportTemplate calls DELETE /api/payroll-export-templates/:id to delete a payroll export template.
+func (c *Client) DeletePayrollExportTemplate(ctx context.Context, id string) error {
+	req := &request{method: http.MethodDelete, path: "/api/payroll-export-templates/" + url.PathEscape(id)}
+	_, err := c.do(ctx, req, nil)
+	return err
+}
+
+// ListPayrollExports calls GET /api/payroll-exports/ to list payroll exports.
+func (c *Client) ListPayrollExports(ctx context.Context, query *PayrollExportSearchQuery) ([]PayrollExportResponse, *PageResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/payroll-exports/", query: query.values()}
+	var data []PayrollExportResponse
+	page, err := c.do(ctx, req, &data)
+	return data, page, err
+}
+
+// GetPayrollExport calls GET /api/payroll-exports/:id to get a payroll export.
+func (c *Client) GetPayrollExport(ctx context.Context, id string) (*PayrollExportResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/payroll-exports/" + url.PathEscape(id)}
+	data := new(PayrollExportResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GeneratePayrollExport calls POST /api/payroll-exports/ to generate a payroll export of a period.
+func (c *Client) GeneratePayrollExport(ctx context.Context, body *PayrollExportRequest) (*PayrollExportResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/payroll-exports/"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(PayrollExportResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// DeletePayrollExport calls DELETE /api/payroll-exports/:id to delete a payroll export.
+func (c *Client) DeletePayrollExport(ctx context.Context, id string) error {
+	req := &request{method: http.MethodDelete, path: "/api/payroll-exports/" + url.PathEscape(id)}
+	_, err := c.do(ctx, req, nil)
+	return err
+}
+
+// DownloadPayrollExport calls GET /api/payroll-exports/:id/download to download the file of a payroll export.
+func (c *Client) DownloadPayrollExport(ctx context.Context, id string) (*File, error) {
+	req := &request{method: http.MethodGet, path: "/api/payroll-exports/" + url.PathEscape(id) + "/download"}
+	return c.download(ctx, req)
+}
+
+// ListPayrollExportChanges calls GET /api/payroll-exports/:id/changes to list the attendance changed since a payroll export was generated.
+func (c *Client) ListPayrollExportChanges(ctx context.Context, id string) ([]PayrollExportChangeResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/payroll-exports/" + url.PathEscape(id) + "/changes"}
+	var data []PayrollExportChangeResponse
+	_, err := c.do(ctx, req, &data)
+	return data, err
+}
+
+// LockPayrollExport calls POST /api/payroll-exports/:id/lock to lock the period of a payroll export.
+func (c *Client) LockPayrollExport(ctx context.Context, id string) (*PayrollExportResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/payroll-exports/" + url.PathEscape(id) + "/lock"}
+	data := new(PayrollExportResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ListPeople calls GET /api/people/ to list people.
+func (c *Client) ListPeople(ctx context.Context, query *PersonSearchQuery) ([]PersonResponse, *PageResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/people/", query: query.values()}
+	var data []PersonResponse
+	page, err := c.do(ctx, req, &data)
+	return data, page, err
+}
+
+// ExportPeople calls GET /api/people/export to export people as a CSV or XLSX file.
+func (c *Client) ExportPeople(ctx context.Context, query *PersonExportQuery) (*File, error) {
+	req := &request{method: http.MethodGet, path: "/api/people/export", query: query.values()}
+	return c.download(ctx, req)
+}
+
+// ImportPeople calls POST /api/people/import to import people from a CSV or XLSX file.
+func (c *Client) ImportPeople(ctx context.Context, query *PersonImportQuery, file *File) (*PersonImportResult, error) {
+	req := &request{method: http.MethodPost, path: "/api/people/import", query: query.values(), files: map[string]*File{"file": file}}
+	data := new(PersonImportResult)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ImportFaceImages calls POST /api/people/face-images/import to import the face images of people from a ZIP file.
+func (c *Client) ImportFaceImages(ctx context.Context, query *PersonFaceImageImportQuery, file *File) (*PersonFaceImageImportResult, error) {
+	req := &request{method: http.MethodPost, path: "/api/people/face-images/import", query: query.values(), files: map[string]*File{"file": file}}
+	data := new(PersonFaceImageImportResult)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GetPerson calls GET /api/people/:id to get a person.
+func (c *Client) GetPerson(ctx context.Context, id string) (*PersonResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/people/" + url.PathEscape(id)}
+	data := new(PersonResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// CreatePerson calls POST /api/people/ to create a person.
+func (c *Client) CreatePerson(ctx context.Context, form *PersonRequest, faceImage *File) (*PersonResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/people/", form: form.values(), files: map[string]*File{"faceImage": faceImage}}
+	data := new(PersonResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// UpdatePerson calls PUT /api/people/:id to replace a person.
+func (c *Client) UpdatePerson(ctx context.Context, id string, form *PersonRequest, faceImage *File) (*PersonResponse, error) {
+	req := &request{method: http.MethodPut, path: "/api/people/" + url.PathEscape(id), form: form.values(), files: map[string]*File{"faceImage": faceImage}}
+	data := new(PersonResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// DeletePerson calls DELETE /api/people/:id to delete a person.
+func (c *Client) DeletePerson(ctx context.Context, id string) error {
+	req := &request{method: http.MethodDelete, path: "/api/people/" + url.PathEscape(id)}
+	_, err := c.do(ctx, req, nil)
+	return err
+}
+
+// SetPersonPIN calls PUT /api/people/:id/pin to set the PIN of a person.
+func (c *Client) SetPersonPIN(ctx context.Context, id string, body *PersonPINRequest) error {
+	req := &request{method: http.MethodPut, path: "/api/people/" + url.PathEscape(id) + "/pin"}
+	if body != nil {
+		req.body = body
+	}
+	_, err := c.do(ctx, req, nil)
+	return err
+}
+
+// ClearPersonPIN calls DELETE /api/people/:id/pin to clear the PIN of a person.
+func (c *Client) ClearPersonPIN(ctx context.Context, id string) error {
+	req := &request{method: http.MethodDelete, path: "/api/people/" + url.PathEscape(id) + "/pin"}
+	_, err := c.do(ctx, req, nil)
+	return err
+}
+
+// ExportPersonData calls GET /api/people/:id/data-export to export every personal data of a person as a ZIP file.
+func (c *Client) ExportPersonData(ctx context.Context, id string, query *DataSubjectExportQuery) (*File, error) {
+	req := &request{method: http.MethodGet, path: "/api/people/" + url.PathEscape(id) + "/data-export", query: query.values()}
+	return c.download(ctx, req)
+}
+
+// ErasePersonData calls POST /api/people/:id/erase to anonymize a person and remove their personal data.
+func (c *Client) ErasePersonData(ctx context.Context, id string, body *DataSubjectEraseRequest) (*PersonResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/people/" + url.PathEscape(id) + "/erase"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(PersonResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ListPersonCards calls GET /api/people/:id/cards to list the cards of a person.
+func (c *Client) ListPersonCards(ctx context.Context, id string) ([]PersonCardResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/people/" + url.PathEscape(id) + "/cards"}
+	var data []PersonCardResponse
+	_, err := c.do(ctx, req, &data)
+	return data, err
+}
+
+// ListPersonCardsHistory calls GET /api/people/:id/cards/history to list the history of every card of a person.
+func (c *Client) ListPersonCardsHistory(ctx context.Context, id string) ([]PersonCardHistoryResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/people/" + url.PathEscape(id) + "/cards/history"}
+	var data []PersonCardHistoryResponse
+	_, err := c.do(ctx, req, &data)
+	return data, err
+}
+
+// CreatePersonCard calls POST /api/people/:id/cards to issue a card to a person.
+func (c *Client) CreatePersonCard(ctx context.Context, id string, body *PersonCardRequest) (*PersonCardResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/people/" + url.PathEscape(id) + "/cards"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(PersonCardResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GetPersonCard calls GET /api/people/:id/cards/:cardId to get a card of a person.
+func (c *Client) GetPersonCard(ctx context.Context, id string, cardID string) (*PersonCardResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/people/" + url.PathEscape(id) + "/cards/" + url.PathEscape(cardID)}
+	data := new(PersonCardResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// UpdatePersonCardValidity calls PATCH /api/people/:id/cards/:cardId to change the validity period of a card.
+func (c *Client) UpdatePersonCardValidity(ctx context.Context, id string, cardID string, body *PersonCardValidityRequest) (*PersonCardResponse, error) {
+	req := &request{method: http.MethodPatch, path: "/api/people/" + url.PathEscape(id) + "/cards/" + url.PathEscape(cardID)}
+	if body != nil {
+		req.body = body
+	}
+	data := new(PersonCardResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ChangePersonCardStatus calls POST /api/people/:id/cards/:cardId/status to change the status of a card.
+func (c *Client) ChangePersonCardStatus(ctx context.Context, id string, cardID string, body *PersonCardStatusRequest) (*PersonCardResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/people/" + url.PathEscape(id) + "/cards/" + url.PathEscape(cardID) + "/status"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(PersonCardResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ListPersonCardHistory calls GET /api/people/:id/cards/:cardId/history to list the history of a card.
+func (c *Client) ListPersonCardHistory(ctx context.Context, id string, cardID string) ([]PersonCardHistoryResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/people/" + url.PathEscape(id) + "/cards/" + url.PathEscape(cardID) + "/history"}
+	var data []PersonCardHistoryResponse
+	_, err := c.do(ctx, req, &data)
+	return data, err
+}
+
+// DeletePersonCard calls DELETE /api/people/:id/cards/:cardId to delete a card of a person.
+func (c *Client) DeletePersonCard(ctx context.Context, id string, cardID string) error {
+	req := &request{method: http.MethodDelete, path: "/api/people/" + url.PathEscape(id) + "/cards/" + url.PathEscape(cardID)}
+	_, err := c.do(ctx, req, nil)
+	return err
+}
+
+// ListLeaveBalances calls GET /api/people/:id/leave-balances to list the leave balances of a person in a year.
+func (c *Client) ListLeaveBalances(ctx context.Context, id string, query *LeaveBalanceQuery) ([]LeaveBalanceResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/people/" + url.PathEscape(id) + "/leave-balances", query: query.values()}
+	var data []LeaveBalanceResponse
+	_, err := c.do(ctx, req, &data)
+	return data, err
+}
+
+// SaveLeaveBalance calls PUT /api/people/:id/leave-balances to set the entitlement of a person for a leave type in a year.
+func (c *Client) SaveLeaveBalance(ctx context.Context, id string, body *LeaveBalanceRequest) (*LeaveBalanceResponse, error) {
+	req := &request{method: http.MethodPut, path: "/api/people/" + url.PathEscape(id) + "/leave-balances"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(LeaveBalanceResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ListShiftAssignments calls GET /api/people/:id/shift-assignments to list the shift rotation assignments of a person.
+func (c *Client) ListShiftAssignments(ctx context.Context, id string) ([]PersonShiftAssignmentResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/people/" + url.PathEscape(id) + "/shift-assignments"}
+	var data []PersonShiftAssignmentResponse
+	_, err := c.do(ctx, req, &data)
+	return data, err
+}
+
+// CreateShiftAssignment calls POST /api/people/:id/shift-assignments to assign a shift rotation to a person.
+func (c *Client) CreateShiftAssignment(ctx context.Context, id string, body *PersonShiftAssignmentRequest) (*PersonShiftAssignmentResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/people/" + url.PathEscape(id) + "/shift-assignments"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(PersonShiftAssignmentResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// DeleteShiftAssignment calls DELETE /api/people/:id/shift-assignments/:assignmentId to delete a shift rotation assignment of a person.
+func (c *Client) DeleteShiftAssignment(ctx context.Context, id string, assignmentID string) error {
+	req := &request{method: http.MethodDelete, path: "/api/people/" + url.PathEscape(id) + "/shift-assignments/" + url.PathEscape(assignmentID)}
+	_, err := c.do(ctx, req, nil)
+	return err
+}
+
+// ListShiftOverrides calls GET /api/people/:id/shift-overrides to list the shift overrides of a person.
+func (c *Client) ListShiftOverrides(ctx context.Context, id string) ([]PersonShiftOverrideResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/people/" + url.PathEscape(id) + "/shift-overrides"}
+	var data []PersonShiftOverrideResponse
+	_, err := c.do(ctx, req, &data)
+	return data, err
+}
+
+// SaveShiftOverride calls POST /api/people/:id/shift-overrides to set the shift of a person on a date.
+func (c *Client) SaveShiftOverride(ctx context.Context, id string, body *PersonShiftOverrideRequest) (*PersonShiftOverrideResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/people/" + url.PathEscape(id) + "/shift-overrides"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(PersonShiftOverrideResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// DeleteShiftOverride calls DELETE /api/people/:id/shift-overrides/:overrideId to delete a shift override of a person.
+func (c *Client) DeleteShiftOverride(ctx context.Context, id string, overrideID string) error {
+	req := &request{method: http.MethodDelete, path: "/api/people/" + url.PathEscape(id) + "/shift-overrides/" + url.PathEscape(overrideID)}
+	_, err := c.do(ctx, req, nil)
+	return err
+}
+
+// GetRetentionPolicy calls GET /api/retention/policy to get the retention policy.
+// Only super admins in the global scope, without a tenant, may use this route.
+func (c *Client) GetRetentionPolicy(ctx context.Context) (*RetentionPolicyResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/retention/policy"}
+	data := new(RetentionPolicyResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ListRetentionRuns calls GET /api/retention/runs to list retention runs.
+// Only super admins in the global scope, without a tenant, may use this route.
+func (c *Client) ListRetentionRuns(ctx context.Context, query *RetentionRunSearchQuery) ([]RetentionRunResponse, *PageResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/retention/runs", query: query.values()}
+	var data []RetentionRunResponse
+	page, err := c.do(ctx, req, &data)
+	return data, page, err
+}
+
+// GetRetentionRun calls GET /api/retention/runs/:id to get a retention run.
+// Only super admins in the global scope, without a tenant, may use this route.
+func (c *Client) GetRetentionRun(ctx context.Context, id string) (*RetentionRunResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/retention/runs/" + url.PathEscape(id)}
+	data := new(RetentionRunResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// RunRetention calls POST /api/retention/runs to purge the data past its retention now.
+// Only super admins in the global scope, without a tenant, may use this route.
+func (c *Client) RunRetention(ctx context.Context) (*RetentionRunResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/retention/runs"}
+	data := new(RetentionRunResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ListShiftRotations calls GET /api/shift-rotations/ to list shift rotations.
+func (c *Client) ListShiftRotations(ctx context.Context, query *ShiftRotationSearchQuery) ([]ShiftRotationResponse, *PageResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/shift-rotations/", query: query.values()}
+	var data []ShiftRotationResponse
+	page, err := c.do(ctx, req, &data)
+	return data, page, err
+}
+
+// GetShiftRotation calls GET /api/shift-rotations/:id to get a shift rotation.
+func (c *Client) GetShiftRotation(ctx context.Context, id string) (*ShiftRotationResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/shift-rotations/" + url.PathEscape(id)}
+	data := new(ShiftRotationResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// CreateShiftRotation calls POST /api/shift-rotations/ to create a shift rotation.
+func (c *Client) CreateShiftRotation(ctx context.Context, body *ShiftRotationRequest) (*ShiftRotationResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/shift-rotations/"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(ShiftRotationResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// UpdateShiftRotation calls PUT /api/shift-rotations/:id to replace a shift rotation.
+func (c *Client) UpdateShiftRotation(ctx context.Context, id string, body *ShiftRotationRequest) (*ShiftRotationResponse, error) {
+	req := &request{method: http.MethodPut, path: "/api/shift-rotations/" + url.PathEscape(id)}
+	if body != nil {
+		req.body = body
+	}
+	data := new(ShiftRotationResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// DeleteShiftRotation calls DELETE /api/shift-rotations/:id to delete a shift rotation.
+func (c *Client) DeleteShiftRotation(ctx context.Context, id string) error {
+	req := &request{method: http.MethodDelete, path: "/api/shift-rotations/" + url.PathEscape(id)}
+	_, err := c.do(ctx, req, nil)
+	return err
+}
+
+// ListShiftTemplates calls GET /api/shift-templates/ to list shift templates.
+func (c *Client) ListShiftTemplates(ctx context.Context, query *ShiftTemplateSearchQuery) ([]ShiftTemplateResponse, *PageResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/shift-templates/", query: query.values()}
+	var data []ShiftTemplateResponse
+	page, err := c.do(ctx, req, &data)
+	return data, page, err
+}
+
+// GetShiftTemplate calls GET /api/shift-templates/:id to get a shift template.
+func (c *Client) GetShiftTemplate(ctx context.Context, id string) (*ShiftTemplateResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/shift-templates/" + url.PathEscape(id)}
+	data := new(ShiftTemplateResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// CreateShiftTemplate calls POST /api/shift-templates/ to create a shift template.
+func (c *Client) CreateShiftTemplate(ctx context.Context, body *ShiftTemplateRequest) (*ShiftTemplateResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/shift-templates/"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(ShiftTemplateResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// UpdateShiftTemplate calls PUT /api/shift-templates/:id to replace a shift template.
+func (c *Client) UpdateShiftTemplate(ctx context.Context, id string, body *ShiftTemplateRequest) (*ShiftTemplateResponse, error) {
+	req := &request{method: http.MethodPut, path: "/api/shift-templates/" + url.PathEscape(id)}
+	if body != nil {
+		req.body = body
+	}
+	data := new(ShiftTemplateResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// DeleteShiftTemplate calls DELETE /api/shift-templates/:id to delete a shift template.
+func (c *Client) DeleteShiftTemplate(ctx context.Context, id string) error {
+	req := &request{method: http.MethodDelete, path: "/api/shift-templates/" + url.PathEscape(id)}
+	_, err := c.do(ctx, req, nil)
+	return err
+}
+
+// ListTenants calls GET /api/tenants/ to list tenants.
+// Only super admins may use this route.
+func (c *Client) ListTenants(ctx context.Context, query *TenantSearchQuery) ([]TenantResponse, *PageResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/tenants/", query: query.values()}
+	var data []TenantResponse
+	page, err := c.do(ctx, req, &data)
+	return data, page, err
+}
+
+// GetTenant calls GET /api/tenants/:id to get a tenant.
+// Only super admins may use this route.
+func (c *Client) GetTenant(ctx context.Context, id string) (*TenantResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/tenants/" + url.PathEscape(id)}
+	data := new(TenantResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// CreateTenant calls POST /api/tenants/ to create a tenant.
+// Only super admins may use this route.
+func (c *Client) CreateTenant(ctx context.Context, body *TenantRequest) (*TenantResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/tenants/"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(TenantResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// UpdateTenant calls PUT /api/tenants/:id to replace a tenant.
+// Only super admins may use this route.
+func (c *Client) UpdateTenant(ctx context.Context, id string, body *TenantRequest) (*TenantResponse, error) {
+	req := &request{method: http.MethodPut, path: "/api/tenants/" + url.PathEscape(id)}
+	if body != nil {
+		req.body = body
+	}
+	data := new(TenantResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// DeleteTenant calls DELETE /api/tenants/:id to delete a tenant.
+// Only super admins may use this route.
+func (c *Client) DeleteTenant(ctx context.Context, id string) error {
+	req := &request{method: http.MethodDelete, path: "/api/tenants/" + url.PathEscape(id)}
+	_, err := c.do(ctx, req, nil)
+	return err
+}
+
+// SwitchTenant calls POST /api/tenants/switch to get a token for another tenant or the global scope.
+// Only super admins may use this route.
+func (c *Client) SwitchTenant(ctx context.Context, body *SwitchTenantRequest) (*TokenResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/tenants/switch"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(TokenResponse)
+	if err := c.doJSON(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ListUsers calls GET /api/users/ to list users.
+func (c *Client) ListUsers(ctx context.Context, query *UserSearchQuery) ([]UserResponse, *PageResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/users/", query: query.values()}
+	var data []UserResponse
+	page, err := c.do(ctx, req, &data)
+	return data, page, err
+}
+
+// GetUser calls GET /api/users/:id to get an user.
+func (c *Client) GetUser(ctx context.Context, id string) (*UserResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/users/" + url.PathEscape(id)}
+	data := new(UserResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// CreateUser calls POST /api/users/ to create an user.
+func (c *Client) CreateUser(ctx context.Context, body *UserRequest) (*UserResponse, error) {
+	req := &request{method: http.MethodPost, path: "/api/users/"}
+	if body != nil {
+		req.body = body
+	}
+	data := new(UserResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// UpdateUser calls PUT /api/users/:id to replace an user.
+func (c *Client) UpdateUser(ctx context.Context, id string, body *UserRequest) (*UserResponse, error) {
+	req := &request{method: http.MethodPut, path: "/api/users/" + url.PathEscape(id)}
+	if body != nil {
+		req.body = body
+	}
+	data := new(UserResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// PatchUser calls PATCH /api/users/:id to update the given fields of an user.
+func (c *Client) PatchUser(ctx context.Context, id string, body *UserRequest) (*UserResponse, error) {
+	req := &request{method: http.MethodPatch, path: "/api/users/" + url.PathEscape(id)}
+	if body != nil {
+		req.body = body
+	}
+	data := new(UserResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// DeleteUser calls DELETE /api/users/:id to delete an user.
+func (c *Client) DeleteUser(ctx context.Context, id string) error {
+	req := &request{method: http.MethodDelete, path: "/api/users/" + url.PathEscape(id)}
+	_, err := c.do(ctx, req, nil)
+	return err
+}
+
+// ListVisitorVehicles calls GET /api/visitor-vehicles/ to list visitor vehicles.
+func (c *Client) ListVisitorVehicles(ctx context.Context, query *VisitorVehicleSearchQuery) ([]VisitorVehicleResponse, *PageResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/visitor-vehicles/", query: query.values()}
+	var data []VisitorVehicleResponse
+	page, err := c.do(ctx, req, &data)
+	return data, page, err
+}
+
+// GetVisitorVehicle calls GET /api/visitor-vehicles/:id to get a visitor vehicle.
+func (c *Client) GetVisitorVehicle(ctx context.Context, id string) (*VisitorVehicleResponse, error) {
+	req := &request{method: http.MethodGet, path: "/api/visitor-vehicles/" + url.PathEscape(id)}
+	data := new(VisitorVehicleResponse)
+	if _, err := c.do(ctx, req, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// DeleteVisitorVehicle calls DELETE /api/visitor-vehicles/:id to delete a visitor vehicle.
+func (c *Client) DeleteVisitorVehicle(ctx context.Context, id string) error {
+	req := &request{method: http.MethodDelete, path: "/api/visitor-vehicles/" + url.PathEscape(id)}
+	_, err := c.do(ctx, req, nil)
+	return err
+}